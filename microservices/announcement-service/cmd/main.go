@@ -0,0 +1,92 @@
+package main
+
+//	@title			Announcement Service API
+//	@version		1.0
+//	@description	Company announcements targeted by department and position, with read tracking and a live feed per employee
+//	@termsOfService	http://swagger.io/terms/
+
+//	@contact.name	API Support
+//	@contact.email	josed.amayar@uqvirtual.edu.co
+
+//	@host		localhost:8090
+//	@BasePath	/announcement-service/api/v1
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"announcement-service/internal/config"
+	"announcement-service/internal/db"
+	"announcement-service/internal/handlers"
+	"announcement-service/internal/repository"
+	"announcement-service/internal/service"
+	"announcement-service/internal/stream"
+
+	_ "announcement-service/docs" // Swagger docs
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+func main() {
+	cfg := config.Load()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbPool := db.NewPostgresPool(cfg)
+	defer dbPool.Close()
+
+	if cfg.MigrateOnStartup {
+		if err := db.Migrate(ctx, dbPool); err != nil {
+			log.Fatalf("database migration failed: %v", err)
+		}
+	}
+
+	// Departments and positions come from employee-management
+	employeeClient := employees.NewClient(cfg.EmployeeServiceURL, cfg.EmployeeServiceTimeout)
+	repo := repository.NewAnnouncementRepository(dbPool)
+	announcementService := service.NewAnnouncementService(repo, employeeClient)
+
+	hub := stream.NewHub(repo, cfg.StreamPollInterval)
+	go hub.Run(ctx)
+
+	handler := handlers.NewAnnouncementHandler(announcementService, hub)
+	healthHandler := httpkit.NewHealthHandler("announcement-service", dbPool)
+
+	router := httpkit.NewRouter()
+
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	v1 := router.Group("/announcement-service/api/v1")
+	{
+		v1.GET("/health", healthHandler.HealthCheck)
+
+		v1.POST("/announcements", handler.CreateAnnouncement)
+		v1.GET("/announcements", handler.GetAllAnnouncements)
+		v1.GET("/announcements/:id", handler.GetAnnouncementByID)
+		v1.POST("/announcements/:id/withdraw", handler.WithdrawAnnouncement)
+		v1.GET("/announcements/:id/reads", handler.GetReads)
+
+		v1.GET("/employees/:id/announcements", handler.GetFeed)
+		v1.GET("/employees/:id/announcements/stream", handler.StreamFeed)
+		v1.POST("/employees/:id/announcements/:announcementId/read", handler.MarkRead)
+	}
+
+	srv := &http.Server{Addr: ":" + cfg.ServerPort, Handler: router}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	log.Printf("Announcement service running on :%s", cfg.ServerPort)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
@@ -0,0 +1,691 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "email": "josed.amayar@uqvirtual.edu.co"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/announcements": {
+            "get": {
+                "description": "Retrieves the announcements newest first with their read counts, only the visible ones unless all is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Announcements"
+                ],
+                "summary": "List announcements",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include withdrawn and expired announcements",
+                        "name": "all",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Announcements",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httpkit.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Announcement"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Publishes an announcement to the employees in one of the departments and one of the positions given, empty lists target everyone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Announcements"
+                ],
+                "summary": "Publish an announcement",
+                "parameters": [
+                    {
+                        "description": "Announcement data",
+                        "name": "announcement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AnnouncementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Announcement published",
+                        "schema": {
+                            "$ref": "#/definitions/models.Announcement"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/announcements/{id}": {
+            "get": {
+                "description": "Retrieves an announcement by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Announcements"
+                ],
+                "summary": "Get an announcement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Announcement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Announcement",
+                        "schema": {
+                            "$ref": "#/definitions/models.Announcement"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid announcement ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Announcement not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/announcements/{id}/reads": {
+            "get": {
+                "description": "Retrieves the employees who read an announcement and when, first readers first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Announcements"
+                ],
+                "summary": "Reads of an announcement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Announcement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reads",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Read"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid announcement ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Announcement not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/announcements/{id}/withdraw": {
+            "post": {
+                "description": "Takes an announcement down from every feed, its reads are kept",
+                "tags": [
+                    "Announcements"
+                ],
+                "summary": "Withdraw an announcement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Announcement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Announcement withdrawn"
+                    },
+                    "400": {
+                        "description": "Invalid announcement ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Announcement not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/announcements": {
+            "get": {
+                "description": "Retrieves the visible announcements targeting the department and position of the employee, newest first, with whether they read them. Poll it with unread=true for a badge count",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Announcements of an employee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only the unread announcements",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Announcements",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.FeedItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid employee ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Employee service unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/announcements/stream": {
+            "get": {
+                "description": "Pushes the new announcements targeting the employee over Server-Sent Events, as announcement events with the announcement id as event id. Send Last-Event-ID to get those published while disconnected",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Live announcements of an employee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the last announcement received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of announcements",
+                        "schema": {
+                            "$ref": "#/definitions/models.Announcement"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid employee ID or Last-Event-ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Employee service unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/announcements/{announcementId}/read": {
+            "post": {
+                "description": "Records that the employee read the announcement. Marking it again keeps the first read time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Mark an announcement read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Announcement ID",
+                        "name": "announcementId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Read recorded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Read"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid employee or announcement ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found, or announcement not shown to them",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Employee service unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.AnnouncementRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "HR"
+                },
+                "body": {
+                    "type": "string",
+                    "example": "The office will be closed for maintenance."
+                },
+                "departments": {
+                    "description": "Departments targeted, empty for every department",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Engineering"
+                    ]
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt hides the announcement from then on",
+                    "type": "string"
+                },
+                "positions": {
+                    "description": "Positions targeted, empty for every position",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Developer"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Office closed on Friday"
+                }
+            }
+        },
+        "httpkit.ErrorResponse": {
+            "description": "Standard error response structure",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpkit.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "pagination": {
+                    "$ref": "#/definitions/httpkit.PaginationMeta"
+                }
+            }
+        },
+        "httpkit.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                },
+                "total_records": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Announcement": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "HR"
+                },
+                "body": {
+                    "type": "string",
+                    "example": "The office will be closed for maintenance."
+                },
+                "departments": {
+                    "description": "Departments targeted, empty for every department",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Engineering"
+                    ]
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "positions": {
+                    "description": "Positions targeted, empty for every position",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Developer"
+                    ]
+                },
+                "publishedAt": {
+                    "type": "string"
+                },
+                "readCount": {
+                    "description": "ReadCount is how many employees have read it",
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Office closed on Friday"
+                },
+                "withdrawnAt": {
+                    "description": "WithdrawnAt is set once the announcement is taken down",
+                    "type": "string"
+                }
+            }
+        },
+        "models.FeedItem": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "HR"
+                },
+                "body": {
+                    "type": "string",
+                    "example": "The office will be closed for maintenance."
+                },
+                "departments": {
+                    "description": "Departments targeted, empty for every department",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Engineering"
+                    ]
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "positions": {
+                    "description": "Positions targeted, empty for every position",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Developer"
+                    ]
+                },
+                "publishedAt": {
+                    "type": "string"
+                },
+                "read": {
+                    "type": "boolean"
+                },
+                "readAt": {
+                    "type": "string"
+                },
+                "readCount": {
+                    "description": "ReadCount is how many employees have read it",
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Office closed on Friday"
+                },
+                "withdrawnAt": {
+                    "description": "WithdrawnAt is set once the announcement is taken down",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Read": {
+            "type": "object",
+            "properties": {
+                "announcementId": {
+                    "type": "integer"
+                },
+                "employeeId": {
+                    "type": "integer"
+                },
+                "readAt": {
+                    "type": "string"
+                }
+            }
+        }
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:8090",
+	BasePath:         "/announcement-service/api/v1",
+	Schemes:          []string{},
+	Title:            "Announcement Service API",
+	Description:      "Company announcements targeted by department and position, with read tracking and a live feed per employee",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
@@ -0,0 +1,148 @@
+// Package config loads the announcement service configuration from
+// defaults, a YAML file, env variables and CLI flags, in that order
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"github.com/joho/godotenv"
+)
+
+// Config holds configuration loaded from defaults, file, env and flags
+type Config struct {
+	ServerPort string `yaml:"server_port"`
+
+	DBHost     string `yaml:"db_host"`
+	DBPort     string `yaml:"db_port"`
+	DBName     string `yaml:"db_name"`
+	DBUser     string `yaml:"db_user"`
+	DBPassword string `yaml:"db_password"`
+	DBSSLMode  string `yaml:"db_sslmode"`
+	DBMaxConns int    `yaml:"db_max_conns"`
+
+	DBRetryMaxWait time.Duration `yaml:"db_retry_max_wait"`
+
+	MigrateOnStartup bool `yaml:"migrate_on_startup"`
+
+	// EmployeeServiceURL is the versioned API base of employee-management
+	EmployeeServiceURL     string        `yaml:"employee_service_url"`
+	EmployeeServiceTimeout time.Duration `yaml:"employee_service_timeout"`
+
+	// StreamPollInterval is how often live streams poll for new
+	// announcements
+	StreamPollInterval time.Duration `yaml:"stream_poll_interval"`
+}
+
+// options lists every setting that can be overridden by env or flags
+var options = []envconfig.Option[Config]{
+	{Env: "SERVER_PORT", Flag: "port", Usage: "HTTP server port", Set: envconfig.String(func(c *Config) *string { return &c.ServerPort })},
+	{Env: "DB_HOST", Flag: "db-host", Usage: "database host", Set: envconfig.String(func(c *Config) *string { return &c.DBHost })},
+	{Env: "DB_PORT", Flag: "db-port", Usage: "database port", Set: envconfig.String(func(c *Config) *string { return &c.DBPort })},
+	{Env: "DB_NAME", Flag: "db-name", Usage: "database name", Set: envconfig.String(func(c *Config) *string { return &c.DBName })},
+	{Env: "DB_USER", Flag: "db-user", Usage: "database user", Set: envconfig.String(func(c *Config) *string { return &c.DBUser })},
+	{Env: "DB_PASSWORD", Flag: "db-password", Usage: "database password", Set: envconfig.String(func(c *Config) *string { return &c.DBPassword })},
+	{Env: "DB_SSL_MODE", Flag: "db-sslmode", Usage: "database sslmode", Set: envconfig.String(func(c *Config) *string { return &c.DBSSLMode })},
+	{Env: "DB_MAX_CONNS", Flag: "db-max-conns", Usage: "maximum open db connections", Set: envconfig.Int(func(c *Config) *int { return &c.DBMaxConns })},
+	{Env: "DB_RETRY_MAX_WAIT", Flag: "db-retry-max-wait", Usage: "how long to wait for the db at startup", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DBRetryMaxWait })},
+	{Env: "MIGRATE_ON_STARTUP", Flag: "migrate-on-startup", Usage: "apply pending migrations at startup", Set: envconfig.Bool(func(c *Config) *bool { return &c.MigrateOnStartup })},
+	{Env: "EMPLOYEE_SERVICE_URL", Flag: "employee-service-url", Usage: "employee-management API base url", Set: envconfig.String(func(c *Config) *string { return &c.EmployeeServiceURL })},
+	{Env: "EMPLOYEE_SERVICE_TIMEOUT", Flag: "employee-service-timeout", Usage: "timeout of employee-management calls", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.EmployeeServiceTimeout })},
+	{Env: "STREAM_POLL_INTERVAL", Flag: "stream-poll-interval", Usage: "how often live streams poll for new announcements", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.StreamPollInterval })},
+}
+
+// Load reads the .env file, then builds the config from the process args
+// Exits if the config is invalid
+func Load() *Config {
+	_ = godotenv.Load()
+
+	cfg, err := load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	return cfg
+}
+
+// load builds and validates the config from the given CLI args
+func load(args []string) (*Config, error) {
+	cfg := defaults()
+	fs := flag.NewFlagSet("announcement-service", flag.ContinueOnError)
+	if err := envconfig.Load(fs, args, cfg, options); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// defaults returns the config used when nothing else is set
+func defaults() *Config {
+	return &Config{
+		ServerPort: "8090",
+
+		DBHost:     "localhost",
+		DBPort:     "5432",
+		DBName:     "announcements",
+		DBUser:     "announcement_user",
+		DBSSLMode:  "disable",
+		DBMaxConns: 5,
+
+		DBRetryMaxWait: time.Minute,
+
+		MigrateOnStartup: true,
+
+		EmployeeServiceURL:     "http://localhost:8081/employees-service/api/v1",
+		EmployeeServiceTimeout: 5 * time.Second,
+
+		StreamPollInterval: 2 * time.Second,
+	}
+}
+
+// Validate checks the final config and returns every problem found
+func (c *Config) Validate() error {
+	var errs []error
+
+	if err := envconfig.ValidatePort(c.ServerPort); err != nil {
+		errs = append(errs, fmt.Errorf("server port: %w", err))
+	}
+	if err := envconfig.ValidatePort(c.DBPort); err != nil {
+		errs = append(errs, fmt.Errorf("db port: %w", err))
+	}
+	if c.DBMaxConns < 1 {
+		errs = append(errs, errors.New("db max conns must be at least 1"))
+	}
+	if u, err := url.Parse(c.EmployeeServiceURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("employee service url %q must be an http(s) url", c.EmployeeServiceURL))
+	}
+	if c.EmployeeServiceTimeout <= 0 {
+		errs = append(errs, errors.New("employee service timeout must be positive"))
+	}
+	if c.StreamPollInterval <= 0 {
+		errs = append(errs, errors.New("stream poll interval must be positive"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// DatabaseURL creates the connection url to the db
+func (c *Config) DatabaseURL() string {
+	return fmt.Sprintf(
+		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
+		c.DBUser,
+		c.DBPassword,
+		c.DBHost,
+		c.DBPort,
+		c.DBName,
+		c.DBSSLMode,
+	)
+}
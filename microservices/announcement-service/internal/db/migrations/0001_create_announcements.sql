@@ -0,0 +1,22 @@
+-- Empty departments or positions target every department or position
+CREATE TABLE IF NOT EXISTS announcements.announcements (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	title VARCHAR(255) NOT NULL,
+	body TEXT NOT NULL,
+	departments TEXT[] NOT NULL DEFAULT '{}',
+	positions TEXT[] NOT NULL DEFAULT '{}',
+	author VARCHAR(255) NOT NULL DEFAULT '',
+	published_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at TIMESTAMPTZ,
+	withdrawn_at TIMESTAMPTZ,
+	CHECK (expires_at IS NULL OR expires_at > published_at)
+);
+
+CREATE TABLE IF NOT EXISTS announcements.reads (
+	announcement_id BIGINT NOT NULL REFERENCES announcements.announcements (id) ON DELETE CASCADE,
+	employee_id BIGINT NOT NULL,
+	read_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (announcement_id, employee_id)
+);
+
+CREATE INDEX IF NOT EXISTS reads_employee_idx ON announcements.reads (employee_id);
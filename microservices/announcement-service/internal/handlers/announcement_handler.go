@@ -0,0 +1,234 @@
+// Package handlers exposes the announcement service over HTTP
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"announcement-service/internal/api"
+	"announcement-service/internal/models"
+	"announcement-service/internal/repository"
+	"announcement-service/internal/service"
+	"announcement-service/internal/stream"
+
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// AnnouncementHandler handles HTTP requests for announcements and the
+// feeds of employees
+type AnnouncementHandler struct {
+	service *service.AnnouncementService
+	hub     *stream.Hub
+}
+
+// NewAnnouncementHandler creates a new AnnouncementHandler instance
+func NewAnnouncementHandler(s *service.AnnouncementService, h *stream.Hub) *AnnouncementHandler {
+	return &AnnouncementHandler{service: s, hub: h}
+}
+
+// AnnouncementRequest is the payload to publish an announcement
+type AnnouncementRequest struct {
+	Title string `json:"title" example:"Office closed on Friday"`
+	Body  string `json:"body" example:"The office will be closed for maintenance."`
+	// Departments targeted, empty for every department
+	Departments []string `json:"departments" example:"Engineering"`
+	// Positions targeted, empty for every position
+	Positions []string `json:"positions" example:"Developer"`
+	Author    string   `json:"author" example:"HR"`
+	// ExpiresAt hides the announcement from then on
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// CreateAnnouncement godoc
+//
+//	@Summary		Publish an announcement
+//	@Description	Publishes an announcement to the employees in one of the departments and one of the positions given, empty lists target everyone
+//	@Tags			Announcements
+//	@Accept			json
+//	@Produce		json
+//	@Param			announcement	body		AnnouncementRequest		true	"Announcement data"
+//	@Success		201				{object}	models.Announcement		"Announcement published"
+//	@Failure		400				{object}	httpkit.ErrorResponse		"Invalid JSON format or validation failed"
+//	@Failure		500				{object}	httpkit.ErrorResponse		"Internal server error"
+//	@Router			/announcements [post]
+func (h *AnnouncementHandler) CreateAnnouncement(c *gin.Context) {
+	var req AnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	announcement := models.Announcement{
+		Title:       strings.TrimSpace(req.Title),
+		Body:        strings.TrimSpace(req.Body),
+		Departments: trimAll(req.Departments),
+		Positions:   trimAll(req.Positions),
+		Author:      strings.TrimSpace(req.Author),
+		ExpiresAt:   req.ExpiresAt,
+	}
+	switch {
+	case announcement.Title == "":
+		httpkit.BadRequest(c, "Title is required")
+		return
+	case announcement.Body == "":
+		httpkit.BadRequest(c, "Body is required")
+		return
+	case announcement.ExpiresAt != nil && !announcement.ExpiresAt.After(time.Now()):
+		httpkit.BadRequest(c, "Expiry must be in the future")
+		return
+	}
+
+	if err := h.service.Publish(c.Request.Context(), &announcement); err != nil {
+		httpkit.InternalServerError(c, "Failed to publish announcement")
+		return
+	}
+
+	c.JSON(http.StatusCreated, announcement)
+}
+
+// GetAllAnnouncements godoc
+//
+//	@Summary		List announcements
+//	@Description	Retrieves the announcements newest first with their read counts, only the visible ones unless all is set
+//	@Tags			Announcements
+//	@Produce		json
+//	@Param			page		query		int					false	"Page number"	default(1)
+//	@Param			page_size	query		int					false	"Page size"		default(20)
+//	@Param			all			query		bool				false	"Include withdrawn and expired announcements"
+//	@Success		200			{object}	httpkit.PaginatedResponse{data=[]models.Announcement}	"Announcements"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid query parameters"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/announcements [get]
+func (h *AnnouncementHandler) GetAllAnnouncements(c *gin.Context) {
+	var query api.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpkit.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	query.Normalize()
+
+	announcements, total, err := h.service.FindAll(c.Request.Context(), query.All, query.Page, query.PageSize)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve announcements")
+		return
+	}
+
+	c.JSON(http.StatusOK, httpkit.Paginate(announcements, query.PageQuery, total))
+}
+
+// GetAnnouncementByID godoc
+//
+//	@Summary		Get an announcement
+//	@Description	Retrieves an announcement by its ID
+//	@Tags			Announcements
+//	@Produce		json
+//	@Param			id	path		int					true	"Announcement ID"
+//	@Success		200	{object}	models.Announcement	"Announcement"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid announcement ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Announcement not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/announcements/{id} [get]
+func (h *AnnouncementHandler) GetAnnouncementByID(c *gin.Context) {
+	id, ok := pathID(c, "Invalid announcement ID")
+	if !ok {
+		return
+	}
+
+	announcement, err := h.service.Find(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrAnnouncementNotFound) {
+			httpkit.NotFound(c, "Announcement not found")
+			return
+		}
+		httpkit.InternalServerError(c, "Failed to retrieve announcement")
+		return
+	}
+
+	c.JSON(http.StatusOK, announcement)
+}
+
+// WithdrawAnnouncement godoc
+//
+//	@Summary		Withdraw an announcement
+//	@Description	Takes an announcement down from every feed, its reads are kept
+//	@Tags			Announcements
+//	@Param			id	path	int	true	"Announcement ID"
+//	@Success		204	"Announcement withdrawn"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid announcement ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Announcement not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/announcements/{id}/withdraw [post]
+func (h *AnnouncementHandler) WithdrawAnnouncement(c *gin.Context) {
+	id, ok := pathID(c, "Invalid announcement ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Withdraw(c.Request.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrAnnouncementNotFound) {
+			httpkit.NotFound(c, "Announcement not found")
+			return
+		}
+		httpkit.InternalServerError(c, "Failed to withdraw announcement")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetReads godoc
+//
+//	@Summary		Reads of an announcement
+//	@Description	Retrieves the employees who read an announcement and when, first readers first
+//	@Tags			Announcements
+//	@Produce		json
+//	@Param			id	path		int					true	"Announcement ID"
+//	@Success		200	{array}		models.Read			"Reads"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid announcement ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Announcement not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/announcements/{id}/reads [get]
+func (h *AnnouncementHandler) GetReads(c *gin.Context) {
+	id, ok := pathID(c, "Invalid announcement ID")
+	if !ok {
+		return
+	}
+
+	reads, err := h.service.FindReads(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrAnnouncementNotFound) {
+			httpkit.NotFound(c, "Announcement not found")
+			return
+		}
+		httpkit.InternalServerError(c, "Failed to retrieve reads")
+		return
+	}
+
+	c.JSON(http.StatusOK, reads)
+}
+
+// trimAll trims every value dropping the empty ones
+func trimAll(values []string) []string {
+	trimmed := []string{}
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			trimmed = append(trimmed, v)
+		}
+	}
+	return trimmed
+}
+
+// pathID parses the id path parameter, answering 400 with message when it
+// is invalid
+func pathID(c *gin.Context, message string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		httpkit.BadRequest(c, message)
+		return 0, false
+	}
+	return id, true
+}
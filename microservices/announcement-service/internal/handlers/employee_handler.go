@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"announcement-service/internal/models"
+	"announcement-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// replayLimit caps the announcements replayed to a resuming client
+const replayLimit = 500
+
+// GetFeed godoc
+//
+//	@Summary		Announcements of an employee
+//	@Description	Retrieves the visible announcements targeting the department and position of the employee, newest first, with whether they read them. Poll it with unread=true for a badge count
+//	@Tags			Feed
+//	@Produce		json
+//	@Param			id		path		int					true	"Employee ID"
+//	@Param			unread	query		bool				false	"Only the unread announcements"
+//	@Success		200		{array}		models.FeedItem		"Announcements"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid employee ID"
+//	@Failure		404		{object}	httpkit.ErrorResponse	"Employee not found"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Failure		503		{object}	httpkit.ErrorResponse	"Employee service unavailable"
+//	@Router			/employees/{id}/announcements [get]
+func (h *AnnouncementHandler) GetFeed(c *gin.Context) {
+	id, ok := pathID(c, "Invalid employee ID")
+	if !ok {
+		return
+	}
+	unread, _ := strconv.ParseBool(c.Query("unread"))
+
+	feed, err := h.service.Feed(c.Request.Context(), id, unread)
+	if err != nil {
+		switch {
+		case errors.Is(err, employees.ErrNotFound):
+			httpkit.NotFound(c, "Employee not found")
+		case errors.Is(err, employees.ErrUnavailable):
+			httpkit.Error(c, http.StatusServiceUnavailable, "Employee service unavailable")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve announcements")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// MarkRead godoc
+//
+//	@Summary		Mark an announcement read
+//	@Description	Records that the employee read the announcement. Marking it again keeps the first read time
+//	@Tags			Feed
+//	@Produce		json
+//	@Param			id				path		int					true	"Employee ID"
+//	@Param			announcementId	path		int					true	"Announcement ID"
+//	@Success		200				{object}	models.Read			"Read recorded"
+//	@Failure		400				{object}	httpkit.ErrorResponse	"Invalid employee or announcement ID"
+//	@Failure		404				{object}	httpkit.ErrorResponse	"Employee not found, or announcement not shown to them"
+//	@Failure		500				{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Failure		503				{object}	httpkit.ErrorResponse	"Employee service unavailable"
+//	@Router			/employees/{id}/announcements/{announcementId}/read [post]
+func (h *AnnouncementHandler) MarkRead(c *gin.Context) {
+	id, ok := pathID(c, "Invalid employee ID")
+	if !ok {
+		return
+	}
+	announcementID, err := strconv.ParseInt(c.Param("announcementId"), 10, 64)
+	if err != nil || announcementID < 1 {
+		httpkit.BadRequest(c, "Invalid announcement ID")
+		return
+	}
+
+	read, err := h.service.MarkRead(c.Request.Context(), id, announcementID)
+	if err != nil {
+		switch {
+		case errors.Is(err, employees.ErrNotFound):
+			httpkit.NotFound(c, "Employee not found")
+		case errors.Is(err, repository.ErrAnnouncementNotFound):
+			httpkit.NotFound(c, "Announcement not found")
+		case errors.Is(err, employees.ErrUnavailable):
+			httpkit.Error(c, http.StatusServiceUnavailable, "Employee service unavailable")
+		default:
+			httpkit.InternalServerError(c, "Failed to mark announcement read")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, read)
+}
+
+// StreamFeed godoc
+//
+//	@Summary		Live announcements of an employee
+//	@Description	Pushes the new announcements targeting the employee over Server-Sent Events, as announcement events with the announcement id as event id. Send Last-Event-ID to get those published while disconnected
+//	@Tags			Feed
+//	@Produce		text/event-stream
+//	@Param			id				path		int					true	"Employee ID"
+//	@Param			Last-Event-ID	header		int					false	"ID of the last announcement received"
+//	@Success		200				{object}	models.Announcement	"Stream of announcements"
+//	@Failure		400				{object}	httpkit.ErrorResponse	"Invalid employee ID or Last-Event-ID"
+//	@Failure		404				{object}	httpkit.ErrorResponse	"Employee not found"
+//	@Failure		500				{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Failure		503				{object}	httpkit.ErrorResponse	"Employee service unavailable"
+//	@Router			/employees/{id}/announcements/stream [get]
+func (h *AnnouncementHandler) StreamFeed(c *gin.Context) {
+	id, ok := pathID(c, "Invalid employee ID")
+	if !ok {
+		return
+	}
+
+	lastID := c.GetHeader("Last-Event-ID")
+	if lastID == "" {
+		lastID = c.Query("lastEventId")
+	}
+
+	var last int64
+	if lastID != "" {
+		n, err := strconv.ParseInt(lastID, 10, 64)
+		if err != nil || n < 0 {
+			httpkit.BadRequest(c, "Invalid Last-Event-ID")
+			return
+		}
+		last = n
+	}
+
+	// The target is read once, a department or position change applies
+	// when the client reconnects
+	employee, err := h.service.Employee(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, employees.ErrNotFound):
+			httpkit.NotFound(c, "Employee not found")
+		case errors.Is(err, employees.ErrUnavailable):
+			httpkit.Error(c, http.StatusServiceUnavailable, "Employee service unavailable")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve employee")
+		}
+		return
+	}
+
+	// Subscribe before replaying so nothing is missed in between
+	live, unsubscribe := h.hub.Subscribe()
+	defer unsubscribe()
+
+	var backlog []models.Announcement
+	if lastID != "" {
+		backlog, err = h.hub.Replay(c.Request.Context(), last, replayLimit)
+		if err != nil {
+			httpkit.InternalServerError(c, "Failed to replay announcements")
+			return
+		}
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for _, a := range backlog {
+		if a.Targets(employee.Department, employee.Position) {
+			renderAnnouncement(c, a)
+		}
+		last = a.ID
+	}
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case a, ok := <-live:
+			if !ok {
+				// Too slow, the client reconnects with Last-Event-ID
+				return false
+			}
+			if a.ID > last && a.Targets(employee.Department, employee.Position) {
+				renderAnnouncement(c, a)
+				last = a.ID
+			}
+			return true
+		}
+	})
+}
+
+// renderAnnouncement writes one SSE frame with the announcement id as id
+func renderAnnouncement(c *gin.Context, a models.Announcement) {
+	c.Render(-1, sse.Event{
+		Id:    strconv.FormatInt(a.ID, 10),
+		Event: "announcement",
+		Data:  a,
+	})
+}
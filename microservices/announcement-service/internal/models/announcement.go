@@ -0,0 +1,53 @@
+// Package models define the core data structures of the announcement
+// service
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Announcement is a message published to the employees of some
+// departments and positions
+type Announcement struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title" example:"Office closed on Friday"`
+	Body  string `json:"body" example:"The office will be closed for maintenance."`
+	// Departments targeted, empty for every department
+	Departments []string `json:"departments" example:"Engineering"`
+	// Positions targeted, empty for every position
+	Positions   []string   `json:"positions" example:"Developer"`
+	Author      string     `json:"author" example:"HR"`
+	PublishedAt time.Time  `json:"publishedAt"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	// WithdrawnAt is set once the announcement is taken down
+	WithdrawnAt *time.Time `json:"withdrawnAt,omitempty"`
+	// ReadCount is how many employees have read it
+	ReadCount int `json:"readCount"`
+}
+
+// Targets reports whether the announcement is meant for an employee of
+// department and position
+func (a *Announcement) Targets(department, position string) bool {
+	return (len(a.Departments) == 0 || slices.Contains(a.Departments, department)) &&
+		(len(a.Positions) == 0 || slices.Contains(a.Positions, position))
+}
+
+// Visible reports whether the announcement is still shown at t
+func (a *Announcement) Visible(t time.Time) bool {
+	return a.WithdrawnAt == nil && (a.ExpiresAt == nil || a.ExpiresAt.After(t))
+}
+
+// FeedItem is an announcement as shown to an employee
+type FeedItem struct {
+	Announcement
+	Read   bool       `json:"read"`
+	ReadAt *time.Time `json:"readAt,omitempty"`
+}
+
+// Read records that an employee has read an announcement
+type Read struct {
+	AnnouncementID int64     `json:"announcementId"`
+	EmployeeID     int64     `json:"employeeId"`
+	ReadAt         time.Time `json:"readAt"`
+}
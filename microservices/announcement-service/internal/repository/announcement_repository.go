@@ -0,0 +1,276 @@
+// Package repository implements the data access layer of the announcement
+// service
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"announcement-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrAnnouncementNotFound is returned when the announcement does not exist
+var ErrAnnouncementNotFound = errors.New("announcement not found")
+
+// AnnouncementRepository defines the interface for announcement data
+// operations
+type AnnouncementRepository interface {
+	Create(ctx context.Context, a *models.Announcement) error
+	Find(ctx context.Context, id int64) (*models.Announcement, error)
+	// FindAll retrieves a page of announcements, newest first, only the
+	// visible ones unless all is set, and the total count
+	FindAll(ctx context.Context, all bool, limit, offset int) ([]models.Announcement, int, error)
+	// Withdraw takes an announcement down, keeping its reads
+	Withdraw(ctx context.Context, id int64) error
+	// FindAfter retrieves up to limit visible announcements with an id
+	// greater than afterID, oldest first
+	FindAfter(ctx context.Context, afterID int64, limit int) ([]models.Announcement, error)
+	// LastID returns the id of the newest announcement, 0 if there is none
+	LastID(ctx context.Context) (int64, error)
+
+	// FindFeed retrieves the visible announcements targeting an employee of
+	// department and position, newest first, with whether they read them
+	FindFeed(ctx context.Context, employeeID int64, department, position string, unreadOnly bool) ([]models.FeedItem, error)
+	// MarkRead records the read, keeping the first read time when the
+	// employee already read the announcement
+	MarkRead(ctx context.Context, r *models.Read) error
+	// FindReads retrieves who read an announcement, first readers first
+	FindReads(ctx context.Context, announcementID int64) ([]models.Read, error)
+}
+
+// announcementRepository is the postgresql implementation of
+// AnnouncementRepository
+type announcementRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAnnouncementRepository creates a new instance of AnnouncementRepository
+func NewAnnouncementRepository(db *pgxpool.Pool) AnnouncementRepository {
+	return &announcementRepository{db: db}
+}
+
+// announcementColumns are the columns scanned by scanAnnouncement
+const announcementColumns = `
+        a.id, a.title, a.body, a.departments, a.positions, a.author, a.published_at, a.expires_at, a.withdrawn_at,
+        (SELECT COUNT(*) FROM announcements.reads r WHERE r.announcement_id = a.id)
+    `
+
+// visible is the condition of the announcements still shown
+const visible = `a.withdrawn_at IS NULL AND (a.expires_at IS NULL OR a.expires_at > CURRENT_TIMESTAMP)`
+
+// scanAnnouncement scans a row selected with announcementColumns, followed
+// by dest
+func scanAnnouncement(row pgx.Row, dest ...any) (models.Announcement, error) {
+	var a models.Announcement
+	err := row.Scan(append([]any{
+		&a.ID, &a.Title, &a.Body, &a.Departments, &a.Positions, &a.Author, &a.PublishedAt, &a.ExpiresAt, &a.WithdrawnAt,
+		&a.ReadCount,
+	}, dest...)...)
+	return a, err
+}
+
+// Create inserts an announcement published now
+func (r *announcementRepository) Create(ctx context.Context, a *models.Announcement) error {
+	query := `
+        INSERT INTO announcements.announcements (title, body, departments, positions, author, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, published_at
+    `
+
+	err := r.db.QueryRow(ctx, query, a.Title, a.Body, a.Departments, a.Positions, a.Author, a.ExpiresAt).Scan(&a.ID, &a.PublishedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create announcement: %w", err)
+	}
+
+	return nil
+}
+
+// Find retrieves an announcement, withdrawn or not
+func (r *announcementRepository) Find(ctx context.Context, id int64) (*models.Announcement, error) {
+	a, err := scanAnnouncement(r.db.QueryRow(ctx, `SELECT `+announcementColumns+` FROM announcements.announcements a WHERE a.id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAnnouncementNotFound
+		}
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// FindAll retrieves a page of announcements and the total count
+func (r *announcementRepository) FindAll(ctx context.Context, all bool, limit, offset int) ([]models.Announcement, int, error) {
+	where := `WHERE $1 OR (` + visible + `)`
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM announcements.announcements a `+where, all).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count announcements: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+announcementColumns+`
+        FROM announcements.announcements a `+where+`
+        ORDER BY a.id DESC
+        LIMIT $2 OFFSET $3`, all, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query announcements: %w", err)
+	}
+
+	announcements, err := collectAnnouncements(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return announcements, total, nil
+}
+
+// Withdraw sets the withdrawal time, once
+func (r *announcementRepository) Withdraw(ctx context.Context, id int64) error {
+	result, err := r.db.Exec(ctx, `
+        UPDATE announcements.announcements
+        SET withdrawn_at = COALESCE(withdrawn_at, CURRENT_TIMESTAMP)
+        WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to withdraw announcement: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAnnouncementNotFound
+	}
+
+	return nil
+}
+
+// FindAfter retrieves the visible announcements after afterID
+func (r *announcementRepository) FindAfter(ctx context.Context, afterID int64, limit int) ([]models.Announcement, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+announcementColumns+`
+        FROM announcements.announcements a
+        WHERE a.id > $1 AND `+visible+`
+        ORDER BY a.id
+        LIMIT $2`, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query announcements: %w", err)
+	}
+
+	return collectAnnouncements(rows)
+}
+
+// LastID returns the newest announcement id
+func (r *announcementRepository) LastID(ctx context.Context) (int64, error) {
+	var id int64
+	err := r.db.QueryRow(ctx, `SELECT COALESCE(MAX(id), 0) FROM announcements.announcements`).Scan(&id)
+	return id, err
+}
+
+// FindFeed retrieves the announcements of an employee with their read
+// status. Empty target arrays match everyone
+func (r *announcementRepository) FindFeed(ctx context.Context, employeeID int64, department, position string, unreadOnly bool) ([]models.FeedItem, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+announcementColumns+`, rd.read_at
+        FROM announcements.announcements a
+        LEFT JOIN announcements.reads rd ON rd.announcement_id = a.id AND rd.employee_id = $1
+        WHERE `+visible+`
+          AND (cardinality(a.departments) = 0 OR $2 = ANY (a.departments))
+          AND (cardinality(a.positions) = 0 OR $3 = ANY (a.positions))
+          AND (rd.read_at IS NULL OR NOT $4)
+        ORDER BY a.id DESC`, employeeID, department, position, unreadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feed: %w", err)
+	}
+	defer rows.Close()
+
+	feed := []models.FeedItem{}
+	for rows.Next() {
+		var item models.FeedItem
+		item.Announcement, err = scanAnnouncement(rows, &item.ReadAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feed row: %w", err)
+		}
+		item.Read = item.ReadAt != nil
+		feed = append(feed, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating feed rows: %w", err)
+	}
+
+	return feed, nil
+}
+
+// MarkRead inserts the read or loads the existing one
+func (r *announcementRepository) MarkRead(ctx context.Context, rd *models.Read) error {
+	query := `
+        WITH inserted AS (
+            INSERT INTO announcements.reads (announcement_id, employee_id)
+            VALUES ($1, $2)
+            ON CONFLICT (announcement_id, employee_id) DO NOTHING
+            RETURNING read_at
+        )
+        SELECT read_at FROM inserted
+        UNION ALL
+        SELECT read_at FROM announcements.reads WHERE announcement_id = $1 AND employee_id = $2
+        LIMIT 1
+    `
+
+	if err := r.db.QueryRow(ctx, query, rd.AnnouncementID, rd.EmployeeID).Scan(&rd.ReadAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrAnnouncementNotFound
+		}
+		return fmt.Errorf("failed to mark announcement read: %w", err)
+	}
+
+	return nil
+}
+
+// FindReads retrieves the reads of an announcement
+func (r *announcementRepository) FindReads(ctx context.Context, announcementID int64) ([]models.Read, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT announcement_id, employee_id, read_at
+        FROM announcements.reads
+        WHERE announcement_id = $1
+        ORDER BY read_at, employee_id`, announcementID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reads: %w", err)
+	}
+	defer rows.Close()
+
+	reads := []models.Read{}
+	for rows.Next() {
+		var rd models.Read
+		if err := rows.Scan(&rd.AnnouncementID, &rd.EmployeeID, &rd.ReadAt); err != nil {
+			return nil, fmt.Errorf("failed to scan read row: %w", err)
+		}
+		reads = append(reads, rd)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating read rows: %w", err)
+	}
+
+	return reads, nil
+}
+
+// collectAnnouncements scans and closes rows selected with
+// announcementColumns
+func collectAnnouncements(rows pgx.Rows) ([]models.Announcement, error) {
+	defer rows.Close()
+
+	announcements := []models.Announcement{}
+	for rows.Next() {
+		a, err := scanAnnouncement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan announcement row: %w", err)
+		}
+		announcements = append(announcements, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating announcement rows: %w", err)
+	}
+
+	return announcements, nil
+}
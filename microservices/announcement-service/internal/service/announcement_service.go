@@ -0,0 +1,93 @@
+// Package service contains the business logic of the announcement service
+package service
+
+import (
+	"context"
+	"time"
+
+	"announcement-service/internal/models"
+	"announcement-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+)
+
+// AnnouncementService publishes announcements and tracks which employees
+// read them
+type AnnouncementService struct {
+	repo      repository.AnnouncementRepository
+	employees *employees.Client
+}
+
+// NewAnnouncementService creates a new AnnouncementService instance
+func NewAnnouncementService(repo repository.AnnouncementRepository, employeeClient *employees.Client) *AnnouncementService {
+	return &AnnouncementService{repo: repo, employees: employeeClient}
+}
+
+// Publish stores the announcement, live streams pick it up on their next
+// poll
+func (s *AnnouncementService) Publish(ctx context.Context, a *models.Announcement) error {
+	return s.repo.Create(ctx, a)
+}
+
+// Find retrieves an announcement
+func (s *AnnouncementService) Find(ctx context.Context, id int64) (*models.Announcement, error) {
+	return s.repo.Find(ctx, id)
+}
+
+// FindAll retrieves a page of announcements, withdrawn and expired ones
+// included with all set
+func (s *AnnouncementService) FindAll(ctx context.Context, all bool, page, pageSize int) ([]models.Announcement, int, error) {
+	return s.repo.FindAll(ctx, all, pageSize, (page-1)*pageSize)
+}
+
+// Withdraw takes an announcement down
+func (s *AnnouncementService) Withdraw(ctx context.Context, id int64) error {
+	return s.repo.Withdraw(ctx, id)
+}
+
+// FindReads retrieves who read an announcement
+func (s *AnnouncementService) FindReads(ctx context.Context, id int64) ([]models.Read, error) {
+	if _, err := s.repo.Find(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.FindReads(ctx, id)
+}
+
+// Employee fetches the employee from employee-management, whose
+// department and position decide the announcements they get
+func (s *AnnouncementService) Employee(ctx context.Context, id int64) (*employees.Employee, error) {
+	return s.employees.Get(ctx, id)
+}
+
+// Feed retrieves the announcements targeting the employee, only the
+// unread ones with unreadOnly set
+func (s *AnnouncementService) Feed(ctx context.Context, employeeID int64, unreadOnly bool) ([]models.FeedItem, error) {
+	employee, err := s.employees.Get(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindFeed(ctx, employeeID, employee.Department, employee.Position, unreadOnly)
+}
+
+// MarkRead records that the employee read the announcement. Announcements
+// not shown to the employee are not found
+func (s *AnnouncementService) MarkRead(ctx context.Context, employeeID, announcementID int64) (*models.Read, error) {
+	employee, err := s.employees.Get(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := s.repo.Find(ctx, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	if !a.Visible(time.Now()) || !a.Targets(employee.Department, employee.Position) {
+		return nil, repository.ErrAnnouncementNotFound
+	}
+
+	read := models.Read{AnnouncementID: announcementID, EmployeeID: employeeID}
+	if err := s.repo.MarkRead(ctx, &read); err != nil {
+		return nil, err
+	}
+	return &read, nil
+}
@@ -0,0 +1,113 @@
+// Package stream pushes new announcements to live clients (SSE) by
+// polling the announcements table
+package stream
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"announcement-service/internal/models"
+	"announcement-service/internal/repository"
+)
+
+// subscriberBuffer is the number of announcements queued per subscriber
+// before it is considered too slow and disconnected
+const subscriberBuffer = 64
+
+// Hub polls the announcements once for every instance and fans the new
+// ones out to the subscribers, which filter them by target. A subscriber
+// that cannot keep up is dropped, it can reconnect and resume from the
+// last id it got
+type Hub struct {
+	repo         repository.AnnouncementRepository
+	pollInterval time.Duration
+
+	mu          sync.Mutex
+	subscribers map[chan models.Announcement]struct{}
+}
+
+// NewHub creates a new Hub
+func NewHub(repo repository.AnnouncementRepository, pollInterval time.Duration) *Hub {
+	return &Hub{
+		repo:         repo,
+		pollInterval: pollInterval,
+		subscribers:  map[chan models.Announcement]struct{}{},
+	}
+}
+
+// Subscribe registers a subscriber. The channel is closed when the
+// subscriber is dropped or unsubscribe is called
+func (h *Hub) Subscribe() (<-chan models.Announcement, func()) {
+	ch := make(chan models.Announcement, subscriberBuffer)
+
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[ch]; ok {
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+// Replay returns up to limit visible announcements after afterID, used to
+// resume a stream
+func (h *Hub) Replay(ctx context.Context, afterID int64, limit int) ([]models.Announcement, error) {
+	return h.repo.FindAfter(ctx, afterID, limit)
+}
+
+// Run polls for new announcements until ctx is done, starting from the
+// newest one
+func (h *Hub) Run(ctx context.Context) {
+	last, err := h.repo.LastID(ctx)
+	if err != nil {
+		log.Printf("stream hub failed to read last announcement: %v", err)
+	}
+
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		batch, err := h.repo.FindAfter(ctx, last, 500)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("stream hub poll failed: %v", err)
+			}
+			continue
+		}
+
+		for _, a := range batch {
+			h.broadcast(a)
+			last = a.ID
+		}
+	}
+}
+
+// broadcast sends a to every subscriber, dropping the slow ones
+func (h *Hub) broadcast(a models.Announcement) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- a:
+		default:
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"api-gateway/internal/config"
+	"api-gateway/internal/discovery"
+)
+
+// registerInstance registers the gateway in the registry and deregisters
+// it when the process is asked to stop
+func registerInstance(cfg *config.Config, registry discovery.Registry) {
+	inst, err := discovery.NewInstance(cfg.ServiceName, cfg.ServiceAddress, cfg.ServerPort, "/health", false)
+	if err != nil {
+		log.Fatalf("invalid service address: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := registry.Register(ctx, inst); err != nil {
+		log.Fatalf("failed to register in %s: %v", cfg.DiscoveryBackend, err)
+	}
+	log.Printf("registered %s as %s in %s", inst.Address, inst.Name, cfg.DiscoveryBackend)
+
+	go func() {
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+		<-stop
+		cancel()
+
+		deregCtx, done := context.WithTimeout(context.Background(), 5*time.Second)
+		defer done()
+		if err := registry.Deregister(deregCtx, inst); err != nil {
+			log.Printf("failed to deregister from %s: %v", cfg.DiscoveryBackend, err)
+		}
+		os.Exit(0)
+	}()
+}
@@ -0,0 +1,87 @@
+// API gateway fronting the microservices: path based routing, bearer
+// token auth, rate limiting, request logging and one Swagger UI for all
+// the services
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"api-gateway/internal/api"
+	"api-gateway/internal/config"
+	"api-gateway/internal/discovery"
+	"api-gateway/internal/docs"
+	"api-gateway/internal/middleware"
+	"api-gateway/internal/proxy"
+
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	cfg := config.Load()
+
+	registry, err := discovery.New(cfg.DiscoveryBackend, cfg.DiscoveryURL, cfg.DiscoveryTTL)
+	if err != nil {
+		log.Fatalf("failed to create discovery client: %v", err)
+	}
+
+	// Upstream per service, static or resolved from the registry
+	targets := map[string]discovery.Target{}
+	for _, s := range cfg.Services {
+		target, err := proxy.NewTarget(s, registry, cfg.DiscoveryRefresh)
+		if err != nil {
+			log.Fatalf("invalid upstream for %s: %v", s.Name, err)
+		}
+		targets[s.Name] = target
+	}
+
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+
+	if err := router.SetTrustedProxies(envconfig.SplitList(cfg.TrustedProxies)); err != nil {
+		log.Fatalf("invalid trusted proxies: %v", err)
+	}
+
+	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger())
+	if !cfg.AuthDisabled {
+		router.Use(middleware.Auth(cfg.JWTSecret, cfg.JWTIssuer, envconfig.SplitList(cfg.AuthPublicPaths)))
+	} else {
+		log.Printf("AUTH_DISABLED set, requests are forwarded without authentication")
+	}
+	// After Auth, so authenticated clients are limited by subject
+	if cfg.RateLimit > 0 {
+		router.Use(middleware.RateLimit(newLimiter(cfg), cfg.RateLimit))
+	}
+
+	router.NoRoute(func(c *gin.Context) {
+		api.Error(c, http.StatusNotFound, "No service for this path")
+	})
+
+	// Gateway health
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "UP"})
+	})
+
+	// Aggregated Swagger UI
+	docs.New(cfg.Services, targets).Register(router)
+
+	// Path based routing, the prefix is kept when forwarding
+	for _, s := range cfg.Services {
+		handler := proxy.New(s, targets[s.Name], cfg.UpstreamTimeout)
+		router.Any(s.Prefix, handler)
+		router.Any(s.Prefix+"/*path", handler)
+		log.Printf("routing %s/* to %s (%s)", s.Prefix, s.Upstream, s.Name)
+	}
+
+	if registry != nil {
+		registerInstance(cfg, registry)
+	}
+
+	log.Printf("API gateway listening on :%s, docs at /swagger/index.html", cfg.ServerPort)
+	if err := router.Run(":" + cfg.ServerPort); err != nil {
+		log.Fatalf("Failed to start gateway: %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+
+	"api-gateway/internal/config"
+	"api-gateway/internal/middleware"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// rateLimitKeyPrefix namespaces the gateway's counters in a shared Redis
+const rateLimitKeyPrefix = "api-gateway:ratelimit:"
+
+// newLimiter builds the configured rate limiter. An unreachable Redis is
+// only logged, requests go through until it is back
+func newLimiter(cfg *config.Config) middleware.Limiter {
+	if cfg.RateLimitBackend != "redis" {
+		return middleware.NewLocalLimiter(cfg.RateLimit, cfg.RateBurst)
+	}
+
+	opts, err := redis.ParseURL(cfg.RedisURL)
+	if err != nil {
+		log.Fatalf("invalid redis url: %v", err)
+	}
+	client := redis.NewClient(opts)
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		log.Printf("redis not reachable, rate limiting is off until it is: %v", err)
+	}
+
+	limit := int(math.Floor(cfg.RateLimit * cfg.RateWindow.Seconds()))
+	log.Printf("rate limiting %d requests per %s per client in redis", limit, cfg.RateWindow)
+	return middleware.NewRedisLimiter(client, rateLimitKeyPrefix, limit, cfg.RateWindow)
+}
@@ -0,0 +1,43 @@
+// Package api writes the gateway's own responses, in the same error shape
+// as the services behind it
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorResponse is the standard error response
+type ErrorResponse struct {
+	Status    int       `json:"status"`
+	Error     string    `json:"error"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+	Path      string    `json:"path"`
+}
+
+// newError builds the error response for r
+func newError(r *http.Request, status int, message string) ErrorResponse {
+	return ErrorResponse{
+		Status:    status,
+		Error:     http.StatusText(status),
+		Message:   message,
+		Timestamp: time.Now().UTC(),
+		Path:      r.URL.Path,
+	}
+}
+
+// Error aborts the request with an error response
+func Error(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, newError(c.Request, status, message))
+}
+
+// Write writes an error response outside of gin, e.g. from the proxy
+func Write(w http.ResponseWriter, r *http.Request, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(newError(r, status, message))
+}
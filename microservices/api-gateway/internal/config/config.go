@@ -0,0 +1,247 @@
+// Package config loads the gateway configuration from defaults, a YAML
+// file, env variables and CLI flags, in that order
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"github.com/joho/godotenv"
+)
+
+// Service is an upstream microservice routed by path prefix
+type Service struct {
+	Name string `yaml:"name"`
+	// Prefix is matched against the request path and forwarded unchanged
+	Prefix string `yaml:"prefix"`
+	// Upstream is the base url of the service, e.g. http://employees:8081,
+	// or discovery://<registered name> to resolve instances from the registry
+	Upstream string `yaml:"upstream"`
+	// SwaggerPath is the spec of the service, aggregated into the gateway UI
+	SwaggerPath string `yaml:"swagger_path"`
+}
+
+// DiscoveryScheme marks upstreams resolved through the service registry
+const DiscoveryScheme = "discovery"
+
+// DiscoveryName returns the registered name for discovery:// upstreams,
+// empty for static ones
+func (s Service) DiscoveryName() string {
+	u, err := url.Parse(s.Upstream)
+	if err != nil || u.Scheme != DiscoveryScheme {
+		return ""
+	}
+	return u.Host
+}
+
+// Config holds configuration loaded from defaults, file, env and flags
+type Config struct {
+	ServerPort string `yaml:"server_port"`
+
+	Services []Service `yaml:"services"`
+
+	UpstreamTimeout time.Duration `yaml:"upstream_timeout"`
+
+	// JWT verification. The secret is required unless auth is disabled
+	// explicitly
+	JWTSecret       string `yaml:"jwt_secret"`
+	JWTIssuer       string `yaml:"jwt_issuer"`
+	AuthPublicPaths string `yaml:"auth_public_paths"`
+	AuthDisabled    bool   `yaml:"auth_disabled"`
+
+	// Rate limiting per client: memory (per instance) or redis (shared)
+	RateLimit        float64       `yaml:"rate_limit"`
+	RateBurst        int           `yaml:"rate_burst"`
+	RateLimitBackend string        `yaml:"rate_limit_backend"`
+	RateWindow       time.Duration `yaml:"rate_window"`
+	RedisURL         string        `yaml:"redis_url"`
+
+	TrustedProxies string `yaml:"trusted_proxies"`
+
+	// Service registry: none, consul or etcd
+	DiscoveryBackend string        `yaml:"discovery_backend"`
+	DiscoveryURL     string        `yaml:"discovery_url"`
+	DiscoveryTTL     time.Duration `yaml:"discovery_ttl"`
+	DiscoveryRefresh time.Duration `yaml:"discovery_refresh"`
+	// Name and host:port the gateway registers itself with
+	ServiceName    string `yaml:"service_name"`
+	ServiceAddress string `yaml:"service_address"`
+}
+
+// options lists every setting that can be overridden by env or flags
+var options = []envconfig.Option[Config]{
+	{Env: "GATEWAY_PORT", Flag: "port", Usage: "HTTP server port", Set: envconfig.String(func(c *Config) *string { return &c.ServerPort })},
+	{Env: "GATEWAY_SERVICES", Flag: "services", Usage: "routed services, name=prefix=upstream[=swagger path],...", Set: setServices},
+	{Env: "UPSTREAM_TIMEOUT", Flag: "upstream-timeout", Usage: "timeout waiting for upstream response headers", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.UpstreamTimeout })},
+	{Env: "JWT_SECRET", Flag: "jwt-secret", Usage: "HS256 secret for bearer tokens, required unless auth is disabled", Set: envconfig.String(func(c *Config) *string { return &c.JWTSecret })},
+	{Env: "JWT_ISSUER", Flag: "jwt-issuer", Usage: "required token issuer, empty accepts any", Set: envconfig.String(func(c *Config) *string { return &c.JWTIssuer })},
+	{Env: "AUTH_PUBLIC_PATHS", Flag: "auth-public-paths", Usage: "comma separated path prefixes reachable without a token", Set: envconfig.String(func(c *Config) *string { return &c.AuthPublicPaths })},
+	{Env: "AUTH_DISABLED", Flag: "auth-disabled", Usage: "forward requests without authentication, for local development", Set: envconfig.Bool(func(c *Config) *bool { return &c.AuthDisabled })},
+	{Env: "RATE_LIMIT", Flag: "rate-limit", Usage: "requests per second per client, 0 disables", Set: envconfig.Float(func(c *Config) *float64 { return &c.RateLimit })},
+	{Env: "RATE_BURST", Flag: "rate-burst", Usage: "requests a client may burst above the rate", Set: envconfig.Int(func(c *Config) *int { return &c.RateBurst })},
+	{Env: "RATE_LIMIT_BACKEND", Flag: "rate-limit-backend", Usage: "rate limiter: memory (per instance) or redis (shared)", Set: envconfig.String(func(c *Config) *string { return &c.RateLimitBackend })},
+	{Env: "RATE_WINDOW", Flag: "rate-window", Usage: "sliding window of the redis rate limiter", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.RateWindow })},
+	{Env: "REDIS_URL", Flag: "redis-url", Usage: "redis:// url of the shared rate limiter", Set: envconfig.String(func(c *Config) *string { return &c.RedisURL })},
+	{Env: "TRUSTED_PROXIES", Flag: "trusted-proxies", Usage: "comma separated proxies trusted for the client ip", Set: envconfig.String(func(c *Config) *string { return &c.TrustedProxies })},
+	{Env: "DISCOVERY_BACKEND", Flag: "discovery-backend", Usage: "service registry: none, consul or etcd", Set: envconfig.String(func(c *Config) *string { return &c.DiscoveryBackend })},
+	{Env: "DISCOVERY_URL", Flag: "discovery-url", Usage: "service registry url (consul agent or etcd endpoint)", Set: envconfig.String(func(c *Config) *string { return &c.DiscoveryURL })},
+	{Env: "DISCOVERY_TTL", Flag: "discovery-ttl", Usage: "how long the gateway registration outlives a dead instance", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DiscoveryTTL })},
+	{Env: "DISCOVERY_REFRESH", Flag: "discovery-refresh", Usage: "how often upstream instances are resolved again", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DiscoveryRefresh })},
+	{Env: "SERVICE_NAME", Flag: "service-name", Usage: "name the gateway registers under", Set: envconfig.String(func(c *Config) *string { return &c.ServiceName })},
+	{Env: "SERVICE_ADDRESS", Flag: "service-address", Usage: "advertised host:port, hostname and port by default", Set: envconfig.String(func(c *Config) *string { return &c.ServiceAddress })},
+}
+
+// Load reads the .env file, then builds the config from the process args
+// Exits if the config is invalid
+func Load() *Config {
+	_ = godotenv.Load()
+
+	cfg, err := load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	return cfg
+}
+
+// load builds and validates the config from the given CLI args
+func load(args []string) (*Config, error) {
+	cfg := defaults()
+	fs := flag.NewFlagSet("api-gateway", flag.ContinueOnError)
+	if err := envconfig.Load(fs, args, cfg, options); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// defaults returns the config used when nothing else is set
+func defaults() *Config {
+	return &Config{
+		ServerPort: "8080",
+
+		Services: []Service{
+			{
+				Name:        "employee",
+				Prefix:      "/employees-service",
+				Upstream:    "http://localhost:8081",
+				SwaggerPath: "/swagger/doc.json",
+			},
+		},
+
+		UpstreamTimeout: 30 * time.Second,
+
+		AuthPublicPaths: "/health,/swagger,/*/api/health,/*/api/*/health",
+
+		RateLimit:        20,
+		RateBurst:        40,
+		RateLimitBackend: "memory",
+		RateWindow:       time.Minute,
+
+		TrustedProxies: "127.0.0.1",
+
+		DiscoveryBackend: "none",
+		DiscoveryTTL:     30 * time.Second,
+		DiscoveryRefresh: 10 * time.Second,
+		ServiceName:      "api-gateway",
+	}
+}
+
+// Validate checks the final config and returns every problem found
+func (c *Config) Validate() error {
+	var errs []error
+
+	if err := envconfig.ValidatePort(c.ServerPort); err != nil {
+		errs = append(errs, fmt.Errorf("server port: %w", err))
+	}
+	if len(c.Services) == 0 {
+		errs = append(errs, errors.New("at least one service must be configured"))
+	}
+
+	names := map[string]bool{}
+	for _, s := range c.Services {
+		if s.Name == "" || names[s.Name] {
+			errs = append(errs, fmt.Errorf("service %q: names must be set and unique", s.Name))
+		}
+		names[s.Name] = true
+
+		if !strings.HasPrefix(s.Prefix, "/") || strings.HasSuffix(s.Prefix, "/") {
+			errs = append(errs, fmt.Errorf("service %s: prefix %q must start and not end with /", s.Name, s.Prefix))
+		}
+		u, err := url.Parse(s.Upstream)
+		switch {
+		case err == nil && u.Scheme == DiscoveryScheme && u.Host != "":
+			if c.DiscoveryBackend == "none" {
+				errs = append(errs, fmt.Errorf("service %s: discovery upstream needs a discovery backend", s.Name))
+			}
+		case err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "":
+			errs = append(errs, fmt.Errorf("service %s: upstream %q must be an http(s) or discovery:// url", s.Name, s.Upstream))
+		}
+	}
+
+	if c.JWTSecret == "" && !c.AuthDisabled {
+		errs = append(errs, errors.New("jwt secret is required, set AUTH_DISABLED=true to forward requests without authentication"))
+	}
+	if c.UpstreamTimeout <= 0 {
+		errs = append(errs, errors.New("upstream timeout must be positive"))
+	}
+	if c.RateLimit < 0 || c.RateBurst < 1 {
+		errs = append(errs, errors.New("rate limit must not be negative and burst must be at least 1"))
+	}
+	switch c.RateLimitBackend {
+	case "memory":
+	case "redis":
+		if c.RedisURL == "" {
+			errs = append(errs, errors.New("redis url is required for the redis rate limiter"))
+		}
+		if c.RateLimit > 0 && c.RateLimit*c.RateWindow.Seconds() < 1 {
+			errs = append(errs, errors.New("rate window must allow at least one request"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown rate limit backend %q", c.RateLimitBackend))
+	}
+	switch c.DiscoveryBackend {
+	case "none":
+	case "consul", "etcd":
+		if c.DiscoveryURL == "" {
+			errs = append(errs, fmt.Errorf("discovery url is required for the %s backend", c.DiscoveryBackend))
+		}
+		if c.DiscoveryTTL < 3*time.Second || c.DiscoveryRefresh <= 0 {
+			errs = append(errs, errors.New("discovery ttl must be at least 3s and refresh positive"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("discovery backend: unknown backend %q", c.DiscoveryBackend))
+	}
+
+	return errors.Join(errs...)
+}
+
+// setServices parses "name=prefix=upstream[=swagger path]" entries
+func setServices(c *Config, val string) error {
+	var services []Service
+	for _, entry := range envconfig.SplitList(val) {
+		parts := strings.Split(entry, "=")
+		if len(parts) < 3 || len(parts) > 4 {
+			return fmt.Errorf("%q is not name=prefix=upstream[=swagger path]", entry)
+		}
+
+		s := Service{Name: parts[0], Prefix: parts[1], Upstream: parts[2]}
+		if len(parts) == 4 {
+			s.SwaggerPath = parts[3]
+		}
+		services = append(services, s)
+	}
+	c.Services = services
+	return nil
+}
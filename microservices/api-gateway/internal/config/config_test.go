@@ -0,0 +1,33 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateJWTSecret(t *testing.T) {
+	cases := []struct {
+		name     string
+		secret   string
+		disabled bool
+		wantErr  bool
+	}{
+		{"secret", "s3cret", false, false},
+		{"no secret", "", false, true},
+		{"no secret, auth disabled", "", true, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := defaults()
+			cfg.Services = []Service{{Name: "employee", Prefix: "/employees-service", Upstream: "http://localhost:8081"}}
+			cfg.JWTSecret = tc.secret
+			cfg.AuthDisabled = tc.disabled
+
+			err := cfg.Validate()
+			if got := err != nil && strings.Contains(err.Error(), "jwt secret"); got != tc.wantErr {
+				t.Fatalf("Validate() = %v, want jwt secret error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
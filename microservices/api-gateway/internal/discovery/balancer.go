@@ -0,0 +1,82 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrNoInstances is returned when a service has no healthy instance
+var ErrNoInstances = errors.New("no healthy instances")
+
+// Target picks the upstream a request is forwarded to
+type Target interface {
+	Next(ctx context.Context) (*url.URL, error)
+}
+
+// Static is a Target with a fixed url
+type Static struct {
+	URL *url.URL
+}
+
+func (s Static) Next(context.Context) (*url.URL, error) {
+	return s.URL, nil
+}
+
+// Balancer round-robins over the instances of a service, refreshing them
+// from the resolver at most once per refresh interval. When the registry
+// is unreachable the last known instances keep being used
+type Balancer struct {
+	resolver Resolver
+	name     string
+	refresh  time.Duration
+
+	mu        sync.Mutex
+	instances []*url.URL
+	fetched   time.Time
+
+	next atomic.Uint64
+}
+
+// NewBalancer creates a balancer for the service registered as name
+func NewBalancer(resolver Resolver, name string, refresh time.Duration) *Balancer {
+	return &Balancer{resolver: resolver, name: name, refresh: refresh}
+}
+
+func (b *Balancer) Next(ctx context.Context) (*url.URL, error) {
+	instances := b.current(ctx)
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("%s: %w", b.name, ErrNoInstances)
+	}
+	return instances[b.next.Add(1)%uint64(len(instances))], nil
+}
+
+// current returns the cached instances, resolving them again when stale
+func (b *Balancer) current(ctx context.Context) []*url.URL {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if time.Since(b.fetched) < b.refresh {
+		return b.instances
+	}
+	// Also throttles lookups while the registry is failing
+	b.fetched = time.Now()
+
+	found, err := b.resolver.Resolve(ctx, b.name)
+	if err != nil {
+		log.Printf("failed to resolve %s, using %d known instances: %v", b.name, len(b.instances), err)
+		return b.instances
+	}
+
+	instances := make([]*url.URL, 0, len(found))
+	for _, inst := range found {
+		instances = append(instances, inst.URL())
+	}
+	b.instances = instances
+	return instances
+}
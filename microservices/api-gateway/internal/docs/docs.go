@@ -0,0 +1,133 @@
+// Package docs aggregates the Swagger specs of the routed services into a
+// single Swagger UI served by the gateway
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"api-gateway/internal/api"
+	"api-gateway/internal/config"
+	"api-gateway/internal/discovery"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+// Docs serves the aggregated Swagger UI
+type Docs struct {
+	services map[string]config.Service
+	targets  map[string]discovery.Target
+	names    []string
+	client   *http.Client
+}
+
+// New creates the aggregator for the services that publish a spec, fetched
+// from the upstreams in targets keyed by service name
+func New(services []config.Service, targets map[string]discovery.Target) *Docs {
+	d := &Docs{
+		services: map[string]config.Service{},
+		targets:  targets,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+	for _, s := range services {
+		if s.SwaggerPath == "" {
+			continue
+		}
+		d.services[s.Name] = s
+		d.names = append(d.names, s.Name)
+	}
+	return d
+}
+
+// Register mounts the UI under /swagger and the specs under
+// /swagger/specs/:name
+func (d *Docs) Register(router *gin.Engine) {
+	router.GET("/swagger/*any", func(c *gin.Context) {
+		switch any := c.Param("any"); {
+		case any == "/" || any == "/index.html":
+			d.index(c)
+		case strings.HasPrefix(any, "/specs/"):
+			d.spec(c, strings.TrimPrefix(any, "/specs/"))
+		default:
+			// Static Swagger UI assets
+			swaggerFiles.Handler.Prefix = "/swagger/"
+			swaggerFiles.Handler.ServeHTTP(c.Writer, c.Request)
+		}
+	})
+}
+
+// spec fetches the spec of a service and points it at the gateway by
+// dropping its host, so "Try it out" goes through the gateway
+func (d *Docs) spec(c *gin.Context, name string) {
+	s, ok := d.services[name]
+	if !ok {
+		api.Error(c, http.StatusNotFound, "Unknown service")
+		return
+	}
+
+	upstream, err := d.targets[name].Next(c.Request.Context())
+	if err != nil {
+		api.Error(c, http.StatusServiceUnavailable, "Service "+name+" has no available instances")
+		return
+	}
+
+	resp, err := d.client.Get(strings.TrimSuffix(upstream.String(), "/") + s.SwaggerPath)
+	if err != nil {
+		api.Error(c, http.StatusBadGateway, "Service "+name+" unavailable")
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		api.Error(c, http.StatusBadGateway, fmt.Sprintf("Service %s answered %d", name, resp.StatusCode))
+		return
+	}
+
+	var spec map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+		api.Error(c, http.StatusBadGateway, "Service "+name+" returned an invalid spec")
+		return
+	}
+
+	delete(spec, "host")
+	delete(spec, "schemes")
+
+	c.JSON(http.StatusOK, spec)
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <title>API Gateway</title>
+  <link rel="stylesheet" type="text/css" href="./swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="./swagger-ui-bundle.js"></script>
+  <script src="./swagger-ui-standalone-preset.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({
+      urls: [{{range .}}{url: "./specs/{{.}}", name: "{{.}}"},{{end}}],
+      dom_id: "#swagger-ui",
+      deepLinking: true,
+      presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+      layout: "StandaloneLayout"
+    });
+  </script>
+</body>
+</html>
+`))
+
+// index renders the UI with one entry per service
+func (d *Docs) index(c *gin.Context) {
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	if err := indexTemplate.Execute(c.Writer, d.names); err != nil {
+		api.Error(c, http.StatusInternalServerError, "Failed to render docs")
+	}
+}
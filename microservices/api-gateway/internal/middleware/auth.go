@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"api-gateway/internal/api"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Identity headers forwarded to the services. Values sent by clients are
+// always dropped so they cannot be spoofed
+const (
+	UserHeader  = "X-User-ID"
+	RolesHeader = "X-User-Roles"
+)
+
+// UserKey is the context key holding the authenticated subject
+const UserKey = "user"
+
+// claims are the token claims the gateway understands
+type claims struct {
+	Roles []string `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+// Auth verifies HS256 bearer tokens and forwards the subject and roles to
+// the services. Paths matching publicPaths skip the check; a "*" segment
+// in a pattern matches any single segment
+func Auth(secret, issuer string, publicPaths []string) gin.HandlerFunc {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	parser := jwt.NewParser(opts...)
+
+	keyFunc := func(*jwt.Token) (any, error) { return []byte(secret), nil }
+
+	return func(c *gin.Context) {
+		c.Request.Header.Del(UserHeader)
+		c.Request.Header.Del(RolesHeader)
+
+		if isPublic(c.Request.URL.Path, publicPaths) {
+			c.Next()
+			return
+		}
+
+		raw, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || raw == "" {
+			c.Header("WWW-Authenticate", `Bearer realm="api"`)
+			api.Error(c, http.StatusUnauthorized, "Missing bearer token")
+			return
+		}
+
+		var tokenClaims claims
+		if _, err := parser.ParseWithClaims(raw, &tokenClaims, keyFunc); err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="api", error="invalid_token"`)
+			api.Error(c, http.StatusUnauthorized, "Invalid or expired token")
+			return
+		}
+
+		c.Set(UserKey, tokenClaims.Subject)
+		c.Request.Header.Set(UserHeader, tokenClaims.Subject)
+		if len(tokenClaims.Roles) > 0 {
+			c.Request.Header.Set(RolesHeader, strings.Join(tokenClaims.Roles, ","))
+		}
+
+		c.Next()
+	}
+}
+
+// isPublic reports whether path starts with one of the patterns
+func isPublic(path string, patterns []string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for _, pattern := range patterns {
+		parts := strings.Split(strings.Trim(pattern, "/"), "/")
+		if len(parts) > len(segments) {
+			continue
+		}
+
+		match := true
+		for i, part := range parts {
+			if part != "*" && part != segments[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+
+	return false
+}
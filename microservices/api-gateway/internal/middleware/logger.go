@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"log"
+	"time"
+
+	"api-gateway/internal/proxy"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Logger logs one line per request with the routed service, the user
+// and the request id
+func Logger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		log.Printf("%s %s %d %s service=%s user=%s ip=%s request_id=%s",
+			c.Request.Method,
+			c.Request.URL.Path,
+			c.Writer.Status(),
+			time.Since(start).Round(time.Millisecond),
+			c.GetString(proxy.ServiceKey),
+			c.GetString(UserKey),
+			c.ClientIP(),
+			c.GetString(RequestIDHeader),
+		)
+	}
+}
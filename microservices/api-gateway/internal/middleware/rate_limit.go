@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"api-gateway/internal/api"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// idleClientTTL is how long a client's bucket is kept after its last request
+const idleClientTTL = 10 * time.Minute
+
+// limiterErrorLogInterval limits how often limiter failures are logged
+const limiterErrorLogInterval = time.Minute
+
+// Limiter decides whether a client may make another request
+type Limiter interface {
+	// Allow reports whether the client identified by key may make a
+	// request now and, when not, how long until it may
+	Allow(ctx context.Context, key string) (bool, time.Duration, error)
+}
+
+// RateLimit rejects clients over the limiter's limit with 429 and
+// Retry-After. Clients are keyed by the subject Auth authenticated, so
+// users sharing an ip get a budget each, or by ip when there is none;
+// register it after Auth. limit is only advertised in X-RateLimit-Limit.
+// If the limiter fails the request is let through
+func RateLimit(limiter Limiter, limit float64) gin.HandlerFunc {
+	var lastErrorLog atomic.Int64
+
+	return func(c *gin.Context) {
+		c.Header("X-RateLimit-Limit", strconv.FormatFloat(limit, 'f', -1, 64))
+
+		allowed, retryAfter, err := limiter.Allow(c.Request.Context(), clientKey(c))
+		if err != nil {
+			// Fail open, a limiter outage must not take the gateway down
+			now := time.Now().UnixNano()
+			if last := lastErrorLog.Load(); now-last > int64(limiterErrorLogInterval) && lastErrorLog.CompareAndSwap(last, now) {
+				log.Printf("rate limiter failed, letting requests through: %v", err)
+			}
+			c.Next()
+			return
+		}
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(max(1, int(math.Ceil(retryAfter.Seconds())))))
+			api.Error(c, http.StatusTooManyRequests, "Rate limit exceeded")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// clientKey identifies the client of the request for the limiter
+func clientKey(c *gin.Context) string {
+	if user := c.GetString(UserKey); user != "" {
+		return "user:" + user
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// client is the token bucket of one client
+type client struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// LocalLimiter keeps a token bucket per client in memory, so every
+// gateway instance limits on its own
+type LocalLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu        sync.Mutex
+	clients   map[string]*client
+	lastSweep time.Time
+}
+
+// NewLocalLimiter allows each client limit requests per second with
+// bursts of burst
+func NewLocalLimiter(limit float64, burst int) *LocalLimiter {
+	return &LocalLimiter{
+		limit:     rate.Limit(limit),
+		burst:     burst,
+		clients:   map[string]*client{},
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow takes a token from the client's bucket
+func (l *LocalLimiter) Allow(_ context.Context, key string) (bool, time.Duration, error) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Forget idle clients now and then so the map does not grow forever
+	if now.Sub(l.lastSweep) > idleClientTTL {
+		for k, cl := range l.clients {
+			if now.Sub(cl.lastSeen) > idleClientTTL {
+				delete(l.clients, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	cl, ok := l.clients[key]
+	if !ok {
+		cl = &client{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.clients[key] = cl
+	}
+	cl.lastSeen = now
+
+	reservation := cl.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay, nil
+	}
+	return true, 0, nil
+}
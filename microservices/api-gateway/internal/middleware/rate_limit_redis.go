@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisLimiterTimeout bounds the Redis round trip added to each request
+const redisLimiterTimeout = 250 * time.Millisecond
+
+// slidingWindowScript counts a request in the current fixed window unless
+// the sliding window estimate is already at the limit: the previous
+// window's count weighted by how much of it still overlaps plus the
+// current count. Returns allowed (0 or 1), current and previous counts
+//
+// KEYS[1] current window, KEYS[2] previous window
+// ARGV[1] limit, ARGV[2] window ms, ARGV[3] ms elapsed in the current window
+var slidingWindowScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+local previous = tonumber(redis.call('GET', KEYS[2]) or '0')
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local elapsed = tonumber(ARGV[3])
+if previous * (window - elapsed) / window + current >= limit then
+	return {0, current, previous}
+end
+current = redis.call('INCR', KEYS[1])
+if current == 1 then
+	redis.call('PEXPIRE', KEYS[1], window * 2)
+end
+return {1, current, previous}
+`)
+
+// RedisLimiter counts requests in Redis with sliding window counters, so
+// the limit holds across every gateway instance sharing the server
+type RedisLimiter struct {
+	client *redis.Client
+	prefix string
+	limit  int64
+	window time.Duration
+}
+
+// NewRedisLimiter allows each client limit requests per sliding window,
+// keeping its counters under keys starting with prefix
+func NewRedisLimiter(client *redis.Client, prefix string, limit int, window time.Duration) *RedisLimiter {
+	return &RedisLimiter{client: client, prefix: prefix, limit: int64(limit), window: window}
+}
+
+// Allow counts the request against the client's window
+func (l *RedisLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, redisLimiterTimeout)
+	defer cancel()
+
+	window := l.window.Milliseconds()
+	now := time.Now().UnixMilli()
+	n, elapsed := now/window, now%window
+
+	// The hash tag keeps both windows in one slot on Redis Cluster
+	base := l.prefix + "{" + key + "}:"
+	keys := []string{base + strconv.FormatInt(n, 10), base + strconv.FormatInt(n-1, 10)}
+
+	res, err := slidingWindowScript.Run(ctx, l.client, keys, l.limit, window, elapsed).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("redis rate limiter: %w", err)
+	}
+	if res[0] == 1 {
+		return true, 0, nil
+	}
+	return false, l.retryAfter(res[1], res[2], elapsed), nil
+}
+
+// retryAfter estimates when the sliding count drops below the limit again
+func (l *RedisLimiter) retryAfter(current, previous, elapsed int64) time.Duration {
+	window := float64(l.window.Milliseconds())
+	limit := float64(l.limit)
+	remaining := window - float64(elapsed)
+
+	// This window becomes the previous one and has to decay in turn
+	wait := remaining + window*(1-limit/float64(max(current, 1)))
+	if current < l.limit && previous > 0 {
+		// The previous window's weight decays within this window
+		wait = remaining - (limit-float64(current))*window/float64(previous)
+	}
+	return time.Duration(max(wait, 0)) * time.Millisecond
+}
@@ -0,0 +1,92 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"api-gateway/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const secret = "test-secret"
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newRouter chains Auth and RateLimit like cmd/main.go does, with a
+// budget of one request per client
+func newRouter() *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.Auth(secret, "", []string{"/public"}))
+	r.Use(middleware.RateLimit(middleware.NewLocalLimiter(0.001, 1), 0.001))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/private", ok)
+	r.GET("/public", ok)
+	return r
+}
+
+// token signs a token of subject
+func token(t *testing.T, subject string) string {
+	t.Helper()
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   subject,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// get sends a request from one ip, with a bearer token when token is set
+func get(r *gin.Engine, path, token string) int {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = "203.0.113.7:40000"
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestRateLimitKeysBySubject(t *testing.T) {
+	r := newRouter()
+	alice, bob := token(t, "alice"), token(t, "bob")
+
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"first request of alice", alice, http.StatusOK},
+		{"first request of bob from the same ip", bob, http.StatusOK},
+		{"second request of alice", alice, http.StatusTooManyRequests},
+		{"second request of bob", bob, http.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		if got := get(r, "/private", tt.token); got != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimitKeysAnonymousByIP(t *testing.T) {
+	r := newRouter()
+
+	if got := get(r, "/public", ""); got != http.StatusOK {
+		t.Fatalf("first anonymous request: status = %d, want %d", got, http.StatusOK)
+	}
+	if got := get(r, "/public", ""); got != http.StatusTooManyRequests {
+		t.Errorf("second anonymous request: status = %d, want %d", got, http.StatusTooManyRequests)
+	}
+	// The budget of the ip is not the one of the users behind it
+	if got := get(r, "/private", token(t, "alice")); got != http.StatusOK {
+		t.Errorf("request of alice: status = %d, want %d", got, http.StatusOK)
+	}
+}
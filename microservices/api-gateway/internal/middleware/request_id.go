@@ -0,0 +1,26 @@
+// Package middleware contains the gateway's cross-cutting handlers: auth,
+// rate limiting and request logging
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the request id to the services and back
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID makes sure every request has an id, keeping the client's one
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > 128 {
+			id = uuid.NewString()
+		}
+
+		c.Request.Header.Set(RequestIDHeader, id)
+		c.Header(RequestIDHeader, id)
+		c.Set(RequestIDHeader, id)
+		c.Next()
+	}
+}
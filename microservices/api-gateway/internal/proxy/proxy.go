@@ -0,0 +1,93 @@
+// Package proxy forwards gateway requests to the upstream services
+package proxy
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+
+	"api-gateway/internal/api"
+	"api-gateway/internal/config"
+	"api-gateway/internal/discovery"
+
+	"github.com/gin-gonic/gin"
+)
+
+// targetKey carries the upstream picked for a request to Rewrite
+type targetKey struct{}
+
+// New returns a handler forwarding requests to an upstream picked by target
+// with the path unchanged. Responses are flushed as they arrive so SSE
+// streams work, and WebSocket upgrades are passed through
+func New(s config.Service, target discovery.Target, timeout time.Duration) gin.HandlerFunc {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConnsPerHost:   100,
+		IdleConnTimeout:       90 * time.Second,
+		ResponseHeaderTimeout: timeout,
+	}
+
+	rp := &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(r.In.Context().Value(targetKey{}).(*url.URL))
+			r.SetXForwarded()
+			r.Out.Header.Set("X-Forwarded-Prefix", s.Prefix)
+		},
+		Transport:     transport,
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			if errors.Is(err, context.Canceled) {
+				// Client went away, nothing to answer
+				return
+			}
+			log.Printf("proxy to %s failed: %v", s.Name, err)
+
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				api.Write(w, r, http.StatusGatewayTimeout, "Service "+s.Name+" timed out")
+				return
+			}
+			api.Write(w, r, http.StatusBadGateway, "Service "+s.Name+" unavailable")
+		},
+	}
+
+	return func(c *gin.Context) {
+		c.Set(ServiceKey, s.Name)
+
+		upstream, err := target.Next(c.Request.Context())
+		if err != nil {
+			log.Printf("no upstream for %s: %v", s.Name, err)
+			api.Error(c, http.StatusServiceUnavailable, "Service "+s.Name+" has no available instances")
+			return
+		}
+
+		ctx := context.WithValue(c.Request.Context(), targetKey{}, upstream)
+		rp.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+	}
+}
+
+// NewTarget returns the target for the service upstream, resolving
+// discovery:// upstreams through registry
+func NewTarget(s config.Service, registry discovery.Resolver, refresh time.Duration) (discovery.Target, error) {
+	if name := s.DiscoveryName(); name != "" {
+		return discovery.NewBalancer(registry, name, refresh), nil
+	}
+
+	u, err := url.Parse(s.Upstream)
+	if err != nil {
+		return nil, err
+	}
+	return discovery.Static{URL: u}, nil
+}
+
+// ServiceKey is the context key holding the name of the routed service
+const ServiceKey = "service"
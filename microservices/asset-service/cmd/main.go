@@ -0,0 +1,119 @@
+package main
+
+//	@title			Asset Service API
+//	@version		1.0
+//	@description	Equipment lent to employees, checkouts and returns, and reclaim checklists of terminated employees
+//	@termsOfService	http://swagger.io/terms/
+
+//	@contact.name	API Support
+//	@contact.email	josed.amayar@uqvirtual.edu.co
+
+//	@host		localhost:8086
+//	@BasePath	/asset-service/api/v1
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"asset-service/internal/config"
+	"asset-service/internal/db"
+	"asset-service/internal/events"
+	"asset-service/internal/handlers"
+	"asset-service/internal/repository"
+	"asset-service/internal/service"
+
+	_ "asset-service/docs" // Swagger docs
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/employeesync"
+	syncstore "github.com/Josedzzz/microservices-fp/pkg/employeesync/postgres"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+func main() {
+	cfg := config.Load()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbPool := db.NewPostgresPool(cfg)
+	defer dbPool.Close()
+
+	if cfg.MigrateOnStartup {
+		if err := db.Migrate(ctx, dbPool); err != nil {
+			log.Fatalf("database migration failed: %v", err)
+		}
+	}
+
+	// Checkouts are only allowed to employees of employee-management,
+	// read from a local copy kept up to date by the events
+	employeeClient := employees.NewClient(cfg.EmployeeServiceURL, cfg.EmployeeServiceTimeout)
+	directory := syncstore.NewStore(dbPool, "assets")
+	syncer := employeesync.New(directory, employeesync.NewHTTPSource(cfg.EmployeeServiceURL, cfg.EmployeeServiceTimeout))
+	go func() {
+		if err := syncer.Bootstrap(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("employee snapshot failed, the copy fills from events: %v", err)
+		}
+	}()
+
+	repo := repository.NewAssetRepository(dbPool)
+	assetService := service.NewAssetService(repo, employeeClient, directory, syncer)
+
+	// Terminations generate the reclaim checklists
+	consumer, err := events.NewConsumer(cfg)
+	if err != nil {
+		log.Fatalf("failed to create event consumer: %v", err)
+	}
+	defer consumer.Close()
+
+	go func() {
+		if err := consumer.Consume(ctx, assetService.HandleEvent); err != nil {
+			log.Fatalf("event consumer stopped: %v", err)
+		}
+	}()
+
+	handler := handlers.NewAssetHandler(assetService)
+	healthHandler := httpkit.NewHealthHandler("asset-service", dbPool)
+
+	router := httpkit.NewRouter()
+
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	v1 := router.Group("/asset-service/api/v1")
+	{
+		v1.GET("/health", healthHandler.HealthCheck)
+
+		v1.POST("/assets", handler.CreateAsset)
+		v1.GET("/assets", handler.GetAllAssets)
+		v1.GET("/assets/:id", handler.GetAssetByID)
+		v1.POST("/assets/:id/retire", handler.RetireAsset)
+		v1.POST("/assets/:id/checkout", handler.Checkout)
+		v1.POST("/assets/:id/return", handler.Return)
+		v1.GET("/assets/:id/checkouts", handler.GetCheckouts)
+
+		v1.GET("/employees/:id/assets", handler.GetEmployeeAssets)
+		v1.POST("/employees/:id/reclaim", handler.Reclaim)
+
+		v1.GET("/checklists", handler.GetAllChecklists)
+		v1.GET("/checklists/:id", handler.GetChecklistByID)
+		v1.POST("/checklists/:id/items/:assetId/lost", handler.MarkLost)
+		v1.POST("/checklists/:id/cancel", handler.CancelChecklist)
+	}
+
+	srv := &http.Server{Addr: ":" + cfg.ServerPort, Handler: router}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	log.Printf("Asset service running on :%s, consuming from %s", cfg.ServerPort, cfg.EventBroker)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
@@ -0,0 +1,1062 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "email": "josed.amayar@uqvirtual.edu.co"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/assets": {
+            "get": {
+                "description": "Retrieves assets by tag, optionally filtered by kind, status and holder",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assets"
+                ],
+                "summary": "List assets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "LAPTOP",
+                            "BADGE",
+                            "PHONE",
+                            "OTHER"
+                        ],
+                        "type": "string",
+                        "description": "Asset kind",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "AVAILABLE",
+                            "CHECKED_OUT",
+                            "LOST",
+                            "RETIRED"
+                        ],
+                        "type": "string",
+                        "description": "Asset status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Holder employee id",
+                        "name": "employeeId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Assets",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httpkit.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Asset"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a piece of equipment to the inventory as AVAILABLE",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assets"
+                ],
+                "summary": "Add an asset",
+                "parameters": [
+                    {
+                        "description": "Asset data",
+                        "name": "asset",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AssetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Asset created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Asset"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Asset tag already exists",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/assets/{id}": {
+            "get": {
+                "description": "Retrieves an asset by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assets"
+                ],
+                "summary": "Get an asset",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Asset",
+                        "schema": {
+                            "$ref": "#/definitions/models.Asset"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid asset ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/assets/{id}/checkout": {
+            "post": {
+                "description": "Lends an AVAILABLE asset to an employee of employee-management who is not retired",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkouts"
+                ],
+                "summary": "Check out an asset",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Employee and optional due date",
+                        "name": "checkout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CheckoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Asset checked out",
+                        "schema": {
+                            "$ref": "#/definitions/models.Checkout"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid asset ID, JSON format or due date",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset or employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Asset is not available or employee is retired",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Employee service unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/assets/{id}/checkouts": {
+            "get": {
+                "description": "Retrieves the checkouts of an asset, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkouts"
+                ],
+                "summary": "Checkout history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checkouts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Checkout"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid asset ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/assets/{id}/retire": {
+            "post": {
+                "description": "Decommissions an AVAILABLE asset, it can no longer be checked out",
+                "tags": [
+                    "Assets"
+                ],
+                "summary": "Retire an asset",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Asset retired"
+                    },
+                    "400": {
+                        "description": "Invalid asset ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Asset is not available",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/assets/{id}/return": {
+            "post": {
+                "description": "Takes a checked out asset back and ticks it off any open reclaim checklist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkouts"
+                ],
+                "summary": "Return an asset",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional condition note",
+                        "name": "return",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Asset returned",
+                        "schema": {
+                            "$ref": "#/definitions/models.Checkout"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid asset ID or JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Asset is not checked out",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/checklists": {
+            "get": {
+                "description": "Retrieves the checklists generated for terminated employees, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklists"
+                ],
+                "summary": "List reclaim checklists",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "OPEN",
+                            "COMPLETED",
+                            "CANCELLED"
+                        ],
+                        "type": "string",
+                        "description": "Checklist status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Employee id",
+                        "name": "employeeId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checklists",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httpkit.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ReclaimChecklist"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/checklists/{id}": {
+            "get": {
+                "description": "Retrieves a checklist with the assets to reclaim and their outcome",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklists"
+                ],
+                "summary": "Get a reclaim checklist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Checklist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checklist",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReclaimChecklist"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid checklist ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Checklist not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/checklists/{id}/cancel": {
+            "post": {
+                "description": "Closes a checklist that is no longer needed, e.g. when a termination is rolled back. Pending items stay pending",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklists"
+                ],
+                "summary": "Cancel a reclaim checklist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Checklist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cancelled checklist",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReclaimChecklist"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid checklist ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Checklist not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/checklists/{id}/items/{assetId}/lost": {
+            "post": {
+                "description": "Resolves a pending checklist item the employee cannot return: the checkout is closed and the asset set LOST. Returned assets are ticked off by the return endpoint",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklists"
+                ],
+                "summary": "Mark an asset lost",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Checklist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "assetId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated checklist",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReclaimChecklist"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid checklist or asset ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset is not pending on the checklist",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/assets": {
+            "get": {
+                "description": "Retrieves the assets an employee currently holds",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assets"
+                ],
+                "summary": "Assets of an employee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Assets",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Asset"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid employee ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/reclaim": {
+            "post": {
+                "description": "Lists the assets the employee holds to get them back, e.g. when offboarding. An open checklist of the employee is returned instead of a new one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checklists"
+                ],
+                "summary": "Open a reclaim checklist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request reference",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReclaimRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checklist",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReclaimChecklist"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid employee ID or missing reference",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.AssetRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "ThinkPad T14"
+                },
+                "kind": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AssetKind"
+                        }
+                    ],
+                    "example": "LAPTOP"
+                },
+                "serialNumber": {
+                    "type": "string",
+                    "example": "PF3XK2L9"
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "LT-0042"
+                }
+            }
+        },
+        "handlers.CheckoutRequest": {
+            "type": "object",
+            "properties": {
+                "dueBackOn": {
+                    "type": "string",
+                    "example": "2026-12-31"
+                },
+                "employeeId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handlers.ReclaimRequest": {
+            "type": "object",
+            "properties": {
+                "reference": {
+                    "description": "Reference identifies the request, retries with the same reference\nreturn the same checklist",
+                    "type": "string",
+                    "example": "saga:6f1c2d4e-8a0b-4c3d-9e2f-1a2b3c4d5e6f"
+                }
+            }
+        },
+        "handlers.ReturnRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Screen scratched"
+                }
+            }
+        },
+        "httpkit.ErrorResponse": {
+            "description": "Standard error response structure",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpkit.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "pagination": {
+                    "$ref": "#/definitions/httpkit.PaginationMeta"
+                }
+            }
+        },
+        "httpkit.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                },
+                "total_records": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Asset": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "employeeId": {
+                    "description": "EmployeeID is the holder while the asset is CHECKED_OUT",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/models.AssetKind"
+                },
+                "serialNumber": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.AssetStatus"
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "LT-0042"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AssetKind": {
+            "type": "string",
+            "enum": [
+                "LAPTOP",
+                "BADGE",
+                "PHONE",
+                "OTHER"
+            ],
+            "x-enum-varnames": [
+                "KindLaptop",
+                "KindBadge",
+                "KindPhone",
+                "KindOther"
+            ]
+        },
+        "models.AssetStatus": {
+            "type": "string",
+            "enum": [
+                "AVAILABLE",
+                "CHECKED_OUT",
+                "LOST",
+                "RETIRED"
+            ],
+            "x-enum-varnames": [
+                "StatusAvailable",
+                "StatusCheckedOut",
+                "StatusLost",
+                "StatusRetired"
+            ]
+        },
+        "models.ChecklistStatus": {
+            "type": "string",
+            "enum": [
+                "OPEN",
+                "COMPLETED",
+                "CANCELLED"
+            ],
+            "x-enum-varnames": [
+                "ChecklistOpen",
+                "ChecklistCompleted",
+                "ChecklistCancelled"
+            ]
+        },
+        "models.Checkout": {
+            "type": "object",
+            "properties": {
+                "assetId": {
+                    "type": "integer"
+                },
+                "checkedOutAt": {
+                    "type": "string"
+                },
+                "dueBackOn": {
+                    "type": "string",
+                    "example": "2026-12-31"
+                },
+                "employeeId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "returnNote": {
+                    "type": "string"
+                },
+                "returnedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ItemStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "RETURNED",
+                "LOST"
+            ],
+            "x-enum-varnames": [
+                "ItemPending",
+                "ItemReturned",
+                "ItemLost"
+            ]
+        },
+        "models.ReclaimChecklist": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "employeeId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReclaimItem"
+                    }
+                },
+                "reason": {
+                    "description": "Reason is the employee event that generated the checklist",
+                    "type": "string",
+                    "example": "RETIRED"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.ChecklistStatus"
+                }
+            }
+        },
+        "models.ReclaimItem": {
+            "type": "object",
+            "properties": {
+                "assetId": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/models.AssetKind"
+                },
+                "resolvedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.ItemStatus"
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
+        }
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:8086",
+	BasePath:         "/asset-service/api/v1",
+	Schemes:          []string{},
+	Title:            "Asset Service API",
+	Description:      "Equipment lent to employees, checkouts and returns, and reclaim checklists of terminated employees",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
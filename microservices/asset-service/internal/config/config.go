@@ -0,0 +1,197 @@
+// Package config loads the asset service configuration from
+// defaults, a YAML file, env variables and CLI flags, in that order
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"github.com/joho/godotenv"
+)
+
+// Config holds configuration loaded from defaults, file, env and flags
+type Config struct {
+	ServerPort string `yaml:"server_port"`
+
+	DBHost     string `yaml:"db_host"`
+	DBPort     string `yaml:"db_port"`
+	DBName     string `yaml:"db_name"`
+	DBUser     string `yaml:"db_user"`
+	DBPassword string `yaml:"db_password"`
+	DBSSLMode  string `yaml:"db_sslmode"`
+	DBMaxConns int    `yaml:"db_max_conns"`
+
+	DBRetryMaxWait time.Duration `yaml:"db_retry_max_wait"`
+
+	MigrateOnStartup bool `yaml:"migrate_on_startup"`
+
+	// EmployeeServiceURL is the versioned API base of employee-management
+	EmployeeServiceURL     string        `yaml:"employee_service_url"`
+	EmployeeServiceTimeout time.Duration `yaml:"employee_service_timeout"`
+
+	// Broker the employee events are consumed from: nats, kafka or rabbitmq
+	EventBroker string `yaml:"event_broker"`
+
+	NATSURL           string `yaml:"nats_url"`
+	NATSStream        string `yaml:"nats_stream"`
+	NATSSubjectPrefix string `yaml:"nats_subject_prefix"`
+	NATSConsumer      string `yaml:"nats_consumer"`
+
+	KafkaBrokers     string `yaml:"kafka_brokers"`
+	KafkaTopicPrefix string `yaml:"kafka_topic_prefix"`
+	KafkaGroupID     string `yaml:"kafka_group_id"`
+
+	RabbitMQURL              string `yaml:"rabbitmq_url"`
+	RabbitMQExchange         string `yaml:"rabbitmq_exchange"`
+	RabbitMQRoutingKeyPrefix string `yaml:"rabbitmq_routing_key_prefix"`
+	RabbitMQQueue            string `yaml:"rabbitmq_queue"`
+}
+
+// options lists every setting that can be overridden by env or flags
+var options = []envconfig.Option[Config]{
+	{Env: "SERVER_PORT", Flag: "port", Usage: "HTTP server port", Set: envconfig.String(func(c *Config) *string { return &c.ServerPort })},
+	{Env: "DB_HOST", Flag: "db-host", Usage: "database host", Set: envconfig.String(func(c *Config) *string { return &c.DBHost })},
+	{Env: "DB_PORT", Flag: "db-port", Usage: "database port", Set: envconfig.String(func(c *Config) *string { return &c.DBPort })},
+	{Env: "DB_NAME", Flag: "db-name", Usage: "database name", Set: envconfig.String(func(c *Config) *string { return &c.DBName })},
+	{Env: "DB_USER", Flag: "db-user", Usage: "database user", Set: envconfig.String(func(c *Config) *string { return &c.DBUser })},
+	{Env: "DB_PASSWORD", Flag: "db-password", Usage: "database password", Set: envconfig.String(func(c *Config) *string { return &c.DBPassword })},
+	{Env: "DB_SSL_MODE", Flag: "db-sslmode", Usage: "database sslmode", Set: envconfig.String(func(c *Config) *string { return &c.DBSSLMode })},
+	{Env: "DB_MAX_CONNS", Flag: "db-max-conns", Usage: "maximum open db connections", Set: envconfig.Int(func(c *Config) *int { return &c.DBMaxConns })},
+	{Env: "DB_RETRY_MAX_WAIT", Flag: "db-retry-max-wait", Usage: "how long to wait for the db at startup", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DBRetryMaxWait })},
+	{Env: "MIGRATE_ON_STARTUP", Flag: "migrate-on-startup", Usage: "apply pending migrations at startup", Set: envconfig.Bool(func(c *Config) *bool { return &c.MigrateOnStartup })},
+	{Env: "EMPLOYEE_SERVICE_URL", Flag: "employee-service-url", Usage: "employee-management API base url", Set: envconfig.String(func(c *Config) *string { return &c.EmployeeServiceURL })},
+	{Env: "EMPLOYEE_SERVICE_TIMEOUT", Flag: "employee-service-timeout", Usage: "timeout of employee-management calls", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.EmployeeServiceTimeout })},
+	{Env: "EVENT_BROKER", Flag: "event-broker", Usage: "broker employee events are consumed from: nats, kafka or rabbitmq", Set: envconfig.String(func(c *Config) *string { return &c.EventBroker })},
+	{Env: "NATS_URL", Flag: "nats-url", Usage: "nats server url", Set: envconfig.String(func(c *Config) *string { return &c.NATSURL })},
+	{Env: "NATS_STREAM", Flag: "nats-stream", Usage: "jetstream stream holding the employee events", Set: envconfig.String(func(c *Config) *string { return &c.NATSStream })},
+	{Env: "NATS_SUBJECT_PREFIX", Flag: "nats-subject-prefix", Usage: "prefix of the event subjects", Set: envconfig.String(func(c *Config) *string { return &c.NATSSubjectPrefix })},
+	{Env: "NATS_CONSUMER", Flag: "nats-consumer", Usage: "durable jetstream consumer name", Set: envconfig.String(func(c *Config) *string { return &c.NATSConsumer })},
+	{Env: "KAFKA_BROKERS", Flag: "kafka-brokers", Usage: "comma separated kafka brokers", Set: envconfig.String(func(c *Config) *string { return &c.KafkaBrokers })},
+	{Env: "KAFKA_TOPIC_PREFIX", Flag: "kafka-topic-prefix", Usage: "prefix of the event topics", Set: envconfig.String(func(c *Config) *string { return &c.KafkaTopicPrefix })},
+	{Env: "KAFKA_GROUP_ID", Flag: "kafka-group-id", Usage: "kafka consumer group", Set: envconfig.String(func(c *Config) *string { return &c.KafkaGroupID })},
+	{Env: "RABBITMQ_URL", Flag: "rabbitmq-url", Usage: "rabbitmq url", Set: envconfig.String(func(c *Config) *string { return &c.RabbitMQURL })},
+	{Env: "RABBITMQ_EXCHANGE", Flag: "rabbitmq-exchange", Usage: "topic exchange the events are published to", Set: envconfig.String(func(c *Config) *string { return &c.RabbitMQExchange })},
+	{Env: "RABBITMQ_ROUTING_KEY_PREFIX", Flag: "rabbitmq-routing-key-prefix", Usage: "prefix of the event routing keys", Set: envconfig.String(func(c *Config) *string { return &c.RabbitMQRoutingKeyPrefix })},
+	{Env: "RABBITMQ_QUEUE", Flag: "rabbitmq-queue", Usage: "durable queue bound to the exchange", Set: envconfig.String(func(c *Config) *string { return &c.RabbitMQQueue })},
+}
+
+// Load reads the .env file, then builds the config from the process args
+// Exits if the config is invalid
+func Load() *Config {
+	_ = godotenv.Load()
+
+	cfg, err := load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	return cfg
+}
+
+// load builds and validates the config from the given CLI args
+func load(args []string) (*Config, error) {
+	cfg := defaults()
+	fs := flag.NewFlagSet("asset-service", flag.ContinueOnError)
+	if err := envconfig.Load(fs, args, cfg, options); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// defaults returns the config used when nothing else is set
+func defaults() *Config {
+	return &Config{
+		ServerPort: "8086",
+
+		DBHost:     "localhost",
+		DBPort:     "5432",
+		DBName:     "assets",
+		DBUser:     "asset_user",
+		DBSSLMode:  "disable",
+		DBMaxConns: 5,
+
+		DBRetryMaxWait: time.Minute,
+
+		MigrateOnStartup: true,
+
+		EmployeeServiceURL:     "http://localhost:8081/employees-service/api/v1",
+		EmployeeServiceTimeout: 5 * time.Second,
+
+		EventBroker: "nats",
+
+		NATSURL:           "nats://localhost:4222",
+		NATSStream:        "HR_EVENTS",
+		NATSSubjectPrefix: "hr.",
+		NATSConsumer:      "asset-service",
+
+		KafkaTopicPrefix: "hr.",
+		KafkaGroupID:     "asset-service",
+
+		RabbitMQExchange: "hr.events",
+		RabbitMQQueue:    "asset-service",
+	}
+}
+
+// Validate checks the final config and returns every problem found
+func (c *Config) Validate() error {
+	var errs []error
+
+	if err := envconfig.ValidatePort(c.ServerPort); err != nil {
+		errs = append(errs, fmt.Errorf("server port: %w", err))
+	}
+	if err := envconfig.ValidatePort(c.DBPort); err != nil {
+		errs = append(errs, fmt.Errorf("db port: %w", err))
+	}
+	if c.DBMaxConns < 1 {
+		errs = append(errs, errors.New("db max conns must be at least 1"))
+	}
+	if u, err := url.Parse(c.EmployeeServiceURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("employee service url %q must be an http(s) url", c.EmployeeServiceURL))
+	}
+	if c.EmployeeServiceTimeout <= 0 {
+		errs = append(errs, errors.New("employee service timeout must be positive"))
+	}
+
+	switch c.EventBroker {
+	case "nats":
+		if c.NATSURL == "" || c.NATSStream == "" || c.NATSConsumer == "" {
+			errs = append(errs, errors.New("nats url, stream and consumer are required for the nats broker"))
+		}
+	case "kafka":
+		if len(envconfig.SplitList(c.KafkaBrokers)) == 0 || c.KafkaGroupID == "" {
+			errs = append(errs, errors.New("kafka brokers and group id are required for the kafka broker"))
+		}
+	case "rabbitmq":
+		if c.RabbitMQURL == "" || c.RabbitMQExchange == "" || c.RabbitMQQueue == "" {
+			errs = append(errs, errors.New("rabbitmq url, exchange and queue are required for the rabbitmq broker"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("event broker: unknown broker %q", c.EventBroker))
+	}
+
+	return errors.Join(errs...)
+}
+
+// DatabaseURL creates the connection url to the db
+func (c *Config) DatabaseURL() string {
+	return fmt.Sprintf(
+		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
+		c.DBUser,
+		c.DBPassword,
+		c.DBHost,
+		c.DBPort,
+		c.DBName,
+		c.DBSSLMode,
+	)
+}
@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS assets.assets (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	tag VARCHAR(50) NOT NULL UNIQUE,
+	kind VARCHAR(20) NOT NULL,
+	description VARCHAR(255) NOT NULL DEFAULT '',
+	serial_number VARCHAR(100) NOT NULL DEFAULT '',
+	status VARCHAR(20) NOT NULL DEFAULT 'AVAILABLE',
+	-- Holder while CHECKED_OUT
+	employee_id BIGINT,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS assets_employee_idx ON assets.assets (employee_id) WHERE employee_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS assets.checkouts (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	asset_id BIGINT NOT NULL REFERENCES assets.assets (id) ON DELETE CASCADE,
+	employee_id BIGINT NOT NULL,
+	checked_out_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	due_back_on DATE,
+	returned_at TIMESTAMPTZ,
+	return_note TEXT NOT NULL DEFAULT ''
+);
+
+-- An asset is held by one employee at a time
+CREATE UNIQUE INDEX IF NOT EXISTS checkouts_open_idx ON assets.checkouts (asset_id) WHERE returned_at IS NULL;
+CREATE INDEX IF NOT EXISTS checkouts_employee_idx ON assets.checkouts (employee_id);
+
+-- Generated from employee events, once per event
+CREATE TABLE IF NOT EXISTS assets.reclaim_checklists (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	employee_id BIGINT NOT NULL,
+	event_id VARCHAR(64) NOT NULL UNIQUE,
+	reason VARCHAR(20) NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS reclaim_checklists_open_idx ON assets.reclaim_checklists (employee_id) WHERE status = 'OPEN';
+
+CREATE TABLE IF NOT EXISTS assets.reclaim_items (
+	checklist_id BIGINT NOT NULL REFERENCES assets.reclaim_checklists (id) ON DELETE CASCADE,
+	asset_id BIGINT NOT NULL REFERENCES assets.assets (id) ON DELETE CASCADE,
+	status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+	resolved_at TIMESTAMPTZ,
+	PRIMARY KEY (checklist_id, asset_id)
+);
@@ -0,0 +1,12 @@
+-- Copy of the employees of employee-management kept by employeesync from
+-- their events. Deleted employees stay as tombstones with their version
+CREATE TABLE IF NOT EXISTS assets.employees (
+	id BIGINT PRIMARY KEY,
+	first_name VARCHAR(255) NOT NULL DEFAULT '',
+	last_name VARCHAR(255) NOT NULL DEFAULT '',
+	department VARCHAR(255) NOT NULL DEFAULT '',
+	status VARCHAR(20) NOT NULL DEFAULT '',
+	version BIGINT NOT NULL,
+	deleted BOOLEAN NOT NULL DEFAULT FALSE,
+	synced_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
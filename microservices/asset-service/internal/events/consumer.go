@@ -0,0 +1,29 @@
+package events
+
+import (
+	"asset-service/internal/config"
+
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"github.com/Josedzzz/microservices-fp/pkg/eventconsumer"
+)
+
+// NewConsumer creates the consumer selected by the event broker setting
+func NewConsumer(cfg *config.Config) (Consumer, error) {
+	return eventconsumer.New(eventconsumer.Config{
+		Broker: cfg.EventBroker,
+
+		NATSURL:           cfg.NATSURL,
+		NATSStream:        cfg.NATSStream,
+		NATSSubjectPrefix: cfg.NATSSubjectPrefix,
+		NATSConsumer:      cfg.NATSConsumer,
+
+		KafkaBrokers:     envconfig.SplitList(cfg.KafkaBrokers),
+		KafkaTopicPrefix: cfg.KafkaTopicPrefix,
+		KafkaGroupID:     cfg.KafkaGroupID,
+
+		RabbitMQURL:              cfg.RabbitMQURL,
+		RabbitMQExchange:         cfg.RabbitMQExchange,
+		RabbitMQRoutingKeyPrefix: cfg.RabbitMQRoutingKeyPrefix,
+		RabbitMQQueue:            cfg.RabbitMQQueue,
+	})
+}
@@ -0,0 +1,49 @@
+// Package events defines the employee events consumed by the service. The
+// envelope and the broker consumers are shared in eventconsumer
+package events
+
+import "github.com/Josedzzz/microservices-fp/pkg/eventconsumer"
+
+// Type is the name of a domain event
+type Type = eventconsumer.Type
+
+// Employee lifecycle events published by employee-management
+const (
+	EmployeeCreated       = eventconsumer.EmployeeCreated
+	EmployeeUpdated       = eventconsumer.EmployeeUpdated
+	EmployeeDeleted       = eventconsumer.EmployeeDeleted
+	EmployeeStatusChanged = eventconsumer.EmployeeStatusChanged
+)
+
+// Event is a domain event as delivered by the brokers
+type Event = eventconsumer.Event
+
+// Employee is the payload of employee.created, updated and deleted
+type Employee struct {
+	ID             int64  `json:"id"`
+	FirstName      string `json:"firstName"`
+	LastName       string `json:"lastName"`
+	Email          string `json:"email"`
+	EmployeeNumber string `json:"employeeNumber"`
+	Position       string `json:"position"`
+	Department     string `json:"department"`
+	Status         string `json:"status"`
+	HireDate       string `json:"hireDate"`
+}
+
+// StatusChange is the payload of employee.status_changed
+type StatusChange struct {
+	EmployeeID int64  `json:"employeeId"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Department string `json:"department,omitempty"`
+	FirstName  string `json:"firstName,omitempty"`
+	LastName   string `json:"lastName,omitempty"`
+	Email      string `json:"email,omitempty"`
+}
+
+// Handler processes one event. An error makes the broker redeliver it
+type Handler = eventconsumer.Handler
+
+// Consumer receives events from a broker with a durable subscription
+type Consumer = eventconsumer.Consumer
@@ -0,0 +1,384 @@
+// Package handlers exposes the asset service over HTTP
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"asset-service/internal/api"
+	"asset-service/internal/models"
+	"asset-service/internal/repository"
+	"asset-service/internal/service"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// AssetHandler handles HTTP requests for assets, checkouts and reclaim
+// checklists
+type AssetHandler struct {
+	service *service.AssetService
+}
+
+// NewAssetHandler creates a new AssetHandler instance
+func NewAssetHandler(s *service.AssetService) *AssetHandler {
+	return &AssetHandler{service: s}
+}
+
+// AssetRequest is the payload to add an asset
+type AssetRequest struct {
+	Tag          string           `json:"tag" example:"LT-0042"`
+	Kind         models.AssetKind `json:"kind" example:"LAPTOP"`
+	Description  string           `json:"description" example:"ThinkPad T14"`
+	SerialNumber string           `json:"serialNumber" example:"PF3XK2L9"`
+}
+
+// CheckoutRequest is the payload to lend an asset
+type CheckoutRequest struct {
+	EmployeeID int64   `json:"employeeId" example:"1"`
+	DueBackOn  *string `json:"dueBackOn" example:"2026-12-31"`
+}
+
+// ReturnRequest is the payload to take an asset back
+type ReturnRequest struct {
+	Note string `json:"note" example:"Screen scratched"`
+}
+
+// CreateAsset godoc
+//
+//	@Summary		Add an asset
+//	@Description	Adds a piece of equipment to the inventory as AVAILABLE
+//	@Tags			Assets
+//	@Accept			json
+//	@Produce		json
+//	@Param			asset	body		AssetRequest		true	"Asset data"
+//	@Success		201		{object}	models.Asset		"Asset created"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid JSON format or validation failed"
+//	@Failure		409		{object}	httpkit.ErrorResponse	"Asset tag already exists"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/assets [post]
+func (h *AssetHandler) CreateAsset(c *gin.Context) {
+	var req AssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	asset := models.Asset{
+		Tag:          strings.TrimSpace(req.Tag),
+		Kind:         req.Kind,
+		Description:  strings.TrimSpace(req.Description),
+		SerialNumber: strings.TrimSpace(req.SerialNumber),
+	}
+	switch {
+	case asset.Tag == "":
+		httpkit.BadRequest(c, "Tag is required")
+		return
+	case !asset.Kind.Valid():
+		httpkit.BadRequest(c, "Kind must be LAPTOP, BADGE, PHONE or OTHER")
+		return
+	}
+
+	if err := h.service.CreateAsset(c.Request.Context(), &asset); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrTagExists):
+			httpkit.Error(c, http.StatusConflict, "Asset tag already exists")
+		default:
+			httpkit.InternalServerError(c, "Failed to create asset")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, asset)
+}
+
+// GetAllAssets godoc
+//
+//	@Summary		List assets
+//	@Description	Retrieves assets by tag, optionally filtered by kind, status and holder
+//	@Tags			Assets
+//	@Produce		json
+//	@Param			page		query		int					false	"Page number"	default(1)
+//	@Param			page_size	query		int					false	"Page size"		default(20)
+//	@Param			kind		query		string				false	"Asset kind"	Enums(LAPTOP, BADGE, PHONE, OTHER)
+//	@Param			status		query		string				false	"Asset status"	Enums(AVAILABLE, CHECKED_OUT, LOST, RETIRED)
+//	@Param			employeeId	query		int					false	"Holder employee id"
+//	@Success		200			{object}	httpkit.PaginatedResponse{data=[]models.Asset}	"Assets"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid query parameters"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/assets [get]
+func (h *AssetHandler) GetAllAssets(c *gin.Context) {
+	var query api.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpkit.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	if query.Kind != "" && !models.AssetKind(query.Kind).Valid() {
+		httpkit.BadRequest(c, "Invalid kind")
+		return
+	}
+	switch models.AssetStatus(query.Status) {
+	case "", models.StatusAvailable, models.StatusCheckedOut, models.StatusLost, models.StatusRetired:
+	default:
+		httpkit.BadRequest(c, "Invalid status")
+		return
+	}
+	query.Normalize()
+
+	filter := repository.AssetFilter{
+		Kind:       models.AssetKind(query.Kind),
+		Status:     models.AssetStatus(query.Status),
+		EmployeeID: query.EmployeeID,
+	}
+
+	assets, total, err := h.service.FindAssets(c.Request.Context(), filter, query.Page, query.PageSize)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve assets")
+		return
+	}
+
+	c.JSON(http.StatusOK, httpkit.Paginate(assets, query.PageQuery, total))
+}
+
+// GetAssetByID godoc
+//
+//	@Summary		Get an asset
+//	@Description	Retrieves an asset by its ID
+//	@Tags			Assets
+//	@Produce		json
+//	@Param			id	path		int					true	"Asset ID"
+//	@Success		200	{object}	models.Asset		"Asset"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid asset ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Asset not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/assets/{id} [get]
+func (h *AssetHandler) GetAssetByID(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid asset ID")
+	if !ok {
+		return
+	}
+
+	asset, err := h.service.FindAsset(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAssetNotFound):
+			httpkit.NotFound(c, "Asset not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve asset")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, asset)
+}
+
+// RetireAsset godoc
+//
+//	@Summary		Retire an asset
+//	@Description	Decommissions an AVAILABLE asset, it can no longer be checked out
+//	@Tags			Assets
+//	@Param			id	path	int	true	"Asset ID"
+//	@Success		204	"Asset retired"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid asset ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Asset not found"
+//	@Failure		409	{object}	httpkit.ErrorResponse	"Asset is not available"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/assets/{id}/retire [post]
+func (h *AssetHandler) RetireAsset(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid asset ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RetireAsset(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAssetNotFound):
+			httpkit.NotFound(c, "Asset not found")
+		case errors.Is(err, repository.ErrAssetUnavailable):
+			httpkit.Error(c, http.StatusConflict, "Asset is not available")
+		default:
+			httpkit.InternalServerError(c, "Failed to retire asset")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Checkout godoc
+//
+//	@Summary		Check out an asset
+//	@Description	Lends an AVAILABLE asset to an employee of employee-management who is not retired
+//	@Tags			Checkouts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Asset ID"
+//	@Param			checkout	body		CheckoutRequest		true	"Employee and optional due date"
+//	@Success		201			{object}	models.Checkout		"Asset checked out"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid asset ID, JSON format or due date"
+//	@Failure		404			{object}	httpkit.ErrorResponse	"Asset or employee not found"
+//	@Failure		409			{object}	httpkit.ErrorResponse	"Asset is not available or employee is retired"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Failure		503			{object}	httpkit.ErrorResponse	"Employee service unavailable"
+//	@Router			/assets/{id}/checkout [post]
+func (h *AssetHandler) Checkout(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid asset ID")
+	if !ok {
+		return
+	}
+
+	var req CheckoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+	if req.EmployeeID < 1 {
+		httpkit.BadRequest(c, "Employee ID is required")
+		return
+	}
+	if req.DueBackOn != nil {
+		if _, err := time.Parse(time.DateOnly, *req.DueBackOn); err != nil {
+			httpkit.BadRequest(c, "Due back on must be a YYYY-MM-DD date")
+			return
+		}
+	}
+
+	checkout, err := h.service.Checkout(c.Request.Context(), id, req.EmployeeID, req.DueBackOn)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAssetNotFound):
+			httpkit.NotFound(c, "Asset not found")
+		case errors.Is(err, employees.ErrNotFound):
+			httpkit.NotFound(c, "Employee not found")
+		case errors.Is(err, repository.ErrAssetUnavailable):
+			httpkit.Error(c, http.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrEmployeeRetired):
+			httpkit.Error(c, http.StatusConflict, "Employee is retired")
+		case errors.Is(err, employees.ErrUnavailable):
+			httpkit.Error(c, http.StatusServiceUnavailable, "Employee service unavailable")
+		default:
+			httpkit.InternalServerError(c, "Failed to check out asset")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, checkout)
+}
+
+// Return godoc
+//
+//	@Summary		Return an asset
+//	@Description	Takes a checked out asset back and ticks it off any open reclaim checklist
+//	@Tags			Checkouts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"Asset ID"
+//	@Param			return	body		ReturnRequest		false	"Optional condition note"
+//	@Success		200		{object}	models.Checkout		"Asset returned"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid asset ID or JSON format"
+//	@Failure		404		{object}	httpkit.ErrorResponse	"Asset not found"
+//	@Failure		409		{object}	httpkit.ErrorResponse	"Asset is not checked out"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/assets/{id}/return [post]
+func (h *AssetHandler) Return(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid asset ID")
+	if !ok {
+		return
+	}
+
+	var req ReturnRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			httpkit.BadRequest(c, "Invalid JSON format")
+			return
+		}
+	}
+
+	checkout, err := h.service.Return(c.Request.Context(), id, strings.TrimSpace(req.Note))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAssetNotFound):
+			httpkit.NotFound(c, "Asset not found")
+		case errors.Is(err, repository.ErrNotCheckedOut):
+			httpkit.Error(c, http.StatusConflict, err.Error())
+		default:
+			httpkit.InternalServerError(c, "Failed to return asset")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, checkout)
+}
+
+// GetCheckouts godoc
+//
+//	@Summary		Checkout history
+//	@Description	Retrieves the checkouts of an asset, newest first
+//	@Tags			Checkouts
+//	@Produce		json
+//	@Param			id	path		int					true	"Asset ID"
+//	@Success		200	{array}		models.Checkout		"Checkouts"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid asset ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Asset not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/assets/{id}/checkouts [get]
+func (h *AssetHandler) GetCheckouts(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid asset ID")
+	if !ok {
+		return
+	}
+
+	checkouts, err := h.service.FindCheckouts(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAssetNotFound):
+			httpkit.NotFound(c, "Asset not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve checkouts")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, checkouts)
+}
+
+// GetEmployeeAssets godoc
+//
+//	@Summary		Assets of an employee
+//	@Description	Retrieves the assets an employee currently holds
+//	@Tags			Assets
+//	@Produce		json
+//	@Param			id	path		int					true	"Employee ID"
+//	@Success		200	{array}		models.Asset		"Assets"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid employee ID"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/employees/{id}/assets [get]
+func (h *AssetHandler) GetEmployeeAssets(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid employee ID")
+	if !ok {
+		return
+	}
+
+	assets, err := h.service.FindEmployeeAssets(c.Request.Context(), id)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve assets")
+		return
+	}
+
+	c.JSON(http.StatusOK, assets)
+}
+
+// pathID parses a positive id path parameter, answering 400 with message
+// when it is invalid
+func pathID(c *gin.Context, name, message string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id < 1 {
+		httpkit.BadRequest(c, message)
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"asset-service/internal/api"
+	"asset-service/internal/models"
+	"asset-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// GetAllChecklists godoc
+//
+//	@Summary		List reclaim checklists
+//	@Description	Retrieves the checklists generated for terminated employees, newest first
+//	@Tags			Checklists
+//	@Produce		json
+//	@Param			page		query		int					false	"Page number"	default(1)
+//	@Param			page_size	query		int					false	"Page size"		default(20)
+//	@Param			status		query		string				false	"Checklist status"	Enums(OPEN, COMPLETED, CANCELLED)
+//	@Param			employeeId	query		int					false	"Employee id"
+//	@Success		200			{object}	httpkit.PaginatedResponse{data=[]models.ReclaimChecklist}	"Checklists"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid query parameters"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/checklists [get]
+func (h *AssetHandler) GetAllChecklists(c *gin.Context) {
+	var query api.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpkit.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	status := models.ChecklistStatus(query.Status)
+	if status != "" && status != models.ChecklistOpen && status != models.ChecklistCompleted && status != models.ChecklistCancelled {
+		httpkit.BadRequest(c, "Invalid status")
+		return
+	}
+	query.Normalize()
+
+	checklists, total, err := h.service.FindChecklists(c.Request.Context(), status, query.EmployeeID, query.Page, query.PageSize)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve checklists")
+		return
+	}
+
+	c.JSON(http.StatusOK, httpkit.Paginate(checklists, query.PageQuery, total))
+}
+
+// GetChecklistByID godoc
+//
+//	@Summary		Get a reclaim checklist
+//	@Description	Retrieves a checklist with the assets to reclaim and their outcome
+//	@Tags			Checklists
+//	@Produce		json
+//	@Param			id	path		int						true	"Checklist ID"
+//	@Success		200	{object}	models.ReclaimChecklist	"Checklist"
+//	@Failure		400	{object}	httpkit.ErrorResponse		"Invalid checklist ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse		"Checklist not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse		"Internal server error"
+//	@Router			/checklists/{id} [get]
+func (h *AssetHandler) GetChecklistByID(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid checklist ID")
+	if !ok {
+		return
+	}
+
+	checklist, err := h.service.FindChecklist(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrChecklistNotFound):
+			httpkit.NotFound(c, "Checklist not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve checklist")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, checklist)
+}
+
+// MarkLost godoc
+//
+//	@Summary		Mark an asset lost
+//	@Description	Resolves a pending checklist item the employee cannot return: the checkout is closed and the asset set LOST. Returned assets are ticked off by the return endpoint
+//	@Tags			Checklists
+//	@Produce		json
+//	@Param			id		path		int						true	"Checklist ID"
+//	@Param			assetId	path		int						true	"Asset ID"
+//	@Success		200		{object}	models.ReclaimChecklist	"Updated checklist"
+//	@Failure		400		{object}	httpkit.ErrorResponse		"Invalid checklist or asset ID"
+//	@Failure		404		{object}	httpkit.ErrorResponse		"Asset is not pending on the checklist"
+//	@Failure		500		{object}	httpkit.ErrorResponse		"Internal server error"
+//	@Router			/checklists/{id}/items/{assetId}/lost [post]
+func (h *AssetHandler) MarkLost(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid checklist ID")
+	if !ok {
+		return
+	}
+	assetID, ok := pathID(c, "assetId", "Invalid asset ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.MarkLost(c.Request.Context(), id, assetID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrItemNotFound):
+			httpkit.NotFound(c, "Asset is not pending on the checklist")
+		default:
+			httpkit.InternalServerError(c, "Failed to mark asset lost")
+		}
+		return
+	}
+
+	checklist, err := h.service.FindChecklist(c.Request.Context(), id)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve checklist")
+		return
+	}
+
+	c.JSON(http.StatusOK, checklist)
+}
+
+// ReclaimRequest is the payload to open a reclaim checklist
+type ReclaimRequest struct {
+	// Reference identifies the request, retries with the same reference
+	// return the same checklist
+	Reference string `json:"reference" example:"saga:6f1c2d4e-8a0b-4c3d-9e2f-1a2b3c4d5e6f"`
+}
+
+// Reclaim godoc
+//
+//	@Summary		Open a reclaim checklist
+//	@Description	Lists the assets the employee holds to get them back, e.g. when offboarding. An open checklist of the employee is returned instead of a new one
+//	@Tags			Checklists
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Employee ID"
+//	@Param			request	body		ReclaimRequest			true	"Request reference"
+//	@Success		200		{object}	models.ReclaimChecklist	"Checklist"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid employee ID or missing reference"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/employees/{id}/reclaim [post]
+func (h *AssetHandler) Reclaim(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid employee ID")
+	if !ok {
+		return
+	}
+
+	var req ReclaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+	reference := strings.TrimSpace(req.Reference)
+	if reference == "" || len(reference) > 64 {
+		httpkit.BadRequest(c, "Reference is required, up to 64 characters")
+		return
+	}
+
+	checklist, err := h.service.Reclaim(c.Request.Context(), id, reference)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to open checklist")
+		return
+	}
+
+	c.JSON(http.StatusOK, checklist)
+}
+
+// CancelChecklist godoc
+//
+//	@Summary		Cancel a reclaim checklist
+//	@Description	Closes a checklist that is no longer needed, e.g. when a termination is rolled back. Pending items stay pending
+//	@Tags			Checklists
+//	@Produce		json
+//	@Param			id	path		int						true	"Checklist ID"
+//	@Success		200	{object}	models.ReclaimChecklist	"Cancelled checklist"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid checklist ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Checklist not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/checklists/{id}/cancel [post]
+func (h *AssetHandler) CancelChecklist(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid checklist ID")
+	if !ok {
+		return
+	}
+
+	checklist, err := h.service.CancelChecklist(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrChecklistNotFound):
+			httpkit.NotFound(c, "Checklist not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to cancel checklist")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, checklist)
+}
@@ -0,0 +1,99 @@
+// Package models define the core data structures of the asset service
+package models
+
+import "time"
+
+// AssetKind is the type of equipment
+type AssetKind string
+
+const (
+	KindLaptop AssetKind = "LAPTOP"
+	KindBadge  AssetKind = "BADGE"
+	KindPhone  AssetKind = "PHONE"
+	KindOther  AssetKind = "OTHER"
+)
+
+// Valid reports whether k is a known kind
+func (k AssetKind) Valid() bool {
+	switch k {
+	case KindLaptop, KindBadge, KindPhone, KindOther:
+		return true
+	}
+	return false
+}
+
+// AssetStatus is where an asset is in its lifecycle
+type AssetStatus string
+
+const (
+	StatusAvailable  AssetStatus = "AVAILABLE"
+	StatusCheckedOut AssetStatus = "CHECKED_OUT"
+	StatusLost       AssetStatus = "LOST"
+	StatusRetired    AssetStatus = "RETIRED"
+)
+
+// Asset is a piece of equipment lent to employees
+type Asset struct {
+	ID           int64       `json:"id"`
+	Tag          string      `json:"tag" example:"LT-0042"`
+	Kind         AssetKind   `json:"kind"`
+	Description  string      `json:"description"`
+	SerialNumber string      `json:"serialNumber,omitempty"`
+	Status       AssetStatus `json:"status"`
+	// EmployeeID is the holder while the asset is CHECKED_OUT
+	EmployeeID *int64    `json:"employeeId,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// Checkout is a period an employee held an asset
+type Checkout struct {
+	ID           int64      `json:"id"`
+	AssetID      int64      `json:"assetId"`
+	EmployeeID   int64      `json:"employeeId"`
+	CheckedOutAt time.Time  `json:"checkedOutAt"`
+	DueBackOn    *string    `json:"dueBackOn,omitempty" example:"2026-12-31"`
+	ReturnedAt   *time.Time `json:"returnedAt,omitempty"`
+	ReturnNote   string     `json:"returnNote,omitempty"`
+}
+
+// ChecklistStatus tells whether every asset of a checklist was resolved
+type ChecklistStatus string
+
+const (
+	ChecklistOpen      ChecklistStatus = "OPEN"
+	ChecklistCompleted ChecklistStatus = "COMPLETED"
+	// ChecklistCancelled is no longer needed, e.g. the termination was
+	// rolled back
+	ChecklistCancelled ChecklistStatus = "CANCELLED"
+)
+
+// ItemStatus is the outcome of reclaiming one asset
+type ItemStatus string
+
+const (
+	ItemPending  ItemStatus = "PENDING"
+	ItemReturned ItemStatus = "RETURNED"
+	ItemLost     ItemStatus = "LOST"
+)
+
+// ReclaimChecklist lists the assets to get back from a terminated employee
+type ReclaimChecklist struct {
+	ID         int64 `json:"id"`
+	EmployeeID int64 `json:"employeeId"`
+	// Reason is the employee event that generated the checklist
+	Reason      string          `json:"reason" example:"RETIRED"`
+	Status      ChecklistStatus `json:"status"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	CompletedAt *time.Time      `json:"completedAt,omitempty"`
+	Items       []ReclaimItem   `json:"items,omitempty"`
+}
+
+// ReclaimItem is an asset to reclaim
+type ReclaimItem struct {
+	AssetID    int64      `json:"assetId"`
+	Tag        string     `json:"tag"`
+	Kind       AssetKind  `json:"kind"`
+	Status     ItemStatus `json:"status"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+}
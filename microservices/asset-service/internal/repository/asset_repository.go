@@ -0,0 +1,526 @@
+// Package repository implements the data access layer of the asset service
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"asset-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Errors returned by AssetRepository
+var (
+	ErrAssetNotFound     = errors.New("asset not found")
+	ErrTagExists         = errors.New("asset tag already exists")
+	ErrAssetUnavailable  = errors.New("asset is not available")
+	ErrNotCheckedOut     = errors.New("asset is not checked out")
+	ErrChecklistNotFound = errors.New("reclaim checklist not found")
+	ErrItemNotFound      = errors.New("asset is not pending on the checklist")
+)
+
+// AssetFilter narrows FindAssets, zero values match everything
+type AssetFilter struct {
+	Kind       models.AssetKind
+	Status     models.AssetStatus
+	EmployeeID int64
+}
+
+// AssetRepository defines the interface for asset data operations
+type AssetRepository interface {
+	CreateAsset(ctx context.Context, a *models.Asset) error
+	FindAsset(ctx context.Context, id int64) (*models.Asset, error)
+	// FindAssets returns a page of assets, every asset with limit 0
+	FindAssets(ctx context.Context, filter AssetFilter, limit, offset int) ([]models.Asset, int, error)
+	// RetireAsset decommissions an AVAILABLE asset
+	RetireAsset(ctx context.Context, id int64) error
+
+	// Checkout lends an AVAILABLE asset to the employee
+	Checkout(ctx context.Context, assetID, employeeID int64, dueBackOn *string) (*models.Checkout, error)
+	// Return closes the open checkout of the asset and resolves it on any
+	// open reclaim checklist
+	Return(ctx context.Context, assetID int64, note string) (*models.Checkout, error)
+	FindCheckouts(ctx context.Context, assetID int64) ([]models.Checkout, error)
+
+	// OpenChecklist lists the assets the employee holds, once per event or
+	// request reference. It returns the checklist already created for
+	// eventID, or the open checklist of the employee, with created false
+	OpenChecklist(ctx context.Context, employeeID int64, eventID, reason string) (checklist *models.ReclaimChecklist, created bool, err error)
+	// CancelChecklist closes a checklist that is no longer needed, e.g.
+	// when a termination is rolled back
+	CancelChecklist(ctx context.Context, id int64) (*models.ReclaimChecklist, error)
+	FindChecklist(ctx context.Context, id int64) (*models.ReclaimChecklist, error)
+	FindChecklists(ctx context.Context, status models.ChecklistStatus, employeeID int64, limit, offset int) ([]models.ReclaimChecklist, int, error)
+	// MarkLost resolves a pending checklist item as lost
+	MarkLost(ctx context.Context, checklistID, assetID int64) error
+}
+
+// assetRepository is the postgresql implementation of AssetRepository
+type assetRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAssetRepository creates a new instance of AssetRepository
+func NewAssetRepository(db *pgxpool.Pool) AssetRepository {
+	return &assetRepository{db: db}
+}
+
+// assetColumns are the columns scanned by scanAsset
+const assetColumns = `id, tag, kind, description, serial_number, status, employee_id, created_at, updated_at`
+
+// scanAsset scans a row selected with assetColumns
+func scanAsset(row pgx.Row) (models.Asset, error) {
+	var a models.Asset
+	err := row.Scan(&a.ID, &a.Tag, &a.Kind, &a.Description, &a.SerialNumber, &a.Status, &a.EmployeeID, &a.CreatedAt, &a.UpdatedAt)
+	return a, err
+}
+
+// checkoutColumns are the columns scanned by scanCheckout
+const checkoutColumns = `
+        id, asset_id, employee_id, checked_out_at, to_char(due_back_on, 'YYYY-MM-DD'), returned_at, return_note
+    `
+
+// scanCheckout scans a row selected with checkoutColumns
+func scanCheckout(row pgx.Row) (models.Checkout, error) {
+	var c models.Checkout
+	err := row.Scan(&c.ID, &c.AssetID, &c.EmployeeID, &c.CheckedOutAt, &c.DueBackOn, &c.ReturnedAt, &c.ReturnNote)
+	return c, err
+}
+
+// checklistLockSpace is the first key of the advisory locks taken per
+// employee while opening a checklist
+const checklistLockSpace = 7341106
+
+// checklistColumns are the columns scanned by scanChecklist
+const checklistColumns = `id, employee_id, reason, status, created_at, completed_at`
+
+// scanChecklist scans a row selected with checklistColumns
+func scanChecklist(row pgx.Row) (models.ReclaimChecklist, error) {
+	var c models.ReclaimChecklist
+	err := row.Scan(&c.ID, &c.EmployeeID, &c.Reason, &c.Status, &c.CreatedAt, &c.CompletedAt)
+	return c, err
+}
+
+// CreateAsset adds an AVAILABLE asset
+func (r *assetRepository) CreateAsset(ctx context.Context, a *models.Asset) error {
+	query := `
+        INSERT INTO assets.assets (tag, kind, description, serial_number)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, status, created_at, updated_at
+    `
+
+	err := r.db.QueryRow(ctx, query, a.Tag, a.Kind, a.Description, a.SerialNumber).Scan(&a.ID, &a.Status, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrTagExists
+		}
+		return fmt.Errorf("failed to create asset: %w", err)
+	}
+
+	return nil
+}
+
+// FindAsset retrieves an asset
+func (r *assetRepository) FindAsset(ctx context.Context, id int64) (*models.Asset, error) {
+	a, err := scanAsset(r.db.QueryRow(ctx, `SELECT `+assetColumns+` FROM assets.assets WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAssetNotFound
+		}
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// FindAssets retrieves a page of assets by tag with the total count
+func (r *assetRepository) FindAssets(ctx context.Context, filter AssetFilter, limit, offset int) ([]models.Asset, int, error) {
+	where := `WHERE ($1 = '' OR kind = $1) AND ($2 = '' OR status = $2) AND ($3 = 0 OR employee_id = $3)`
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM assets.assets `+where, filter.Kind, filter.Status, filter.EmployeeID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count assets: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+assetColumns+` FROM assets.assets `+where+`
+        ORDER BY tag
+        LIMIT NULLIF($4, 0) OFFSET $5`, filter.Kind, filter.Status, filter.EmployeeID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query assets: %w", err)
+	}
+	defer rows.Close()
+
+	assets := []models.Asset{}
+	for rows.Next() {
+		a, err := scanAsset(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan asset row: %w", err)
+		}
+		assets = append(assets, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating asset rows: %w", err)
+	}
+
+	return assets, total, nil
+}
+
+// RetireAsset sets an AVAILABLE asset as RETIRED
+func (r *assetRepository) RetireAsset(ctx context.Context, id int64) error {
+	result, err := r.db.Exec(ctx, `
+        UPDATE assets.assets SET status = 'RETIRED', updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = 'AVAILABLE'`, id)
+	if err != nil {
+		return fmt.Errorf("failed to retire asset: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		if _, err := r.FindAsset(ctx, id); err != nil {
+			return err
+		}
+		return ErrAssetUnavailable
+	}
+
+	return nil
+}
+
+// Checkout locks the asset, opens a checkout and records the holder
+func (r *assetRepository) Checkout(ctx context.Context, assetID, employeeID int64, dueBackOn *string) (*models.Checkout, error) {
+	var checkout models.Checkout
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err := lockAsset(ctx, tx, assetID, models.StatusAvailable, ErrAssetUnavailable); err != nil {
+			return err
+		}
+
+		var err error
+		checkout, err = scanCheckout(tx.QueryRow(ctx, `
+            INSERT INTO assets.checkouts (asset_id, employee_id, due_back_on)
+            VALUES ($1, $2, $3::date)
+            RETURNING `+checkoutColumns, assetID, employeeID, dueBackOn))
+		if err != nil {
+			return fmt.Errorf("failed to create checkout: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+            UPDATE assets.assets SET status = 'CHECKED_OUT', employee_id = $2, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1`, assetID, employeeID)
+		if err != nil {
+			return fmt.Errorf("failed to update asset: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &checkout, nil
+}
+
+// Return closes the checkout, frees the asset and ticks it off the open
+// checklists of its holder
+func (r *assetRepository) Return(ctx context.Context, assetID int64, note string) (*models.Checkout, error) {
+	var checkout models.Checkout
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err := lockAsset(ctx, tx, assetID, models.StatusCheckedOut, ErrNotCheckedOut); err != nil {
+			return err
+		}
+
+		var err error
+		checkout, err = closeCheckout(ctx, tx, assetID, note)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+            UPDATE assets.assets SET status = 'AVAILABLE', employee_id = NULL, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1`, assetID)
+		if err != nil {
+			return fmt.Errorf("failed to update asset: %w", err)
+		}
+
+		return resolveItems(ctx, tx, `
+            UPDATE assets.reclaim_items i SET status = 'RETURNED', resolved_at = CURRENT_TIMESTAMP
+            FROM assets.reclaim_checklists c
+            WHERE c.id = i.checklist_id AND c.status = 'OPEN' AND i.asset_id = $1 AND i.status = 'PENDING'
+            RETURNING i.checklist_id`, assetID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &checkout, nil
+}
+
+// FindCheckouts retrieves the checkout history of an asset, newest first
+func (r *assetRepository) FindCheckouts(ctx context.Context, assetID int64) ([]models.Checkout, error) {
+	if _, err := r.FindAsset(ctx, assetID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+checkoutColumns+`
+        FROM assets.checkouts
+        WHERE asset_id = $1
+        ORDER BY checked_out_at DESC, id DESC`, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query checkouts: %w", err)
+	}
+	defer rows.Close()
+
+	checkouts := []models.Checkout{}
+	for rows.Next() {
+		c, err := scanCheckout(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan checkout row: %w", err)
+		}
+		checkouts = append(checkouts, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating checkout rows: %w", err)
+	}
+
+	return checkouts, nil
+}
+
+// OpenChecklist inserts the checklist and one item per held asset. An
+// employee holding nothing gets a COMPLETED checklist, which still marks
+// the event as handled
+func (r *assetRepository) OpenChecklist(ctx context.Context, employeeID int64, eventID, reason string) (*models.ReclaimChecklist, bool, error) {
+	var id int64
+	created := false
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		// Serializes the checklists of the employee so two events or
+		// requests cannot both open one
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1, hashint8($2))`, checklistLockSpace, employeeID); err != nil {
+			return fmt.Errorf("failed to lock employee checklists: %w", err)
+		}
+
+		err := tx.QueryRow(ctx, `
+            SELECT id FROM assets.reclaim_checklists
+            WHERE event_id = $1 OR (employee_id = $2 AND status = 'OPEN')
+            ORDER BY event_id = $1 DESC
+            LIMIT 1`, eventID, employeeID).Scan(&id)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to find reclaim checklist: %w", err)
+		}
+
+		err = tx.QueryRow(ctx, `
+            INSERT INTO assets.reclaim_checklists (employee_id, event_id, reason)
+            VALUES ($1, $2, $3)
+            RETURNING id`, employeeID, eventID, reason).Scan(&id)
+		if err != nil {
+			return fmt.Errorf("failed to create reclaim checklist: %w", err)
+		}
+		created = true
+
+		result, err := tx.Exec(ctx, `
+            INSERT INTO assets.reclaim_items (checklist_id, asset_id)
+            SELECT $1, id FROM assets.assets WHERE employee_id = $2 AND status = 'CHECKED_OUT'`, id, employeeID)
+		if err != nil {
+			return fmt.Errorf("failed to create reclaim items: %w", err)
+		}
+
+		if result.RowsAffected() == 0 {
+			_, err = tx.Exec(ctx, `
+                UPDATE assets.reclaim_checklists SET status = 'COMPLETED', completed_at = CURRENT_TIMESTAMP
+                WHERE id = $1`, id)
+			if err != nil {
+				return fmt.Errorf("failed to complete reclaim checklist: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	checklist, err := r.FindChecklist(ctx, id)
+	return checklist, created, err
+}
+
+// CancelChecklist sets the checklist CANCELLED, its pending items stay
+// pending. Cancelling twice is a no-op
+func (r *assetRepository) CancelChecklist(ctx context.Context, id int64) (*models.ReclaimChecklist, error) {
+	result, err := r.db.Exec(ctx, `
+        UPDATE assets.reclaim_checklists
+        SET status = 'CANCELLED', completed_at = COALESCE(completed_at, CURRENT_TIMESTAMP)
+        WHERE id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel reclaim checklist: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, ErrChecklistNotFound
+	}
+
+	return r.FindChecklist(ctx, id)
+}
+
+// FindChecklist retrieves a checklist with its items
+func (r *assetRepository) FindChecklist(ctx context.Context, id int64) (*models.ReclaimChecklist, error) {
+	c, err := scanChecklist(r.db.QueryRow(ctx, `SELECT `+checklistColumns+` FROM assets.reclaim_checklists WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrChecklistNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+        SELECT i.asset_id, a.tag, a.kind, i.status, i.resolved_at
+        FROM assets.reclaim_items i
+        JOIN assets.assets a ON a.id = i.asset_id
+        WHERE i.checklist_id = $1
+        ORDER BY a.tag`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reclaim items: %w", err)
+	}
+	defer rows.Close()
+
+	c.Items = []models.ReclaimItem{}
+	for rows.Next() {
+		var item models.ReclaimItem
+		if err := rows.Scan(&item.AssetID, &item.Tag, &item.Kind, &item.Status, &item.ResolvedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reclaim item row: %w", err)
+		}
+		c.Items = append(c.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reclaim item rows: %w", err)
+	}
+
+	return &c, nil
+}
+
+// FindChecklists retrieves a page of checklists newest first with the
+// total count
+func (r *assetRepository) FindChecklists(ctx context.Context, status models.ChecklistStatus, employeeID int64, limit, offset int) ([]models.ReclaimChecklist, int, error) {
+	where := `WHERE ($1 = '' OR status = $1) AND ($2 = 0 OR employee_id = $2)`
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM assets.reclaim_checklists `+where, status, employeeID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count reclaim checklists: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+checklistColumns+` FROM assets.reclaim_checklists `+where+`
+        ORDER BY created_at DESC, id DESC
+        LIMIT $3 OFFSET $4`, status, employeeID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query reclaim checklists: %w", err)
+	}
+	defer rows.Close()
+
+	checklists := []models.ReclaimChecklist{}
+	for rows.Next() {
+		c, err := scanChecklist(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan reclaim checklist row: %w", err)
+		}
+		checklists = append(checklists, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating reclaim checklist rows: %w", err)
+	}
+
+	return checklists, total, nil
+}
+
+// MarkLost writes the asset off: the checkout is closed, the asset is
+// LOST and the item resolved
+func (r *assetRepository) MarkLost(ctx context.Context, checklistID, assetID int64) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err := lockAsset(ctx, tx, assetID, models.StatusCheckedOut, ErrItemNotFound); err != nil {
+			if errors.Is(err, ErrAssetNotFound) {
+				return ErrItemNotFound
+			}
+			return err
+		}
+
+		err := resolveItems(ctx, tx, `
+            UPDATE assets.reclaim_items i SET status = 'LOST', resolved_at = CURRENT_TIMESTAMP
+            WHERE i.checklist_id = $2 AND i.asset_id = $1 AND i.status = 'PENDING'
+            RETURNING i.checklist_id`, assetID, checklistID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := closeCheckout(ctx, tx, assetID, "lost"); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+            UPDATE assets.assets SET status = 'LOST', employee_id = NULL, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1`, assetID)
+		if err != nil {
+			return fmt.Errorf("failed to update asset: %w", err)
+		}
+		return nil
+	})
+}
+
+// lockAsset locks the asset row, failing with wrong if it is not in the
+// given status
+func lockAsset(ctx context.Context, tx pgx.Tx, id int64, status models.AssetStatus, wrong error) error {
+	var current models.AssetStatus
+	err := tx.QueryRow(ctx, `SELECT status FROM assets.assets WHERE id = $1 FOR UPDATE`, id).Scan(&current)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAssetNotFound
+		}
+		return fmt.Errorf("failed to lock asset: %w", err)
+	}
+
+	if current != status {
+		return fmt.Errorf("%w: it is %s", wrong, current)
+	}
+	return nil
+}
+
+// closeCheckout sets the open checkout of the asset as returned
+func closeCheckout(ctx context.Context, tx pgx.Tx, assetID int64, note string) (models.Checkout, error) {
+	checkout, err := scanCheckout(tx.QueryRow(ctx, `
+        UPDATE assets.checkouts SET returned_at = CURRENT_TIMESTAMP, return_note = $2
+        WHERE asset_id = $1 AND returned_at IS NULL
+        RETURNING `+checkoutColumns, assetID, note))
+	if err != nil {
+		return checkout, fmt.Errorf("failed to close checkout: %w", err)
+	}
+	return checkout, nil
+}
+
+// resolveItems runs an update of reclaim items returning their checklist
+// ids, then completes the checklists left without pending items. With
+// args[1] set (a checklist id) no updated row means ErrItemNotFound
+func resolveItems(ctx context.Context, tx pgx.Tx, query string, args ...any) error {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to resolve reclaim items: %w", err)
+	}
+	checklists, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return fmt.Errorf("failed to resolve reclaim items: %w", err)
+	}
+	if len(checklists) == 0 && len(args) > 1 {
+		return ErrItemNotFound
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE assets.reclaim_checklists c SET status = 'COMPLETED', completed_at = CURRENT_TIMESTAMP
+        WHERE c.id = ANY($1) AND c.status = 'OPEN'
+          AND NOT EXISTS (SELECT 1 FROM assets.reclaim_items i WHERE i.checklist_id = c.id AND i.status = 'PENDING')`, checklists)
+	if err != nil {
+		return fmt.Errorf("failed to complete reclaim checklists: %w", err)
+	}
+	return nil
+}
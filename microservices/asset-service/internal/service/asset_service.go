@@ -0,0 +1,210 @@
+// Package service contains the business logic of the asset service
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"asset-service/internal/events"
+	"asset-service/internal/models"
+	"asset-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/employeesync"
+)
+
+// ErrEmployeeRetired is returned when checking out an asset to an
+// employee who is no longer employed
+var ErrEmployeeRetired = errors.New("employee is retired")
+
+// Reasons of the reclaim checklists
+const (
+	ReasonDeleted = "DELETED"
+	ReasonRetired = "RETIRED"
+	// ReasonRequested is a checklist opened through the API, e.g. by the
+	// offboarding saga
+	ReasonRequested = "REQUESTED"
+)
+
+// AssetService manages the equipment, its checkouts and the reclaim
+// checklists of terminated employees
+type AssetService struct {
+	repo      repository.AssetRepository
+	employees *employees.Client
+	// directory is the local copy of the employees kept by sync
+	directory employeesync.Store
+	sync      *employeesync.Syncer
+}
+
+// NewAssetService creates a new AssetService instance
+// directory is the copy of the employees sync keeps up to date
+func NewAssetService(repo repository.AssetRepository, employeeClient *employees.Client, directory employeesync.Store, sync *employeesync.Syncer) *AssetService {
+	return &AssetService{repo: repo, employees: employeeClient, directory: directory, sync: sync}
+}
+
+// CreateAsset adds an asset to the inventory
+func (s *AssetService) CreateAsset(ctx context.Context, a *models.Asset) error {
+	return s.repo.CreateAsset(ctx, a)
+}
+
+// FindAsset retrieves an asset
+func (s *AssetService) FindAsset(ctx context.Context, id int64) (*models.Asset, error) {
+	return s.repo.FindAsset(ctx, id)
+}
+
+// FindAssets retrieves a page of assets with the total count
+func (s *AssetService) FindAssets(ctx context.Context, filter repository.AssetFilter, page, pageSize int) ([]models.Asset, int, error) {
+	return s.repo.FindAssets(ctx, filter, pageSize, (page-1)*pageSize)
+}
+
+// FindEmployeeAssets retrieves the assets an employee holds
+func (s *AssetService) FindEmployeeAssets(ctx context.Context, employeeID int64) ([]models.Asset, error) {
+	filter := repository.AssetFilter{Status: models.StatusCheckedOut, EmployeeID: employeeID}
+	assets, _, err := s.repo.FindAssets(ctx, filter, 0, 0)
+	return assets, err
+}
+
+// RetireAsset decommissions an asset that is not checked out
+func (s *AssetService) RetireAsset(ctx context.Context, id int64) error {
+	return s.repo.RetireAsset(ctx, id)
+}
+
+// Checkout lends the asset to an employee, who must exist in
+// employee-management and not be retired
+func (s *AssetService) Checkout(ctx context.Context, assetID, employeeID int64, dueBackOn *string) (*models.Checkout, error) {
+	status, err := s.employeeStatus(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if status == employees.StatusRetired {
+		return nil, ErrEmployeeRetired
+	}
+
+	return s.repo.Checkout(ctx, assetID, employeeID, dueBackOn)
+}
+
+// employeeStatus reads the status from the local copy of the employees,
+// and from employee-management for employees the copy does not hold, e.g.
+// while it is bootstrapped
+func (s *AssetService) employeeStatus(ctx context.Context, id int64) (string, error) {
+	employee, err := s.directory.Get(ctx, id)
+	if err == nil {
+		return employee.Status, nil
+	}
+	if !errors.Is(err, employeesync.ErrNotFound) {
+		log.Printf("employee copy lookup failed: %v", err)
+	}
+
+	remote, err := s.employees.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return remote.Status, nil
+}
+
+// Return takes the asset back from its holder
+func (s *AssetService) Return(ctx context.Context, assetID int64, note string) (*models.Checkout, error) {
+	return s.repo.Return(ctx, assetID, note)
+}
+
+// FindCheckouts retrieves the checkout history of an asset
+func (s *AssetService) FindCheckouts(ctx context.Context, assetID int64) ([]models.Checkout, error) {
+	return s.repo.FindCheckouts(ctx, assetID)
+}
+
+// FindChecklist retrieves a reclaim checklist with its items
+func (s *AssetService) FindChecklist(ctx context.Context, id int64) (*models.ReclaimChecklist, error) {
+	return s.repo.FindChecklist(ctx, id)
+}
+
+// FindChecklists retrieves a page of reclaim checklists with the total count
+func (s *AssetService) FindChecklists(ctx context.Context, status models.ChecklistStatus, employeeID int64, page, pageSize int) ([]models.ReclaimChecklist, int, error) {
+	return s.repo.FindChecklists(ctx, status, employeeID, pageSize, (page-1)*pageSize)
+}
+
+// MarkLost writes off an asset the terminated employee cannot return
+func (s *AssetService) MarkLost(ctx context.Context, checklistID, assetID int64) error {
+	return s.repo.MarkLost(ctx, checklistID, assetID)
+}
+
+// Reclaim opens a reclaim checklist for the employee. reference makes a
+// retried request return the same checklist, and an open checklist of the
+// employee is returned instead of a new one
+func (s *AssetService) Reclaim(ctx context.Context, employeeID int64, reference string) (*models.ReclaimChecklist, error) {
+	checklist, _, err := s.repo.OpenChecklist(ctx, employeeID, reference, ReasonRequested)
+	return checklist, err
+}
+
+// CancelChecklist closes a checklist that is no longer needed
+func (s *AssetService) CancelChecklist(ctx context.Context, id int64) (*models.ReclaimChecklist, error) {
+	return s.repo.CancelChecklist(ctx, id)
+}
+
+// HandleEvent updates the copy of the employees, and generates a reclaim
+// checklist when an employee is deleted or retired. The event id makes
+// redeliveries create nothing, and an employee with an open checklist does
+// not get a second one
+func (s *AssetService) HandleEvent(ctx context.Context, e events.Event) error {
+	err := s.sync.Handle(ctx, employeesync.Event{
+		ID:          e.ID,
+		Type:        string(e.Type),
+		AggregateID: e.AggregateID,
+		Version:     e.Version,
+		Payload:     e.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	employeeID, reason, err := terminated(e)
+	if err != nil {
+		// A payload we cannot read will not get better on redelivery
+		log.Printf("skipping event %s: %v", e.ID, err)
+		return nil
+	}
+	if reason == "" {
+		return nil
+	}
+
+	checklist, created, err := s.repo.OpenChecklist(ctx, employeeID, e.ID, reason)
+	if err != nil {
+		return err
+	}
+	if created && len(checklist.Items) > 0 {
+		log.Printf("reclaim checklist %d: %d assets of employee %d", checklist.ID, len(checklist.Items), employeeID)
+	}
+	return nil
+}
+
+// terminated returns the employee and the checklist reason of an event
+// ending an employment, or an empty reason for any other event
+func terminated(e events.Event) (int64, string, error) {
+	switch e.Type {
+	case events.EmployeeDeleted:
+		var employee events.Employee
+		if err := json.Unmarshal(e.Payload, &employee); err != nil {
+			return 0, "", err
+		}
+		return employeeID(employee.ID, e), ReasonDeleted, nil
+	case events.EmployeeStatusChanged:
+		var change events.StatusChange
+		if err := json.Unmarshal(e.Payload, &change); err != nil {
+			return 0, "", err
+		}
+		if change.To != employees.StatusRetired {
+			return 0, "", nil
+		}
+		return employeeID(change.EmployeeID, e), ReasonRetired, nil
+	}
+	return 0, "", nil
+}
+
+// employeeID falls back to the aggregate id when the payload has none
+func employeeID(id int64, e events.Event) int64 {
+	if id == 0 {
+		return e.AggregateID
+	}
+	return id
+}
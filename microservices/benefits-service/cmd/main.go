@@ -0,0 +1,90 @@
+package main
+
+//	@title			Benefits Service API
+//	@version		1.0
+//	@description	Benefit plans, enrollment windows and employee elections with eligibility by employment status and hire date
+//	@termsOfService	http://swagger.io/terms/
+
+//	@contact.name	API Support
+//	@contact.email	josed.amayar@uqvirtual.edu.co
+
+//	@host		localhost:8088
+//	@BasePath	/benefits-service/api/v1
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"benefits-service/internal/config"
+	"benefits-service/internal/db"
+	"benefits-service/internal/handlers"
+	"benefits-service/internal/repository"
+	"benefits-service/internal/service"
+
+	_ "benefits-service/docs" // Swagger docs
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+func main() {
+	cfg := config.Load()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbPool := db.NewPostgresPool(cfg)
+	defer dbPool.Close()
+
+	if cfg.MigrateOnStartup {
+		if err := db.Migrate(ctx, dbPool); err != nil {
+			log.Fatalf("database migration failed: %v", err)
+		}
+	}
+
+	// Statuses and hire dates come from employee-management
+	employeeClient := employees.NewClient(cfg.EmployeeServiceURL, cfg.EmployeeServiceTimeout)
+	repo := repository.NewBenefitsRepository(dbPool)
+	benefitsService := service.NewBenefitsService(repo, employeeClient, cfg.NewHireEnrollmentDays)
+
+	handler := handlers.NewBenefitsHandler(benefitsService)
+	healthHandler := httpkit.NewHealthHandler("benefits-service", dbPool)
+
+	router := httpkit.NewRouter()
+
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	v1 := router.Group("/benefits-service/api/v1")
+	{
+		v1.GET("/health", healthHandler.HealthCheck)
+
+		v1.POST("/plans", handler.CreatePlan)
+		v1.GET("/plans", handler.GetAllPlans)
+		v1.GET("/plans/:id", handler.GetPlanByID)
+		v1.POST("/plans/:id/deactivate", handler.DeactivatePlan)
+
+		v1.POST("/windows", handler.CreateWindow)
+		v1.GET("/windows", handler.GetAllWindows)
+
+		v1.GET("/employees/:id/enrollment", handler.GetEnrollment)
+		v1.POST("/employees/:id/elections", handler.Elect)
+		v1.GET("/employees/:id/elections", handler.GetElections)
+	}
+
+	srv := &http.Server{Addr: ":" + cfg.ServerPort, Handler: router}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	log.Printf("Benefits service running on :%s", cfg.ServerPort)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
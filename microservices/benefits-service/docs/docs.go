@@ -0,0 +1,745 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "email": "josed.amayar@uqvirtual.edu.co"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/employees/{id}/elections": {
+            "get": {
+                "description": "Retrieves the latest election of the employee for each plan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Elections"
+                ],
+                "summary": "Elections of an employee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Elections",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Election"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid employee ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Records the coverage chosen for a plan, or WAIVED to decline it. A new election in the same window or new hire period replaces the previous one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Elections"
+                ],
+                "summary": "Elect a plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Plan and coverage",
+                        "name": "election",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ElectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Election recorded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Election"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid employee ID, JSON format or coverage",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee or plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not eligible, plan inactive or no enrollment period",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Employee service unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/enrollment": {
+            "get": {
+                "description": "Tells whether the employee can enroll today, through an open window or their new hire period, and which active plans they are eligible for",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Elections"
+                ],
+                "summary": "Enrollment of an employee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Enrollment",
+                        "schema": {
+                            "$ref": "#/definitions/models.Enrollment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid employee ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Employee service unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/plans": {
+            "get": {
+                "description": "Retrieves the plans by name, only the active ones unless all is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plans"
+                ],
+                "summary": "List plans",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include deactivated plans",
+                        "name": "all",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Plans",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Plan"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a benefit plan open to employees in one of the eligible statuses once waitingDays have passed since their hire date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plans"
+                ],
+                "summary": "Add a plan",
+                "parameters": [
+                    {
+                        "description": "Plan data",
+                        "name": "plan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Plan created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Plan"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Plan name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/plans/{id}": {
+            "get": {
+                "description": "Retrieves a plan by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plans"
+                ],
+                "summary": "Get a plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Plan",
+                        "schema": {
+                            "$ref": "#/definitions/models.Plan"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid plan ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/plans/{id}/deactivate": {
+            "post": {
+                "description": "Stops offering a plan, elections already made are kept",
+                "tags": [
+                    "Plans"
+                ],
+                "summary": "Deactivate a plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Plan deactivated"
+                    },
+                    "400": {
+                        "description": "Invalid plan ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/windows": {
+            "get": {
+                "description": "Retrieves the enrollment windows, latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Windows"
+                ],
+                "summary": "List enrollment windows",
+                "responses": {
+                    "200": {
+                        "description": "Windows",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Window"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a period, both dates included, in which every eligible employee can change their elections. Windows cannot overlap",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Windows"
+                ],
+                "summary": "Add an enrollment window",
+                "parameters": [
+                    {
+                        "description": "Window data",
+                        "name": "window",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WindowRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Window created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Window"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Window overlaps another window",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.ElectionRequest": {
+            "type": "object",
+            "properties": {
+                "coverage": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Coverage"
+                        }
+                    ],
+                    "example": "FAMILY"
+                },
+                "planId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handlers.PlanRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "eligibleStatuses": {
+                    "description": "EligibleStatuses defaults to ACTIVE",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ACTIVE",
+                        "ON_VACATION"
+                    ]
+                },
+                "kind": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PlanKind"
+                        }
+                    ],
+                    "example": "HEALTH"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Health PPO"
+                },
+                "waitingDays": {
+                    "type": "integer",
+                    "example": 90
+                }
+            }
+        },
+        "handlers.WindowRequest": {
+            "type": "object",
+            "properties": {
+                "closesOn": {
+                    "type": "string",
+                    "example": "2026-11-30"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Open enrollment 2027"
+                },
+                "opensOn": {
+                    "type": "string",
+                    "example": "2026-11-01"
+                }
+            }
+        },
+        "httpkit.ErrorResponse": {
+            "description": "Standard error response structure",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Coverage": {
+            "type": "string",
+            "enum": [
+                "EMPLOYEE",
+                "EMPLOYEE_SPOUSE",
+                "EMPLOYEE_CHILDREN",
+                "FAMILY",
+                "WAIVED"
+            ],
+            "x-enum-varnames": [
+                "CoverageEmployee",
+                "CoverageEmployeeSpouse",
+                "CoverageEmployeeChildren",
+                "CoverageFamily",
+                "CoverageWaived"
+            ]
+        },
+        "models.Election": {
+            "type": "object",
+            "properties": {
+                "coverage": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Coverage"
+                        }
+                    ],
+                    "example": "FAMILY"
+                },
+                "electedAt": {
+                    "type": "string"
+                },
+                "employeeId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "planId": {
+                    "type": "integer"
+                },
+                "planName": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "windowId": {
+                    "description": "WindowID is the enrollment window, empty for new hire elections",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Enrollment": {
+            "type": "object",
+            "properties": {
+                "canEnroll": {
+                    "type": "boolean"
+                },
+                "employeeId": {
+                    "type": "integer"
+                },
+                "newHireUntil": {
+                    "description": "NewHireUntil is the last day of the new hire period, if in it",
+                    "type": "string",
+                    "example": "2026-04-01"
+                },
+                "plans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlanEligibility"
+                    }
+                },
+                "window": {
+                    "description": "Window is the enrollment window open today, if any",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Window"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.Plan": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "eligibleStatuses": {
+                    "description": "EligibleStatuses are the employment statuses that may enroll",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ACTIVE",
+                        "ON_VACATION"
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PlanKind"
+                        }
+                    ],
+                    "example": "HEALTH"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Health PPO"
+                },
+                "waitingDays": {
+                    "description": "WaitingDays is how long after the hire date the plan opens",
+                    "type": "integer",
+                    "example": 90
+                }
+            }
+        },
+        "models.PlanEligibility": {
+            "type": "object",
+            "properties": {
+                "eligible": {
+                    "type": "boolean"
+                },
+                "eligibleOn": {
+                    "description": "EligibleOn is when the waiting period ends, if that is the reason",
+                    "type": "string",
+                    "example": "2026-06-01"
+                },
+                "planId": {
+                    "type": "integer"
+                },
+                "planName": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason explains why the employee is not eligible",
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlanKind": {
+            "type": "string",
+            "enum": [
+                "HEALTH",
+                "DENTAL",
+                "VISION",
+                "LIFE",
+                "RETIREMENT",
+                "OTHER"
+            ],
+            "x-enum-varnames": [
+                "KindHealth",
+                "KindDental",
+                "KindVision",
+                "KindLife",
+                "KindRetirement",
+                "KindOther"
+            ]
+        },
+        "models.Window": {
+            "type": "object",
+            "properties": {
+                "closesOn": {
+                    "type": "string",
+                    "example": "2026-11-30"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Open enrollment 2027"
+                },
+                "opensOn": {
+                    "type": "string",
+                    "example": "2026-11-01"
+                }
+            }
+        }
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:8088",
+	BasePath:         "/benefits-service/api/v1",
+	Schemes:          []string{},
+	Title:            "Benefits Service API",
+	Description:      "Benefit plans, enrollment windows and employee elections with eligibility by employment status and hire date",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
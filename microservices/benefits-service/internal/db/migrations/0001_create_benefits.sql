@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS benefits.plans (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	name VARCHAR(255) NOT NULL UNIQUE,
+	kind VARCHAR(20) NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	eligible_statuses TEXT[] NOT NULL DEFAULT '{ACTIVE}',
+	waiting_days INTEGER NOT NULL DEFAULT 0,
+	active BOOLEAN NOT NULL DEFAULT TRUE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CHECK (waiting_days >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS benefits.enrollment_windows (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	opens_on DATE NOT NULL,
+	closes_on DATE NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CHECK (closes_on >= opens_on)
+);
+
+CREATE INDEX IF NOT EXISTS enrollment_windows_dates_idx ON benefits.enrollment_windows (opens_on, closes_on);
+
+-- window_id is NULL for elections made in the new hire period
+CREATE TABLE IF NOT EXISTS benefits.elections (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	employee_id BIGINT NOT NULL,
+	plan_id BIGINT NOT NULL REFERENCES benefits.plans (id),
+	window_id BIGINT REFERENCES benefits.enrollment_windows (id),
+	coverage VARCHAR(20) NOT NULL,
+	elected_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS elections_period_idx ON benefits.elections (employee_id, plan_id, COALESCE(window_id, 0));
@@ -0,0 +1,29 @@
+// Package db provides database connection management
+package db
+
+import (
+	"context"
+	"log"
+
+	"benefits-service/internal/config"
+
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit/postgres"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewPostgresPool creates and return a new Postgresql connection pool
+// It pings the db until it answers, and will terminate the app if the db
+// is still unreachable after DBRetryMaxWait
+func NewPostgresPool(cfg *config.Config) *pgxpool.Pool {
+	pool, err := postgres.NewPool(context.Background(), postgres.PoolConfig{
+		URL:          cfg.DatabaseURL(),
+		MaxConns:     cfg.DBMaxConns,
+		RetryMaxWait: cfg.DBRetryMaxWait,
+	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	return pool
+}
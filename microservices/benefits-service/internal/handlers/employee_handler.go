@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"benefits-service/internal/models"
+	"benefits-service/internal/repository"
+	"benefits-service/internal/service"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// ElectionRequest is the payload of an election
+type ElectionRequest struct {
+	PlanID   int64           `json:"planId" example:"1"`
+	Coverage models.Coverage `json:"coverage" example:"FAMILY"`
+}
+
+// GetEnrollment godoc
+//
+//	@Summary		Enrollment of an employee
+//	@Description	Tells whether the employee can enroll today, through an open window or their new hire period, and which active plans they are eligible for
+//	@Tags			Elections
+//	@Produce		json
+//	@Param			id	path		int					true	"Employee ID"
+//	@Success		200	{object}	models.Enrollment	"Enrollment"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid employee ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Employee not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Failure		503	{object}	httpkit.ErrorResponse	"Employee service unavailable"
+//	@Router			/employees/{id}/enrollment [get]
+func (h *BenefitsHandler) GetEnrollment(c *gin.Context) {
+	id, ok := pathID(c, "Invalid employee ID")
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.service.Enrollment(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, employees.ErrNotFound):
+			httpkit.NotFound(c, "Employee not found")
+		case errors.Is(err, employees.ErrUnavailable):
+			httpkit.Error(c, http.StatusServiceUnavailable, "Employee service unavailable")
+		default:
+			httpkit.InternalServerError(c, "Failed to check enrollment")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// Elect godoc
+//
+//	@Summary		Elect a plan
+//	@Description	Records the coverage chosen for a plan, or WAIVED to decline it. A new election in the same window or new hire period replaces the previous one
+//	@Tags			Elections
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Employee ID"
+//	@Param			election	body		ElectionRequest		true	"Plan and coverage"
+//	@Success		201			{object}	models.Election		"Election recorded"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid employee ID, JSON format or coverage"
+//	@Failure		404			{object}	httpkit.ErrorResponse	"Employee or plan not found"
+//	@Failure		409			{object}	httpkit.ErrorResponse	"Not eligible, plan inactive or no enrollment period"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Failure		503			{object}	httpkit.ErrorResponse	"Employee service unavailable"
+//	@Router			/employees/{id}/elections [post]
+func (h *BenefitsHandler) Elect(c *gin.Context) {
+	id, ok := pathID(c, "Invalid employee ID")
+	if !ok {
+		return
+	}
+
+	var req ElectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+	switch {
+	case req.PlanID < 1:
+		httpkit.BadRequest(c, "Plan ID is required")
+		return
+	case !req.Coverage.Valid():
+		httpkit.BadRequest(c, "Coverage must be EMPLOYEE, EMPLOYEE_SPOUSE, EMPLOYEE_CHILDREN, FAMILY or WAIVED")
+		return
+	}
+
+	election := models.Election{EmployeeID: id, PlanID: req.PlanID, Coverage: req.Coverage}
+	if err := h.service.Elect(c.Request.Context(), &election); err != nil {
+		switch {
+		case errors.Is(err, employees.ErrNotFound):
+			httpkit.NotFound(c, "Employee not found")
+		case errors.Is(err, repository.ErrPlanNotFound):
+			httpkit.NotFound(c, "Plan not found")
+		case errors.Is(err, service.ErrNotEligible),
+			errors.Is(err, service.ErrPlanInactive),
+			errors.Is(err, service.ErrNoEnrollmentPeriod):
+			httpkit.Error(c, http.StatusConflict, err.Error())
+		case errors.Is(err, employees.ErrUnavailable):
+			httpkit.Error(c, http.StatusServiceUnavailable, "Employee service unavailable")
+		default:
+			httpkit.InternalServerError(c, "Failed to record election")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, election)
+}
+
+// GetElections godoc
+//
+//	@Summary		Elections of an employee
+//	@Description	Retrieves the latest election of the employee for each plan
+//	@Tags			Elections
+//	@Produce		json
+//	@Param			id	path		int					true	"Employee ID"
+//	@Success		200	{array}		models.Election		"Elections"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid employee ID"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/employees/{id}/elections [get]
+func (h *BenefitsHandler) GetElections(c *gin.Context) {
+	id, ok := pathID(c, "Invalid employee ID")
+	if !ok {
+		return
+	}
+
+	elections, err := h.service.FindElections(c.Request.Context(), id)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve elections")
+		return
+	}
+
+	c.JSON(http.StatusOK, elections)
+}
@@ -0,0 +1,271 @@
+// Package handlers exposes the benefits service over HTTP
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"benefits-service/internal/models"
+	"benefits-service/internal/repository"
+	"benefits-service/internal/service"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// BenefitsHandler handles HTTP requests for plans, enrollment windows and
+// elections
+type BenefitsHandler struct {
+	service *service.BenefitsService
+}
+
+// NewBenefitsHandler creates a new BenefitsHandler instance
+func NewBenefitsHandler(s *service.BenefitsService) *BenefitsHandler {
+	return &BenefitsHandler{service: s}
+}
+
+// PlanRequest is the payload to add a plan
+type PlanRequest struct {
+	Name        string          `json:"name" example:"Health PPO"`
+	Kind        models.PlanKind `json:"kind" example:"HEALTH"`
+	Description string          `json:"description"`
+	// EligibleStatuses defaults to ACTIVE
+	EligibleStatuses []string `json:"eligibleStatuses" example:"ACTIVE,ON_VACATION"`
+	WaitingDays      int      `json:"waitingDays" example:"90"`
+}
+
+// WindowRequest is the payload to add an enrollment window
+type WindowRequest struct {
+	Name     string `json:"name" example:"Open enrollment 2027"`
+	OpensOn  string `json:"opensOn" example:"2026-11-01"`
+	ClosesOn string `json:"closesOn" example:"2026-11-30"`
+}
+
+// CreatePlan godoc
+//
+//	@Summary		Add a plan
+//	@Description	Adds a benefit plan open to employees in one of the eligible statuses once waitingDays have passed since their hire date
+//	@Tags			Plans
+//	@Accept			json
+//	@Produce		json
+//	@Param			plan	body		PlanRequest			true	"Plan data"
+//	@Success		201		{object}	models.Plan			"Plan created"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid JSON format or validation failed"
+//	@Failure		409		{object}	httpkit.ErrorResponse	"Plan name already exists"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/plans [post]
+func (h *BenefitsHandler) CreatePlan(c *gin.Context) {
+	var req PlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	plan := models.Plan{
+		Name:             strings.TrimSpace(req.Name),
+		Kind:             req.Kind,
+		Description:      strings.TrimSpace(req.Description),
+		EligibleStatuses: req.EligibleStatuses,
+		WaitingDays:      req.WaitingDays,
+	}
+	if len(plan.EligibleStatuses) == 0 {
+		plan.EligibleStatuses = []string{employees.StatusActive}
+	}
+	for _, status := range plan.EligibleStatuses {
+		switch status {
+		case employees.StatusActive, employees.StatusProbation, employees.StatusOnVacation, employees.StatusRetired:
+		default:
+			httpkit.BadRequest(c, "Eligible statuses must be ACTIVE, PROBATION, ON_VACATION or RETIRED")
+			return
+		}
+	}
+	switch {
+	case plan.Name == "":
+		httpkit.BadRequest(c, "Name is required")
+		return
+	case !plan.Kind.Valid():
+		httpkit.BadRequest(c, "Kind must be HEALTH, DENTAL, VISION, LIFE, RETIREMENT or OTHER")
+		return
+	case plan.WaitingDays < 0:
+		httpkit.BadRequest(c, "Waiting days cannot be negative")
+		return
+	}
+
+	if err := h.service.CreatePlan(c.Request.Context(), &plan); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrPlanExists):
+			httpkit.Error(c, http.StatusConflict, "Plan name already exists")
+		default:
+			httpkit.InternalServerError(c, "Failed to create plan")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, plan)
+}
+
+// GetAllPlans godoc
+//
+//	@Summary		List plans
+//	@Description	Retrieves the plans by name, only the active ones unless all is set
+//	@Tags			Plans
+//	@Produce		json
+//	@Param			all	query		bool				false	"Include deactivated plans"
+//	@Success		200	{array}		models.Plan			"Plans"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/plans [get]
+func (h *BenefitsHandler) GetAllPlans(c *gin.Context) {
+	all, _ := strconv.ParseBool(c.Query("all"))
+
+	plans, err := h.service.FindPlans(c.Request.Context(), !all)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve plans")
+		return
+	}
+
+	c.JSON(http.StatusOK, plans)
+}
+
+// GetPlanByID godoc
+//
+//	@Summary		Get a plan
+//	@Description	Retrieves a plan by its ID
+//	@Tags			Plans
+//	@Produce		json
+//	@Param			id	path		int					true	"Plan ID"
+//	@Success		200	{object}	models.Plan			"Plan"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid plan ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Plan not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/plans/{id} [get]
+func (h *BenefitsHandler) GetPlanByID(c *gin.Context) {
+	id, ok := pathID(c, "Invalid plan ID")
+	if !ok {
+		return
+	}
+
+	plan, err := h.service.FindPlan(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrPlanNotFound):
+			httpkit.NotFound(c, "Plan not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve plan")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// DeactivatePlan godoc
+//
+//	@Summary		Deactivate a plan
+//	@Description	Stops offering a plan, elections already made are kept
+//	@Tags			Plans
+//	@Param			id	path	int	true	"Plan ID"
+//	@Success		204	"Plan deactivated"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid plan ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Plan not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/plans/{id}/deactivate [post]
+func (h *BenefitsHandler) DeactivatePlan(c *gin.Context) {
+	id, ok := pathID(c, "Invalid plan ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeactivatePlan(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrPlanNotFound):
+			httpkit.NotFound(c, "Plan not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to deactivate plan")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateWindow godoc
+//
+//	@Summary		Add an enrollment window
+//	@Description	Adds a period, both dates included, in which every eligible employee can change their elections. Windows cannot overlap
+//	@Tags			Windows
+//	@Accept			json
+//	@Produce		json
+//	@Param			window	body		WindowRequest		true	"Window data"
+//	@Success		201		{object}	models.Window		"Window created"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid JSON format or validation failed"
+//	@Failure		409		{object}	httpkit.ErrorResponse	"Window overlaps another window"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/windows [post]
+func (h *BenefitsHandler) CreateWindow(c *gin.Context) {
+	var req WindowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	window := models.Window{Name: strings.TrimSpace(req.Name), OpensOn: req.OpensOn, ClosesOn: req.ClosesOn}
+	opens, opensErr := time.Parse(time.DateOnly, window.OpensOn)
+	closes, closesErr := time.Parse(time.DateOnly, window.ClosesOn)
+	switch {
+	case window.Name == "":
+		httpkit.BadRequest(c, "Name is required")
+		return
+	case opensErr != nil || closesErr != nil:
+		httpkit.BadRequest(c, "Opens on and closes on must be YYYY-MM-DD dates")
+		return
+	case closes.Before(opens):
+		httpkit.BadRequest(c, "Closes on cannot be before opens on")
+		return
+	}
+
+	if err := h.service.CreateWindow(c.Request.Context(), &window); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrWindowOverlap):
+			httpkit.Error(c, http.StatusConflict, "Window overlaps another window")
+		default:
+			httpkit.InternalServerError(c, "Failed to create window")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, window)
+}
+
+// GetAllWindows godoc
+//
+//	@Summary		List enrollment windows
+//	@Description	Retrieves the enrollment windows, latest first
+//	@Tags			Windows
+//	@Produce		json
+//	@Success		200	{array}		models.Window		"Windows"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/windows [get]
+func (h *BenefitsHandler) GetAllWindows(c *gin.Context) {
+	windows, err := h.service.FindWindows(c.Request.Context())
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve windows")
+		return
+	}
+
+	c.JSON(http.StatusOK, windows)
+}
+
+// pathID parses the id path parameter, answering 400 with message when it
+// is invalid
+func pathID(c *gin.Context, message string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		httpkit.BadRequest(c, message)
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,103 @@
+// Package models define the core data structures of the benefits service
+package models
+
+import "time"
+
+// PlanKind is the type of benefit a plan provides
+type PlanKind string
+
+const (
+	KindHealth     PlanKind = "HEALTH"
+	KindDental     PlanKind = "DENTAL"
+	KindVision     PlanKind = "VISION"
+	KindLife       PlanKind = "LIFE"
+	KindRetirement PlanKind = "RETIREMENT"
+	KindOther      PlanKind = "OTHER"
+)
+
+// Valid reports whether k is a known kind
+func (k PlanKind) Valid() bool {
+	switch k {
+	case KindHealth, KindDental, KindVision, KindLife, KindRetirement, KindOther:
+		return true
+	}
+	return false
+}
+
+// Plan is a benefit employees can enroll in
+type Plan struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name" example:"Health PPO"`
+	Kind        PlanKind `json:"kind" example:"HEALTH"`
+	Description string   `json:"description"`
+	// EligibleStatuses are the employment statuses that may enroll
+	EligibleStatuses []string `json:"eligibleStatuses" example:"ACTIVE,ON_VACATION"`
+	// WaitingDays is how long after the hire date the plan opens
+	WaitingDays int       `json:"waitingDays" example:"90"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Window is a period every eligible employee can change their elections
+type Window struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name" example:"Open enrollment 2027"`
+	OpensOn   string    `json:"opensOn" example:"2026-11-01"`
+	ClosesOn  string    `json:"closesOn" example:"2026-11-30"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Coverage is who an election covers, or WAIVED to decline the plan
+type Coverage string
+
+const (
+	CoverageEmployee         Coverage = "EMPLOYEE"
+	CoverageEmployeeSpouse   Coverage = "EMPLOYEE_SPOUSE"
+	CoverageEmployeeChildren Coverage = "EMPLOYEE_CHILDREN"
+	CoverageFamily           Coverage = "FAMILY"
+	CoverageWaived           Coverage = "WAIVED"
+)
+
+// Valid reports whether c is a known coverage
+func (c Coverage) Valid() bool {
+	switch c {
+	case CoverageEmployee, CoverageEmployeeSpouse, CoverageEmployeeChildren, CoverageFamily, CoverageWaived:
+		return true
+	}
+	return false
+}
+
+// Election is the choice of an employee for a plan in an enrollment period
+type Election struct {
+	ID         int64  `json:"id"`
+	EmployeeID int64  `json:"employeeId"`
+	PlanID     int64  `json:"planId"`
+	PlanName   string `json:"planName"`
+	// WindowID is the enrollment window, empty for new hire elections
+	WindowID  *int64    `json:"windowId,omitempty"`
+	Coverage  Coverage  `json:"coverage" example:"FAMILY"`
+	ElectedAt time.Time `json:"electedAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// PlanEligibility tells whether an employee may enroll in a plan
+type PlanEligibility struct {
+	PlanID   int64  `json:"planId"`
+	PlanName string `json:"planName"`
+	Eligible bool   `json:"eligible"`
+	// Reason explains why the employee is not eligible
+	Reason string `json:"reason,omitempty"`
+	// EligibleOn is when the waiting period ends, if that is the reason
+	EligibleOn *string `json:"eligibleOn,omitempty" example:"2026-06-01"`
+}
+
+// Enrollment is what an employee can elect today
+type Enrollment struct {
+	EmployeeID int64 `json:"employeeId"`
+	// Window is the enrollment window open today, if any
+	Window *Window `json:"window,omitempty"`
+	// NewHireUntil is the last day of the new hire period, if in it
+	NewHireUntil *string           `json:"newHireUntil,omitempty" example:"2026-04-01"`
+	CanEnroll    bool              `json:"canEnroll"`
+	Plans        []PlanEligibility `json:"plans"`
+}
@@ -0,0 +1,277 @@
+// Package repository implements the data access layer of the benefits service
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"benefits-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Errors returned by BenefitsRepository
+var (
+	ErrPlanNotFound  = errors.New("plan not found")
+	ErrPlanExists    = errors.New("plan name already exists")
+	ErrWindowOverlap = errors.New("enrollment window overlaps another window")
+)
+
+// BenefitsRepository defines the interface for benefits data operations
+type BenefitsRepository interface {
+	CreatePlan(ctx context.Context, p *models.Plan) error
+	FindPlan(ctx context.Context, id int64) (*models.Plan, error)
+	// FindPlans retrieves the plans by name, only the active ones with
+	// activeOnly set
+	FindPlans(ctx context.Context, activeOnly bool) ([]models.Plan, error)
+	// DeactivatePlan closes a plan to new elections
+	DeactivatePlan(ctx context.Context, id int64) error
+
+	// CreateWindow adds a window that does not overlap the existing ones
+	CreateWindow(ctx context.Context, w *models.Window) error
+	FindWindows(ctx context.Context) ([]models.Window, error)
+	// FindOpenWindow returns the window open on day, nil if there is none
+	FindOpenWindow(ctx context.Context, day string) (*models.Window, error)
+
+	// Elect records the election, replacing the one the employee made for
+	// the plan in the same window or new hire period
+	Elect(ctx context.Context, e *models.Election) error
+	// FindElections retrieves the latest election of the employee per plan
+	FindElections(ctx context.Context, employeeID int64) ([]models.Election, error)
+}
+
+// benefitsRepository is the postgresql implementation of BenefitsRepository
+type benefitsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewBenefitsRepository creates a new instance of BenefitsRepository
+func NewBenefitsRepository(db *pgxpool.Pool) BenefitsRepository {
+	return &benefitsRepository{db: db}
+}
+
+// planColumns are the columns scanned by scanPlan
+const planColumns = `id, name, kind, description, eligible_statuses, waiting_days, active, created_at`
+
+// scanPlan scans a row selected with planColumns
+func scanPlan(row pgx.Row) (models.Plan, error) {
+	var p models.Plan
+	err := row.Scan(&p.ID, &p.Name, &p.Kind, &p.Description, &p.EligibleStatuses, &p.WaitingDays, &p.Active, &p.CreatedAt)
+	return p, err
+}
+
+// windowColumns are the columns scanned by scanWindow
+const windowColumns = `id, name, to_char(opens_on, 'YYYY-MM-DD'), to_char(closes_on, 'YYYY-MM-DD'), created_at`
+
+// scanWindow scans a row selected with windowColumns
+func scanWindow(row pgx.Row) (models.Window, error) {
+	var w models.Window
+	err := row.Scan(&w.ID, &w.Name, &w.OpensOn, &w.ClosesOn, &w.CreatedAt)
+	return w, err
+}
+
+// CreatePlan inserts an active plan
+func (r *benefitsRepository) CreatePlan(ctx context.Context, p *models.Plan) error {
+	query := `
+        INSERT INTO benefits.plans (name, kind, description, eligible_statuses, waiting_days)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, active, created_at
+    `
+
+	err := r.db.QueryRow(ctx, query, p.Name, p.Kind, p.Description, p.EligibleStatuses, p.WaitingDays).Scan(&p.ID, &p.Active, &p.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrPlanExists
+		}
+		return fmt.Errorf("failed to create plan: %w", err)
+	}
+
+	return nil
+}
+
+// FindPlan retrieves a plan
+func (r *benefitsRepository) FindPlan(ctx context.Context, id int64) (*models.Plan, error) {
+	p, err := scanPlan(r.db.QueryRow(ctx, `SELECT `+planColumns+` FROM benefits.plans WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPlanNotFound
+		}
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// FindPlans retrieves the plans by name
+func (r *benefitsRepository) FindPlans(ctx context.Context, activeOnly bool) ([]models.Plan, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+planColumns+`
+        FROM benefits.plans
+        WHERE active OR NOT $1
+        ORDER BY name`, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query plans: %w", err)
+	}
+	defer rows.Close()
+
+	plans := []models.Plan{}
+	for rows.Next() {
+		p, err := scanPlan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan plan row: %w", err)
+		}
+		plans = append(plans, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating plan rows: %w", err)
+	}
+
+	return plans, nil
+}
+
+// DeactivatePlan sets the plan inactive, existing elections are kept
+func (r *benefitsRepository) DeactivatePlan(ctx context.Context, id int64) error {
+	result, err := r.db.Exec(ctx, `UPDATE benefits.plans SET active = FALSE WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate plan: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrPlanNotFound
+	}
+
+	return nil
+}
+
+// CreateWindow locks the windows table so two overlapping windows cannot
+// be added at once
+func (r *benefitsRepository) CreateWindow(ctx context.Context, w *models.Window) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `LOCK TABLE benefits.enrollment_windows IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			return fmt.Errorf("failed to lock enrollment windows: %w", err)
+		}
+
+		var overlap bool
+		err := tx.QueryRow(ctx, `
+            SELECT EXISTS (
+                SELECT 1 FROM benefits.enrollment_windows
+                WHERE opens_on <= $2::date AND closes_on >= $1::date
+            )`, w.OpensOn, w.ClosesOn).Scan(&overlap)
+		if err != nil {
+			return fmt.Errorf("failed to check enrollment windows: %w", err)
+		}
+		if overlap {
+			return ErrWindowOverlap
+		}
+
+		created, err := scanWindow(tx.QueryRow(ctx, `
+            INSERT INTO benefits.enrollment_windows (name, opens_on, closes_on)
+            VALUES ($1, $2::date, $3::date)
+            RETURNING `+windowColumns, w.Name, w.OpensOn, w.ClosesOn))
+		if err != nil {
+			return fmt.Errorf("failed to create enrollment window: %w", err)
+		}
+
+		*w = created
+		return nil
+	})
+}
+
+// FindWindows retrieves the enrollment windows, latest first
+func (r *benefitsRepository) FindWindows(ctx context.Context) ([]models.Window, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+windowColumns+` FROM benefits.enrollment_windows ORDER BY opens_on DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query enrollment windows: %w", err)
+	}
+	defer rows.Close()
+
+	windows := []models.Window{}
+	for rows.Next() {
+		w, err := scanWindow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan enrollment window row: %w", err)
+		}
+		windows = append(windows, w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating enrollment window rows: %w", err)
+	}
+
+	return windows, nil
+}
+
+// FindOpenWindow retrieves the window whose dates include day
+func (r *benefitsRepository) FindOpenWindow(ctx context.Context, day string) (*models.Window, error) {
+	w, err := scanWindow(r.db.QueryRow(ctx, `SELECT `+windowColumns+`
+        FROM benefits.enrollment_windows
+        WHERE $1::date BETWEEN opens_on AND closes_on`, day))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// Elect upserts the election of the period
+func (r *benefitsRepository) Elect(ctx context.Context, e *models.Election) error {
+	query := `
+        WITH election AS (
+            INSERT INTO benefits.elections (employee_id, plan_id, window_id, coverage)
+            VALUES ($1, $2, $3, $4)
+            ON CONFLICT (employee_id, plan_id, COALESCE(window_id, 0))
+            DO UPDATE SET coverage = EXCLUDED.coverage, updated_at = CURRENT_TIMESTAMP
+            RETURNING id, plan_id, elected_at, updated_at
+        )
+        SELECT e.id, p.name, e.elected_at, e.updated_at
+        FROM election e
+        JOIN benefits.plans p ON p.id = e.plan_id
+    `
+
+	err := r.db.QueryRow(ctx, query, e.EmployeeID, e.PlanID, e.WindowID, e.Coverage).Scan(&e.ID, &e.PlanName, &e.ElectedAt, &e.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrPlanNotFound
+		}
+		return fmt.Errorf("failed to record election: %w", err)
+	}
+
+	return nil
+}
+
+// FindElections retrieves the most recent election per plan
+func (r *benefitsRepository) FindElections(ctx context.Context, employeeID int64) ([]models.Election, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT DISTINCT ON (e.plan_id) e.id, e.employee_id, e.plan_id, p.name, e.window_id, e.coverage, e.elected_at, e.updated_at
+        FROM benefits.elections e
+        JOIN benefits.plans p ON p.id = e.plan_id
+        WHERE e.employee_id = $1
+        ORDER BY e.plan_id, e.elected_at DESC, e.id DESC`, employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query elections: %w", err)
+	}
+	defer rows.Close()
+
+	elections := []models.Election{}
+	for rows.Next() {
+		var e models.Election
+		if err := rows.Scan(&e.ID, &e.EmployeeID, &e.PlanID, &e.PlanName, &e.WindowID, &e.Coverage, &e.ElectedAt, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan election row: %w", err)
+		}
+		elections = append(elections, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating election rows: %w", err)
+	}
+
+	return elections, nil
+}
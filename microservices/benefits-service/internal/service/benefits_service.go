@@ -0,0 +1,193 @@
+// Package service contains the business logic of the benefits service
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"benefits-service/internal/models"
+	"benefits-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+)
+
+var (
+	// ErrPlanInactive is returned when electing a deactivated plan
+	ErrPlanInactive = errors.New("plan is no longer offered")
+	// ErrNotEligible is returned when the employee may not enroll in the
+	// plan, the error message gives the reason
+	ErrNotEligible = errors.New("employee is not eligible for the plan")
+	// ErrNoEnrollmentPeriod is returned when no window is open and the
+	// employee is past the new hire period
+	ErrNoEnrollmentPeriod = errors.New("no enrollment window is open and the new hire period is over")
+)
+
+// BenefitsService manages the plans, the enrollment windows and the
+// elections of employees
+type BenefitsService struct {
+	repo        repository.BenefitsRepository
+	employees   *employees.Client
+	newHireDays int
+}
+
+// NewBenefitsService creates a new BenefitsService instance
+// newHireDays is how long after the hire date employees can enroll outside
+// of a window
+func NewBenefitsService(repo repository.BenefitsRepository, employeeClient *employees.Client, newHireDays int) *BenefitsService {
+	return &BenefitsService{repo: repo, employees: employeeClient, newHireDays: newHireDays}
+}
+
+// CreatePlan adds a plan
+func (s *BenefitsService) CreatePlan(ctx context.Context, p *models.Plan) error {
+	return s.repo.CreatePlan(ctx, p)
+}
+
+// FindPlan retrieves a plan
+func (s *BenefitsService) FindPlan(ctx context.Context, id int64) (*models.Plan, error) {
+	return s.repo.FindPlan(ctx, id)
+}
+
+// FindPlans retrieves the plans, only the active ones with activeOnly set
+func (s *BenefitsService) FindPlans(ctx context.Context, activeOnly bool) ([]models.Plan, error) {
+	return s.repo.FindPlans(ctx, activeOnly)
+}
+
+// DeactivatePlan stops offering a plan
+func (s *BenefitsService) DeactivatePlan(ctx context.Context, id int64) error {
+	return s.repo.DeactivatePlan(ctx, id)
+}
+
+// CreateWindow adds an enrollment window
+func (s *BenefitsService) CreateWindow(ctx context.Context, w *models.Window) error {
+	return s.repo.CreateWindow(ctx, w)
+}
+
+// FindWindows retrieves the enrollment windows
+func (s *BenefitsService) FindWindows(ctx context.Context) ([]models.Window, error) {
+	return s.repo.FindWindows(ctx)
+}
+
+// FindElections retrieves the current elections of an employee
+func (s *BenefitsService) FindElections(ctx context.Context, employeeID int64) ([]models.Election, error) {
+	return s.repo.FindElections(ctx, employeeID)
+}
+
+// Enrollment tells whether the employee can enroll today and in which of
+// the active plans, from their status and hire date in employee-management
+func (s *BenefitsService) Enrollment(ctx context.Context, employeeID int64) (*models.Enrollment, error) {
+	employee, hired, err := s.employee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := today()
+	enrollment := &models.Enrollment{EmployeeID: employeeID, Plans: []models.PlanEligibility{}}
+
+	enrollment.Window, err = s.repo.FindOpenWindow(ctx, today.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	if until := s.newHireUntil(hired); !today.After(until) {
+		day := until.Format(time.DateOnly)
+		enrollment.NewHireUntil = &day
+	}
+	enrollment.CanEnroll = enrollment.Window != nil || enrollment.NewHireUntil != nil
+
+	plans, err := s.repo.FindPlans(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range plans {
+		enrollment.Plans = append(enrollment.Plans, eligibility(&p, employee, hired, today))
+	}
+
+	return enrollment, nil
+}
+
+// Elect records the coverage the employee chose for a plan. The plan must
+// be active, the employee eligible for it, and either an enrollment
+// window open or the employee in their new hire period
+func (s *BenefitsService) Elect(ctx context.Context, e *models.Election) error {
+	employee, hired, err := s.employee(ctx, e.EmployeeID)
+	if err != nil {
+		return err
+	}
+
+	plan, err := s.repo.FindPlan(ctx, e.PlanID)
+	if err != nil {
+		return err
+	}
+	if !plan.Active {
+		return ErrPlanInactive
+	}
+
+	today := today()
+	if pe := eligibility(plan, employee, hired, today); !pe.Eligible {
+		return fmt.Errorf("%w: %s", ErrNotEligible, pe.Reason)
+	}
+
+	window, err := s.repo.FindOpenWindow(ctx, today.Format(time.DateOnly))
+	if err != nil {
+		return err
+	}
+	switch {
+	case window != nil:
+		e.WindowID = &window.ID
+	case !today.After(s.newHireUntil(hired)):
+		e.WindowID = nil
+	default:
+		return ErrNoEnrollmentPeriod
+	}
+
+	return s.repo.Elect(ctx, e)
+}
+
+// employee fetches the employee and parses their hire date
+func (s *BenefitsService) employee(ctx context.Context, id int64) (*employees.Employee, time.Time, error) {
+	employee, err := s.employees.Get(ctx, id)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	hired, err := time.Parse(time.DateOnly, employee.HireDate)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%w: invalid hire date %q", employees.ErrUnavailable, employee.HireDate)
+	}
+	return employee, hired, nil
+}
+
+// newHireUntil is the last day of the new hire period
+func (s *BenefitsService) newHireUntil(hired time.Time) time.Time {
+	return hired.AddDate(0, 0, s.newHireDays)
+}
+
+// eligibility applies the rules of the plan to the employee: their status
+// must be one of the eligible ones and the waiting period over
+func eligibility(p *models.Plan, employee *employees.Employee, hired, today time.Time) models.PlanEligibility {
+	pe := models.PlanEligibility{PlanID: p.ID, PlanName: p.Name}
+
+	if !slices.Contains(p.EligibleStatuses, employee.Status) {
+		pe.Reason = fmt.Sprintf("employment status %s is not eligible", employee.Status)
+		return pe
+	}
+
+	if eligibleOn := hired.AddDate(0, 0, p.WaitingDays); today.Before(eligibleOn) {
+		day := eligibleOn.Format(time.DateOnly)
+		pe.Reason = fmt.Sprintf("waiting period ends on %s", day)
+		pe.EligibleOn = &day
+		return pe
+	}
+
+	pe.Eligible = true
+	return pe
+}
+
+// today returns the current date at midnight UTC, comparable with the
+// dates parsed from employee-management
+func today() time.Time {
+	t, _ := time.Parse(time.DateOnly, time.Now().Format(time.DateOnly))
+	return t
+}
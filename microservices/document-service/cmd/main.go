@@ -0,0 +1,88 @@
+package main
+
+//	@title			Document Service API
+//	@version		1.0
+//	@description	Versioned documents with retention classes and access grants, linked to the records of other services
+//	@termsOfService	http://swagger.io/terms/
+
+//	@contact.name	API Support
+//	@contact.email	josed.amayar@uqvirtual.edu.co
+
+//	@host		localhost:8089
+//	@BasePath	/document-service/api/v1
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"document-service/internal/config"
+	"document-service/internal/db"
+	"document-service/internal/handlers"
+	"document-service/internal/repository"
+	"document-service/internal/retention"
+	"document-service/internal/service"
+
+	_ "document-service/docs" // Swagger docs
+
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+func main() {
+	cfg := config.Load()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbPool := db.NewPostgresPool(cfg)
+	defer dbPool.Close()
+
+	if cfg.MigrateOnStartup {
+		if err := db.Migrate(ctx, dbPool); err != nil {
+			log.Fatalf("database migration failed: %v", err)
+		}
+	}
+
+	repo := repository.NewDocumentRepository(dbPool)
+	documentService := service.NewDocumentService(repo)
+
+	go retention.New(repo, cfg.PurgeInterval).Run(ctx)
+
+	handler := handlers.NewDocumentHandler(documentService, cfg.DocumentMaxSize, envconfig.SplitList(cfg.AdminRoles), cfg.AnonymousAccess)
+	healthHandler := httpkit.NewHealthHandler("document-service", dbPool)
+
+	router := httpkit.NewRouter()
+
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	v1 := router.Group("/document-service/api/v1")
+	{
+		v1.GET("/health", healthHandler.HealthCheck)
+
+		v1.POST("/documents", handler.CreateDocument)
+		v1.GET("/documents", handler.GetAllDocuments)
+		v1.GET("/documents/:id", handler.GetDocumentByID)
+		v1.DELETE("/documents/:id", handler.DeleteDocument)
+		v1.POST("/documents/:id/versions", handler.AddVersion)
+		v1.GET("/documents/:id/content", handler.GetContent)
+		v1.PUT("/documents/:id/grants", handler.PutGrant)
+		v1.DELETE("/documents/:id/grants/:principal", handler.DeleteGrant)
+	}
+
+	srv := &http.Server{Addr: ":" + cfg.ServerPort, Handler: router}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	log.Printf("Document service running on :%s", cfg.ServerPort)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
@@ -0,0 +1,785 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "email": "josed.amayar@uqvirtual.edu.co"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/documents": {
+            "get": {
+                "description": "Retrieves the documents the caller can read, newest first, optionally those linked to a record",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "List documents",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service owning the linked record",
+                        "name": "ownerService",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Linked record",
+                        "name": "ownerRef",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Documents",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httpkit.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Document"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing identity",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Stores a new document owned by the caller, with the file as version 1. ownerService and ownerRef link it to a record of another service",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Upload a document",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Document file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Title",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "TEMPORARY",
+                            "STANDARD",
+                            "PERMANENT"
+                        ],
+                        "type": "string",
+                        "description": "Retention class",
+                        "name": "retentionClass",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service owning the linked record",
+                        "name": "ownerService",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Linked record, e.g. expense/42",
+                        "name": "ownerRef",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Document stored",
+                        "schema": {
+                            "$ref": "#/definitions/models.Document"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing file or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing identity",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/{id}": {
+            "get": {
+                "description": "Retrieves a document with its versions and grants",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Get a document",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Document ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Document",
+                        "schema": {
+                            "$ref": "#/definitions/models.Document"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid document ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing identity",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Document not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a document with all its versions once its retention has passed. Only the creator and admins can",
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Delete a document",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Document ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Document deleted"
+                    },
+                    "400": {
+                        "description": "Invalid document ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing identity",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed on this document",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Document not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Document is under retention",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/{id}/content": {
+            "get": {
+                "description": "Returns the file of the current version, or of the version given",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Versions"
+                ],
+                "summary": "Download a document",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Document ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version, the current one by default",
+                        "name": "version",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Document file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid document ID or version",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing identity",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Document or version not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/{id}/grants": {
+            "put": {
+                "description": "Gives a user (user:\u003cid\u003e) or a role (role:\u003cname\u003e) READ or WRITE access, replacing its previous grant. Only the creator and admins can",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Grants"
+                ],
+                "summary": "Grant access",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Document ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Principal and permission",
+                        "name": "grant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GrantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Access granted",
+                        "schema": {
+                            "$ref": "#/definitions/models.Grant"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid document ID, principal or permission",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing identity",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed on this document",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Document not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/{id}/grants/{principal}": {
+            "delete": {
+                "description": "Removes the grant of a principal. Only the creator and admins can",
+                "tags": [
+                    "Grants"
+                ],
+                "summary": "Revoke access",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Document ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Principal, e.g. role:payroll",
+                        "name": "principal",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Access revoked"
+                    },
+                    "400": {
+                        "description": "Invalid document ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing identity",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed on this document",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Document or grant not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/{id}/versions": {
+            "post": {
+                "description": "Stores the file as the next version of the document, the caller needs WRITE",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Versions"
+                ],
+                "summary": "Upload a new version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Document ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Document file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Version stored",
+                        "schema": {
+                            "$ref": "#/definitions/models.Version"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid document ID or missing file",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing identity",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed on this document",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Document not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.GrantRequest": {
+            "type": "object",
+            "properties": {
+                "permission": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Permission"
+                        }
+                    ],
+                    "example": "READ"
+                },
+                "principal": {
+                    "type": "string",
+                    "example": "role:payroll"
+                }
+            }
+        },
+        "httpkit.ErrorResponse": {
+            "description": "Standard error response structure",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpkit.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "pagination": {
+                    "$ref": "#/definitions/httpkit.PaginationMeta"
+                }
+            }
+        },
+        "httpkit.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                },
+                "total_records": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Document": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "currentVersion": {
+                    "type": "integer"
+                },
+                "grants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Grant"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ownerRef": {
+                    "type": "string",
+                    "example": "expense/42"
+                },
+                "ownerService": {
+                    "description": "OwnerService and OwnerRef identify the record the document belongs\nto, e.g. expense-service and expense/42",
+                    "type": "string",
+                    "example": "expense-service"
+                },
+                "retainUntil": {
+                    "description": "RetainUntil is when the document may be deleted, empty if never",
+                    "type": "string"
+                },
+                "retentionClass": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RetentionClass"
+                        }
+                    ],
+                    "example": "STANDARD"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Signed contract"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "versions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Version"
+                    }
+                }
+            }
+        },
+        "models.Grant": {
+            "type": "object",
+            "properties": {
+                "grantedAt": {
+                    "type": "string"
+                },
+                "grantedBy": {
+                    "type": "string"
+                },
+                "permission": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Permission"
+                        }
+                    ],
+                    "example": "READ"
+                },
+                "principal": {
+                    "description": "Principal is user:\u003cid\u003e or role:\u003cname\u003e",
+                    "type": "string",
+                    "example": "role:payroll"
+                }
+            }
+        },
+        "models.Permission": {
+            "type": "string",
+            "enum": [
+                "",
+                "READ",
+                "WRITE",
+                "MANAGE"
+            ],
+            "x-enum-varnames": [
+                "PermissionNone",
+                "PermissionRead",
+                "PermissionWrite",
+                "PermissionManage"
+            ]
+        },
+        "models.RetentionClass": {
+            "type": "string",
+            "enum": [
+                "TEMPORARY",
+                "STANDARD",
+                "PERMANENT"
+            ],
+            "x-enum-varnames": [
+                "RetentionTemporary",
+                "RetentionStandard",
+                "RetentionPermanent"
+            ]
+        },
+        "models.Version": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "fileName": {
+                    "type": "string",
+                    "example": "contract.pdf"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "uploadedAt": {
+                    "type": "string"
+                },
+                "uploadedBy": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        }
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:8089",
+	BasePath:         "/document-service/api/v1",
+	Schemes:          []string{},
+	Title:            "Document Service API",
+	Description:      "Versioned documents with retention classes and access grants, linked to the records of other services",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
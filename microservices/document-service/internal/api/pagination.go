@@ -0,0 +1,11 @@
+// Package api holds the query parameters of the list endpoints
+package api
+
+import "github.com/Josedzzz/microservices-fp/pkg/httpkit"
+
+// PaginationQuery represents the query parameters of the document list
+type PaginationQuery struct {
+	httpkit.PageQuery
+	OwnerService string `form:"ownerService"`
+	OwnerRef     string `form:"ownerRef"`
+}
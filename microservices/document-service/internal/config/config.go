@@ -0,0 +1,164 @@
+// Package config loads the document service configuration from
+// defaults, a YAML file, env variables and CLI flags, in that order
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"github.com/joho/godotenv"
+)
+
+// Config holds configuration loaded from defaults, file, env and flags
+type Config struct {
+	ServerPort string `yaml:"server_port"`
+
+	DBHost     string `yaml:"db_host"`
+	DBPort     string `yaml:"db_port"`
+	DBName     string `yaml:"db_name"`
+	DBUser     string `yaml:"db_user"`
+	DBPassword string `yaml:"db_password"`
+	DBSSLMode  string `yaml:"db_sslmode"`
+	DBMaxConns int    `yaml:"db_max_conns"`
+
+	DBRetryMaxWait time.Duration `yaml:"db_retry_max_wait"`
+
+	MigrateOnStartup bool `yaml:"migrate_on_startup"`
+
+	// DocumentMaxSize is the largest file accepted, in bytes
+	DocumentMaxSize int64 `yaml:"document_max_size"`
+
+	// AdminRoles are the roles, forwarded by the gateway, with access to
+	// every document
+	AdminRoles string `yaml:"admin_roles"`
+	// AnonymousAccess treats requests without an identity as admin, for
+	// setups without gateway auth
+	AnonymousAccess bool `yaml:"anonymous_access"`
+
+	// PurgeInterval is how often documents past their retention are deleted
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+// options lists every setting that can be overridden by env or flags
+var options = []envconfig.Option[Config]{
+	{Env: "SERVER_PORT", Flag: "port", Usage: "HTTP server port", Set: envconfig.String(func(c *Config) *string { return &c.ServerPort })},
+	{Env: "DB_HOST", Flag: "db-host", Usage: "database host", Set: envconfig.String(func(c *Config) *string { return &c.DBHost })},
+	{Env: "DB_PORT", Flag: "db-port", Usage: "database port", Set: envconfig.String(func(c *Config) *string { return &c.DBPort })},
+	{Env: "DB_NAME", Flag: "db-name", Usage: "database name", Set: envconfig.String(func(c *Config) *string { return &c.DBName })},
+	{Env: "DB_USER", Flag: "db-user", Usage: "database user", Set: envconfig.String(func(c *Config) *string { return &c.DBUser })},
+	{Env: "DB_PASSWORD", Flag: "db-password", Usage: "database password", Set: envconfig.String(func(c *Config) *string { return &c.DBPassword })},
+	{Env: "DB_SSL_MODE", Flag: "db-sslmode", Usage: "database sslmode", Set: envconfig.String(func(c *Config) *string { return &c.DBSSLMode })},
+	{Env: "DB_MAX_CONNS", Flag: "db-max-conns", Usage: "maximum open db connections", Set: envconfig.Int(func(c *Config) *int { return &c.DBMaxConns })},
+	{Env: "DB_RETRY_MAX_WAIT", Flag: "db-retry-max-wait", Usage: "how long to wait for the db at startup", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DBRetryMaxWait })},
+	{Env: "MIGRATE_ON_STARTUP", Flag: "migrate-on-startup", Usage: "apply pending migrations at startup", Set: envconfig.Bool(func(c *Config) *bool { return &c.MigrateOnStartup })},
+	{Env: "DOCUMENT_MAX_SIZE", Flag: "document-max-size", Usage: "largest file accepted in bytes", Set: setInt64(func(c *Config) *int64 { return &c.DocumentMaxSize })},
+	{Env: "ADMIN_ROLES", Flag: "admin-roles", Usage: "comma separated roles with access to every document", Set: envconfig.String(func(c *Config) *string { return &c.AdminRoles })},
+	{Env: "ANONYMOUS_ACCESS", Flag: "anonymous-access", Usage: "treat requests without identity as admin", Set: envconfig.Bool(func(c *Config) *bool { return &c.AnonymousAccess })},
+	{Env: "PURGE_INTERVAL", Flag: "purge-interval", Usage: "how often expired documents are deleted", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.PurgeInterval })},
+}
+
+// Load reads the .env file, then builds the config from the process args
+// Exits if the config is invalid
+func Load() *Config {
+	_ = godotenv.Load()
+
+	cfg, err := load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	return cfg
+}
+
+// load builds and validates the config from the given CLI args
+func load(args []string) (*Config, error) {
+	cfg := defaults()
+	fs := flag.NewFlagSet("document-service", flag.ContinueOnError)
+	if err := envconfig.Load(fs, args, cfg, options); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// defaults returns the config used when nothing else is set
+func defaults() *Config {
+	return &Config{
+		ServerPort: "8089",
+
+		DBHost:     "localhost",
+		DBPort:     "5432",
+		DBName:     "documents",
+		DBUser:     "document_user",
+		DBSSLMode:  "disable",
+		DBMaxConns: 5,
+
+		DBRetryMaxWait: time.Minute,
+
+		MigrateOnStartup: true,
+
+		DocumentMaxSize: 25 << 20,
+
+		AdminRoles: "hr-admin",
+
+		PurgeInterval: time.Hour,
+	}
+}
+
+// Validate checks the final config and returns every problem found
+func (c *Config) Validate() error {
+	var errs []error
+
+	if err := envconfig.ValidatePort(c.ServerPort); err != nil {
+		errs = append(errs, fmt.Errorf("server port: %w", err))
+	}
+	if err := envconfig.ValidatePort(c.DBPort); err != nil {
+		errs = append(errs, fmt.Errorf("db port: %w", err))
+	}
+	if c.DBMaxConns < 1 {
+		errs = append(errs, errors.New("db max conns must be at least 1"))
+	}
+	if c.DocumentMaxSize < 1 {
+		errs = append(errs, errors.New("document max size must be positive"))
+	}
+	if c.PurgeInterval <= 0 {
+		errs = append(errs, errors.New("purge interval must be positive"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// DatabaseURL creates the connection url to the db
+func (c *Config) DatabaseURL() string {
+	return fmt.Sprintf(
+		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
+		c.DBUser,
+		c.DBPassword,
+		c.DBHost,
+		c.DBPort,
+		c.DBName,
+		c.DBSSLMode,
+	)
+}
+
+// setInt64 returns a setter that parses the value as an int64
+func setInt64(field func(c *Config) *int64) func(c *Config, val string) error {
+	return func(c *Config, val string) error {
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return err
+		}
+		*field(c) = n
+		return nil
+	}
+}
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS documents.documents (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	title VARCHAR(255) NOT NULL,
+	owner_service VARCHAR(100) NOT NULL DEFAULT '',
+	owner_ref VARCHAR(255) NOT NULL DEFAULT '',
+	retention_class VARCHAR(20) NOT NULL,
+	retain_until TIMESTAMPTZ,
+	current_version INTEGER NOT NULL DEFAULT 1,
+	created_by VARCHAR(255) NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS documents_owner_idx ON documents.documents (owner_service, owner_ref);
+CREATE INDEX IF NOT EXISTS documents_retain_until_idx ON documents.documents (retain_until) WHERE retain_until IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS documents.versions (
+	document_id BIGINT NOT NULL REFERENCES documents.documents (id) ON DELETE CASCADE,
+	version INTEGER NOT NULL,
+	file_name VARCHAR(255) NOT NULL,
+	content_type VARCHAR(100) NOT NULL,
+	size BIGINT NOT NULL,
+	sha256 CHAR(64) NOT NULL,
+	content BYTEA NOT NULL,
+	uploaded_by VARCHAR(255) NOT NULL,
+	uploaded_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (document_id, version)
+);
+
+-- principal is user:<id> or role:<name>
+CREATE TABLE IF NOT EXISTS documents.grants (
+	document_id BIGINT NOT NULL REFERENCES documents.documents (id) ON DELETE CASCADE,
+	principal VARCHAR(255) NOT NULL,
+	permission VARCHAR(10) NOT NULL,
+	granted_by VARCHAR(255) NOT NULL,
+	granted_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (document_id, principal)
+);
+
+CREATE INDEX IF NOT EXISTS grants_principal_idx ON documents.grants (principal);
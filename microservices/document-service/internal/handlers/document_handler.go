@@ -0,0 +1,461 @@
+// Package handlers exposes the document service over HTTP
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"document-service/internal/api"
+	"document-service/internal/models"
+	"document-service/internal/repository"
+	"document-service/internal/service"
+
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// Identity headers set by the gateway from the bearer token
+const (
+	userHeader  = "X-User-ID"
+	rolesHeader = "X-User-Roles"
+)
+
+// DocumentHandler handles HTTP requests for documents, their versions and
+// grants
+type DocumentHandler struct {
+	service    *service.DocumentService
+	maxSize    int64
+	adminRoles []string
+	anonymous  bool
+}
+
+// NewDocumentHandler creates a new DocumentHandler instance
+// adminRoles can access every document, and with anonymous set requests
+// without identity are treated as admin
+func NewDocumentHandler(s *service.DocumentService, maxSize int64, adminRoles []string, anonymous bool) *DocumentHandler {
+	return &DocumentHandler{service: s, maxSize: maxSize, adminRoles: adminRoles, anonymous: anonymous}
+}
+
+// GrantRequest is the payload to give access to a document
+type GrantRequest struct {
+	Principal  string            `json:"principal" example:"role:payroll"`
+	Permission models.Permission `json:"permission" example:"READ"`
+}
+
+// CreateDocument godoc
+//
+//	@Summary		Upload a document
+//	@Description	Stores a new document owned by the caller, with the file as version 1. ownerService and ownerRef link it to a record of another service
+//	@Tags			Documents
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file			formData	file				true	"Document file"
+//	@Param			title			formData	string				true	"Title"
+//	@Param			retentionClass	formData	string				true	"Retention class"	Enums(TEMPORARY, STANDARD, PERMANENT)
+//	@Param			ownerService	formData	string				false	"Service owning the linked record"
+//	@Param			ownerRef		formData	string				false	"Linked record, e.g. expense/42"
+//	@Success		201				{object}	models.Document		"Document stored"
+//	@Failure		400				{object}	httpkit.ErrorResponse	"Missing file or validation failed"
+//	@Failure		401				{object}	httpkit.ErrorResponse	"Missing identity"
+//	@Failure		413				{object}	httpkit.ErrorResponse	"File too large"
+//	@Failure		500				{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/documents [post]
+func (h *DocumentHandler) CreateDocument(c *gin.Context) {
+	caller, ok := h.identity(c)
+	if !ok {
+		return
+	}
+
+	file, ok := h.file(c)
+	if !ok {
+		return
+	}
+
+	document := models.Document{
+		Title:          strings.TrimSpace(c.PostForm("title")),
+		OwnerService:   strings.TrimSpace(c.PostForm("ownerService")),
+		OwnerRef:       strings.TrimSpace(c.PostForm("ownerRef")),
+		RetentionClass: models.RetentionClass(c.PostForm("retentionClass")),
+	}
+	switch {
+	case document.Title == "":
+		httpkit.BadRequest(c, "Title is required")
+		return
+	case !document.RetentionClass.Valid():
+		httpkit.BadRequest(c, "Retention class must be TEMPORARY, STANDARD or PERMANENT")
+		return
+	case (document.OwnerService == "") != (document.OwnerRef == ""):
+		httpkit.BadRequest(c, "Owner service and owner ref go together")
+		return
+	}
+
+	if err := h.service.Create(c.Request.Context(), caller, &document, file); err != nil {
+		httpkit.InternalServerError(c, "Failed to store document")
+		return
+	}
+
+	c.JSON(http.StatusCreated, document)
+}
+
+// GetAllDocuments godoc
+//
+//	@Summary		List documents
+//	@Description	Retrieves the documents the caller can read, newest first, optionally those linked to a record
+//	@Tags			Documents
+//	@Produce		json
+//	@Param			page			query		int					false	"Page number"	default(1)
+//	@Param			page_size		query		int					false	"Page size"		default(20)
+//	@Param			ownerService	query		string				false	"Service owning the linked record"
+//	@Param			ownerRef		query		string				false	"Linked record"
+//	@Success		200				{object}	httpkit.PaginatedResponse{data=[]models.Document}	"Documents"
+//	@Failure		400				{object}	httpkit.ErrorResponse	"Invalid query parameters"
+//	@Failure		401				{object}	httpkit.ErrorResponse	"Missing identity"
+//	@Failure		500				{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/documents [get]
+func (h *DocumentHandler) GetAllDocuments(c *gin.Context) {
+	caller, ok := h.identity(c)
+	if !ok {
+		return
+	}
+
+	var query api.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpkit.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	query.Normalize()
+
+	filter := repository.DocumentFilter{OwnerService: query.OwnerService, OwnerRef: query.OwnerRef}
+	documents, total, err := h.service.FindAll(c.Request.Context(), caller, filter, query.Page, query.PageSize)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve documents")
+		return
+	}
+
+	c.JSON(http.StatusOK, httpkit.Paginate(documents, query.PageQuery, total))
+}
+
+// GetDocumentByID godoc
+//
+//	@Summary		Get a document
+//	@Description	Retrieves a document with its versions and grants
+//	@Tags			Documents
+//	@Produce		json
+//	@Param			id	path		int					true	"Document ID"
+//	@Success		200	{object}	models.Document		"Document"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid document ID"
+//	@Failure		401	{object}	httpkit.ErrorResponse	"Missing identity"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Document not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/documents/{id} [get]
+func (h *DocumentHandler) GetDocumentByID(c *gin.Context) {
+	caller, ok := h.identity(c)
+	if !ok {
+		return
+	}
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	document, err := h.service.Find(c.Request.Context(), caller, id)
+	if err != nil {
+		h.fail(c, err, "Failed to retrieve document")
+		return
+	}
+
+	c.JSON(http.StatusOK, document)
+}
+
+// DeleteDocument godoc
+//
+//	@Summary		Delete a document
+//	@Description	Deletes a document with all its versions once its retention has passed. Only the creator and admins can
+//	@Tags			Documents
+//	@Param			id	path	int	true	"Document ID"
+//	@Success		204	"Document deleted"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid document ID"
+//	@Failure		401	{object}	httpkit.ErrorResponse	"Missing identity"
+//	@Failure		403	{object}	httpkit.ErrorResponse	"Not allowed on this document"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Document not found"
+//	@Failure		409	{object}	httpkit.ErrorResponse	"Document is under retention"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/documents/{id} [delete]
+func (h *DocumentHandler) DeleteDocument(c *gin.Context) {
+	caller, ok := h.identity(c)
+	if !ok {
+		return
+	}
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), caller, id); err != nil {
+		h.fail(c, err, "Failed to delete document")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddVersion godoc
+//
+//	@Summary		Upload a new version
+//	@Description	Stores the file as the next version of the document, the caller needs WRITE
+//	@Tags			Versions
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		int					true	"Document ID"
+//	@Param			file	formData	file				true	"Document file"
+//	@Success		201		{object}	models.Version		"Version stored"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid document ID or missing file"
+//	@Failure		401		{object}	httpkit.ErrorResponse	"Missing identity"
+//	@Failure		403		{object}	httpkit.ErrorResponse	"Not allowed on this document"
+//	@Failure		404		{object}	httpkit.ErrorResponse	"Document not found"
+//	@Failure		413		{object}	httpkit.ErrorResponse	"File too large"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/documents/{id}/versions [post]
+func (h *DocumentHandler) AddVersion(c *gin.Context) {
+	caller, ok := h.identity(c)
+	if !ok {
+		return
+	}
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	file, ok := h.file(c)
+	if !ok {
+		return
+	}
+
+	version, err := h.service.AddVersion(c.Request.Context(), caller, id, file)
+	if err != nil {
+		h.fail(c, err, "Failed to store version")
+		return
+	}
+
+	c.JSON(http.StatusCreated, version)
+}
+
+// GetContent godoc
+//
+//	@Summary		Download a document
+//	@Description	Returns the file of the current version, or of the version given
+//	@Tags			Versions
+//	@Produce		octet-stream
+//	@Param			id		path		int					true	"Document ID"
+//	@Param			version	query		int					false	"Version, the current one by default"
+//	@Success		200		{file}		file				"Document file"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid document ID or version"
+//	@Failure		401		{object}	httpkit.ErrorResponse	"Missing identity"
+//	@Failure		404		{object}	httpkit.ErrorResponse	"Document or version not found"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/documents/{id}/content [get]
+func (h *DocumentHandler) GetContent(c *gin.Context) {
+	caller, ok := h.identity(c)
+	if !ok {
+		return
+	}
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	version := 0
+	if raw := c.Query("version"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			httpkit.BadRequest(c, "Invalid version")
+			return
+		}
+		version = n
+	}
+
+	v, content, err := h.service.Content(c.Request.Context(), caller, id, version)
+	if err != nil {
+		h.fail(c, err, "Failed to retrieve document")
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+strings.ReplaceAll(v.FileName, `"`, "")+`"`)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("ETag", `"`+v.SHA256+`"`)
+	c.Data(http.StatusOK, v.ContentType, content)
+}
+
+// PutGrant godoc
+//
+//	@Summary		Grant access
+//	@Description	Gives a user (user:<id>) or a role (role:<name>) READ or WRITE access, replacing its previous grant. Only the creator and admins can
+//	@Tags			Grants
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"Document ID"
+//	@Param			grant	body		GrantRequest		true	"Principal and permission"
+//	@Success		200		{object}	models.Grant		"Access granted"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid document ID, principal or permission"
+//	@Failure		401		{object}	httpkit.ErrorResponse	"Missing identity"
+//	@Failure		403		{object}	httpkit.ErrorResponse	"Not allowed on this document"
+//	@Failure		404		{object}	httpkit.ErrorResponse	"Document not found"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/documents/{id}/grants [put]
+func (h *DocumentHandler) PutGrant(c *gin.Context) {
+	caller, ok := h.identity(c)
+	if !ok {
+		return
+	}
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	var req GrantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+	switch {
+	case !models.ValidPrincipal(req.Principal):
+		httpkit.BadRequest(c, "Principal must be user:<id> or role:<name>")
+		return
+	case req.Permission != models.PermissionRead && req.Permission != models.PermissionWrite:
+		httpkit.BadRequest(c, "Permission must be READ or WRITE")
+		return
+	}
+
+	grant := models.Grant{Principal: req.Principal, Permission: req.Permission}
+	if err := h.service.Grant(c.Request.Context(), caller, id, &grant); err != nil {
+		h.fail(c, err, "Failed to grant access")
+		return
+	}
+
+	c.JSON(http.StatusOK, grant)
+}
+
+// DeleteGrant godoc
+//
+//	@Summary		Revoke access
+//	@Description	Removes the grant of a principal. Only the creator and admins can
+//	@Tags			Grants
+//	@Param			id			path	int		true	"Document ID"
+//	@Param			principal	path	string	true	"Principal, e.g. role:payroll"
+//	@Success		204	"Access revoked"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid document ID"
+//	@Failure		401	{object}	httpkit.ErrorResponse	"Missing identity"
+//	@Failure		403	{object}	httpkit.ErrorResponse	"Not allowed on this document"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Document or grant not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/documents/{id}/grants/{principal} [delete]
+func (h *DocumentHandler) DeleteGrant(c *gin.Context) {
+	caller, ok := h.identity(c)
+	if !ok {
+		return
+	}
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Revoke(c.Request.Context(), caller, id, c.Param("principal")); err != nil {
+		h.fail(c, err, "Failed to revoke access")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// identity reads the caller from the gateway headers, answering 401 when
+// there is none and anonymous access is off
+func (h *DocumentHandler) identity(c *gin.Context) (models.Identity, bool) {
+	userID := c.GetHeader(userHeader)
+	if userID == "" {
+		if h.anonymous {
+			return models.Identity{UserID: "anonymous", Admin: true}, true
+		}
+		httpkit.Error(c, http.StatusUnauthorized, "Missing identity")
+		return models.Identity{}, false
+	}
+
+	caller := models.Identity{UserID: userID}
+	for _, role := range strings.Split(c.GetHeader(rolesHeader), ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			caller.Roles = append(caller.Roles, role)
+			caller.Admin = caller.Admin || slices.Contains(h.adminRoles, role)
+		}
+	}
+	return caller, true
+}
+
+// file reads the multipart field file, answering 400 or 413 on failure
+func (h *DocumentHandler) file(c *gin.Context) (service.File, bool) {
+	// Leave room for the multipart framing and the other fields
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize+1<<20)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			httpkit.Error(c, http.StatusRequestEntityTooLarge, "File too large")
+			return service.File{}, false
+		}
+		httpkit.BadRequest(c, "File is required")
+		return service.File{}, false
+	}
+	if header.Size > h.maxSize {
+		httpkit.Error(c, http.StatusRequestEntityTooLarge, "File too large")
+		return service.File{}, false
+	}
+
+	f, err := header.Open()
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to read file")
+		return service.File{}, false
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to read file")
+		return service.File{}, false
+	}
+
+	return service.File{
+		Name:        filepath.Base(header.Filename),
+		ContentType: header.Header.Get("Content-Type"),
+		Content:     content,
+	}, true
+}
+
+// fail maps the service errors shared by the document endpoints
+func (h *DocumentHandler) fail(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrDocumentNotFound):
+		httpkit.NotFound(c, "Document not found")
+	case errors.Is(err, repository.ErrVersionNotFound):
+		httpkit.NotFound(c, "Version not found")
+	case errors.Is(err, repository.ErrGrantNotFound):
+		httpkit.NotFound(c, "Grant not found")
+	case errors.Is(err, service.ErrForbidden):
+		httpkit.Error(c, http.StatusForbidden, "Not allowed on this document")
+	case errors.Is(err, service.ErrUnderRetention):
+		httpkit.Error(c, http.StatusConflict, "Document is under retention")
+	default:
+		httpkit.InternalServerError(c, message)
+	}
+}
+
+// pathID parses the id path parameter, answering 400 when it is invalid
+func pathID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		httpkit.BadRequest(c, "Invalid document ID")
+		return 0, false
+	}
+	return id, true
+}
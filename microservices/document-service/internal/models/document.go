@@ -0,0 +1,143 @@
+// Package models define the core data structures of the document service
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// RetentionClass is how long a document must be kept
+type RetentionClass string
+
+const (
+	// RetentionTemporary documents are kept 90 days
+	RetentionTemporary RetentionClass = "TEMPORARY"
+	// RetentionStandard documents are kept 7 years, e.g. payroll records
+	RetentionStandard RetentionClass = "STANDARD"
+	// RetentionPermanent documents are never deleted
+	RetentionPermanent RetentionClass = "PERMANENT"
+)
+
+// Valid reports whether r is a known retention class
+func (r RetentionClass) Valid() bool {
+	switch r {
+	case RetentionTemporary, RetentionStandard, RetentionPermanent:
+		return true
+	}
+	return false
+}
+
+// RetainUntil is when a document created at t may be deleted, nil for
+// documents kept forever
+func (r RetentionClass) RetainUntil(t time.Time) *time.Time {
+	var until time.Time
+	switch r {
+	case RetentionTemporary:
+		until = t.AddDate(0, 0, 90)
+	case RetentionStandard:
+		until = t.AddDate(7, 0, 0)
+	default:
+		return nil
+	}
+	return &until
+}
+
+// Permission is what a grant allows on a document
+type Permission string
+
+const (
+	PermissionNone  Permission = ""
+	PermissionRead  Permission = "READ"
+	PermissionWrite Permission = "WRITE"
+	// PermissionManage is held by the creator and admins: grants, deletion
+	PermissionManage Permission = "MANAGE"
+)
+
+// rank orders the permissions, each one includes the previous ones
+var rank = map[Permission]int{PermissionNone: 0, PermissionRead: 1, PermissionWrite: 2, PermissionManage: 3}
+
+// Allows reports whether p includes want
+func (p Permission) Allows(want Permission) bool {
+	return rank[p] >= rank[want]
+}
+
+// Document is a file other services link to by id or by owner reference
+type Document struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title" example:"Signed contract"`
+	// OwnerService and OwnerRef identify the record the document belongs
+	// to, e.g. expense-service and expense/42
+	OwnerService   string         `json:"ownerService,omitempty" example:"expense-service"`
+	OwnerRef       string         `json:"ownerRef,omitempty" example:"expense/42"`
+	RetentionClass RetentionClass `json:"retentionClass" example:"STANDARD"`
+	// RetainUntil is when the document may be deleted, empty if never
+	RetainUntil    *time.Time `json:"retainUntil,omitempty"`
+	CurrentVersion int        `json:"currentVersion"`
+	CreatedBy      string     `json:"createdBy"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+	Versions       []Version  `json:"versions,omitempty"`
+	Grants         []Grant    `json:"grants,omitempty"`
+}
+
+// Version is one upload of a document, the content is downloaded
+// separately
+type Version struct {
+	Version     int       `json:"version"`
+	FileName    string    `json:"fileName" example:"contract.pdf"`
+	ContentType string    `json:"contentType" example:"application/pdf"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	UploadedBy  string    `json:"uploadedBy"`
+	UploadedAt  time.Time `json:"uploadedAt"`
+}
+
+// Grant gives a user or a role access to a document
+type Grant struct {
+	// Principal is user:<id> or role:<name>
+	Principal  string     `json:"principal" example:"role:payroll"`
+	Permission Permission `json:"permission" example:"READ"`
+	GrantedBy  string     `json:"grantedBy"`
+	GrantedAt  time.Time  `json:"grantedAt"`
+}
+
+// Identity is the caller as forwarded by the gateway
+type Identity struct {
+	UserID string
+	Roles  []string
+	// Admin callers can access every document
+	Admin bool
+}
+
+// Principals are the grant principals matching the caller
+func (i Identity) Principals() []string {
+	principals := []string{"user:" + i.UserID}
+	for _, role := range i.Roles {
+		principals = append(principals, "role:"+role)
+	}
+	return principals
+}
+
+// Permission returns what the caller can do with the document: everything
+// as admin or creator, else the highest of their grants
+func (d *Document) Permission(caller Identity) Permission {
+	if caller.Admin || (caller.UserID != "" && d.CreatedBy == caller.UserID) {
+		return PermissionManage
+	}
+
+	principals := caller.Principals()
+	best := PermissionNone
+	for _, g := range d.Grants {
+		if slices.Contains(principals, g.Principal) && !best.Allows(g.Permission) {
+			best = g.Permission
+		}
+	}
+	return best
+}
+
+// ValidPrincipal reports whether p is user:<id> or role:<name>
+func ValidPrincipal(p string) bool {
+	kind, name, ok := strings.Cut(p, ":")
+	return ok && name != "" && (kind == "user" || kind == "role")
+}
@@ -0,0 +1,316 @@
+// Package repository implements the data access layer of the document service
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"document-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Errors returned by DocumentRepository
+var (
+	ErrDocumentNotFound = errors.New("document not found")
+	ErrVersionNotFound  = errors.New("document version not found")
+	ErrGrantNotFound    = errors.New("grant not found")
+)
+
+// DocumentFilter narrows FindAll, zero values match everything
+type DocumentFilter struct {
+	OwnerService string
+	OwnerRef     string
+}
+
+// DocumentRepository defines the interface for document data operations
+type DocumentRepository interface {
+	// Create stores the document with v as its first version
+	Create(ctx context.Context, d *models.Document, v *models.Version, content []byte) error
+	// Find retrieves a document with its versions and grants
+	Find(ctx context.Context, id int64) (*models.Document, error)
+	// FindAll retrieves a page of the documents the caller can read
+	FindAll(ctx context.Context, filter DocumentFilter, caller models.Identity, limit, offset int) ([]models.Document, int, error)
+	Delete(ctx context.Context, id int64) error
+
+	// AddVersion stores v as the next version of the document
+	AddVersion(ctx context.Context, id int64, v *models.Version, content []byte) error
+	// FindContent retrieves a version with its content, the current one
+	// when version is 0
+	FindContent(ctx context.Context, id int64, version int) (*models.Version, []byte, error)
+
+	// Grant gives or changes the permission of a principal
+	Grant(ctx context.Context, id int64, g *models.Grant) error
+	Revoke(ctx context.Context, id int64, principal string) error
+
+	// PurgeExpired deletes the documents past their retention
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// documentRepository is the postgresql implementation of DocumentRepository
+type documentRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDocumentRepository creates a new instance of DocumentRepository
+func NewDocumentRepository(db *pgxpool.Pool) DocumentRepository {
+	return &documentRepository{db: db}
+}
+
+// documentColumns are the columns scanned by scanDocument
+const documentColumns = `
+        id, title, owner_service, owner_ref, retention_class, retain_until,
+        current_version, created_by, created_at, updated_at
+    `
+
+// scanDocument scans a row selected with documentColumns
+func scanDocument(row pgx.Row) (models.Document, error) {
+	var d models.Document
+	err := row.Scan(
+		&d.ID, &d.Title, &d.OwnerService, &d.OwnerRef, &d.RetentionClass, &d.RetainUntil,
+		&d.CurrentVersion, &d.CreatedBy, &d.CreatedAt, &d.UpdatedAt,
+	)
+	return d, err
+}
+
+// versionColumns are the columns scanned by scanVersion
+const versionColumns = `version, file_name, content_type, size, sha256, uploaded_by, uploaded_at`
+
+// scanVersion scans a row selected with versionColumns
+func scanVersion(row pgx.Row) (models.Version, error) {
+	var v models.Version
+	err := row.Scan(&v.Version, &v.FileName, &v.ContentType, &v.Size, &v.SHA256, &v.UploadedBy, &v.UploadedAt)
+	return v, err
+}
+
+// Create inserts the document and its first version in one transaction
+func (r *documentRepository) Create(ctx context.Context, d *models.Document, v *models.Version, content []byte) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		created, err := scanDocument(tx.QueryRow(ctx, `
+            INSERT INTO documents.documents (title, owner_service, owner_ref, retention_class, retain_until, created_by)
+            VALUES ($1, $2, $3, $4, $5, $6)
+            RETURNING `+documentColumns,
+			d.Title, d.OwnerService, d.OwnerRef, d.RetentionClass, d.RetainUntil, d.CreatedBy))
+		if err != nil {
+			return fmt.Errorf("failed to create document: %w", err)
+		}
+
+		v.Version = 1
+		if err := insertVersion(ctx, tx, created.ID, v, content); err != nil {
+			return err
+		}
+
+		*d = created
+		d.Versions = []models.Version{*v}
+		return nil
+	})
+}
+
+// Find retrieves a document and the metadata of its versions, newest
+// first, and its grants
+func (r *documentRepository) Find(ctx context.Context, id int64) (*models.Document, error) {
+	d, err := scanDocument(r.db.QueryRow(ctx, `SELECT `+documentColumns+` FROM documents.documents WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDocumentNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+versionColumns+`
+        FROM documents.versions
+        WHERE document_id = $1
+        ORDER BY version DESC`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query versions: %w", err)
+	}
+	d.Versions, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Version, error) {
+		return scanVersion(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan version rows: %w", err)
+	}
+
+	rows, err = r.db.Query(ctx, `
+        SELECT principal, permission, granted_by, granted_at
+        FROM documents.grants
+        WHERE document_id = $1
+        ORDER BY principal`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query grants: %w", err)
+	}
+	d.Grants, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Grant, error) {
+		var g models.Grant
+		err := row.Scan(&g.Principal, &g.Permission, &g.GrantedBy, &g.GrantedAt)
+		return g, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan grant rows: %w", err)
+	}
+
+	return &d, nil
+}
+
+// FindAll retrieves a page of documents, newest first, with the total
+// count. Non admin callers only see what they created or were granted
+func (r *documentRepository) FindAll(ctx context.Context, filter DocumentFilter, caller models.Identity, limit, offset int) ([]models.Document, int, error) {
+	where := `
+        WHERE ($1 = '' OR owner_service = $1) AND ($2 = '' OR owner_ref = $2)
+          AND ($3 OR created_by = $4 OR EXISTS (
+              SELECT 1 FROM documents.grants g WHERE g.document_id = d.id AND g.principal = ANY($5)
+          ))`
+	args := []any{filter.OwnerService, filter.OwnerRef, caller.Admin, caller.UserID, caller.Principals()}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM documents.documents d `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+documentColumns+` FROM documents.documents d `+where+`
+        ORDER BY created_at DESC, id DESC
+        LIMIT $6 OFFSET $7`, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query documents: %w", err)
+	}
+	defer rows.Close()
+
+	documents := []models.Document{}
+	for rows.Next() {
+		d, err := scanDocument(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan document row: %w", err)
+		}
+		documents = append(documents, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating document rows: %w", err)
+	}
+
+	return documents, total, nil
+}
+
+// Delete removes the document with its versions and grants
+func (r *documentRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM documents.documents WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrDocumentNotFound
+	}
+
+	return nil
+}
+
+// AddVersion locks the document so concurrent uploads get distinct
+// version numbers
+func (r *documentRepository) AddVersion(ctx context.Context, id int64, v *models.Version, content []byte) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var current int
+		err := tx.QueryRow(ctx, `SELECT current_version FROM documents.documents WHERE id = $1 FOR UPDATE`, id).Scan(&current)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrDocumentNotFound
+			}
+			return fmt.Errorf("failed to lock document: %w", err)
+		}
+
+		v.Version = current + 1
+		if err := insertVersion(ctx, tx, id, v, content); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+            UPDATE documents.documents SET current_version = $2, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1`, id, v.Version)
+		if err != nil {
+			return fmt.Errorf("failed to update document: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindContent retrieves a version of the document with its content
+func (r *documentRepository) FindContent(ctx context.Context, id int64, version int) (*models.Version, []byte, error) {
+	var content []byte
+	row := r.db.QueryRow(ctx, `
+        SELECT v.version, v.file_name, v.content_type, v.size, v.sha256, v.uploaded_by, v.uploaded_at, v.content
+        FROM documents.versions v
+        JOIN documents.documents d ON d.id = v.document_id
+        WHERE v.document_id = $1 AND v.version = CASE WHEN $2 = 0 THEN d.current_version ELSE $2 END`, id, version)
+
+	var v models.Version
+	err := row.Scan(&v.Version, &v.FileName, &v.ContentType, &v.Size, &v.SHA256, &v.UploadedBy, &v.UploadedAt, &content)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrVersionNotFound
+		}
+		return nil, nil, err
+	}
+
+	return &v, content, nil
+}
+
+// Grant upserts the grant of the principal
+func (r *documentRepository) Grant(ctx context.Context, id int64, g *models.Grant) error {
+	query := `
+        INSERT INTO documents.grants (document_id, principal, permission, granted_by)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (document_id, principal)
+        DO UPDATE SET permission = EXCLUDED.permission, granted_by = EXCLUDED.granted_by, granted_at = CURRENT_TIMESTAMP
+        RETURNING granted_at
+    `
+
+	err := r.db.QueryRow(ctx, query, id, g.Principal, g.Permission, g.GrantedBy).Scan(&g.GrantedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrDocumentNotFound
+		}
+		return fmt.Errorf("failed to grant access: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke removes the grant of the principal
+func (r *documentRepository) Revoke(ctx context.Context, id int64, principal string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM documents.grants WHERE document_id = $1 AND principal = $2`, id, principal)
+	if err != nil {
+		return fmt.Errorf("failed to revoke access: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrGrantNotFound
+	}
+
+	return nil
+}
+
+// PurgeExpired deletes every document whose retention has passed
+func (r *documentRepository) PurgeExpired(ctx context.Context) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM documents.documents WHERE retain_until <= CURRENT_TIMESTAMP`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge documents: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// insertVersion stores a version of the document
+func insertVersion(ctx context.Context, tx pgx.Tx, id int64, v *models.Version, content []byte) error {
+	err := tx.QueryRow(ctx, `
+        INSERT INTO documents.versions (document_id, version, file_name, content_type, size, sha256, content, uploaded_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING uploaded_at`,
+		id, v.Version, v.FileName, v.ContentType, v.Size, v.SHA256, content, v.UploadedBy).Scan(&v.UploadedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store version: %w", err)
+	}
+	return nil
+}
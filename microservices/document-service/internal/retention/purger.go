@@ -0,0 +1,44 @@
+// Package retention deletes the documents whose retention has passed
+package retention
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"document-service/internal/repository"
+)
+
+// Purger periodically deletes expired documents with all their versions
+type Purger struct {
+	repo     repository.DocumentRepository
+	interval time.Duration
+}
+
+// New creates a new Purger
+func New(repo repository.DocumentRepository, interval time.Duration) *Purger {
+	return &Purger{repo: repo, interval: interval}
+}
+
+// Run purges every interval until ctx is done
+func (p *Purger) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		purged, err := p.repo.PurgeExpired(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("retention purge failed: %v", err)
+		case purged > 0:
+			log.Printf("retention purge: deleted %d documents", purged)
+		}
+		timer.Reset(p.interval)
+	}
+}
@@ -0,0 +1,152 @@
+// Package service contains the business logic of the document service
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"document-service/internal/models"
+	"document-service/internal/repository"
+)
+
+var (
+	// ErrForbidden is returned when the caller can read the document but
+	// not make the change
+	ErrForbidden = errors.New("not allowed on this document")
+	// ErrUnderRetention is returned when deleting a document that must
+	// still be kept
+	ErrUnderRetention = errors.New("document is under retention")
+)
+
+// DocumentService stores versioned documents and enforces their grants and
+// retention
+type DocumentService struct {
+	repo repository.DocumentRepository
+}
+
+// NewDocumentService creates a new DocumentService instance
+func NewDocumentService(repo repository.DocumentRepository) *DocumentService {
+	return &DocumentService{repo: repo}
+}
+
+// File is an uploaded file
+type File struct {
+	Name string
+	// ContentType is the type declared by the client, sniffed when empty
+	ContentType string
+	Content     []byte
+}
+
+// Create stores a new document owned by the caller with file as version 1
+func (s *DocumentService) Create(ctx context.Context, caller models.Identity, d *models.Document, file File) error {
+	now := time.Now()
+	d.CreatedBy = caller.UserID
+	d.RetainUntil = d.RetentionClass.RetainUntil(now)
+
+	v := newVersion(caller, file)
+	return s.repo.Create(ctx, d, &v, file.Content)
+}
+
+// Find retrieves a document the caller can read. Documents the caller
+// cannot read are reported as not found
+func (s *DocumentService) Find(ctx context.Context, caller models.Identity, id int64) (*models.Document, error) {
+	d, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !d.Permission(caller).Allows(models.PermissionRead) {
+		return nil, repository.ErrDocumentNotFound
+	}
+	return d, nil
+}
+
+// FindAll retrieves a page of the documents the caller can read
+func (s *DocumentService) FindAll(ctx context.Context, caller models.Identity, filter repository.DocumentFilter, page, pageSize int) ([]models.Document, int, error) {
+	return s.repo.FindAll(ctx, filter, caller, pageSize, (page-1)*pageSize)
+}
+
+// AddVersion uploads a new version, the caller needs WRITE
+func (s *DocumentService) AddVersion(ctx context.Context, caller models.Identity, id int64, file File) (*models.Version, error) {
+	if _, err := s.authorize(ctx, caller, id, models.PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	v := newVersion(caller, file)
+	if err := s.repo.AddVersion(ctx, id, &v, file.Content); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// Content retrieves a version with its content, the current one when
+// version is 0
+func (s *DocumentService) Content(ctx context.Context, caller models.Identity, id int64, version int) (*models.Version, []byte, error) {
+	if _, err := s.authorize(ctx, caller, id, models.PermissionRead); err != nil {
+		return nil, nil, err
+	}
+	return s.repo.FindContent(ctx, id, version)
+}
+
+// Grant gives a user or role access, only the creator and admins can
+func (s *DocumentService) Grant(ctx context.Context, caller models.Identity, id int64, g *models.Grant) error {
+	if _, err := s.authorize(ctx, caller, id, models.PermissionManage); err != nil {
+		return err
+	}
+
+	g.GrantedBy = caller.UserID
+	return s.repo.Grant(ctx, id, g)
+}
+
+// Revoke removes a grant, only the creator and admins can
+func (s *DocumentService) Revoke(ctx context.Context, caller models.Identity, id int64, principal string) error {
+	if _, err := s.authorize(ctx, caller, id, models.PermissionManage); err != nil {
+		return err
+	}
+	return s.repo.Revoke(ctx, id, principal)
+}
+
+// Delete removes a document whose retention has passed, only the creator
+// and admins can
+func (s *DocumentService) Delete(ctx context.Context, caller models.Identity, id int64) error {
+	d, err := s.authorize(ctx, caller, id, models.PermissionManage)
+	if err != nil {
+		return err
+	}
+	if d.RetainUntil == nil || time.Now().Before(*d.RetainUntil) {
+		return ErrUnderRetention
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// authorize loads the document and checks the caller holds want
+func (s *DocumentService) authorize(ctx context.Context, caller models.Identity, id int64, want models.Permission) (*models.Document, error) {
+	d, err := s.Find(ctx, caller, id)
+	if err != nil {
+		return nil, err
+	}
+	if !d.Permission(caller).Allows(want) {
+		return nil, ErrForbidden
+	}
+	return d, nil
+}
+
+// newVersion describes the uploaded file
+func newVersion(caller models.Identity, file File) models.Version {
+	contentType := file.ContentType
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(file.Content)
+	}
+
+	sum := sha256.Sum256(file.Content)
+	return models.Version{
+		FileName:    file.Name,
+		ContentType: contentType,
+		Size:        int64(len(file.Content)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		UploadedBy:  caller.UserID,
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"employee-management/internal/backup"
+	"employee-management/internal/config"
+	"employee-management/internal/db"
+	"employee-management/internal/events"
+	"employee-management/internal/loadgen"
+	"employee-management/internal/repository"
+	"employee-management/internal/search"
+	"employee-management/internal/seed"
+)
+
+// runCommand runs a one-off subcommand instead of starting the server
+//
+//	migrate [up]      apply pending migrations
+//	migrate status    list migrations and when they were applied
+//	events tail       print events using the durable broker consumer
+//	seed [count]      load demo employees, skipping the ones already there
+//	loadgen count [api url]
+//	                  create fake employees for load tests, in the db or
+//	                  through the API of the service at url
+//	backup            export every employee to the backup store once
+//	reindex           rebuild the employee search index
+func runCommand(cfg *config.Config, migrator db.Migrator, employees repository.EmployeeRepository) {
+	ctx := context.Background()
+	args := cfg.Args
+
+	switch args[0] {
+	case "migrate":
+		if migrator == nil {
+			log.Fatalf("storage backend %s has no migrations", cfg.StorageBackend)
+		}
+		action := "up"
+		if len(args) > 1 {
+			action = args[1]
+		}
+
+		switch action {
+		case "up":
+			if err := migrator.Migrate(ctx); err != nil {
+				log.Fatalf("database migration failed: %v", err)
+			}
+			log.Printf("database is up to date")
+		case "status":
+			migrations, err := migrator.Status(ctx)
+			if err != nil {
+				log.Fatalf("failed to read migration status: %v", err)
+			}
+			for _, m := range migrations {
+				state := "pending"
+				if m.AppliedAt != nil {
+					state = "applied " + m.AppliedAt.Format("2006-01-02 15:04:05")
+				}
+				fmt.Printf("%04d_%s\t%s\n", m.Version, m.Name, state)
+			}
+		default:
+			log.Fatalf("unknown migrate action %q, expected up or status", action)
+		}
+	case "events":
+		if len(args) < 2 || args[1] != "tail" {
+			log.Fatalf("usage: events tail")
+		}
+
+		publisher, err := events.NewPublisher(ctx, cfg)
+		if err != nil {
+			log.Fatalf("failed to create event publisher: %v", err)
+		}
+		defer publisher.Close()
+
+		subscriber, ok := publisher.(events.Subscriber)
+		if !ok {
+			log.Fatalf("event broker %q does not support consuming", cfg.EventBroker)
+		}
+
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		err = subscriber.Subscribe(ctx, cfg.NATSConsumer, func(_ context.Context, e events.Event) error {
+			fmt.Printf("%s\t%s\t%d\t%s\n", e.OccurredAt.Format("2006-01-02 15:04:05"), e.Type, e.AggregateID, e.Payload)
+			return nil
+		})
+		if err != nil {
+			log.Fatalf("failed to consume events: %v", err)
+		}
+	case "seed":
+		if cfg.StorageBackend == "memory" {
+			log.Fatalf("storage backend memory keeps no data between runs, nothing to seed")
+		}
+		count := 50
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil {
+				log.Fatalf("usage: seed [count]")
+			}
+			count = n
+		}
+
+		if migrator != nil && cfg.MigrateOnStartup {
+			if err := migrator.Migrate(ctx); err != nil {
+				log.Fatalf("database migration failed: %v", err)
+			}
+		}
+
+		result, err := seed.Run(ctx, employees, count)
+		if err != nil {
+			log.Fatalf("seeding failed after %d employees: %v", result.Created, err)
+		}
+		log.Printf("seeded %d demo employees, %d already there", result.Created, result.Skipped)
+	case "loadgen":
+		if len(args) != 2 && (len(args) != 4 || args[2] != "api") {
+			log.Fatalf("usage: loadgen count [api url]")
+		}
+		count, err := strconv.Atoi(args[1])
+		if err != nil {
+			log.Fatalf("usage: loadgen count [api url]")
+		}
+
+		var sink loadgen.Sink = employees
+		workers := 8
+		if len(args) == 4 {
+			sink = loadgen.NewAPISink(args[3])
+		} else {
+			if cfg.StorageBackend == "memory" {
+				log.Fatalf("storage backend memory keeps no data between runs, use loadgen %d api <url>", count)
+			}
+			if migrator != nil && cfg.MigrateOnStartup {
+				if err := migrator.Migrate(ctx); err != nil {
+					log.Fatalf("database migration failed: %v", err)
+				}
+			}
+			// Leave connections to the rest of the pool
+			workers = max(1, min(workers, cfg.DBMaxConns-1))
+		}
+
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		result, err := loadgen.Run(ctx, loadgen.NewGenerator(time.Now().UnixNano()), sink, count, workers, func(created int) {
+			log.Printf("created %d of %d employees", created, count)
+		})
+		if err != nil {
+			log.Fatalf("load generation failed after %d employees: %v", result.Created, err)
+		}
+		log.Printf("created %d employees in %s", result.Created, result.Elapsed.Round(time.Millisecond))
+	case "backup":
+		if cfg.StorageBackend == "memory" {
+			log.Fatalf("storage backend memory keeps no data between runs, nothing to back up")
+		}
+		store, err := backup.NewStore(cfg)
+		if err != nil {
+			log.Fatalf("failed to create backup store: %v", err)
+		}
+
+		name, count, err := backup.NewJob(employees.FindAllStream, store, 0, cfg.BackupRetention).RunOnce(ctx)
+		if err != nil {
+			log.Fatalf("backup failed: %v", err)
+		}
+		log.Printf("backup %s written with %d employees", name, count)
+	case "reindex":
+		if cfg.SearchURL == "" {
+			log.Fatalf("search url is not set, nothing to reindex")
+		}
+		index := search.NewIndex(cfg.SearchURL, cfg.SearchIndex, cfg.SearchUsername, cfg.SearchPassword, cfg.SearchTimeout)
+		if _, err := index.EnsureIndex(ctx); err != nil {
+			log.Fatalf("search index setup failed: %v", err)
+		}
+
+		count, err := search.NewIndexer(index, employees).Reindex(ctx)
+		if err != nil {
+			log.Fatalf("reindex failed after %d employees: %v", count, err)
+		}
+		log.Printf("indexed %d employees", count)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", args[0])
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"employee-management/internal/config"
+	"employee-management/internal/discovery"
+)
+
+// registerInstance registers the service in the configured registry and
+// deregisters it when the process is asked to stop
+func registerInstance(cfg *config.Config) {
+	registrar, err := discovery.NewRegistrar(cfg.DiscoveryBackend, cfg.DiscoveryURL, cfg.DiscoveryTTL)
+	if err != nil {
+		log.Fatalf("failed to create discovery registrar: %v", err)
+	}
+	if registrar == nil {
+		return
+	}
+
+	healthPath := apiBasePath + "/" + apiVersions[len(apiVersions)-1].Name + "/health"
+	inst, err := discovery.NewInstance(cfg.ServiceName, cfg.ServiceAddress, cfg.ServerPort, healthPath, cfg.TLSEnabled())
+	if err != nil {
+		log.Fatalf("invalid service address: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := registrar.Register(ctx, inst); err != nil {
+		log.Fatalf("failed to register in %s: %v", cfg.DiscoveryBackend, err)
+	}
+	log.Printf("registered %s as %s in %s", inst.Address, inst.Name, cfg.DiscoveryBackend)
+
+	onShutdown(func() {
+		cancel()
+
+		deregCtx, done := context.WithTimeout(context.Background(), 5*time.Second)
+		defer done()
+		if err := registrar.Deregister(deregCtx, inst); err != nil {
+			log.Printf("failed to deregister from %s: %v", cfg.DiscoveryBackend, err)
+		}
+	})
+}
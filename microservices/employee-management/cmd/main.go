@@ -9,48 +9,427 @@ package main
 //	@contact.email	josed.amayar@uqvirtual.edu.co
 
 //	@host		localhost:8081
-//	@BasePath	/employees-service/api
+//	@BasePath	/employees-service/api/v1
 
 import (
+	"context"
 	"log"
+	"maps"
 	"net/http"
+	"strings"
+	"time"
+	_ "time/tzdata" // zone database for ORG_TIMEZONE on minimal images
 
 	"employee-management/internal/api"
+	"employee-management/internal/archive"
+	"employee-management/internal/backup"
+	"employee-management/internal/badge"
+	"employee-management/internal/breaker"
+	"employee-management/internal/cache"
+	"employee-management/internal/changefeed"
 	"employee-management/internal/config"
 	"employee-management/internal/db"
+	"employee-management/internal/directory"
+	"employee-management/internal/events"
+	"employee-management/internal/features"
+	"employee-management/internal/gql"
 	"employee-management/internal/handlers"
+	"employee-management/internal/jobs"
+	"employee-management/internal/metrics"
 	"employee-management/internal/middleware"
+	"employee-management/internal/models"
+	"employee-management/internal/outbox"
+	"employee-management/internal/pact"
+	"employee-management/internal/probation"
+	"employee-management/internal/reminders"
+	"employee-management/internal/report"
 	"employee-management/internal/repository"
+	"employee-management/internal/retention"
+	"employee-management/internal/search"
+	"employee-management/internal/server"
 	"employee-management/internal/service"
+	"employee-management/internal/slo"
+	"employee-management/internal/stream"
+	"employee-management/internal/verification"
+	"employee-management/internal/webhooks"
 
-	_ "employee-management/docs" // <-- Swagger docs (IMPORTANT)
+	"employee-management/docs" // <-- Swagger docs (IMPORTANT)
 
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"github.com/getsentry/sentry-go"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 func main() {
 	cfg := config.Load()
+	models.Location = cfg.Location()
+	if cfg.Debugging() {
+		log.SetFlags(log.LstdFlags | log.Lmicroseconds | log.Lshortfile)
+		log.Printf("debugging on (APP_ENV=%s, DEBUG=%t): Gin debug mode, Swagger and pprof, never in production", cfg.AppEnv, cfg.Debug)
+	}
+
+	if cfg.ValidateConfig {
+		validateConfig(cfg)
+		return
+	}
+
+	// Webhooks, skills, retention and the archive are only available on
+	// PostgreSQL, where their tables live; the other backends leave dbPool
+	// nil
+	var dbPool *pgxpool.Pool
+	var migrator db.Migrator
+	var employeeRepo repository.EmployeeRepository
+	var outboxRepo repository.OutboxRepository
+	var memoryStore *repository.MemoryStore
+	switch cfg.StorageBackend {
+	case "memory":
+		memoryStore = repository.NewMemoryStore()
+		employeeRepo, outboxRepo = memoryStore.Employees(), memoryStore.Outbox()
+		log.Printf("storage backend memory: data is lost on restart, webhooks, skills, retention and archive are disabled")
+	case "mysql":
+		mysqlDB := db.NewMySQLDB(cfg)
+		defer mysqlDB.Close()
+		migrator = db.NewMySQLMigrator(mysqlDB)
+		employeeRepo, outboxRepo = repository.NewMySQLEmployeeRepository(mysqlDB), repository.NewMySQLOutboxRepository(mysqlDB)
+		log.Printf("storage backend mysql: webhooks, skills, retention and archive are disabled")
+	case "mongodb":
+		mongoDB := db.NewMongoDatabase(cfg)
+		defer mongoDB.Client().Disconnect(context.Background())
+		migrator = db.NewMongoMigrator(mongoDB)
+		employeeRepo, outboxRepo = repository.NewMongoEmployeeRepository(mongoDB), repository.NewMongoOutboxRepository(mongoDB)
+		log.Printf("storage backend mongodb: webhooks, skills, retention and archive are disabled")
+	default:
+		dbPool = db.NewPostgresPool(cfg)
+		defer dbPool.Close()
+		metrics.RegisterPool(dbPool)
+		migrator = db.NewPostgresMigrator(dbPool)
+		employeeRepo, outboxRepo = repository.NewEmployeeRepository(dbPool), repository.NewOutboxRepository(dbPool)
+	}
+
+	if len(cfg.Args) > 0 {
+		runCommand(cfg, migrator, employeeRepo)
+		return
+	}
+
+	if migrator != nil && cfg.MigrateOnStartup {
+		if err := migrator.Migrate(context.Background()); err != nil {
+			log.Fatalf("database migration failed: %v", err)
+		}
+	}
+
+	// Circuit breaker around the db
+	dbBreaker := breaker.New(breaker.Settings{
+		FailureThreshold: cfg.BreakerFailureThreshold,
+		OpenTimeout:      cfg.BreakerOpenTimeout,
+		HalfOpenMaxCalls: cfg.BreakerHalfOpenMaxCalls,
+	})
+	dbBreaker.OnStateChange = func(from, to breaker.State) {
+		log.Printf("db circuit breaker %s -> %s", from, to)
+		metrics.CircuitState.Set(float64(to))
+		metrics.CircuitTransitions.WithLabelValues(from.String(), to.String()).Inc()
+	}
+
+	var redisClient *redis.Client
+	if cfg.RedisURL != "" {
+		redisClient = db.NewRedisClient(cfg.RedisURL)
+		defer redisClient.Close()
+	}
+
+	// Feature flags: env, then file, then Redis (later sources win)
+	sources := []features.Source{features.EnvSource{}}
+	if cfg.FeaturesFile != "" {
+		sources = append(sources, features.FileSource{Path: cfg.FeaturesFile})
+	}
+	if redisClient != nil {
+		sources = append(sources, features.RedisSource{Client: redisClient, Key: cfg.FeaturesRedisKey})
+	}
 
-	dbPool := db.NewPostgresPool(cfg.DatabaseURL())
-	defer dbPool.Close()
+	flags := features.New(sources...)
+	if err := flags.Refresh(context.Background()); err != nil {
+		log.Fatalf("failed to load feature flags: %v", err)
+	}
+	go flags.Watch(context.Background(), cfg.FeaturesRefresh)
+
+	repo := repository.NewBreakerRepository(employeeRepo, dbBreaker)
+
+	// Read cache, toggled at runtime by the caching flag
+	var employeeCache cache.Cache
+	switch cfg.CacheBackend {
+	case "memory":
+		employeeCache = cache.NewMemoryCache(cfg.CacheMaxEntries, cfg.CacheMaxBytes)
+	case "redis":
+		employeeCache = cache.NewRedisCache(redisClient)
+	}
+	var invalidator service.Invalidator
+	if employeeCache != nil {
+		cachingEnabled := func() bool { return flags.Enabled(features.Caching) }
+		repo = repository.NewCachedRepository(repo, employeeCache, cfg.CacheTTL, cachingEnabled, cfg.CacheVerify)
+		invalidator = repository.NewCacheInvalidator(employeeCache)
+	}
 
-	repo := repository.NewEmployeeRepository(dbPool)
-	service := service.NewEmployeeService(repo)
-	handler := handlers.NewEmployeeHandler(service)
+	// Full-text search index answering ?q=
+	var searchIndex *search.Index
+	var searcher service.Searcher
+	if cfg.SearchURL != "" {
+		searchIndex = search.NewIndex(cfg.SearchURL, cfg.SearchIndex, cfg.SearchUsername, cfg.SearchPassword, cfg.SearchTimeout)
+		searcher = searchIndex
+	}
+
+	if err := checkConfig(cfg); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	var verifier *verification.Verifier
+	if cfg.EmailVerificationKey != "" {
+		verifier = verification.New(cfg.EmailVerificationKey, cfg.EmailVerificationTTL, cfg.EmailVerificationURL)
+		if !flags.Enabled(features.Events) {
+			log.Printf("email verification is on but the %s feature is off: no verification emails are sent until it is enabled", features.Events)
+		}
+	}
+	employeeService := service.NewEmployeeService(repo, flags, searcher, invalidator, models.IDFormat(cfg.IDFormat), verifier)
+
+	// Outbox dispatcher delivering stored events to the broker
+	publisher, err := events.NewPublisher(context.Background(), cfg)
+	if err != nil {
+		log.Fatalf("failed to create event publisher: %v", err)
+	}
+	defer publisher.Close()
+
+	// Background tasks: exports, webhook deliveries and purges run on a
+	// bounded pool drained at shutdown
+	pool := jobs.NewPool(cfg.JobWorkers, cfg.JobQueueSize, cfg.JobMaxAttempts, cfg.JobMaxBackoff)
+	onShutdown(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.JobDrainTimeout)
+		defer cancel()
+		if err := pool.Drain(ctx); err != nil {
+			log.Printf("background tasks canceled at shutdown: %v", err)
+		}
+	})
+	go handleShutdown()
+
+	// Webhooks get every dispatched event, delivered by their own worker
+	var webhookHandler *handlers.WebhookHandler
+	var skillHandler *handlers.SkillHandler
+	var skillLoader handlers.SkillLoader // nil interface when skills are disabled
+	if dbPool != nil {
+		webhookRepo := repository.NewWebhookRepository(dbPool)
+		deliverer := webhooks.NewDeliverer(
+			webhookRepo,
+			cfg.WebhookTimeout,
+			cfg.WebhookPollInterval,
+			cfg.WebhookBatchSize,
+			cfg.WebhookMaxAttempts,
+			cfg.WebhookMaxBackoff,
+		)
+		go deliverer.Run(context.Background(), pool)
+
+		publisher = webhooks.NewPublisher(publisher, webhookRepo)
+		webhookHandler = handlers.NewWebhookHandler(service.NewWebhookService(webhookRepo))
+
+		skillRepo := repository.NewSkillRepository(dbPool)
+		skillService := service.NewSkillService(skillRepo, repo)
+		skillHandler = handlers.NewSkillHandler(skillService, employeeService)
+		skillLoader = skillService
+	}
+
+	dispatcher := outbox.NewDispatcher(
+		outboxRepo,
+		publisher,
+		cfg.OutboxPollInterval,
+		cfg.OutboxBatchSize,
+		cfg.OutboxMaxBackoff,
+	)
+	go dispatcher.Run(context.Background())
+
+	// Live change stream tailing the outbox
+	hub := stream.NewHub(outboxRepo, cfg.StreamPollInterval)
+	go hub.Run(context.Background())
+
+	// The search indexer follows the events of the hub, the periodic
+	// reindex covers events being disabled
+	if searchIndex != nil {
+		indexer := search.NewIndexer(searchIndex, employeeService)
+		go indexer.Run(context.Background(), hub.Subscribe, cfg.SearchReindexInterval)
+	}
+
+	// Change feed: new events wake the stream hub and changes made by other
+	// instances evict their employees from the local cache. A Redis cache
+	// is shared, the instance making the change already evicted it
+	if dbPool != nil && cfg.ChangeFeed {
+		listener := changefeed.NewListener(dbPool)
+		listener.OnChange(func(change changefeed.Change) {
+			switch change.Table {
+			case changefeed.TableOutbox:
+				hub.Notify()
+			case changefeed.TableEmployees:
+				if cfg.CacheBackend != "memory" {
+					return
+				}
+				if err := repository.EvictEmployees(context.Background(), employeeCache, change.EmployeeID); err != nil {
+					log.Printf("cache eviction of employee %d failed: %v", change.EmployeeID, err)
+				}
+			}
+		})
+		go listener.Run(context.Background())
+	}
+
+	// Scheduled jobs: backups, purges, probations, reminders, the archive
+	// and the directory sync run on one instance, see startScheduled
+	var scheduled []func(ctx context.Context)
+
+	// Scheduled export of every employee to the backup store
+	if cfg.BackupInterval > 0 {
+		store, err := backup.NewStore(cfg)
+		if err != nil {
+			log.Fatalf("failed to create backup store: %v", err)
+		}
+		job := backup.NewJob(employeeService.FindAllStream, store, cfg.BackupInterval, cfg.BackupRetention)
+		scheduled = append(scheduled, func(ctx context.Context) { job.Run(ctx, pool) })
+	}
 
-	// Gin config
-	gin.SetMode(gin.ReleaseMode) // Change mode for development
+	// Retention policy purging long retired employees
+	var retentionHandler *handlers.RetentionHandler
+	if dbPool != nil {
+		policy := retention.Policy{
+			Years:  cfg.RetentionYears,
+			Action: models.RetentionAction(strings.ToUpper(cfg.RetentionAction)),
+		}
+		engine := retention.NewEngine(repository.NewRetentionRepository(dbPool), policy, flags, employeeCache)
+		if cfg.RetentionInterval > 0 {
+			scheduled = append(scheduled, func(ctx context.Context) { engine.Run(ctx, cfg.RetentionInterval, pool) })
+		}
+		retentionHandler = handlers.NewRetentionHandler(engine)
+	}
+
+	// End of probation of employees whose probation end date lapsed
+	if cfg.ProbationInterval > 0 {
+		scheduler := probation.NewScheduler(employeeService.EndProbations)
+		scheduled = append(scheduled, func(ctx context.Context) { scheduler.Run(ctx, cfg.ProbationInterval) })
+	}
+
+	// Daily anniversary and birthday reminders of the opted-in departments,
+	// published like the dispatched events, webhooks included
+	if cfg.ReminderDepartments != "" {
+		job := reminders.NewJob(employeeService.FindAllStream, publisher, cfg.ReminderDaysAhead, envconfig.SplitList(cfg.ReminderDepartments))
+		scheduled = append(scheduled, job.Run)
+	}
+
+	// Archive of long retired employees, read with ?archived=true
+	if dbPool != nil && cfg.ArchiveInterval > 0 {
+		archiver := archive.NewArchiver(repository.NewArchiveRepository(dbPool), cfg.ArchiveAfterMonths, employeeCache)
+		scheduled = append(scheduled, func(ctx context.Context) { archiver.Run(ctx, cfg.ArchiveInterval, pool) })
+	}
+
+	// Sync of the employees with the LDAP directory
+	var directoryHandler *handlers.DirectoryHandler
+	if cfg.LDAPURL != "" {
+		mapping, err := directory.ParseMapping(cfg.LDAPAttributes)
+		if err != nil {
+			log.Fatalf("invalid directory sync config: %v", err)
+		}
+		ldap := directory.NewLDAP(cfg.LDAPURL, cfg.LDAPBindDN, cfg.LDAPBindPassword, cfg.LDAPBaseDN, cfg.LDAPFilter, mapping, cfg.LDAPTimeout)
+		syncer := directory.NewSyncer(ldap, employeeService)
+		if cfg.LDAPSyncInterval > 0 {
+			scheduled = append(scheduled, func(ctx context.Context) { syncer.Run(ctx, cfg.LDAPSyncInterval) })
+		}
+		directoryHandler = handlers.NewDirectoryHandler(syncer)
+	}
+
+	startScheduled(cfg, dbPool, scheduled)
+
+	handler := handlers.NewEmployeeHandler(employeeService, skillLoader)
+	streamHandler := handlers.NewStreamHandler(hub)
+	wsHandler := handlers.NewWebSocketHandler(hub, cfg)
+	var badgeSigner *badge.Signer
+	if cfg.BadgeSigningKey != "" {
+		badgeSigner = badge.NewSigner(cfg.BadgeSigningKey, cfg.BadgeTokenTTL)
+	}
+	badgeHandler := handlers.NewBadgeHandler(employeeService, badgeSigner)
+	calendarHandler := handlers.NewCalendarHandler(employeeService.FindAllStream)
+	vcardHandler := handlers.NewVCardHandler(employeeService, employeeService.FindAllStream, cfg.OrgName)
+	reportHandler := handlers.NewReportHandler(employeeService.FindAllStream, report.Template{
+		Title:    cfg.ReportTitle,
+		PageSize: cfg.ReportPageSize,
+	}, cfg.OrgName)
+
+	schema, err := gql.NewSchema(employeeService)
+	if err != nil {
+		log.Fatalf("failed to build GraphQL schema: %v", err)
+	}
+	graphqlHandler := handlers.NewGraphQLHandler(schema)
+
+	featureHandler := handlers.NewFeatureHandler(flags)
+	healthHandler := handlers.NewHealthHandler(dbBreaker, dbPool)
+
+	// Latency objectives per route, streams stay open so they have none
+	sloTracker := slo.NewTracker(cfg.SLOTarget, cfg.SLOLatency, cfg.RouteSLOLatencies, cfg.SLOWindow)
+	metrics.RegisterSLO(sloTracker)
+	sloHandler := handlers.NewSLOHandler(sloTracker)
+
+	api.SetEnvelope(cfg.ResponseEnvelope)
+
+	// Error reporting of panics and 5xx responses, flushed at shutdown
+	if cfg.SentryDSN != "" {
+		environment := cfg.SentryEnvironment
+		if environment == "" {
+			environment = cfg.AppEnv
+		}
+		err := sentry.Init(sentry.ClientOptions{
+			Dsn:              cfg.SentryDSN,
+			Environment:      environment,
+			Release:          cfg.SentryRelease,
+			SampleRate:       cfg.SentrySampleRate,
+			AttachStacktrace: true,
+		})
+		if err != nil {
+			log.Fatalf("failed to set up Sentry: %v", err)
+		}
+		onShutdown(func() {
+			sentry.Flush(2 * time.Second)
+		})
+	}
+
+	// Gin config, debug mode logs the routes and warnings
+	gin.SetMode(gin.ReleaseMode)
+	if cfg.Debugging() {
+		gin.SetMode(gin.DebugMode)
+	}
 	router := gin.New()
 
 	// Trusted proxies
 	router.SetTrustedProxies([]string{"127.0.0.1"})
 
 	// Middleware
+	router.Use(middleware.RequestID())
+	if cfg.SentryDSN != "" {
+		router.Use(middleware.ErrorReporting())
+	}
+	router.Use(middleware.Latency(sloTracker, streamRoutes()))
+	if cfg.LogBodies() {
+		log.Printf("body logging on: request and response bodies are logged with %s redacted", cfg.LogRedactFields)
+		router.Use(middleware.BodyLogging(envconfig.SplitList(cfg.LogRedactFields), cfg.BodyLogMaxBytes))
+	}
 	router.Use(middleware.Recovery())
 	router.Use(middleware.ErrorHandler())
 	router.Use(gin.Logger())
+	// Streams stay open, so they get no deadline unless configured
+	routeTimeouts := map[string]time.Duration{}
+	for _, route := range streamRoutes() {
+		routeTimeouts[route] = 0
+	}
+	maps.Copy(routeTimeouts, cfg.RouteTimeouts)
+	router.Use(middleware.Timeout(cfg.RequestTimeout, routeTimeouts))
+	// Requests are checked against the generated Swagger spec
+	if cfg.OpenAPIValidation {
+		specValidator, err := middleware.OpenAPI([]byte(docs.SwaggerInfo.ReadDoc()), mountedPrefixes(), cfg.OpenAPIValidateResponses)
+		if err != nil {
+			log.Fatalf("failed to load OpenAPI spec: %v", err)
+		}
+		router.Use(specValidator)
+	}
 	router.Use(gin.Recovery()) // Recovery fallback
 
 	// Global handlers
@@ -62,29 +441,94 @@ func main() {
 		api.Error(c, http.StatusMethodNotAllowed, "Method not allowed")
 	})
 
-	apiGroup := router.Group("/employees-service/api")
-	{
-		// Health
-		apiGroup.GET("/health", handlers.HealthCheck)
+	// Prometheus metrics
+	router.GET("/metrics", metrics.Handler())
 
-		// Swagger
+	// Swagger, also fetched by the API gateway for its aggregated docs
+	if cfg.ServeSwagger() {
 		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	}
+
+	// Pact provider states, outside the versioned API and its spec
+	if cfg.TestMode {
+		states := pact.NewStates(employeeService, memoryStore.Reset)
+		router.POST("/_pact/provider-states", handlers.NewProviderStateHandler(states).ChangeState)
+		log.Printf("test mode: provider states at /_pact/provider-states wipe every employee, never enable it in production")
+	}
 
-		// Employee routes
-		employees := apiGroup.Group("/employees")
+	// SCIM provisioning, outside the versioned API as it follows the
+	// protocol rather than the spec
+	if cfg.SCIMToken != "" {
+		scimHandler := handlers.NewSCIMHandler(employeeService, cfg.SCIMToken)
+		scimRoutes := router.Group("/scim/v2", scimHandler.Authenticate)
 		{
-			employees.POST("/", handler.CreateEmployee)
-			employees.GET("/:id", handler.GetEmployeeByID)
-			employees.GET("/", handler.GetAllEmployees)
-			employees.PUT("/:id", handler.UpdateEmployee)
-			employees.DELETE("/:id", handler.DeleteEmployee)
+			scimRoutes.GET("/ServiceProviderConfig", scimHandler.GetServiceProviderConfig)
+			scimRoutes.GET("/ResourceTypes", scimHandler.GetResourceTypes)
+			scimRoutes.GET("/Users", scimHandler.ListUsers)
+			scimRoutes.POST("/Users", scimHandler.CreateUser)
+			scimRoutes.GET("/Users/:id", scimHandler.GetUser)
+			scimRoutes.PUT("/Users/:id", scimHandler.ReplaceUser)
+			scimRoutes.PATCH("/Users/:id", scimHandler.PatchUser)
+			scimRoutes.DELETE("/Users/:id", scimHandler.DeactivateUser)
 		}
 	}
 
-	log.Printf("Employee service running on :%s", cfg.ServerPort)
-	log.Printf("Swagger UI available at http://localhost:%s/swagger/index.html", cfg.ServerPort)
+	// Versioned API
+	mountAPI(router, routeHandlers{
+		employee: handler,
+		stream:   streamHandler,
+		ws:       wsHandler,
+		graphql:  graphqlHandler,
+		webhook:  webhookHandler,
+		skill:    skillHandler,
+		retain:   retentionHandler,
+		feature:  featureHandler,
+		health:   healthHandler,
+		slo:      sloHandler,
+		badge:    badgeHandler,
+		calendar: calendarHandler,
+		vcard:    vcardHandler,
+		report:   reportHandler,
+		dir:      directoryHandler,
+	}, middleware.CacheControl(cfg.HTTPCacheMaxAge, cfg.RouteCacheMaxAges))
 
-	if err := router.Run(":" + cfg.ServerPort); err != nil {
+	scheme := "http"
+	if cfg.TLSEnabled() {
+		scheme = "https"
+	}
+	if cfg.ServeSwagger() {
+		log.Printf("Swagger UI available at %s://localhost:%s/swagger/index.html", scheme, cfg.ServerPort)
+	}
+
+	server.RunAdmin(cfg)
+
+	// At shutdown in-flight requests finish before background tasks drain
+	srv := server.New(cfg, router)
+	onShutdown(func() {
+		server.Shutdown(srv, cfg.ShutdownTimeout)
+	})
+
+	// Readiness fails first, for SHUTDOWN_DELAY, so load balancers that
+	// lag behind (kube-proxy, gateways) stop routing here before the
+	// listener closes. Keep-alive connections are closed after their
+	// next response so their clients reconnect elsewhere
+	onShutdown(func() {
+		healthHandler.Drain()
+		srv.SetKeepAlivesEnabled(false)
+		if cfg.ShutdownDelay > 0 {
+			log.Printf("draining: readiness failing for %s before closing the listener", cfg.ShutdownDelay)
+			time.Sleep(cfg.ShutdownDelay)
+		}
+	})
+
+	// Self-registration so the gateway finds this instance
+	registerInstance(cfg)
+
+	if err := server.Run(cfg, srv); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
+
+	// The listener closed at shutdown, handleShutdown exits once the
+	// remaining hooks ran
+	select {}
 }
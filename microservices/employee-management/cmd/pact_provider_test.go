@@ -0,0 +1,121 @@
+//go:build pact
+
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pact-foundation/pact-go/v2/provider"
+)
+
+// TestPactProvider verifies the contracts of the consumers of the API
+// against the service started in test mode. The contracts come from the
+// broker of PACT_BROKER_URL (PACT_BROKER_TOKEN or PACT_BROKER_USERNAME
+// and PACT_BROKER_PASSWORD authenticate), from the pact files in
+// PACT_DIR, or both. With PACT_PROVIDER_VERSION the results are
+// published to the broker for that version
+func TestPactProvider(t *testing.T) {
+	brokerURL := os.Getenv("PACT_BROKER_URL")
+	pactDir := os.Getenv("PACT_DIR")
+	if brokerURL == "" && pactDir == "" {
+		t.Skip("no contracts to verify, set PACT_BROKER_URL or PACT_DIR")
+	}
+
+	baseURL := startTestMode(t)
+
+	version := os.Getenv("PACT_PROVIDER_VERSION")
+	if brokerURL != "" && version == "" {
+		// The broker picks the contracts for a version, unpublished
+		version = "local"
+	}
+	req := provider.VerifyRequest{
+		Provider:        "employee-management",
+		ProviderBaseURL: baseURL,
+		// The states are set up by the state handlers of the service, as
+		// they must change the employees of the process under test
+		ProviderStatesSetupURL:     baseURL + "/_pact/provider-states",
+		BrokerURL:                  brokerURL,
+		ProviderVersion:            version,
+		ProviderBranch:             os.Getenv("PACT_PROVIDER_BRANCH"),
+		PublishVerificationResults: os.Getenv("PACT_PROVIDER_VERSION") != "",
+		EnablePending:              true,
+	}
+	if pactDir != "" {
+		req.PactDirs = []string{pactDir}
+	}
+
+	if err := provider.NewVerifier().VerifyProvider(t, req); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// startTestMode builds the service and runs it in test mode, on the
+// memory backend without cache, until the test ends. It returns the base
+// url once the service is ready
+func startTestMode(t *testing.T) string {
+	t.Helper()
+
+	bin := filepath.Join(t.TempDir(), "employee-management")
+	build := exec.Command("go", "build", "-o", bin, ".")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("failed to build the service: %v\n%s", err, out)
+	}
+
+	port := freePort(t)
+	cmd := exec.Command(bin)
+	cmd.Env = append(os.Environ(),
+		"TEST_MODE=true",
+		"STORAGE_BACKEND=memory",
+		"CACHE_BACKEND=none",
+		"SERVER_PORT="+strconv.Itoa(port),
+		"PPROF_ENABLED=false",
+		"DISCOVERY_BACKEND=none",
+	)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start the service: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Signal(syscall.SIGTERM)
+		_ = cmd.Wait()
+	})
+
+	baseURL := "http://localhost:" + strconv.Itoa(port)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	for {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+apiBasePath+"/v1/health", nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return baseURL
+			}
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("service not ready on %s: %v", baseURL, err)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// freePort returns a port nothing listens on
+func freePort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
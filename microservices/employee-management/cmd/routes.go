@@ -0,0 +1,213 @@
+package main
+
+import (
+	"employee-management/internal/handlers"
+	"employee-management/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiBasePath is the prefix every API version is mounted under
+const apiBasePath = "/employees-service/api"
+
+// routeHandlers are the handlers the API versions are built from
+type routeHandlers struct {
+	employee *handlers.EmployeeHandler
+	stream   *handlers.StreamHandler
+	ws       *handlers.WebSocketHandler
+	graphql  *handlers.GraphQLHandler
+	webhook  *handlers.WebhookHandler
+	skill    *handlers.SkillHandler
+	retain   *handlers.RetentionHandler
+	feature  *handlers.FeatureHandler
+	health   *handlers.HealthHandler
+	slo      *handlers.SLOHandler
+	badge    *handlers.BadgeHandler
+	calendar *handlers.CalendarHandler
+	vcard    *handlers.VCardHandler
+	report   *handlers.ReportHandler
+	dir      *handlers.DirectoryHandler
+}
+
+// apiVersion is a mounted version of the API
+type apiVersion struct {
+	Name     string
+	Register func(rg *gin.RouterGroup, h routeHandlers)
+}
+
+// apiVersions lists the API versions, oldest first. Versions are frozen
+// once released: a breaking change to a DTO goes into a new version whose
+// Register reuses the route sets that did not change, e.g.
+//
+//	func registerV2(rg *gin.RouterGroup, h routeHandlers) {
+//		registerMetaRoutes(rg, h)
+//		registerWebhookRoutes(rg, h)
+//		registerEmployeeRoutesV2(rg, h) // new DTOs
+//	}
+var apiVersions = []apiVersion{
+	{Name: "v1", Register: registerV1},
+}
+
+// legacyVersion is also served on the unversioned base path for clients
+// written before versioning, with a Deprecation header
+const legacyVersion = "v1"
+
+// mountAPI registers every API version under apiBasePath, each behind
+// the middleware mw
+func mountAPI(router *gin.Engine, h routeHandlers, mw ...gin.HandlerFunc) {
+	for _, v := range apiVersions {
+		group := router.Group(apiBasePath+"/"+v.Name, middleware.APIVersion(v.Name))
+		group.Use(mw...)
+		v.Register(group, h)
+
+		if v.Name == legacyVersion {
+			legacy := router.Group(apiBasePath,
+				middleware.APIVersion(v.Name),
+				middleware.Deprecated(apiBasePath+"/"+v.Name),
+			)
+			legacy.Use(mw...)
+			v.Register(legacy, h)
+		}
+	}
+}
+
+// mountedPrefixes returns every path prefix an API version is served on
+func mountedPrefixes() []string {
+	var prefixes []string
+	for _, v := range apiVersions {
+		prefixes = append(prefixes, apiBasePath+"/"+v.Name)
+		if v.Name == legacyVersion {
+			prefixes = append(prefixes, apiBasePath)
+		}
+	}
+	return prefixes
+}
+
+// streamRoutes returns the long lived routes of every mounted version,
+// keyed like ROUTE_TIMEOUTS, so they can be left without a deadline
+func streamRoutes() []string {
+	var routes []string
+	for _, p := range mountedPrefixes() {
+		routes = append(routes,
+			"GET "+p+"/employees/stream",
+			"GET "+p+"/employees/ws",
+		)
+	}
+	return routes
+}
+
+// registerV1 registers the v1 API
+func registerV1(rg *gin.RouterGroup, h routeHandlers) {
+	registerMetaRoutes(rg, h)
+	registerEmployeeRoutes(rg, h)
+	registerWebhookRoutes(rg, h)
+	registerSkillRoutes(rg, h)
+	registerRetentionRoutes(rg, h)
+	registerDirectoryRoutes(rg, h)
+}
+
+// registerMetaRoutes registers health, SLOs, feature flags and GraphQL
+func registerMetaRoutes(rg *gin.RouterGroup, h routeHandlers) {
+	// Health
+	rg.GET("/health", h.health.HealthCheck)
+	rg.GET("/health/ready", h.health.Ready)
+
+	// Error budget burn per route
+	rg.GET("/slo", h.slo.GetSLO)
+
+	// Feature flags
+	rg.GET("/features", h.feature.ListFeatures)
+
+	// GraphQL
+	rg.POST("/graphql", h.graphql.GraphQL)
+	rg.GET("/graphql", h.graphql.GraphQL)
+}
+
+// registerEmployeeRoutes registers the employee routes
+func registerEmployeeRoutes(rg *gin.RouterGroup, h routeHandlers) {
+	employees := rg.Group("/employees")
+	{
+		employees.POST("/", h.employee.CreateEmployee)
+		employees.GET("/stream", h.stream.StreamEmployees)
+		employees.GET("/ws", h.ws.EmployeesWebSocket)
+		employees.GET("/snapshot", h.employee.GetSnapshot)
+		employees.GET("/number-format", h.employee.GetEmployeeNumberFormat)
+		employees.GET("/calendar.ics", h.calendar.GetCalendar)
+		employees.GET("/vcard", h.vcard.GetVCards)
+		employees.GET("/report.pdf", h.report.GetReportPDF)
+		employees.GET("/verify-email", h.employee.VerifyEmail)
+		employees.GET("/:id", h.employee.GetEmployeeByID)
+		employees.GET("/:id/badge.png", h.badge.GetBadge)
+		employees.GET("/:id/vcard", h.vcard.GetVCard)
+		employees.GET("/", h.employee.GetAllEmployees)
+		employees.PUT("/:id", h.employee.UpdateEmployee)
+		employees.PATCH("/:id", h.employee.PatchEmployee)
+		employees.POST("/:id/rehire", h.employee.RehireEmployee)
+		employees.POST("/:id/verification-email", h.employee.RequestEmailVerification)
+		employees.POST("/bulk-update", h.employee.BulkUpdateEmployees)
+		employees.DELETE("/:id", h.employee.DeleteEmployee)
+	}
+}
+
+// registerWebhookRoutes registers the webhook subscription routes, none
+// when webhooks are disabled
+func registerWebhookRoutes(rg *gin.RouterGroup, h routeHandlers) {
+	if h.webhook == nil {
+		return
+	}
+	webhookRoutes := rg.Group("/webhooks")
+	{
+		webhookRoutes.POST("/", h.webhook.CreateWebhook)
+		webhookRoutes.GET("/", h.webhook.GetAllWebhooks)
+		webhookRoutes.GET("/:id", h.webhook.GetWebhookByID)
+		webhookRoutes.DELETE("/:id", h.webhook.DeleteWebhook)
+		webhookRoutes.GET("/:id/deliveries", h.webhook.GetWebhookDeliveries)
+	}
+}
+
+// registerSkillRoutes registers the skills catalog and the skills of each
+// employee, none when skills are disabled
+func registerSkillRoutes(rg *gin.RouterGroup, h routeHandlers) {
+	if h.skill == nil {
+		return
+	}
+	skillRoutes := rg.Group("/skills")
+	{
+		skillRoutes.POST("/", h.skill.CreateSkill)
+		skillRoutes.GET("/", h.skill.GetAllSkills)
+		skillRoutes.GET("/:id", h.skill.GetSkillByID)
+		skillRoutes.DELETE("/:id", h.skill.DeleteSkill)
+	}
+	employeeSkills := rg.Group("/employees/:id/skills")
+	{
+		employeeSkills.GET("/", h.skill.GetEmployeeSkills)
+		employeeSkills.PUT("/:skillId", h.skill.AssignSkill)
+		employeeSkills.DELETE("/:skillId", h.skill.UnassignSkill)
+	}
+}
+
+// registerRetentionRoutes registers the retention report and audit log,
+// none when retention is disabled
+func registerRetentionRoutes(rg *gin.RouterGroup, h routeHandlers) {
+	if h.retain == nil {
+		return
+	}
+	retentionRoutes := rg.Group("/retention")
+	{
+		retentionRoutes.GET("/report", h.retain.GetRetentionReport)
+		retentionRoutes.GET("/audit", h.retain.GetRetentionAudit)
+	}
+}
+
+// registerDirectoryRoutes registers the directory sync, none when it is
+// disabled
+func registerDirectoryRoutes(rg *gin.RouterGroup, h routeHandlers) {
+	if h.dir == nil {
+		return
+	}
+	directoryRoutes := rg.Group("/directory")
+	{
+		directoryRoutes.GET("/sync", h.dir.GetDirectorySync)
+		directoryRoutes.POST("/sync", h.dir.SyncDirectory)
+	}
+}
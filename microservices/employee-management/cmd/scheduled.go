@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"employee-management/internal/config"
+	"employee-management/internal/leader"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// schedulerLock names the advisory lock the instances campaign for
+const schedulerLock = "employee-management:scheduler"
+
+// startScheduled runs the scheduled jobs. Instances sharing a PostgreSQL
+// database elect a leader that runs them alone; without PostgreSQL or
+// with leader election off every instance runs them
+func startScheduled(cfg *config.Config, dbPool *pgxpool.Pool, scheduled []func(ctx context.Context)) {
+	if len(scheduled) == 0 {
+		return
+	}
+
+	run := leader.RunAll(scheduled...)
+	if !cfg.LeaderElection || dbPool == nil {
+		// The memory backend is not shared, so each instance is alone anyway
+		if cfg.LeaderElection && cfg.StorageBackend != "memory" {
+			log.Printf("leader election needs the postgres storage backend, scheduled jobs run on every instance")
+		}
+		go run(context.Background())
+		return
+	}
+
+	elector := leader.New(dbPool, schedulerLock, cfg.LeaderCheckInterval)
+	go elector.Run(context.Background(), run)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+var (
+	shutdownMu    sync.Mutex
+	shutdownHooks []func()
+)
+
+// onShutdown registers fn to run when the process is asked to stop
+// Hooks run last registered first, like defers, so what started last
+// stops first
+func onShutdown(fn func()) {
+	shutdownMu.Lock()
+	defer shutdownMu.Unlock()
+	shutdownHooks = append(shutdownHooks, fn)
+}
+
+// handleShutdown waits for SIGINT or SIGTERM, runs the shutdown hooks and
+// exits
+func handleShutdown() {
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+	log.Printf("shutting down")
+
+	shutdownMu.Lock()
+	hooks := shutdownHooks
+	shutdownMu.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+	os.Exit(0)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+
+	"employee-management/internal/backup"
+	"employee-management/internal/config"
+	"employee-management/internal/db"
+	"employee-management/internal/features"
+	"employee-management/internal/validator"
+)
+
+// checkConfig checks the settings Validate cannot, those needing files
+// or other packages, and applies the validator settings. It returns
+// every problem found
+func checkConfig(cfg *config.Config) error {
+	var errs []error
+
+	if err := validator.SetDefaultPhoneCountry(cfg.PhoneDefaultCountry); err != nil {
+		errs = append(errs, fmt.Errorf("invalid phone default country: %w", err))
+	}
+	if err := validator.SetEmployeeNumberPattern(cfg.EmployeeNumberPattern); err != nil {
+		errs = append(errs, fmt.Errorf("invalid employee number pattern: %w", err))
+	}
+	if cfg.ValidationRulesFile != "" {
+		loaded, err := validator.LoadRules(cfg.ValidationRulesFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to load validation rules: %w", err))
+		} else {
+			log.Printf("loaded %d validation rules from %s", loaded, cfg.ValidationRulesFile)
+		}
+	}
+
+	if cfg.TLSCertFile != "" {
+		if _, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile); err != nil {
+			errs = append(errs, fmt.Errorf("invalid TLS certificate: %w", err))
+		}
+	}
+	if cfg.FeaturesFile != "" {
+		if _, err := (features.FileSource{Path: cfg.FeaturesFile}).Load(context.Background()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if cfg.BackupInterval > 0 {
+		if _, err := backup.NewStore(cfg); err != nil {
+			errs = append(errs, fmt.Errorf("invalid backup store: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateConfig runs the -validate-config mode once the config printed
+// by config.Load passed its own validation. Problems exit non-zero
+func validateConfig(cfg *config.Config) {
+	if err := checkConfig(cfg); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	if cfg.CheckConnections {
+		checkConnections(cfg)
+	}
+
+	log.Printf("configuration is valid")
+}
+
+// checkConnections connects to Redis and the database. The constructors
+// retry up to DB_RETRY_MAX_WAIT, then exit on failure
+func checkConnections(cfg *config.Config) {
+	if cfg.RedisURL != "" {
+		db.NewRedisClient(cfg.RedisURL).Close()
+		log.Printf("connected to redis")
+	}
+
+	switch cfg.StorageBackend {
+	case "memory":
+		return
+	case "mysql":
+		db.NewMySQLDB(cfg).Close()
+	case "mongodb":
+		db.NewMongoDatabase(cfg).Client().Disconnect(context.Background())
+	default:
+		db.NewPostgresPool(cfg).Close()
+	}
+	log.Printf("connected to the %s database", cfg.StorageBackend)
+}
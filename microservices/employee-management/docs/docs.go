@@ -19,12 +19,102 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/employees": {
+        "/directory/sync": {
             "get": {
-                "description": "Retrieves employees with pagination support. Can filter by department, status, position.",
+                "description": "Reports the last directory sync that was not a dry run, scheduled or requested: one result per directory entry with the fields taken from the directory and the conflicts left for HR to resolve, and the employees missing from the directory",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Directory"
+                ],
+                "summary": "Last directory sync",
+                "responses": {
+                    "200": {
+                        "description": "Last sync",
+                        "schema": {
+                            "$ref": "#/definitions/models.DirectorySyncReport"
+                        }
+                    },
+                    "404": {
+                        "description": "No sync ran yet",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Syncs the employees with the LDAP directory now: entries without an employee are created and the fields the directory changed since the last sync are updated. Fields edited in the service since the last sync that the directory disagrees with are reported as conflicts and left as they are. With dry_run=true the outcomes are reported without changing anything",
                 "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Directory"
+                ],
+                "summary": "Sync the directory",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Report what would change without changing it",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sync report",
+                        "schema": {
+                            "$ref": "#/definitions/models.DirectorySyncReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A sync is already running",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Directory unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees": {
+            "get": {
+                "description": "Retrieves employees with pagination support. Can filter by department, status, position, address country and city, and skill. With archived=true lists the archived employees instead. With q the employees are searched in the search index, fuzzily across names, email, employee number, position, department and city, best match first.",
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "text/csv"
+                ],
                 "tags": [
                     "Employees"
                 ],
@@ -43,31 +133,106 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by department",
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by department, any of several values given repeated or comma separated",
                         "name": "department",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by status (ACTIVE, ON_VACATION, RETIRED)",
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by status (ACTIVE, ON_VACATION, RETIRED, PROBATION), any of several values given repeated or comma separated",
                         "name": "status",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by position",
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by position, any of several values given repeated or comma separated",
                         "name": "position",
                         "in": "query"
+                    },
+                    {
+                        "maxLength": 2,
+                        "minLength": 2,
+                        "type": "string",
+                        "description": "Filter by address country (ISO 3166-1 alpha-2, e.g. CO)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by address city",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only employees holding this skill, by name regardless of case (postgres storage only)",
+                        "name": "skill",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "List the employees moved to the archive instead of the current ones (postgres storage only)",
+                        "name": "archived",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 200,
+                        "type": "string",
+                        "description": "Full-text search, ranked by relevance (requires SEARCH_URL, not combinable with skill or archived)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed in each employee, comma separated: skills (postgres storage only)",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the matching employees (default: true), false skips the count and reports total_records and total_pages as -1",
+                        "name": "include_total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.PaginatedResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.EmployeeResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
+                    "304": {
+                        "description": "Page still the If-None-Match ETag (no content)"
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -77,6 +242,12 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -85,6 +256,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             },
@@ -107,7 +290,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Employee"
+                            "$ref": "#/definitions/models.CreateEmployeeRequest"
                         }
                     }
                 ],
@@ -115,7 +298,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Employee created successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.Employee"
+                            "$ref": "#/definitions/models.EmployeeResponse"
                         }
                     },
                     "400": {
@@ -135,44 +318,67 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/employees/{id}": {
-            "get": {
-                "description": "Retrieves an employee by its ID",
+        "/employees/bulk-update": {
+            "post": {
+                "description": "Sets the department and/or position of every employee matching the filter, e.g. moves a whole department, in one transaction: all of them are updated or, on an error, none. Retired employees are skipped. The response reports the outcome for each matched employee with the fields changed. With events enabled every updated employee gets an employee.updated event, stored in the same transaction. At most 1000 employees can be matched. With dry_run=true the request is validated and the outcomes reported without changing anything",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Employees"
                 ],
-                "summary": "Get employee by ID",
+                "summary": "Bulk update employees",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Employee ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter and changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Report what would change without changing it",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Employee found",
+                        "description": "Outcome per matched employee",
                         "schema": {
-                            "$ref": "#/definitions/models.Employee"
+                            "$ref": "#/definitions/models.BulkUpdateResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid ID format",
+                        "description": "Invalid JSON format, validation failed or empty filter or changes",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Employee not found",
+                    "422": {
+                        "description": "The filter matches more than 1000 employees",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -182,203 +388,2802 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing employee",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/employees/calendar.ics": {
+            "get": {
+                "description": "Returns an iCalendar feed of the work anniversaries and birthdays of the employees who are not retired, from 30 days ago to a year ahead, for calendar applications to subscribe to. Birthdays do not tell the age",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Get the anniversaries and birthdays calendar",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only employees of these departments, given repeated or comma separated",
+                        "name": "department",
+                        "in": "query"
+                    }
                 ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/number-format": {
+            "get": {
+                "description": "Returns the regular expression employee numbers must match, set by the deployment with EMPLOYEE_NUMBER_PATTERN, so clients can check them before submitting",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Employees"
                 ],
-                "summary": "Update employee",
+                "summary": "Employee number format",
+                "responses": {
+                    "200": {
+                        "description": "Employee number format",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeNumberFormat"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/report.pdf": {
+            "get": {
+                "description": "Renders a printable PDF of the employees matching the filters: a summary with the headcount, hires of the last year, average tenure and the headcount by status and department, then the roster sorted by department and name. Retired employees are left out unless status asks for them",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Get the employee report as PDF",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Employee ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by department, any of several values given repeated or comma separated",
+                        "name": "department",
+                        "in": "query"
                     },
                     {
-                        "description": "Updated employee data",
-                        "name": "employee",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Employee"
-                        }
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by status (ACTIVE, ON_VACATION, RETIRED, PROBATION), any of several values given repeated or comma separated",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Employee updated successfully",
+                        "description": "PDF report",
                         "schema": {
-                            "$ref": "#/definitions/models.Employee"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Invalid JSON format or validation failed",
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Employee not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Email or employee number already exists",
+                    "503": {
+                        "description": "Database temporarily unavailable",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "504": {
+                        "description": "Request timed out",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes an employee by ID",
+            }
+        },
+        "/employees/snapshot": {
+            "get": {
+                "description": "Lists every employee by id with the version of its latest event, for services building their own copy of the employees from the events. Page through it with after set to the nextAfter of the previous page, then apply only events with a higher version than the employee's",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Employees"
                 ],
-                "summary": "Delete employee",
+                "summary": "Employee snapshot",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Employee ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 0,
+                        "description": "Return employees with a greater id",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 500,
+                        "description": "Page size (max 1000)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Employee deleted successfully (no content)"
+                    "200": {
+                        "description": "Snapshot page",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.SnapshotPage"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.VersionedEmployee"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
                     "400": {
-                        "description": "Invalid ID format",
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Employee not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "503": {
+                        "description": "Database temporarily unavailable",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/stream": {
+            "get": {
+                "description": "Pushes employee.created, employee.updated, employee.status_changed and employee.deleted events over Server-Sent Events. Send Last-Event-ID to resume after a disconnect",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Employee change stream",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sequence of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of events",
+                        "schema": {
+                            "$ref": "#/definitions/events.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Last-Event-ID",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/vcard": {
+            "get": {
+                "description": "Returns the vCards of the employees matching the filters in one file, for a bulk import into address books. Retired employees are left out unless status asks for them",
+                "produces": [
+                    "text/vcard"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Get the vCards of many employees",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by department, any of several values given repeated or comma separated",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by status (ACTIVE, ON_VACATION, RETIRED, PROBATION), any of several values given repeated or comma separated",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "vCards",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/verify-email": {
+            "get": {
+                "description": "Marks the email of the employee as verified, from the link of the verification email sent when the employee was created or their email changed. Tokens expire after EMAIL_VERIFICATION_TTL and stop working once the email changes. Verifying an email again returns the employee unchanged",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Verify an employee email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token of the link",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email verified",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired verification token, or email verification not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/ws": {
+            "get": {
+                "description": "Upgrades to a WebSocket that pushes employee events. The initial filter comes from the query, the client can replace it at any time by sending a filter object as JSON. Clients that fall behind are disconnected with close code 1013 and can resume with since",
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Employee changes over WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only events for this department",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events for employees in this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only events for this employee",
+                        "name": "employeeId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated event types",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Replay events after this sequence first",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols"
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}": {
+            "get": {
+                "description": "Retrieves an employee by its ID",
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Get employee by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID, or uuid with ID_FORMAT=uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed, comma separated: skills (postgres storage only)",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Employee found",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified since If-Modified-Since, or still the If-None-Match ETag (no content)"
+                    },
+                    "400": {
+                        "description": "Invalid ID format or include",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "406": {
+                        "description": "No acceptable representation",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing employee",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Update employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID, or uuid with ID_FORMAT=uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated employee data",
+                        "name": "employee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateEmployeeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Employee updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email or employee number already exists, the employee is retired or the status change is not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an employee by ID. With dry_run=true nothing is deleted, the employee that would be is returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Delete employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID, or uuid with ID_FORMAT=uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the employee that would be deleted without deleting it",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Employee that would be deleted (dry run)",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "204": {
+                        "description": "Employee deleted successfully (no content)"
+                    },
+                    "400": {
+                        "description": "Invalid ID format or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Applies a JSON Patch (RFC 6902) or JSON Merge Patch (RFC 7396) to the employee as PUT takes it, then validates and saves the result like PUT. Merge patches set members and remove optional ones with null; JSON Patch operations address them by pointer, e.g. {\"op\": \"remove\", \"path\": \"/phone\"}, all of them or none applied",
+                "consumes": [
+                    "application/json-patch+json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Patch employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID, or uuid with ID_FORMAT=uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "JSON Patch operations (an array of patch.Operation) or a merge patch of models.UpdateEmployeeRequest",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {}
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Employee updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid patch or the patched employee failed validation",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A test operation failed, or as for PUT",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported patch media type",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Patch does not apply to the employee",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/badge.png": {
+            "get": {
+                "description": "Renders a PNG QR code, or Code 128 barcode, of the employee number for badge printing and check-in kiosks. With signed=true it encodes a token \"\u003cemployee number\u003e.\u003cexpiry unix\u003e.\u003csignature\u003e\" instead, the signature the unpadded base64url HMAC-SHA256 of the rest with BADGE_SIGNING_KEY, which kiosks verify offline",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Get an employee badge code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID, or uuid with ID_FORMAT=uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "qr",
+                            "code128"
+                        ],
+                        "type": "string",
+                        "default": "qr",
+                        "description": "Kind of code",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1024,
+                        "minimum": 64,
+                        "type": "integer",
+                        "default": 256,
+                        "description": "Width in pixels, more when the content needs it",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Encode a signed token (requires BADGE_SIGNING_KEY)",
+                        "name": "signed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Badge code",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/rehire": {
+            "post": {
+                "description": "Moves a retired employee back to ACTIVE, the only way out of RETIRED",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Rehire employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID, or uuid with ID_FORMAT=uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Employee rehired",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Employee is not retired",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/skills": {
+            "get": {
+                "description": "Retrieves the skills of an employee by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "List employee skills",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID, or uuid with ID_FORMAT=uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Skills of the employee",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EmployeeSkill"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/skills/{skillId}": {
+            "put": {
+                "description": "Gives an employee a skill of the catalog, or changes its proficiency when already assigned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "Assign a skill",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID, or uuid with ID_FORMAT=uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Skill ID",
+                        "name": "skillId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Proficiency",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SkillAssignmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Skill assigned",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeSkill"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee or skill not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a skill from an employee",
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "Unassign a skill",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID, or uuid with ID_FORMAT=uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Skill ID",
+                        "name": "skillId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Skill unassigned successfully (no content)"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee does not have the skill",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/vcard": {
+            "get": {
+                "description": "Returns the contact details of the employee as a vCard 4.0 (RFC 6350): name, work email, position and department, for importing into address books",
+                "produces": [
+                    "text/vcard"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Get the vCard of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID, or uuid with ID_FORMAT=uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "vCard",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/verification-email": {
+            "post": {
+                "description": "Sends the employee a new email verification link, through an employee.email_verification_requested event delivered by the notification service. Requires EMAIL_VERIFICATION and events enabled",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Resend the verification email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee ID, or uuid with ID_FORMAT=uuid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Verification email requested"
+                    },
+                    "400": {
+                        "description": "Invalid ID format, or email verification not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email is already verified",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database temporarily unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/features": {
+            "get": {
+                "description": "Returns every known feature flag and which ones are active",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Features"
+                ],
+                "summary": "List feature flags",
+                "responses": {
+                    "200": {
+                        "description": "Feature flags",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.FeaturesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "description": "Executes a GraphQL query or mutation. Errors are reported in the errors array with extensions.code (NOT_FOUND, CONFLICT, BAD_USER_INPUT, UNAVAILABLE, TIMEOUT, INTERNAL)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GraphQL"
+                ],
+                "summary": "GraphQL endpoint",
+                "parameters": [
+                    {
+                        "description": "GraphQL operation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GraphQLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GraphQL result",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/retention/audit": {
+            "get": {
+                "description": "Retrieves the employees anonymized or deleted by the retention policy, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Retention"
+                ],
+                "summary": "Retention audit log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default: 20, max: 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit entries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RetentionAuditEntry"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/retention/report": {
+            "get": {
+                "description": "Lists the employees retired for longer than the retention period, which the next purge would anonymize or delete. Nothing is changed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Retention"
+                ],
+                "summary": "Retention dry run",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum employees listed (max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Retention report",
+                        "schema": {
+                            "$ref": "#/definitions/models.RetentionReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/skills": {
+            "get": {
+                "description": "Retrieves the skills catalog by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "List skills",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only skills of this category",
+                        "name": "category",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Skills",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Skill"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a skill to the catalog. Names are unique regardless of case",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "Add a skill",
+                "parameters": [
+                    {
+                        "description": "Skill data",
+                        "name": "skill",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SkillRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Skill created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Skill"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Skill already exists",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/skills/{id}": {
+            "get": {
+                "description": "Retrieves a skill of the catalog by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "Get skill by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Skill ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Skill found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Skill"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Skill not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a skill from the catalog. Skills still assigned to employees cannot be deleted",
+                "tags": [
+                    "Skills"
+                ],
+                "summary": "Delete skill",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Skill ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Skill deleted successfully (no content)"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Skill not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Skill is assigned to employees",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/slo": {
+            "get": {
+                "description": "Returns, per route requested within the SLO window, its objective, the requests counted and how fast its error budget burns, the fastest burning first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SLO"
+                ],
+                "summary": "Get the SLO error budget burn",
+                "responses": {
+                    "200": {
+                        "description": "Error budget burn per route",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SLOResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Retrieves every webhook subscription",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "Webhook subscriptions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookSubscription"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a url receiving the given event types (\"*\" for all), signed with the secret",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Webhook registered successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "description": "Retrieves a webhook subscription by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get webhook by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook found",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a webhook subscription and its delivery log",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Webhook deleted successfully (no content)"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Retrieves the deliveries of a webhook, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Webhook delivery log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default: 20, max: 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deliveries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.ErrorDetail": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the key of the message, the field and the failed rule (e.g.\naddress.city.notblank), for clients showing messages of their own",
+                    "type": "string",
+                    "example": "email.email_address"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "params": {
+                    "description": "Params are the values filled in the message, e.g. the country of a\npostal code rule",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rejectedValue": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ErrorResponse": {
+            "description": "Standard error response structure",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ErrorDetail"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Can hold any slice ([]models.Employee to be concrete). Maybe \"any\" can be replaced by interface{}?"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "has_next": {
+                    "type": "boolean"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "description": "-1 when not counted",
+                    "type": "integer"
+                },
+                "total_records": {
+                    "description": "-1 when not counted",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.SnapshotPage": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data holds the employees by id, each with its latest event version"
+                },
+                "nextAfter": {
+                    "description": "NextAfter is the after value of the next page, 0 on the last page",
+                    "type": "integer",
+                    "example": 500
+                }
+            }
+        },
+        "events.Event": {
+            "type": "object",
+            "properties": {
+                "aggregateId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "sequence": {
+                    "description": "Sequence is the outbox position of the event, set when read back\nfrom the outbox. It orders events and lets streams resume",
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/events.Type"
+                },
+                "version": {
+                    "description": "Version numbers the events of the aggregate from 1 without gaps,\nset when stored in the outbox. A consumer that sees a version more\nthan one past the last it applied has missed an event",
+                    "type": "integer"
+                }
+            }
+        },
+        "events.Type": {
+            "type": "string",
+            "enum": [
+                "employee.created",
+                "employee.updated",
+                "employee.deleted",
+                "employee.status_changed",
+                "employee.anniversary_upcoming",
+                "employee.birthday_upcoming",
+                "employee.email_verification_requested"
+            ],
+            "x-enum-varnames": [
+                "EmployeeCreated",
+                "EmployeeUpdated",
+                "EmployeeDeleted",
+                "EmployeeStatusChanged",
+                "EmployeeAnniversaryUpcoming",
+                "EmployeeBirthdayUpcoming",
+                "EmployeeEmailVerificationRequested"
+            ]
+        },
+        "features.Flag": {
+            "type": "string",
+            "enum": [
+                "events",
+                "caching"
+            ],
+            "x-enum-varnames": [
+                "Events",
+                "Caching"
+            ]
+        },
+        "handlers.FeaturesResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/features.Flag"
+                    }
+                },
+                "flags": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                }
+            }
+        },
+        "handlers.GraphQLRequest": {
+            "type": "object",
+            "required": [
+                "query"
+            ],
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "handlers.SLOResponse": {
+            "type": "object",
+            "properties": {
+                "routes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/slo.RouteReport"
+                    }
+                },
+                "window": {
+                    "type": "string",
+                    "example": "1h0m0s"
+                }
+            }
+        },
+        "handlers.SkillAssignmentRequest": {
+            "type": "object",
+            "properties": {
+                "proficiency": {
+                    "enum": [
+                        "BEGINNER",
+                        "INTERMEDIATE",
+                        "ADVANCED",
+                        "EXPERT"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Proficiency"
+                        }
+                    ]
+                }
+            }
+        },
+        "handlers.SkillRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Programming languages"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Go"
+                }
+            }
+        },
+        "handlers.WebhookRequest": {
+            "type": "object",
+            "required": [
+                "eventTypes"
+            ],
+            "properties": {
+                "eventTypes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Address": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Armenia"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "CO"
+                },
+                "postalCode": {
+                    "type": "string",
+                    "example": "630004"
+                },
+                "state": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Quindio"
+                },
+                "street": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Carrera 15 # 12N-45"
+                }
+            }
+        },
+        "models.BulkChanges": {
+            "type": "object",
+            "properties": {
+                "department": {
+                    "type": "string",
+                    "example": "Engineering"
+                },
+                "position": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BulkFilter": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "CO"
+                },
+                "department": {
+                    "type": "string",
+                    "example": "Sales"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "status": {
+                    "enum": [
+                        "ACTIVE",
+                        "ON_VACATION",
+                        "RETIRED",
+                        "PROBATION"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.EmployeeStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.BulkResult": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Changes are the fields updated, or that would be in a dry run",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldChange"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "Reason says why the employee was skipped",
+                    "type": "string",
+                    "example": "Retired employees cannot be edited"
+                },
+                "result": {
+                    "type": "string",
+                    "enum": [
+                        "updated",
+                        "unchanged",
+                        "skipped"
+                    ]
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "0b6f6a3e-5d1c-4f7e-9a51-3c2f8d9e4b10"
+                }
+            }
+        },
+        "models.BulkUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "$ref": "#/definitions/models.BulkFilter"
+                },
+                "set": {
+                    "$ref": "#/definitions/models.BulkChanges"
+                }
+            }
+        },
+        "models.BulkUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "matched": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CreateEmployeeRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "employeeNumber"
+            ],
+            "properties": {
+                "address": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Address"
+                        }
+                    ],
+                    "x-nullable": true
+                },
+                "dateOfBirth": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "1990-05-17"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "employeeNumber": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "gender": {
+                    "enum": [
+                        "FEMALE",
+                        "MALE",
+                        "NON_BINARY",
+                        "UNDISCLOSED"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Gender"
+                        }
+                    ],
+                    "x-nullable": true
+                },
+                "hireDate": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "2024-03-01"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "nationalId": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "1094123456"
+                },
+                "personalEmail": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "x-nullable": true,
+                    "example": "jane.doe@gmail.com"
+                },
+                "phone": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "+57 300 123 4567"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "probationEndDate": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "2024-06-01"
+                }
+            }
+        },
+        "models.DeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "DELIVERED",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
+                "DeliveryPending",
+                "DeliveryDelivered",
+                "DeliveryFailed"
+            ]
+        },
+        "models.DirectoryResult": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Changes are the fields taken from the directory, or that would be\nin a dry run",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldChange"
+                    }
+                },
+                "conflicts": {
+                    "description": "Conflicts are the fields edited in the service since the last sync\nthat the directory disagrees with, left as they are: From is the\nvalue of the service, To the value of the directory",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldChange"
+                    }
+                },
+                "dn": {
+                    "description": "DN is the distinguished name of the directory entry",
+                    "type": "string",
+                    "example": "uid=jdoe,ou=people,dc=example,dc=com"
+                },
+                "employeeNumber": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID is the employee, 0 when none was created or matched",
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "Reason says why the entry is a conflict",
+                    "type": "string",
+                    "example": "Email already belongs to another employee"
+                },
+                "result": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "updated",
+                        "unchanged",
+                        "conflict"
+                    ]
+                }
+            }
+        },
+        "models.DirectorySyncReport": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "entries": {
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "Error is why the sync stopped before its end, the results up to it\nare kept",
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "missing": {
+                    "description": "Missing are the employee numbers of the employees, retired ones\naside, not found in the directory. They are left as they are",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DirectoryResult"
+                    }
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.EmployeeNumberFormat": {
+            "type": "object",
+            "properties": {
+                "maxLength": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "pattern": {
+                    "type": "string",
+                    "example": "^(?:EMP-\\d{5})$"
+                }
+            }
+        },
+        "models.EmployeeResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Address"
+                        }
+                    ],
+                    "x-nullable": true
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "dateOfBirth": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "1990-05-17"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "emailVerifiedAt": {
+                    "description": "EmailVerifiedAt is when the employee confirmed their email, null\nuntil they do and again once it changes",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "employeeNumber": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "gender": {
+                    "enum": [
+                        "FEMALE",
+                        "MALE",
+                        "NON_BINARY",
+                        "UNDISCLOSED"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Gender"
+                        }
+                    ],
+                    "x-nullable": true
+                },
+                "hireDate": {
+                    "type": "string",
+                    "example": "2024-03-01"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "nationalId": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "1094123456"
+                },
+                "personalEmail": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "jane.doe@gmail.com"
+                },
+                "phone": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "+573001234567"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "probationEndDate": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "2024-06-01"
+                },
+                "skills": {
+                    "description": "Skills are embedded with include=skills, left out of CSV",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EmployeeSkill"
                     }
+                },
+                "status": {
+                    "enum": [
+                        "ACTIVE",
+                        "ON_VACATION",
+                        "RETIRED",
+                        "PROBATION"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.EmployeeStatus"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "0b6f6a3e-5d1c-4f7e-9a51-3c2f8d9e4b10"
                 }
             }
-        }
-    },
-    "definitions": {
-        "api.ErrorDetail": {
+        },
+        "models.EmployeeSkill": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "Programming languages"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Go"
+                },
+                "proficiency": {
+                    "enum": [
+                        "BEGINNER",
+                        "INTERMEDIATE",
+                        "ADVANCED",
+                        "EXPERT"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Proficiency"
+                        }
+                    ]
+                },
+                "skillId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EmployeeStatus": {
+            "type": "string",
+            "enum": [
+                "ACTIVE",
+                "ON_VACATION",
+                "RETIRED",
+                "PROBATION"
+            ],
+            "x-enum-varnames": [
+                "StatusActive",
+                "StatusOnVacation",
+                "StatusRetired",
+                "StatusProbation"
+            ]
+        },
+        "models.FieldChange": {
             "type": "object",
             "properties": {
                 "field": {
+                    "type": "string",
+                    "example": "department"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "Sales"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "Engineering"
+                }
+            }
+        },
+        "models.Gender": {
+            "type": "string",
+            "enum": [
+                "FEMALE",
+                "MALE",
+                "NON_BINARY",
+                "UNDISCLOSED"
+            ],
+            "x-enum-varnames": [
+                "GenderFemale",
+                "GenderMale",
+                "GenderNonBinary",
+                "GenderUndisclosed"
+            ]
+        },
+        "models.Proficiency": {
+            "type": "string",
+            "enum": [
+                "BEGINNER",
+                "INTERMEDIATE",
+                "ADVANCED",
+                "EXPERT"
+            ],
+            "x-enum-varnames": [
+                "ProficiencyBeginner",
+                "ProficiencyIntermediate",
+                "ProficiencyAdvanced",
+                "ProficiencyExpert"
+            ]
+        },
+        "models.RetentionAction": {
+            "type": "string",
+            "enum": [
+                "ANONYMIZE",
+                "DELETE"
+            ],
+            "x-enum-varnames": [
+                "RetentionAnonymize",
+                "RetentionDelete"
+            ]
+        },
+        "models.RetentionAuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "enum": [
+                        "ANONYMIZE",
+                        "DELETE"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RetentionAction"
+                        }
+                    ]
+                },
+                "employeeId": {
+                    "type": "integer"
+                },
+                "employeeNumber": {
                     "type": "string"
                 },
-                "message": {
+                "id": {
+                    "type": "integer"
+                },
+                "purgedAt": {
                     "type": "string"
                 },
-                "rejectedValue": {
+                "retiredAt": {
                     "type": "string"
                 }
             }
         },
-        "api.ErrorResponse": {
-            "description": "Standard error response structure",
+        "models.RetentionCandidate": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
-                },
-                "errors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/api.ErrorDetail"
-                    }
+                "archived": {
+                    "type": "boolean"
                 },
-                "message": {
+                "department": {
                     "type": "string"
                 },
-                "path": {
+                "employeeNumber": {
                     "type": "string"
                 },
-                "status": {
+                "id": {
                     "type": "integer"
                 },
-                "timestamp": {
+                "retiredAt": {
                     "type": "string"
                 }
             }
         },
-        "api.PaginatedResponse": {
+        "models.RetentionReport": {
             "type": "object",
             "properties": {
-                "data": {
-                    "description": "Can hold any slice ([]models.Employee to be concrete). Maybe \"any\" can be replaced by interface{}?"
+                "action": {
+                    "enum": [
+                        "ANONYMIZE",
+                        "DELETE"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RetentionAction"
+                        }
+                    ]
                 },
-                "pagination": {
-                    "$ref": "#/definitions/api.PaginationMeta"
+                "cutoff": {
+                    "type": "string"
+                },
+                "employees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RetentionCandidate"
+                    }
                 }
             }
         },
-        "api.PaginationMeta": {
+        "models.Skill": {
             "type": "object",
             "properties": {
-                "current_page": {
-                    "type": "integer"
+                "category": {
+                    "type": "string",
+                    "example": "Programming languages"
                 },
-                "page_size": {
-                    "type": "integer"
+                "createdAt": {
+                    "type": "string"
                 },
-                "total_pages": {
-                    "type": "integer"
+                "description": {
+                    "type": "string"
                 },
-                "total_records": {
+                "id": {
                     "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Go"
+                }
+            }
+        },
+        "models.UpdateEmployeeRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "employeeNumber"
+            ],
+            "properties": {
+                "address": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Address"
+                        }
+                    ],
+                    "x-nullable": true
+                },
+                "dateOfBirth": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "1990-05-17"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "employeeNumber": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "gender": {
+                    "enum": [
+                        "FEMALE",
+                        "MALE",
+                        "NON_BINARY",
+                        "UNDISCLOSED"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Gender"
+                        }
+                    ],
+                    "x-nullable": true
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "nationalId": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "1094123456"
+                },
+                "personalEmail": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "x-nullable": true,
+                    "example": "jane.doe@gmail.com"
+                },
+                "phone": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "+57 300 123 4567"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "status": {
+                    "enum": [
+                        "ACTIVE",
+                        "ON_VACATION",
+                        "RETIRED",
+                        "PROBATION"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.EmployeeStatus"
+                        }
+                    ]
                 }
             }
         },
-        "models.Employee": {
+        "models.VersionedEmployee": {
             "type": "object",
             "properties": {
+                "address": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Address"
+                        }
+                    ],
+                    "x-nullable": true
+                },
                 "createdAt": {
                     "type": "string"
                 },
+                "dateOfBirth": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "1990-05-17"
+                },
                 "department": {
                     "type": "string"
                 },
                 "email": {
                     "type": "string"
                 },
+                "emailVerifiedAt": {
+                    "type": "string",
+                    "x-nullable": true
+                },
                 "employeeNumber": {
                     "type": "string"
                 },
                 "firstName": {
                     "type": "string"
                 },
+                "gender": {
+                    "enum": [
+                        "FEMALE",
+                        "MALE",
+                        "NON_BINARY",
+                        "UNDISCLOSED"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Gender"
+                        }
+                    ],
+                    "x-nullable": true
+                },
                 "hireDate": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2024-03-01"
                 },
                 "id": {
                     "type": "integer"
@@ -386,29 +3191,150 @@ const docTemplate = `{
                 "lastName": {
                     "type": "string"
                 },
+                "nationalId": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "1094123456"
+                },
+                "personalEmail": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "jane.doe@gmail.com"
+                },
+                "phone": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "+573001234567"
+                },
                 "position": {
                     "type": "string"
                 },
+                "probationEndDate": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "2024-06-01"
+                },
                 "status": {
-                    "$ref": "#/definitions/models.EmployeeStatus"
+                    "enum": [
+                        "ACTIVE",
+                        "ON_VACATION",
+                        "RETIRED",
+                        "PROBATION"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.EmployeeStatus"
+                        }
+                    ]
                 },
                 "updatedAt": {
                     "type": "string"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "0b6f6a3e-5d1c-4f7e-9a51-3c2f8d9e4b10"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "models.EmployeeStatus": {
-            "type": "string",
-            "enum": [
-                "ACTIVE",
-                "ON_VACATION",
-                "RETIRED"
-            ],
-            "x-enum-varnames": [
-                "StatusActive",
-                "StatusOnVacation",
-                "StatusRetired"
-            ]
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "eventId": {
+                    "type": "string"
+                },
+                "eventType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "responseCode": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.DeliveryStatus"
+                },
+                "subscriptionId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "slo.RouteReport": {
+            "type": "object",
+            "properties": {
+                "bad": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "budgetRemaining": {
+                    "description": "BudgetRemaining is the share of the window's error budget left, below\n0 once overspent",
+                    "type": "number",
+                    "example": -1.5
+                },
+                "burnRate": {
+                    "description": "BurnRate is the error rate over the allowed one: 1 spends the budget\nexactly over the window, above 1 faster",
+                    "type": "number",
+                    "example": 2.5
+                },
+                "latency": {
+                    "type": "string",
+                    "example": "300ms"
+                },
+                "requests": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "route": {
+                    "type": "string",
+                    "example": "GET /employees-service/api/v1/employees/:id"
+                },
+                "target": {
+                    "type": "number",
+                    "example": 0.99
+                }
+            }
         }
     }
 }`
@@ -417,7 +3343,7 @@ const docTemplate = `{
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
 	Host:             "localhost:8081",
-	BasePath:         "/employees-service/api",
+	BasePath:         "/employees-service/api/v1",
 	Schemes:          []string{},
 	Title:            "Employee Management API",
 	Description:      "API for managing employees",
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDKey is the context key holding the id of the request
+const RequestIDKey = "requestID"
+
+// EnvelopedKey is set in the context of requests answered with an
+// envelope, whose bodies no longer match the API spec
+const EnvelopedKey = "enveloped"
+
+// envelopeDefault is whether JSON success bodies are enveloped when the
+// client does not ask, see SetEnvelope
+var envelopeDefault bool
+
+// SetEnvelope sets whether JSON success bodies are enveloped by default,
+// clients still choose per request with the envelope parameter of Accept
+func SetEnvelope(enabled bool) {
+	envelopeDefault = enabled
+}
+
+// Envelope is the uniform body of JSON success responses
+//
+//	@Description	Success response envelope, see RESPONSE_ENVELOPE
+type Envelope struct {
+	Data      any    `json:"data"`
+	Meta      any    `json:"meta,omitempty"`
+	RequestID string `json:"request_id,omitempty" example:"5f0c6a3e-8f0e-4c1b-9a4e-2d8b7c6f1e90"`
+}
+
+// Enveloper is implemented by bodies that split into data and meta, such
+// as pages, instead of being enveloped whole as data
+type Enveloper interface {
+	EnvelopeParts() (data, meta any)
+}
+
+// RequestID returns the id the request is logged and answered with
+func RequestID(c *gin.Context) string {
+	return c.GetString(RequestIDKey)
+}
+
+// Success writes v as JSON, in an envelope if the client or the
+// configuration asks for one. Use Respond for bodies with more than one
+// representation
+func Success(c *gin.Context, status int, v any) {
+	if wantsEnvelope(c) {
+		v = envelope(c, v)
+	}
+	c.JSON(status, v)
+}
+
+// envelope wraps v with the request id, marking the request as enveloped
+func envelope(c *gin.Context, v any) Envelope {
+	c.Set(EnvelopedKey, true)
+	env := Envelope{Data: v, RequestID: RequestID(c)}
+	if e, ok := v.(Enveloper); ok {
+		env.Data, env.Meta = e.EnvelopeParts()
+	}
+	return env
+}
+
+// wantsEnvelope reads the envelope parameter of the JSON media range in
+// Accept, e.g. "application/json; envelope=true", falling back to the
+// configured default
+func wantsEnvelope(c *gin.Context) bool {
+	for _, part := range strings.Split(c.GetHeader("Accept"), ",") {
+		mediaType, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(mediaType), gin.MIMEJSON) {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(key, "envelope") {
+				continue
+			}
+			if enabled, err := strconv.ParseBool(strings.Trim(value, `"`)); err == nil {
+				return enabled
+			}
+		}
+	}
+	return envelopeDefault
+}
@@ -1,25 +1,75 @@
 package api
 
+import (
+	"encoding/xml"
+	"slices"
+	"strings"
+)
+
 // PaginationQuery represents common pagination query parameters
 // It can be used with Gin's ShouldBindQuery.
 type PaginationQuery struct {
-	Page       int    `form:"page" json:"page" binding:"omitempty,min=1"`
-	PageSize   int    `form:"page_size" json:"page_size" binding:"omitempty,min=1,max=100"`
-	Department string `form:"department" json:"department"`
-	Status     string `form:"status" json:"status" binding:"omitempty,oneof=ACTIVE ON_VACATION RETIRED"`
-	Position   string `form:"position" json:"position"`
+	Page     int `form:"page" json:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" json:"page_size" binding:"omitempty,min=1,max=100"`
+	// Department, Status and Position match any of their values, given
+	// repeated or comma separated
+	Department []string `form:"department" json:"department"`
+	Status     []string `form:"status" json:"status"`
+	Position   []string `form:"position" json:"position"`
+	Country    string   `form:"country" json:"country" binding:"omitempty,len=2"`
+	City       string   `form:"city" json:"city"`
+	Skill      string   `form:"skill" json:"skill"`
+	Archived   bool     `form:"archived" json:"archived"`
+	Q          string   `form:"q" json:"q" binding:"omitempty,max=200"`
+
+	// IncludeTotal false skips counting the matching employees, nil
+	// counts them
+	IncludeTotal *bool `form:"include_total" json:"include_total"`
+}
+
+// WithTotal reports whether the list should count the matching employees
+func (q PaginationQuery) WithTotal() bool {
+	return q.IncludeTotal == nil || *q.IncludeTotal
+}
+
+// Values splits the values of a multi-value filter, repeated
+// (status=ACTIVE&status=RETIRED) or comma separated
+// (status=ACTIVE,RETIRED), dropping blanks and duplicates
+func Values(params []string) []string {
+	var values []string
+	for _, param := range params {
+		for _, v := range strings.Split(param, ",") {
+			if v = strings.TrimSpace(v); v != "" && !slices.Contains(values, v) {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
 }
 
 // PaginatedResponse is a generic structure for paginated results
 type PaginatedResponse struct {
-	Data       any            `json:"data"` // Can hold any slice ([]models.Employee to be concrete). Maybe "any" can be replaced by interface{}?
-	Pagination PaginationMeta `json:"pagination"`
+	XMLName    xml.Name       `json:"-" xml:"response" swaggerignore:"true"`
+	Data       any            `json:"data" xml:"data>item"` // Can hold any slice ([]models.Employee to be concrete). Maybe "any" can be replaced by interface{}?
+	Pagination PaginationMeta `json:"pagination" xml:"pagination"`
+}
+
+// CSVBody renders only the data as CSV, the pagination is left to the
+// caller (e.g. response headers)
+func (p PaginatedResponse) CSVBody() any {
+	return p.Data
+}
+
+// EnvelopeParts puts the pagination in the meta of the envelope
+func (p PaginatedResponse) EnvelopeParts() (data, meta any) {
+	return p.Data, p.Pagination
 }
 
 // PaginationMeta contains metadata about the pagination
 type PaginationMeta struct {
-	CurrentPage  int `json:"current_page"`
-	PageSize     int `json:"page_size"`
-	TotalPages   int `json:"total_pages"`
-	TotalRecords int `json:"total_records"`
+	CurrentPage  int  `json:"current_page" xml:"current_page"`
+	PageSize     int  `json:"page_size" xml:"page_size"`
+	TotalPages   int  `json:"total_pages" xml:"total_pages"`     // -1 when not counted
+	TotalRecords int  `json:"total_records" xml:"total_records"` // -1 when not counted
+	HasNext      bool `json:"has_next" xml:"has_next"`
 }
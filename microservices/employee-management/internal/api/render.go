@@ -0,0 +1,299 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Renderer writes a response body in one media type
+type Renderer interface {
+	ContentType() string
+	Render(w io.Writer, v any) error
+}
+
+// renderers are the negotiable representations, the first one is used
+// when the client does not ask for a specific type
+var renderers = []Renderer{jsonRenderer{}, xmlRenderer{}, csvRenderer{}}
+
+// RegisterRenderer adds a representation, replacing any renderer for the
+// same content type
+func RegisterRenderer(r Renderer) {
+	for i, existing := range renderers {
+		if existing.ContentType() == r.ContentType() {
+			renderers[i] = r
+			return
+		}
+	}
+	renderers = append(renderers, r)
+}
+
+// Respond writes v in the representation selected by the Accept header,
+// or 406 if none of the registered renderers is acceptable. JSON is
+// enveloped as Success does. Successful GETs carry an ETag and answer
+// 304 without a body to conditional requests for what the client has
+func Respond(c *gin.Context, status int, v any) {
+	offers := make([]string, len(renderers))
+	for i, r := range renderers {
+		offers[i] = r.ContentType()
+	}
+
+	format := negotiate(c.GetHeader("Accept"), offers)
+	if format == "" {
+		Error(c, http.StatusNotAcceptable, "No acceptable representation")
+		return
+	}
+
+	for _, r := range renderers {
+		if r.ContentType() == format {
+			c.Header("Vary", "Accept")
+			if status == http.StatusOK && c.Request.Method == http.MethodGet && notModified(c, format, v) {
+				c.Status(http.StatusNotModified)
+				c.Writer.WriteHeaderNow()
+				return
+			}
+			if format == gin.MIMEJSON && wantsEnvelope(c) {
+				v = envelope(c, v)
+			}
+			c.Render(status, render{renderer: r, value: v})
+			return
+		}
+	}
+}
+
+// notModified sets the ETag of v in format, a hash of the value rather
+// than of the body so the request id of an envelope does not change it,
+// and reports whether the conditional headers of the request say the
+// client already has it: If-None-Match matching the ETag or, without
+// it, If-Modified-Since no older than the Last-Modified set by the
+// handler
+func notModified(c *gin.Context, format string, v any) bool {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+	sum := sha256.Sum256(append([]byte(format+"\n"), data...))
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+
+	if inm := c.GetHeader("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	ims, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	lastModified, err := http.ParseTime(c.Writer.Header().Get("Last-Modified"))
+	return err == nil && !lastModified.After(ims)
+}
+
+// negotiate picks the offer with the highest quality in accept, earlier
+// offers win ties. An empty Accept gets the first offer
+func negotiate(accept string, offers []string) string {
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		q := 0.0
+		specificity := -1
+		for _, part := range strings.Split(accept, ",") {
+			mediaType, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+			mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+			s := matchSpecificity(mediaType, offer)
+			if s < 0 || s < specificity {
+				continue
+			}
+
+			weight := 1.0
+			for _, param := range strings.Split(params, ";") {
+				key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if ok && strings.EqualFold(key, "q") {
+					if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+						weight = parsed
+					}
+				}
+			}
+
+			// The most specific matching range decides the quality
+			if s > specificity || weight > q {
+				q, specificity = weight, s
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+
+	return best
+}
+
+// matchSpecificity reports how specifically mediaRange matches offer:
+// 2 exact, 1 type/*, 0 */*, -1 no match
+func matchSpecificity(mediaRange, offer string) int {
+	switch {
+	case mediaRange == offer:
+		return 2
+	case mediaRange == "*/*" || mediaRange == "*":
+		return 0
+	case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(offer, strings.TrimSuffix(mediaRange, "*")):
+		return 1
+	default:
+		return -1
+	}
+}
+
+// render adapts a Renderer to gin's render.Render
+type render struct {
+	renderer Renderer
+	value    any
+}
+
+func (r render) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return r.renderer.Render(w, r.value)
+}
+
+func (r render) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", r.renderer.ContentType()+"; charset=utf-8")
+}
+
+type jsonRenderer struct{}
+
+func (jsonRenderer) ContentType() string { return gin.MIMEJSON }
+
+func (jsonRenderer) Render(w io.Writer, v any) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+type xmlRenderer struct{}
+
+func (xmlRenderer) ContentType() string { return gin.MIMEXML }
+
+func (xmlRenderer) Render(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(v)
+}
+
+// CSVBody is implemented by envelopes whose CSV representation is only
+// part of the value, e.g. the data of a paginated response
+type CSVBody interface {
+	CSVBody() any
+}
+
+type csvRenderer struct{}
+
+func (csvRenderer) ContentType() string { return "text/csv" }
+
+// Render writes a struct or a slice of structs as CSV, one column per
+// exported field named after its json tag, except fields tagged csv:"-"
+func (csvRenderer) Render(w io.Writer, v any) error {
+	if b, ok := v.(CSVBody); ok {
+		v = b.CSVBody()
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	rows := []reflect.Value{rv}
+	if rv.Kind() == reflect.Slice {
+		rows = make([]reflect.Value, rv.Len())
+		for i := range rows {
+			rows[i] = reflect.Indirect(rv.Index(i))
+		}
+	}
+
+	elem := rv.Type()
+	if rv.Kind() == reflect.Slice {
+		elem = elem.Elem()
+		if elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+	}
+	if elem.Kind() != reflect.Struct {
+		return errors.New("csv: value is not a struct or slice of structs")
+	}
+
+	var header []string
+	var fields []int
+	for i := 0; i < elem.NumField(); i++ {
+		f := elem.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if !f.IsExported() || name == "-" || f.Tag.Get("csv") == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		header = append(header, name)
+		fields = append(fields, i)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(fields))
+	for _, row := range rows {
+		for i, idx := range fields {
+			record[i] = csvValue(row.Field(idx))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvValue formats a single cell
+func csvValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch val := v.Interface().(type) {
+	case time.Time:
+		if val.IsZero() {
+			return ""
+		}
+		return val.Format(time.RFC3339)
+	case fmt.Stringer:
+		return val.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Struct:
+		data, _ := json.Marshal(v.Interface())
+		return string(data)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
@@ -2,17 +2,67 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
+	"employee-management/internal/i18n"
+
 	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
 )
 
 // ErrorDetail represents a specific validation error
 type ErrorDetail struct {
-	Field         string `json:"field"`
-	Message       string `json:"message"`
-	RejectedValue string `json:"rejectedValue,omitempty"`
+	Field string `json:"field"`
+	// Code is the key of the message, the field and the failed rule (e.g.
+	// address.city.notblank), for clients showing messages of their own
+	Code    string `json:"code,omitempty" example:"email.email_address"`
+	Message string `json:"message"`
+	// Params are the values filled in the message, e.g. the country of a
+	// postal code rule
+	Params        []string `json:"params,omitempty"`
+	RejectedValue string   `json:"rejectedValue,omitempty"`
+
+	// format is the message before its params are filled in, what gets
+	// translated
+	format string
+}
+
+// NewErrorDetail creates the error detail of field with the message code,
+// format filled in with params
+func NewErrorDetail(field, code, format string, params ...string) ErrorDetail {
+	detail := ErrorDetail{Field: field, Code: code, Message: format, Params: params, format: format}
+	if len(params) > 0 {
+		detail.Message = fmt.Sprintf(format, toArgs(params)...)
+	}
+	return detail
+}
+
+// Localize returns the details with their messages translated into lang
+func Localize(lang language.Tag, details []ErrorDetail) []ErrorDetail {
+	translated := make([]ErrorDetail, len(details))
+	for i, detail := range details {
+		switch {
+		case detail.format == "":
+			detail.Message = i18n.T(lang, detail.Message)
+		case len(detail.Params) > 0:
+			detail.Message = fmt.Sprintf(i18n.T(lang, detail.format), toArgs(detail.Params)...)
+		default:
+			detail.Message = i18n.T(lang, detail.format)
+		}
+		translated[i] = detail
+	}
+	return translated
+}
+
+// toArgs converts params into Sprintf arguments
+func toArgs(params []string) []interface{} {
+	args := make([]interface{}, len(params))
+	for i, p := range params {
+		args[i] = p
+	}
+	return args
 }
 
 // ErrorResponse is the standart struct for error response
@@ -28,32 +78,43 @@ type ErrorResponse struct {
 }
 
 // ValidationError creates a validation error response
+// Messages are translated to the language negotiated from Accept-Language
 func ValidationError(c *gin.Context, status int, message string, errors []ErrorDetail) {
+	lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	c.Header("Content-Language", lang.String())
+
 	response := ErrorResponse{
 		Status:    status,
 		Error:     http.StatusText(status),
-		Message:   message,
+		Message:   i18n.T(lang, message),
 		Timestamp: time.Now().UTC(),
 		Path:      c.Request.URL.Path,
-		Errors:    errors,
+		Errors:    Localize(lang, errors),
 	}
 	c.JSON(status, response)
 }
 
 // Error creates a simple error response
 func Error(c *gin.Context, status int, message string) {
+	lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	c.Header("Content-Language", lang.String())
+
 	response := ErrorResponse{
 		Status:    status,
 		Error:     http.StatusText(status),
-		Message:   message,
+		Message:   i18n.T(lang, message),
 		Timestamp: time.Now().UTC(),
 		Path:      c.Request.URL.Path,
 	}
 	c.JSON(status, response)
 }
 
-// InternalServerError for 500 errors
-func InternalServerError(c *gin.Context, message string) {
+// InternalServerError for 500 errors. The cause is kept in c.Errors for
+// the error handler to log and report, clients only get the message
+func InternalServerError(c *gin.Context, err error, message string) {
+	if err != nil {
+		_ = c.Error(err)
+	}
 	Error(c, http.StatusInternalServerError, message)
 }
 
@@ -67,6 +128,16 @@ func NotFound(c *gin.Context, message string) {
 	Error(c, http.StatusNotFound, message)
 }
 
+// ServiceUnavailable for 503 errors
+func ServiceUnavailable(c *gin.Context, message string) {
+	Error(c, http.StatusServiceUnavailable, message)
+}
+
+// GatewayTimeout for 504 errors
+func GatewayTimeout(c *gin.Context, message string) {
+	Error(c, http.StatusGatewayTimeout, message)
+}
+
 // Conflict for 409 errors
 func Conflict(c *gin.Context, message string) {
 	Error(c, http.StatusConflict, message)
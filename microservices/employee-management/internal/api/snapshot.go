@@ -0,0 +1,25 @@
+package api
+
+// SnapshotQuery represents the query parameters of the employee snapshot
+type SnapshotQuery struct {
+	After int64 `form:"after" binding:"omitempty,min=0"`
+	Limit int   `form:"limit" binding:"omitempty,min=1,max=1000"`
+}
+
+// SnapshotPage is one page of the employee snapshot
+type SnapshotPage struct {
+	// Data holds the employees by id, each with its latest event version
+	Data any `json:"data"`
+	// NextAfter is the after value of the next page, 0 on the last page
+	NextAfter int64 `json:"nextAfter" example:"500"`
+}
+
+// SnapshotMeta is the meta of an enveloped snapshot page
+type SnapshotMeta struct {
+	NextAfter int64 `json:"nextAfter"`
+}
+
+// EnvelopeParts puts the next page position in the meta of the envelope
+func (p SnapshotPage) EnvelopeParts() (data, meta any) {
+	return p.Data, SnapshotMeta{NextAfter: p.NextAfter}
+}
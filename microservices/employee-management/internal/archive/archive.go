@@ -0,0 +1,88 @@
+// Package archive moves employees retired long ago out of the employees
+// table, keeping the table every list query scans small
+package archive
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"employee-management/internal/cache"
+	"employee-management/internal/jobs"
+	"employee-management/internal/repository"
+)
+
+// batchSize is how many employees are moved per statement
+const batchSize = 500
+
+// Archiver moves employees retired for more than a number of months to
+// the archive
+type Archiver struct {
+	repo   repository.ArchiveRepository
+	months int
+
+	// cache, if not nil, drops the archived employees, which are no
+	// longer found by id
+	cache cache.Cache
+}
+
+// NewArchiver creates a new Archiver
+func NewArchiver(repo repository.ArchiveRepository, months int, c cache.Cache) *Archiver {
+	return &Archiver{repo: repo, months: months, cache: c}
+}
+
+// ArchiveOnce moves every employee retired for more than the configured
+// months, a batch at a time, and returns how many were moved
+func (a *Archiver) ArchiveOnce(ctx context.Context) (int, error) {
+	cutoff := time.Now().AddDate(0, -a.months, 0)
+	archived := 0
+
+	for {
+		ids, err := a.repo.Archive(ctx, cutoff, batchSize)
+		if err != nil {
+			return archived, err
+		}
+		archived += len(ids)
+
+		if a.cache != nil && len(ids) > 0 {
+			if err := repository.EvictEmployees(ctx, a.cache, ids...); err != nil {
+				log.Printf("cache eviction of archived employees failed: %v", err)
+			}
+		}
+
+		if len(ids) < batchSize {
+			return archived, nil
+		}
+	}
+}
+
+// Run queues an archive run on pool every interval until ctx is done
+func (a *Archiver) Run(ctx context.Context, interval time.Duration, pool *jobs.Pool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := pool.Submit(jobs.Task{Name: "archive", Run: a.archive}); err != nil {
+			log.Printf("employee archive not queued: %v", err)
+		}
+	}
+}
+
+// archive runs ArchiveOnce as a pool task
+func (a *Archiver) archive(ctx context.Context) error {
+	archived, err := a.ArchiveOnce(ctx)
+	if err != nil {
+		return fmt.Errorf("employee archive failed after %d employees: %w", archived, err)
+	}
+	if archived > 0 {
+		log.Printf("archived %d retired employees", archived)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Package backup periodically exports every employee to a compressed,
+// timestamped archive and keeps the latest few
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"employee-management/internal/jobs"
+	"employee-management/internal/metrics"
+	"employee-management/internal/models"
+)
+
+// Archive names are employees-<UTC timestamp>.jsonl.gz, so sorting them
+// sorts them by age
+const (
+	namePrefix = "employees-"
+	nameSuffix = ".jsonl.gz"
+	timeLayout = "20060102T150405Z"
+)
+
+// StreamFunc calls fn for every employee, as EmployeeService.FindAllStream
+type StreamFunc func(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error
+
+// Job exports the employees every interval, keeping the latest retention
+// archives in the store
+type Job struct {
+	stream    StreamFunc
+	store     Store
+	interval  time.Duration
+	retention int
+}
+
+// NewJob creates a new Job
+func NewJob(stream StreamFunc, store Store, interval time.Duration, retention int) *Job {
+	return &Job{stream: stream, store: store, interval: interval, retention: retention}
+}
+
+// Run queues a backup on pool every interval until ctx is done. The
+// first backup runs one interval after startup, so restarts do not pile
+// up archives
+func (j *Job) Run(ctx context.Context, pool *jobs.Pool) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := pool.Submit(jobs.Task{Name: "backup", Run: j.backup}); err != nil {
+			metrics.Backups.WithLabelValues("failure").Inc()
+			log.Printf("employee backup not queued: %v", err)
+		}
+	}
+}
+
+// backup writes one archive as a pool task
+func (j *Job) backup(ctx context.Context) error {
+	name, count, err := j.RunOnce(ctx)
+	if err != nil {
+		metrics.Backups.WithLabelValues("failure").Inc()
+		return err
+	}
+	log.Printf("employee backup %s written with %d employees", name, count)
+	return nil
+}
+
+// RunOnce writes one archive, then deletes the oldest ones beyond the
+// retention. It returns the archive name and how many employees it holds
+func (j *Job) RunOnce(ctx context.Context) (string, int, error) {
+	name := namePrefix + time.Now().UTC().Format(timeLayout) + nameSuffix
+
+	// The archive is staged in a temp file, so the upload knows its size
+	// and a failed export uploads nothing
+	tmp, err := os.CreateTemp("", "employees-backup-*")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	count, err := j.export(ctx, tmp)
+	if err != nil {
+		return "", 0, err
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+	if err := j.store.Put(ctx, name, tmp, size); err != nil {
+		return "", 0, err
+	}
+
+	metrics.Backups.WithLabelValues("success").Inc()
+	metrics.BackupLastSuccess.SetToCurrentTime()
+
+	if err := j.prune(ctx); err != nil {
+		log.Printf("employee backup retention failed: %v", err)
+	}
+
+	return name, count, nil
+}
+
+// export writes every employee to w as gzipped JSON lines
+func (j *Job) export(ctx context.Context, w io.Writer) (int, error) {
+	zw := gzip.NewWriter(w)
+	enc := json.NewEncoder(zw)
+
+	count := 0
+	err := j.stream(ctx, nil, func(e models.Employee) error {
+		count++
+		return enc.Encode(e)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to export employees: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return 0, fmt.Errorf("failed to compress backup: %w", err)
+	}
+	return count, nil
+}
+
+// prune deletes the oldest archives beyond the retention. Other files in
+// the store are left alone
+func (j *Job) prune(ctx context.Context) error {
+	names, err := j.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var archives []string
+	for _, name := range names {
+		if strings.HasPrefix(name, namePrefix) && strings.HasSuffix(name, nameSuffix) {
+			archives = append(archives, name)
+		}
+	}
+	if len(archives) <= j.retention {
+		return nil
+	}
+
+	slices.Sort(archives)
+	for _, name := range archives[:len(archives)-j.retention] {
+		if err := j.store.Delete(ctx, name); err != nil {
+			return fmt.Errorf("failed to delete backup %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"employee-management/internal/config"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// Store keeps the backup archives
+type Store interface {
+	// Put stores size bytes read from r under name
+	Put(ctx context.Context, name string, r io.Reader, size int64) error
+	// List returns the names of the stored archives
+	List(ctx context.Context) ([]string, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// NewStore creates the store selected by cfg.BackupStore
+func NewStore(cfg *config.Config) (Store, error) {
+	if cfg.BackupStore == "s3" {
+		return NewS3Store(
+			cfg.BackupS3Endpoint,
+			cfg.BackupS3Region,
+			cfg.BackupS3Bucket,
+			cfg.BackupS3Prefix,
+			cfg.BackupS3Access,
+			cfg.BackupS3Secret,
+			cfg.BackupS3UseSSL,
+		)
+	}
+	return FileStore{Dir: cfg.BackupDir}, nil
+}
+
+// FileStore keeps archives in a local directory
+type FileStore struct {
+	Dir string
+}
+
+// Put writes to a temporary file renamed once complete, so a failed
+// backup never leaves a truncated archive behind
+func (s FileStore) Put(_ context.Context, name string, r io.Reader, _ int64) error {
+	if err := os.MkdirAll(s.Dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create backup dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.Dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(s.Dir, name))
+}
+
+func (s FileStore) List(_ context.Context) ([]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list backup dir: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+func (s FileStore) Delete(_ context.Context, name string) error {
+	return os.Remove(filepath.Join(s.Dir, name))
+}
+
+// S3Store keeps archives in an S3 compatible bucket under a key prefix
+type S3Store struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Store connects to the S3 compatible endpoint (host[:port], without
+// scheme). Empty keys use the credentials of the environment, such as an
+// instance role
+func NewS3Store(endpoint, region, bucket, prefix, accessKey, secretKey string, useSSL bool) (*S3Store, error) {
+	creds := credentials.NewIAM("")
+	if accessKey != "" {
+		creds = credentials.NewStaticV4(accessKey, secretKey, "")
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: useSSL,
+		Region: region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	return &S3Store{client: client, bucket: bucket, prefix: prefix}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, name string, r io.Reader, size int64) error {
+	_, err := s.client.PutObject(ctx, s.bucket, s.prefix+name, r, size, minio.PutObjectOptions{
+		ContentType:     "application/x-ndjson",
+		ContentEncoding: "gzip",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload backup: %w", err)
+	}
+	return nil
+}
+
+func (s *S3Store) List(ctx context.Context) ([]string, error) {
+	var names []string
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: s.prefix}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("failed to list backups: %w", obj.Err)
+		}
+		name := strings.TrimPrefix(obj.Key, s.prefix)
+		if !strings.Contains(name, "/") {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, name string) error {
+	return s.client.RemoveObject(ctx, s.bucket, s.prefix+name, minio.RemoveObjectOptions{})
+}
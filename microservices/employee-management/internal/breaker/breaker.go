@@ -0,0 +1,163 @@
+// Package breaker provides a circuit breaker that fails fast while a
+// dependency is down instead of piling up callers waiting on it
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned without calling the dependency while the circuit is open
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the current state of the breaker
+type State int
+
+const (
+	// Closed lets every call through and counts consecutive failures
+	Closed State = iota
+	// HalfOpen lets a limited number of trial calls through
+	HalfOpen
+	// Open rejects every call until the open timeout expires
+	Open
+)
+
+// String returns the lower case name of the state
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case HalfOpen:
+		return "half-open"
+	case Open:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// Settings configures when the breaker trips and recovers
+type Settings struct {
+	FailureThreshold int           // consecutive failures that open the circuit
+	OpenTimeout      time.Duration // time in open state before trying again
+	HalfOpenMaxCalls int           // trial calls allowed while half-open
+}
+
+// Breaker is a consecutive-failures circuit breaker safe for concurrent use
+type Breaker struct {
+	settings Settings
+
+	// IsFailure decides which errors count against the dependency
+	// Defaults to any non-nil error
+	IsFailure func(err error) bool
+
+	// OnStateChange is called after every transition, used for metrics
+	OnStateChange func(from, to State)
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	inFlight int
+	openedAt time.Time
+}
+
+// New creates a closed breaker
+func New(settings Settings) *Breaker {
+	if settings.FailureThreshold < 1 {
+		settings.FailureThreshold = 1
+	}
+	if settings.HalfOpenMaxCalls < 1 {
+		settings.HalfOpenMaxCalls = 1
+	}
+	return &Breaker{
+		settings:  settings,
+		IsFailure: func(err error) bool { return err != nil },
+	}
+}
+
+// State returns the current state, moving from open to half-open once the
+// open timeout has expired
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance()
+	return b.state
+}
+
+// Execute runs fn if the circuit allows it and records the outcome
+func (b *Breaker) Execute(fn func() error) error {
+	if err := b.before(); err != nil {
+		return err
+	}
+
+	err := fn()
+	b.after(b.IsFailure(err))
+	return err
+}
+
+// before reserves a call slot or rejects the call
+func (b *Breaker) before() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+
+	switch b.state {
+	case Open:
+		return ErrOpen
+	case HalfOpen:
+		if b.inFlight >= b.settings.HalfOpenMaxCalls {
+			return ErrOpen
+		}
+	}
+
+	b.inFlight++
+	return nil
+}
+
+// after records the result of a call
+func (b *Breaker) after(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.inFlight--
+
+	if failed {
+		b.failures++
+		if b.state == HalfOpen || b.failures >= b.settings.FailureThreshold {
+			b.transition(Open)
+		}
+		return
+	}
+
+	b.failures = 0
+	if b.state == HalfOpen {
+		b.transition(Closed)
+	}
+}
+
+// advance moves an expired open circuit to half-open. Caller holds mu
+func (b *Breaker) advance() {
+	if b.state == Open && time.Since(b.openedAt) >= b.settings.OpenTimeout {
+		b.transition(HalfOpen)
+	}
+}
+
+// transition changes state and notifies the listener. Caller holds mu
+func (b *Breaker) transition(to State) {
+	from := b.state
+	if from == to {
+		return
+	}
+
+	b.state = to
+	b.failures = 0
+	if to == Open {
+		b.openedAt = time.Now()
+	}
+
+	if b.OnStateChange != nil {
+		b.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,15 @@
+// Package cache provides the cache used to speed up employee reads
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores raw values by key with a TTL
+// Get reports found=false on a miss, errors are reserved for backend failures
+type Cache interface {
+	Get(ctx context.Context, key string) (val []byte, found bool, err error)
+	Set(ctx context.Context, key string, val []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryCache is an in-process LRU Cache with per entry TTL
+// It evicts the least recently used entries once either the entry count
+// or the total size of keys and values exceeds its limits
+type MemoryCache struct {
+	maxEntries int
+	maxBytes   int
+
+	mu    sync.Mutex
+	ll    *list.List // front is most recently used
+	items map[string]*list.Element
+	bytes int
+}
+
+// memoryEntry is the value held by each list element
+type memoryEntry struct {
+	key       string
+	val       []byte
+	expiresAt time.Time
+}
+
+// size is the memory accounted for an entry
+func (e *memoryEntry) size() int {
+	return len(e.key) + len(e.val)
+}
+
+// NewMemoryCache creates a MemoryCache. A zero limit means unlimited
+func NewMemoryCache(maxEntries, maxBytes int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		ll:         list.New(),
+		items:      map[string]*list.Element{},
+	}
+}
+
+// Get returns the value at key if present and not expired
+func (c *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := el.Value.(*memoryEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.remove(el)
+		return nil, false, nil
+	}
+
+	c.ll.MoveToFront(el)
+	return entry.val, true, nil
+}
+
+// Set stores val at key expiring after ttl, evicting old entries as needed
+// Values bigger than the whole cache are not stored
+func (c *MemoryCache) Set(_ context.Context, key string, val []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+
+	entry := &memoryEntry{key: key, val: val, expiresAt: time.Now().Add(ttl)}
+	if c.maxBytes > 0 && entry.size() > c.maxBytes {
+		return nil
+	}
+
+	c.items[key] = c.ll.PushFront(entry)
+	c.bytes += entry.size()
+
+	for (c.maxEntries > 0 && c.ll.Len() > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		c.remove(c.ll.Back())
+	}
+
+	return nil
+}
+
+// Delete removes the keys
+func (c *MemoryCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := c.items[key]; ok {
+			c.remove(el)
+		}
+	}
+	return nil
+}
+
+// remove drops an element from the list and index. Caller holds mu
+func (c *MemoryCache) remove(el *list.Element) {
+	entry := c.ll.Remove(el).(*memoryEntry)
+	delete(c.items, entry.key)
+	c.bytes -= entry.size()
+}
@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache is a Cache backed by Redis
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache creates a new RedisCache using the given client
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+// Get returns the value stored at key
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	val, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return val, true, nil
+}
+
+// Set stores val at key expiring after ttl
+func (c *RedisCache) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, val, ttl).Err()
+}
+
+// Delete removes the keys
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
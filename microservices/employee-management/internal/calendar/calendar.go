@@ -0,0 +1,146 @@
+// Package calendar builds an iCalendar (RFC 5545) feed of the work
+// anniversaries and birthdays of the employees, for managers to
+// subscribe to from their calendar application
+package calendar
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"employee-management/internal/models"
+)
+
+// Source walks the employees matching filters, e.g.
+// service.EmployeeService.FindAllStream
+type Source func(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error
+
+// Kinds of event
+const (
+	KindAnniversary = "anniversary"
+	KindBirthday    = "birthday"
+)
+
+// Event is an all-day event of the feed
+type Event struct {
+	UID     string
+	Kind    string
+	Date    models.Date
+	Summary string
+}
+
+// Events returns the anniversaries and birthdays of the employees source
+// walks that fall between from and to, inclusive, by date. Retired
+// employees are left out. Birthdays do not tell the age
+func Events(ctx context.Context, source Source, filters map[string]interface{}, from, to models.Date) ([]Event, error) {
+	var feed []Event
+	err := source(ctx, filters, func(e models.Employee) error {
+		if e.Status == models.StatusRetired {
+			return nil
+		}
+		name := e.FirstName + " " + e.LastName
+
+		for year := from.Time().Year(); year <= to.Time().Year(); year++ {
+			years := year - e.HireDate.Time().Year()
+			if day := e.HireDate.Anniversary(year); years > 0 && inRange(day, from, to) {
+				feed = append(feed, Event{
+					UID:     fmt.Sprintf("%s-%d-%d@employee-management", KindAnniversary, e.ID, year),
+					Kind:    KindAnniversary,
+					Date:    day,
+					Summary: fmt.Sprintf("%s: %d %s at the company", name, years, plural(years, "year")),
+				})
+			}
+			if e.DateOfBirth == nil {
+				continue
+			}
+			if day := e.DateOfBirth.Anniversary(year); inRange(day, from, to) {
+				feed = append(feed, Event{
+					UID:     fmt.Sprintf("%s-%d-%d@employee-management", KindBirthday, e.ID, year),
+					Kind:    KindBirthday,
+					Date:    day,
+					Summary: name + "'s birthday",
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(feed, func(a, b Event) int {
+		return a.Date.Time().Compare(b.Date.Time())
+	})
+	return feed, nil
+}
+
+// Write writes events as a calendar named name, stamped with now
+func Write(w io.Writer, name string, events []Event, now time.Time) error {
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		bw.WriteString(fold(s))
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//employee-management//calendar//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escape(name))
+	// Subscribed calendars poll the feed about this often
+	line("REFRESH-INTERVAL;VALUE=DURATION:PT12H")
+	line("X-PUBLISHED-TTL:PT12H")
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + e.UID)
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + e.Date.Time().Format("20060102"))
+		line("DTEND;VALUE=DATE:" + e.Date.AddDays(1).Time().Format("20060102"))
+		line("SUMMARY:" + escape(e.Summary))
+		line("CATEGORIES:" + strings.ToUpper(e.Kind))
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// inRange reports whether day is between from and to, inclusive
+func inRange(day, from, to models.Date) bool {
+	return !day.Before(from) && !day.After(to)
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
+
+// escape escapes a TEXT value
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// fold terminates a content line with CRLF, folding it into lines of at
+// most 75 octets without splitting a UTF-8 sequence
+func fold(s string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+	return b.String()
+}
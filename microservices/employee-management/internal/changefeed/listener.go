@@ -0,0 +1,120 @@
+// Package changefeed turns the PostgreSQL notifications sent on employee
+// changes into change events for the components of this instance, so
+// they learn about writes made by other instances right away
+package changefeed
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Channel is the notification channel the triggers of migration 0010
+// notify on
+const Channel = "employee_changes"
+
+// Tables a change may come from
+const (
+	TableEmployees = "employees"
+	TableOutbox    = "outbox"
+)
+
+// maxBackoff bounds the wait between reconnection attempts
+const maxBackoff = 30 * time.Second
+
+// Change is a committed change of a row. For the outbox, EmployeeID is
+// the aggregate of the event and Sequence its position
+type Change struct {
+	Table      string `json:"table"`
+	Op         string `json:"op"`
+	EmployeeID int64  `json:"id"`
+	Sequence   int64  `json:"sequence,omitempty"`
+}
+
+// Listener holds a connection listening on Channel and passes every change
+// to the registered handlers. Notifications sent while it reconnects are
+// lost, so handlers must keep a fallback (cache TTLs, outbox polling)
+type Listener struct {
+	pool *pgxpool.Pool
+
+	mu       sync.Mutex
+	handlers []func(Change)
+}
+
+// NewListener creates a Listener using a connection of pool
+func NewListener(pool *pgxpool.Pool) *Listener {
+	return &Listener{pool: pool}
+}
+
+// OnChange registers fn to be called with every change. Handlers run on
+// the listener goroutine and must not block
+func (l *Listener) OnChange(fn func(Change)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.handlers = append(l.handlers, fn)
+}
+
+// Run listens until ctx is done, reconnecting with exponential backoff
+func (l *Listener) Run(ctx context.Context) {
+	backoff := time.Second
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("change feed listener failed, reconnecting in %s: %v", backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// listen holds one connection until it fails
+func (l *Listener) listen(ctx context.Context) error {
+	pooled, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection keeps listening, so it never goes back to the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{Channel}.Sanitize()); err != nil {
+		return err
+	}
+	log.Printf("change feed listening on %s", Channel)
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var change Change
+		if err := json.Unmarshal([]byte(n.Payload), &change); err != nil {
+			log.Printf("change feed ignored malformed notification %q: %v", n.Payload, err)
+			continue
+		}
+		l.dispatch(change)
+	}
+}
+
+// dispatch calls every handler with change
+func (l *Listener) dispatch(change Change) {
+	l.mu.Lock()
+	handlers := l.handlers
+	l.mu.Unlock()
+
+	for _, fn := range handlers {
+		fn(change)
+	}
+}
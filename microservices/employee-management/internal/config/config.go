@@ -1,48 +1,1015 @@
-// Package config provides configuration management from enviroment variables
+// Package config provides configuration management from defaults, a YAML
+// file, enviroment variables and command line flags
 package config
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"net"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"github.com/go-sql-driver/mysql"
 	"github.com/joho/godotenv"
+	"go.yaml.in/yaml/v3"
 )
 
-// Config holds configuration loaded from env
+// Config holds configuration loaded from defaults, file, env and flags
 type Config struct {
-	ServerPort string
+	ServerPort string `yaml:"server_port"`
 
-	DBHost     string
-	DBPort     string
-	DBName     string
-	DBUser     string
-	DBPassword string
-	DBSSLMode  string
+	// Timezone is the organization's canonical IANA zone, used to turn
+	// instants into calendar dates
+	Timezone string `yaml:"timezone"`
+	// OrgName is the company name of exported contacts
+	OrgName string `yaml:"org_name"`
+
+	TLSCertFile         string `yaml:"tls_cert_file"`
+	TLSKeyFile          string `yaml:"tls_key_file"`
+	TLSAutocertDomains  string `yaml:"tls_autocert_domains"`
+	TLSAutocertCacheDir string `yaml:"tls_autocert_cache_dir"`
+	HTTPRedirectPort    string `yaml:"http_redirect_port"`
+
+	// ShutdownDelay is how long readiness fails before the listener
+	// closes, ShutdownTimeout how long in-flight requests then get
+	ShutdownDelay   time.Duration `yaml:"shutdown_delay"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+
+	RequestTimeout time.Duration            `yaml:"request_timeout"`
+	RouteTimeouts  map[string]time.Duration `yaml:"route_timeouts"`
+
+	// HTTPCacheMaxAge is the max-age of the private Cache-Control of GET
+	// responses, RouteCacheMaxAges overrides it by "METHOD /route/pattern"
+	HTTPCacheMaxAge   time.Duration            `yaml:"http_cache_max_age"`
+	RouteCacheMaxAges map[string]time.Duration `yaml:"route_cache_max_ages"`
+
+	// SLOTarget of the requests of each route must be good, below 5xx and
+	// within SLOLatency (RouteSLOLatencies by "METHOD /route/pattern").
+	// Burn rates are computed over SLOWindow
+	SLOTarget         float64                  `yaml:"slo_target"`
+	SLOLatency        time.Duration            `yaml:"slo_latency"`
+	RouteSLOLatencies map[string]time.Duration `yaml:"route_slo_latencies"`
+	SLOWindow         time.Duration            `yaml:"slo_window"`
+
+	// AppEnv is production or development. Development, like Debug,
+	// turns on Gin debug mode, verbose logging, Swagger and pprof
+	AppEnv         string `yaml:"app_env"`
+	Debug          bool   `yaml:"debug"`
+	SwaggerEnabled bool   `yaml:"swagger_enabled"`
+
+	PprofEnabled bool   `yaml:"pprof_enabled"`
+	AdminHost    string `yaml:"admin_host"`
+	AdminPort    string `yaml:"admin_port"`
+	AdminToken   string `yaml:"admin_token"`
+
+	// StorageBackend holds the employees: postgres, mysql (MySQL 8 or
+	// MariaDB 10.6+), mongodb, or memory for local development without a
+	// database
+	StorageBackend string `yaml:"storage_backend"`
+
+	// TestMode mounts the endpoints contract tests set their data up with,
+	// which wipe every employee. It requires the memory backend
+	TestMode bool `yaml:"test_mode"`
+
+	// IDFormat is how the API addresses employees: int by their id, or
+	// uuid by their random uuid so ids cannot be enumerated
+	IDFormat string `yaml:"id_format"`
+
+	// PhoneDefaultCountry is the ISO 3166-1 alpha-2 country whose calling
+	// code national phone numbers get, empty to accept international
+	// numbers only
+	PhoneDefaultCountry string `yaml:"phone_default_country"`
+
+	// EmployeeNumberPattern is a regular expression whole employee numbers
+	// must match, e.g. EMP-\d{5}, replacing the default format
+	EmployeeNumberPattern string `yaml:"employee_number_pattern"`
+
+	// ValidationRulesFile is a YAML file with the deployment's own checks
+	// of employee requests, e.g. a corporate email domain
+	ValidationRulesFile string `yaml:"validation_rules_file"`
+
+	// MongoURI is the connection string of the mongodb backend, which
+	// uses the database DB_NAME
+	MongoURI string `yaml:"mongo_uri"`
+
+	DBHost     string `yaml:"db_host"`
+	DBPort     string `yaml:"db_port"`
+	DBName     string `yaml:"db_name"`
+	DBUser     string `yaml:"db_user"`
+	DBPassword string `yaml:"db_password"`
+	DBSSLMode  string `yaml:"db_sslmode"`
+
+	DBMaxConns          int           `yaml:"db_max_conns"`
+	DBMinConns          int           `yaml:"db_min_conns"`
+	DBMaxConnLifetime   time.Duration `yaml:"db_max_conn_lifetime"`
+	DBMaxConnIdleTime   time.Duration `yaml:"db_max_conn_idle_time"`
+	DBHealthCheckPeriod time.Duration `yaml:"db_health_check_period"`
+	DBConnectTimeout    time.Duration `yaml:"db_connect_timeout"`
+
+	DBStatementTimeout time.Duration `yaml:"db_statement_timeout"`
+
+	DBRetryInitialBackoff time.Duration `yaml:"db_retry_initial_backoff"`
+	DBRetryMaxBackoff     time.Duration `yaml:"db_retry_max_backoff"`
+	DBRetryMaxWait        time.Duration `yaml:"db_retry_max_wait"`
+
+	MigrateOnStartup bool `yaml:"migrate_on_startup"`
+
+	BreakerFailureThreshold int           `yaml:"breaker_failure_threshold"`
+	BreakerOpenTimeout      time.Duration `yaml:"breaker_open_timeout"`
+	BreakerHalfOpenMaxCalls int           `yaml:"breaker_half_open_max_calls"`
+
+	OutboxPollInterval time.Duration `yaml:"outbox_poll_interval"`
+	OutboxBatchSize    int           `yaml:"outbox_batch_size"`
+	OutboxMaxBackoff   time.Duration `yaml:"outbox_max_backoff"`
+
+	// Background task pool running exports, webhook deliveries and purges
+	JobWorkers      int           `yaml:"job_workers"`
+	JobQueueSize    int           `yaml:"job_queue_size"`
+	JobMaxAttempts  int           `yaml:"job_max_attempts"`
+	JobMaxBackoff   time.Duration `yaml:"job_max_backoff"`
+	JobDrainTimeout time.Duration `yaml:"job_drain_timeout"`
+
+	EventBroker      string `yaml:"event_broker"`
+	KafkaBrokers     string `yaml:"kafka_brokers"`
+	KafkaTopicPrefix string `yaml:"kafka_topic_prefix"`
+
+	RabbitMQURL              string `yaml:"rabbitmq_url"`
+	RabbitMQExchange         string `yaml:"rabbitmq_exchange"`
+	RabbitMQRoutingKeyPrefix string `yaml:"rabbitmq_routing_key_prefix"`
+
+	NATSURL           string `yaml:"nats_url"`
+	NATSStream        string `yaml:"nats_stream"`
+	NATSSubjectPrefix string `yaml:"nats_subject_prefix"`
+	NATSConsumer      string `yaml:"nats_consumer"`
+
+	WebhookTimeout      time.Duration `yaml:"webhook_timeout"`
+	WebhookPollInterval time.Duration `yaml:"webhook_poll_interval"`
+	WebhookBatchSize    int           `yaml:"webhook_batch_size"`
+	WebhookMaxAttempts  int           `yaml:"webhook_max_attempts"`
+	WebhookMaxBackoff   time.Duration `yaml:"webhook_max_backoff"`
+
+	OpenAPIValidation        bool `yaml:"openapi_validation"`
+	OpenAPIValidateResponses bool `yaml:"openapi_validate_responses"`
+
+	// ResponseEnvelope wraps JSON success bodies in data, meta and
+	// request_id unless the client opts out
+	ResponseEnvelope bool `yaml:"response_envelope"`
+
+	StreamPollInterval time.Duration `yaml:"stream_poll_interval"`
+	ChangeFeed         bool          `yaml:"change_feed"`
+	WSAllowedOrigins   string        `yaml:"ws_allowed_origins"`
+
+	// Service registry the instance registers in: none, consul or etcd
+	DiscoveryBackend string        `yaml:"discovery_backend"`
+	DiscoveryURL     string        `yaml:"discovery_url"`
+	DiscoveryTTL     time.Duration `yaml:"discovery_ttl"`
+	ServiceName      string        `yaml:"service_name"`
+	// ServiceAddress is the host:port other services reach this instance
+	// on, the hostname and server port when empty
+	ServiceAddress string `yaml:"service_address"`
+
+	RedisURL string `yaml:"redis_url"`
+
+	CacheBackend string        `yaml:"cache_backend"`
+	CacheTTL     time.Duration `yaml:"cache_ttl"`
+
+	CacheMaxEntries int `yaml:"cache_max_entries"`
+	CacheMaxBytes   int `yaml:"cache_max_bytes"`
+
+	// CacheVerify reads the db on every cache hit too and counts the hits
+	// that differ, for development
+	CacheVerify bool `yaml:"cache_verify"`
+
+	// BackupInterval is how often every employee is exported to the
+	// backup store, 0 disables the job
+	BackupInterval   time.Duration `yaml:"backup_interval"`
+	BackupRetention  int           `yaml:"backup_retention"`
+	BackupStore      string        `yaml:"backup_store"`
+	BackupDir        string        `yaml:"backup_dir"`
+	BackupS3Endpoint string        `yaml:"backup_s3_endpoint"`
+	BackupS3Region   string        `yaml:"backup_s3_region"`
+	BackupS3Bucket   string        `yaml:"backup_s3_bucket"`
+	BackupS3Prefix   string        `yaml:"backup_s3_prefix"`
+	BackupS3Access   string        `yaml:"backup_s3_access_key"`
+	BackupS3Secret   string        `yaml:"backup_s3_secret_key"`
+	BackupS3UseSSL   bool          `yaml:"backup_s3_use_ssl"`
+
+	// RetentionInterval is how often employees retired for more than
+	// RetentionYears are purged with RetentionAction, 0 disables the job
+	RetentionInterval time.Duration `yaml:"retention_interval"`
+	RetentionYears    int           `yaml:"retention_years"`
+	RetentionAction   string        `yaml:"retention_action"`
+
+	// ProbationInterval is how often employees whose probation lapsed
+	// are made ACTIVE, 0 disables the job
+	ProbationInterval time.Duration `yaml:"probation_interval"`
+
+	// ReminderDepartments are the comma separated departments whose
+	// upcoming anniversaries and birthdays are announced, * all of them,
+	// empty disables the job. Reminders go out ReminderDaysAhead days
+	// before the day
+	ReminderDepartments string `yaml:"reminder_departments"`
+	ReminderDaysAhead   int    `yaml:"reminder_days_ahead"`
+
+	// ArchiveInterval is how often employees retired for more than
+	// ArchiveAfterMonths are moved to the archive, 0 disables the job
+	ArchiveInterval    time.Duration `yaml:"archive_interval"`
+	ArchiveAfterMonths int           `yaml:"archive_after_months"`
+
+	// LeaderElection makes instances sharing a PostgreSQL database elect
+	// the one running the scheduled jobs, campaigning and checking the
+	// held lock every LeaderCheckInterval
+	LeaderElection      bool          `yaml:"leader_election"`
+	LeaderCheckInterval time.Duration `yaml:"leader_check_interval"`
+
+	// SearchURL is the Elasticsearch or OpenSearch cluster answering the
+	// q search of the employee list, empty disables search
+	SearchURL             string        `yaml:"search_url"`
+	SearchIndex           string        `yaml:"search_index"`
+	SearchUsername        string        `yaml:"search_username"`
+	SearchPassword        string        `yaml:"search_password"`
+	SearchTimeout         time.Duration `yaml:"search_timeout"`
+	SearchReindexInterval time.Duration `yaml:"search_reindex_interval"`
+
+	FeaturesFile     string        `yaml:"features_file"`
+	FeaturesRedisKey string        `yaml:"features_redis_key"`
+	FeaturesRefresh  time.Duration `yaml:"features_refresh"`
+
+	// SentryDSN enables reporting panics and 5xx responses to Sentry,
+	// SentrySampleRate is the share of them sent
+	SentryDSN         string  `yaml:"sentry_dsn"`
+	SentryEnvironment string  `yaml:"sentry_environment"`
+	SentryRelease     string  `yaml:"sentry_release"`
+	SentrySampleRate  float64 `yaml:"sentry_sample_rate"`
+
+	// BodyLogging logs request and response bodies, also on while
+	// debugging, with the LogRedactFields values replaced
+	BodyLogging     bool   `yaml:"body_logging"`
+	LogRedactFields string `yaml:"log_redact_fields"`
+	BodyLogMaxBytes int    `yaml:"body_log_max_bytes"`
+
+	// BadgeSigningKey enables signed badge tokens, valid for BadgeTokenTTL
+	BadgeSigningKey string        `yaml:"badge_signing_key"`
+	BadgeTokenTTL   time.Duration `yaml:"badge_token_ttl"`
+
+	// ReportTitle and ReportPageSize lay out the PDF report
+	ReportTitle    string `yaml:"report_title"`
+	ReportPageSize string `yaml:"report_page_size"`
+
+	// EmailVerificationKey enables email verification, signing the links
+	// to EmailVerificationURL sent to new and changed emails, valid for
+	// EmailVerificationTTL
+	EmailVerificationKey string        `yaml:"email_verification_key"`
+	EmailVerificationURL string        `yaml:"email_verification_url"`
+	EmailVerificationTTL time.Duration `yaml:"email_verification_ttl"`
+
+	// LDAPURL enables the directory sync with the LDAP or Active Directory
+	// server, importing the entries of LDAPBaseDN matching LDAPFilter
+	// every LDAPSyncInterval (0 syncs on request only). LDAPAttributes
+	// maps the employee fields to directory attributes
+	LDAPURL          string        `yaml:"ldap_url"`
+	LDAPBindDN       string        `yaml:"ldap_bind_dn"`
+	LDAPBindPassword string        `yaml:"ldap_bind_password"`
+	LDAPBaseDN       string        `yaml:"ldap_base_dn"`
+	LDAPFilter       string        `yaml:"ldap_filter"`
+	LDAPAttributes   string        `yaml:"ldap_attributes"`
+	LDAPSyncInterval time.Duration `yaml:"ldap_sync_interval"`
+	LDAPTimeout      time.Duration `yaml:"ldap_timeout"`
+
+	// SCIMToken enables the SCIM 2.0 provisioning endpoint, the bearer
+	// token identity providers authenticate with
+	SCIMToken string `yaml:"scim_token"`
+
+	// Args holds the positional arguments left after the flags, e.g. a
+	// subcommand such as "migrate"
+	Args []string `yaml:"-"`
+
+	// ValidateConfig (-validate-config) prints the redacted effective
+	// config and exits once it is checked, CheckConnections
+	// (-check-connections) also connects to the database and Redis
+	ValidateConfig   bool `yaml:"-"`
+	CheckConnections bool `yaml:"-"`
+}
+
+// options lists every setting that can be overridden by env or flags
+var options = []envconfig.Option[Config]{
+	{Env: "SERVER_PORT", Flag: "port", Usage: "HTTP server port", Set: envconfig.String(func(c *Config) *string { return &c.ServerPort })},
+	{Env: "ORG_TIMEZONE", Flag: "timezone", Usage: "organization time zone (IANA name) for calendar dates", Set: envconfig.String(func(c *Config) *string { return &c.Timezone })},
+	{Env: "ORG_NAME", Flag: "org-name", Usage: "organization name of exported vCards", Set: envconfig.String(func(c *Config) *string { return &c.OrgName })},
+	{Env: "TLS_CERT_FILE", Flag: "tls-cert", Usage: "TLS certificate file", Set: envconfig.String(func(c *Config) *string { return &c.TLSCertFile })},
+	{Env: "TLS_KEY_FILE", Flag: "tls-key", Usage: "TLS private key file", Set: envconfig.String(func(c *Config) *string { return &c.TLSKeyFile })},
+	{Env: "TLS_AUTOCERT_DOMAINS", Flag: "tls-autocert-domains", Usage: "comma separated domains for Let's Encrypt certificates", Set: envconfig.String(func(c *Config) *string { return &c.TLSAutocertDomains })},
+	{Env: "TLS_AUTOCERT_CACHE_DIR", Flag: "tls-autocert-cache-dir", Usage: "directory caching autocert certificates", Set: envconfig.String(func(c *Config) *string { return &c.TLSAutocertCacheDir })},
+	{Env: "HTTP_REDIRECT_PORT", Flag: "http-redirect-port", Usage: "plain HTTP port redirecting to HTTPS, empty disables", Set: envconfig.String(func(c *Config) *string { return &c.HTTPRedirectPort })},
+	{Env: "SHUTDOWN_DELAY", Flag: "shutdown-delay", Usage: "how long readiness fails before the listener closes at shutdown", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.ShutdownDelay })},
+	{Env: "SHUTDOWN_TIMEOUT", Flag: "shutdown-timeout", Usage: "wait for in-flight requests at shutdown before closing their connections", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.ShutdownTimeout })},
+	{Env: "REQUEST_TIMEOUT", Flag: "request-timeout", Usage: "default request deadline, 0 disables", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.RequestTimeout })},
+	{Env: "ROUTE_TIMEOUTS", Flag: "route-timeouts", Usage: "per route deadlines as 'METHOD /path=duration,...'", Set: setDurationMap(func(c *Config) *map[string]time.Duration { return &c.RouteTimeouts })},
+	{Env: "HTTP_CACHE_MAX_AGE", Flag: "http-cache-max-age", Usage: "max-age of the private Cache-Control of GET responses, 0 makes clients revalidate", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.HTTPCacheMaxAge })},
+	{Env: "ROUTE_CACHE_MAX_AGES", Flag: "route-cache-max-ages", Usage: "per route Cache-Control max-ages as 'METHOD /path=duration,...'", Set: setDurationMap(func(c *Config) *map[string]time.Duration { return &c.RouteCacheMaxAges })},
+	{Env: "SLO_TARGET", Flag: "slo-target", Usage: "share of the requests of each route that must be good, e.g. 0.99", Set: envconfig.Float(func(c *Config) *float64 { return &c.SLOTarget })},
+	{Env: "SLO_LATENCY", Flag: "slo-latency", Usage: "latency a good request is answered within", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.SLOLatency })},
+	{Env: "ROUTE_SLO_LATENCIES", Flag: "route-slo-latencies", Usage: "per route latency objectives as 'METHOD /path=duration,...'", Set: setDurationMap(func(c *Config) *map[string]time.Duration { return &c.RouteSLOLatencies })},
+	{Env: "SLO_WINDOW", Flag: "slo-window", Usage: "window the error budget burn rates are computed over", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.SLOWindow })},
+	{Env: "APP_ENV", Flag: "app-env", Usage: "production or development, which turns on the debug tooling", Set: envconfig.String(func(c *Config) *string { return &c.AppEnv })},
+	{Env: "DEBUG", Flag: "debug", Usage: "Gin debug mode, verbose logging, Swagger and pprof", Set: envconfig.Bool(func(c *Config) *bool { return &c.Debug })},
+	{Env: "SWAGGER_ENABLED", Flag: "swagger", Usage: "serve the Swagger UI and spec at /swagger", Set: envconfig.Bool(func(c *Config) *bool { return &c.SwaggerEnabled })},
+	{Env: "PPROF_ENABLED", Flag: "pprof", Usage: "expose pprof on the admin listener", Set: envconfig.Bool(func(c *Config) *bool { return &c.PprofEnabled })},
+	{Env: "ADMIN_HOST", Flag: "admin-host", Usage: "admin listener host", Set: envconfig.String(func(c *Config) *string { return &c.AdminHost })},
+	{Env: "ADMIN_PORT", Flag: "admin-port", Usage: "admin listener port", Set: envconfig.String(func(c *Config) *string { return &c.AdminPort })},
+	{Env: "ADMIN_TOKEN", Flag: "admin-token", Usage: "bearer token required by the admin listener", Set: envconfig.String(func(c *Config) *string { return &c.AdminToken })},
+	{Env: "STORAGE_BACKEND", Flag: "storage-backend", Usage: "employee storage: postgres, mysql, mongodb or memory (development, lost on restart)", Set: envconfig.String(func(c *Config) *string { return &c.StorageBackend })},
+	{Env: "TEST_MODE", Flag: "test-mode", Usage: "mount the Pact provider state endpoint, which wipes data (memory backend only)", Set: envconfig.Bool(func(c *Config) *bool { return &c.TestMode })},
+	{Env: "ID_FORMAT", Flag: "id-format", Usage: "how the API addresses employees: int or uuid", Set: envconfig.String(func(c *Config) *string { return &c.IDFormat })},
+	{Env: "EMPLOYEE_NUMBER_PATTERN", Flag: "employee-number-pattern", Usage: "regular expression employee numbers must match, e.g. EMP-\\d{5}", Set: envconfig.String(func(c *Config) *string { return &c.EmployeeNumberPattern })},
+	{Env: "VALIDATION_RULES_FILE", Flag: "validation-rules-file", Usage: "YAML file with additional checks of employee requests", Set: envconfig.String(func(c *Config) *string { return &c.ValidationRulesFile })},
+	{Env: "PHONE_DEFAULT_COUNTRY", Flag: "phone-default-country", Usage: "country (ISO 3166-1 alpha-2) whose calling code national phone numbers get", Set: envconfig.String(func(c *Config) *string { return &c.PhoneDefaultCountry })},
+	{Env: "MONGO_URI", Flag: "mongo-uri", Usage: "MongoDB connection string of the mongodb storage backend", Set: envconfig.String(func(c *Config) *string { return &c.MongoURI })},
+	{Env: "DB_HOST", Flag: "db-host", Usage: "database host", Set: envconfig.String(func(c *Config) *string { return &c.DBHost })},
+	{Env: "DB_PORT", Flag: "db-port", Usage: "database port", Set: envconfig.String(func(c *Config) *string { return &c.DBPort })},
+	{Env: "DB_NAME", Flag: "db-name", Usage: "database name", Set: envconfig.String(func(c *Config) *string { return &c.DBName })},
+	{Env: "DB_USER", Flag: "db-user", Usage: "database user", Set: envconfig.String(func(c *Config) *string { return &c.DBUser })},
+	{Env: "DB_PASSWORD", Flag: "db-password", Usage: "database password", Set: envconfig.String(func(c *Config) *string { return &c.DBPassword })},
+	{Env: "DB_SSLMODE", Flag: "db-sslmode", Usage: "database SSL mode", Set: envconfig.String(func(c *Config) *string { return &c.DBSSLMode })},
+	{Env: "DB_MAX_CONNS", Flag: "db-max-conns", Usage: "maximum pool connections", Set: envconfig.Int(func(c *Config) *int { return &c.DBMaxConns })},
+	{Env: "DB_MIN_CONNS", Flag: "db-min-conns", Usage: "minimum idle pool connections", Set: envconfig.Int(func(c *Config) *int { return &c.DBMinConns })},
+	{Env: "DB_MAX_CONN_LIFETIME", Flag: "db-max-conn-lifetime", Usage: "maximum connection lifetime", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DBMaxConnLifetime })},
+	{Env: "DB_MAX_CONN_IDLE_TIME", Flag: "db-max-conn-idle-time", Usage: "maximum connection idle time", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DBMaxConnIdleTime })},
+	{Env: "DB_HEALTH_CHECK_PERIOD", Flag: "db-health-check-period", Usage: "pool health check period", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DBHealthCheckPeriod })},
+	{Env: "DB_CONNECT_TIMEOUT", Flag: "db-connect-timeout", Usage: "timeout for opening a connection", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DBConnectTimeout })},
+	{Env: "DB_STATEMENT_TIMEOUT", Flag: "db-statement-timeout", Usage: "PostgreSQL statement_timeout, 0 disables", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DBStatementTimeout })},
+	{Env: "DB_RETRY_INITIAL_BACKOFF", Flag: "db-retry-initial-backoff", Usage: "first wait between db connection attempts", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DBRetryInitialBackoff })},
+	{Env: "DB_RETRY_MAX_BACKOFF", Flag: "db-retry-max-backoff", Usage: "maximum wait between db connection attempts", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DBRetryMaxBackoff })},
+	{Env: "DB_RETRY_MAX_WAIT", Flag: "db-retry-max-wait", Usage: "total time to wait for the db at startup, 0 disables retries", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DBRetryMaxWait })},
+	{Env: "MIGRATE_ON_STARTUP", Flag: "migrate-on-startup", Usage: "apply pending migrations at startup", Set: envconfig.Bool(func(c *Config) *bool { return &c.MigrateOnStartup })},
+	{Env: "BREAKER_FAILURE_THRESHOLD", Flag: "breaker-failure-threshold", Usage: "consecutive db failures that open the circuit", Set: envconfig.Int(func(c *Config) *int { return &c.BreakerFailureThreshold })},
+	{Env: "BREAKER_OPEN_TIMEOUT", Flag: "breaker-open-timeout", Usage: "time the circuit stays open before a trial call", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.BreakerOpenTimeout })},
+	{Env: "BREAKER_HALF_OPEN_MAX_CALLS", Flag: "breaker-half-open-max-calls", Usage: "trial calls allowed while half-open", Set: envconfig.Int(func(c *Config) *int { return &c.BreakerHalfOpenMaxCalls })},
+	{Env: "OUTBOX_POLL_INTERVAL", Flag: "outbox-poll-interval", Usage: "how often the outbox is polled for pending events", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.OutboxPollInterval })},
+	{Env: "OUTBOX_BATCH_SIZE", Flag: "outbox-batch-size", Usage: "events published per outbox poll", Set: envconfig.Int(func(c *Config) *int { return &c.OutboxBatchSize })},
+	{Env: "OUTBOX_MAX_BACKOFF", Flag: "outbox-max-backoff", Usage: "maximum wait between retries of a failed event", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.OutboxMaxBackoff })},
+	{Env: "JOB_WORKERS", Flag: "job-workers", Usage: "background tasks run at once", Set: envconfig.Int(func(c *Config) *int { return &c.JobWorkers })},
+	{Env: "JOB_QUEUE_SIZE", Flag: "job-queue-size", Usage: "background tasks waiting for a worker before new ones are rejected", Set: envconfig.Int(func(c *Config) *int { return &c.JobQueueSize })},
+	{Env: "JOB_MAX_ATTEMPTS", Flag: "job-max-attempts", Usage: "runs of a failing background task before it is given up", Set: envconfig.Int(func(c *Config) *int { return &c.JobMaxAttempts })},
+	{Env: "JOB_MAX_BACKOFF", Flag: "job-max-backoff", Usage: "maximum wait between runs of a failing background task", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.JobMaxBackoff })},
+	{Env: "JOB_DRAIN_TIMEOUT", Flag: "job-drain-timeout", Usage: "wait for background tasks at shutdown before canceling them", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.JobDrainTimeout })},
+	{Env: "EVENT_BROKER", Flag: "event-broker", Usage: "broker receiving domain events: log, kafka, rabbitmq or nats", Set: envconfig.String(func(c *Config) *string { return &c.EventBroker })},
+	{Env: "KAFKA_BROKERS", Flag: "kafka-brokers", Usage: "comma separated Kafka bootstrap brokers", Set: envconfig.String(func(c *Config) *string { return &c.KafkaBrokers })},
+	{Env: "KAFKA_TOPIC_PREFIX", Flag: "kafka-topic-prefix", Usage: "prefix of the Kafka topic of each event type", Set: envconfig.String(func(c *Config) *string { return &c.KafkaTopicPrefix })},
+	{Env: "RABBITMQ_URL", Flag: "rabbitmq-url", Usage: "RabbitMQ amqp:// url", Set: envconfig.String(func(c *Config) *string { return &c.RabbitMQURL })},
+	{Env: "RABBITMQ_EXCHANGE", Flag: "rabbitmq-exchange", Usage: "RabbitMQ topic exchange receiving events", Set: envconfig.String(func(c *Config) *string { return &c.RabbitMQExchange })},
+	{Env: "RABBITMQ_ROUTING_KEY_PREFIX", Flag: "rabbitmq-routing-key-prefix", Usage: "prefix of the routing key of each event type", Set: envconfig.String(func(c *Config) *string { return &c.RabbitMQRoutingKeyPrefix })},
+	{Env: "NATS_URL", Flag: "nats-url", Usage: "NATS server url", Set: envconfig.String(func(c *Config) *string { return &c.NATSURL })},
+	{Env: "NATS_STREAM", Flag: "nats-stream", Usage: "JetStream stream provisioned for events", Set: envconfig.String(func(c *Config) *string { return &c.NATSStream })},
+	{Env: "NATS_SUBJECT_PREFIX", Flag: "nats-subject-prefix", Usage: "prefix of the subject of each event type", Set: envconfig.String(func(c *Config) *string { return &c.NATSSubjectPrefix })},
+	{Env: "NATS_CONSUMER", Flag: "nats-consumer", Usage: "durable JetStream consumer name", Set: envconfig.String(func(c *Config) *string { return &c.NATSConsumer })},
+	{Env: "WEBHOOK_TIMEOUT", Flag: "webhook-timeout", Usage: "timeout of each webhook request", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.WebhookTimeout })},
+	{Env: "WEBHOOK_POLL_INTERVAL", Flag: "webhook-poll-interval", Usage: "how often pending webhook deliveries are polled", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.WebhookPollInterval })},
+	{Env: "WEBHOOK_BATCH_SIZE", Flag: "webhook-batch-size", Usage: "webhook deliveries sent per poll", Set: envconfig.Int(func(c *Config) *int { return &c.WebhookBatchSize })},
+	{Env: "WEBHOOK_MAX_ATTEMPTS", Flag: "webhook-max-attempts", Usage: "attempts before a webhook delivery is marked failed", Set: envconfig.Int(func(c *Config) *int { return &c.WebhookMaxAttempts })},
+	{Env: "WEBHOOK_MAX_BACKOFF", Flag: "webhook-max-backoff", Usage: "maximum wait between webhook retries", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.WebhookMaxBackoff })},
+	{Env: "OPENAPI_VALIDATION", Flag: "openapi-validation", Usage: "reject requests that do not match the OpenAPI spec", Set: envconfig.Bool(func(c *Config) *bool { return &c.OpenAPIValidation })},
+	{Env: "OPENAPI_VALIDATE_RESPONSES", Flag: "openapi-validate-responses", Usage: "log responses that do not match the OpenAPI spec (development)", Set: envconfig.Bool(func(c *Config) *bool { return &c.OpenAPIValidateResponses })},
+	{Env: "RESPONSE_ENVELOPE", Flag: "response-envelope", Usage: "wrap JSON success bodies in data, meta and request_id", Set: envconfig.Bool(func(c *Config) *bool { return &c.ResponseEnvelope })},
+	{Env: "STREAM_POLL_INTERVAL", Flag: "stream-poll-interval", Usage: "how often live streams poll the outbox", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.StreamPollInterval })},
+	{Env: "CHANGE_FEED", Flag: "change-feed", Usage: "listen to PostgreSQL change notifications to invalidate caches and wake streams", Set: envconfig.Bool(func(c *Config) *bool { return &c.ChangeFeed })},
+	{Env: "WS_ALLOWED_ORIGINS", Flag: "ws-allowed-origins", Usage: "comma separated origins allowed to open WebSockets, * for any", Set: envconfig.String(func(c *Config) *string { return &c.WSAllowedOrigins })},
+	{Env: "DISCOVERY_BACKEND", Flag: "discovery-backend", Usage: "service registry: none, consul or etcd", Set: envconfig.String(func(c *Config) *string { return &c.DiscoveryBackend })},
+	{Env: "DISCOVERY_URL", Flag: "discovery-url", Usage: "service registry url (consul agent or etcd endpoint)", Set: envconfig.String(func(c *Config) *string { return &c.DiscoveryURL })},
+	{Env: "DISCOVERY_TTL", Flag: "discovery-ttl", Usage: "how long a registration outlives a dead instance", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DiscoveryTTL })},
+	{Env: "SERVICE_NAME", Flag: "service-name", Usage: "name the instance registers under", Set: envconfig.String(func(c *Config) *string { return &c.ServiceName })},
+	{Env: "SERVICE_ADDRESS", Flag: "service-address", Usage: "advertised host:port, hostname and server port by default", Set: envconfig.String(func(c *Config) *string { return &c.ServiceAddress })},
+	{Env: "REDIS_URL", Flag: "redis-url", Usage: "redis connection url", Set: envconfig.String(func(c *Config) *string { return &c.RedisURL })},
+	{Env: "CACHE_BACKEND", Flag: "cache-backend", Usage: "employee read cache: none, memory or redis", Set: envconfig.String(func(c *Config) *string { return &c.CacheBackend })},
+	{Env: "CACHE_TTL", Flag: "cache-ttl", Usage: "employee cache entry TTL", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.CacheTTL })},
+	{Env: "CACHE_MAX_ENTRIES", Flag: "cache-max-entries", Usage: "memory cache entry limit, 0 unlimited", Set: envconfig.Int(func(c *Config) *int { return &c.CacheMaxEntries })},
+	{Env: "CACHE_MAX_BYTES", Flag: "cache-max-bytes", Usage: "memory cache size limit in bytes, 0 unlimited", Set: envconfig.Int(func(c *Config) *int { return &c.CacheMaxBytes })},
+	{Env: "CACHE_VERIFY", Flag: "cache-verify", Usage: "compare cache hits with the db and count stale ones (development)", Set: envconfig.Bool(func(c *Config) *bool { return &c.CacheVerify })},
+	{Env: "BACKUP_INTERVAL", Flag: "backup-interval", Usage: "how often employees are exported to the backup store, 0 disables", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.BackupInterval })},
+	{Env: "BACKUP_RETENTION", Flag: "backup-retention", Usage: "backup archives kept, older ones are deleted", Set: envconfig.Int(func(c *Config) *int { return &c.BackupRetention })},
+	{Env: "BACKUP_STORE", Flag: "backup-store", Usage: "where backups are written: file or s3", Set: envconfig.String(func(c *Config) *string { return &c.BackupStore })},
+	{Env: "BACKUP_DIR", Flag: "backup-dir", Usage: "directory of the file backup store", Set: envconfig.String(func(c *Config) *string { return &c.BackupDir })},
+	{Env: "BACKUP_S3_ENDPOINT", Flag: "backup-s3-endpoint", Usage: "S3 compatible endpoint as host[:port]", Set: envconfig.String(func(c *Config) *string { return &c.BackupS3Endpoint })},
+	{Env: "BACKUP_S3_REGION", Flag: "backup-s3-region", Usage: "S3 region, empty to detect it", Set: envconfig.String(func(c *Config) *string { return &c.BackupS3Region })},
+	{Env: "BACKUP_S3_BUCKET", Flag: "backup-s3-bucket", Usage: "S3 bucket receiving backups", Set: envconfig.String(func(c *Config) *string { return &c.BackupS3Bucket })},
+	{Env: "BACKUP_S3_PREFIX", Flag: "backup-s3-prefix", Usage: "key prefix of the backups in the bucket", Set: envconfig.String(func(c *Config) *string { return &c.BackupS3Prefix })},
+	{Env: "BACKUP_S3_ACCESS_KEY", Flag: "backup-s3-access-key", Usage: "S3 access key, empty uses the instance role", Set: envconfig.String(func(c *Config) *string { return &c.BackupS3Access })},
+	{Env: "BACKUP_S3_SECRET_KEY", Flag: "backup-s3-secret-key", Usage: "S3 secret key", Set: envconfig.String(func(c *Config) *string { return &c.BackupS3Secret })},
+	{Env: "BACKUP_S3_USE_SSL", Flag: "backup-s3-use-ssl", Usage: "connect to the S3 endpoint over HTTPS", Set: envconfig.Bool(func(c *Config) *bool { return &c.BackupS3UseSSL })},
+	{Env: "RETENTION_INTERVAL", Flag: "retention-interval", Usage: "how often long retired employees are purged, 0 disables", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.RetentionInterval })},
+	{Env: "RETENTION_YEARS", Flag: "retention-years", Usage: "years an employee stays retired before being purged", Set: envconfig.Int(func(c *Config) *int { return &c.RetentionYears })},
+	{Env: "RETENTION_ACTION", Flag: "retention-action", Usage: "what a purge does: anonymize or delete", Set: envconfig.String(func(c *Config) *string { return &c.RetentionAction })},
+	{Env: "PROBATION_INTERVAL", Flag: "probation-interval", Usage: "how often employees whose probation lapsed are made active, 0 disables", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.ProbationInterval })},
+	{Env: "REMINDER_DEPARTMENTS", Flag: "reminder-departments", Usage: "comma separated departments getting anniversary and birthday reminders, * for all", Set: envconfig.String(func(c *Config) *string { return &c.ReminderDepartments })},
+	{Env: "REMINDER_DAYS_AHEAD", Flag: "reminder-days-ahead", Usage: "days before an anniversary or birthday its reminder is published", Set: envconfig.Int(func(c *Config) *int { return &c.ReminderDaysAhead })},
+	{Env: "ARCHIVE_INTERVAL", Flag: "archive-interval", Usage: "how often long retired employees are archived, 0 disables", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.ArchiveInterval })},
+	{Env: "ARCHIVE_AFTER_MONTHS", Flag: "archive-after-months", Usage: "months an employee stays retired before being archived", Set: envconfig.Int(func(c *Config) *int { return &c.ArchiveAfterMonths })},
+	{Env: "LEADER_ELECTION", Flag: "leader-election", Usage: "run the scheduled jobs on the elected instance only (PostgreSQL)", Set: envconfig.Bool(func(c *Config) *bool { return &c.LeaderElection })},
+	{Env: "LEADER_CHECK_INTERVAL", Flag: "leader-check-interval", Usage: "how often instances campaign and the leader checks its lock", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.LeaderCheckInterval })},
+	{Env: "SEARCH_URL", Flag: "search-url", Usage: "Elasticsearch or OpenSearch URL for employee search, empty disables it", Set: envconfig.String(func(c *Config) *string { return &c.SearchURL })},
+	{Env: "SEARCH_INDEX", Flag: "search-index", Usage: "name of the employee search index", Set: envconfig.String(func(c *Config) *string { return &c.SearchIndex })},
+	{Env: "SEARCH_USERNAME", Flag: "search-username", Usage: "search cluster basic auth username", Set: envconfig.String(func(c *Config) *string { return &c.SearchUsername })},
+	{Env: "SEARCH_PASSWORD", Flag: "search-password", Usage: "search cluster basic auth password", Set: envconfig.String(func(c *Config) *string { return &c.SearchPassword })},
+	{Env: "SEARCH_TIMEOUT", Flag: "search-timeout", Usage: "timeout of a request to the search cluster", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.SearchTimeout })},
+	{Env: "SEARCH_REINDEX_INTERVAL", Flag: "search-reindex-interval", Usage: "how often every employee is reindexed, 0 disables", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.SearchReindexInterval })},
+	{Env: "FEATURES_FILE", Flag: "features-file", Usage: "YAML file with feature flags", Set: envconfig.String(func(c *Config) *string { return &c.FeaturesFile })},
+	{Env: "FEATURES_REDIS_KEY", Flag: "features-redis-key", Usage: "redis hash holding feature flags", Set: envconfig.String(func(c *Config) *string { return &c.FeaturesRedisKey })},
+	{Env: "FEATURES_REFRESH", Flag: "features-refresh", Usage: "feature flag refresh interval, 0 disables", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.FeaturesRefresh })},
+	{Env: "SENTRY_DSN", Flag: "sentry-dsn", Usage: "Sentry DSN panics and 5xx responses are reported to, empty disables", Set: envconfig.String(func(c *Config) *string { return &c.SentryDSN })},
+	{Env: "SENTRY_ENVIRONMENT", Flag: "sentry-environment", Usage: "environment of the Sentry events, APP_ENV when empty", Set: envconfig.String(func(c *Config) *string { return &c.SentryEnvironment })},
+	{Env: "SENTRY_RELEASE", Flag: "sentry-release", Usage: "release of the Sentry events, the VCS revision when empty", Set: envconfig.String(func(c *Config) *string { return &c.SentryRelease })},
+	{Env: "SENTRY_SAMPLE_RATE", Flag: "sentry-sample-rate", Usage: "share of errors sent to Sentry, 0 to 1", Set: envconfig.Float(func(c *Config) *float64 { return &c.SentrySampleRate })},
+	{Env: "BODY_LOGGING", Flag: "body-logging", Usage: "log request and response bodies, on while debugging", Set: envconfig.Bool(func(c *Config) *bool { return &c.BodyLogging })},
+	{Env: "LOG_REDACT_FIELDS", Flag: "log-redact-fields", Usage: "comma separated JSON fields and query parameters redacted in logged bodies", Set: envconfig.String(func(c *Config) *string { return &c.LogRedactFields })},
+	{Env: "BODY_LOG_MAX_BYTES", Flag: "body-log-max-bytes", Usage: "logged bodies are cut at this size", Set: envconfig.Int(func(c *Config) *int { return &c.BodyLogMaxBytes })},
+	{Env: "BADGE_SIGNING_KEY", Flag: "badge-signing-key", Usage: "HMAC key of signed badge tokens, empty disables them", Set: envconfig.String(func(c *Config) *string { return &c.BadgeSigningKey })},
+	{Env: "BADGE_TOKEN_TTL", Flag: "badge-token-ttl", Usage: "how long a signed badge token is valid", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.BadgeTokenTTL })},
+	{Env: "REPORT_TITLE", Flag: "report-title", Usage: "title printed on the PDF report", Set: envconfig.String(func(c *Config) *string { return &c.ReportTitle })},
+	{Env: "REPORT_PAGE_SIZE", Flag: "report-page-size", Usage: "page size of the PDF report: A4 or Letter", Set: envconfig.String(func(c *Config) *string { return &c.ReportPageSize })},
+	{Env: "EMAIL_VERIFICATION_KEY", Flag: "email-verification-key", Usage: "HMAC key of email verification links, empty disables email verification", Set: envconfig.String(func(c *Config) *string { return &c.EmailVerificationKey })},
+	{Env: "EMAIL_VERIFICATION_URL", Flag: "email-verification-url", Usage: "page the verification links open, with the token appended as query parameter", Set: envconfig.String(func(c *Config) *string { return &c.EmailVerificationURL })},
+	{Env: "EMAIL_VERIFICATION_TTL", Flag: "email-verification-ttl", Usage: "how long an email verification link is valid", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.EmailVerificationTTL })},
+	{Env: "LDAP_URL", Flag: "ldap-url", Usage: "ldap:// or ldaps:// url of the directory employees are synced from, empty disables the sync", Set: envconfig.String(func(c *Config) *string { return &c.LDAPURL })},
+	{Env: "LDAP_BIND_DN", Flag: "ldap-bind-dn", Usage: "DN the directory sync binds as, empty binds anonymously", Set: envconfig.String(func(c *Config) *string { return &c.LDAPBindDN })},
+	{Env: "LDAP_BIND_PASSWORD", Flag: "ldap-bind-password", Usage: "password of LDAP_BIND_DN", Set: envconfig.String(func(c *Config) *string { return &c.LDAPBindPassword })},
+	{Env: "LDAP_BASE_DN", Flag: "ldap-base-dn", Usage: "DN of the subtree searched for employees", Set: envconfig.String(func(c *Config) *string { return &c.LDAPBaseDN })},
+	{Env: "LDAP_FILTER", Flag: "ldap-filter", Usage: "LDAP filter of the entries synced as employees", Set: envconfig.String(func(c *Config) *string { return &c.LDAPFilter })},
+	{Env: "LDAP_ATTRIBUTES", Flag: "ldap-attributes", Usage: "comma separated field=attribute pairs overriding the directory attribute mapping", Set: envconfig.String(func(c *Config) *string { return &c.LDAPAttributes })},
+	{Env: "LDAP_SYNC_INTERVAL", Flag: "ldap-sync-interval", Usage: "how often employees are synced from the directory, 0 syncs on request only", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.LDAPSyncInterval })},
+	{Env: "LDAP_TIMEOUT", Flag: "ldap-timeout", Usage: "how long a directory request may take", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.LDAPTimeout })},
+	{Env: "SCIM_TOKEN", Flag: "scim-token", Usage: "bearer token of the SCIM provisioning endpoint, empty disables it", Set: envconfig.String(func(c *Config) *string { return &c.SCIMToken })},
 }
 
-// Load gets the config from env variables
-// Exits if DATABASE_URL is not set
+// sslModes are the sslmode values accepted by PostgreSQL
+var sslModes = map[string]bool{
+	"disable":     true,
+	"allow":       true,
+	"prefer":      true,
+	"require":     true,
+	"verify-ca":   true,
+	"verify-full": true,
+}
+
+// Load gets the config merging, in order of precedence, defaults, the YAML
+// file, env variables and CLI flags
+// Exits if the resulting configuration is invalid
+// With -validate-config the effective config is printed first, redacted
 func Load() *Config {
 	_ = godotenv.Load()
 
-	cfg := &Config{
-		ServerPort: getEnv("SERVER_PORT", "8081"),
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnv("DB_PORT", "5432"),
-		DBName:     getEnv("DB_NAME", ""),
-		DBUser:     getEnv("DB_USER", ""),
-		DBPassword: getEnv("DB_PASSWORD", ""),
-		DBSSLMode:  getEnv("DB_SSLMODE", "disable"),
+	cfg, err := load(os.Args[1:])
+	if cfg != nil && cfg.ValidateConfig {
+		if err := cfg.Redacted().Write(os.Stdout); err != nil {
+			log.Fatalf("failed to print configuration: %v", err)
+		}
 	}
-
-	if cfg.DBName == "" || cfg.DBUser == "" {
-		log.Fatal("database configuration is incomplete")
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
 	}
 
 	return cfg
 }
 
+// redacted replaces secrets in printed configs
+const redacted = "REDACTED"
+
+// Redacted returns a copy of the config with passwords, tokens and the
+// credentials of urls replaced, safe to print
+func (c *Config) Redacted() *Config {
+	r := *c
+	for _, secret := range []*string{&r.AdminToken, &r.DBPassword, &r.BackupS3Secret, &r.SearchPassword, &r.BadgeSigningKey, &r.EmailVerificationKey, &r.LDAPBindPassword, &r.SCIMToken} {
+		if *secret != "" {
+			*secret = redacted
+		}
+	}
+	for _, u := range []*string{&r.SentryDSN, &r.MongoURI, &r.RabbitMQURL, &r.NATSURL, &r.DiscoveryURL, &r.RedisURL, &r.SearchURL} {
+		*u = redactURL(*u)
+	}
+	return &r
+}
+
+// redactURL replaces the password of a url, or the user when it is the
+// only credential (e.g. a token)
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	} else {
+		u.User = url.User(redacted)
+	}
+	return u.String()
+}
+
+// Write writes the config as YAML, in the format of the config file
+func (c *Config) Write(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// load builds and validates the config from the given CLI args
+func load(args []string) (*Config, error) {
+	cfg := defaults()
+	fs := flag.NewFlagSet("employee-management", flag.ContinueOnError)
+	validate := fs.Bool("validate-config", false, "print the redacted effective config and exit, non-zero when invalid")
+	checkConnections := fs.Bool("check-connections", false, "with -validate-config, also connect to the database and Redis")
+	if err := envconfig.Load(fs, args, cfg, options); err != nil {
+		return nil, err
+	}
+
+	cfg.Args = fs.Args()
+	cfg.ValidateConfig = *validate
+	cfg.CheckConnections = *checkConnections
+
+	// The config is returned with its problems so it can still be printed
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+
+	return cfg, nil
+}
+
+// defaults returns the config used when nothing else is set
+func defaults() *Config {
+	return &Config{
+		ServerPort: "8081",
+		Timezone:   "UTC",
+
+		TLSAutocertCacheDir: "certs",
+
+		ShutdownTimeout: 20 * time.Second,
+
+		RequestTimeout:  10 * time.Second,
+		HTTPCacheMaxAge: 30 * time.Second,
+
+		SLOTarget:  0.99,
+		SLOLatency: 300 * time.Millisecond,
+		SLOWindow:  time.Hour,
+
+		AppEnv:         "production",
+		SwaggerEnabled: true,
+
+		AdminHost: "127.0.0.1",
+		AdminPort: "6060",
+
+		StorageBackend: "postgres",
+		IDFormat:       "int",
+		MongoURI:       "mongodb://localhost:27017/?replicaSet=rs0",
+
+		DBHost:    "localhost",
+		DBPort:    "5432",
+		DBSSLMode: "disable",
+
+		DBMaxConns:          10,
+		DBMinConns:          2,
+		DBMaxConnLifetime:   time.Hour,
+		DBMaxConnIdleTime:   30 * time.Minute,
+		DBHealthCheckPeriod: time.Minute,
+		DBConnectTimeout:    5 * time.Second,
+
+		DBStatementTimeout: 5 * time.Second,
+
+		DBRetryInitialBackoff: 500 * time.Millisecond,
+		DBRetryMaxBackoff:     10 * time.Second,
+		DBRetryMaxWait:        time.Minute,
+
+		MigrateOnStartup: true,
+
+		BreakerFailureThreshold: 5,
+		BreakerOpenTimeout:      30 * time.Second,
+		BreakerHalfOpenMaxCalls: 1,
+
+		OutboxPollInterval: time.Second,
+		OutboxBatchSize:    100,
+		OutboxMaxBackoff:   5 * time.Minute,
+
+		JobWorkers:      4,
+		JobQueueSize:    100,
+		JobMaxAttempts:  3,
+		JobMaxBackoff:   time.Minute,
+		JobDrainTimeout: 30 * time.Second,
+
+		EventBroker:      "log",
+		KafkaTopicPrefix: "hr.",
+
+		RabbitMQExchange: "hr.events",
+
+		NATSURL:           "nats://localhost:4222",
+		NATSStream:        "HR_EVENTS",
+		NATSSubjectPrefix: "hr.",
+		NATSConsumer:      "employee-management",
+
+		WebhookTimeout:      10 * time.Second,
+		WebhookPollInterval: 2 * time.Second,
+		WebhookBatchSize:    50,
+		WebhookMaxAttempts:  8,
+		WebhookMaxBackoff:   time.Hour,
+
+		OpenAPIValidation: true,
+
+		StreamPollInterval: time.Second,
+		ChangeFeed:         true,
+
+		DiscoveryBackend: "none",
+		DiscoveryTTL:     30 * time.Second,
+		ServiceName:      "employee-management",
+
+		CacheBackend: "none",
+		CacheTTL:     5 * time.Minute,
+
+		CacheMaxEntries: 10000,
+		CacheMaxBytes:   64 << 20,
+
+		BackupRetention:  7,
+		BackupStore:      "file",
+		BackupDir:        "backups",
+		BackupS3Endpoint: "s3.amazonaws.com",
+		BackupS3Prefix:   "employee-management/",
+		BackupS3UseSSL:   true,
+
+		RetentionYears:  7,
+		RetentionAction: "anonymize",
+
+		ProbationInterval: time.Hour,
+
+		ReminderDaysAhead: 7,
+
+		ArchiveAfterMonths: 12,
+
+		LeaderElection:      true,
+		LeaderCheckInterval: 10 * time.Second,
+
+		SearchIndex:           "employees",
+		SearchTimeout:         5 * time.Second,
+		SearchReindexInterval: 24 * time.Hour,
+
+		FeaturesRedisKey: "employee-management:features",
+		FeaturesRefresh:  30 * time.Second,
+
+		SentrySampleRate: 1,
+
+		LogRedactFields: "firstName,lastName,name,email,personalEmail,phone,nationalId,dateOfBirth,gender,address,password,token,secret",
+		BodyLogMaxBytes: 4096,
+
+		BadgeTokenTTL: 365 * 24 * time.Hour,
+
+		ReportTitle:    "Employee report",
+		ReportPageSize: "A4",
+
+		EmailVerificationTTL: 72 * time.Hour,
+
+		LDAPFilter:       "(&(objectClass=person)(mail=*))",
+		LDAPSyncInterval: time.Hour,
+		LDAPTimeout:      30 * time.Second,
+	}
+}
+
+// Validate checks the final config and returns every problem found
+func (c *Config) Validate() error {
+	var errs []error
+
+	if err := envconfig.ValidatePort(c.ServerPort); err != nil {
+		errs = append(errs, fmt.Errorf("server port: %w", err))
+	}
+	if _, err := time.LoadLocation(c.Timezone); err != nil || c.Timezone == "" {
+		errs = append(errs, fmt.Errorf("timezone: unknown zone %q", c.Timezone))
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		errs = append(errs, errors.New("tls cert file and key file must be set together"))
+	}
+	if c.TLSCertFile != "" && c.TLSAutocertDomains != "" {
+		errs = append(errs, errors.New("tls cert files and autocert domains are mutually exclusive"))
+	}
+	if c.HTTPRedirectPort != "" {
+		if !c.TLSEnabled() {
+			errs = append(errs, errors.New("http redirect port requires TLS to be enabled"))
+		}
+		if err := envconfig.ValidatePort(c.HTTPRedirectPort); err != nil {
+			errs = append(errs, fmt.Errorf("http redirect port: %w", err))
+		}
+	}
+	if c.AppEnv != "production" && c.AppEnv != "development" {
+		errs = append(errs, fmt.Errorf("app env %q is not production or development", c.AppEnv))
+	}
+	if c.ServePprof() {
+		if err := envconfig.ValidatePort(c.AdminPort); err != nil {
+			errs = append(errs, fmt.Errorf("admin port: %w", err))
+		}
+		if c.AdminPort == c.ServerPort {
+			errs = append(errs, errors.New("admin port must differ from server port"))
+		}
+		// Without a token anyone reaching the admin host can profile
+		if c.AdminToken == "" && !isLoopback(c.AdminHost) {
+			errs = append(errs, fmt.Errorf("admin token is required when the admin host %q is not loopback", c.AdminHost))
+		}
+	}
+	if err := envconfig.ValidatePort(c.DBPort); err != nil {
+		errs = append(errs, fmt.Errorf("db port: %w", err))
+	}
+	if !sslModes[c.DBSSLMode] {
+		errs = append(errs, fmt.Errorf("db sslmode %q is not one of disable, allow, prefer, require, verify-ca, verify-full", c.DBSSLMode))
+	}
+	switch c.StorageBackend {
+	case "postgres", "mysql":
+		if c.DBName == "" {
+			errs = append(errs, errors.New("db name is required"))
+		}
+		if c.DBUser == "" {
+			errs = append(errs, errors.New("db user is required"))
+		}
+	case "mongodb":
+		if c.MongoURI == "" || c.DBName == "" {
+			errs = append(errs, errors.New("storage backend mongodb requires mongo uri and db name"))
+		}
+	case "memory":
+	default:
+		errs = append(errs, fmt.Errorf("storage backend %q is not one of postgres, mysql, mongodb, memory", c.StorageBackend))
+	}
+	if c.TestMode && (c.StorageBackend != "memory" || c.CacheBackend != "none") {
+		errs = append(errs, errors.New("test mode requires storage backend memory and cache backend none"))
+	}
+	if c.IDFormat != "int" && c.IDFormat != "uuid" {
+		errs = append(errs, fmt.Errorf("id format %q is not one of int, uuid", c.IDFormat))
+	}
+	if c.PhoneDefaultCountry != "" && len(c.PhoneDefaultCountry) != 2 {
+		errs = append(errs, fmt.Errorf("phone default country %q is not a two-letter country code", c.PhoneDefaultCountry))
+	}
+	if _, err := regexp.Compile(c.EmployeeNumberPattern); err != nil {
+		errs = append(errs, fmt.Errorf("employee number pattern: %w", err))
+	}
+	if c.DBMaxConns < 1 {
+		errs = append(errs, errors.New("db max conns must be at least 1"))
+	}
+	if c.DBMinConns < 0 || c.DBMinConns > c.DBMaxConns {
+		errs = append(errs, fmt.Errorf("db min conns must be between 0 and db max conns (%d)", c.DBMaxConns))
+	}
+	if c.DBMaxConnLifetime <= 0 || c.DBMaxConnIdleTime <= 0 || c.DBHealthCheckPeriod <= 0 || c.DBConnectTimeout <= 0 {
+		errs = append(errs, errors.New("db pool durations must be positive"))
+	}
+	if c.ShutdownDelay < 0 || c.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("shutdown delay must not be negative and shutdown timeout must be positive"))
+	}
+	if c.RequestTimeout < 0 || c.DBStatementTimeout < 0 {
+		errs = append(errs, errors.New("request and db statement timeouts must not be negative"))
+	}
+	for route, d := range c.RouteTimeouts {
+		if d < 0 {
+			errs = append(errs, fmt.Errorf("route timeout for %q must not be negative", route))
+		}
+	}
+	if c.HTTPCacheMaxAge < 0 {
+		errs = append(errs, errors.New("http cache max age must not be negative"))
+	}
+	for route, d := range c.RouteCacheMaxAges {
+		if d < 0 {
+			errs = append(errs, fmt.Errorf("route cache max age for %q must not be negative", route))
+		}
+	}
+	if c.SLOTarget <= 0 || c.SLOTarget >= 1 {
+		errs = append(errs, errors.New("slo target must be between 0 and 1, exclusive"))
+	}
+	if c.SLOLatency <= 0 {
+		errs = append(errs, errors.New("slo latency must be positive"))
+	}
+	for route, d := range c.RouteSLOLatencies {
+		if d <= 0 {
+			errs = append(errs, fmt.Errorf("slo latency for %q must be positive", route))
+		}
+	}
+	if c.SLOWindow < time.Minute {
+		errs = append(errs, errors.New("slo window must be at least 1m"))
+	}
+	if c.DBRetryInitialBackoff <= 0 || c.DBRetryMaxBackoff < c.DBRetryInitialBackoff {
+		errs = append(errs, errors.New("db retry backoff must be positive and max backoff at least the initial backoff"))
+	}
+	if c.DBRetryMaxWait < 0 {
+		errs = append(errs, errors.New("db retry max wait must not be negative"))
+	}
+	if c.BreakerFailureThreshold < 1 || c.BreakerHalfOpenMaxCalls < 1 {
+		errs = append(errs, errors.New("breaker failure threshold and half-open max calls must be at least 1"))
+	}
+	if c.BreakerOpenTimeout <= 0 {
+		errs = append(errs, errors.New("breaker open timeout must be positive"))
+	}
+	if c.OutboxPollInterval <= 0 || c.OutboxMaxBackoff <= 0 {
+		errs = append(errs, errors.New("outbox poll interval and max backoff must be positive"))
+	}
+	if c.OutboxBatchSize < 1 {
+		errs = append(errs, errors.New("outbox batch size must be at least 1"))
+	}
+	if c.JobWorkers < 1 || c.JobMaxAttempts < 1 {
+		errs = append(errs, errors.New("job workers and max attempts must be at least 1"))
+	}
+	if c.JobQueueSize < 0 {
+		errs = append(errs, errors.New("job queue size must not be negative"))
+	}
+	if c.JobMaxBackoff <= 0 || c.JobDrainTimeout <= 0 {
+		errs = append(errs, errors.New("job max backoff and drain timeout must be positive"))
+	}
+	switch c.EventBroker {
+	case "log":
+	case "kafka":
+		if len(envconfig.SplitList(c.KafkaBrokers)) == 0 {
+			errs = append(errs, errors.New("event broker kafka requires kafka brokers"))
+		}
+	case "rabbitmq":
+		if c.RabbitMQURL == "" || c.RabbitMQExchange == "" {
+			errs = append(errs, errors.New("event broker rabbitmq requires rabbitmq url and exchange"))
+		}
+	case "nats":
+		if c.NATSURL == "" || c.NATSStream == "" || c.NATSSubjectPrefix == "" {
+			errs = append(errs, errors.New("event broker nats requires nats url, stream and subject prefix"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("event broker %q is not one of log, kafka, rabbitmq, nats", c.EventBroker))
+	}
+	if c.WebhookTimeout <= 0 || c.WebhookPollInterval <= 0 || c.WebhookMaxBackoff <= 0 {
+		errs = append(errs, errors.New("webhook timeout, poll interval and max backoff must be positive"))
+	}
+	if c.WebhookBatchSize < 1 || c.WebhookMaxAttempts < 1 {
+		errs = append(errs, errors.New("webhook batch size and max attempts must be at least 1"))
+	}
+	if c.StreamPollInterval <= 0 {
+		errs = append(errs, errors.New("stream poll interval must be positive"))
+	}
+	switch c.DiscoveryBackend {
+	case "none":
+	case "consul", "etcd":
+		if c.DiscoveryURL == "" {
+			errs = append(errs, fmt.Errorf("discovery url is required for the %s backend", c.DiscoveryBackend))
+		}
+		if c.ServiceName == "" {
+			errs = append(errs, errors.New("service name is required for discovery"))
+		}
+		if c.DiscoveryTTL < 3*time.Second {
+			errs = append(errs, errors.New("discovery ttl must be at least 3s"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("discovery backend: unknown backend %q", c.DiscoveryBackend))
+	}
+	if c.BackupInterval < 0 {
+		errs = append(errs, errors.New("backup interval must not be negative"))
+	}
+	if c.BackupRetention < 1 {
+		errs = append(errs, errors.New("backup retention must be at least 1"))
+	}
+	switch c.BackupStore {
+	case "file":
+		if c.BackupDir == "" {
+			errs = append(errs, errors.New("backup store file requires backup dir"))
+		}
+	case "s3":
+		if c.BackupS3Endpoint == "" || c.BackupS3Bucket == "" {
+			errs = append(errs, errors.New("backup store s3 requires s3 endpoint and bucket"))
+		}
+		if (c.BackupS3Access == "") != (c.BackupS3Secret == "") {
+			errs = append(errs, errors.New("backup s3 access key and secret key must be set together"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("backup store %q is not one of file, s3", c.BackupStore))
+	}
+	if c.RetentionInterval < 0 {
+		errs = append(errs, errors.New("retention interval must not be negative"))
+	}
+	if c.RetentionYears < 1 {
+		errs = append(errs, errors.New("retention years must be at least 1"))
+	}
+	if c.RetentionAction != "anonymize" && c.RetentionAction != "delete" {
+		errs = append(errs, fmt.Errorf("retention action %q is not one of anonymize, delete", c.RetentionAction))
+	}
+	if c.ProbationInterval < 0 {
+		errs = append(errs, errors.New("probation interval must not be negative"))
+	}
+	if c.ReminderDaysAhead < 0 || c.ReminderDaysAhead > 365 {
+		errs = append(errs, errors.New("reminder days ahead must be between 0 and 365"))
+	}
+	if c.ArchiveInterval < 0 {
+		errs = append(errs, errors.New("archive interval must not be negative"))
+	}
+	if c.ArchiveAfterMonths < 1 {
+		errs = append(errs, errors.New("archive after months must be at least 1"))
+	}
+	if c.LeaderCheckInterval <= 0 {
+		errs = append(errs, errors.New("leader check interval must be positive"))
+	}
+	if c.SearchURL != "" && c.SearchIndex == "" {
+		errs = append(errs, errors.New("search url requires search index"))
+	}
+	if c.SearchTimeout <= 0 {
+		errs = append(errs, errors.New("search timeout must be positive"))
+	}
+	if c.SearchReindexInterval < 0 {
+		errs = append(errs, errors.New("search reindex interval must not be negative"))
+	}
+	switch c.CacheBackend {
+	case "none":
+	case "memory":
+		if c.CacheMaxEntries < 0 || c.CacheMaxBytes < 0 {
+			errs = append(errs, errors.New("cache max entries and max bytes must not be negative"))
+		}
+	case "redis":
+		if c.RedisURL == "" {
+			errs = append(errs, errors.New("cache backend redis requires redis url"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("cache backend %q is not one of none, memory, redis", c.CacheBackend))
+	}
+	if c.CacheTTL <= 0 {
+		errs = append(errs, errors.New("cache ttl must be positive"))
+	}
+	if c.FeaturesRefresh < 0 {
+		errs = append(errs, errors.New("features refresh must not be negative"))
+	}
+	if c.SentrySampleRate <= 0 || c.SentrySampleRate > 1 {
+		errs = append(errs, errors.New("sentry sample rate must be above 0 and at most 1"))
+	}
+	if c.BodyLogMaxBytes <= 0 {
+		errs = append(errs, errors.New("body log max bytes must be positive"))
+	}
+	if c.BadgeSigningKey != "" && len(c.BadgeSigningKey) < 32 {
+		errs = append(errs, errors.New("badge signing key must be at least 32 characters"))
+	}
+	if c.BadgeTokenTTL <= 0 {
+		errs = append(errs, errors.New("badge token ttl must be positive"))
+	}
+	if c.ReportPageSize != "A4" && c.ReportPageSize != "Letter" {
+		errs = append(errs, fmt.Errorf("report page size %q is not A4 or Letter", c.ReportPageSize))
+	}
+	if c.EmailVerificationKey != "" && len(c.EmailVerificationKey) < 32 {
+		errs = append(errs, errors.New("email verification key must be at least 32 characters"))
+	}
+	if c.EmailVerificationKey != "" {
+		if u, err := url.Parse(c.EmailVerificationURL); err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			errs = append(errs, errors.New("email verification key requires an absolute http(s) email verification url"))
+		}
+	}
+	if c.EmailVerificationTTL <= 0 {
+		errs = append(errs, errors.New("email verification ttl must be positive"))
+	}
+	if c.LDAPURL != "" {
+		if u, err := url.Parse(c.LDAPURL); err != nil || u.Host == "" || (u.Scheme != "ldap" && u.Scheme != "ldaps") {
+			errs = append(errs, fmt.Errorf("ldap url %q is not an ldap:// or ldaps:// url", c.LDAPURL))
+		}
+		if c.LDAPBaseDN == "" {
+			errs = append(errs, errors.New("ldap url requires ldap base dn"))
+		}
+		if c.LDAPFilter == "" {
+			errs = append(errs, errors.New("ldap url requires ldap filter"))
+		}
+	}
+	if c.LDAPSyncInterval < 0 {
+		errs = append(errs, errors.New("ldap sync interval must not be negative"))
+	}
+	if c.LDAPTimeout <= 0 {
+		errs = append(errs, errors.New("ldap timeout must be positive"))
+	}
+	if c.SCIMToken != "" && len(c.SCIMToken) < 32 {
+		errs = append(errs, errors.New("scim token must be at least 32 characters"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// TLSEnabled reports whether the server should terminate TLS itself
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" || c.TLSAutocertDomains != ""
+}
+
+// Debugging reports whether the debug tooling is on, with DEBUG or in
+// development
+func (c *Config) Debugging() bool {
+	return c.Debug || c.AppEnv == "development"
+}
+
+// ServeSwagger reports whether the Swagger UI and spec are served
+func (c *Config) ServeSwagger() bool {
+	return c.SwaggerEnabled || c.Debugging()
+}
+
+// ServePprof reports whether the admin listener serves pprof
+func (c *Config) ServePprof() bool {
+	return c.PprofEnabled || c.Debugging()
+}
+
+// LogBodies reports whether request and response bodies are logged
+func (c *Config) LogBodies() bool {
+	return c.BodyLogging || c.Debugging()
+}
+
 // DatabaseURL creates the connection url to the db
 func (c *Config) DatabaseURL() string {
 	return fmt.Sprintf(
@@ -56,10 +1023,78 @@ func (c *Config) DatabaseURL() string {
 	)
 }
 
-// getEnv returns env variable value or default if not set
-func getEnv(key, defaultVal string) string {
-	if val, ok := os.LookupEnv(key); ok {
-		return val
+// mysqlTLS maps the PostgreSQL sslmode values onto the tls parameter of
+// the MySQL driver
+var mysqlTLS = map[string]string{
+	"disable":     "false",
+	"allow":       "preferred",
+	"prefer":      "preferred",
+	"require":     "skip-verify",
+	"verify-ca":   "skip-verify",
+	"verify-full": "true",
+}
+
+// MySQLDSN creates the data source name of the MySQL db. Sessions run in
+// UTC and DATETIME values are scanned as UTC time.Time
+func (c *Config) MySQLDSN() string {
+	dsn := mysql.NewConfig()
+	dsn.User = c.DBUser
+	dsn.Passwd = c.DBPassword
+	dsn.Net = "tcp"
+	dsn.Addr = c.DBHost + ":" + c.DBPort
+	dsn.DBName = c.DBName
+	dsn.TLSConfig = mysqlTLS[c.DBSSLMode]
+	dsn.Timeout = c.DBConnectTimeout
+	dsn.ParseTime = true
+	dsn.Loc = time.UTC
+	// Updates report the matched rows, not only the changed ones
+	dsn.ClientFoundRows = true
+	dsn.Params = map[string]string{"time_zone": "'+00:00'"}
+	if c.DBStatementTimeout > 0 {
+		dsn.Params["max_execution_time"] = strconv.FormatInt(c.DBStatementTimeout.Milliseconds(), 10)
+	}
+	return dsn.FormatDSN()
+}
+
+// isLoopback reports whether host only accepts local connections, an
+// empty host listens on every interface
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// setDurationMap returns a setter that parses "key=duration,..." pairs
+func setDurationMap(field func(c *Config) *map[string]time.Duration) func(c *Config, val string) error {
+	return func(c *Config, val string) error {
+		m := map[string]time.Duration{}
+		for _, pair := range strings.Split(val, ",") {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+
+			key, raw, ok := strings.Cut(pair, "=")
+			if !ok {
+				return fmt.Errorf("%q is not key=duration", pair)
+			}
+			d, err := time.ParseDuration(strings.TrimSpace(raw))
+			if err != nil {
+				return err
+			}
+			m[strings.TrimSpace(key)] = d
+		}
+		*field(c) = m
+		return nil
+	}
+}
+
+// Location returns the organization's time zone, validated by Validate
+func (c *Config) Location() *time.Location {
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.UTC
 	}
-	return defaultVal
+	return loc
 }
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateAdminToken(t *testing.T) {
+	cases := []struct {
+		name    string
+		pprof   bool
+		host    string
+		token   string
+		wantErr bool
+	}{
+		{"loopback ipv4 without token", true, "127.0.0.1", "", false},
+		{"loopback ipv6 without token", true, "::1", "", false},
+		{"localhost without token", true, "localhost", "", false},
+		{"every interface without token", true, "0.0.0.0", "", true},
+		{"empty host without token", true, "", "", true},
+		{"private address without token", true, "10.0.0.5", "", true},
+		{"hostname without token", true, "admin.internal", "", true},
+		{"every interface with token", true, "0.0.0.0", "s3cret", false},
+		{"pprof off", false, "0.0.0.0", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := defaults()
+			cfg.PprofEnabled = tc.pprof
+			cfg.AdminHost = tc.host
+			cfg.AdminToken = tc.token
+
+			err := cfg.Validate()
+			if got := err != nil && strings.Contains(err.Error(), "admin token"); got != tc.wantErr {
+				t.Fatalf("Validate() = %v, want admin token error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the advisory lock key held while migrating so
+// several instances starting at once do not race
+const migrationLockID = 7341001
+
+// Migration is a versioned schema change embedded in the binary
+// Files are named <version>_<name>.sql, e.g. 0002_add_phone.sql
+type Migration struct {
+	Version   int64
+	Name      string
+	SQL       string
+	AppliedAt *time.Time
+}
+
+// Migrator applies and lists the migrations of a storage backend
+type Migrator interface {
+	Migrate(ctx context.Context) error
+	Status(ctx context.Context) ([]Migration, error)
+}
+
+// postgresMigrator is the Migrator of a PostgreSQL pool
+type postgresMigrator struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresMigrator returns the Migrator running Migrate and
+// MigrationStatus on pool
+func NewPostgresMigrator(pool *pgxpool.Pool) Migrator {
+	return postgresMigrator{pool: pool}
+}
+
+func (m postgresMigrator) Migrate(ctx context.Context) error { return Migrate(ctx, m.pool) }
+
+func (m postgresMigrator) Status(ctx context.Context) ([]Migration, error) {
+	return MigrationStatus(ctx, m.pool)
+}
+
+// Migrate applies every pending migration in version order
+// Each migration runs in its own transaction together with the insert
+// into schema_migrations, so a failed migration leaves no partial state
+func Migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	migrations, err := MigrationStatus(ctx, pool)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.AppliedAt != nil {
+			continue
+		}
+
+		err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, m.SQL); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx,
+				"INSERT INTO employee.schema_migrations (version, name) VALUES ($1, $2)",
+				m.Version, m.Name,
+			)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %04d_%s failed: %w", m.Version, m.Name, err)
+		}
+
+		log.Printf("applied migration %04d_%s", m.Version, m.Name)
+	}
+
+	return nil
+}
+
+// MigrationStatus returns every embedded migration with the time it was
+// applied, nil for pending ones
+func MigrationStatus(ctx context.Context, pool *pgxpool.Pool) ([]Migration, error) {
+	if err := ensureMigrationsTable(ctx, pool); err != nil {
+		return nil, err
+	}
+
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pool.Query(ctx, "SELECT version, applied_at FROM employee.schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range migrations {
+		if at, ok := applied[migrations[i].Version]; ok {
+			migrations[i].AppliedAt = &at
+		}
+	}
+
+	return migrations, nil
+}
+
+// ensureMigrationsTable creates the table tracking applied migrations
+func ensureMigrationsTable(ctx context.Context, pool *pgxpool.Pool) error {
+	query := `
+	CREATE SCHEMA IF NOT EXISTS employee;
+	CREATE TABLE IF NOT EXISTS employee.schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	`
+
+	_, err := pool.Exec(ctx, query)
+	return err
+}
+
+// loadMigrations reads the migration files in dir sorted by version
+func loadMigrations(files embed.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	seen := map[int64]string{}
+	for _, entry := range entries {
+		file := entry.Name()
+		base := strings.TrimSuffix(file, ".sql")
+
+		versionStr, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s must be named <version>_<name>.sql", file)
+		}
+
+		version, err := strconv.ParseInt(versionStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has an invalid version: %w", file, err)
+		}
+		if other, dup := seen[version]; dup {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, file, version)
+		}
+		seen[version] = file
+
+		sql, err := files.ReadFile(dir + "/" + file)
+		if err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, Migration{Version: version, Name: name, SQL: string(sql)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
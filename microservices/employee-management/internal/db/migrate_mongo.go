@@ -0,0 +1,204 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// mongoMigration is a versioned change of the MongoDB collections. The
+// collections themselves need no schema, migrations create their indexes
+type mongoMigration struct {
+	Version int64
+	Name    string
+	Apply   func(ctx context.Context, db *mongo.Database) error
+}
+
+// mongoMigrations lists the MongoDB migrations in version order
+var mongoMigrations = []mongoMigration{
+	{Version: 1, Name: "create_indexes", Apply: createMongoIndexes},
+	{Version: 2, Name: "address_location", Apply: createMongoAddressIndex},
+	{Version: 3, Name: "case_insensitive_email", Apply: caseInsensitiveMongoEmail},
+	{Version: 4, Name: "employee_uuid", Apply: addMongoEmployeeUUID},
+}
+
+// mongoIndexNotFound is the error code of dropping a missing index
+const mongoIndexNotFound = 27
+
+// addMongoEmployeeUUID gives every employee without one a random uuid and
+// makes it unique. MongoDB cannot generate them, so they are set one by
+// one; a failed run resumes with the employees left
+func addMongoEmployeeUUID(ctx context.Context, db *mongo.Database) error {
+	employees := db.Collection("employees")
+	cursor, err := employees.Find(ctx, bson.M{"uuid": bson.M{"$exists": false}}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID int64 `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		if _, err := employees.UpdateOne(ctx,
+			bson.M{"_id": doc.ID, "uuid": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"uuid": uuid.NewString()}},
+		); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	_, err = employees.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "uuid", Value: 1}},
+		Options: options.Index().SetName("employees_uuid_key").SetUnique(true),
+	})
+	return err
+}
+
+// caseInsensitiveMongoEmail lower-cases the stored emails and rebuilds
+// their unique index with a case-insensitive collation. Employees whose
+// emails differ only in case fail it and must be fixed by hand first
+func caseInsensitiveMongoEmail(ctx context.Context, db *mongo.Database) error {
+	employees := db.Collection("employees")
+	if _, err := employees.UpdateMany(ctx, bson.M{},
+		bson.A{bson.M{"$set": bson.M{"email": bson.M{"$toLower": "$email"}}}},
+	); err != nil {
+		return err
+	}
+
+	var cmdErr mongo.CommandError
+	if err := employees.Indexes().DropOne(ctx, "employees_email_key"); err != nil &&
+		!(errors.As(err, &cmdErr) && cmdErr.Code == mongoIndexNotFound) {
+		return err
+	}
+
+	_, err := employees.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().
+			SetName("employees_email_key").
+			SetUnique(true).
+			SetCollation(&options.Collation{Locale: "en", Strength: 2}),
+	})
+	return err
+}
+
+// createMongoAddressIndex backs the country and city filters of the list
+func createMongoAddressIndex(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("employees").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "address_country", Value: 1}, {Key: "address_city", Value: 1}},
+		Options: options.Index().SetName("employees_address_location_idx"),
+	})
+	return err
+}
+
+// createMongoIndexes adds the unique keys of employees and the outbox, named
+// like their PostgreSQL constraints so duplicates map to the same errors
+func createMongoIndexes(ctx context.Context, db *mongo.Database) error {
+	unique := func(name string, keys bson.D) mongo.IndexModel {
+		return mongo.IndexModel{Keys: keys, Options: options.Index().SetName(name).SetUnique(true)}
+	}
+
+	if _, err := db.Collection("employees").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		unique("employees_email_key", bson.D{{Key: "email", Value: 1}}),
+		unique("employees_employee_number_key", bson.D{{Key: "employee_number", Value: 1}}),
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+	}); err != nil {
+		return err
+	}
+
+	_, err := db.Collection("outbox").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		unique("outbox_event_id_key", bson.D{{Key: "event_id", Value: 1}}),
+		unique("outbox_sequence_key", bson.D{{Key: "sequence", Value: 1}}),
+		unique("outbox_aggregate_version_idx", bson.D{{Key: "aggregate_id", Value: 1}, {Key: "aggregate_version", Value: 1}}),
+		{Keys: bson.D{{Key: "published_at", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+	})
+	return err
+}
+
+// mongoMigrator is the Migrator of a MongoDB database
+type mongoMigrator struct {
+	db *mongo.Database
+}
+
+// NewMongoMigrator returns the Migrator of the MongoDB collections. Applied
+// versions are tracked in the schema_migrations collection; migrations
+// are written to be repeatable, as nothing locks them against instances
+// starting at once
+func NewMongoMigrator(db *mongo.Database) Migrator {
+	return mongoMigrator{db: db}
+}
+
+// Migrate applies every pending migration in version order
+func (m mongoMigrator) Migrate(ctx context.Context) error {
+	migrations, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i, mig := range migrations {
+		if mig.AppliedAt != nil {
+			continue
+		}
+
+		if err := mongoMigrations[i].Apply(ctx, m.db); err != nil {
+			return fmt.Errorf("migration %04d_%s failed: %w", mig.Version, mig.Name, err)
+		}
+		_, err := m.db.Collection("schema_migrations").UpdateOne(ctx,
+			bson.M{"_id": mig.Version},
+			bson.M{"$setOnInsert": bson.M{"name": mig.Name, "applied_at": time.Now().UTC()}},
+			options.UpdateOne().SetUpsert(true),
+		)
+		if err != nil {
+			return fmt.Errorf("migration %04d_%s failed: %w", mig.Version, mig.Name, err)
+		}
+
+		log.Printf("applied migration %04d_%s", mig.Version, mig.Name)
+	}
+
+	return nil
+}
+
+// Status returns every MongoDB migration with the time it was applied,
+// nil for pending ones
+func (m mongoMigrator) Status(ctx context.Context) ([]Migration, error) {
+	cursor, err := m.db.Collection("schema_migrations").Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	var applied []struct {
+		Version   int64     `bson:"_id"`
+		AppliedAt time.Time `bson:"applied_at"`
+	}
+	if err := cursor.All(ctx, &applied); err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	at := map[int64]time.Time{}
+	for _, a := range applied {
+		at[a.Version] = a.AppliedAt
+	}
+
+	migrations := make([]Migration, len(mongoMigrations))
+	for i, mig := range mongoMigrations {
+		migrations[i] = Migration{Version: mig.Version, Name: mig.Name}
+		if t, ok := at[mig.Version]; ok {
+			migrations[i].AppliedAt = &t
+		}
+	}
+
+	return migrations, nil
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+//go:embed mysql_migrations/*.sql
+var mysqlMigrationFiles embed.FS
+
+// mysqlMigrationLock is the named lock held while migrating MySQL
+const mysqlMigrationLock = "employee-management.migrate"
+
+// mysqlMigrator is the Migrator of a MySQL db
+type mysqlMigrator struct {
+	db *sql.DB
+}
+
+// NewMySQLMigrator returns the Migrator of the MySQL schema. MySQL commits
+// DDL statements implicitly, so a failed migration may leave the
+// statements before the failing one applied; they are written to be
+// repeatable
+func NewMySQLMigrator(db *sql.DB) Migrator {
+	return mysqlMigrator{db: db}
+}
+
+// Migrate applies every pending migration in version order, one statement
+// at a time
+func (m mysqlMigrator) Migrate(ctx context.Context) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT GET_LOCK(?, -1)", mysqlMigrationLock); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", mysqlMigrationLock)
+
+	migrations, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, mig := range migrations {
+		if mig.AppliedAt != nil {
+			continue
+		}
+
+		for _, stmt := range splitStatements(mig.SQL) {
+			if _, err := conn.ExecContext(ctx, stmt); err != nil {
+				return fmt.Errorf("migration %04d_%s failed: %w", mig.Version, mig.Name, err)
+			}
+		}
+		if _, err := conn.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name) VALUES (?, ?)",
+			mig.Version, mig.Name,
+		); err != nil {
+			return fmt.Errorf("migration %04d_%s failed: %w", mig.Version, mig.Name, err)
+		}
+
+		log.Printf("applied migration %04d_%s", mig.Version, mig.Name)
+	}
+
+	return nil
+}
+
+// Status returns every embedded MySQL migration with the time it was
+// applied, nil for pending ones
+func (m mysqlMigrator) Status(ctx context.Context) ([]Migration, error) {
+	if _, err := m.db.ExecContext(ctx, `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version BIGINT PRIMARY KEY,
+            name VARCHAR(255) NOT NULL,
+            applied_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+        )`); err != nil {
+		return nil, err
+	}
+
+	migrations, err := loadMigrations(mysqlMigrationFiles, "mysql_migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range migrations {
+		if at, ok := applied[migrations[i].Version]; ok {
+			migrations[i].AppliedAt = &at
+		}
+	}
+
+	return migrations, nil
+}
+
+// splitStatements splits a migration on the semicolons ending a line, the
+// driver runs one statement per call
+func splitStatements(sql string) []string {
+	var stmts []string
+	for _, stmt := range strings.Split(sql, ";\n") {
+		if stmt = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(stmt), ";")); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts
+}
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS employee.outbox (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	event_id UUID UNIQUE NOT NULL,
+	event_type VARCHAR(100) NOT NULL,
+	aggregate_id BIGINT NOT NULL,
+	payload JSONB NOT NULL,
+	occurred_at TIMESTAMP NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT,
+	next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	published_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx
+	ON employee.outbox (next_attempt_at)
+	WHERE published_at IS NULL;
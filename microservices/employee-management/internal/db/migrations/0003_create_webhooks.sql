@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS employee.webhook_subscriptions (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	url TEXT NOT NULL,
+	secret TEXT NOT NULL,
+	event_types TEXT[] NOT NULL,
+	active BOOLEAN NOT NULL DEFAULT TRUE,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS employee.webhook_deliveries (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	subscription_id BIGINT NOT NULL REFERENCES employee.webhook_subscriptions (id) ON DELETE CASCADE,
+	event_id UUID NOT NULL,
+	event_type VARCHAR(100) NOT NULL,
+	payload JSONB NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+	attempts INTEGER NOT NULL DEFAULT 0,
+	response_code INTEGER,
+	last_error TEXT,
+	next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	delivered_at TIMESTAMP,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx
+	ON employee.webhook_deliveries (next_attempt_at)
+	WHERE status = 'PENDING';
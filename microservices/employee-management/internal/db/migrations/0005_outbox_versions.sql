@@ -0,0 +1,17 @@
+-- aggregate_version numbers the events of each employee 1, 2, 3... so
+-- consumers keeping a copy of the employees can tell they missed one. The
+-- outbox id cannot tell: rolled back inserts leave holes in it
+ALTER TABLE employee.outbox ADD COLUMN IF NOT EXISTS aggregate_version BIGINT;
+
+UPDATE employee.outbox o
+SET aggregate_version = v.version
+FROM (
+	SELECT id, ROW_NUMBER() OVER (PARTITION BY aggregate_id ORDER BY id) AS version
+	FROM employee.outbox
+) v
+WHERE o.id = v.id AND o.aggregate_version IS NULL;
+
+ALTER TABLE employee.outbox ALTER COLUMN aggregate_version SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS outbox_aggregate_version_idx
+	ON employee.outbox (aggregate_id, aggregate_version);
@@ -0,0 +1,7 @@
+-- Optional personal profile fields, NULL when not recorded
+ALTER TABLE employee.employees
+	ADD COLUMN IF NOT EXISTS phone VARCHAR(20),
+	ADD COLUMN IF NOT EXISTS date_of_birth DATE,
+	ADD COLUMN IF NOT EXISTS national_id VARCHAR(50),
+	ADD COLUMN IF NOT EXISTS gender VARCHAR(20),
+	ADD COLUMN IF NOT EXISTS personal_email VARCHAR(255);
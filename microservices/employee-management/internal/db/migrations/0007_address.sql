@@ -0,0 +1,11 @@
+-- Postal address of an employee, embedded in the row. The columns are all
+-- NULL when no address is recorded; country is an ISO 3166-1 alpha-2 code
+ALTER TABLE employee.employees
+	ADD COLUMN IF NOT EXISTS address_street VARCHAR(255),
+	ADD COLUMN IF NOT EXISTS address_city VARCHAR(100),
+	ADD COLUMN IF NOT EXISTS address_state VARCHAR(100),
+	ADD COLUMN IF NOT EXISTS address_postal_code VARCHAR(10),
+	ADD COLUMN IF NOT EXISTS address_country CHAR(2);
+
+CREATE INDEX IF NOT EXISTS employees_address_location_idx
+	ON employee.employees (address_country, address_city);
@@ -0,0 +1,22 @@
+-- Catalog of skills and the skills of each employee. Skills still
+-- assigned to someone cannot be deleted; deleting an employee drops their
+-- assignments
+CREATE TABLE IF NOT EXISTS employee.skills (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	name VARCHAR(100) NOT NULL,
+	category VARCHAR(100) NOT NULL DEFAULT '',
+	description TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS skills_name_key ON employee.skills (LOWER(name));
+
+CREATE TABLE IF NOT EXISTS employee.employee_skills (
+	employee_id INTEGER NOT NULL REFERENCES employee.employees (id) ON DELETE CASCADE,
+	skill_id BIGINT NOT NULL REFERENCES employee.skills (id) ON DELETE RESTRICT,
+	proficiency VARCHAR(20) NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (employee_id, skill_id)
+);
+
+CREATE INDEX IF NOT EXISTS employee_skills_skill_idx ON employee.employee_skills (skill_id);
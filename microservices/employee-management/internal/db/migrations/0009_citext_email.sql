@@ -0,0 +1,9 @@
+-- Emails are unique regardless of case. Existing emails are lower-cased
+-- first, the way the service now stores them; employees whose emails
+-- differ only in case make this fail and must be fixed by hand first.
+-- citext is a trusted extension, so the database owner can create it
+CREATE EXTENSION IF NOT EXISTS citext;
+
+UPDATE employee.employees SET email = LOWER(email) WHERE email <> LOWER(email);
+
+ALTER TABLE employee.employees ALTER COLUMN email TYPE CITEXT;
@@ -0,0 +1,28 @@
+-- Committed changes of employees and new outbox events are announced on
+-- the employee_changes channel, so every instance can drop cached copies
+-- and wake its live streams without waiting for the next poll. Payloads
+-- are JSON: {"table": "employees", "op": "UPDATE", "id": 42}
+CREATE OR REPLACE FUNCTION employee.notify_employee_change() RETURNS trigger AS $$
+BEGIN
+	IF TG_TABLE_NAME = 'outbox' THEN
+		PERFORM pg_notify('employee_changes', json_build_object(
+			'table', TG_TABLE_NAME, 'op', TG_OP, 'id', NEW.aggregate_id, 'sequence', NEW.id
+		)::text);
+	ELSE
+		PERFORM pg_notify('employee_changes', json_build_object(
+			'table', TG_TABLE_NAME, 'op', TG_OP, 'id', COALESCE(NEW.id, OLD.id)
+		)::text);
+	END IF;
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS employees_notify_change ON employee.employees;
+CREATE TRIGGER employees_notify_change
+	AFTER INSERT OR UPDATE OR DELETE ON employee.employees
+	FOR EACH ROW EXECUTE FUNCTION employee.notify_employee_change();
+
+DROP TRIGGER IF EXISTS outbox_notify_change ON employee.outbox;
+CREATE TRIGGER outbox_notify_change
+	AFTER INSERT ON employee.outbox
+	FOR EACH ROW EXECUTE FUNCTION employee.notify_employee_change();
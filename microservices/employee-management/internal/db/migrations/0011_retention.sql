@@ -0,0 +1,40 @@
+-- Retention: retired_at is when an employee last became RETIRED, so the
+-- retention job finds the ones retired long ago. Employees already retired
+-- take their last update as the best guess
+ALTER TABLE employee.employees
+	ADD COLUMN IF NOT EXISTS retired_at TIMESTAMPTZ,
+	ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMPTZ;
+
+UPDATE employee.employees SET retired_at = updated_at
+WHERE status = 'RETIRED' AND retired_at IS NULL;
+
+CREATE OR REPLACE FUNCTION employee.track_retirement() RETURNS trigger AS $$
+BEGIN
+	IF NEW.status <> 'RETIRED' THEN
+		NEW.retired_at := NULL;
+	ELSIF TG_OP = 'INSERT' OR OLD.status <> 'RETIRED' THEN
+		NEW.retired_at := COALESCE(NEW.retired_at, CURRENT_TIMESTAMP);
+	END IF;
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS employees_track_retirement ON employee.employees;
+CREATE TRIGGER employees_track_retirement
+	BEFORE INSERT OR UPDATE OF status ON employee.employees
+	FOR EACH ROW EXECUTE FUNCTION employee.track_retirement();
+
+CREATE INDEX IF NOT EXISTS employees_retired_at_idx
+	ON employee.employees (retired_at)
+	WHERE anonymized_at IS NULL;
+
+-- One row per employee anonymized or deleted by the retention job. It
+-- keeps no personal data, only what identifies the record
+CREATE TABLE IF NOT EXISTS employee.retention_audit (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	employee_id BIGINT NOT NULL,
+	employee_number VARCHAR(50) NOT NULL,
+	action VARCHAR(20) NOT NULL,
+	retired_at TIMESTAMPTZ NOT NULL,
+	purged_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,12 @@
+-- Employees retired long ago are moved here by the archive job, so the
+-- table every list query scans only holds current staff. The archive has
+-- the columns of employees without their unique constraints; a column
+-- added to employees must be added here too
+CREATE TABLE IF NOT EXISTS employee.employees_archive (
+	LIKE employee.employees INCLUDING DEFAULTS,
+	archived_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS employees_archive_created_at_idx
+	ON employee.employees_archive (created_at);
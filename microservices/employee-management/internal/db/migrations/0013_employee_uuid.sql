@@ -0,0 +1,12 @@
+-- Random public identifier of an employee, used by the API instead of the
+-- sequential id when ID_FORMAT=uuid so ids cannot be enumerated. Adding
+-- the column fills a different uuid into every existing row
+ALTER TABLE employee.employees
+	ADD COLUMN IF NOT EXISTS uuid UUID NOT NULL DEFAULT gen_random_uuid();
+
+CREATE UNIQUE INDEX IF NOT EXISTS employees_uuid_key ON employee.employees (uuid);
+
+ALTER TABLE employee.employees_archive
+	ADD COLUMN IF NOT EXISTS uuid UUID NOT NULL DEFAULT gen_random_uuid();
+
+CREATE UNIQUE INDEX IF NOT EXISTS employees_archive_uuid_key ON employee.employees_archive (uuid);
@@ -0,0 +1,12 @@
+-- Probation: the last day of probation of employees hired on PROBATION,
+-- NULL for the others. The probation job makes employees whose date has
+-- lapsed ACTIVE. The archive gets the column too, see 0012_archive.sql
+ALTER TABLE employee.employees
+	ADD COLUMN IF NOT EXISTS probation_end_date DATE;
+
+ALTER TABLE employee.employees_archive
+	ADD COLUMN IF NOT EXISTS probation_end_date DATE;
+
+CREATE INDEX IF NOT EXISTS employees_probation_end_date_idx
+	ON employee.employees (probation_end_date)
+	WHERE status = 'PROBATION';
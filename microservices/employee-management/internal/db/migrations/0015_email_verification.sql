@@ -0,0 +1,8 @@
+-- Email verification: when the employee confirmed their current email,
+-- NULL until they do. Changing the email clears it. The archive gets the
+-- column too, see 0012_archive.sql
+ALTER TABLE employee.employees
+	ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
+
+ALTER TABLE employee.employees_archive
+	ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
@@ -0,0 +1,11 @@
+-- One row per employee whose probation ended, made ACTIVE by the
+-- probation job (reason probation_ended) or an update. It is written with
+-- the status change, whether events are enabled or not
+CREATE TABLE IF NOT EXISTS employee.probation_audit (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	employee_id BIGINT NOT NULL,
+	employee_number VARCHAR(50) NOT NULL,
+	probation_end_date DATE,
+	reason VARCHAR(50) NOT NULL DEFAULT '',
+	ended_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
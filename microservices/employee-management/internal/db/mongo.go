@@ -0,0 +1,38 @@
+package db
+
+import (
+	"context"
+	"log"
+
+	"employee-management/internal/config"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// NewMongoDatabase connects to MongoDB and returns the database DB_NAME
+// Like NewPostgresPool it waits for the server with exponential backoff
+// and terminates the app if it is still unreachable after the max wait
+func NewMongoDatabase(cfg *config.Config) *mongo.Database {
+	opts := options.Client().
+		ApplyURI(cfg.MongoURI).
+		SetMaxPoolSize(uint64(cfg.DBMaxConns)).
+		SetMinPoolSize(uint64(cfg.DBMinConns)).
+		SetMaxConnIdleTime(cfg.DBMaxConnIdleTime).
+		SetConnectTimeout(cfg.DBConnectTimeout)
+	if cfg.DBStatementTimeout > 0 {
+		opts.SetTimeout(cfg.DBStatementTimeout)
+	}
+
+	client, err := mongo.Connect(opts)
+	if err != nil {
+		log.Fatalf("invalid db configuration: %v", err)
+	}
+
+	ping := func(ctx context.Context) error { return client.Ping(ctx, nil) }
+	if err := pingWithRetry(context.Background(), ping, cfg); err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	return client.Database(cfg.DBName)
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"employee-management/internal/config"
+
+	_ "github.com/go-sql-driver/mysql" // registers the mysql driver
+)
+
+// NewMySQLDB creates and returns a MySQL connection pool tuned with the
+// pool settings from the config
+// Like NewPostgresPool it waits for the db with exponential backoff and
+// terminates the app if it is still unreachable after the max wait
+func NewMySQLDB(cfg *config.Config) *sql.DB {
+	db, err := sql.Open("mysql", cfg.MySQLDSN())
+	if err != nil {
+		log.Fatalf("invalid db configuration: %v", err)
+	}
+
+	db.SetMaxOpenConns(cfg.DBMaxConns)
+	db.SetMaxIdleConns(max(cfg.DBMinConns, 2))
+	db.SetConnMaxLifetime(cfg.DBMaxConnLifetime)
+	db.SetConnMaxIdleTime(cfg.DBMaxConnIdleTime)
+
+	if err := pingWithRetry(context.Background(), db.PingContext, cfg); err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	return db
+}
@@ -0,0 +1,34 @@
+-- The MySQL schema starts at the state the PostgreSQL migrations reached,
+-- the tables live in the database of DB_NAME
+CREATE TABLE IF NOT EXISTS employees (
+	id BIGINT AUTO_INCREMENT PRIMARY KEY,
+	first_name VARCHAR(255) NOT NULL,
+	last_name VARCHAR(255) NOT NULL,
+	email VARCHAR(255) NOT NULL,
+	employee_number VARCHAR(50) NOT NULL,
+	position VARCHAR(255) NOT NULL,
+	department VARCHAR(255) NOT NULL,
+	status VARCHAR(20) NOT NULL,
+	hire_date DATE NOT NULL,
+	created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+	updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+	CONSTRAINT employees_email_key UNIQUE (email),
+	CONSTRAINT employees_employee_number_key UNIQUE (employee_number)
+);
+
+CREATE TABLE IF NOT EXISTS outbox (
+	id BIGINT AUTO_INCREMENT PRIMARY KEY,
+	event_id CHAR(36) NOT NULL,
+	event_type VARCHAR(100) NOT NULL,
+	aggregate_id BIGINT NOT NULL,
+	aggregate_version BIGINT NOT NULL,
+	payload JSON NOT NULL,
+	occurred_at DATETIME(6) NOT NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	last_error TEXT,
+	next_attempt_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+	published_at DATETIME(6),
+	CONSTRAINT outbox_event_id_key UNIQUE (event_id),
+	CONSTRAINT outbox_aggregate_version_idx UNIQUE (aggregate_id, aggregate_version),
+	INDEX outbox_pending_idx (published_at, next_attempt_at)
+);
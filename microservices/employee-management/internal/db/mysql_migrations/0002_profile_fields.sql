@@ -0,0 +1,8 @@
+-- Optional personal profile fields, NULL when not recorded. A single
+-- ALTER TABLE applies all of them or none
+ALTER TABLE employees
+	ADD COLUMN phone VARCHAR(20) NULL,
+	ADD COLUMN date_of_birth DATE NULL,
+	ADD COLUMN national_id VARCHAR(50) NULL,
+	ADD COLUMN gender VARCHAR(20) NULL,
+	ADD COLUMN personal_email VARCHAR(255) NULL;
@@ -0,0 +1,9 @@
+-- Postal address of an employee, embedded in the row. The columns are all
+-- NULL when no address is recorded; country is an ISO 3166-1 alpha-2 code
+ALTER TABLE employees
+	ADD COLUMN address_street VARCHAR(255) NULL,
+	ADD COLUMN address_city VARCHAR(100) NULL,
+	ADD COLUMN address_state VARCHAR(100) NULL,
+	ADD COLUMN address_postal_code VARCHAR(10) NULL,
+	ADD COLUMN address_country CHAR(2) NULL,
+	ADD INDEX employees_address_location_idx (address_country, address_city);
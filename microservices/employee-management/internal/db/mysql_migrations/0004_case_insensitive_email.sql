@@ -0,0 +1,6 @@
+-- Emails are unique regardless of case. The column gets an explicit case
+-- insensitive collation instead of relying on the server default, and
+-- existing emails are lower-cased the way the service now stores them
+ALTER TABLE employees MODIFY email VARCHAR(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL;
+
+UPDATE employees SET email = LOWER(email) WHERE BINARY email <> BINARY LOWER(email);
@@ -0,0 +1,10 @@
+-- Random public identifier of an employee, used by the API instead of the
+-- sequential id when ID_FORMAT=uuid. UUID() is time based, so existing
+-- employees get random bytes instead; new ones get a uuid from the service
+ALTER TABLE employees ADD COLUMN uuid CHAR(36) NULL;
+
+UPDATE employees SET uuid = BIN_TO_UUID(RANDOM_BYTES(16)) WHERE uuid IS NULL;
+
+ALTER TABLE employees
+	MODIFY uuid CHAR(36) NOT NULL,
+	ADD UNIQUE INDEX employees_uuid_key (uuid);
@@ -0,0 +1,3 @@
+-- Probation: the last day of probation of employees hired on PROBATION,
+-- NULL for the others
+ALTER TABLE employees ADD COLUMN probation_end_date DATE NULL;
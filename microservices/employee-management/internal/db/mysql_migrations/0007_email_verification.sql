@@ -0,0 +1,3 @@
+-- Email verification: when the employee confirmed their current email,
+-- NULL until they do. Changing the email clears it
+ALTER TABLE employees ADD COLUMN email_verified_at DATETIME(6) NULL;
@@ -0,0 +1,10 @@
+-- One row per employee whose probation ended, see
+-- migrations/0016_probation_audit.sql
+CREATE TABLE IF NOT EXISTS probation_audit (
+	id BIGINT AUTO_INCREMENT PRIMARY KEY,
+	employee_id BIGINT NOT NULL,
+	employee_number VARCHAR(50) NOT NULL,
+	probation_end_date DATE NULL,
+	reason VARCHAR(50) NOT NULL DEFAULT '',
+	ended_at DATETIME(6) NOT NULL
+);
@@ -3,61 +3,88 @@ package db
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strconv"
+	"time"
 
+	"employee-management/internal/config"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // NewPostgresPool creates and return a new Postgresql connection pool
-// It validates the connection by pinging the hb and will terminate the
-// app if connection or ping fails
-func NewPostgresPool(dbURL string) *pgxpool.Pool {
-	pool, err := pgxpool.New(context.Background(), dbURL)
+// tuned with the pool settings from the config
+// It validates the connection by pinging the db, retrying with exponential
+// backoff while the db is not ready yet, and will terminate the app if the
+// db is still unreachable after the configured max wait
+func NewPostgresPool(cfg *config.Config) *pgxpool.Pool {
+	poolCfg, err := pgxpool.ParseConfig(cfg.DatabaseURL())
 	if err != nil {
-		log.Fatalf("failed to create db pool: %v", err)
+		log.Fatalf("invalid db configuration: %v", err)
 	}
 
-	if err := pool.Ping(context.Background()); err != nil {
-		log.Fatalf("failed to connect to database: %v", err)
+	poolCfg.MaxConns = int32(cfg.DBMaxConns)
+	poolCfg.MinConns = int32(cfg.DBMinConns)
+	poolCfg.MaxConnLifetime = cfg.DBMaxConnLifetime
+	poolCfg.MaxConnIdleTime = cfg.DBMaxConnIdleTime
+	poolCfg.HealthCheckPeriod = cfg.DBHealthCheckPeriod
+	poolCfg.ConnConfig.ConnectTimeout = cfg.DBConnectTimeout
+	if cfg.DBStatementTimeout > 0 {
+		poolCfg.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.DBStatementTimeout.Milliseconds(), 10)
+	}
+
+	// timestamptz values are scanned as UTC, whatever the local zone
+	poolCfg.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		conn.TypeMap().RegisterType(&pgtype.Type{
+			Name:  "timestamptz",
+			OID:   pgtype.TimestamptzOID,
+			Codec: &pgtype.TimestamptzCodec{ScanLocation: time.UTC},
+		})
+		return nil
+	}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
+	if err != nil {
+		log.Fatalf("failed to create db pool: %v", err)
 	}
 
-	if err := ensureSchemaAndTable(context.Background(), pool); err != nil {
-		log.Fatalf("database initialization failed: %v", err)
+	if err := pingWithRetry(context.Background(), pool.Ping, cfg); err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
 	}
 
 	return pool
 }
 
-// ensureSchemaAndTable validates if the schema and table exists
-// If not, creates the schema an table
-func ensureSchemaAndTable(ctx context.Context, db *pgxpool.Pool) error {
-	schemaQuery := `
-	CREATE SCHEMA IF NOT EXISTS employee;
-	`
-
-	tableQuery := `
-	CREATE TABLE IF NOT EXISTS employee.employees (
-		id INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
-		first_name VARCHAR(255) NOT NULL,
-		last_name VARCHAR(255) NOT NULL,
-		email VARCHAR(255) UNIQUE NOT NULL,
-		employee_number VARCHAR(50) UNIQUE NOT NULL,
-		position VARCHAR(255) NOT NULL,
-		department VARCHAR(255) NOT NULL,
-		status VARCHAR(20) NOT NULL,
-		hire_date TIMESTAMP NOT NULL,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-	`
-
-	if _, err := db.Exec(ctx, schemaQuery); err != nil {
-		return err
-	}
+// pingWithRetry pings the db until it answers, doubling the wait between
+// attempts up to DBRetryMaxBackoff, and gives up after DBRetryMaxWait
+func pingWithRetry(ctx context.Context, ping func(ctx context.Context) error, cfg *config.Config) error {
+	deadline := time.Now().Add(cfg.DBRetryMaxWait)
+	backoff := cfg.DBRetryInitialBackoff
 
-	if _, err := db.Exec(ctx, tableQuery); err != nil {
-		return err
-	}
+	for attempt := 1; ; attempt++ {
+		err := ping(ctx)
+		if err == nil {
+			return nil
+		}
 
-	return nil
+		if time.Now().Add(backoff).After(deadline) {
+			return fmt.Errorf("gave up after %d attempts: %w", attempt, err)
+		}
+
+		log.Printf("database not ready (attempt %d), retrying in %s: %v", attempt, backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > cfg.DBRetryMaxBackoff {
+			backoff = cfg.DBRetryMaxBackoff
+		}
+	}
 }
@@ -0,0 +1,25 @@
+package db
+
+import (
+	"context"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NewRedisClient creates and return a new Redis client from a redis:// url
+// It validates the connection by pinging the server and will terminate
+// the app if the url is invalid or the ping fails
+func NewRedisClient(redisURL string) *redis.Client {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		log.Fatalf("invalid redis url: %v", err)
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		log.Fatalf("failed to connect to redis: %v", err)
+	}
+
+	return client
+}
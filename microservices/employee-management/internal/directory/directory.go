@@ -0,0 +1,139 @@
+// Package directory synchronizes employees with an LDAP or Active
+// Directory server: on a schedule the people of the directory are
+// imported as employees, or update the employees with their employee
+// number, through a configurable mapping of attributes. Every run is
+// reported field by field, and employees edited in the service since
+// the last sync are flagged as conflicts instead of being overwritten
+package directory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"employee-management/internal/validator"
+)
+
+// Field is an employee field the directory sets
+type Field string
+
+// Fields the directory can set
+const (
+	FieldEmployeeNumber Field = "employeeNumber"
+	FieldFirstName      Field = "firstName"
+	FieldLastName       Field = "lastName"
+	FieldEmail          Field = "email"
+	FieldPosition       Field = "position"
+	FieldDepartment     Field = "department"
+	FieldPhone          Field = "phone"
+)
+
+// fields lists the fields in the order they are reported
+var fields = []Field{
+	FieldEmployeeNumber,
+	FieldFirstName,
+	FieldLastName,
+	FieldEmail,
+	FieldPosition,
+	FieldDepartment,
+	FieldPhone,
+}
+
+// Mapping maps the employee fields to the directory attributes holding
+// them. Fields left out are not synced, except the employee number
+// matching entries to employees
+type Mapping map[Field]string
+
+// DefaultMapping holds the attributes of Active Directory, which most
+// LDAP schemas share
+var DefaultMapping = Mapping{
+	FieldEmployeeNumber: "employeeID",
+	FieldFirstName:      "givenName",
+	FieldLastName:       "sn",
+	FieldEmail:          "mail",
+	FieldPosition:       "title",
+	FieldDepartment:     "department",
+	FieldPhone:          "telephoneNumber",
+}
+
+// ParseMapping parses comma separated field=attribute pairs on top of
+// DefaultMapping, e.g. "employeeNumber=employeeNumber,phone=mobile". A
+// field mapped to nothing, "phone=", is not synced
+func ParseMapping(s string) (Mapping, error) {
+	m := Mapping{}
+	for field, attr := range DefaultMapping {
+		m[field] = attr
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		field, attr, ok := strings.Cut(pair, "=")
+		if !ok || !slices.Contains(fields, Field(strings.TrimSpace(field))) {
+			return nil, fmt.Errorf("invalid directory attribute mapping %q, want <field>=<attribute> with a field among %v", pair, fields)
+		}
+		if attr = strings.TrimSpace(attr); attr == "" {
+			delete(m, Field(strings.TrimSpace(field)))
+		} else {
+			m[Field(strings.TrimSpace(field))] = attr
+		}
+	}
+
+	if m[FieldEmployeeNumber] == "" {
+		return nil, fmt.Errorf("the directory attribute mapping needs %s", FieldEmployeeNumber)
+	}
+	return m, nil
+}
+
+// Attributes returns the directory attributes to read
+func (m Mapping) Attributes() []string {
+	attrs := make([]string, 0, len(m))
+	for _, field := range fields {
+		if attr, ok := m[field]; ok {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// Entry is a person of the directory with the values of the mapped
+// fields
+type Entry struct {
+	DN     string
+	Values map[Field]string
+}
+
+// Directory lists the people to sync
+type Directory interface {
+	Entries(ctx context.Context) ([]Entry, error)
+}
+
+// normalized returns a copy of e with its values normalized the way the
+// service stores them, so a directory value only differs from the
+// employee when it is another value
+func (e Entry) normalized() Entry {
+	values := make(map[Field]string, len(e.Values))
+	for field, value := range e.Values {
+		values[field] = normalize(field, value)
+	}
+	return Entry{DN: e.DN, Values: values}
+}
+
+// normalize returns value of field the way the service stores it
+func normalize(field Field, value string) string {
+	value = strings.TrimSpace(value)
+	switch field {
+	case FieldFirstName, FieldLastName:
+		return validator.NormalizeName(value)
+	case FieldEmail:
+		return strings.ToLower(value)
+	case FieldPhone:
+		if phone, ok := validator.NormalizePhone(value); ok {
+			return phone
+		}
+	}
+	return value
+}
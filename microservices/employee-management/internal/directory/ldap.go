@@ -0,0 +1,79 @@
+package directory
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// pageSize is how many entries are read per LDAP page, below the 1000
+// Active Directory answers at most
+const pageSize = 500
+
+// LDAP reads the people of an LDAP or Active Directory server
+type LDAP struct {
+	url          string
+	bindDN       string
+	bindPassword string
+	baseDN       string
+	filter       string
+	mapping      Mapping
+	timeout      time.Duration
+}
+
+// NewLDAP creates a Directory searching the subtree of baseDN on the
+// server at url (ldap:// or ldaps://) for the entries matching filter,
+// bound as bindDN unless it is empty. Every request waits up to timeout
+func NewLDAP(url, bindDN, bindPassword, baseDN, filter string, mapping Mapping, timeout time.Duration) *LDAP {
+	return &LDAP{
+		url:          url,
+		bindDN:       bindDN,
+		bindPassword: bindPassword,
+		baseDN:       baseDN,
+		filter:       filter,
+		mapping:      mapping,
+		timeout:      timeout,
+	}
+}
+
+// Entries reads every matching entry, page by page
+func (d *LDAP) Entries(ctx context.Context) ([]Entry, error) {
+	conn, err := ldap.DialURL(d.url, ldap.DialWithDialer(&net.Dialer{Timeout: d.timeout}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to directory: %w", err)
+	}
+	defer conn.Close()
+	conn.SetTimeout(d.timeout)
+	// The client has no context support, closing the connection
+	// interrupts the pending request
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if d.bindDN != "" {
+		if err := conn.Bind(d.bindDN, d.bindPassword); err != nil {
+			return nil, fmt.Errorf("failed to bind to directory: %w", err)
+		}
+	}
+
+	req := ldap.NewSearchRequest(d.baseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false, d.filter, d.mapping.Attributes(), nil)
+	res, err := conn.SearchWithPaging(req, pageSize)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("failed to search directory: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		entry := Entry{DN: e.DN, Values: map[Field]string{}}
+		for field, attr := range d.mapping {
+			entry.Values[field] = e.GetEqualFoldAttributeValue(attr)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,360 @@
+package directory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"employee-management/internal/api"
+	"employee-management/internal/metrics"
+	"employee-management/internal/models"
+	"employee-management/internal/repository"
+	"employee-management/internal/validator"
+)
+
+// Errors of Sync
+var (
+	// ErrSyncRunning is returned while another sync runs
+	ErrSyncRunning = errors.New("a directory sync is already running")
+
+	// ErrDirectory wraps the failures to read the directory
+	ErrDirectory = errors.New("directory unavailable")
+)
+
+// Employees is the employee side of the sync, *service.EmployeeService
+type Employees interface {
+	FindAllStream(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error
+	Create(ctx context.Context, e *models.Employee) error
+	Update(ctx context.Context, e *models.Employee) error
+}
+
+// Syncer syncs the employees with a directory. The directory is the
+// source of the mapped fields: entries without an employee are created,
+// and the fields the directory changed since the last sync are updated.
+// A field the directory disagrees with that was edited in the service
+// since the last sync is a conflict, reported and left as it is until
+// one side is changed to match the other
+type Syncer struct {
+	directory Directory
+	employees Employees
+
+	// started is when the syncer was created, employees edited since
+	// are the ones edited after a sync the first run has no baseline of
+	started time.Time
+
+	// mu is held by the running sync and guards baseline
+	mu sync.Mutex
+	// baseline holds the directory values of the last sync by employee
+	// number, a service value differing from it was edited since
+	baseline map[string]map[Field]string
+
+	lastMu sync.RWMutex
+	last   *models.DirectorySyncReport
+}
+
+// NewSyncer creates a new Syncer
+func NewSyncer(directory Directory, employees Employees) *Syncer {
+	return &Syncer{directory: directory, employees: employees, started: time.Now()}
+}
+
+// Last returns the report of the last sync that was not a dry run, nil
+// before the first one
+func (s *Syncer) Last() *models.DirectorySyncReport {
+	s.lastMu.RLock()
+	defer s.lastMu.RUnlock()
+	return s.last
+}
+
+// Sync syncs the employees with the directory and reports it entry by
+// entry. A dry run reports what the sync would do without changing
+// anything. A sync stopped by an error reports the entries synced up to
+// it with the error
+func (s *Syncer) Sync(ctx context.Context, dryRun bool) (*models.DirectorySyncReport, error) {
+	if !s.mu.TryLock() {
+		return nil, ErrSyncRunning
+	}
+	defer s.mu.Unlock()
+
+	report := &models.DirectorySyncReport{
+		DryRun:    dryRun,
+		StartedAt: time.Now().UTC(),
+		Missing:   []string{},
+		Results:   []models.DirectoryResult{},
+	}
+	err := s.sync(ctx, report, dryRun)
+	report.FinishedAt = time.Now().UTC()
+	report.Count()
+	if err != nil {
+		report.Error = err.Error()
+	}
+
+	if !dryRun {
+		for _, result := range report.Results {
+			metrics.DirectorySyncResults.WithLabelValues(result.Result).Inc()
+		}
+		s.lastMu.Lock()
+		s.last = report
+		s.lastMu.Unlock()
+	}
+	return report, err
+}
+
+// sync syncs every entry into report
+func (s *Syncer) sync(ctx context.Context, report *models.DirectorySyncReport, dryRun bool) error {
+	entries, err := s.directory.Entries(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDirectory, err)
+	}
+
+	employees := map[string]*models.Employee{}
+	err = s.employees.FindAllStream(ctx, nil, func(e models.Employee) error {
+		employees[e.EmployeeNumber] = &e
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	baseline := map[string]map[Field]string{}
+	for _, entry := range entries {
+		entry = entry.normalized()
+		number := entry.Values[FieldEmployeeNumber]
+		result := models.DirectoryResult{DN: entry.DN, EmployeeNumber: number}
+
+		switch current := employees[number]; {
+		case number == "":
+			conflict(&result, "Entry has no employee number")
+		case baseline[number] != nil:
+			conflict(&result, "Employee number is shared with another directory entry")
+		case current == nil:
+			err = s.create(ctx, &result, entry, dryRun)
+		default:
+			err = s.update(ctx, &result, entry, current, dryRun)
+		}
+		if err != nil {
+			return err
+		}
+		if number != "" && baseline[number] == nil {
+			baseline[number] = entry.Values
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	for number, e := range employees {
+		if baseline[number] == nil && e.Status != models.StatusRetired {
+			report.Missing = append(report.Missing, number)
+		}
+	}
+	slices.Sort(report.Missing)
+
+	if !dryRun {
+		s.baseline = baseline
+	}
+	return nil
+}
+
+// create creates the employee of an entry without one
+func (s *Syncer) create(ctx context.Context, result *models.DirectoryResult, entry Entry, dryRun bool) error {
+	req := models.CreateEmployeeRequest{
+		EmployeeNumber: entry.Values[FieldEmployeeNumber],
+		FirstName:      entry.Values[FieldFirstName],
+		LastName:       entry.Values[FieldLastName],
+		Email:          entry.Values[FieldEmail],
+		Position:       entry.Values[FieldPosition],
+		Department:     entry.Values[FieldDepartment],
+	}
+	if phone := entry.Values[FieldPhone]; phone != "" {
+		req.Phone = &phone
+	}
+	if validation := validator.Struct(&req); !validation.IsValid {
+		conflict(result, "Entry fails validation: "+invalidFields(validation.Errors))
+		return nil
+	}
+
+	result.Result = models.DirectoryCreated
+	for _, field := range fields {
+		if value := entry.Values[field]; value != "" {
+			result.Changes = append(result.Changes, models.FieldChange{Field: string(field), To: value})
+		}
+	}
+	if dryRun {
+		return nil
+	}
+
+	e := req.Employee()
+	err := s.employees.Create(ctx, e)
+	switch {
+	case errors.Is(err, repository.ErrEmailAlreadyExists):
+		conflict(result, "Email already belongs to another employee")
+	case errors.Is(err, repository.ErrEmployeeNumberAlreadyExists):
+		conflict(result, "Employee number was taken during the sync")
+	case err != nil:
+		return err
+	default:
+		result.ID = e.ID
+	}
+	return nil
+}
+
+// update takes the fields the directory changed into the employee of an
+// entry and reports the conflicting ones
+func (s *Syncer) update(ctx context.Context, result *models.DirectoryResult, entry Entry, current *models.Employee, dryRun bool) error {
+	result.ID = current.ID
+	previous, synced := s.baseline[current.EmployeeNumber]
+
+	req := models.NewUpdateEmployeeRequest(current)
+	for _, field := range fields {
+		to := entry.Values[field]
+		from := value(current, field)
+		// Values missing from the directory are not synced
+		if to == "" || to == from {
+			continue
+		}
+
+		change := models.FieldChange{Field: string(field), From: from, To: to}
+		edited := current.UpdatedAt.After(s.started)
+		if synced {
+			edited = from != previous[field]
+		}
+		if edited {
+			result.Conflicts = append(result.Conflicts, change)
+			continue
+		}
+		result.Changes = append(result.Changes, change)
+		set(&req, field, to)
+	}
+
+	switch {
+	case len(result.Changes) == 0 && len(result.Conflicts) == 0:
+		result.Result = models.DirectoryUnchanged
+		return nil
+	case current.Status == models.StatusRetired:
+		conflict(result, "Retired employees cannot be edited")
+		return nil
+	}
+	if len(result.Changes) > 0 {
+		if validation := validator.Struct(&req); !validation.IsValid {
+			conflict(result, "Directory values fail validation: "+invalidFields(validation.Errors))
+			return nil
+		}
+	}
+
+	result.Result = models.DirectoryUpdated
+	if len(result.Conflicts) > 0 {
+		result.Result = models.DirectoryConflict
+		result.Reason = "Edited in the service since the last sync"
+	}
+	if len(result.Changes) == 0 || dryRun {
+		return nil
+	}
+
+	err := s.employees.Update(ctx, req.Employee(current.ID))
+	switch {
+	case errors.Is(err, repository.ErrEmailAlreadyExists):
+		conflict(result, "Email already belongs to another employee")
+	case errors.Is(err, repository.ErrEmployeeNotFound):
+		conflict(result, "Employee was deleted during the sync")
+	case err != nil:
+		return err
+	}
+	return nil
+}
+
+// Run syncs right away, then every interval until ctx is done
+func (s *Syncer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce syncs and logs the outcome
+func (s *Syncer) runOnce(ctx context.Context) {
+	report, err := s.Sync(ctx, false)
+	if errors.Is(err, ErrSyncRunning) {
+		return
+	}
+	if err != nil && ctx.Err() == nil {
+		log.Printf("directory sync failed after %d entries: %v", report.Entries, err)
+		return
+	}
+	if report.Created > 0 || report.Updated > 0 || report.Conflicts > 0 {
+		log.Printf("directory sync: %d created, %d updated, %d conflicts, %d missing from the directory",
+			report.Created, report.Updated, report.Conflicts, len(report.Missing))
+	}
+}
+
+// conflict makes result a conflict for reason, nothing of it applied
+func conflict(result *models.DirectoryResult, reason string) {
+	result.Result = models.DirectoryConflict
+	result.Reason = reason
+	result.Conflicts = append(result.Conflicts, result.Changes...)
+	result.Changes = nil
+}
+
+// invalidFields lists the fields of failed validation rules
+func invalidFields(details []api.ErrorDetail) string {
+	var invalid []string
+	for _, d := range details {
+		if !slices.Contains(invalid, d.Field) {
+			invalid = append(invalid, d.Field)
+		}
+	}
+	return strings.Join(invalid, ", ")
+}
+
+// value returns the value of field of e
+func value(e *models.Employee, field Field) string {
+	switch field {
+	case FieldEmployeeNumber:
+		return e.EmployeeNumber
+	case FieldFirstName:
+		return e.FirstName
+	case FieldLastName:
+		return e.LastName
+	case FieldEmail:
+		return e.Email
+	case FieldPosition:
+		return e.Position
+	case FieldDepartment:
+		return e.Department
+	case FieldPhone:
+		if e.Phone != nil {
+			return *e.Phone
+		}
+	}
+	return ""
+}
+
+// set sets field of req to v
+func set(req *models.UpdateEmployeeRequest, field Field, v string) {
+	switch field {
+	case FieldEmployeeNumber:
+		req.EmployeeNumber = v
+	case FieldFirstName:
+		req.FirstName = v
+	case FieldLastName:
+		req.LastName = v
+	case FieldEmail:
+		req.Email = v
+	case FieldPosition:
+		req.Position = v
+	case FieldDepartment:
+		req.Department = v
+	case FieldPhone:
+		req.Phone = &v
+	}
+}
@@ -0,0 +1,88 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// consulRegistrar registers through the local Consul agent HTTP API
+// Consul polls the health endpoint itself and drops the instance once it
+// has been critical for a while, so no keep-alive is needed
+type consulRegistrar struct {
+	url    string
+	client *http.Client
+	ttl    time.Duration
+}
+
+// consulService is the body of /v1/agent/service/register
+type consulService struct {
+	ID      string            `json:"ID"`
+	Name    string            `json:"Name"`
+	Address string            `json:"Address"`
+	Port    int               `json:"Port"`
+	Meta    map[string]string `json:"Meta"`
+	Check   consulCheck       `json:"Check"`
+}
+
+type consulCheck struct {
+	HTTP                           string `json:"HTTP"`
+	Interval                       string `json:"Interval"`
+	Timeout                        string `json:"Timeout"`
+	DeregisterCriticalServiceAfter string `json:"DeregisterCriticalServiceAfter"`
+}
+
+func (r *consulRegistrar) Register(ctx context.Context, inst Instance) error {
+	host, port, err := hostPort(inst.Address)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(consulService{
+		ID:      inst.ID,
+		Name:    inst.Name,
+		Address: host,
+		Port:    port,
+		Meta:    map[string]string{"scheme": inst.Scheme},
+		Check: consulCheck{
+			HTTP:                           inst.HealthURL,
+			Interval:                       (r.ttl / 3).String(),
+			Timeout:                        "5s",
+			DeregisterCriticalServiceAfter: (r.ttl * 2).String(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.put(ctx, "/v1/agent/service/register", body)
+}
+
+func (r *consulRegistrar) Deregister(ctx context.Context, inst Instance) error {
+	return r.put(ctx, "/v1/agent/service/deregister/"+url.PathEscape(inst.ID), nil)
+}
+
+// put sends a PUT to the agent API
+func (r *consulRegistrar) put(ctx context.Context, path string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, strings.TrimSuffix(r.url, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("consul request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("consul answered %d for %s", resp.StatusCode, path)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Package discovery registers the service instance in a service registry
+// (Consul or etcd) so the gateway and other services can find it
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Instance describes this running instance
+type Instance struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Address string `json:"address"` // host:port
+	Scheme  string `json:"scheme"`  // http or https
+	// HealthURL is polled by the registry (Consul) or published (etcd)
+	HealthURL string `json:"healthUrl"`
+}
+
+// Registrar registers an instance and keeps the registration alive
+type Registrar interface {
+	// Register adds the instance. Keep-alives run until ctx is done
+	Register(ctx context.Context, inst Instance) error
+	// Deregister removes the instance
+	Deregister(ctx context.Context, inst Instance) error
+}
+
+// NewRegistrar returns the registrar for backend, nil for "none"
+func NewRegistrar(backend, registryURL string, ttl time.Duration) (Registrar, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	switch backend {
+	case "", "none":
+		return nil, nil
+	case "consul":
+		return &consulRegistrar{url: registryURL, client: client, ttl: ttl}, nil
+	case "etcd":
+		return &etcdRegistrar{url: registryURL, client: client, ttl: ttl}, nil
+	default:
+		return nil, fmt.Errorf("unknown discovery backend %q", backend)
+	}
+}
+
+// NewInstance builds the instance of name listening on port. An empty
+// advertise address falls back to the hostname, which is what other
+// containers on the same network resolve
+func NewInstance(name, advertise, port, healthPath string, tls bool) (Instance, error) {
+	address := advertise
+	if address == "" {
+		host, err := os.Hostname()
+		if err != nil {
+			return Instance{}, err
+		}
+		address = net.JoinHostPort(host, port)
+	}
+
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return Instance{}, fmt.Errorf("advertise address %q must be host:port", address)
+	}
+
+	scheme := "http"
+	if tls {
+		scheme = "https"
+	}
+
+	return Instance{
+		ID:        name + "-" + address,
+		Name:      name,
+		Address:   address,
+		Scheme:    scheme,
+		HealthURL: scheme + "://" + address + healthPath,
+	}, nil
+}
+
+// hostPort splits an instance address, the port as an int
+func hostPort(address string) (string, int, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", 0, err
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil {
+		return "", 0, err
+	}
+	return host, n, nil
+}
@@ -0,0 +1,137 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeyPrefix is where instances are stored in etcd, as
+// <KeyPrefix><name>/<id> with the Instance as JSON
+const KeyPrefix = "/services/"
+
+// etcdRegistrar registers through the etcd v3 JSON gateway. The key is
+// bound to a lease that is kept alive while the service runs, so a
+// crashed instance disappears after the TTL
+type etcdRegistrar struct {
+	url    string
+	client *http.Client
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	lease string
+}
+
+func (r *etcdRegistrar) Register(ctx context.Context, inst Instance) error {
+	var grant struct {
+		ID string `json:"ID"`
+	}
+	if err := r.post(ctx, "/v3/lease/grant", map[string]any{"TTL": int64(r.ttl.Seconds())}, &grant); err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(inst)
+	if err != nil {
+		return err
+	}
+
+	put := map[string]any{
+		"key":   b64(KeyPrefix + inst.Name + "/" + inst.ID),
+		"value": base64.StdEncoding.EncodeToString(value),
+		"lease": grant.ID,
+	}
+	if err := r.post(ctx, "/v3/kv/put", put, nil); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.lease = grant.ID
+	r.mu.Unlock()
+
+	go r.keepAlive(ctx, inst, grant.ID)
+	return nil
+}
+
+// keepAlive refreshes the lease at a third of the TTL, registering again
+// if the lease was lost (e.g. etcd was unreachable for longer than the TTL)
+func (r *etcdRegistrar) keepAlive(ctx context.Context, inst Instance, lease string) {
+	ticker := time.NewTicker(r.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var resp struct {
+			Result struct {
+				TTL string `json:"TTL"`
+			} `json:"result"`
+		}
+		err := r.post(ctx, "/v3/lease/keepalive", map[string]any{"ID": lease}, &resp)
+		if err == nil && resp.Result.TTL != "" && resp.Result.TTL != "0" {
+			continue
+		}
+
+		log.Printf("etcd lease lost, registering %s again: %v", inst.ID, err)
+		if err := r.Register(ctx, inst); err != nil {
+			log.Printf("etcd registration failed: %v", err)
+			continue
+		}
+		return
+	}
+}
+
+func (r *etcdRegistrar) Deregister(ctx context.Context, inst Instance) error {
+	r.mu.Lock()
+	lease := r.lease
+	r.mu.Unlock()
+
+	if lease == "" {
+		return nil
+	}
+	// Revoking the lease deletes the key with it
+	return r.post(ctx, "/v3/lease/revoke", map[string]any{"ID": lease}, nil)
+}
+
+// post calls the JSON gateway, decoding the answer into out when set
+func (r *etcdRegistrar) post(ctx context.Context, path string, body, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(r.url, "/")+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("etcd request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("etcd answered %d for %s", resp.StatusCode, path)
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
+
+// b64 encodes a key for the JSON gateway
+func b64(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
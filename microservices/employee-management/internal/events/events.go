@@ -0,0 +1,138 @@
+// Package events defines the domain events emitted on employee changes
+// and the publisher interface used to deliver them
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"employee-management/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Type is the name of a domain event
+type Type string
+
+// Employee lifecycle events
+const (
+	EmployeeCreated       Type = "employee.created"
+	EmployeeUpdated       Type = "employee.updated"
+	EmployeeDeleted       Type = "employee.deleted"
+	EmployeeStatusChanged Type = "employee.status_changed"
+)
+
+// Reminder events, published ahead of the day by the reminders job
+const (
+	EmployeeAnniversaryUpcoming Type = "employee.anniversary_upcoming"
+	EmployeeBirthdayUpcoming    Type = "employee.birthday_upcoming"
+)
+
+// EmployeeEmailVerificationRequested asks the notification service to
+// email the employee a link confirming their address, published on
+// creation and email changes when email verification is on
+const EmployeeEmailVerificationRequested Type = "employee.email_verification_requested"
+
+// Types lists every event type the service emits
+var Types = []Type{
+	EmployeeCreated, EmployeeUpdated, EmployeeDeleted, EmployeeStatusChanged,
+	EmployeeAnniversaryUpcoming, EmployeeBirthdayUpcoming,
+	EmployeeEmailVerificationRequested,
+}
+
+// IsKnown reports whether t is one of Types
+func IsKnown(t string) bool {
+	for _, known := range Types {
+		if string(known) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is a domain event as stored in the outbox and delivered to brokers
+type Event struct {
+	ID          string          `json:"id"`
+	Type        Type            `json:"type"`
+	AggregateID int64           `json:"aggregateId"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	Payload     json.RawMessage `json:"payload" swaggertype:"object"`
+
+	// Version numbers the events of the aggregate from 1 without gaps,
+	// set when stored in the outbox. A consumer that sees a version more
+	// than one past the last it applied has missed an event
+	Version int64 `json:"version,omitempty"`
+
+	// Sequence is the outbox position of the event, set when read back
+	// from the outbox. It orders events and lets streams resume
+	Sequence int64 `json:"sequence,omitempty"`
+}
+
+// StatusChange is the payload of employee.status_changed
+type StatusChange struct {
+	EmployeeID int64                 `json:"employeeId"`
+	From       models.EmployeeStatus `json:"from"`
+	To         models.EmployeeStatus `json:"to"`
+	Department string                `json:"department,omitempty"`
+
+	// Reason says what made the change when it was not an update, e.g.
+	// probation_ended
+	Reason string `json:"reason,omitempty"`
+
+	// Contact details so consumers (e.g. notifications) need no lookup
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	Email     string `json:"email,omitempty"`
+}
+
+// Reminder is the payload of employee.anniversary_upcoming and
+// employee.birthday_upcoming
+type Reminder struct {
+	EmployeeID int64 `json:"employeeId"`
+
+	// Date is the day of the anniversary or birthday
+	Date models.Date `json:"date" swaggertype:"string" example:"2025-03-01"`
+
+	// Years of service completed on an anniversary
+	Years      int    `json:"years,omitempty"`
+	Department string `json:"department,omitempty"`
+
+	// Contact details so consumers (e.g. notifications) need no lookup
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	Email     string `json:"email,omitempty"`
+}
+
+// EmailVerification is the payload of employee.email_verification_requested
+type EmailVerification struct {
+	EmployeeID int64  `json:"employeeId"`
+	FirstName  string `json:"firstName"`
+	LastName   string `json:"lastName"`
+	Email      string `json:"email"`
+
+	// VerificationURL confirms the email when opened, until ExpiresAt
+	VerificationURL string    `json:"verificationUrl"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+}
+
+// Reasons of the status changes not made by an update
+const (
+	ReasonRehire         = "rehire"
+	ReasonProbationEnded = "probation_ended"
+)
+
+// New creates an event with a fresh id, marshalling payload as JSON
+func New(t Type, aggregateID int64, payload any) (*Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		ID:          uuid.NewString(),
+		Type:        t,
+		AggregateID: aggregateID,
+		OccurredAt:  time.Now().UTC(),
+		Payload:     data,
+	}, nil
+}
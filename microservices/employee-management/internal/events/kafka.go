@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaPublisher publishes events to Kafka, one topic per event type
+// named <prefix><type>, e.g. hr.employee.created. Messages are keyed by
+// employee id so events of the same employee stay ordered
+type KafkaPublisher struct {
+	writer      *kafka.Writer
+	topicPrefix string
+}
+
+// NewKafkaPublisher creates a publisher writing to the given brokers
+func NewKafkaPublisher(brokers []string, topicPrefix string) *KafkaPublisher {
+	return &KafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+			BatchTimeout:           10 * time.Millisecond,
+		},
+		topicPrefix: topicPrefix,
+	}
+}
+
+// Publish writes the event and waits for every in-sync replica to ack it
+func (p *KafkaPublisher) Publish(ctx context.Context, e Event) error {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Topic: p.topicPrefix + string(e.Type),
+		Key:   []byte(strconv.FormatInt(e.AggregateID, 10)),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: "event-id", Value: []byte(e.ID)},
+			{Key: "event-type", Value: []byte(e.Type)},
+		},
+	})
+}
+
+// Close flushes pending messages and closes the connections
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,102 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATSPublisher publishes events to a JetStream stream on the subject
+// <prefix><type>. The event id is sent as Nats-Msg-Id so JetStream drops
+// duplicates redelivered by the outbox within its dedup window
+type NATSPublisher struct {
+	conn          *nats.Conn
+	js            jetstream.JetStream
+	stream        string
+	subjectPrefix string
+}
+
+// NewNATSPublisher connects to NATS and creates or updates the stream
+// capturing every subject under the prefix
+func NewNATSPublisher(ctx context.Context, url, stream, subjectPrefix string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url, nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create jetstream context: %w", err)
+	}
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     stream,
+		Subjects: []string{subjectPrefix + ">"},
+		Storage:  jetstream.FileStorage,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to provision jetstream stream %s: %w", stream, err)
+	}
+
+	return &NATSPublisher{conn: conn, js: js, stream: stream, subjectPrefix: subjectPrefix}, nil
+}
+
+// Publish sends the event and waits for the JetStream ack
+func (p *NATSPublisher) Publish(ctx context.Context, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.js.Publish(ctx, p.subjectPrefix+string(e.Type), data, jetstream.WithMsgID(e.ID))
+	if err != nil {
+		return fmt.Errorf("failed to publish to jetstream: %w", err)
+	}
+	return nil
+}
+
+// Subscribe consumes the stream with a durable consumer until ctx is done
+// Messages are acked when handler succeeds and redelivered otherwise
+func (p *NATSPublisher) Subscribe(ctx context.Context, durable string, handler func(ctx context.Context, e Event) error) error {
+	consumer, err := p.js.CreateOrUpdateConsumer(ctx, p.stream, jetstream.ConsumerConfig{
+		Durable:   durable,
+		AckPolicy: jetstream.AckExplicitPolicy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create jetstream consumer %s: %w", durable, err)
+	}
+
+	consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
+		var e Event
+		if err := json.Unmarshal(msg.Data(), &e); err != nil {
+			log.Printf("dropping malformed event on %s: %v", msg.Subject(), err)
+			_ = msg.Term()
+			return
+		}
+
+		if err := handler(ctx, e); err != nil {
+			log.Printf("event %s handler failed, redelivering: %v", e.ID, err)
+			_ = msg.Nak()
+			return
+		}
+		_ = msg.Ack()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to consume jetstream: %w", err)
+	}
+
+	<-ctx.Done()
+	consumeCtx.Stop()
+	return nil
+}
+
+// Close drains pending messages and closes the connection
+func (p *NATSPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"employee-management/internal/config"
+
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+)
+
+// Publisher delivers events to a message broker
+// Delivery is at-least-once, consumers must deduplicate by event id
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+	Close() error
+}
+
+// LogPublisher only logs events, used when no broker is configured
+type LogPublisher struct{}
+
+// Publish logs the event
+func (LogPublisher) Publish(_ context.Context, e Event) error {
+	log.Printf("event %s %s aggregate=%d", e.ID, e.Type, e.AggregateID)
+	return nil
+}
+
+// Close does nothing
+func (LogPublisher) Close() error { return nil }
+
+// Subscriber consumes events from a broker with a durable subscription
+// handler errors cause the event to be redelivered
+type Subscriber interface {
+	Subscribe(ctx context.Context, durable string, handler func(ctx context.Context, e Event) error) error
+}
+
+// NewPublisher creates the publisher selected by the event broker setting
+func NewPublisher(ctx context.Context, cfg *config.Config) (Publisher, error) {
+	switch cfg.EventBroker {
+	case "log":
+		return LogPublisher{}, nil
+	case "kafka":
+		return NewKafkaPublisher(envconfig.SplitList(cfg.KafkaBrokers), cfg.KafkaTopicPrefix), nil
+	case "rabbitmq":
+		return NewRabbitMQPublisher(cfg.RabbitMQURL, cfg.RabbitMQExchange, cfg.RabbitMQRoutingKeyPrefix)
+	case "nats":
+		return NewNATSPublisher(ctx, cfg.NATSURL, cfg.NATSStream, cfg.NATSSubjectPrefix)
+	default:
+		return nil, fmt.Errorf("unknown event broker %q", cfg.EventBroker)
+	}
+}
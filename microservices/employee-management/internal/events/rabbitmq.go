@@ -0,0 +1,120 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// RabbitMQPublisher publishes events to a durable topic exchange with the
+// routing key <prefix><type>. Each publish waits for the broker confirm,
+// and the connection is re-established on the next publish if it drops
+type RabbitMQPublisher struct {
+	url              string
+	exchange         string
+	routingKeyPrefix string
+
+	mu   sync.Mutex
+	conn *amqp.Connection
+	ch   *amqp.Channel
+}
+
+// NewRabbitMQPublisher connects to the broker and declares the exchange
+func NewRabbitMQPublisher(url, exchange, routingKeyPrefix string) (*RabbitMQPublisher, error) {
+	p := &RabbitMQPublisher{url: url, exchange: exchange, routingKeyPrefix: routingKeyPrefix}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.connect(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Publish sends the event as a persistent message and waits for the confirm
+func (p *RabbitMQPublisher) Publish(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil || p.conn.IsClosed() || p.ch == nil || p.ch.IsClosed() {
+		if err := p.connect(); err != nil {
+			return err
+		}
+	}
+
+	confirm, err := p.ch.PublishWithDeferredConfirmWithContext(ctx, p.exchange, p.routingKeyPrefix+string(e.Type), true, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    e.ID,
+		Type:         string(e.Type),
+		Timestamp:    e.OccurredAt,
+		Body:         body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish to rabbitmq: %w", err)
+	}
+
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for rabbitmq confirm: %w", err)
+	}
+	if !acked {
+		return errors.New("rabbitmq nacked the event")
+	}
+
+	return nil
+}
+
+// Close closes the channel and connection
+func (p *RabbitMQPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		return nil
+	}
+	return p.conn.Close()
+}
+
+// connect dials the broker, enables confirms and declares the exchange
+// Caller holds mu
+func (p *RabbitMQPublisher) connect() error {
+	if p.conn != nil && !p.conn.IsClosed() {
+		_ = p.conn.Close()
+	}
+
+	conn, err := amqp.Dial(p.url)
+	if err != nil {
+		return fmt.Errorf("failed to connect to rabbitmq: %w", err)
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to open rabbitmq channel: %w", err)
+	}
+
+	if err := ch.Confirm(false); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to enable rabbitmq confirms: %w", err)
+	}
+
+	if err := ch.ExchangeDeclare(p.exchange, "topic", true, false, false, false, nil); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to declare rabbitmq exchange: %w", err)
+	}
+
+	p.conn = conn
+	p.ch = ch
+	return nil
+}
@@ -0,0 +1,111 @@
+// Package features provides feature flags loaded from env, file or Redis
+// so new behaviors can be toggled per environment without code changes
+package features
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Flag is the name of a feature flag
+type Flag string
+
+// Known feature flags
+const (
+	Events  Flag = "events"
+	Caching Flag = "caching"
+)
+
+// Source loads flag values from a backing store
+type Source interface {
+	Load(ctx context.Context) (map[Flag]bool, error)
+}
+
+// Flags holds the current flag values merged from every source
+// Later sources override earlier ones
+type Flags struct {
+	mu      sync.RWMutex
+	values  map[Flag]bool
+	sources []Source
+}
+
+// New creates a Flags instance reading from the given sources in order
+func New(sources ...Source) *Flags {
+	return &Flags{values: map[Flag]bool{}, sources: sources}
+}
+
+// Enabled reports whether a flag is on. Unknown flags are off
+func (f *Flags) Enabled(name Flag) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.values[name]
+}
+
+// All returns a copy of every flag value
+func (f *Flags) All() map[Flag]bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	values := make(map[Flag]bool, len(f.values))
+	for k, v := range f.values {
+		values[k] = v
+	}
+	return values
+}
+
+// Active returns the names of the enabled flags sorted alphabetically
+func (f *Flags) Active() []Flag {
+	active := []Flag{}
+	for name, on := range f.All() {
+		if on {
+			active = append(active, name)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i] < active[j] })
+	return active
+}
+
+// Refresh reloads every source. Values are only replaced if all sources
+// load successfully, so a failing source keeps the last known state
+func (f *Flags) Refresh(ctx context.Context) error {
+	values := map[Flag]bool{}
+	for _, s := range f.sources {
+		loaded, err := s.Load(ctx)
+		if err != nil {
+			return err
+		}
+		for k, v := range loaded {
+			values[k] = v
+		}
+	}
+
+	f.mu.Lock()
+	f.values = values
+	f.mu.Unlock()
+
+	return nil
+}
+
+// Watch refreshes the flags every interval until ctx is done
+func (f *Flags) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.Refresh(ctx); err != nil {
+				log.Printf("failed to refresh feature flags: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.yaml.in/yaml/v3"
+)
+
+// envPrefix is prepended to the upper-cased flag name, FEATURE_EVENTS
+const envPrefix = "FEATURE_"
+
+// EnvSource reads flags from FEATURE_<NAME> env variables
+type EnvSource struct{}
+
+// Load parses every FEATURE_* env variable as a boolean
+func (EnvSource) Load(_ context.Context) (map[Flag]bool, error) {
+	values := map[Flag]bool{}
+	for _, kv := range os.Environ() {
+		key, val, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(key, envPrefix) {
+			continue
+		}
+
+		on, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("env %s: %w", key, err)
+		}
+		values[Flag(strings.ToLower(strings.TrimPrefix(key, envPrefix)))] = on
+	}
+	return values, nil
+}
+
+// FileSource reads flags from a YAML file mapping flag name to bool
+type FileSource struct {
+	Path string
+}
+
+// Load reads the YAML file on every call so edits are picked up on refresh
+func (s FileSource) Load(_ context.Context) (map[Flag]bool, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feature file: %w", err)
+	}
+
+	values := map[Flag]bool{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse feature file %s: %w", s.Path, err)
+	}
+	return values, nil
+}
+
+// RedisSource reads flags from a Redis hash of flag name to bool
+type RedisSource struct {
+	Client *redis.Client
+	Key    string
+}
+
+// Load fetches the whole hash with HGETALL
+func (s RedisSource) Load(ctx context.Context) (map[Flag]bool, error) {
+	raw, err := s.Client.HGetAll(ctx, s.Key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feature flags from redis: %w", err)
+	}
+
+	values := make(map[Flag]bool, len(raw))
+	for k, v := range raw {
+		on, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("redis flag %s: %w", k, err)
+		}
+		values[Flag(k)] = on
+	}
+	return values, nil
+}
@@ -0,0 +1,471 @@
+// Package gql exposes the employee service as a GraphQL schema so clients
+// can fetch exactly the fields they need in one request
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"employee-management/internal/api"
+	"employee-management/internal/breaker"
+	"employee-management/internal/models"
+	"employee-management/internal/repository"
+	"employee-management/internal/search"
+	"employee-management/internal/service"
+	"employee-management/internal/validator"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Error is a resolver error carrying a machine readable code, rendered in
+// the extensions of the GraphQL error
+type Error struct {
+	Code    string
+	Message string
+	Details []api.ErrorDetail
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Extensions implements gqlerrors.ExtendedError
+func (e *Error) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"code": e.Code}
+	if len(e.Details) > 0 {
+		ext["details"] = e.Details
+	}
+	return ext
+}
+
+// resolveError maps service errors to coded GraphQL errors, the same way
+// the REST handlers map them to status codes
+func resolveError(err error, fallback string) error {
+	switch {
+	case errors.Is(err, repository.ErrEmployeeNotFound):
+		return &Error{Code: "NOT_FOUND", Message: "Employee not found"}
+	case errors.Is(err, repository.ErrEmailAlreadyExists):
+		return &Error{Code: "CONFLICT", Message: "Email already exists"}
+	case errors.Is(err, repository.ErrEmployeeNumberAlreadyExists):
+		return &Error{Code: "CONFLICT", Message: "Employee number already exists"}
+	case errors.Is(err, service.ErrRehireRequired):
+		return &Error{Code: "CONFLICT", Message: "Retired employees return to active through a rehire"}
+	case errors.Is(err, service.ErrEmployeeRetired):
+		return &Error{Code: "CONFLICT", Message: "Retired employees cannot be edited"}
+	case errors.Is(err, service.ErrProbationStatus):
+		return &Error{Code: "CONFLICT", Message: "Employees are only on probation from their hire"}
+	case errors.Is(err, repository.ErrSkillFilterUnsupported):
+		return &Error{Code: "BAD_USER_INPUT", Message: "Filtering by skill requires the postgres storage backend"}
+	case errors.Is(err, repository.ErrArchiveUnsupported):
+		return &Error{Code: "BAD_USER_INPUT", Message: "Archived employees require the postgres storage backend"}
+	case errors.Is(err, service.ErrSearchDisabled):
+		return &Error{Code: "BAD_USER_INPUT", Message: "Search is not enabled"}
+	case errors.Is(err, search.ErrFilterUnsupported):
+		return &Error{Code: "BAD_USER_INPUT", Message: "Search cannot be combined with the skill or archived filters"}
+	case errors.Is(err, breaker.ErrOpen):
+		return &Error{Code: "UNAVAILABLE", Message: "Database temporarily unavailable"}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &Error{Code: "TIMEOUT", Message: "Request timed out"}
+	default:
+		return &Error{Code: "INTERNAL", Message: fallback}
+	}
+}
+
+var statusEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "EmployeeStatus",
+	Values: graphql.EnumValueConfigMap{
+		"ACTIVE":      &graphql.EnumValueConfig{Value: models.StatusActive},
+		"ON_VACATION": &graphql.EnumValueConfig{Value: models.StatusOnVacation},
+		"RETIRED":     &graphql.EnumValueConfig{Value: models.StatusRetired},
+		"PROBATION":   &graphql.EnumValueConfig{Value: models.StatusProbation},
+	},
+})
+
+var genderEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "Gender",
+	Values: graphql.EnumValueConfigMap{
+		"FEMALE":      &graphql.EnumValueConfig{Value: models.GenderFemale},
+		"MALE":        &graphql.EnumValueConfig{Value: models.GenderMale},
+		"NON_BINARY":  &graphql.EnumValueConfig{Value: models.GenderNonBinary},
+		"UNDISCLOSED": &graphql.EnumValueConfig{Value: models.GenderUndisclosed},
+	},
+})
+
+// dateScalar is a calendar date, YYYY-MM-DD. Input also accepts RFC3339
+// timestamps, converted to the date in the organization's time zone
+var dateScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Date",
+	Description: "A calendar date as YYYY-MM-DD",
+	Serialize: func(value interface{}) interface{} {
+		switch d := value.(type) {
+		case models.Date:
+			return d.String()
+		case *models.Date:
+			if d == nil {
+				return nil
+			}
+			return d.String()
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		s, ok := value.(string)
+		if !ok {
+			return nil
+		}
+		d, err := models.ParseDate(s)
+		if err != nil {
+			return nil
+		}
+		return d
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		s, ok := valueAST.(*ast.StringValue)
+		if !ok {
+			return nil
+		}
+		d, err := models.ParseDate(s.Value)
+		if err != nil {
+			return nil
+		}
+		return d
+	},
+})
+
+var addressType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Address",
+	Fields: graphql.Fields{
+		"street":     &graphql.Field{Type: graphql.String},
+		"city":       &graphql.Field{Type: graphql.String},
+		"state":      &graphql.Field{Type: graphql.String},
+		"postalCode": &graphql.Field{Type: graphql.String},
+		"country":    &graphql.Field{Type: graphql.String},
+	},
+})
+
+var addressInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "AddressInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"street":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"city":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"state":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"postalCode": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"country":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+	},
+})
+
+var employeeType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Employee",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return strconv.FormatInt(p.Source.(*models.Employee).ID, 10), nil
+			},
+		},
+		"uuid":             &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"firstName":        &graphql.Field{Type: graphql.String},
+		"lastName":         &graphql.Field{Type: graphql.String},
+		"email":            &graphql.Field{Type: graphql.String},
+		"employeeNumber":   &graphql.Field{Type: graphql.String},
+		"position":         &graphql.Field{Type: graphql.String},
+		"department":       &graphql.Field{Type: graphql.String},
+		"status":           &graphql.Field{Type: statusEnum},
+		"hireDate":         &graphql.Field{Type: dateScalar},
+		"probationEndDate": &graphql.Field{Type: dateScalar},
+		"phone":            &graphql.Field{Type: graphql.String},
+		"dateOfBirth":      &graphql.Field{Type: dateScalar},
+		"nationalId":       &graphql.Field{Type: graphql.String},
+		"gender": &graphql.Field{
+			Type: genderEnum,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if g := p.Source.(*models.Employee).Gender; g != nil {
+					return *g, nil
+				}
+				return nil, nil
+			},
+		},
+		"personalEmail":   &graphql.Field{Type: graphql.String},
+		"address":         &graphql.Field{Type: addressType},
+		"emailVerifiedAt": &graphql.Field{Type: graphql.DateTime},
+		"createdAt":       &graphql.Field{Type: graphql.DateTime},
+		"updatedAt":       &graphql.Field{Type: graphql.DateTime},
+	},
+})
+
+var employeePageType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "EmployeePage",
+	Fields: graphql.Fields{
+		"items":        &graphql.Field{Type: graphql.NewList(employeeType)},
+		"page":         &graphql.Field{Type: graphql.Int},
+		"pageSize":     &graphql.Field{Type: graphql.Int},
+		"totalPages":   &graphql.Field{Type: graphql.Int},
+		"totalRecords": &graphql.Field{Type: graphql.Int},
+	},
+})
+
+var employeeInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "EmployeeInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"firstName":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"lastName":         &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"email":            &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"employeeNumber":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"position":         &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"department":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"status":           &graphql.InputObjectFieldConfig{Type: statusEnum},
+		"hireDate":         &graphql.InputObjectFieldConfig{Type: dateScalar},
+		"probationEndDate": &graphql.InputObjectFieldConfig{Type: dateScalar},
+		"phone":            &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"dateOfBirth":      &graphql.InputObjectFieldConfig{Type: dateScalar},
+		"nationalId":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"gender":           &graphql.InputObjectFieldConfig{Type: genderEnum},
+		"personalEmail":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"address":          &graphql.InputObjectFieldConfig{Type: addressInput},
+	},
+})
+
+// employeePage is the result of the employees query
+type employeePage struct {
+	Items        []*models.Employee `json:"items"`
+	Page         int                `json:"page"`
+	PageSize     int                `json:"pageSize"`
+	TotalPages   int                `json:"totalPages"`
+	TotalRecords int                `json:"totalRecords"`
+}
+
+// NewSchema builds the schema with resolvers backed by s
+func NewSchema(s *service.EmployeeService) (graphql.Schema, error) {
+	r := &resolver{service: s}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"employee": &graphql.Field{
+				Type: employeeType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.employee,
+			},
+			"employees": &graphql.Field{
+				Type: graphql.NewNonNull(employeePageType),
+				Args: graphql.FieldConfigArgument{
+					"page":       &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+					"pageSize":   &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+					"department": &graphql.ArgumentConfig{Type: graphql.String},
+					"status":     &graphql.ArgumentConfig{Type: statusEnum},
+					"position":   &graphql.ArgumentConfig{Type: graphql.String},
+					"country":    &graphql.ArgumentConfig{Type: graphql.String},
+					"city":       &graphql.ArgumentConfig{Type: graphql.String},
+					"skill":      &graphql.ArgumentConfig{Type: graphql.String},
+					"archived":   &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"q":          &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.employees,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createEmployee": &graphql.Field{
+				Type: employeeType,
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(employeeInput)},
+				},
+				Resolve: r.createEmployee,
+			},
+			"updateEmployee": &graphql.Field{
+				Type: employeeType,
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(employeeInput)},
+				},
+				Resolve: r.updateEmployee,
+			},
+			"deleteEmployee": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.deleteEmployee,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// resolver resolves the root fields against the service layer
+type resolver struct {
+	service *service.EmployeeService
+}
+
+func (r *resolver) employee(p graphql.ResolveParams) (interface{}, error) {
+	id, err := r.idArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	emp, err := r.service.FindByID(p.Context, id)
+	if err != nil {
+		return nil, resolveError(err, "Failed to retrieve employee")
+	}
+
+	return emp, nil
+}
+
+func (r *resolver) employees(p graphql.ResolveParams) (interface{}, error) {
+	page, _ := p.Args["page"].(int)
+	pageSize, _ := p.Args["pageSize"].(int)
+
+	// Same bounds as the REST list
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	} else if pageSize > 100 {
+		pageSize = 100
+	}
+
+	filters := make(map[string]interface{})
+	for _, key := range []string{"department", "position", "city", "skill"} {
+		if v, ok := p.Args[key].(string); ok && v != "" {
+			filters[key] = v
+		}
+	}
+	if v, ok := p.Args["country"].(string); ok && v != "" {
+		filters["country"] = strings.ToUpper(v)
+	}
+	if v, ok := p.Args["status"].(models.EmployeeStatus); ok {
+		filters["status"] = string(v)
+	}
+	if v, ok := p.Args["archived"].(bool); ok && v {
+		filters["archived"] = true
+	}
+
+	var list []models.Employee
+	var total int
+	var err error
+	if q, _ := p.Args["q"].(string); strings.TrimSpace(q) != "" {
+		list, total, err = r.service.Search(p.Context, strings.TrimSpace(q), page, pageSize, filters)
+	} else {
+		list, total, err = r.service.FindAll(p.Context, page, pageSize, filters)
+	}
+	if err != nil {
+		return nil, resolveError(err, "Failed to retrieve employees")
+	}
+
+	items := make([]*models.Employee, len(list))
+	for i := range list {
+		items[i] = &list[i]
+	}
+
+	return &employeePage{
+		Items:        items,
+		Page:         page,
+		PageSize:     pageSize,
+		TotalPages:   (total + pageSize - 1) / pageSize,
+		TotalRecords: total,
+	}, nil
+}
+
+func (r *resolver) createEmployee(p graphql.ResolveParams) (interface{}, error) {
+	var req models.CreateEmployeeRequest
+	if err := decodeInput(p.Args["input"], &req); err != nil {
+		return nil, err
+	}
+
+	emp := req.Employee()
+	if err := r.service.Create(p.Context, emp); err != nil {
+		return nil, resolveError(err, "Failed to create employee")
+	}
+
+	return emp, nil
+}
+
+func (r *resolver) updateEmployee(p graphql.ResolveParams) (interface{}, error) {
+	id, err := r.idArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var req models.UpdateEmployeeRequest
+	if err := decodeInput(p.Args["input"], &req); err != nil {
+		return nil, err
+	}
+
+	if err := r.service.Update(p.Context, req.Employee(id)); err != nil {
+		return nil, resolveError(err, "Failed to update employee")
+	}
+
+	// The stored employee, with the hire date and creation time the input
+	// does not carry
+	emp, err := r.service.FindByID(p.Context, id)
+	if err != nil {
+		return nil, resolveError(err, "Failed to retrieve employee")
+	}
+
+	return emp, nil
+}
+
+func (r *resolver) deleteEmployee(p graphql.ResolveParams) (interface{}, error) {
+	id, err := r.idArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.service.Delete(p.Context, id); err != nil {
+		return nil, resolveError(err, "Failed to delete employee")
+	}
+
+	return true, nil
+}
+
+// idArg validates the id argument like the REST path parameter, resolving
+// it by uuid with ID_FORMAT=uuid
+func (r *resolver) idArg(p graphql.ResolveParams) (int64, error) {
+	raw, _ := p.Args["id"].(string)
+
+	if r.service.IDFormat() == models.IDFormatUUID {
+		uuid, errs := validator.ValidateUUID(raw)
+		if errs != nil {
+			return 0, &Error{Code: "BAD_USER_INPUT", Message: "Invalid ID", Details: errs}
+		}
+		emp, err := r.service.FindByUUID(p.Context, uuid)
+		if err != nil {
+			return 0, resolveError(err, "Failed to retrieve employee")
+		}
+		return emp.ID, nil
+	}
+
+	id, errs := validator.ValidateID(raw)
+	if errs != nil {
+		return 0, &Error{Code: "BAD_USER_INPUT", Message: "Invalid ID", Details: errs}
+	}
+
+	return id, nil
+}
+
+// decodeInput converts an EmployeeInput into req, a request payload of
+// the REST API, and validates it against the same binding rules
+func decodeInput(raw interface{}, req interface{}) error {
+	data, err := json.Marshal(raw)
+	if err == nil {
+		err = json.Unmarshal(data, req)
+	}
+	if err != nil {
+		return &Error{Code: "BAD_USER_INPUT", Message: "Invalid input"}
+	}
+
+	if validation := validator.Struct(req); !validation.IsValid {
+		return &Error{Code: "BAD_USER_INPUT", Message: "Validation failed", Details: validation.Errors}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"employee-management/internal/api"
+	"employee-management/internal/badge"
+	"employee-management/internal/breaker"
+	"employee-management/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BadgeHandler renders the codes printed on employee badges
+type BadgeHandler struct {
+	service EmployeeService
+	signer  *badge.Signer
+}
+
+// NewBadgeHandler creates a new BadgeHandler instance, signer is nil when
+// no badge signing key is configured
+func NewBadgeHandler(s EmployeeService, signer *badge.Signer) *BadgeHandler {
+	return &BadgeHandler{service: s, signer: signer}
+}
+
+// GetBadge godoc
+//
+//	@Summary		Get an employee badge code
+//	@Description	Renders a PNG QR code, or Code 128 barcode, of the employee number for badge printing and check-in kiosks. With signed=true it encodes a token "<employee number>.<expiry unix>.<signature>" instead, the signature the unpadded base64url HMAC-SHA256 of the rest with BADGE_SIGNING_KEY, which kiosks verify offline
+//	@Tags			Employees
+//	@Produce		png
+//	@Param			id		path		string				true	"Employee ID, or uuid with ID_FORMAT=uuid"
+//	@Param			code	query		string				false	"Kind of code"	Enums(qr, code128)	default(qr)
+//	@Param			size	query		int					false	"Width in pixels, more when the content needs it"	minimum(64)	maximum(1024)	default(256)
+//	@Param			signed	query		bool				false	"Encode a signed token (requires BADGE_SIGNING_KEY)"
+//	@Success		200		{file}		file				"Badge code"
+//	@Failure		400		{object}	api.ErrorResponse	"Invalid ID or query parameters"
+//	@Failure		404		{object}	api.ErrorResponse	"Employee not found"
+//	@Failure		500		{object}	api.ErrorResponse	"Internal server error"
+//	@Failure		503		{object}	api.ErrorResponse	"Database temporarily unavailable"
+//	@Failure		504		{object}	api.ErrorResponse	"Request timed out"
+//	@Router			/employees/{id}/badge.png [get]
+func (h *BadgeHandler) GetBadge(c *gin.Context) {
+	kind := c.DefaultQuery("code", badge.KindQR)
+	if kind != badge.KindQR && kind != badge.KindCode128 {
+		api.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	size, err := strconv.Atoi(c.DefaultQuery("size", "256"))
+	if err != nil || size < 64 || size > 1024 {
+		api.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	signed, err := strconv.ParseBool(c.DefaultQuery("signed", "false"))
+	if err != nil {
+		api.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	if signed && h.signer == nil {
+		api.BadRequest(c, "Signed badges are not configured")
+		return
+	}
+
+	id, ok := employeeIDParam(c, h.service)
+	if !ok {
+		return
+	}
+
+	emp, err := h.service.FindByID(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEmployeeNotFound):
+			api.NotFound(c, "Employee not found")
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to retrieve employee")
+		}
+		return
+	}
+
+	content := emp.EmployeeNumber
+	if signed {
+		content = h.signer.Token(emp.EmployeeNumber)
+	}
+
+	var buf bytes.Buffer
+	if err := badge.WritePNG(&buf, kind, content, size); err != nil {
+		api.InternalServerError(c, err, "Failed to render badge")
+		return
+	}
+
+	// Tokens carry their expiry, so signed badges are never reused
+	if signed {
+		c.Header("Cache-Control", "no-store")
+	}
+	c.Data(http.StatusOK, "image/png", buf.Bytes())
+}
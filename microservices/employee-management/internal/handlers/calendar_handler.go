@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"employee-management/internal/api"
+	"employee-management/internal/breaker"
+	"employee-management/internal/calendar"
+	"employee-management/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Days of the calendar feed around today. Past events stay a while so a
+// calendar refreshed late still shows them
+const (
+	calendarDaysBack  = 30
+	calendarDaysAhead = 365
+)
+
+// CalendarHandler serves the iCalendar feed of anniversaries and birthdays
+type CalendarHandler struct {
+	source calendar.Source
+}
+
+// NewCalendarHandler creates a new CalendarHandler instance
+func NewCalendarHandler(source calendar.Source) *CalendarHandler {
+	return &CalendarHandler{source: source}
+}
+
+// GetCalendar godoc
+//
+//	@Summary		Get the anniversaries and birthdays calendar
+//	@Description	Returns an iCalendar feed of the work anniversaries and birthdays of the employees who are not retired, from 30 days ago to a year ahead, for calendar applications to subscribe to. Birthdays do not tell the age
+//	@Tags			Employees
+//	@Produce		text/calendar
+//	@Param			department	query		[]string			false	"Only employees of these departments, given repeated or comma separated"	collectionFormat(multi)
+//	@Success		200			{string}	string				"iCalendar feed"
+//	@Failure		500			{object}	api.ErrorResponse	"Internal server error"
+//	@Failure		503			{object}	api.ErrorResponse	"Database temporarily unavailable"
+//	@Failure		504			{object}	api.ErrorResponse	"Request timed out"
+//	@Router			/employees/calendar.ics [get]
+func (h *CalendarHandler) GetCalendar(c *gin.Context) {
+	filters := map[string]interface{}{}
+	departments := api.Values(c.QueryArray("department"))
+	if v := filterValue(departments); v != nil {
+		filters["department"] = v
+	}
+
+	today := models.Today()
+	events, err := calendar.Events(c.Request.Context(), h.source, filters, today.AddDays(-calendarDaysBack), today.AddDays(calendarDaysAhead))
+	if err != nil {
+		switch {
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to build calendar")
+		}
+		return
+	}
+
+	name := "Employee anniversaries and birthdays"
+	if len(departments) > 0 {
+		name += " (" + strings.Join(departments, ", ") + ")"
+	}
+
+	var buf bytes.Buffer
+	if err := calendar.Write(&buf, name, events, time.Now()); err != nil {
+		api.InternalServerError(c, err, "Failed to build calendar")
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="calendar.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
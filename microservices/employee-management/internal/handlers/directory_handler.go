@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"employee-management/internal/api"
+	"employee-management/internal/breaker"
+	"employee-management/internal/directory"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DirectoryHandler handles HTTP requests for the LDAP directory sync
+type DirectoryHandler struct {
+	syncer *directory.Syncer
+}
+
+// NewDirectoryHandler creates a new DirectoryHandler instance
+func NewDirectoryHandler(s *directory.Syncer) *DirectoryHandler {
+	return &DirectoryHandler{syncer: s}
+}
+
+// GetDirectorySync godoc
+//
+//	@Summary		Last directory sync
+//	@Description	Reports the last directory sync that was not a dry run, scheduled or requested: one result per directory entry with the fields taken from the directory and the conflicts left for HR to resolve, and the employees missing from the directory
+//	@Tags			Directory
+//	@Produce		json
+//	@Success		200	{object}	models.DirectorySyncReport	"Last sync"
+//	@Failure		404	{object}	api.ErrorResponse			"No sync ran yet"
+//	@Router			/directory/sync [get]
+func (h *DirectoryHandler) GetDirectorySync(c *gin.Context) {
+	report := h.syncer.Last()
+	if report == nil {
+		api.NotFound(c, "No directory sync ran yet")
+		return
+	}
+
+	api.Success(c, http.StatusOK, report)
+}
+
+// SyncDirectory godoc
+//
+//	@Summary		Sync the directory
+//	@Description	Syncs the employees with the LDAP directory now: entries without an employee are created and the fields the directory changed since the last sync are updated. Fields edited in the service since the last sync that the directory disagrees with are reported as conflicts and left as they are. With dry_run=true the outcomes are reported without changing anything
+//	@Tags			Directory
+//	@Produce		json
+//	@Param			dry_run	query		bool						false	"Report what would change without changing it"
+//	@Success		200		{object}	models.DirectorySyncReport	"Sync report"
+//	@Failure		400		{object}	api.ErrorResponse			"Invalid query parameters"
+//	@Failure		409		{object}	api.ErrorResponse			"A sync is already running"
+//	@Failure		500		{object}	api.ErrorResponse			"Internal server error"
+//	@Failure		502		{object}	api.ErrorResponse			"Directory unavailable"
+//	@Failure		503		{object}	api.ErrorResponse			"Database temporarily unavailable"
+//	@Failure		504		{object}	api.ErrorResponse			"Request timed out"
+//	@Router			/directory/sync [post]
+func (h *DirectoryHandler) SyncDirectory(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		api.BadRequest(c, "Invalid query parameters")
+		return
+	}
+
+	report, err := h.syncer.Sync(c.Request.Context(), dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, directory.ErrSyncRunning):
+			api.Conflict(c, "A directory sync is already running")
+		case errors.Is(err, directory.ErrDirectory):
+			api.Error(c, http.StatusBadGateway, "Directory unavailable")
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to sync directory")
+		}
+		return
+	}
+
+	api.Success(c, http.StatusOK, report)
+}
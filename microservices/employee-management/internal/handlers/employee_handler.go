@@ -2,27 +2,66 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
-	"time"
+	"strconv"
+	"strings"
 
 	"employee-management/internal/api"
+	"employee-management/internal/breaker"
+	"employee-management/internal/i18n"
 	"employee-management/internal/models"
+	"employee-management/internal/patch"
 	"employee-management/internal/repository"
+	"employee-management/internal/search"
 	"employee-management/internal/service"
 	"employee-management/internal/validator"
+	"employee-management/internal/verification"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
+//go:generate go run go.uber.org/mock/mockgen -source=employee_handler.go -destination=mocks/employee_service.go -package=mocks
+
+// EmployeeService is the employee business logic the handlers use,
+// implemented by *service.EmployeeService. Handlers depend on it so they
+// can be exercised against a fake without a database
+type EmployeeService interface {
+	IDFormat() models.IDFormat
+	Create(ctx context.Context, e *models.Employee) error
+	FindByID(ctx context.Context, id int64) (*models.Employee, error)
+	FindByUUID(ctx context.Context, uuid string) (*models.Employee, error)
+	FindAll(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]models.Employee, int, error)
+	FindAllWithoutTotal(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]models.Employee, bool, error)
+	Search(ctx context.Context, q string, page, pageSize int, filters map[string]interface{}) ([]models.Employee, int, error)
+	Snapshot(ctx context.Context, afterID int64, limit int) ([]models.VersionedEmployee, error)
+	Update(ctx context.Context, e *models.Employee) error
+	Rehire(ctx context.Context, id int64) (*models.Employee, error)
+	VerifyEmail(ctx context.Context, token string) (*models.Employee, error)
+	RequestEmailVerification(ctx context.Context, id int64) error
+	BulkUpdate(ctx context.Context, filters map[string]interface{}, changes models.BulkChanges, dryRun bool) ([]models.BulkResult, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// SkillLoader loads the skills of many employees at once, implemented by
+// *service.SkillService
+type SkillLoader interface {
+	SkillsByEmployee(ctx context.Context, employeeIDs []int64) (map[int64][]models.EmployeeSkill, error)
+}
+
 // EmployeeHandler handles HTTP requests for employee operations
 type EmployeeHandler struct {
-	service *service.EmployeeService // Bussiness logic dependency
+	service EmployeeService // Bussiness logic dependency
+	skills  SkillLoader     // nil when skills are disabled
 }
 
-// NewEmployeeHandler creates a new EmployeeHandler instance
-func NewEmployeeHandler(s *service.EmployeeService) *EmployeeHandler {
-	return &EmployeeHandler{service: s}
+// NewEmployeeHandler creates a new EmployeeHandler instance, skills is
+// nil when the storage backend has no skills
+func NewEmployeeHandler(s EmployeeService, skills SkillLoader) *EmployeeHandler {
+	return &EmployeeHandler{service: s, skills: skills}
 }
 
 // CreateEmployee godoc
@@ -32,43 +71,41 @@ func NewEmployeeHandler(s *service.EmployeeService) *EmployeeHandler {
 //	@Tags			Employees
 //	@Accept			json
 //	@Produce		json
-//	@Param			employee	body		models.Employee		true	"Employee data"
-//	@Success		201			{object}	models.Employee		"Employee created successfully"
+//	@Param			employee	body		models.CreateEmployeeRequest	true	"Employee data"
+//	@Success		201			{object}	models.EmployeeResponse			"Employee created successfully"
 //	@Failure		400			{object}	api.ErrorResponse	"Invalid JSON format or validation failed"
 //	@Failure		409			{object}	api.ErrorResponse	"Email or employee number already exists"
 //	@Failure		500			{object}	api.ErrorResponse	"Internal server error"
+//	@Failure		503			{object}	api.ErrorResponse	"Database temporarily unavailable"
+//	@Failure		504			{object}	api.ErrorResponse	"Request timed out"
 //	@Router			/employees [post]
 func (h *EmployeeHandler) CreateEmployee(c *gin.Context) {
-	var req models.Employee
-
-	// Check JSON shape / types
-	if err := c.ShouldBindJSON(&req); err != nil {
-		api.BadRequest(c, "Invalid JSON format")
-		return
-	}
+	var req models.CreateEmployeeRequest
 
-	// Input validation
-	validation := validator.ValidateEmployee(req.Email, req.EmployeeNumber, req.FirstName, req.LastName)
-
-	if !validation.IsValid {
-		api.ValidationError(c, http.StatusBadRequest, "Validation failed", validation.Errors)
+	// Check JSON shape / types and the binding rules
+	if !bindJSON(c, &req) {
 		return
 	}
+	emp := req.Employee()
 
 	// Business logic
-	if err := h.service.Create(c.Request.Context(), &req); err != nil {
+	if err := h.service.Create(c.Request.Context(), emp); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrEmailAlreadyExists):
 			api.Conflict(c, "Email already exist")
 		case errors.Is(err, repository.ErrEmployeeNumberAlreadyExists):
 			api.Conflict(c, "Employee number already exists")
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
 		default:
-			api.InternalServerError(c, "Failed to create employee")
+			api.InternalServerError(c, err, "Failed to create employee")
 		}
 		return
 	}
 
-	c.JSON(http.StatusCreated, req)
+	api.Success(c, http.StatusCreated, models.NewEmployeeResponse(emp))
 }
 
 // GetEmployeeByID godoc
@@ -76,19 +113,26 @@ func (h *EmployeeHandler) CreateEmployee(c *gin.Context) {
 //	@Summary		Get employee by ID
 //	@Description	Retrieves an employee by its ID
 //	@Tags			Employees
-//	@Produce		json
-//	@Param			id	path		int					true	"Employee ID"
-//	@Success		200	{object}	models.Employee		"Employee found"
-//	@Failure		400	{object}	api.ErrorResponse	"Invalid ID format"
-//	@Failure		404	{object}	api.ErrorResponse	"Employee not found"
-//	@Failure		500	{object}	api.ErrorResponse	"Internal server error"
+//	@Produce		json,application/xml,text/csv
+//	@Param			id		path		string				true	"Employee ID, or uuid with ID_FORMAT=uuid"
+//	@Param			include	query		string				false	"Related resources to embed, comma separated: skills (postgres storage only)"
+//	@Success		200		{object}	models.EmployeeResponse	"Employee found"
+//	@Success		304		"Not modified since If-Modified-Since, or still the If-None-Match ETag (no content)"
+//	@Failure		400		{object}	api.ErrorResponse	"Invalid ID format or include"
+//	@Failure		404		{object}	api.ErrorResponse	"Employee not found"
+//	@Failure		406		{object}	api.ErrorResponse	"No acceptable representation"
+//	@Failure		500		{object}	api.ErrorResponse	"Internal server error"
+//	@Failure		503		{object}	api.ErrorResponse	"Database temporarily unavailable"
+//	@Failure		504		{object}	api.ErrorResponse	"Request timed out"
 //	@Router			/employees/{id} [get]
 func (h *EmployeeHandler) GetEmployeeByID(c *gin.Context) {
-	idParam := c.Param("id")
+	include, ok := h.includeParam(c)
+	if !ok {
+		return
+	}
 
-	id, errs := validator.ValidateID(idParam)
-	if errs != nil {
-		api.ValidationError(c, http.StatusBadRequest, "Invalid ID", errs)
+	id, ok := employeeIDParam(c, h.service)
+	if !ok {
 		return
 	}
 
@@ -97,28 +141,52 @@ func (h *EmployeeHandler) GetEmployeeByID(c *gin.Context) {
 		switch {
 		case errors.Is(err, repository.ErrEmployeeNotFound):
 			api.NotFound(c, "Employee not found")
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
 		default:
-			api.InternalServerError(c, "Failed to retrieve employee")
+			api.InternalServerError(c, err, "Failed to retrieve employee")
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, emp)
+	responses := []models.EmployeeResponse{models.NewEmployeeResponse(emp)}
+	if !h.embed(c, include, responses) {
+		return
+	}
+	// Embedded resources change without touching the employee
+	if include == (includes{}) {
+		c.Header("Last-Modified", emp.UpdatedAt.UTC().Format(http.TimeFormat))
+	}
+
+	api.Respond(c, http.StatusOK, responses[0])
 }
 
 // GetAllEmployees godoc
 // @Summary Get all employees with pagination and filtering
-// @Description Retrieves employees with pagination support. Can filter by department, status, position.
+// @Description Retrieves employees with pagination support. Can filter by department, status, position, address country and city, and skill. With archived=true lists the archived employees instead. With q the employees are searched in the search index, fuzzily across names, email, employee number, position, department and city, best match first.
 // @Tags Employees
-// @Produce json
+// @Produce json,application/xml,text/csv
 // @Param page query int false "Page number (default: 1)"
 // @Param page_size query int false "Number of items per page (default: 10, max: 100)"
-// @Param department query string false "Filter by department"
-// @Param status query string false "Filter by status (ACTIVE, ON_VACATION, RETIRED)"
-// @Param position query string false "Filter by position"
-// @Success 200 {object} api.PaginatedResponse
+// @Param department query []string false "Filter by department, any of several values given repeated or comma separated" collectionFormat(multi)
+// @Param status query []string false "Filter by status (ACTIVE, ON_VACATION, RETIRED, PROBATION), any of several values given repeated or comma separated" collectionFormat(multi)
+// @Param position query []string false "Filter by position, any of several values given repeated or comma separated" collectionFormat(multi)
+// @Param country query string false "Filter by address country (ISO 3166-1 alpha-2, e.g. CO)" minlength(2) maxlength(2)
+// @Param city query string false "Filter by address city"
+// @Param skill query string false "Only employees holding this skill, by name regardless of case (postgres storage only)"
+// @Param archived query bool false "List the employees moved to the archive instead of the current ones (postgres storage only)"
+// @Param q query string false "Full-text search, ranked by relevance (requires SEARCH_URL, not combinable with skill or archived)" maxlength(200)
+// @Param include query string false "Related resources to embed in each employee, comma separated: skills (postgres storage only)"
+// @Param include_total query bool false "Count the matching employees (default: true), false skips the count and reports total_records and total_pages as -1"
+// @Success 200 {object} api.PaginatedResponse{data=[]models.EmployeeResponse}
+// @Success 304 "Page still the If-None-Match ETag (no content)"
 // @Failure 400 {object} map[string]string
+// @Failure 406 {object} api.ErrorResponse
 // @Failure 500 {object} map[string]string
+// @Failure 503 {object} api.ErrorResponse
+// @Failure 504 {object} api.ErrorResponse
 // @Router /employees [get]
 func (h *EmployeeHandler) GetAllEmployees(c *gin.Context) {
 	var query api.PaginationQuery
@@ -126,6 +194,17 @@ func (h *EmployeeHandler) GetAllEmployees(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
 		return
 	}
+	statuses := api.Values(query.Status)
+	for _, status := range statuses {
+		if !models.EmployeeStatus(status).Valid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+			return
+		}
+	}
+	include, ok := h.includeParam(c)
+	if !ok {
+		return
+	}
 
 	// Set defaults for pagination
 	if query.Page < 1 {
@@ -139,35 +218,152 @@ func (h *EmployeeHandler) GetAllEmployees(c *gin.Context) {
 
 	// Build filters map
 	filters := make(map[string]interface{})
-	if query.Department != "" {
-		filters["department"] = query.Department
+	if v := filterValue(api.Values(query.Department)); v != nil {
+		filters["department"] = v
 	}
-	if query.Status != "" {
-		filters["status"] = query.Status
+	if v := filterValue(statuses); v != nil {
+		filters["status"] = v
 	}
-	if query.Position != "" {
-		filters["position"] = query.Position
+	if v := filterValue(api.Values(query.Position)); v != nil {
+		filters["position"] = v
+	}
+	if query.Country != "" {
+		filters["country"] = strings.ToUpper(query.Country)
+	}
+	if query.City != "" {
+		filters["city"] = query.City
+	}
+	if query.Skill != "" {
+		filters["skill"] = query.Skill
+	}
+	if query.Archived {
+		filters["archived"] = true
 	}
 
-	employees, total, err := h.service.FindAll(c.Request.Context(), query.Page, query.PageSize, filters)
+	var employees []models.Employee
+	var total int
+	var hasNext bool
+	var err error
+	if q := strings.TrimSpace(query.Q); q != "" {
+		employees, total, err = h.service.Search(c.Request.Context(), q, query.Page, query.PageSize, filters)
+		hasNext = query.Page*query.PageSize < total
+	} else if query.WithTotal() {
+		employees, total, err = h.service.FindAll(c.Request.Context(), query.Page, query.PageSize, filters)
+		hasNext = query.Page*query.PageSize < total
+	} else {
+		employees, hasNext, err = h.service.FindAllWithoutTotal(c.Request.Context(), query.Page, query.PageSize, filters)
+	}
+	if errors.Is(err, service.ErrSearchDisabled) {
+		api.BadRequest(c, "Search is not enabled")
+		return
+	}
+	if errors.Is(err, search.ErrFilterUnsupported) {
+		api.BadRequest(c, "Search cannot be combined with the skill or archived filters")
+		return
+	}
+	if errors.Is(err, repository.ErrSkillFilterUnsupported) {
+		api.BadRequest(c, "Filtering by skill requires the postgres storage backend")
+		return
+	}
+	if errors.Is(err, repository.ErrArchiveUnsupported) {
+		api.BadRequest(c, "Archived employees require the postgres storage backend")
+		return
+	}
+	if errors.Is(err, breaker.ErrOpen) {
+		api.ServiceUnavailable(c, "Database temporarily unavailable")
+		return
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		api.GatewayTimeout(c, "Request timed out")
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
 	}
 
-	totalPages := (total + query.PageSize - 1) / query.PageSize
+	responses := models.NewEmployeeResponses(employees)
+	if !h.embed(c, include, responses) {
+		return
+	}
 
 	response := api.PaginatedResponse{
-		Data: employees,
+		Data: responses,
 		Pagination: api.PaginationMeta{
 			CurrentPage:  query.Page,
 			PageSize:     query.PageSize,
-			TotalPages:   totalPages,
-			TotalRecords: total,
+			TotalPages:   -1,
+			TotalRecords: -1,
+			HasNext:      hasNext,
 		},
 	}
 
-	c.JSON(http.StatusOK, response)
+	if query.WithTotal() {
+		response.Pagination.TotalPages = (total + query.PageSize - 1) / query.PageSize
+		response.Pagination.TotalRecords = total
+		c.Header("X-Total-Count", strconv.Itoa(total))
+	}
+	api.Respond(c, http.StatusOK, response)
+}
+
+// GetSnapshot godoc
+//
+//	@Summary		Employee snapshot
+//	@Description	Lists every employee by id with the version of its latest event, for services building their own copy of the employees from the events. Page through it with after set to the nextAfter of the previous page, then apply only events with a higher version than the employee's
+//	@Tags			Employees
+//	@Produce		json
+//	@Param			after	query		int					false	"Return employees with a greater id"	default(0)
+//	@Param			limit	query		int					false	"Page size (max 1000)"				default(500)
+//	@Success		200		{object}	api.SnapshotPage{data=[]models.VersionedEmployee}	"Snapshot page"
+//	@Failure		400		{object}	api.ErrorResponse	"Invalid query parameters"
+//	@Failure		500		{object}	api.ErrorResponse	"Internal server error"
+//	@Failure		503		{object}	api.ErrorResponse	"Database temporarily unavailable"
+//	@Failure		504		{object}	api.ErrorResponse	"Request timed out"
+//	@Router			/employees/snapshot [get]
+func (h *EmployeeHandler) GetSnapshot(c *gin.Context) {
+	var query api.SnapshotQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		api.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = 500
+	}
+
+	employees, err := h.service.Snapshot(c.Request.Context(), query.After, query.Limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to retrieve snapshot")
+		}
+		return
+	}
+
+	page := api.SnapshotPage{Data: employees}
+	if len(employees) == query.Limit {
+		page.NextAfter = employees[len(employees)-1].ID
+	}
+
+	api.Success(c, http.StatusOK, page)
+}
+
+// GetEmployeeNumberFormat godoc
+//
+//	@Summary		Employee number format
+//	@Description	Returns the regular expression employee numbers must match, set by the deployment with EMPLOYEE_NUMBER_PATTERN, so clients can check them before submitting
+//	@Tags			Employees
+//	@Produce		json
+//	@Success		200	{object}	models.EmployeeNumberFormat	"Employee number format"
+//	@Router			/employees/number-format [get]
+func (h *EmployeeHandler) GetEmployeeNumberFormat(c *gin.Context) {
+	api.Success(c, http.StatusOK, models.EmployeeNumberFormat{
+		Pattern:   validator.EmployeeNumberPattern(),
+		MaxLength: validator.MaxEmployeeNumberLength(),
+	})
 }
 
 // UpdateEmployee godoc
@@ -177,44 +373,118 @@ func (h *EmployeeHandler) GetAllEmployees(c *gin.Context) {
 //	@Tags			Employees
 //	@Accept			json
 //	@Produce		json
-//	@Param			id			path		int					true	"Employee ID"
-//	@Param			employee	body		models.Employee		true	"Updated employee data"
-//	@Success		200			{object}	models.Employee		"Employee updated successfully"
+//	@Param			id			path		string				true	"Employee ID, or uuid with ID_FORMAT=uuid"
+//	@Param			employee	body		models.UpdateEmployeeRequest	true	"Updated employee data"
+//	@Success		200			{object}	models.EmployeeResponse			"Employee updated successfully"
 //	@Failure		400			{object}	api.ErrorResponse	"Invalid JSON format or validation failed"
 //	@Failure		404			{object}	api.ErrorResponse	"Employee not found"
-//	@Failure		409			{object}	api.ErrorResponse	"Email or employee number already exists"
+//	@Failure		409			{object}	api.ErrorResponse	"Email or employee number already exists, the employee is retired or the status change is not allowed"
 //	@Failure		500			{object}	api.ErrorResponse	"Internal server error"
+//	@Failure		503			{object}	api.ErrorResponse	"Database temporarily unavailable"
+//	@Failure		504			{object}	api.ErrorResponse	"Request timed out"
 //	@Router			/employees/{id} [put]
 func (h *EmployeeHandler) UpdateEmployee(c *gin.Context) {
-	idParam := c.Param("id")
+	id, ok := employeeIDParam(c, h.service)
+	if !ok {
+		return
+	}
 
-	id, errs := validator.ValidateID(idParam)
-	if errs != nil {
-		api.ValidationError(c, http.StatusBadRequest, "Invalid ID", errs)
+	var req models.UpdateEmployeeRequest
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	var req models.Employee
-	if err := c.ShouldBindJSON(&req); err != nil {
-		api.BadRequest(c, "Invalid JSON format")
+	h.update(c, id, &req)
+}
+
+// PatchEmployee godoc
+//
+//	@Summary		Patch employee
+//	@Description	Applies a JSON Patch (RFC 6902) or JSON Merge Patch (RFC 7396) to the employee as PUT takes it, then validates and saves the result like PUT. Merge patches set members and remove optional ones with null; JSON Patch operations address them by pointer, e.g. {"op": "remove", "path": "/phone"}, all of them or none applied
+//	@Tags			Employees
+//	@Accept			application/json-patch+json,application/merge-patch+json
+//	@Produce		json
+//	@Param			id		path		string				true	"Employee ID, or uuid with ID_FORMAT=uuid"
+//	@Param			patch	body		interface{}			true	"JSON Patch operations (an array of patch.Operation) or a merge patch of models.UpdateEmployeeRequest"
+//	@Success		200		{object}	models.EmployeeResponse	"Employee updated successfully"
+//	@Failure		400		{object}	api.ErrorResponse	"Invalid patch or the patched employee failed validation"
+//	@Failure		404		{object}	api.ErrorResponse	"Employee not found"
+//	@Failure		409		{object}	api.ErrorResponse	"A test operation failed, or as for PUT"
+//	@Failure		415		{object}	api.ErrorResponse	"Unsupported patch media type"
+//	@Failure		422		{object}	api.ErrorResponse	"Patch does not apply to the employee"
+//	@Failure		500		{object}	api.ErrorResponse	"Internal server error"
+//	@Failure		503		{object}	api.ErrorResponse	"Database temporarily unavailable"
+//	@Failure		504		{object}	api.ErrorResponse	"Request timed out"
+//	@Router			/employees/{id} [patch]
+func (h *EmployeeHandler) PatchEmployee(c *gin.Context) {
+	contentType := c.ContentType()
+	if contentType != patch.JSONPatchType && contentType != patch.MergePatchType {
+		api.Error(c, http.StatusUnsupportedMediaType, "Content-Type must be application/json-patch+json or application/merge-patch+json")
+		return
+	}
+
+	id, ok := employeeIDParam(c, h.service)
+	if !ok {
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		api.BadRequest(c, "Invalid patch document")
 		return
 	}
 
-	req.ID = id
+	current, err := h.service.FindByID(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEmployeeNotFound):
+			api.NotFound(c, "Employee not found")
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to retrieve employee")
+		}
+		return
+	}
 
-	validation := validator.ValidateEmployee(
-		req.Email,
-		req.EmployeeNumber,
-		req.FirstName,
-		req.LastName,
-	)
+	doc, err := json.Marshal(models.NewUpdateEmployeeRequest(current))
+	if err != nil {
+		api.InternalServerError(c, err, "Failed to update employee")
+		return
+	}
+	if contentType == patch.JSONPatchType {
+		doc, err = patch.Apply(doc, body)
+	} else {
+		doc, err = patch.Merge(doc, body)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, patch.ErrTestFailed):
+			api.Conflict(c, "Patch test operation failed")
+		case errors.Is(err, patch.ErrUnprocessable):
+			api.Error(c, http.StatusUnprocessableEntity, "Patch does not apply to the employee")
+		default:
+			api.BadRequest(c, "Invalid patch document")
+		}
+		return
+	}
 
-	if !validation.IsValid {
-		api.ValidationError(c, http.StatusBadRequest, "Validation failed", validation.Errors)
+	var req models.UpdateEmployeeRequest
+	if err := binding.JSON.BindBody(doc, &req); err != nil {
+		bindError(c, err)
 		return
 	}
 
-	if err := h.service.Update(c.Request.Context(), &req); err != nil {
+	h.update(c, id, &req)
+}
+
+// update saves the employee id as req replaces it and answers with the
+// stored employee, writing the error response on failure
+func (h *EmployeeHandler) update(c *gin.Context, id int64, req *models.UpdateEmployeeRequest) {
+	emp := req.Employee(id)
+	if err := h.service.Update(c.Request.Context(), emp); err != nil {
 		switch {
 		case errors.Is(err, repository.ErrEmployeeNotFound):
 			api.NotFound(c, "Employee not found")
@@ -222,53 +492,417 @@ func (h *EmployeeHandler) UpdateEmployee(c *gin.Context) {
 			api.Conflict(c, "Email already exists")
 		case errors.Is(err, repository.ErrEmployeeNumberAlreadyExists):
 			api.Conflict(c, "Employee number already exists")
+		case errors.Is(err, service.ErrRehireRequired):
+			api.Conflict(c, "Retired employees return to active through a rehire")
+		case errors.Is(err, service.ErrEmployeeRetired):
+			api.Conflict(c, "Retired employees cannot be edited")
+		case errors.Is(err, service.ErrProbationStatus):
+			api.Conflict(c, "Employees are only on probation from their hire")
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
 		default:
-			api.InternalServerError(c, "Failed to update employee")
+			api.InternalServerError(c, err, "Failed to update employee")
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, req)
+	// Answer with the stored employee, the request carries neither its
+	// hire date nor its creation time
+	stored, err := h.service.FindByID(c.Request.Context(), id)
+	if err != nil {
+		api.InternalServerError(c, err, "Failed to retrieve employee")
+		return
+	}
+
+	api.Success(c, http.StatusOK, models.NewEmployeeResponse(stored))
+}
+
+// RehireEmployee godoc
+//
+//	@Summary		Rehire employee
+//	@Description	Moves a retired employee back to ACTIVE, the only way out of RETIRED
+//	@Tags			Employees
+//	@Produce		json
+//	@Param			id	path		string					true	"Employee ID, or uuid with ID_FORMAT=uuid"
+//	@Success		200	{object}	models.EmployeeResponse	"Employee rehired"
+//	@Failure		400	{object}	api.ErrorResponse		"Invalid ID format"
+//	@Failure		404	{object}	api.ErrorResponse		"Employee not found"
+//	@Failure		409	{object}	api.ErrorResponse		"Employee is not retired"
+//	@Failure		500	{object}	api.ErrorResponse		"Internal server error"
+//	@Failure		503	{object}	api.ErrorResponse		"Database temporarily unavailable"
+//	@Failure		504	{object}	api.ErrorResponse		"Request timed out"
+//	@Router			/employees/{id}/rehire [post]
+func (h *EmployeeHandler) RehireEmployee(c *gin.Context) {
+	id, ok := employeeIDParam(c, h.service)
+	if !ok {
+		return
+	}
+
+	emp, err := h.service.Rehire(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEmployeeNotFound):
+			api.NotFound(c, "Employee not found")
+		case errors.Is(err, service.ErrNotRetired):
+			api.Conflict(c, "Employee is not retired")
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to rehire employee")
+		}
+		return
+	}
+
+	api.Success(c, http.StatusOK, models.NewEmployeeResponse(emp))
+}
+
+// VerifyEmail godoc
+//
+//	@Summary		Verify an employee email
+//	@Description	Marks the email of the employee as verified, from the link of the verification email sent when the employee was created or their email changed. Tokens expire after EMAIL_VERIFICATION_TTL and stop working once the email changes. Verifying an email again returns the employee unchanged
+//	@Tags			Employees
+//	@Produce		json
+//	@Param			token	query		string					true	"Verification token of the link"
+//	@Success		200		{object}	models.EmployeeResponse	"Email verified"
+//	@Failure		400		{object}	api.ErrorResponse		"Invalid or expired verification token, or email verification not enabled"
+//	@Failure		500		{object}	api.ErrorResponse		"Internal server error"
+//	@Failure		503		{object}	api.ErrorResponse		"Database temporarily unavailable"
+//	@Failure		504		{object}	api.ErrorResponse		"Request timed out"
+//	@Router			/employees/verify-email [get]
+func (h *EmployeeHandler) VerifyEmail(c *gin.Context) {
+	emp, err := h.service.VerifyEmail(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, verification.ErrInvalidToken):
+			api.BadRequest(c, "Invalid or expired verification token")
+		case errors.Is(err, service.ErrVerificationDisabled):
+			api.BadRequest(c, "Email verification is not enabled")
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to verify email")
+		}
+		return
+	}
+
+	api.Success(c, http.StatusOK, models.NewEmployeeResponse(emp))
+}
+
+// RequestEmailVerification godoc
+//
+//	@Summary		Resend the verification email
+//	@Description	Sends the employee a new email verification link, through an employee.email_verification_requested event delivered by the notification service. Requires EMAIL_VERIFICATION and events enabled
+//	@Tags			Employees
+//	@Produce		json
+//	@Param			id	path	string	true	"Employee ID, or uuid with ID_FORMAT=uuid"
+//	@Success		202	"Verification email requested"
+//	@Failure		400	{object}	api.ErrorResponse	"Invalid ID format, or email verification not enabled"
+//	@Failure		404	{object}	api.ErrorResponse	"Employee not found"
+//	@Failure		409	{object}	api.ErrorResponse	"Email is already verified"
+//	@Failure		500	{object}	api.ErrorResponse	"Internal server error"
+//	@Failure		503	{object}	api.ErrorResponse	"Database temporarily unavailable"
+//	@Failure		504	{object}	api.ErrorResponse	"Request timed out"
+//	@Router			/employees/{id}/verification-email [post]
+func (h *EmployeeHandler) RequestEmailVerification(c *gin.Context) {
+	id, ok := employeeIDParam(c, h.service)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RequestEmailVerification(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEmployeeNotFound):
+			api.NotFound(c, "Employee not found")
+		case errors.Is(err, service.ErrVerificationDisabled):
+			api.BadRequest(c, "Email verification is not enabled")
+		case errors.Is(err, service.ErrEmailAlreadyVerified):
+			api.Conflict(c, "Email is already verified")
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to request email verification")
+		}
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// BulkUpdateEmployees godoc
+//
+//	@Summary		Bulk update employees
+//	@Description	Sets the department and/or position of every employee matching the filter, e.g. moves a whole department, in one transaction: all of them are updated or, on an error, none. Retired employees are skipped. The response reports the outcome for each matched employee with the fields changed. With events enabled every updated employee gets an employee.updated event, stored in the same transaction. At most 1000 employees can be matched. With dry_run=true the request is validated and the outcomes reported without changing anything
+//	@Tags			Employees
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		models.BulkUpdateRequest	true	"Filter and changes"
+//	@Param			dry_run	query		bool						false	"Report what would change without changing it"
+//	@Success		200		{object}	models.BulkUpdateResponse	"Outcome per matched employee"
+//	@Failure		400		{object}	api.ErrorResponse	"Invalid JSON format, validation failed or empty filter or changes"
+//	@Failure		422		{object}	api.ErrorResponse	"The filter matches more than 1000 employees"
+//	@Failure		500		{object}	api.ErrorResponse	"Internal server error"
+//	@Failure		503		{object}	api.ErrorResponse	"Database temporarily unavailable"
+//	@Failure		504		{object}	api.ErrorResponse	"Request timed out"
+//	@Router			/employees/bulk-update [post]
+func (h *EmployeeHandler) BulkUpdateEmployees(c *gin.Context) {
+	dryRun, ok := dryRunParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.BulkUpdateRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	// An empty filter would change every employee, which is never meant
+	if req.Filter.IsEmpty() {
+		api.BadRequest(c, "Filter must set at least one field")
+		return
+	}
+	if req.Set.IsEmpty() {
+		api.BadRequest(c, "Changes must set at least one field")
+		return
+	}
+
+	results, err := h.service.BulkUpdate(c.Request.Context(), req.Filter.Filters(), req.Set, dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBulkTooLarge):
+			api.Error(c, http.StatusUnprocessableEntity, "Filter matches more than 1000 employees")
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to update employees")
+		}
+		return
+	}
+
+	lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	c.Header("Content-Language", lang.String())
+	for i := range results {
+		if results[i].Reason != "" {
+			results[i].Reason = i18n.T(lang, results[i].Reason)
+		}
+	}
+
+	api.Success(c, http.StatusOK, models.NewBulkUpdateResponse(results, dryRun))
 }
 
 // DeleteEmployee godoc
 //
 //	@Summary		Delete employee
-//	@Description	Deletes an employee by ID
+//	@Description	Deletes an employee by ID. With dry_run=true nothing is deleted, the employee that would be is returned
 //	@Tags			Employees
-//	@Param			id	path	int	true	"Employee ID"
+//	@Produce		json
+//	@Param			id		path	string	true	"Employee ID, or uuid with ID_FORMAT=uuid"
+//	@Param			dry_run	query	bool	false	"Return the employee that would be deleted without deleting it"
+//	@Success		200	{object}	models.EmployeeResponse	"Employee that would be deleted (dry run)"
 //	@Success		204	"Employee deleted successfully (no content)"
-//	@Failure		400	{object}	api.ErrorResponse	"Invalid ID format"
+//	@Failure		400	{object}	api.ErrorResponse	"Invalid ID format or query parameters"
 //	@Failure		404	{object}	api.ErrorResponse	"Employee not found"
 //	@Failure		500	{object}	api.ErrorResponse	"Internal server error"
+//	@Failure		503	{object}	api.ErrorResponse	"Database temporarily unavailable"
+//	@Failure		504	{object}	api.ErrorResponse	"Request timed out"
 //	@Router			/employees/{id} [delete]
 func (h *EmployeeHandler) DeleteEmployee(c *gin.Context) {
-	idParam := c.Param("id")
-
-	id, errs := validator.ValidateID(idParam)
-	if errs != nil {
-		api.ValidationError(c, http.StatusBadRequest, "Invalid ID", errs)
+	id, ok := employeeIDParam(c, h.service)
+	if !ok {
+		return
+	}
+	dryRun, ok := dryRunParam(c)
+	if !ok {
 		return
 	}
 
-	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+	var emp *models.Employee
+	var err error
+	if dryRun {
+		emp, err = h.service.FindByID(c.Request.Context(), id)
+	} else {
+		err = h.service.Delete(c.Request.Context(), id)
+	}
+	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrEmployeeNotFound):
 			api.NotFound(c, "Employee not found")
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
 		default:
-			api.InternalServerError(c, "Failed to delete employee")
+			api.InternalServerError(c, err, "Failed to delete employee")
 		}
 		return
 	}
 
+	if dryRun {
+		api.Success(c, http.StatusOK, models.NewEmployeeResponse(emp))
+		return
+	}
 	c.Status(http.StatusNoContent)
 }
 
-// HealthCheck handles GET /health
-func HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":    "UP",
-		"service":   "employee-management",
-		"timestamp": time.Now().UTC(),
-	})
+// bindJSON binds the JSON body into req, checking its binding rules. It
+// writes the error response and returns false when the body is malformed
+// or breaks a rule
+func bindJSON(c *gin.Context, req interface{}) bool {
+	err := c.ShouldBindJSON(req)
+	if err == nil {
+		return true
+	}
+	bindError(c, err)
+	return false
+}
+
+// bindError writes the response of a failed binding, the field errors of
+// a failed validation or a bad request for malformed JSON
+func bindError(c *gin.Context, err error) {
+	if errs, ok := validator.FieldErrors(err); ok {
+		api.ValidationError(c, http.StatusBadRequest, "Validation failed", errs)
+	} else {
+		api.BadRequest(c, "Invalid JSON format")
+	}
+}
+
+// filterValue returns the filter matching any of values: nil for none, the
+// value itself for one and the slice, matched with IN, for more
+func filterValue(values []string) interface{} {
+	switch len(values) {
+	case 0:
+		return nil
+	case 1:
+		return values[0]
+	default:
+		return values
+	}
+}
+
+// includes are the related resources embedded in employee responses
+type includes struct {
+	skills bool
+}
+
+// includeNames are the values accepted by the include query parameter
+const includeNames = "skills"
+
+// includeParam reads the comma separated related resources of the include
+// query parameter. It writes the error response and returns false when
+// one is unknown or not available on the storage backend
+func (h *EmployeeHandler) includeParam(c *gin.Context) (includes, bool) {
+	var inc includes
+	for _, name := range strings.Split(c.Query("include"), ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+		case "skills":
+			inc.skills = true
+		default:
+			detail := api.NewErrorDetail("include", "include.oneof", "Include must be one of: %s", includeNames)
+			detail.RejectedValue = strings.TrimSpace(name)
+			api.ValidationError(c, http.StatusBadRequest, "Invalid query parameters", []api.ErrorDetail{detail})
+			return inc, false
+		}
+	}
+
+	if inc.skills && h.skills == nil {
+		api.BadRequest(c, "Including skills requires the postgres storage backend")
+		return inc, false
+	}
+	return inc, true
+}
+
+// embed loads the related resources of inc for all responses at once and
+// sets them. It writes the error response and returns false on failure
+func (h *EmployeeHandler) embed(c *gin.Context, inc includes, responses []models.EmployeeResponse) bool {
+	if !inc.skills {
+		return true
+	}
+
+	ids := make([]int64, len(responses))
+	for i, r := range responses {
+		ids[i] = r.ID
+	}
+	skills, err := h.skills.SkillsByEmployee(c.Request.Context(), ids)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to retrieve employee skills")
+		}
+		return false
+	}
+
+	for i := range responses {
+		held := skills[responses[i].ID]
+		if held == nil {
+			held = []models.EmployeeSkill{}
+		}
+		responses[i].Skills = &held
+	}
+	return true
+}
+
+// dryRunParam reads the dry_run query parameter, false when absent. It
+// writes the error response and returns false when it is not a bool
+func dryRunParam(c *gin.Context) (dryRun bool, ok bool) {
+	v := c.Query("dry_run")
+	if v == "" {
+		return false, true
+	}
+
+	dryRun, err := strconv.ParseBool(v)
+	if err != nil {
+		api.BadRequest(c, "Invalid query parameters")
+		return false, false
+	}
+	return dryRun, true
+}
+
+// employeeIDParam reads the employee of the :id path parameter: their id or,
+// with ID_FORMAT=uuid, their uuid resolved to the id. Integer ids are
+// rejected then, so employees cannot be enumerated. It writes the error
+// response and returns false when the employee cannot be resolved
+func employeeIDParam(c *gin.Context, s EmployeeService) (int64, bool) {
+	if s.IDFormat() != models.IDFormatUUID {
+		id, errs := validator.ValidateID(c.Param("id"))
+		if errs != nil {
+			api.ValidationError(c, http.StatusBadRequest, "Invalid ID", errs)
+			return 0, false
+		}
+		return id, true
+	}
+
+	uuid, errs := validator.ValidateUUID(c.Param("id"))
+	if errs != nil {
+		api.ValidationError(c, http.StatusBadRequest, "Invalid ID", errs)
+		return 0, false
+	}
+
+	emp, err := s.FindByUUID(c.Request.Context(), uuid)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEmployeeNotFound):
+			api.NotFound(c, "Employee not found")
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to retrieve employee")
+		}
+		return 0, false
+	}
+
+	return emp.ID, true
 }
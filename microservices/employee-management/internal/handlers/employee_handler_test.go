@@ -0,0 +1,628 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"employee-management/internal/breaker"
+	"employee-management/internal/handlers"
+	"employee-management/internal/handlers/mocks"
+	"employee-management/internal/models"
+	"employee-management/internal/patch"
+	"employee-management/internal/repository"
+	"employee-management/internal/search"
+	"employee-management/internal/service"
+	"employee-management/internal/verification"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	employeeUUID = "6f1c2a9e-3b7d-4c8a-9e5f-0a1b2c3d4e5f"
+
+	createBody = `{"firstName":"Jane","lastName":"Doe","email":"jane.doe@example.com","employeeNumber":"EMP-001"}`
+	updateBody = `{"firstName":"Jane","lastName":"Doe","email":"jane.doe@example.com","employeeNumber":"EMP-001","status":"ACTIVE"}`
+	bulkBody   = `{"filter":{"department":"Sales"},"set":{"department":"Engineering"}}`
+)
+
+var errDB = errors.New("connection reset by peer")
+
+// handlerCase is one request against the employee routes and the status
+// it must be answered with
+type handlerCase struct {
+	name   string
+	method string
+	path   string
+	body   string
+	header map[string]string
+	// format is the ID_FORMAT of the service, int when empty
+	format models.IDFormat
+	// skills enables the skill loader
+	skills bool
+	setup  func(s *mocks.MockEmployeeService, sk *mocks.MockSkillLoader)
+	want   int
+}
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newRouter registers the employee routes like cmd/routes.go does
+func newRouter(h *handlers.EmployeeHandler) *gin.Engine {
+	r := gin.New()
+	employees := r.Group("/employees")
+	employees.POST("/", h.CreateEmployee)
+	employees.GET("/snapshot", h.GetSnapshot)
+	employees.GET("/number-format", h.GetEmployeeNumberFormat)
+	employees.GET("/verify-email", h.VerifyEmail)
+	employees.GET("/:id", h.GetEmployeeByID)
+	employees.GET("/", h.GetAllEmployees)
+	employees.PUT("/:id", h.UpdateEmployee)
+	employees.PATCH("/:id", h.PatchEmployee)
+	employees.POST("/:id/rehire", h.RehireEmployee)
+	employees.POST("/:id/verification-email", h.RequestEmailVerification)
+	employees.POST("/bulk-update", h.BulkUpdateEmployees)
+	employees.DELETE("/:id", h.DeleteEmployee)
+	return r
+}
+
+func runCases(t *testing.T, cases []handlerCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			s := mocks.NewMockEmployeeService(ctrl)
+			sk := mocks.NewMockSkillLoader(ctrl)
+
+			format := tc.format
+			if format == "" {
+				format = models.IDFormatInt
+			}
+			s.EXPECT().IDFormat().Return(format).AnyTimes()
+			if tc.setup != nil {
+				tc.setup(s, sk)
+			}
+
+			var skills handlers.SkillLoader
+			if tc.skills {
+				skills = sk
+			}
+			r := newRouter(handlers.NewEmployeeHandler(s, skills))
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			for k, v := range tc.header {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tc.want {
+				t.Fatalf("%s %s = %d, want %d, body %s", tc.method, tc.path, w.Code, tc.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func employee(id int64) *models.Employee {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &models.Employee{
+		ID:             id,
+		UUID:           employeeUUID,
+		FirstName:      "Jane",
+		LastName:       "Doe",
+		Email:          "jane.doe@example.com",
+		EmployeeNumber: "EMP-001",
+		Department:     "Sales",
+		Status:         models.StatusActive,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// failing returns the cases answering the common service errors, doing
+// the call set up by expect
+func failing(method, path, body string, expect func(s *mocks.MockEmployeeService, err error)) []handlerCase {
+	errs := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"breaker open", breaker.ErrOpen, http.StatusServiceUnavailable},
+		{"deadline exceeded", context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"database error", errDB, http.StatusInternalServerError},
+	}
+	cases := make([]handlerCase, len(errs))
+	for i, e := range errs {
+		err := e.err
+		cases[i] = handlerCase{
+			name:   e.name,
+			method: method,
+			path:   path,
+			body:   body,
+			setup:  func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) { expect(s, err) },
+			want:   e.want,
+		}
+	}
+	return cases
+}
+
+func TestCreateEmployee(t *testing.T) {
+	cases := []handlerCase{
+		{
+			name: "created", method: http.MethodPost, path: "/employees/", body: createBody,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e *models.Employee) error {
+					e.ID = 1
+					return nil
+				})
+			},
+			want: http.StatusCreated,
+		},
+		{name: "invalid json", method: http.MethodPost, path: "/employees/", body: `{"firstName":`, want: http.StatusBadRequest},
+		{name: "validation failed", method: http.MethodPost, path: "/employees/", body: `{"firstName":"Jane","lastName":"Doe","email":"not-an-email","employeeNumber":"EMP-001"}`, want: http.StatusBadRequest},
+		{
+			name: "email exists", method: http.MethodPost, path: "/employees/", body: createBody,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repository.ErrEmailAlreadyExists)
+			},
+			want: http.StatusConflict,
+		},
+		{
+			name: "employee number exists", method: http.MethodPost, path: "/employees/", body: createBody,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repository.ErrEmployeeNumberAlreadyExists)
+			},
+			want: http.StatusConflict,
+		},
+	}
+	cases = append(cases, failing(http.MethodPost, "/employees/", createBody, func(s *mocks.MockEmployeeService, err error) {
+		s.EXPECT().Create(gomock.Any(), gomock.Any()).Return(err)
+	})...)
+	runCases(t, cases)
+}
+
+func TestGetEmployeeByID(t *testing.T) {
+	cases := []handlerCase{
+		{
+			name: "found", method: http.MethodGet, path: "/employees/1",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(employee(1), nil)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "with skills", method: http.MethodGet, path: "/employees/1?include=skills", skills: true,
+			setup: func(s *mocks.MockEmployeeService, sk *mocks.MockSkillLoader) {
+				s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(employee(1), nil)
+				sk.EXPECT().SkillsByEmployee(gomock.Any(), []int64{1}).Return(map[int64][]models.EmployeeSkill{}, nil)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "skills time out", method: http.MethodGet, path: "/employees/1?include=skills", skills: true,
+			setup: func(s *mocks.MockEmployeeService, sk *mocks.MockSkillLoader) {
+				s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(employee(1), nil)
+				sk.EXPECT().SkillsByEmployee(gomock.Any(), gomock.Any()).Return(nil, context.DeadlineExceeded)
+			},
+			want: http.StatusGatewayTimeout,
+		},
+		{
+			name: "skills fail", method: http.MethodGet, path: "/employees/1?include=skills", skills: true,
+			setup: func(s *mocks.MockEmployeeService, sk *mocks.MockSkillLoader) {
+				s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(employee(1), nil)
+				sk.EXPECT().SkillsByEmployee(gomock.Any(), gomock.Any()).Return(nil, errDB)
+			},
+			want: http.StatusInternalServerError,
+		},
+		{name: "invalid id", method: http.MethodGet, path: "/employees/abc", want: http.StatusBadRequest},
+		{name: "non positive id", method: http.MethodGet, path: "/employees/0", want: http.StatusBadRequest},
+		{name: "unknown include", method: http.MethodGet, path: "/employees/1?include=managers", want: http.StatusBadRequest},
+		{name: "skills disabled", method: http.MethodGet, path: "/employees/1?include=skills", want: http.StatusBadRequest},
+		{
+			name: "not found", method: http.MethodGet, path: "/employees/1",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(nil, repository.ErrEmployeeNotFound)
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name: "not acceptable", method: http.MethodGet, path: "/employees/1",
+			header: map[string]string{"Accept": "image/png"},
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(employee(1), nil)
+			},
+			want: http.StatusNotAcceptable,
+		},
+		{
+			name: "not modified", method: http.MethodGet, path: "/employees/1",
+			header: map[string]string{"If-Modified-Since": time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat)},
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(employee(1), nil)
+			},
+			want: http.StatusNotModified,
+		},
+		{
+			name: "by uuid", method: http.MethodGet, path: "/employees/" + employeeUUID, format: models.IDFormatUUID,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().FindByUUID(gomock.Any(), employeeUUID).Return(employee(7), nil)
+				s.EXPECT().FindByID(gomock.Any(), int64(7)).Return(employee(7), nil)
+			},
+			want: http.StatusOK,
+		},
+		{name: "integer id with uuid format", method: http.MethodGet, path: "/employees/7", format: models.IDFormatUUID, want: http.StatusBadRequest},
+		{
+			name: "uuid not found", method: http.MethodGet, path: "/employees/" + employeeUUID, format: models.IDFormatUUID,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().FindByUUID(gomock.Any(), employeeUUID).Return(nil, repository.ErrEmployeeNotFound)
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name: "uuid lookup breaker open", method: http.MethodGet, path: "/employees/" + employeeUUID, format: models.IDFormatUUID,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().FindByUUID(gomock.Any(), employeeUUID).Return(nil, breaker.ErrOpen)
+			},
+			want: http.StatusServiceUnavailable,
+		},
+	}
+	cases = append(cases, failing(http.MethodGet, "/employees/1", "", func(s *mocks.MockEmployeeService, err error) {
+		s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(nil, err)
+	})...)
+	runCases(t, cases)
+}
+
+func TestGetAllEmployees(t *testing.T) {
+	findAllFails := func(err error) func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+		return func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+			s.EXPECT().FindAll(gomock.Any(), 1, 10, gomock.Any()).Return(nil, 0, err)
+		}
+	}
+	cases := []handlerCase{
+		{
+			name: "listed", method: http.MethodGet, path: "/employees/?status=ACTIVE,RETIRED&country=co",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				filters := map[string]interface{}{"status": []string{"ACTIVE", "RETIRED"}, "country": "CO"}
+				s.EXPECT().FindAll(gomock.Any(), 1, 10, filters).Return([]models.Employee{*employee(1)}, 1, nil)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "without total", method: http.MethodGet, path: "/employees/?include_total=false&page=2&page_size=5",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().FindAllWithoutTotal(gomock.Any(), 2, 5, gomock.Any()).Return([]models.Employee{}, false, nil)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "searched", method: http.MethodGet, path: "/employees/?q=jane",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Search(gomock.Any(), "jane", 1, 10, gomock.Any()).Return([]models.Employee{*employee(1)}, 1, nil)
+			},
+			want: http.StatusOK,
+		},
+		{name: "invalid page size", method: http.MethodGet, path: "/employees/?page_size=500", want: http.StatusBadRequest},
+		{name: "invalid status", method: http.MethodGet, path: "/employees/?status=FIRED", want: http.StatusBadRequest},
+		{name: "unknown include", method: http.MethodGet, path: "/employees/?include=managers", want: http.StatusBadRequest},
+		{
+			name: "search disabled", method: http.MethodGet, path: "/employees/?q=jane",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Search(gomock.Any(), "jane", 1, 10, gomock.Any()).Return(nil, 0, service.ErrSearchDisabled)
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "search with skill filter", method: http.MethodGet, path: "/employees/?q=jane&skill=go",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Search(gomock.Any(), "jane", 1, 10, gomock.Any()).Return(nil, 0, search.ErrFilterUnsupported)
+			},
+			want: http.StatusBadRequest,
+		},
+		{name: "skill filter unsupported", method: http.MethodGet, path: "/employees/?skill=go", setup: findAllFails(repository.ErrSkillFilterUnsupported), want: http.StatusBadRequest},
+		{name: "archive unsupported", method: http.MethodGet, path: "/employees/?archived=true", setup: findAllFails(repository.ErrArchiveUnsupported), want: http.StatusBadRequest},
+		{
+			name: "not acceptable", method: http.MethodGet, path: "/employees/",
+			header: map[string]string{"Accept": "image/png"},
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().FindAll(gomock.Any(), 1, 10, gomock.Any()).Return([]models.Employee{}, 0, nil)
+			},
+			want: http.StatusNotAcceptable,
+		},
+	}
+	cases = append(cases, failing(http.MethodGet, "/employees/", "", func(s *mocks.MockEmployeeService, err error) {
+		s.EXPECT().FindAll(gomock.Any(), 1, 10, gomock.Any()).Return(nil, 0, err)
+	})...)
+	runCases(t, cases)
+}
+
+func TestGetSnapshot(t *testing.T) {
+	cases := []handlerCase{
+		{
+			name: "page", method: http.MethodGet, path: "/employees/snapshot?after=10&limit=2",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Snapshot(gomock.Any(), int64(10), 2).Return([]models.VersionedEmployee{}, nil)
+			},
+			want: http.StatusOK,
+		},
+		{name: "limit too large", method: http.MethodGet, path: "/employees/snapshot?limit=5000", want: http.StatusBadRequest},
+		{name: "negative after", method: http.MethodGet, path: "/employees/snapshot?after=-1", want: http.StatusBadRequest},
+	}
+	cases = append(cases, failing(http.MethodGet, "/employees/snapshot", "", func(s *mocks.MockEmployeeService, err error) {
+		s.EXPECT().Snapshot(gomock.Any(), int64(0), 500).Return(nil, err)
+	})...)
+	runCases(t, cases)
+}
+
+func TestGetEmployeeNumberFormat(t *testing.T) {
+	runCases(t, []handlerCase{
+		{name: "format", method: http.MethodGet, path: "/employees/number-format", want: http.StatusOK},
+	})
+}
+
+func TestUpdateEmployee(t *testing.T) {
+	updateFails := func(err error) func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+		return func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+			s.EXPECT().Update(gomock.Any(), gomock.Any()).Return(err)
+		}
+	}
+	cases := []handlerCase{
+		{
+			name: "updated", method: http.MethodPut, path: "/employees/1", body: updateBody,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(employee(1), nil)
+			},
+			want: http.StatusOK,
+		},
+		{name: "invalid id", method: http.MethodPut, path: "/employees/abc", body: updateBody, want: http.StatusBadRequest},
+		{name: "invalid json", method: http.MethodPut, path: "/employees/1", body: `[`, want: http.StatusBadRequest},
+		{name: "validation failed", method: http.MethodPut, path: "/employees/1", body: `{"firstName":"Jane","lastName":"Doe","email":"jane.doe@example.com","employeeNumber":"EMP-001","status":"FIRED"}`, want: http.StatusBadRequest},
+		{name: "not found", method: http.MethodPut, path: "/employees/1", body: updateBody, setup: updateFails(repository.ErrEmployeeNotFound), want: http.StatusNotFound},
+		{name: "email exists", method: http.MethodPut, path: "/employees/1", body: updateBody, setup: updateFails(repository.ErrEmailAlreadyExists), want: http.StatusConflict},
+		{name: "employee number exists", method: http.MethodPut, path: "/employees/1", body: updateBody, setup: updateFails(repository.ErrEmployeeNumberAlreadyExists), want: http.StatusConflict},
+		{name: "rehire required", method: http.MethodPut, path: "/employees/1", body: updateBody, setup: updateFails(service.ErrRehireRequired), want: http.StatusConflict},
+		{name: "retired", method: http.MethodPut, path: "/employees/1", body: updateBody, setup: updateFails(service.ErrEmployeeRetired), want: http.StatusConflict},
+		{name: "probation", method: http.MethodPut, path: "/employees/1", body: updateBody, setup: updateFails(service.ErrProbationStatus), want: http.StatusConflict},
+		{
+			name: "refetch fails", method: http.MethodPut, path: "/employees/1", body: updateBody,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(nil, errDB)
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+	cases = append(cases, failing(http.MethodPut, "/employees/1", updateBody, func(s *mocks.MockEmployeeService, err error) {
+		s.EXPECT().Update(gomock.Any(), gomock.Any()).Return(err)
+	})...)
+	runCases(t, cases)
+}
+
+func TestPatchEmployee(t *testing.T) {
+	mergePatch := map[string]string{"Content-Type": patch.MergePatchType}
+	jsonPatch := map[string]string{"Content-Type": patch.JSONPatchType}
+	found := func(s *mocks.MockEmployeeService) {
+		s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(employee(1), nil)
+	}
+	cases := []handlerCase{
+		{
+			name: "merge patched", method: http.MethodPatch, path: "/employees/1", body: `{"department":"Engineering","phone":null}`, header: mergePatch,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				found(s)
+				s.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e *models.Employee) error {
+					if e.Department != "Engineering" {
+						t.Errorf("department = %q, want Engineering", e.Department)
+					}
+					return nil
+				})
+				found(s)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "json patched", method: http.MethodPatch, path: "/employees/1", body: `[{"op":"replace","path":"/position","value":"Lead"}]`, header: jsonPatch,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				found(s)
+				s.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				found(s)
+			},
+			want: http.StatusOK,
+		},
+		{name: "unsupported media type", method: http.MethodPatch, path: "/employees/1", body: `{}`, want: http.StatusUnsupportedMediaType},
+		{name: "invalid id", method: http.MethodPatch, path: "/employees/abc", body: `{}`, header: mergePatch, want: http.StatusBadRequest},
+		{
+			name: "not found", method: http.MethodPatch, path: "/employees/1", body: `{}`, header: mergePatch,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(nil, repository.ErrEmployeeNotFound)
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name: "test operation failed", method: http.MethodPatch, path: "/employees/1", body: `[{"op":"test","path":"/department","value":"Legal"}]`, header: jsonPatch,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) { found(s) },
+			want:  http.StatusConflict,
+		},
+		{
+			name: "unprocessable", method: http.MethodPatch, path: "/employees/1", body: `[{"op":"remove","path":"/missing"}]`, header: jsonPatch,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) { found(s) },
+			want:  http.StatusUnprocessableEntity,
+		},
+		{
+			name: "invalid patch", method: http.MethodPatch, path: "/employees/1", body: `[{"op":"frobnicate","path":"/department"}]`, header: jsonPatch,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) { found(s) },
+			want:  http.StatusBadRequest,
+		},
+		{
+			name: "patched employee invalid", method: http.MethodPatch, path: "/employees/1", body: `{"email":"not-an-email"}`, header: mergePatch,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) { found(s) },
+			want:  http.StatusBadRequest,
+		},
+		{
+			name: "update conflicts", method: http.MethodPatch, path: "/employees/1", body: `{"status":"PROBATION"}`, header: mergePatch,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				found(s)
+				s.EXPECT().Update(gomock.Any(), gomock.Any()).Return(service.ErrProbationStatus)
+			},
+			want: http.StatusConflict,
+		},
+	}
+	for _, tc := range failing(http.MethodPatch, "/employees/1", `{}`, func(s *mocks.MockEmployeeService, err error) {
+		s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(nil, err)
+	}) {
+		tc.header = mergePatch
+		cases = append(cases, tc)
+	}
+	runCases(t, cases)
+}
+
+func TestRehireEmployee(t *testing.T) {
+	cases := []handlerCase{
+		{
+			name: "rehired", method: http.MethodPost, path: "/employees/1/rehire",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Rehire(gomock.Any(), int64(1)).Return(employee(1), nil)
+			},
+			want: http.StatusOK,
+		},
+		{name: "invalid id", method: http.MethodPost, path: "/employees/-1/rehire", want: http.StatusBadRequest},
+		{
+			name: "not found", method: http.MethodPost, path: "/employees/1/rehire",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Rehire(gomock.Any(), int64(1)).Return(nil, repository.ErrEmployeeNotFound)
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name: "not retired", method: http.MethodPost, path: "/employees/1/rehire",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Rehire(gomock.Any(), int64(1)).Return(nil, service.ErrNotRetired)
+			},
+			want: http.StatusConflict,
+		},
+	}
+	cases = append(cases, failing(http.MethodPost, "/employees/1/rehire", "", func(s *mocks.MockEmployeeService, err error) {
+		s.EXPECT().Rehire(gomock.Any(), int64(1)).Return(nil, err)
+	})...)
+	runCases(t, cases)
+}
+
+func TestVerifyEmail(t *testing.T) {
+	verifyFails := func(err error) func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+		return func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+			s.EXPECT().VerifyEmail(gomock.Any(), "tok").Return(nil, err)
+		}
+	}
+	cases := []handlerCase{
+		{
+			name: "verified", method: http.MethodGet, path: "/employees/verify-email?token=tok",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().VerifyEmail(gomock.Any(), "tok").Return(employee(1), nil)
+			},
+			want: http.StatusOK,
+		},
+		{name: "invalid token", method: http.MethodGet, path: "/employees/verify-email?token=tok", setup: verifyFails(verification.ErrInvalidToken), want: http.StatusBadRequest},
+		{name: "disabled", method: http.MethodGet, path: "/employees/verify-email?token=tok", setup: verifyFails(service.ErrVerificationDisabled), want: http.StatusBadRequest},
+	}
+	cases = append(cases, failing(http.MethodGet, "/employees/verify-email?token=tok", "", func(s *mocks.MockEmployeeService, err error) {
+		s.EXPECT().VerifyEmail(gomock.Any(), "tok").Return(nil, err)
+	})...)
+	runCases(t, cases)
+}
+
+func TestRequestEmailVerification(t *testing.T) {
+	requestFails := func(err error) func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+		return func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+			s.EXPECT().RequestEmailVerification(gomock.Any(), int64(1)).Return(err)
+		}
+	}
+	path := "/employees/1/verification-email"
+	cases := []handlerCase{
+		{name: "requested", method: http.MethodPost, path: path, setup: requestFails(nil), want: http.StatusAccepted},
+		{name: "invalid id", method: http.MethodPost, path: "/employees/x/verification-email", want: http.StatusBadRequest},
+		{name: "not found", method: http.MethodPost, path: path, setup: requestFails(repository.ErrEmployeeNotFound), want: http.StatusNotFound},
+		{name: "disabled", method: http.MethodPost, path: path, setup: requestFails(service.ErrVerificationDisabled), want: http.StatusBadRequest},
+		{name: "already verified", method: http.MethodPost, path: path, setup: requestFails(service.ErrEmailAlreadyVerified), want: http.StatusConflict},
+	}
+	cases = append(cases, failing(http.MethodPost, path, "", func(s *mocks.MockEmployeeService, err error) {
+		s.EXPECT().RequestEmailVerification(gomock.Any(), int64(1)).Return(err)
+	})...)
+	runCases(t, cases)
+}
+
+func TestBulkUpdateEmployees(t *testing.T) {
+	path := "/employees/bulk-update"
+	cases := []handlerCase{
+		{
+			name: "updated", method: http.MethodPost, path: path, body: bulkBody,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				changes := models.BulkChanges{Department: "Engineering"}
+				s.EXPECT().BulkUpdate(gomock.Any(), map[string]interface{}{"department": "Sales"}, changes, false).
+					Return([]models.BulkResult{}, nil)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "dry run", method: http.MethodPost, path: path + "?dry_run=true", body: bulkBody,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().BulkUpdate(gomock.Any(), gomock.Any(), gomock.Any(), true).Return([]models.BulkResult{}, nil)
+			},
+			want: http.StatusOK,
+		},
+		{name: "invalid dry run", method: http.MethodPost, path: path + "?dry_run=maybe", body: bulkBody, want: http.StatusBadRequest},
+		{name: "invalid json", method: http.MethodPost, path: path, body: `{"filter":`, want: http.StatusBadRequest},
+		{name: "validation failed", method: http.MethodPost, path: path, body: `{"filter":{"status":"FIRED"},"set":{"department":"Engineering"}}`, want: http.StatusBadRequest},
+		{name: "empty filter", method: http.MethodPost, path: path, body: `{"filter":{},"set":{"department":"Engineering"}}`, want: http.StatusBadRequest},
+		{name: "empty changes", method: http.MethodPost, path: path, body: `{"filter":{"department":"Sales"},"set":{}}`, want: http.StatusBadRequest},
+		{
+			name: "too large", method: http.MethodPost, path: path, body: bulkBody,
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().BulkUpdate(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(nil, service.ErrBulkTooLarge)
+			},
+			want: http.StatusUnprocessableEntity,
+		},
+	}
+	cases = append(cases, failing(http.MethodPost, path, bulkBody, func(s *mocks.MockEmployeeService, err error) {
+		s.EXPECT().BulkUpdate(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(nil, err)
+	})...)
+	runCases(t, cases)
+}
+
+func TestDeleteEmployee(t *testing.T) {
+	cases := []handlerCase{
+		{
+			name: "deleted", method: http.MethodDelete, path: "/employees/1",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Delete(gomock.Any(), int64(1)).Return(nil)
+			},
+			want: http.StatusNoContent,
+		},
+		{
+			name: "dry run", method: http.MethodDelete, path: "/employees/1?dry_run=true",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().FindByID(gomock.Any(), int64(1)).Return(employee(1), nil)
+			},
+			want: http.StatusOK,
+		},
+		{name: "invalid id", method: http.MethodDelete, path: "/employees/abc", want: http.StatusBadRequest},
+		{name: "invalid dry run", method: http.MethodDelete, path: "/employees/1?dry_run=maybe", want: http.StatusBadRequest},
+		{
+			name: "not found", method: http.MethodDelete, path: "/employees/1",
+			setup: func(s *mocks.MockEmployeeService, _ *mocks.MockSkillLoader) {
+				s.EXPECT().Delete(gomock.Any(), int64(1)).Return(repository.ErrEmployeeNotFound)
+			},
+			want: http.StatusNotFound,
+		},
+	}
+	cases = append(cases, failing(http.MethodDelete, "/employees/1", "", func(s *mocks.MockEmployeeService, err error) {
+		s.EXPECT().Delete(gomock.Any(), int64(1)).Return(err)
+	})...)
+	runCases(t, cases)
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"employee-management/internal/api"
+	"employee-management/internal/features"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureHandler handles HTTP requests for feature flags
+type FeatureHandler struct {
+	flags *features.Flags
+}
+
+// NewFeatureHandler creates a new FeatureHandler instance
+func NewFeatureHandler(f *features.Flags) *FeatureHandler {
+	return &FeatureHandler{flags: f}
+}
+
+// FeaturesResponse lists the feature flags and their state
+type FeaturesResponse struct {
+	Active []features.Flag        `json:"active"`
+	Flags  map[features.Flag]bool `json:"flags"`
+}
+
+// ListFeatures godoc
+//
+//	@Summary		List feature flags
+//	@Description	Returns every known feature flag and which ones are active
+//	@Tags			Features
+//	@Produce		json
+//	@Success		200	{object}	FeaturesResponse	"Feature flags"
+//	@Router			/features [get]
+func (h *FeatureHandler) ListFeatures(c *gin.Context) {
+	api.Success(c, http.StatusOK, FeaturesResponse{
+		Active: h.flags.Active(),
+		Flags:  h.flags.All(),
+	})
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"employee-management/internal/api"
+	"employee-management/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// GraphQLHandler executes GraphQL operations against the employee schema
+type GraphQLHandler struct {
+	schema graphql.Schema
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler instance
+func NewGraphQLHandler(schema graphql.Schema) *GraphQLHandler {
+	return &GraphQLHandler{schema: schema}
+}
+
+// GraphQLRequest is a GraphQL operation sent over HTTP
+type GraphQLRequest struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// GraphQL godoc
+//
+//	@Summary		GraphQL endpoint
+//	@Description	Executes a GraphQL query or mutation. Errors are reported in the errors array with extensions.code (NOT_FOUND, CONFLICT, BAD_USER_INPUT, UNAVAILABLE, TIMEOUT, INTERNAL)
+//	@Tags			GraphQL
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		GraphQLRequest		true	"GraphQL operation"
+//	@Success		200		{object}	map[string]any		"GraphQL result"
+//	@Failure		400		{object}	api.ErrorResponse	"Invalid JSON format"
+//	@Router			/graphql [post]
+func (h *GraphQLHandler) GraphQL(c *gin.Context) {
+	var req GraphQLRequest
+
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if v := c.Query("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				api.BadRequest(c, "Invalid variables")
+				return
+			}
+		}
+		if req.Query == "" {
+			api.BadRequest(c, "Missing query")
+			return
+		}
+		// GET must not change state
+		if hasMutation(req.Query) {
+			api.Error(c, http.StatusMethodNotAllowed, "Mutations require POST")
+			return
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		api.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        c.Request.Context(),
+	})
+
+	// Error messages follow Accept-Language like the REST errors
+	lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	for i, e := range result.Errors {
+		result.Errors[i].Message = i18n.T(lang, e.Message)
+		if details, ok := e.Extensions["details"].([]api.ErrorDetail); ok {
+			e.Extensions["details"] = api.Localize(lang, details)
+		}
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// hasMutation reports whether the document defines a mutation. Documents
+// that do not parse are left for Do to report
+func hasMutation(query string) bool {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return false
+	}
+
+	for _, def := range doc.Definitions {
+		if op, ok := def.(*ast.OperationDefinition); ok && op.Operation == ast.OperationTypeMutation {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"employee-management/internal/breaker"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// HealthHandler handles the health endpoints
+type HealthHandler struct {
+	breaker  *breaker.Breaker // Repository circuit breaker
+	pool     *pgxpool.Pool    // PostgreSQL pool, nil on the other backends
+	draining atomic.Bool      // set at shutdown, fails readiness
+}
+
+// NewHealthHandler creates a new HealthHandler instance, pool is nil
+// when the storage backend is not PostgreSQL
+func NewHealthHandler(b *breaker.Breaker, pool *pgxpool.Pool) *HealthHandler {
+	return &HealthHandler{breaker: b, pool: pool}
+}
+
+// HealthCheck handles GET /health
+// Reports DEGRADED while the db circuit breaker is not closed, and the
+// connection pool usage on PostgreSQL
+func (h *HealthHandler) HealthCheck(c *gin.Context) {
+	state := h.breaker.State()
+
+	status := "UP"
+	if state != breaker.Closed {
+		status = "DEGRADED"
+	}
+
+	database := gin.H{
+		"circuit": state.String(),
+	}
+	if h.pool != nil {
+		database["pool"] = poolHealth(h.pool.Stat())
+	}
+
+	// Probes must always see the current state
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{
+		"status":    status,
+		"service":   "employee-management",
+		"timestamp": time.Now().UTC(),
+		"database":  database,
+	})
+}
+
+// poolHealth summarizes the pool statistics. Exhausted means every
+// connection is in use, so further requests wait for one
+func poolHealth(stat *pgxpool.Stat) gin.H {
+	return gin.H{
+		"acquired":             stat.AcquiredConns(),
+		"idle":                 stat.IdleConns(),
+		"total":                stat.TotalConns(),
+		"max":                  stat.MaxConns(),
+		"exhausted":            stat.AcquiredConns() >= stat.MaxConns(),
+		"acquireCount":         stat.AcquireCount(),
+		"emptyAcquireCount":    stat.EmptyAcquireCount(),
+		"canceledAcquireCount": stat.CanceledAcquireCount(),
+		"acquireWaitSeconds":   stat.AcquireDuration().Seconds(),
+	}
+}
+
+// Drain makes readiness fail from now on, so load balancers stop sending
+// requests before the instance stops
+func (h *HealthHandler) Drain() {
+	h.draining.Store(true)
+}
+
+// Ready handles GET /health/ready
+// Answers 503 DRAINING once the instance is shutting down, while /health
+// keeps answering so the instance is not restarted meanwhile
+func (h *HealthHandler) Ready(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	if h.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "DRAINING"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "READY"})
+}
@@ -0,0 +1,289 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: employee_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=employee_handler.go -destination=mocks/employee_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "employee-management/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEmployeeService is a mock of EmployeeService interface.
+type MockEmployeeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmployeeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockEmployeeServiceMockRecorder is the mock recorder for MockEmployeeService.
+type MockEmployeeServiceMockRecorder struct {
+	mock *MockEmployeeService
+}
+
+// NewMockEmployeeService creates a new mock instance.
+func NewMockEmployeeService(ctrl *gomock.Controller) *MockEmployeeService {
+	mock := &MockEmployeeService{ctrl: ctrl}
+	mock.recorder = &MockEmployeeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmployeeService) EXPECT() *MockEmployeeServiceMockRecorder {
+	return m.recorder
+}
+
+// BulkUpdate mocks base method.
+func (m *MockEmployeeService) BulkUpdate(ctx context.Context, filters map[string]any, changes models.BulkChanges, dryRun bool) ([]models.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdate", ctx, filters, changes, dryRun)
+	ret0, _ := ret[0].([]models.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdate indicates an expected call of BulkUpdate.
+func (mr *MockEmployeeServiceMockRecorder) BulkUpdate(ctx, filters, changes, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdate", reflect.TypeOf((*MockEmployeeService)(nil).BulkUpdate), ctx, filters, changes, dryRun)
+}
+
+// Create mocks base method.
+func (m *MockEmployeeService) Create(ctx context.Context, e *models.Employee) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEmployeeServiceMockRecorder) Create(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEmployeeService)(nil).Create), ctx, e)
+}
+
+// Delete mocks base method.
+func (m *MockEmployeeService) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockEmployeeServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEmployeeService)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockEmployeeService) FindAll(ctx context.Context, page, pageSize int, filters map[string]any) ([]models.Employee, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, page, pageSize, filters)
+	ret0, _ := ret[0].([]models.Employee)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockEmployeeServiceMockRecorder) FindAll(ctx, page, pageSize, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockEmployeeService)(nil).FindAll), ctx, page, pageSize, filters)
+}
+
+// FindAllWithoutTotal mocks base method.
+func (m *MockEmployeeService) FindAllWithoutTotal(ctx context.Context, page, pageSize int, filters map[string]any) ([]models.Employee, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllWithoutTotal", ctx, page, pageSize, filters)
+	ret0, _ := ret[0].([]models.Employee)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAllWithoutTotal indicates an expected call of FindAllWithoutTotal.
+func (mr *MockEmployeeServiceMockRecorder) FindAllWithoutTotal(ctx, page, pageSize, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllWithoutTotal", reflect.TypeOf((*MockEmployeeService)(nil).FindAllWithoutTotal), ctx, page, pageSize, filters)
+}
+
+// FindByID mocks base method.
+func (m *MockEmployeeService) FindByID(ctx context.Context, id int64) (*models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockEmployeeServiceMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockEmployeeService)(nil).FindByID), ctx, id)
+}
+
+// FindByUUID mocks base method.
+func (m *MockEmployeeService) FindByUUID(ctx context.Context, uuid string) (*models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUUID", ctx, uuid)
+	ret0, _ := ret[0].(*models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUUID indicates an expected call of FindByUUID.
+func (mr *MockEmployeeServiceMockRecorder) FindByUUID(ctx, uuid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUUID", reflect.TypeOf((*MockEmployeeService)(nil).FindByUUID), ctx, uuid)
+}
+
+// IDFormat mocks base method.
+func (m *MockEmployeeService) IDFormat() models.IDFormat {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IDFormat")
+	ret0, _ := ret[0].(models.IDFormat)
+	return ret0
+}
+
+// IDFormat indicates an expected call of IDFormat.
+func (mr *MockEmployeeServiceMockRecorder) IDFormat() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IDFormat", reflect.TypeOf((*MockEmployeeService)(nil).IDFormat))
+}
+
+// Rehire mocks base method.
+func (m *MockEmployeeService) Rehire(ctx context.Context, id int64) (*models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rehire", ctx, id)
+	ret0, _ := ret[0].(*models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rehire indicates an expected call of Rehire.
+func (mr *MockEmployeeServiceMockRecorder) Rehire(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rehire", reflect.TypeOf((*MockEmployeeService)(nil).Rehire), ctx, id)
+}
+
+// RequestEmailVerification mocks base method.
+func (m *MockEmployeeService) RequestEmailVerification(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmailVerification", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestEmailVerification indicates an expected call of RequestEmailVerification.
+func (mr *MockEmployeeServiceMockRecorder) RequestEmailVerification(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailVerification", reflect.TypeOf((*MockEmployeeService)(nil).RequestEmailVerification), ctx, id)
+}
+
+// Search mocks base method.
+func (m *MockEmployeeService) Search(ctx context.Context, q string, page, pageSize int, filters map[string]any) ([]models.Employee, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, q, page, pageSize, filters)
+	ret0, _ := ret[0].([]models.Employee)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockEmployeeServiceMockRecorder) Search(ctx, q, page, pageSize, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockEmployeeService)(nil).Search), ctx, q, page, pageSize, filters)
+}
+
+// Snapshot mocks base method.
+func (m *MockEmployeeService) Snapshot(ctx context.Context, afterID int64, limit int) ([]models.VersionedEmployee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx, afterID, limit)
+	ret0, _ := ret[0].([]models.VersionedEmployee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockEmployeeServiceMockRecorder) Snapshot(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockEmployeeService)(nil).Snapshot), ctx, afterID, limit)
+}
+
+// Update mocks base method.
+func (m *MockEmployeeService) Update(ctx context.Context, e *models.Employee) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEmployeeServiceMockRecorder) Update(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEmployeeService)(nil).Update), ctx, e)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockEmployeeService) VerifyEmail(ctx context.Context, token string) (*models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(*models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockEmployeeServiceMockRecorder) VerifyEmail(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockEmployeeService)(nil).VerifyEmail), ctx, token)
+}
+
+// MockSkillLoader is a mock of SkillLoader interface.
+type MockSkillLoader struct {
+	ctrl     *gomock.Controller
+	recorder *MockSkillLoaderMockRecorder
+	isgomock struct{}
+}
+
+// MockSkillLoaderMockRecorder is the mock recorder for MockSkillLoader.
+type MockSkillLoaderMockRecorder struct {
+	mock *MockSkillLoader
+}
+
+// NewMockSkillLoader creates a new mock instance.
+func NewMockSkillLoader(ctrl *gomock.Controller) *MockSkillLoader {
+	mock := &MockSkillLoader{ctrl: ctrl}
+	mock.recorder = &MockSkillLoaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSkillLoader) EXPECT() *MockSkillLoaderMockRecorder {
+	return m.recorder
+}
+
+// SkillsByEmployee mocks base method.
+func (m *MockSkillLoader) SkillsByEmployee(ctx context.Context, employeeIDs []int64) (map[int64][]models.EmployeeSkill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SkillsByEmployee", ctx, employeeIDs)
+	ret0, _ := ret[0].(map[int64][]models.EmployeeSkill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SkillsByEmployee indicates an expected call of SkillsByEmployee.
+func (mr *MockSkillLoaderMockRecorder) SkillsByEmployee(ctx, employeeIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SkillsByEmployee", reflect.TypeOf((*MockSkillLoader)(nil).SkillsByEmployee), ctx, employeeIDs)
+}
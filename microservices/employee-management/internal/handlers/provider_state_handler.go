@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"employee-management/internal/api"
+	"employee-management/internal/pact"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProviderStateRequest is the provider state change a Pact verifier posts
+// before (setup) and after (teardown) each interaction
+type ProviderStateRequest struct {
+	Consumer string         `json:"consumer"`
+	State    string         `json:"state"`
+	Params   map[string]any `json:"params"`
+	Action   string         `json:"action"`
+}
+
+// ProviderStateHandler handles the provider state changes of Pact
+// verification, mounted in test mode only
+type ProviderStateHandler struct {
+	states *pact.States
+}
+
+// NewProviderStateHandler creates a new ProviderStateHandler instance
+func NewProviderStateHandler(states *pact.States) *ProviderStateHandler {
+	return &ProviderStateHandler{states: states}
+}
+
+// ChangeState sets up or tears down a provider state. A setup answers the
+// values consumers can inject in their requests, e.g. {"id": 1}. It is
+// left out of the API documentation, being for tests only
+func (h *ProviderStateHandler) ChangeState(c *gin.Context) {
+	var req ProviderStateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		api.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	switch req.Action {
+	case "teardown":
+		h.states.Teardown()
+		c.JSON(http.StatusOK, gin.H{})
+		return
+	case "", "setup":
+	default:
+		api.BadRequest(c, "Action must be setup or teardown")
+		return
+	}
+
+	values, err := h.states.Setup(c.Request.Context(), req.State, req.Params)
+	switch {
+	case errors.Is(err, pact.ErrUnknownState):
+		api.BadRequest(c, err.Error())
+	case err != nil:
+		api.InternalServerError(c, err, "Failed to set up provider state: "+err.Error())
+	default:
+		c.JSON(http.StatusOK, values)
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"employee-management/internal/api"
+	"employee-management/internal/breaker"
+	"employee-management/internal/models"
+	"employee-management/internal/report"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportHandler renders the printable employee report
+type ReportHandler struct {
+	source       report.Source
+	template     report.Template
+	organization string
+}
+
+// NewReportHandler creates a new ReportHandler instance
+func NewReportHandler(source report.Source, template report.Template, organization string) *ReportHandler {
+	return &ReportHandler{source: source, template: template, organization: organization}
+}
+
+// GetReportPDF godoc
+//
+//	@Summary		Get the employee report as PDF
+//	@Description	Renders a printable PDF of the employees matching the filters: a summary with the headcount, hires of the last year, average tenure and the headcount by status and department, then the roster sorted by department and name. Retired employees are left out unless status asks for them
+//	@Tags			Employees
+//	@Produce		application/pdf
+//	@Param			department	query		[]string			false	"Filter by department, any of several values given repeated or comma separated"	collectionFormat(multi)
+//	@Param			status		query		[]string			false	"Filter by status (ACTIVE, ON_VACATION, RETIRED, PROBATION), any of several values given repeated or comma separated"	collectionFormat(multi)
+//	@Success		200			{file}		file				"PDF report"
+//	@Failure		400			{object}	api.ErrorResponse	"Invalid query parameters"
+//	@Failure		500			{object}	api.ErrorResponse	"Internal server error"
+//	@Failure		503			{object}	api.ErrorResponse	"Database temporarily unavailable"
+//	@Failure		504			{object}	api.ErrorResponse	"Request timed out"
+//	@Router			/employees/report.pdf [get]
+func (h *ReportHandler) GetReportPDF(c *gin.Context) {
+	statuses := api.Values(c.QueryArray("status"))
+	for _, status := range statuses {
+		if !models.EmployeeStatus(status).Valid() {
+			api.BadRequest(c, "Invalid query parameters")
+			return
+		}
+	}
+	departments := api.Values(c.QueryArray("department"))
+
+	filters := map[string]interface{}{}
+	if v := filterValue(departments); v != nil {
+		filters["department"] = v
+	}
+	if v := filterValue(statuses); v != nil {
+		filters["status"] = v
+	}
+	keep := func(e models.Employee) bool {
+		return len(statuses) > 0 || e.Status != models.StatusRetired
+	}
+
+	r, err := report.Build(c.Request.Context(), h.source, filters, keep, models.Today())
+	if err != nil {
+		switch {
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to build report")
+		}
+		return
+	}
+	r.Organization = h.organization
+	r.Scope = reportScope(departments, statuses)
+
+	var buf bytes.Buffer
+	if err := report.WritePDF(&buf, r, h.template); err != nil {
+		api.InternalServerError(c, err, "Failed to build report")
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="employee-report-`+r.Today.String()+`.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// reportScope describes the report filters for its footer
+func reportScope(departments, statuses []string) string {
+	var scope []string
+	if len(departments) > 0 {
+		scope = append(scope, "Department: "+strings.Join(departments, ", "))
+	}
+	if len(statuses) > 0 {
+		scope = append(scope, "Status: "+strings.Join(statuses, ", "))
+	} else {
+		scope = append(scope, "Retired employees excluded")
+	}
+	return strings.Join(scope, "; ")
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"employee-management/internal/api"
+	"employee-management/internal/retention"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RetentionHandler handles HTTP requests for the data retention policy
+type RetentionHandler struct {
+	engine *retention.Engine
+}
+
+// NewRetentionHandler creates a new RetentionHandler instance
+func NewRetentionHandler(e *retention.Engine) *RetentionHandler {
+	return &RetentionHandler{engine: e}
+}
+
+// GetRetentionReport godoc
+//
+//	@Summary		Retention dry run
+//	@Description	Lists the employees retired for longer than the retention period, which the next purge would anonymize or delete. Nothing is changed
+//	@Tags			Retention
+//	@Produce		json
+//	@Param			limit	query		int						false	"Maximum employees listed (max 1000)"	default(100)
+//	@Success		200		{object}	models.RetentionReport	"Retention report"
+//	@Failure		400		{object}	api.ErrorResponse		"Invalid query parameters"
+//	@Failure		500		{object}	api.ErrorResponse		"Internal server error"
+//	@Router			/retention/report [get]
+func (h *RetentionHandler) GetRetentionReport(c *gin.Context) {
+	limit := 100
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 1000 {
+			api.BadRequest(c, "Invalid query parameters")
+			return
+		}
+		limit = n
+	}
+
+	report, err := h.engine.Report(c.Request.Context(), limit)
+	if err != nil {
+		api.InternalServerError(c, err, "Failed to build retention report")
+		return
+	}
+
+	api.Success(c, http.StatusOK, report)
+}
+
+// GetRetentionAudit godoc
+//
+//	@Summary		Retention audit log
+//	@Description	Retrieves the employees anonymized or deleted by the retention policy, newest first
+//	@Tags			Retention
+//	@Produce		json
+//	@Param			page		query		int							false	"Page number (default: 1)"
+//	@Param			page_size	query		int							false	"Number of items per page (default: 20, max: 100)"
+//	@Success		200			{array}		models.RetentionAuditEntry	"Audit entries"
+//	@Failure		500			{object}	api.ErrorResponse			"Internal server error"
+//	@Router			/retention/audit [get]
+func (h *RetentionHandler) GetRetentionAudit(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+
+	entries, err := h.engine.Audit(c.Request.Context(), page, pageSize)
+	if err != nil {
+		api.InternalServerError(c, err, "Failed to retrieve retention audit")
+		return
+	}
+
+	api.Success(c, http.StatusOK, entries)
+}
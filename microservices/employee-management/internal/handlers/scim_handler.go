@@ -0,0 +1,361 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"employee-management/internal/api"
+	"employee-management/internal/breaker"
+	"employee-management/internal/models"
+	"employee-management/internal/repository"
+	"employee-management/internal/scim"
+	"employee-management/internal/service"
+	"employee-management/internal/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Page sizes of SCIM user lists
+const (
+	scimDefaultCount = 100
+	scimMaxCount     = 200
+)
+
+// SCIMService is the employee side of SCIM provisioning, implemented by
+// *service.EmployeeService
+type SCIMService interface {
+	FindByUUID(ctx context.Context, uuid string) (*models.Employee, error)
+	FindAllStream(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error
+	Create(ctx context.Context, e *models.Employee) error
+	Update(ctx context.Context, e *models.Employee) error
+	RehireWith(ctx context.Context, e *models.Employee) error
+}
+
+// SCIMHandler handles the SCIM 2.0 Users endpoint identity providers
+// provision employees through. It speaks the protocol rather than the
+// REST API: its own media type, error body and bearer token
+type SCIMHandler struct {
+	service SCIMService
+	token   string
+}
+
+// NewSCIMHandler creates a new SCIMHandler instance accepting requests
+// with the bearer token
+func NewSCIMHandler(s SCIMService, token string) *SCIMHandler {
+	return &SCIMHandler{service: s, token: token}
+}
+
+// Authenticate rejects the requests without the bearer token
+func (h *SCIMHandler) Authenticate(c *gin.Context) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		c.Header("WWW-Authenticate", `Bearer realm="scim"`)
+		scimError(c, http.StatusUnauthorized, "", "Invalid or missing bearer token")
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// ListUsers lists a page of the users matching the filter parameter,
+// startIndex being 1-based
+func (h *SCIMHandler) ListUsers(c *gin.Context) {
+	startIndex, err1 := strconv.Atoi(c.DefaultQuery("startIndex", "1"))
+	count, err2 := strconv.Atoi(c.DefaultQuery("count", strconv.Itoa(scimDefaultCount)))
+	if err1 != nil || err2 != nil {
+		scimError(c, http.StatusBadRequest, "invalidValue", "startIndex and count must be integers")
+		return
+	}
+	startIndex = max(startIndex, 1)
+	count = min(max(count, 0), scimMaxCount)
+
+	list := scim.ListResponse{
+		Schemas:    []string{scim.SchemaListResponse},
+		StartIndex: startIndex,
+		Resources:  []scim.User{},
+	}
+
+	// userName looks up the employee by email, the other filters are
+	// matched against every employee
+	var filter *scim.Filter
+	filters := map[string]interface{}{}
+	if s := c.Query("filter"); s != "" {
+		var err error
+		if filter, err = scim.ParseFilter(s); err != nil {
+			scimError(c, http.StatusBadRequest, "invalidFilter", err.Error())
+			return
+		}
+		// externalId is not stored, no user has one
+		if _, ok := filter.Equal("externalId"); ok {
+			scimJSON(c, http.StatusOK, list)
+			return
+		}
+		if userName, ok := filter.Equal("userName"); ok {
+			filters["email"] = strings.ToLower(userName)
+		}
+	}
+
+	usersURL := scimUsersURL(c)
+	err := h.service.FindAllStream(c.Request.Context(), filters, func(e models.Employee) error {
+		u := scim.NewUser(&e, usersURL)
+		if filter != nil && !filter.Match(&u) {
+			return nil
+		}
+		list.TotalResults++
+		if list.TotalResults >= startIndex && len(list.Resources) < count {
+			list.Resources = append(list.Resources, u)
+		}
+		return nil
+	})
+	if err != nil {
+		h.serviceError(c, err, "Failed to list users")
+		return
+	}
+	list.ItemsPerPage = len(list.Resources)
+
+	scimJSON(c, http.StatusOK, list)
+}
+
+// GetUser returns the user of an employee uuid
+func (h *SCIMHandler) GetUser(c *gin.Context) {
+	e, ok := h.find(c)
+	if !ok {
+		return
+	}
+
+	scimJSON(c, http.StatusOK, scim.NewUser(e, scimUsersURL(c)))
+}
+
+// CreateUser creates the employee of a user, active whatever the user
+// says as the employees of the service start active
+func (h *SCIMHandler) CreateUser(c *gin.Context) {
+	var u scim.User
+	if err := c.ShouldBindJSON(&u); err != nil {
+		scimError(c, http.StatusBadRequest, "invalidSyntax", "Invalid JSON format")
+		return
+	}
+
+	req := u.CreateRequest()
+	if validation := validator.Struct(&req); !validation.IsValid {
+		scimError(c, http.StatusBadRequest, "invalidValue", invalidDetail(validation.Errors))
+		return
+	}
+
+	e := req.Employee()
+	if err := h.service.Create(c.Request.Context(), e); err != nil {
+		h.serviceError(c, err, "Failed to create user")
+		return
+	}
+
+	user := scim.NewUser(e, scimUsersURL(c))
+	c.Header("Location", user.Meta.Location)
+	scimJSON(c, http.StatusCreated, user)
+}
+
+// ReplaceUser replaces the employee of a user with it. An inactive user
+// retires the employee and an active one rehires a retired employee
+func (h *SCIMHandler) ReplaceUser(c *gin.Context) {
+	current, ok := h.find(c)
+	if !ok {
+		return
+	}
+
+	var u scim.User
+	if err := c.ShouldBindJSON(&u); err != nil {
+		scimError(c, http.StatusBadRequest, "invalidSyntax", "Invalid JSON format")
+		return
+	}
+
+	h.replace(c, current, &u)
+}
+
+// PatchUser applies the operations of a patch to the user of an
+// employee, then replaces the employee like ReplaceUser
+func (h *SCIMHandler) PatchUser(c *gin.Context) {
+	current, ok := h.find(c)
+	if !ok {
+		return
+	}
+
+	var patch scim.PatchRequest
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		scimError(c, http.StatusBadRequest, "invalidSyntax", "Invalid JSON format")
+		return
+	}
+
+	u := scim.NewUser(current, scimUsersURL(c))
+	if err := patch.Apply(&u); err != nil {
+		switch {
+		case errors.Is(err, scim.ErrInvalidPath):
+			scimError(c, http.StatusBadRequest, "invalidPath", err.Error())
+		case errors.Is(err, scim.ErrInvalidValue):
+			scimError(c, http.StatusBadRequest, "invalidValue", err.Error())
+		default:
+			scimError(c, http.StatusBadRequest, "invalidSyntax", err.Error())
+		}
+		return
+	}
+
+	h.replace(c, current, &u)
+}
+
+// DeactivateUser retires the employee of a user, as deprovisioning
+// deactivates users rather than deleting employees. Retired employees
+// are left as they are
+func (h *SCIMHandler) DeactivateUser(c *gin.Context) {
+	current, ok := h.find(c)
+	if !ok {
+		return
+	}
+
+	if current.Status != models.StatusRetired {
+		req := models.NewUpdateEmployeeRequest(current)
+		req.Status = models.StatusRetired
+		if err := h.service.Update(c.Request.Context(), req.Employee(current.ID)); err != nil {
+			h.serviceError(c, err, "Failed to deactivate user")
+			return
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetServiceProviderConfig describes the features of the endpoint
+func (h *SCIMHandler) GetServiceProviderConfig(c *gin.Context) {
+	supported := func(v bool) gin.H { return gin.H{"supported": v} }
+	scimJSON(c, http.StatusOK, gin.H{
+		"schemas":        []string{scim.SchemaServiceProviderConfig},
+		"patch":          supported(true),
+		"bulk":           gin.H{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         gin.H{"supported": true, "maxResults": scimMaxCount},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []gin.H{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer token",
+			"description": "The token of SCIM_TOKEN in the Authorization header",
+		}},
+	})
+}
+
+// GetResourceTypes lists the resource types, users only
+func (h *SCIMHandler) GetResourceTypes(c *gin.Context) {
+	scimJSON(c, http.StatusOK, gin.H{
+		"schemas":      []string{scim.SchemaListResponse},
+		"totalResults": 1,
+		"startIndex":   1,
+		"itemsPerPage": 1,
+		"Resources": []gin.H{{
+			"schemas":          []string{scim.SchemaResourceType},
+			"id":               "User",
+			"name":             "User",
+			"endpoint":         "/Users",
+			"schema":           scim.SchemaUser,
+			"schemaExtensions": []gin.H{{"schema": scim.SchemaEnterpriseUser, "required": false}},
+			"meta":             gin.H{"resourceType": "ResourceType", "location": strings.TrimSuffix(scimUsersURL(c), "/Users") + "/ResourceTypes/User"},
+		}},
+	})
+}
+
+// replace replaces current with u and answers the user it became
+func (h *SCIMHandler) replace(c *gin.Context, current *models.Employee, u *scim.User) {
+	req := models.NewUpdateEmployeeRequest(current)
+	u.Apply(&req)
+	if validation := validator.Struct(&req); !validation.IsValid {
+		scimError(c, http.StatusBadRequest, "invalidValue", invalidDetail(validation.Errors))
+		return
+	}
+
+	ctx := c.Request.Context()
+	save := h.service.Update
+	switch active := u.IsActive(); {
+	case !active:
+		req.Status = models.StatusRetired
+	case current.Status == models.StatusRetired:
+		// Reactivated users are rehired with their other changes at once
+		save = h.service.RehireWith
+	}
+
+	if err := save(ctx, req.Employee(current.ID)); err != nil {
+		h.serviceError(c, err, "Failed to update user")
+		return
+	}
+
+	e, err := h.service.FindByUUID(ctx, current.UUID)
+	if err != nil {
+		h.serviceError(c, err, "Failed to retrieve user")
+		return
+	}
+	scimJSON(c, http.StatusOK, scim.NewUser(e, scimUsersURL(c)))
+}
+
+// find returns the employee of the :id uuid, answering the error when
+// it fails
+func (h *SCIMHandler) find(c *gin.Context) (*models.Employee, bool) {
+	e, err := h.service.FindByUUID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.serviceError(c, err, "Failed to retrieve user")
+		return nil, false
+	}
+	return e, true
+}
+
+// serviceError answers the error of the service
+func (h *SCIMHandler) serviceError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrEmployeeNotFound):
+		scimError(c, http.StatusNotFound, "", "User not found")
+	case errors.Is(err, repository.ErrEmailAlreadyExists):
+		scimError(c, http.StatusConflict, "uniqueness", "Email already belongs to another user")
+	case errors.Is(err, repository.ErrEmployeeNumberAlreadyExists):
+		scimError(c, http.StatusConflict, "uniqueness", "Employee number already belongs to another user")
+	case errors.Is(err, service.ErrNotRetired):
+		scimError(c, http.StatusConflict, "", "User was already reactivated")
+	case errors.Is(err, service.ErrEmployeeRetired):
+		scimError(c, http.StatusBadRequest, "mutability", "Inactive users cannot be edited, reactivate them first")
+	case errors.Is(err, breaker.ErrOpen):
+		scimError(c, http.StatusServiceUnavailable, "", "Database temporarily unavailable")
+	case errors.Is(err, context.DeadlineExceeded):
+		scimError(c, http.StatusGatewayTimeout, "", "Request timed out")
+	default:
+		_ = c.Error(err)
+		scimError(c, http.StatusInternalServerError, "", message)
+	}
+}
+
+// scimUsersURL returns the url of the Users endpoint a request was sent
+// to
+func scimUsersURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	path, _, _ := strings.Cut(c.FullPath(), "/Users")
+	path, _, _ = strings.Cut(path, "/ResourceTypes")
+	return scheme + "://" + c.Request.Host + path + "/Users"
+}
+
+// scimJSON answers v with the SCIM media type
+func scimJSON(c *gin.Context, status int, v any) {
+	c.Header("Content-Type", scim.ContentType)
+	c.JSON(status, v)
+}
+
+// scimError answers the SCIM error of status
+func scimError(c *gin.Context, status int, scimType, detail string) {
+	scimJSON(c, status, scim.NewError(status, scimType, detail))
+}
+
+// invalidDetail describes the failed validation rules
+func invalidDetail(details []api.ErrorDetail) string {
+	messages := make([]string, 0, len(details))
+	for _, d := range details {
+		messages = append(messages, d.Field+": "+d.Message)
+	}
+	return "Validation failed: " + strings.Join(messages, "; ")
+}
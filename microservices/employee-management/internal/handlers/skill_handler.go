@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"employee-management/internal/api"
+	"employee-management/internal/models"
+	"employee-management/internal/repository"
+	"employee-management/internal/service"
+	"employee-management/internal/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SkillHandler handles HTTP requests for the skills catalog and the skills
+// of each employee
+type SkillHandler struct {
+	service   *service.SkillService
+	employees EmployeeService // resolves the employee path parameter
+}
+
+// NewSkillHandler creates a new SkillHandler instance
+func NewSkillHandler(s *service.SkillService, employees EmployeeService) *SkillHandler {
+	return &SkillHandler{service: s, employees: employees}
+}
+
+// SkillRequest is the payload to add a skill to the catalog
+type SkillRequest struct {
+	Name        string `json:"name" binding:"notblank,max=100" example:"Go"`
+	Category    string `json:"category" binding:"max=100" example:"Programming languages"`
+	Description string `json:"description"`
+}
+
+// SkillAssignmentRequest is the payload to assign a skill to an employee
+type SkillAssignmentRequest struct {
+	Proficiency models.Proficiency `json:"proficiency" binding:"proficiency" enums:"BEGINNER,INTERMEDIATE,ADVANCED,EXPERT"`
+}
+
+// CreateSkill godoc
+//
+//	@Summary		Add a skill
+//	@Description	Adds a skill to the catalog. Names are unique regardless of case
+//	@Tags			Skills
+//	@Accept			json
+//	@Produce		json
+//	@Param			skill	body		SkillRequest		true	"Skill data"
+//	@Success		201		{object}	models.Skill		"Skill created successfully"
+//	@Failure		400		{object}	api.ErrorResponse	"Invalid JSON format or validation failed"
+//	@Failure		409		{object}	api.ErrorResponse	"Skill already exists"
+//	@Failure		500		{object}	api.ErrorResponse	"Internal server error"
+//	@Router			/skills [post]
+func (h *SkillHandler) CreateSkill(c *gin.Context) {
+	var req SkillRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	skill := models.Skill{
+		Name:        strings.TrimSpace(req.Name),
+		Category:    strings.TrimSpace(req.Category),
+		Description: strings.TrimSpace(req.Description),
+	}
+
+	if err := h.service.Create(c.Request.Context(), &skill); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSkillAlreadyExists):
+			api.Conflict(c, "Skill already exists")
+		default:
+			api.InternalServerError(c, err, "Failed to create skill")
+		}
+		return
+	}
+
+	api.Success(c, http.StatusCreated, skill)
+}
+
+// GetAllSkills godoc
+//
+//	@Summary		List skills
+//	@Description	Retrieves the skills catalog by name
+//	@Tags			Skills
+//	@Produce		json
+//	@Param			category	query		string				false	"Only skills of this category"
+//	@Success		200			{array}		models.Skill		"Skills"
+//	@Failure		500			{object}	api.ErrorResponse	"Internal server error"
+//	@Router			/skills [get]
+func (h *SkillHandler) GetAllSkills(c *gin.Context) {
+	skills, err := h.service.FindAll(c.Request.Context(), strings.TrimSpace(c.Query("category")))
+	if err != nil {
+		api.InternalServerError(c, err, "Failed to retrieve skills")
+		return
+	}
+
+	api.Success(c, http.StatusOK, skills)
+}
+
+// GetSkillByID godoc
+//
+//	@Summary		Get skill by ID
+//	@Description	Retrieves a skill of the catalog by its ID
+//	@Tags			Skills
+//	@Produce		json
+//	@Param			id	path		int					true	"Skill ID"
+//	@Success		200	{object}	models.Skill		"Skill found"
+//	@Failure		400	{object}	api.ErrorResponse	"Invalid ID format"
+//	@Failure		404	{object}	api.ErrorResponse	"Skill not found"
+//	@Failure		500	{object}	api.ErrorResponse	"Internal server error"
+//	@Router			/skills/{id} [get]
+func (h *SkillHandler) GetSkillByID(c *gin.Context) {
+	id, errs := validator.ValidateID(c.Param("id"))
+	if errs != nil {
+		api.ValidationError(c, http.StatusBadRequest, "Invalid ID", errs)
+		return
+	}
+
+	skill, err := h.service.FindByID(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSkillNotFound):
+			api.NotFound(c, "Skill not found")
+		default:
+			api.InternalServerError(c, err, "Failed to retrieve skill")
+		}
+		return
+	}
+
+	api.Success(c, http.StatusOK, skill)
+}
+
+// DeleteSkill godoc
+//
+//	@Summary		Delete skill
+//	@Description	Removes a skill from the catalog. Skills still assigned to employees cannot be deleted
+//	@Tags			Skills
+//	@Param			id	path	int	true	"Skill ID"
+//	@Success		204	"Skill deleted successfully (no content)"
+//	@Failure		400	{object}	api.ErrorResponse	"Invalid ID format"
+//	@Failure		404	{object}	api.ErrorResponse	"Skill not found"
+//	@Failure		409	{object}	api.ErrorResponse	"Skill is assigned to employees"
+//	@Failure		500	{object}	api.ErrorResponse	"Internal server error"
+//	@Router			/skills/{id} [delete]
+func (h *SkillHandler) DeleteSkill(c *gin.Context) {
+	id, errs := validator.ValidateID(c.Param("id"))
+	if errs != nil {
+		api.ValidationError(c, http.StatusBadRequest, "Invalid ID", errs)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSkillNotFound):
+			api.NotFound(c, "Skill not found")
+		case errors.Is(err, repository.ErrSkillInUse):
+			api.Conflict(c, "Skill is assigned to employees")
+		default:
+			api.InternalServerError(c, err, "Failed to delete skill")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetEmployeeSkills godoc
+//
+//	@Summary		List employee skills
+//	@Description	Retrieves the skills of an employee by name
+//	@Tags			Skills
+//	@Produce		json
+//	@Param			id	path		string					true	"Employee ID, or uuid with ID_FORMAT=uuid"
+//	@Success		200	{array}		models.EmployeeSkill	"Skills of the employee"
+//	@Failure		400	{object}	api.ErrorResponse		"Invalid ID format"
+//	@Failure		404	{object}	api.ErrorResponse		"Employee not found"
+//	@Failure		500	{object}	api.ErrorResponse		"Internal server error"
+//	@Router			/employees/{id}/skills [get]
+func (h *SkillHandler) GetEmployeeSkills(c *gin.Context) {
+	id, ok := employeeIDParam(c, h.employees)
+	if !ok {
+		return
+	}
+
+	skills, err := h.service.EmployeeSkills(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEmployeeNotFound):
+			api.NotFound(c, "Employee not found")
+		default:
+			api.InternalServerError(c, err, "Failed to retrieve employee skills")
+		}
+		return
+	}
+
+	api.Success(c, http.StatusOK, skills)
+}
+
+// AssignSkill godoc
+//
+//	@Summary		Assign a skill
+//	@Description	Gives an employee a skill of the catalog, or changes its proficiency when already assigned
+//	@Tags			Skills
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"Employee ID, or uuid with ID_FORMAT=uuid"
+//	@Param			skillId		path		int						true	"Skill ID"
+//	@Param			assignment	body		SkillAssignmentRequest	true	"Proficiency"
+//	@Success		200			{object}	models.EmployeeSkill		"Skill assigned"
+//	@Failure		400			{object}	api.ErrorResponse		"Invalid ID format or validation failed"
+//	@Failure		404			{object}	api.ErrorResponse		"Employee or skill not found"
+//	@Failure		500			{object}	api.ErrorResponse		"Internal server error"
+//	@Router			/employees/{id}/skills/{skillId} [put]
+func (h *SkillHandler) AssignSkill(c *gin.Context) {
+	employeeID, ok := employeeIDParam(c, h.employees)
+	if !ok {
+		return
+	}
+	skillID, errs := validator.ValidateID(c.Param("skillId"))
+	if errs != nil {
+		api.ValidationError(c, http.StatusBadRequest, "Invalid skill ID", errs)
+		return
+	}
+
+	var req SkillAssignmentRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	skill := models.EmployeeSkill{SkillID: skillID, Proficiency: req.Proficiency}
+	if err := h.service.Assign(c.Request.Context(), employeeID, &skill); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEmployeeNotFound):
+			api.NotFound(c, "Employee not found")
+		case errors.Is(err, repository.ErrSkillNotFound):
+			api.NotFound(c, "Skill not found")
+		default:
+			api.InternalServerError(c, err, "Failed to assign skill")
+		}
+		return
+	}
+
+	api.Success(c, http.StatusOK, skill)
+}
+
+// UnassignSkill godoc
+//
+//	@Summary		Unassign a skill
+//	@Description	Removes a skill from an employee
+//	@Tags			Skills
+//	@Param			id		path	string	true	"Employee ID, or uuid with ID_FORMAT=uuid"
+//	@Param			skillId	path	int		true	"Skill ID"
+//	@Success		204		"Skill unassigned successfully (no content)"
+//	@Failure		400		{object}	api.ErrorResponse	"Invalid ID format"
+//	@Failure		404		{object}	api.ErrorResponse	"Employee does not have the skill"
+//	@Failure		500		{object}	api.ErrorResponse	"Internal server error"
+//	@Router			/employees/{id}/skills/{skillId} [delete]
+func (h *SkillHandler) UnassignSkill(c *gin.Context) {
+	employeeID, ok := employeeIDParam(c, h.employees)
+	if !ok {
+		return
+	}
+	skillID, errs := validator.ValidateID(c.Param("skillId"))
+	if errs != nil {
+		api.ValidationError(c, http.StatusBadRequest, "Invalid skill ID", errs)
+		return
+	}
+
+	if err := h.service.Unassign(c.Request.Context(), employeeID, skillID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSkillNotFound):
+			api.NotFound(c, "Employee does not have the skill")
+		default:
+			api.InternalServerError(c, err, "Failed to unassign skill")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
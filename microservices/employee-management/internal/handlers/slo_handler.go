@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"employee-management/internal/api"
+	"employee-management/internal/slo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SLOHandler reports the service level objectives of the routes
+type SLOHandler struct {
+	tracker *slo.Tracker
+}
+
+// NewSLOHandler creates a new SLOHandler instance
+func NewSLOHandler(t *slo.Tracker) *SLOHandler {
+	return &SLOHandler{tracker: t}
+}
+
+// SLOResponse lists the error budget burn of every route requested within
+// the window
+type SLOResponse struct {
+	Window string            `json:"window" example:"1h0m0s"`
+	Routes []slo.RouteReport `json:"routes"`
+}
+
+// GetSLO godoc
+//
+//	@Summary		Get the SLO error budget burn
+//	@Description	Returns, per route requested within the SLO window, its objective, the requests counted and how fast its error budget burns, the fastest burning first
+//	@Tags			SLO
+//	@Produce		json
+//	@Success		200	{object}	SLOResponse	"Error budget burn per route"
+//	@Router			/slo [get]
+func (h *SLOHandler) GetSLO(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	api.Success(c, http.StatusOK, SLOResponse{
+		Window: h.tracker.Window().String(),
+		Routes: h.tracker.Report(),
+	})
+}
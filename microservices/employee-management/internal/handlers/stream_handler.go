@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"employee-management/internal/api"
+	"employee-management/internal/events"
+	"employee-management/internal/stream"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// replayLimit caps the events replayed to a resuming client
+const replayLimit = 1000
+
+// StreamHandler handles the live change streams
+type StreamHandler struct {
+	hub *stream.Hub
+}
+
+// NewStreamHandler creates a new StreamHandler instance
+func NewStreamHandler(h *stream.Hub) *StreamHandler {
+	return &StreamHandler{hub: h}
+}
+
+// StreamEmployees godoc
+//
+//	@Summary		Employee change stream
+//	@Description	Pushes employee.created, employee.updated, employee.status_changed and employee.deleted events over Server-Sent Events. Send Last-Event-ID to resume after a disconnect
+//	@Tags			Employees
+//	@Produce		text/event-stream
+//	@Param			Last-Event-ID	header		int					false	"Sequence of the last event received"
+//	@Success		200				{object}	events.Event		"Stream of events"
+//	@Failure		400				{object}	api.ErrorResponse	"Invalid Last-Event-ID"
+//	@Failure		500				{object}	api.ErrorResponse	"Internal server error"
+//	@Router			/employees/stream [get]
+func (h *StreamHandler) StreamEmployees(c *gin.Context) {
+	lastID := c.GetHeader("Last-Event-ID")
+	if lastID == "" {
+		lastID = c.Query("lastEventId")
+	}
+
+	var lastSeq int64
+	if lastID != "" {
+		seq, err := strconv.ParseInt(lastID, 10, 64)
+		if err != nil || seq < 0 {
+			api.BadRequest(c, "Invalid Last-Event-ID")
+			return
+		}
+		lastSeq = seq
+	}
+
+	// Subscribe before replaying so nothing is missed in between
+	live, unsubscribe := h.hub.Subscribe()
+	defer unsubscribe()
+
+	var backlog []events.Event
+	if lastID != "" {
+		var err error
+		backlog, err = h.hub.Replay(c.Request.Context(), lastSeq, replayLimit)
+		if err != nil {
+			api.InternalServerError(c, err, "Failed to replay events")
+			return
+		}
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for _, e := range backlog {
+		renderEvent(c, e)
+		lastSeq = e.Sequence
+	}
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case e, ok := <-live:
+			if !ok {
+				// Too slow, the client reconnects with Last-Event-ID
+				return false
+			}
+			if e.Sequence > lastSeq {
+				renderEvent(c, e)
+				lastSeq = e.Sequence
+			}
+			return true
+		}
+	})
+}
+
+// renderEvent writes one SSE frame with the sequence as id
+func renderEvent(c *gin.Context, e events.Event) {
+	c.Render(-1, sse.Event{
+		Id:    strconv.FormatInt(e.Sequence, 10),
+		Event: string(e.Type),
+		Data:  e,
+	})
+}
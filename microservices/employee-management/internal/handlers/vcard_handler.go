@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+
+	"employee-management/internal/api"
+	"employee-management/internal/breaker"
+	"employee-management/internal/models"
+	"employee-management/internal/repository"
+	"employee-management/internal/vcard"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VCardHandler exports the contact details of employees as vCards
+type VCardHandler struct {
+	service      EmployeeService
+	source       vcard.Source
+	organization string
+}
+
+// NewVCardHandler creates a new VCardHandler instance, organization is
+// the company name of the cards
+func NewVCardHandler(s EmployeeService, source vcard.Source, organization string) *VCardHandler {
+	return &VCardHandler{service: s, source: source, organization: organization}
+}
+
+// GetVCard godoc
+//
+//	@Summary		Get the vCard of an employee
+//	@Description	Returns the contact details of the employee as a vCard 4.0 (RFC 6350): name, work email, position and department, for importing into address books
+//	@Tags			Employees
+//	@Produce		text/vcard
+//	@Param			id	path		string				true	"Employee ID, or uuid with ID_FORMAT=uuid"
+//	@Success		200	{string}	string				"vCard"
+//	@Failure		400	{object}	api.ErrorResponse	"Invalid ID format"
+//	@Failure		404	{object}	api.ErrorResponse	"Employee not found"
+//	@Failure		500	{object}	api.ErrorResponse	"Internal server error"
+//	@Failure		503	{object}	api.ErrorResponse	"Database temporarily unavailable"
+//	@Failure		504	{object}	api.ErrorResponse	"Request timed out"
+//	@Router			/employees/{id}/vcard [get]
+func (h *VCardHandler) GetVCard(c *gin.Context) {
+	id, ok := employeeIDParam(c, h.service)
+	if !ok {
+		return
+	}
+
+	emp, err := h.service.FindByID(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEmployeeNotFound):
+			api.NotFound(c, "Employee not found")
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to retrieve employee")
+		}
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := vcard.Write(&buf, h.organization, *emp); err != nil {
+		api.InternalServerError(c, err, "Failed to export vCards")
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+emp.EmployeeNumber+`.vcf"`)
+	c.Data(http.StatusOK, "text/vcard; charset=utf-8", buf.Bytes())
+}
+
+// GetVCards godoc
+//
+//	@Summary		Get the vCards of many employees
+//	@Description	Returns the vCards of the employees matching the filters in one file, for a bulk import into address books. Retired employees are left out unless status asks for them
+//	@Tags			Employees
+//	@Produce		text/vcard
+//	@Param			department	query		[]string			false	"Filter by department, any of several values given repeated or comma separated"	collectionFormat(multi)
+//	@Param			status		query		[]string			false	"Filter by status (ACTIVE, ON_VACATION, RETIRED, PROBATION), any of several values given repeated or comma separated"	collectionFormat(multi)
+//	@Success		200			{string}	string				"vCards"
+//	@Failure		400			{object}	api.ErrorResponse	"Invalid query parameters"
+//	@Failure		500			{object}	api.ErrorResponse	"Internal server error"
+//	@Failure		503			{object}	api.ErrorResponse	"Database temporarily unavailable"
+//	@Failure		504			{object}	api.ErrorResponse	"Request timed out"
+//	@Router			/employees/vcard [get]
+func (h *VCardHandler) GetVCards(c *gin.Context) {
+	statuses := api.Values(c.QueryArray("status"))
+	for _, status := range statuses {
+		if !models.EmployeeStatus(status).Valid() {
+			api.BadRequest(c, "Invalid query parameters")
+			return
+		}
+	}
+
+	filters := map[string]interface{}{}
+	if v := filterValue(api.Values(c.QueryArray("department"))); v != nil {
+		filters["department"] = v
+	}
+	if v := filterValue(statuses); v != nil {
+		filters["status"] = v
+	}
+
+	var buf bytes.Buffer
+	err := h.source(c.Request.Context(), filters, func(e models.Employee) error {
+		if len(statuses) == 0 && e.Status == models.StatusRetired {
+			return nil
+		}
+		return vcard.Write(&buf, h.organization, e)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, breaker.ErrOpen):
+			api.ServiceUnavailable(c, "Database temporarily unavailable")
+		case errors.Is(err, context.DeadlineExceeded):
+			api.GatewayTimeout(c, "Request timed out")
+		default:
+			api.InternalServerError(c, err, "Failed to export vCards")
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="employees.vcf"`)
+	c.Data(http.StatusOK, "text/vcard; charset=utf-8", buf.Bytes())
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"employee-management/internal/api"
+	"employee-management/internal/models"
+	"employee-management/internal/repository"
+	"employee-management/internal/service"
+	"employee-management/internal/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler handles HTTP requests for webhook subscriptions
+type WebhookHandler struct {
+	service *service.WebhookService
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance
+func NewWebhookHandler(s *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: s}
+}
+
+// WebhookRequest is the payload to register a webhook subscription
+type WebhookRequest struct {
+	URL        string   `json:"url" binding:"webhook_url"`
+	Secret     string   `json:"secret" binding:"min=16"`
+	EventTypes []string `json:"eventTypes" binding:"required,min=1,dive,event_type"`
+}
+
+// CreateWebhook godoc
+//
+//	@Summary		Register a webhook
+//	@Description	Registers a url receiving the given event types ("*" for all), signed with the secret
+//	@Tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook	body		WebhookRequest				true	"Webhook data"
+//	@Success		201		{object}	models.WebhookSubscription	"Webhook registered successfully"
+//	@Failure		400		{object}	api.ErrorResponse			"Invalid JSON format or validation failed"
+//	@Failure		500		{object}	api.ErrorResponse			"Internal server error"
+//	@Router			/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req WebhookRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	webhook := models.WebhookSubscription{URL: req.URL, Secret: req.Secret, EventTypes: req.EventTypes}
+	if err := h.service.Create(c.Request.Context(), &webhook); err != nil {
+		api.InternalServerError(c, err, "Failed to register webhook")
+		return
+	}
+
+	api.Success(c, http.StatusCreated, webhook)
+}
+
+// GetAllWebhooks godoc
+//
+//	@Summary		List webhooks
+//	@Description	Retrieves every webhook subscription
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Success		200	{array}		models.WebhookSubscription	"Webhook subscriptions"
+//	@Failure		500	{object}	api.ErrorResponse			"Internal server error"
+//	@Router			/webhooks [get]
+func (h *WebhookHandler) GetAllWebhooks(c *gin.Context) {
+	webhooks, err := h.service.FindAll(c.Request.Context())
+	if err != nil {
+		api.InternalServerError(c, err, "Failed to retrieve webhooks")
+		return
+	}
+
+	api.Success(c, http.StatusOK, webhooks)
+}
+
+// GetWebhookByID godoc
+//
+//	@Summary		Get webhook by ID
+//	@Description	Retrieves a webhook subscription by its ID
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Param			id	path		int							true	"Webhook ID"
+//	@Success		200	{object}	models.WebhookSubscription	"Webhook found"
+//	@Failure		400	{object}	api.ErrorResponse			"Invalid ID format"
+//	@Failure		404	{object}	api.ErrorResponse			"Webhook not found"
+//	@Failure		500	{object}	api.ErrorResponse			"Internal server error"
+//	@Router			/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhookByID(c *gin.Context) {
+	id, errs := validator.ValidateID(c.Param("id"))
+	if errs != nil {
+		api.ValidationError(c, http.StatusBadRequest, "Invalid ID", errs)
+		return
+	}
+
+	webhook, err := h.service.FindByID(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrWebhookNotFound):
+			api.NotFound(c, "Webhook not found")
+		default:
+			api.InternalServerError(c, err, "Failed to retrieve webhook")
+		}
+		return
+	}
+
+	api.Success(c, http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+//
+//	@Summary		Delete webhook
+//	@Description	Deletes a webhook subscription and its delivery log
+//	@Tags			Webhooks
+//	@Param			id	path	int	true	"Webhook ID"
+//	@Success		204	"Webhook deleted successfully (no content)"
+//	@Failure		400	{object}	api.ErrorResponse	"Invalid ID format"
+//	@Failure		404	{object}	api.ErrorResponse	"Webhook not found"
+//	@Failure		500	{object}	api.ErrorResponse	"Internal server error"
+//	@Router			/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, errs := validator.ValidateID(c.Param("id"))
+	if errs != nil {
+		api.ValidationError(c, http.StatusBadRequest, "Invalid ID", errs)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrWebhookNotFound):
+			api.NotFound(c, "Webhook not found")
+		default:
+			api.InternalServerError(c, err, "Failed to delete webhook")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries godoc
+//
+//	@Summary		Webhook delivery log
+//	@Description	Retrieves the deliveries of a webhook, newest first
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Param			id			path		int						true	"Webhook ID"
+//	@Param			page		query		int						false	"Page number (default: 1)"
+//	@Param			page_size	query		int						false	"Number of items per page (default: 20, max: 100)"
+//	@Success		200			{array}		models.WebhookDelivery	"Deliveries"
+//	@Failure		400			{object}	api.ErrorResponse		"Invalid ID format"
+//	@Failure		404			{object}	api.ErrorResponse		"Webhook not found"
+//	@Failure		500			{object}	api.ErrorResponse		"Internal server error"
+//	@Router			/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	id, errs := validator.ValidateID(c.Param("id"))
+	if errs != nil {
+		api.ValidationError(c, http.StatusBadRequest, "Invalid ID", errs)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+
+	deliveries, err := h.service.Deliveries(c.Request.Context(), id, page, pageSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrWebhookNotFound):
+			api.NotFound(c, "Webhook not found")
+		default:
+			api.InternalServerError(c, err, "Failed to retrieve webhook deliveries")
+		}
+		return
+	}
+
+	api.Success(c, http.StatusOK, deliveries)
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"employee-management/internal/api"
+	"employee-management/internal/config"
+	"employee-management/internal/events"
+	"employee-management/internal/stream"
+
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// Connection tuning for the WebSocket channel
+const (
+	wsWriteWait      = 10 * time.Second
+	wsPongWait       = 60 * time.Second
+	wsPingPeriod     = wsPongWait * 9 / 10
+	wsMaxMessageSize = 4096
+)
+
+// WebSocketHandler streams employee changes over WebSocket
+type WebSocketHandler struct {
+	hub      *stream.Hub
+	upgrader websocket.Upgrader
+}
+
+// NewWebSocketHandler creates a new WebSocketHandler instance
+// Allowed origins come from WS_ALLOWED_ORIGINS, empty allows same origin only
+func NewWebSocketHandler(h *stream.Hub, cfg *config.Config) *WebSocketHandler {
+	allowed := map[string]bool{}
+	for _, o := range envconfig.SplitList(cfg.WSAllowedOrigins) {
+		allowed[o] = true
+	}
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+	}
+	if len(allowed) > 0 {
+		upgrader.CheckOrigin = func(r *http.Request) bool {
+			return allowed["*"] || allowed[r.Header.Get("Origin")]
+		}
+	}
+
+	return &WebSocketHandler{hub: h, upgrader: upgrader}
+}
+
+// wsMessage is a frame sent to the client
+type wsMessage struct {
+	Kind   string         `json:"kind"` // event, filter or error
+	Event  *events.Event  `json:"event,omitempty"`
+	Filter *stream.Filter `json:"filter,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// EmployeesWebSocket godoc
+//
+//	@Summary		Employee changes over WebSocket
+//	@Description	Upgrades to a WebSocket that pushes employee events. The initial filter comes from the query, the client can replace it at any time by sending a filter object as JSON. Clients that fall behind are disconnected with close code 1013 and can resume with since
+//	@Tags			Employees
+//	@Param			department	query	string	false	"Only events for this department"
+//	@Param			status		query	string	false	"Only events for employees in this status"
+//	@Param			employeeId	query	int		false	"Only events for this employee"
+//	@Param			types		query	string	false	"Comma separated event types"
+//	@Param			since		query	int		false	"Replay events after this sequence first"
+//	@Success		101			"Switching protocols"
+//	@Failure		400			{object}	api.ErrorResponse	"Invalid query parameters"
+//	@Router			/employees/ws [get]
+func (h *WebSocketHandler) EmployeesWebSocket(c *gin.Context) {
+	filter := stream.Filter{
+		Department: c.Query("department"),
+		Status:     c.Query("status"),
+		Types:      envconfig.SplitList(c.Query("types")),
+	}
+	if v := c.Query("employeeId"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			api.BadRequest(c, "Invalid employeeId")
+			return
+		}
+		filter.EmployeeID = id
+	}
+
+	var since int64 = -1
+	if v := c.Query("since"); v != "" {
+		seq, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || seq < 0 {
+			api.BadRequest(c, "Invalid since")
+			return
+		}
+		since = seq
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader already answered with an error
+		return
+	}
+	defer conn.Close()
+
+	live, unsubscribe := h.hub.Subscribe()
+	defer unsubscribe()
+
+	// The filter is replaced by the reader and used by the writer
+	var mu sync.Mutex
+	current := filter
+	matches := func(e events.Event) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return current.Matches(e)
+	}
+
+	updates := make(chan stream.Filter, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		readFilters(conn, func(f stream.Filter) {
+			mu.Lock()
+			current = f
+			mu.Unlock()
+			select {
+			case updates <- f:
+			default:
+			}
+		})
+	}()
+
+	write := func(m wsMessage) error {
+		conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+		return conn.WriteJSON(m)
+	}
+
+	var lastSeq int64
+	if since >= 0 {
+		backlog, err := h.hub.Replay(c.Request.Context(), since, replayLimit)
+		if err != nil {
+			write(wsMessage{Kind: "error", Error: "failed to replay events"})
+			return
+		}
+		for i := range backlog {
+			lastSeq = backlog[i].Sequence
+			if matches(backlog[i]) {
+				if err := write(wsMessage{Kind: "event", Event: &backlog[i]}); err != nil {
+					return
+				}
+			}
+		}
+	}
+
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case f := <-updates:
+			if err := write(wsMessage{Kind: "filter", Filter: &f}); err != nil {
+				return
+			}
+		case e, ok := <-live:
+			if !ok {
+				// Dropped by the hub for falling behind
+				msg := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too slow, resume with since="+strconv.FormatInt(lastSeq, 10))
+				conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteWait))
+				return
+			}
+			if e.Sequence <= lastSeq {
+				continue
+			}
+			lastSeq = e.Sequence
+			if matches(e) {
+				if err := write(wsMessage{Kind: "event", Event: &e}); err != nil {
+					return
+				}
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readFilters reads filter updates until the connection fails or closes
+func readFilters(conn *websocket.Conn, apply func(stream.Filter)) {
+	conn.SetReadLimit(wsMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("websocket read failed: %v", err)
+			}
+			return
+		}
+
+		var f stream.Filter
+		if err := json.Unmarshal(data, &f); err != nil {
+			// Ignore malformed frames, keep the current filter
+			continue
+		}
+		apply(f)
+	}
+}
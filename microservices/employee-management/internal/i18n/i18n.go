@@ -0,0 +1,81 @@
+// Package i18n translates the user facing error and validation messages
+// Messages are written in English in the code and the English text is the
+// key in the catalogs, so a missing translation falls back to English
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// supported lists the languages with a catalog, English first as the
+// fallback
+var supported = []language.Tag{language.English}
+
+// catalogs maps a language to its translations
+var catalogs = map[language.Tag]map[string]string{}
+
+var matcher language.Matcher
+
+func init() {
+	files, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, f := range files {
+		tag, err := language.Parse(strings.TrimSuffix(f.Name(), ".json"))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog name %s: %v", f.Name(), err))
+		}
+
+		data, err := localeFS.ReadFile(path.Join("locales", f.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog %s: %v", f.Name(), err))
+		}
+
+		catalogs[tag] = messages
+		if tag != language.English {
+			supported = append(supported, tag)
+		}
+	}
+
+	matcher = language.NewMatcher(supported)
+}
+
+// Negotiate picks the best supported language for an Accept-Language
+// header, English when nothing matches
+func Negotiate(acceptLanguage string) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return language.English
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return language.English
+	}
+	return supported[index]
+}
+
+// T translates message into lang, returning it unchanged when there is
+// no translation
+func T(lang language.Tag, message string) string {
+	if translated, ok := catalogs[lang][message]; ok {
+		return translated
+	}
+	return message
+}
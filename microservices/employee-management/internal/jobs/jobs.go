@@ -0,0 +1,162 @@
+// Package jobs runs background tasks on a bounded pool of workers, so
+// exports, webhook deliveries and purges share a concurrency limit, are
+// retried when they fail and finish before the process exits
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"employee-management/internal/backoff"
+	"employee-management/internal/metrics"
+)
+
+// Errors of Submit
+var (
+	// ErrQueueFull is returned when every worker is busy and the queue
+	// holds as many tasks as it can
+	ErrQueueFull = errors.New("job queue is full")
+
+	// ErrDraining is returned once Drain was called
+	ErrDraining = errors.New("job pool is draining")
+)
+
+// Task is a unit of background work
+type Task struct {
+	// Name identifies the task in logs and metrics
+	Name string
+
+	// Attempts is how many times the task runs before it is given up,
+	// 0 uses the pool default
+	Attempts int
+
+	// Run does the work. ctx is canceled when draining times out
+	Run func(ctx context.Context) error
+}
+
+// Pool runs submitted tasks on a fixed number of workers, retrying the
+// failed ones with exponential backoff
+type Pool struct {
+	tasks      chan Task
+	attempts   int
+	maxBackoff time.Duration
+
+	// ctx is passed to the tasks and canceled when draining times out
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	workers sync.WaitGroup
+
+	// mu guards draining and sending on tasks, which is closed by Drain
+	mu       sync.RWMutex
+	draining bool
+}
+
+// NewPool starts a pool of workers running the submitted tasks, queueing
+// up to queueSize of them while every worker is busy. A failed task runs
+// up to attempts times, waiting up to maxBackoff between runs
+func NewPool(workers, queueSize, attempts int, maxBackoff time.Duration) *Pool {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		tasks:      make(chan Task, queueSize),
+		attempts:   attempts,
+		maxBackoff: maxBackoff,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+
+	p.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer p.workers.Done()
+			for t := range p.tasks {
+				p.run(t)
+			}
+		}()
+	}
+
+	return p
+}
+
+// Submit queues t, it does not wait for it to run
+func (p *Pool) Submit(t Task) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.draining {
+		return ErrDraining
+	}
+
+	select {
+	case p.tasks <- t:
+		return nil
+	default:
+		metrics.Jobs.WithLabelValues(t.Name, "rejected").Inc()
+		return ErrQueueFull
+	}
+}
+
+// Drain stops accepting tasks and waits for the queued and running ones
+// to finish. When ctx is done first their context is canceled and Drain
+// returns once they returned
+func (p *Pool) Drain(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.draining {
+		p.draining = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// run runs t until it succeeds, its attempts are exhausted or the pool
+// is canceled
+func (p *Pool) run(t Task) {
+	attempts := t.Attempts
+	if attempts <= 0 {
+		attempts = p.attempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := t.Run(p.ctx)
+		if err == nil {
+			metrics.Jobs.WithLabelValues(t.Name, "success").Inc()
+			return
+		}
+
+		if attempt >= attempts || p.ctx.Err() != nil {
+			metrics.Jobs.WithLabelValues(t.Name, "failure").Inc()
+			log.Printf("job %s failed after %d attempts: %v", t.Name, attempt, err)
+			return
+		}
+
+		wait := backoff.Exponential(time.Second, p.maxBackoff, attempt)
+		metrics.Jobs.WithLabelValues(t.Name, "retry").Inc()
+		log.Printf("job %s failed, retrying in %s: %v", t.Name, wait, err)
+
+		select {
+		case <-p.ctx.Done():
+			metrics.Jobs.WithLabelValues(t.Name, "failure").Inc()
+			return
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Package leader elects one instance among those sharing a PostgreSQL
+// database to run the scheduled jobs, using a session advisory lock
+package leader
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sync"
+	"time"
+
+	"employee-management/internal/metrics"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Elector campaigns for the advisory lock of its name. The instance
+// holding it is the leader until its connection drops, when the server
+// releases the lock and another instance takes it on its next attempt
+type Elector struct {
+	pool     *pgxpool.Pool
+	name     string
+	key      int64
+	interval time.Duration
+}
+
+// New creates an Elector for the lock named name, campaigning and
+// checking the held lock every interval
+func New(pool *pgxpool.Pool, name string, interval time.Duration) *Elector {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return &Elector{pool: pool, name: name, key: int64(h.Sum64()), interval: interval}
+}
+
+// Run calls lead whenever this instance becomes the leader, with a
+// context canceled when it stops being one, and campaigns again once lead
+// returns. It returns when ctx is done
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.campaign(ctx, lead); err != nil && ctx.Err() == nil {
+			log.Printf("leader election of %s failed: %v", e.name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// campaign tries to take the lock and, if it does, leads until the
+// connection holding it fails or ctx is done
+func (e *Elector) campaign(ctx context.Context, lead func(ctx context.Context)) error {
+	pooled, err := e.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The lock lives as long as the session, so the connection never goes
+	// back to the pool; closing it releases the lock
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", e.key).Scan(&acquired); err != nil {
+		return err
+	}
+	if !acquired {
+		return nil
+	}
+
+	log.Printf("elected leader of %s", e.name)
+	metrics.Leader.Set(1)
+	defer metrics.Leader.Set(0)
+
+	leadCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		lead(leadCtx)
+	}()
+	// Stop leading before the lock can be taken by another instance
+	defer wg.Wait()
+	defer cancel()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if _, err := conn.Exec(ctx, "SELECT 1"); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("lost leadership: %w", err)
+		}
+	}
+}
+
+// RunAll returns a lead function running every job until its context is
+// done
+func RunAll(jobs ...func(ctx context.Context)) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		var wg sync.WaitGroup
+		for _, job := range jobs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				job(ctx)
+			}()
+		}
+		wg.Wait()
+	}
+}
@@ -0,0 +1,324 @@
+// Package loadgen generates realistic fake employees in bulk, to exercise
+// pagination, filters and indexes at scale in load tests
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"employee-management/internal/models"
+)
+
+// EmailDomain is the domain of every generated email, so generated
+// employees can be found and removed
+const EmailDomain = "loadgen.example.com"
+
+// MaxCount bounds the number of employees a run creates
+const MaxCount = 1000000
+
+// weighted is a value picked with a relative weight
+type weighted[T any] struct {
+	value  T
+	weight int
+}
+
+// pick returns a value of choices at random, by weight
+func pick[T any](r *rand.Rand, choices []weighted[T]) T {
+	total := 0
+	for _, c := range choices {
+		total += c.weight
+	}
+	n := r.Intn(total)
+	for _, c := range choices {
+		if n < c.weight {
+			return c.value
+		}
+		n -= c.weight
+	}
+	return choices[len(choices)-1].value
+}
+
+// department is a department with its positions, the first ones the
+// most common
+type department struct {
+	name      string
+	positions []string
+}
+
+// departments are sized like a mid-sized tech company
+var departments = []weighted[department]{
+	{department{"Engineering", []string{"Software Engineer", "Senior Software Engineer", "QA Engineer", "DevOps Engineer", "Engineering Manager"}}, 35},
+	{department{"Sales", []string{"Sales Representative", "Account Executive", "Sales Manager"}}, 18},
+	{department{"Customer Support", []string{"Support Agent", "Support Team Lead"}}, 15},
+	{department{"Operations", []string{"Operations Analyst", "Logistics Coordinator", "Office Manager"}}, 10},
+	{department{"Marketing", []string{"Marketing Specialist", "Content Writer", "Product Marketing Manager"}}, 8},
+	{department{"Finance", []string{"Accountant", "Financial Analyst", "Payroll Specialist"}}, 7},
+	{department{"Human Resources", []string{"HR Generalist", "Recruiter", "HR Manager"}}, 5},
+	{department{"Legal", []string{"Legal Counsel", "Compliance Officer"}}, 2},
+}
+
+// statuses are weighted as in a steady workforce
+var statuses = []weighted[models.EmployeeStatus]{
+	{models.StatusActive, 85},
+	{models.StatusOnVacation, 7},
+	{models.StatusRetired, 5},
+	{models.StatusProbation, 3},
+}
+
+// city is where an employee lives, with a state and postal code valid
+// for its country
+type city struct {
+	name, state, postalCode, country string
+}
+
+var cities = []weighted[city]{
+	{city{"Bogota", "Bogota D.C.", "110111", "CO"}, 30},
+	{city{"Medellin", "Antioquia", "050001", "CO"}, 15},
+	{city{"Cali", "Valle del Cauca", "760001", "CO"}, 8},
+	{city{"Armenia", "Quindio", "630004", "CO"}, 4},
+	{city{"Mexico City", "CDMX", "06600", "MX"}, 10},
+	{city{"Guadalajara", "Jalisco", "44100", "MX"}, 4},
+	{city{"Austin", "TX", "73301", "US"}, 8},
+	{city{"New York", "NY", "10001", "US"}, 6},
+	{city{"Madrid", "Madrid", "28001", "ES"}, 5},
+	{city{"Lima", "Lima", "15001", "PE"}, 5},
+	{city{"Santiago", "Region Metropolitana", "8320000", "CL"}, 5},
+}
+
+var firstNames = []string{
+	"Ana", "Andres", "Camila", "Carlos", "Daniela", "David", "Diana", "Felipe",
+	"Gabriela", "Juan", "Laura", "Luis", "Maria", "Mateo", "Natalia", "Nicolas",
+	"Paula", "Santiago", "Sofia", "Valentina", "Alejandro", "Isabella", "Sebastian", "Mariana",
+	"Emily", "James", "Olivia", "Michael", "Emma", "William", "Ava", "Benjamin",
+	"Lucia", "Hugo", "Martina", "Pablo", "Chloe", "Ethan", "Mia", "Noah",
+	"Ana Maria", "Juan Pablo", "Maria Jose", "Luis Fernando",
+}
+
+var lastNames = []string{
+	"Garcia", "Rodriguez", "Martinez", "Lopez", "Gonzalez", "Hernandez", "Perez", "Sanchez",
+	"Ramirez", "Torres", "Flores", "Rivera", "Gomez", "Diaz", "Cruz", "Morales",
+	"Ortiz", "Gutierrez", "Castro", "Vargas", "Rojas", "Jimenez", "Moreno", "Herrera",
+	"Smith", "Johnson", "Williams", "Brown", "Jones", "Miller", "Davis", "Wilson",
+	"O'Brien", "Garcia-Lopez", "Fernandez", "Ruiz", "Navarro", "Silva", "Mendoza", "Reyes",
+}
+
+var genders = []weighted[models.Gender]{
+	{models.GenderFemale, 48},
+	{models.GenderMale, 48},
+	{models.GenderNonBinary, 2},
+	{models.GenderUndisclosed, 2},
+}
+
+// Generator makes fake employees. It is not safe for concurrent use
+type Generator struct {
+	rand  *rand.Rand
+	run   string
+	next  int
+	today time.Time
+}
+
+// NewGenerator creates a Generator. The same seed makes the same
+// employees, apart from the run id keeping their numbers and emails
+// unique across runs
+func NewGenerator(seed int64) *Generator {
+	return &Generator{
+		rand:  rand.New(rand.NewSource(seed)),
+		run:   strconv.FormatInt(time.Now().Unix(), 36),
+		today: models.Today().Time(),
+	}
+}
+
+// Next returns a new fake employee
+func (g *Generator) Next() models.Employee {
+	g.next++
+	r := g.rand
+
+	first := firstNames[r.Intn(len(firstNames))]
+	last := lastNames[r.Intn(len(lastNames))]
+	dept := pick(r, departments)
+	// Earlier positions of a department are the more common ones
+	position := dept.positions[r.Intn(len(dept.positions))*r.Intn(2)]
+	home := pick(r, cities)
+	gender := pick(r, genders)
+
+	e := models.Employee{
+		FirstName:      first,
+		LastName:       last,
+		Email:          fmt.Sprintf("%s.%s.%s%d@%s", emailPart(first), emailPart(last), g.run, g.next, EmailDomain),
+		EmployeeNumber: fmt.Sprintf("LOAD-%s-%07d", g.run, g.next),
+		Position:       position,
+		Department:     dept.name,
+		Status:         pick(r, statuses),
+		// Hire dates over the last fifteen years, more of them recent
+		HireDate: models.DateOf(g.today.AddDate(0, 0, -int(5475*r.Float64()*r.Float64()))),
+		Gender:   &gender,
+		Address: &models.Address{
+			Street:     fmt.Sprintf("Calle %d # %d-%d", 1+r.Intn(150), 1+r.Intn(99), 1+r.Intn(99)),
+			City:       home.name,
+			State:      home.state,
+			PostalCode: home.postalCode,
+			Country:    home.country,
+		},
+	}
+
+	// Born 20 to 60 years before today, hired at 18 at the earliest
+	born := g.today.AddDate(-20-r.Intn(40), 0, -r.Intn(365))
+	if adult := born.AddDate(18, 0, 0); e.HireDate.Time().Before(adult) {
+		e.HireDate = models.DateOf(adult)
+	}
+	birthDate := models.DateOf(born)
+	e.DateOfBirth = &birthDate
+
+	if e.Status == models.StatusProbation {
+		end := models.DateOf(g.today.AddDate(0, 0, 1+r.Intn(90)))
+		e.ProbationEndDate = &end
+		e.HireDate = models.DateOf(g.today.AddDate(0, 0, -r.Intn(90)))
+	}
+
+	return e
+}
+
+// emailPart lower-cases a name for an email, dropping its spaces and
+// apostrophes
+func emailPart(name string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "'", "").Replace(name))
+}
+
+// Sink stores the generated employees, a repository or the API
+type Sink interface {
+	Create(ctx context.Context, e *models.Employee) error
+}
+
+// APISink creates employees through the REST API
+type APISink struct {
+	client *http.Client
+	url    string
+}
+
+// NewAPISink creates an APISink posting to the employees collection of
+// the service at baseURL, e.g. http://localhost:8081
+func NewAPISink(baseURL string) *APISink {
+	return &APISink{
+		client: &http.Client{Timeout: 30 * time.Second},
+		url:    strings.TrimSuffix(baseURL, "/") + "/employees-service/api/v1/employees/",
+	}
+}
+
+// Create posts e. The service sets the status, so the employees are
+// ACTIVE or, with a probation end date, on PROBATION
+func (s *APISink) Create(ctx context.Context, e *models.Employee) error {
+	body, err := json.Marshal(models.CreateEmployeeRequest{
+		FirstName:        e.FirstName,
+		LastName:         e.LastName,
+		Email:            e.Email,
+		EmployeeNumber:   e.EmployeeNumber,
+		Position:         e.Position,
+		Department:       e.Department,
+		HireDate:         &e.HireDate,
+		ProbationEndDate: e.ProbationEndDate,
+		DateOfBirth:      e.DateOfBirth,
+		Gender:           e.Gender,
+		Address:          e.Address,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("service answered %s: %s", resp.Status, msg)
+	}
+	return nil
+}
+
+// Result counts what a run did
+type Result struct {
+	Created int
+	Elapsed time.Duration
+}
+
+// Run creates count employees from g in sink with workers concurrent
+// creates, logging progress through progress every 1000 employees. It
+// stops at the first failure
+func Run(ctx context.Context, g *Generator, sink Sink, count, workers int, progress func(created int)) (Result, error) {
+	if count < 1 || count > MaxCount {
+		return Result{}, fmt.Errorf("count must be between 1 and %d", MaxCount)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	start := time.Now()
+	queue := make(chan models.Employee, workers)
+	go func() {
+		defer close(queue)
+		for i := 0; i < count; i++ {
+			select {
+			case queue <- g.Next():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mu       sync.Mutex
+		created  int
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for e := range queue {
+				err := sink.Create(ctx, &e)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("failed to create employee %s: %w", e.EmployeeNumber, err)
+						cancel()
+					}
+				} else {
+					created++
+					if created%1000 == 0 && progress != nil {
+						progress(created)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	result := Result{Created: created, Elapsed: time.Since(start)}
+	if firstErr != nil {
+		return result, firstErr
+	}
+	return result, ctx.Err()
+}
@@ -0,0 +1,111 @@
+// Package metrics defines the Prometheus metrics exposed by the service
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// CircuitState is the repository circuit breaker state
+	// 0 closed, 1 half-open, 2 open
+	CircuitState = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "employee_db_circuit_state",
+		Help: "Repository circuit breaker state (0 closed, 1 half-open, 2 open)",
+	})
+
+	// CircuitTransitions counts circuit breaker state changes
+	CircuitTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "employee_db_circuit_transitions_total",
+		Help: "Repository circuit breaker state transitions",
+	}, []string{"from", "to"})
+
+	// CircuitRejected counts calls rejected while the circuit was open
+	CircuitRejected = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "employee_db_circuit_rejected_total",
+		Help: "Repository calls rejected by the open circuit breaker",
+	})
+)
+
+// CacheRequests counts employee cache lookups by result (hit, miss)
+var CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "employee_cache_requests_total",
+	Help: "Employee cache lookups by result",
+}, []string{"result"})
+
+// CacheStaleHits counts cache hits differing from the db by kind
+// (employee, list), checked only with CACHE_VERIFY
+var CacheStaleHits = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "employee_cache_stale_hits_total",
+	Help: "Employee cache hits differing from the database",
+}, []string{"kind"})
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
+
+// Backups counts employee backups by result (success, failure)
+var Backups = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "employee_backups_total",
+	Help: "Employee backups by result",
+}, []string{"result"})
+
+// BackupLastSuccess is when the last employee backup was written
+var BackupLastSuccess = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "employee_backup_last_success_timestamp_seconds",
+	Help: "Unix time of the last successful employee backup",
+})
+
+// RetentionPurged counts employees purged by the retention policy by
+// action (ANONYMIZE, DELETE)
+var RetentionPurged = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "employee_retention_purged_total",
+	Help: "Employees purged by the retention policy by action",
+}, []string{"action"})
+
+// ProbationsEnded counts employees made ACTIVE by the probation job when
+// their probation lapsed
+var ProbationsEnded = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "employee_probations_ended_total",
+	Help: "Employees made active when their probation lapsed",
+})
+
+// DirectorySyncResults counts the directory entries synced by result
+// (created, updated, unchanged, conflict), dry runs aside
+var DirectorySyncResults = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "employee_directory_sync_results_total",
+	Help: "Directory entries synced by result",
+}, []string{"result"})
+
+// Jobs counts background task runs by task name and result (success,
+// retry, failure, rejected)
+var Jobs = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "employee_jobs_total",
+	Help: "Background task runs by task and result",
+}, []string{"name", "result"})
+
+// Leader is 1 while this instance is the elected leader running the
+// scheduled jobs
+var Leader = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "employee_scheduler_leader",
+	Help: "Whether this instance is the leader running the scheduled jobs",
+})
+
+// RequestDuration is the latency of requests by method, route pattern and
+// status class (2xx, 4xx, ...). The buckets include the usual latency
+// objectives, the SLO counters below are exact for any
+var RequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "employee_http_request_duration_seconds",
+	Help:    "Request latency by method, route and status class",
+	Buckets: []float64{.005, .01, .025, .05, .1, .2, .3, .5, .75, 1, 2.5, 5, 10},
+}, []string{"method", "route", "status"})
+
+// SLORequests counts requests by method, route and result against the
+// route's objective: good when below 5xx and within its latency
+var SLORequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "employee_slo_requests_total",
+	Help: "Requests by route and result (good, bad) against the route's objective",
+}, []string{"method", "route", "result"})
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Descriptions of the connection pool metrics
+var (
+	poolAcquiredDesc = prometheus.NewDesc("employee_db_pool_acquired_conns",
+		"Connections of the db pool in use", nil, nil)
+	poolIdleDesc = prometheus.NewDesc("employee_db_pool_idle_conns",
+		"Idle connections of the db pool", nil, nil)
+	poolTotalDesc = prometheus.NewDesc("employee_db_pool_total_conns",
+		"Connections of the db pool, acquired, idle and being opened", nil, nil)
+	poolMaxDesc = prometheus.NewDesc("employee_db_pool_max_conns",
+		"Maximum connections of the db pool", nil, nil)
+	poolAcquiresDesc = prometheus.NewDesc("employee_db_pool_acquires_total",
+		"Successful connection acquires from the db pool", nil, nil)
+	poolEmptyAcquiresDesc = prometheus.NewDesc("employee_db_pool_empty_acquires_total",
+		"Acquires that waited for a connection because none was idle", nil, nil)
+	poolCanceledAcquiresDesc = prometheus.NewDesc("employee_db_pool_canceled_acquires_total",
+		"Acquires canceled by their context while waiting", nil, nil)
+	poolAcquireWaitDesc = prometheus.NewDesc("employee_db_pool_acquire_wait_seconds_total",
+		"Time spent acquiring connections from the db pool", nil, nil)
+)
+
+// poolCollector reads the pool statistics on every scrape
+type poolCollector struct {
+	pool *pgxpool.Pool
+}
+
+// RegisterPool exports the statistics of the PostgreSQL connection pool
+func RegisterPool(pool *pgxpool.Pool) {
+	prometheus.MustRegister(poolCollector{pool: pool})
+}
+
+// Describe implements prometheus.Collector
+func (p poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolAcquiredDesc
+	ch <- poolIdleDesc
+	ch <- poolTotalDesc
+	ch <- poolMaxDesc
+	ch <- poolAcquiresDesc
+	ch <- poolEmptyAcquiresDesc
+	ch <- poolCanceledAcquiresDesc
+	ch <- poolAcquireWaitDesc
+}
+
+// Collect implements prometheus.Collector
+func (p poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := p.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(poolAcquiredDesc, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(poolIdleDesc, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(poolTotalDesc, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(poolMaxDesc, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquiresDesc, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquiresDesc, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolCanceledAcquiresDesc, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireWaitDesc, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"strings"
+
+	"employee-management/internal/slo"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// sloBurnRateDesc describes the burn rate gauge of each route
+var sloBurnRateDesc = prometheus.NewDesc("employee_slo_burn_rate",
+	"Error budget burn rate of the route over the SLO window, 1 spends it exactly",
+	[]string{"method", "route"}, nil)
+
+// sloCollector reads the burn rates on every scrape
+type sloCollector struct {
+	tracker *slo.Tracker
+}
+
+// RegisterSLO exports the burn rates of the routes tracked by tracker
+func RegisterSLO(tracker *slo.Tracker) {
+	prometheus.MustRegister(sloCollector{tracker: tracker})
+}
+
+// Describe implements prometheus.Collector
+func (s sloCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sloBurnRateDesc
+}
+
+// Collect implements prometheus.Collector
+func (s sloCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, r := range s.tracker.Report() {
+		method, route, _ := strings.Cut(r.Route, " ")
+		ch <- prometheus.MustNewConstMetric(sloBurnRateDesc, prometheus.GaugeValue, r.BurnRate, method, route)
+	}
+}
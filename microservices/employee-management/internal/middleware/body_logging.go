@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+
+	"employee-management/internal/api"
+
+	"github.com/gin-gonic/gin"
+)
+
+// redactedValue replaces the values of redacted fields
+const redactedValue = "[REDACTED]"
+
+// BodyLogging logs the body of every request and response for debugging,
+// the values of JSON fields and query parameters named in fields, matched
+// case insensitively, replaced by [REDACTED]. A redacted object or array is
+// hidden whole. Bodies that are not JSON are logged by size only and
+// logged bodies are cut at maxBytes. Streams and upgrades are skipped.
+// Mount it before Recovery and ErrorHandler to see the responses they write
+func BodyLogging(fields []string, maxBytes int) gin.HandlerFunc {
+	redact := map[string]bool{}
+	for _, f := range fields {
+		redact[strings.ToLower(f)] = true
+	}
+
+	return func(c *gin.Context) {
+		if c.GetHeader("Upgrade") != "" || strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+			c.Next()
+			return
+		}
+
+		var request []byte
+		if c.Request.Body != nil {
+			var err error
+			if request, err = io.ReadAll(c.Request.Body); err != nil {
+				log.Printf("[%s] body logging failed to read the request: %v", c.GetString(api.RequestIDKey), err)
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(request))
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		log.Printf("[%s] %s %s request=%s response=%d %s",
+			c.GetString(api.RequestIDKey),
+			c.Request.Method,
+			redactQuery(c.Request.URL, redact),
+			redactBody(request, c.ContentType(), redact, maxBytes),
+			recorder.Status(),
+			redactBody(recorder.body.Bytes(), recorder.Header().Get("Content-Type"), redact, maxBytes),
+		)
+	}
+}
+
+// redactQuery returns the path and query of u with the redacted
+// parameters replaced
+func redactQuery(u *url.URL, redact map[string]bool) string {
+	query := u.Query()
+	if len(query) == 0 {
+		return u.Path
+	}
+	for name, values := range query {
+		if redact[strings.ToLower(name)] {
+			for i := range values {
+				values[i] = redactedValue
+			}
+		}
+	}
+	return u.Path + "?" + query.Encode()
+}
+
+// redactBody returns a JSON body with the redacted fields replaced, cut at
+// maxBytes, or a placeholder with the size of any other body
+func redactBody(body []byte, contentType string, redact map[string]bool, maxBytes int) string {
+	if len(body) == 0 {
+		return "-"
+	}
+	if !strings.Contains(contentType, "json") {
+		return fmt.Sprintf("<%d bytes of %s>", len(body), cmp.Or(contentType, "unknown type"))
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("<%d bytes of invalid JSON>", len(body))
+	}
+	out, _ := json.Marshal(redactValue(value, redact))
+	if len(out) > maxBytes {
+		return string(out[:maxBytes]) + "...(truncated)"
+	}
+	return string(out)
+}
+
+// redactValue walks a decoded JSON value replacing the redacted fields
+func redactValue(value any, redact map[string]bool) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if redact[strings.ToLower(key)] {
+				if field != nil {
+					v[key] = redactedValue
+				}
+				continue
+			}
+			v[key] = redactValue(field, redact)
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactValue(v[i], redact)
+		}
+	}
+	return value
+}
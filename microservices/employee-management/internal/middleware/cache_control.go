@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControl sets Cache-Control on successful and not modified GET
+// responses: private,
+// as they hold personal data, with a max-age of maxAge or of the
+// override keyed by "METHOD /route/pattern". A max-age of 0 sends
+// no-cache, so caches revalidate every time. Handlers setting
+// Cache-Control themselves keep theirs
+func CacheControl(maxAge time.Duration, overrides map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		age := maxAge
+		if d, ok := overrides[c.Request.Method+" "+c.FullPath()]; ok {
+			age = d
+		}
+
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, value: cacheControlValue(age)}
+		c.Next()
+	}
+}
+
+// cacheControlValue is the Cache-Control header of a max-age
+func cacheControlValue(maxAge time.Duration) string {
+	if maxAge <= 0 {
+		return "private, no-cache"
+	}
+	return "private, max-age=" + strconv.Itoa(int(maxAge/time.Second))
+}
+
+// cacheControlWriter adds Cache-Control when a 200 or 304 response is
+// written
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	value string
+}
+
+// setHeader adds the header before it is sent, for 200 and 304 responses
+// without one of their own
+func (w *cacheControlWriter) setHeader() {
+	status := w.Status()
+	if w.Written() || (status != http.StatusOK && status != http.StatusNotModified) || w.Header().Get("Cache-Control") != "" {
+		return
+	}
+	w.Header().Set("Cache-Control", w.value)
+}
+
+func (w *cacheControlWriter) WriteHeaderNow() {
+	w.setHeader()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *cacheControlWriter) Write(data []byte) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheControlWriter) WriteString(s string) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.WriteString(s)
+}
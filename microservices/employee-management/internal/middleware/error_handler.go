@@ -7,9 +7,12 @@ import (
 
 	"employee-management/internal/api"
 
+	"github.com/getsentry/sentry-go"
 	"github.com/gin-gonic/gin"
 )
 
+// ErrorHandler logs the errors handlers recorded in c.Errors, e.g. the
+// cause of a 500, and answers 500 when no response was written
 func ErrorHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
@@ -17,6 +20,10 @@ func ErrorHandler() gin.HandlerFunc {
 		// Verify unhandled errors
 		if len(c.Errors) > 0 {
 			err := c.Errors.Last()
+			if c.Writer.Written() {
+				log.Printf("%s %s failed with %d (request %s): %v", c.Request.Method, c.Request.URL.Path, c.Writer.Status(), c.GetString(api.RequestIDKey), err)
+				return
+			}
 			log.Printf("unhandled error %v", err)
 
 			api.Error(c, http.StatusInternalServerError, "Internal server error")
@@ -27,11 +34,17 @@ func ErrorHandler() gin.HandlerFunc {
 	}
 }
 
+// Recovery answers 500 to requests whose handler panicked, reporting the
+// panic with its stack trace when ErrorReporting bound a Sentry hub
 func Recovery() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
 				log.Printf("Panic recovered: %v", err)
+				if hub := sentry.GetHubFromContext(c.Request.Context()); hub != nil {
+					hub.RecoverWithContext(c.Request.Context(), err)
+					c.Set(panicReportedKey, true)
+				}
 				api.Error(c, http.StatusInternalServerError, "Internal server error")
 				c.Abort()
 			}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"employee-management/internal/api"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gin-gonic/gin"
+)
+
+// panicReportedKey marks requests whose panic Recovery already reported
+const panicReportedKey = "panicReported"
+
+// ErrorReporting binds a Sentry hub scoped to the request (method, url,
+// headers without credentials, request id) and reports 5xx responses
+// with the error the handler recorded in c.Errors. 503s are expected
+// while the database is down or the instance drains and are left to the
+// health checks. Mount it after RequestID and before Recovery
+func ErrorReporting() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		hub := sentry.CurrentHub().Clone()
+		hub.Scope().SetRequest(c.Request)
+		hub.Scope().SetTag("request_id", c.GetString(api.RequestIDKey))
+		c.Request = c.Request.WithContext(sentry.SetHubOnContext(c.Request.Context(), hub))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if status < http.StatusInternalServerError || status == http.StatusServiceUnavailable || c.GetBool(panicReportedKey) {
+			return
+		}
+
+		hub.WithScope(func(scope *sentry.Scope) {
+			scope.SetTag("route", c.FullPath())
+			scope.SetTag("status", strconv.Itoa(status))
+			if len(c.Errors) > 0 {
+				hub.CaptureException(c.Errors.Last().Err)
+				return
+			}
+			hub.CaptureMessage(fmt.Sprintf("%s %s answered %d", c.Request.Method, c.FullPath(), status))
+		})
+	}
+}
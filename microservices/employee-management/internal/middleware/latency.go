@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"employee-management/internal/metrics"
+	"employee-management/internal/slo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Latency records the duration of every request in the latency histogram
+// and counts it against the objective of its route in tracker. Routes in
+// skip, e.g. streams, and requests matching no route are only timed
+func Latency(tracker *slo.Tracker, skip []string) gin.HandlerFunc {
+	skipped := map[string]bool{}
+	for _, route := range skip {
+		skipped[route] = true
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		took := time.Since(start)
+
+		status := c.Writer.Status()
+		path := c.FullPath()
+		if path == "" {
+			path = "unmatched"
+		}
+		metrics.RequestDuration.
+			WithLabelValues(c.Request.Method, path, strconv.Itoa(status/100)+"xx").
+			Observe(took.Seconds())
+
+		route := c.Request.Method + " " + c.FullPath()
+		if c.FullPath() == "" || skipped[route] {
+			return
+		}
+		result := "bad"
+		if tracker.Observe(route, status, took) {
+			result = "good"
+		}
+		metrics.SLORequests.WithLabelValues(c.Request.Method, path, result).Inc()
+	}
+}
@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"employee-management/internal/api"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPI validates requests against the generated Swagger 2.0 spec and
+// rejects the ones that do not match with a structured 400. With
+// validateResponses, JSON responses are checked too and mismatches are
+// logged, meant for development. Routes missing from the spec (metrics,
+// swagger UI) pass through untouched. The spec is mounted under every
+// path in basePaths
+func OpenAPI(spec []byte, basePaths []string, validateResponses bool) (gin.HandlerFunc, error) {
+	var doc2 openapi2.T
+	if err := json.Unmarshal(spec, &doc2); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	doc, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert OpenAPI spec: %w", err)
+	}
+
+	// Match on the path only, whatever host the service is reached on
+	doc.Servers = nil
+	for _, p := range basePaths {
+		doc.Servers = append(doc.Servers, &openapi3.Server{URL: p})
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI router: %w", err)
+	}
+
+	options := &openapi3filter.Options{
+		MultiError:          true,
+		SkipSettingDefaults: true,
+		AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(c *gin.Context) {
+		route, params, err := findRoute(router, c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: params,
+			Route:      route,
+			Options:    options,
+		}
+
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			api.ValidationError(c, http.StatusBadRequest, "Request does not match the API specification", specErrorDetails(err))
+			c.Abort()
+			return
+		}
+
+		// Streams and upgrades cannot be buffered
+		if !validateResponses || c.GetHeader("Upgrade") != "" || strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+			c.Next()
+			return
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Enveloped bodies are not what the spec describes
+		if !strings.HasPrefix(recorder.Header().Get("Content-Type"), gin.MIMEJSON) || c.GetBool(api.EnvelopedKey) {
+			return
+		}
+
+		out := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 recorder.Status(),
+			Header:                 recorder.Header(),
+			Options:                options,
+		}
+		out.SetBodyBytes(recorder.body.Bytes())
+
+		if err := openapi3filter.ValidateResponse(c.Request.Context(), out); err != nil {
+			log.Printf("response to %s %s does not match the API specification: %v", c.Request.Method, c.Request.URL.Path, err)
+		}
+	}, nil
+}
+
+// findRoute looks up the spec operation, ignoring the trailing slash gin
+// routes use for collections
+func findRoute(router routers.Router, req *http.Request) (*routers.Route, map[string]string, error) {
+	if path := req.URL.Path; len(path) > 1 && strings.HasSuffix(path, "/") {
+		clone := *req
+		u := *req.URL
+		u.Path = strings.TrimSuffix(path, "/")
+		u.RawPath = ""
+		clone.URL = &u
+		req = &clone
+	}
+	return router.FindRoute(req)
+}
+
+// specErrorDetails flattens validation errors into error details
+func specErrorDetails(err error) []api.ErrorDetail {
+	var multi openapi3.MultiError
+	if !errors.As(err, &multi) {
+		multi = openapi3.MultiError{err}
+	}
+
+	details := []api.ErrorDetail{}
+	for _, e := range multi {
+		detail := api.ErrorDetail{Message: e.Error()}
+
+		var reqErr *openapi3filter.RequestError
+		if errors.As(e, &reqErr) {
+			switch {
+			case reqErr.Parameter != nil:
+				detail.Field = reqErr.Parameter.Name
+			case reqErr.RequestBody != nil:
+				detail.Field = "body"
+			}
+			detail.Message = reqErr.Reason
+		}
+
+		var schemaErr *openapi3.SchemaError
+		if errors.As(e, &schemaErr) {
+			if pointer := schemaErr.JSONPointer(); len(pointer) > 0 {
+				if detail.Field == "body" {
+					detail.Field = strings.Join(pointer, ".")
+				} else {
+					detail.Field += "." + strings.Join(pointer, ".")
+				}
+			}
+			detail.Message = schemaErr.Reason
+		}
+
+		if detail.Message == "" {
+			detail.Message = e.Error()
+		}
+		details = append(details, detail)
+	}
+
+	return details
+}
+
+// bodyRecorder keeps a copy of the response body for validation
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"employee-management/internal/api"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxRequestIDLength bounds the X-Request-ID accepted from clients
+const maxRequestIDLength = 128
+
+// RequestID tags requests with the X-Request-ID sent by the client or
+// proxy, or a new uuid, and echoes it in the response header
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader("X-Request-ID")
+		if id == "" || len(id) > maxRequestIDLength || !printable(id) {
+			id = uuid.NewString()
+		}
+		c.Set(api.RequestIDKey, id)
+		c.Header("X-Request-ID", id)
+		c.Next()
+	}
+}
+
+// printable reports whether s holds only visible ASCII characters
+func printable(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x21 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout attaches a deadline to the request context so handlers and db
+// calls give up instead of hanging. The deadline is looked up by
+// "METHOD /route/pattern" in overrides and falls back to defaultTimeout
+// A zero timeout leaves the request without a deadline
+func Timeout(defaultTimeout time.Duration, overrides map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := defaultTimeout
+		if d, ok := overrides[c.Request.Method+" "+c.FullPath()]; ok {
+			timeout = d
+		}
+
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// VersionKey is the context key holding the API version of the request
+const VersionKey = "apiVersion"
+
+// APIVersion tags requests with the API version they were routed to and
+// echoes it in the API-Version response header
+func APIVersion(version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(VersionKey, version)
+		c.Header("API-Version", version)
+		c.Next()
+	}
+}
+
+// Deprecated marks responses of a deprecated route group and points
+// clients at the path that replaces it
+func Deprecated(successor string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		c.Header("Link", "<"+successor+">; rel=\"successor-version\"")
+		c.Next()
+	}
+}
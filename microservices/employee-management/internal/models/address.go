@@ -0,0 +1,27 @@
+package models
+
+import "strings"
+
+// Address is the postal address of an employee. Country is an ISO 3166-1
+// alpha-2 code, which decides how the state and postal code are checked
+type Address struct {
+	Street     string `json:"street" xml:"street" binding:"notblank,max=255" example:"Carrera 15 # 12N-45"`
+	City       string `json:"city" xml:"city" binding:"notblank,max=100" example:"Armenia"`
+	State      string `json:"state,omitempty" xml:"state,omitempty" binding:"max=100" example:"Quindio"`
+	PostalCode string `json:"postalCode,omitempty" xml:"postalCode,omitempty" example:"630004"`
+	Country    string `json:"country" xml:"country" binding:"iso_country" example:"CO"`
+}
+
+// Normalize trims the fields and upper-cases the country and postal code
+func (a *Address) Normalize() {
+	a.Street = strings.TrimSpace(a.Street)
+	a.City = strings.TrimSpace(a.City)
+	a.State = strings.TrimSpace(a.State)
+	a.PostalCode = strings.ToUpper(strings.TrimSpace(a.PostalCode))
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+}
+
+// IsZero reports whether no field of the address is set
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
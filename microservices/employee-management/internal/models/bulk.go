@@ -0,0 +1,128 @@
+package models
+
+import "strings"
+
+// Outcomes of a bulk update for one employee
+const (
+	BulkUpdated   = "updated"
+	BulkUnchanged = "unchanged"
+	BulkSkipped   = "skipped"
+)
+
+// BulkFilter selects the employees of a bulk update, as the filters of
+// the employee list do. At least one field must be set
+type BulkFilter struct {
+	Department string         `json:"department" example:"Sales"`
+	Status     EmployeeStatus `json:"status" binding:"omitempty,employee_status" enums:"ACTIVE,ON_VACATION,RETIRED,PROBATION"`
+	Position   string         `json:"position"`
+	Country    string         `json:"country" binding:"omitempty,len=2" example:"CO"`
+	City       string         `json:"city"`
+}
+
+// IsEmpty reports whether f sets no field, matching every employee
+func (f BulkFilter) IsEmpty() bool {
+	return f == BulkFilter{}
+}
+
+// Filters returns the repository filters of f
+func (f BulkFilter) Filters() map[string]interface{} {
+	filters := map[string]interface{}{}
+	if f.Department != "" {
+		filters["department"] = f.Department
+	}
+	if f.Status != "" {
+		filters["status"] = string(f.Status)
+	}
+	if f.Position != "" {
+		filters["position"] = f.Position
+	}
+	if f.Country != "" {
+		filters["country"] = strings.ToUpper(f.Country)
+	}
+	if f.City != "" {
+		filters["city"] = f.City
+	}
+	return filters
+}
+
+// BulkChanges are the fields a bulk update sets on every matched
+// employee, empty fields are left as they are. At least one field must
+// be set
+type BulkChanges struct {
+	Department string `json:"department" binding:"omitempty,notblank" example:"Engineering"`
+	Position   string `json:"position" binding:"omitempty,notblank"`
+}
+
+// IsEmpty reports whether c sets no field
+func (c BulkChanges) IsEmpty() bool {
+	return c == BulkChanges{}
+}
+
+// Apply sets the changes on e and returns the fields it changed, none
+// when e already had the values
+func (c BulkChanges) Apply(e *Employee) []FieldChange {
+	var changes []FieldChange
+	if c.Department != "" && c.Department != e.Department {
+		changes = append(changes, FieldChange{Field: "department", From: e.Department, To: c.Department})
+		e.Department = c.Department
+	}
+	if c.Position != "" && c.Position != e.Position {
+		changes = append(changes, FieldChange{Field: "position", From: e.Position, To: c.Position})
+		e.Position = c.Position
+	}
+	return changes
+}
+
+// FieldChange is a field a bulk update changes with its old and new value
+type FieldChange struct {
+	Field string `json:"field" example:"department"`
+	From  string `json:"from" example:"Sales"`
+	To    string `json:"to" example:"Engineering"`
+}
+
+// BulkUpdateRequest sets the changes on every employee matching the filter
+type BulkUpdateRequest struct {
+	Filter BulkFilter  `json:"filter"`
+	Set    BulkChanges `json:"set"`
+}
+
+// BulkResult is the outcome of a bulk update for one employee
+type BulkResult struct {
+	ID     int64  `json:"id"`
+	UUID   string `json:"uuid" example:"0b6f6a3e-5d1c-4f7e-9a51-3c2f8d9e4b10"`
+	Result string `json:"result" enums:"updated,unchanged,skipped"`
+
+	// Changes are the fields updated, or that would be in a dry run
+	Changes []FieldChange `json:"changes,omitempty"`
+
+	// Reason says why the employee was skipped
+	Reason string `json:"reason,omitempty" example:"Retired employees cannot be edited"`
+}
+
+// BulkUpdateResponse reports a bulk update, one result per matched
+// employee. In a dry run nothing was changed, the results are what the
+// update would do
+type BulkUpdateResponse struct {
+	DryRun    bool         `json:"dryRun"`
+	Matched   int          `json:"matched"`
+	Updated   int          `json:"updated"`
+	Unchanged int          `json:"unchanged"`
+	Skipped   int          `json:"skipped"`
+	Results   []BulkResult `json:"results"`
+}
+
+// NewBulkUpdateResponse counts results into a response
+func NewBulkUpdateResponse(results []BulkResult, dryRun bool) BulkUpdateResponse {
+	response := BulkUpdateResponse{DryRun: dryRun, Matched: len(results), Results: results}
+	for _, r := range results {
+		switch r.Result {
+		case BulkUpdated:
+			response.Updated++
+		case BulkUnchanged:
+			response.Unchanged++
+		case BulkSkipped:
+			response.Skipped++
+		}
+	}
+	return response
+}
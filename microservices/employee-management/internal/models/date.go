@@ -0,0 +1,155 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateLayout is the wire format of a Date
+const DateLayout = "2006-01-02"
+
+// Location is the organization's canonical time zone. Instants (RFC3339
+// input, now) are turned into calendar dates in this zone. Set it once at
+// startup
+var Location = time.UTC
+
+// Date is a calendar date without time of day, like a hire date
+// It is stored as DATE and serialized as YYYY-MM-DD, so the day never
+// shifts with the zone of the server or the client
+type Date struct {
+	t time.Time // midnight UTC
+}
+
+// NewDate returns the date of year, month and day
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{t: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf returns the calendar date of the instant t in Location
+func DateOf(t time.Time) Date {
+	y, m, d := t.In(Location).Date()
+	return NewDate(y, m, d)
+}
+
+// Today returns the current date in Location
+func Today() Date {
+	return DateOf(time.Now())
+}
+
+// ParseDate accepts a date (2024-03-01) or an RFC3339 timestamp with an
+// explicit offset, which is converted to its date in Location
+func ParseDate(s string) (Date, error) {
+	if t, err := time.Parse(DateLayout, s); err == nil {
+		return NewDate(t.Date()), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD or RFC3339", s)
+	}
+	return DateOf(t), nil
+}
+
+// Time returns the date as midnight UTC
+func (d Date) Time() time.Time { return d.t }
+
+// IsZero reports whether the date is unset
+func (d Date) IsZero() bool { return d.t.IsZero() }
+
+// Before reports whether d is before other
+func (d Date) Before(other Date) bool { return d.t.Before(other.t) }
+
+// After reports whether d is after other
+func (d Date) After(other Date) bool { return d.t.After(other.t) }
+
+// AddDays returns the date n days after d, before it when n is negative
+func (d Date) AddDays(n int) Date { return NewDate(d.t.AddDate(0, 0, n).Date()) }
+
+// Anniversary returns the anniversary of d in year. Dates on February 29
+// fall on February 28 in common years
+func (d Date) Anniversary(year int) Date {
+	_, month, day := d.t.Date()
+	if month == time.February && day == 29 && !isLeap(year) {
+		day = 28
+	}
+	return NewDate(year, month, day)
+}
+
+// isLeap reports whether year has a February 29
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.t.Format(DateLayout)
+}
+
+// MarshalText implements encoding.TextMarshaler, used by JSON and XML
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (d *Date) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*d = Date{}
+		return nil
+	}
+
+	parsed, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON renders the zero date as null
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts null, a date or an RFC3339 timestamp
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = Date{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid date: %w", err)
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner for DATE columns
+func (d *Date) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Date{}
+	case time.Time:
+		*d = NewDate(v.Date())
+	case string:
+		return d.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("cannot scan %T into Date", src)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.t, nil
+}
@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// Outcomes of a directory sync for one directory entry
+const (
+	DirectoryCreated   = "created"
+	DirectoryUpdated   = "updated"
+	DirectoryUnchanged = "unchanged"
+	DirectoryConflict  = "conflict"
+)
+
+// DirectoryResult is the outcome of a directory sync for one entry
+type DirectoryResult struct {
+	// DN is the distinguished name of the directory entry
+	DN             string `json:"dn" example:"uid=jdoe,ou=people,dc=example,dc=com"`
+	EmployeeNumber string `json:"employeeNumber"`
+	// ID is the employee, 0 when none was created or matched
+	ID     int64  `json:"id,omitempty"`
+	Result string `json:"result" enums:"created,updated,unchanged,conflict"`
+
+	// Changes are the fields taken from the directory, or that would be
+	// in a dry run
+	Changes []FieldChange `json:"changes,omitempty"`
+
+	// Conflicts are the fields edited in the service since the last sync
+	// that the directory disagrees with, left as they are: From is the
+	// value of the service, To the value of the directory
+	Conflicts []FieldChange `json:"conflicts,omitempty"`
+
+	// Reason says why the entry is a conflict
+	Reason string `json:"reason,omitempty" example:"Email already belongs to another employee"`
+}
+
+// DirectorySyncReport reports a directory sync, one result per directory
+// entry. In a dry run nothing was changed, the results are what the sync
+// would do
+type DirectorySyncReport struct {
+	DryRun     bool      `json:"dryRun"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+
+	Entries   int `json:"entries"`
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Conflicts int `json:"conflicts"`
+
+	// Missing are the employee numbers of the employees, retired ones
+	// aside, not found in the directory. They are left as they are
+	Missing []string `json:"missing"`
+
+	Results []DirectoryResult `json:"results"`
+
+	// Error is why the sync stopped before its end, the results up to it
+	// are kept
+	Error string `json:"error,omitempty"`
+}
+
+// Count counts the results into r
+func (r *DirectorySyncReport) Count() {
+	r.Entries = len(r.Results)
+	r.Created, r.Updated, r.Unchanged, r.Conflicts = 0, 0, 0, 0
+	for _, result := range r.Results {
+		switch result.Result {
+		case DirectoryCreated:
+			r.Created++
+		case DirectoryUpdated:
+			r.Updated++
+		case DirectoryUnchanged:
+			r.Unchanged++
+		case DirectoryConflict:
+			r.Conflicts++
+		}
+	}
+}
@@ -1,7 +1,10 @@
 // Package models define the core data structures for the employee management
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // EmployeeStatus represents the current employment status
 type EmployeeStatus string
@@ -10,20 +13,106 @@ const (
 	StatusActive     EmployeeStatus = "ACTIVE"
 	StatusOnVacation EmployeeStatus = "ON_VACATION"
 	StatusRetired    EmployeeStatus = "RETIRED"
+
+	// StatusProbation is a new hire until their probation end date
+	// lapses, when they become ACTIVE
+	StatusProbation EmployeeStatus = "PROBATION"
+)
+
+// Valid reports whether s is a known status
+func (s EmployeeStatus) Valid() bool {
+	switch s {
+	case StatusActive, StatusOnVacation, StatusRetired, StatusProbation:
+		return true
+	}
+	return false
+}
+
+// IDFormat is how the API addresses employees
+type IDFormat string
+
+const (
+	// IDFormatInt addresses employees by their sequential id
+	IDFormatInt IDFormat = "int"
+	// IDFormatUUID addresses employees by their random uuid, so ids
+	// cannot be guessed or enumerated
+	IDFormatUUID IDFormat = "uuid"
+)
+
+// Gender is the gender an employee chose to record
+type Gender string
+
+const (
+	GenderFemale      Gender = "FEMALE"
+	GenderMale        Gender = "MALE"
+	GenderNonBinary   Gender = "NON_BINARY"
+	GenderUndisclosed Gender = "UNDISCLOSED"
 )
 
+// Valid reports whether g is a known gender
+func (g Gender) Valid() bool {
+	switch g {
+	case GenderFemale, GenderMale, GenderNonBinary, GenderUndisclosed:
+		return true
+	}
+	return false
+}
+
 // Employee represents an employee record in the system
 // All fields are tagged for JSON serialization
+// The personal profile fields are optional and null when not recorded, the
+// probation end date too for employees hired without probation, and the
+// email verification time until the current email is verified
 type Employee struct {
-	ID             int64          `json:"id"`
-	FirstName      string         `json:"firstName"`
-	LastName       string         `json:"lastName"`
-	Email          string         `json:"email"`
-	EmployeeNumber string         `json:"employeeNumber"`
-	Position       string         `json:"position"`
-	Department     string         `json:"department"`
-	Status         EmployeeStatus `json:"status"`
-	HireDate       time.Time      `json:"hireDate"`
-	CreatedAt      time.Time      `json:"createdAt"`
-	UpdatedAt      time.Time      `json:"updatedAt"`
+	ID               int64          `json:"id" xml:"id"`
+	UUID             string         `json:"uuid" xml:"uuid" example:"0b6f6a3e-5d1c-4f7e-9a51-3c2f8d9e4b10"`
+	FirstName        string         `json:"firstName" xml:"firstName"`
+	LastName         string         `json:"lastName" xml:"lastName"`
+	Email            string         `json:"email" xml:"email"`
+	EmployeeNumber   string         `json:"employeeNumber" xml:"employeeNumber"`
+	Position         string         `json:"position" xml:"position"`
+	Department       string         `json:"department" xml:"department"`
+	Status           EmployeeStatus `json:"status" xml:"status" enums:"ACTIVE,ON_VACATION,RETIRED,PROBATION"`
+	HireDate         Date           `json:"hireDate" xml:"hireDate" swaggertype:"string" example:"2024-03-01"`
+	ProbationEndDate *Date          `json:"probationEndDate" xml:"probationEndDate,omitempty" swaggertype:"string" example:"2024-06-01" extensions:"x-nullable"`
+	Phone            *string        `json:"phone" xml:"phone,omitempty" example:"+573001234567" extensions:"x-nullable"`
+	DateOfBirth      *Date          `json:"dateOfBirth" xml:"dateOfBirth,omitempty" swaggertype:"string" example:"1990-05-17" extensions:"x-nullable"`
+	NationalID       *string        `json:"nationalId" xml:"nationalId,omitempty" example:"1094123456" extensions:"x-nullable"`
+	Gender           *Gender        `json:"gender" xml:"gender,omitempty" enums:"FEMALE,MALE,NON_BINARY,UNDISCLOSED" extensions:"x-nullable"`
+	PersonalEmail    *string        `json:"personalEmail" xml:"personalEmail,omitempty" example:"jane.doe@gmail.com" extensions:"x-nullable"`
+	Address          *Address       `json:"address" xml:"address,omitempty" extensions:"x-nullable"`
+	EmailVerifiedAt  *time.Time     `json:"emailVerifiedAt" xml:"emailVerifiedAt,omitempty" extensions:"x-nullable"`
+	CreatedAt        time.Time      `json:"createdAt" xml:"createdAt"`
+	UpdatedAt        time.Time      `json:"updatedAt" xml:"updatedAt"`
+}
+
+// NormalizeProfile trims the optional profile fields and the address and
+// clears the ones left blank
+func (e *Employee) NormalizeProfile() {
+	for _, s := range []**string{&e.Phone, &e.NationalID, &e.PersonalEmail} {
+		if *s == nil {
+			continue
+		}
+		if v := strings.TrimSpace(**s); v != "" {
+			*s = &v
+		} else {
+			*s = nil
+		}
+	}
+	if e.Gender != nil && strings.TrimSpace(string(*e.Gender)) == "" {
+		e.Gender = nil
+	}
+	if e.Address != nil {
+		e.Address.Normalize()
+		if e.Address.IsZero() {
+			e.Address = nil
+		}
+	}
+}
+
+// VersionedEmployee is an employee with the version of its latest event,
+// as listed in snapshots for consumers building their own copy
+type VersionedEmployee struct {
+	Employee
+	Version int64 `json:"version" xml:"version" example:"3"`
 }
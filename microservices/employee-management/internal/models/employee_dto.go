@@ -0,0 +1,190 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// CreateEmployeeRequest is the payload creating an employee. The id,
+// uuid, status and timestamps are set by the service, the hire date too
+// when omitted. A probation end date hires the employee on PROBATION
+type CreateEmployeeRequest struct {
+	FirstName        string   `json:"firstName" binding:"notblank,person_name"`
+	LastName         string   `json:"lastName" binding:"notblank,person_name"`
+	Email            string   `json:"email" binding:"required,email_address"`
+	EmployeeNumber   string   `json:"employeeNumber" binding:"required,employee_number"`
+	Position         string   `json:"position"`
+	Department       string   `json:"department"`
+	HireDate         *Date    `json:"hireDate" binding:"omitempty,hire_date" swaggertype:"string" example:"2024-03-01" extensions:"x-nullable"`
+	ProbationEndDate *Date    `json:"probationEndDate" binding:"omitempty,probation_end_date" swaggertype:"string" example:"2024-06-01" extensions:"x-nullable"`
+	Phone            *string  `json:"phone" binding:"omitempty,phone" example:"+57 300 123 4567" extensions:"x-nullable"`
+	DateOfBirth      *Date    `json:"dateOfBirth" binding:"omitempty,date_of_birth" swaggertype:"string" example:"1990-05-17" extensions:"x-nullable"`
+	NationalID       *string  `json:"nationalId" binding:"omitempty,national_id" example:"1094123456" extensions:"x-nullable"`
+	Gender           *Gender  `json:"gender" binding:"omitempty,gender" enums:"FEMALE,MALE,NON_BINARY,UNDISCLOSED" extensions:"x-nullable"`
+	PersonalEmail    *string  `json:"personalEmail" binding:"omitempty,max=255,personal_email" example:"jane.doe@gmail.com" extensions:"x-nullable"`
+	Address          *Address `json:"address" extensions:"x-nullable"`
+}
+
+// Employee returns the employee the request creates, its profile
+// normalized
+func (r *CreateEmployeeRequest) Employee() *Employee {
+	e := &Employee{
+		FirstName:        r.FirstName,
+		LastName:         r.LastName,
+		Email:            r.Email,
+		EmployeeNumber:   r.EmployeeNumber,
+		Position:         r.Position,
+		Department:       r.Department,
+		Phone:            r.Phone,
+		DateOfBirth:      r.DateOfBirth,
+		NationalID:       r.NationalID,
+		Gender:           r.Gender,
+		PersonalEmail:    r.PersonalEmail,
+		Address:          r.Address,
+		ProbationEndDate: r.ProbationEndDate,
+	}
+	if r.HireDate != nil {
+		e.HireDate = *r.HireDate
+	}
+	e.NormalizeProfile()
+	return e
+}
+
+// UpdateEmployeeRequest is the payload replacing an employee. Unlike
+// creation it carries the status; the hire and probation end dates and
+// the timestamps are kept
+type UpdateEmployeeRequest struct {
+	FirstName      string         `json:"firstName" binding:"notblank,person_name"`
+	LastName       string         `json:"lastName" binding:"notblank,person_name"`
+	Email          string         `json:"email" binding:"required,email_address"`
+	EmployeeNumber string         `json:"employeeNumber" binding:"required,employee_number"`
+	Position       string         `json:"position"`
+	Department     string         `json:"department"`
+	Status         EmployeeStatus `json:"status" binding:"employee_status" enums:"ACTIVE,ON_VACATION,RETIRED,PROBATION"`
+	Phone          *string        `json:"phone" binding:"omitempty,phone" example:"+57 300 123 4567" extensions:"x-nullable"`
+	DateOfBirth    *Date          `json:"dateOfBirth" binding:"omitempty,date_of_birth" swaggertype:"string" example:"1990-05-17" extensions:"x-nullable"`
+	NationalID     *string        `json:"nationalId" binding:"omitempty,national_id" example:"1094123456" extensions:"x-nullable"`
+	Gender         *Gender        `json:"gender" binding:"omitempty,gender" enums:"FEMALE,MALE,NON_BINARY,UNDISCLOSED" extensions:"x-nullable"`
+	PersonalEmail  *string        `json:"personalEmail" binding:"omitempty,max=255,personal_email" example:"jane.doe@gmail.com" extensions:"x-nullable"`
+	Address        *Address       `json:"address" extensions:"x-nullable"`
+}
+
+// NewUpdateEmployeeRequest returns the update request that leaves e as it
+// is, the document patches of e are applied to
+func NewUpdateEmployeeRequest(e *Employee) UpdateEmployeeRequest {
+	return UpdateEmployeeRequest{
+		FirstName:      e.FirstName,
+		LastName:       e.LastName,
+		Email:          e.Email,
+		EmployeeNumber: e.EmployeeNumber,
+		Position:       e.Position,
+		Department:     e.Department,
+		Status:         e.Status,
+		Phone:          e.Phone,
+		DateOfBirth:    e.DateOfBirth,
+		NationalID:     e.NationalID,
+		Gender:         e.Gender,
+		PersonalEmail:  e.PersonalEmail,
+		Address:        e.Address,
+	}
+}
+
+// Employee returns the employee id as the request replaces it, its
+// profile normalized
+func (r *UpdateEmployeeRequest) Employee(id int64) *Employee {
+	e := &Employee{
+		ID:             id,
+		FirstName:      r.FirstName,
+		LastName:       r.LastName,
+		Email:          r.Email,
+		EmployeeNumber: r.EmployeeNumber,
+		Position:       r.Position,
+		Department:     r.Department,
+		Status:         r.Status,
+		Phone:          r.Phone,
+		DateOfBirth:    r.DateOfBirth,
+		NationalID:     r.NationalID,
+		Gender:         r.Gender,
+		PersonalEmail:  r.PersonalEmail,
+		Address:        r.Address,
+	}
+	e.NormalizeProfile()
+	return e
+}
+
+// EmployeeResponse is an employee as the REST API returns it
+type EmployeeResponse struct {
+	// XMLName keeps Employee as the XML element of a single employee,
+	// lists name their items themselves
+	XMLName          xml.Name       `json:"-" swaggerignore:"true"`
+	ID               int64          `json:"id" xml:"id"`
+	UUID             string         `json:"uuid" xml:"uuid" example:"0b6f6a3e-5d1c-4f7e-9a51-3c2f8d9e4b10"`
+	FirstName        string         `json:"firstName" xml:"firstName"`
+	LastName         string         `json:"lastName" xml:"lastName"`
+	Email            string         `json:"email" xml:"email"`
+	EmployeeNumber   string         `json:"employeeNumber" xml:"employeeNumber"`
+	Position         string         `json:"position" xml:"position"`
+	Department       string         `json:"department" xml:"department"`
+	Status           EmployeeStatus `json:"status" xml:"status" enums:"ACTIVE,ON_VACATION,RETIRED,PROBATION"`
+	HireDate         Date           `json:"hireDate" xml:"hireDate" swaggertype:"string" example:"2024-03-01"`
+	ProbationEndDate *Date          `json:"probationEndDate" xml:"probationEndDate,omitempty" swaggertype:"string" example:"2024-06-01" extensions:"x-nullable"`
+	Phone            *string        `json:"phone" xml:"phone,omitempty" example:"+573001234567" extensions:"x-nullable"`
+	DateOfBirth      *Date          `json:"dateOfBirth" xml:"dateOfBirth,omitempty" swaggertype:"string" example:"1990-05-17" extensions:"x-nullable"`
+	NationalID       *string        `json:"nationalId" xml:"nationalId,omitempty" example:"1094123456" extensions:"x-nullable"`
+	Gender           *Gender        `json:"gender" xml:"gender,omitempty" enums:"FEMALE,MALE,NON_BINARY,UNDISCLOSED" extensions:"x-nullable"`
+	PersonalEmail    *string        `json:"personalEmail" xml:"personalEmail,omitempty" example:"jane.doe@gmail.com" extensions:"x-nullable"`
+	Address          *Address       `json:"address" xml:"address,omitempty" extensions:"x-nullable"`
+	// EmailVerifiedAt is when the employee confirmed their email, null
+	// until they do and again once it changes
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt" xml:"emailVerifiedAt,omitempty" extensions:"x-nullable"`
+	CreatedAt       time.Time  `json:"createdAt" xml:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt" xml:"updatedAt"`
+
+	// Skills are embedded with include=skills, left out of CSV
+	Skills *[]EmployeeSkill `json:"skills,omitempty" xml:"skills>skill,omitempty" csv:"-"`
+}
+
+// NewEmployeeResponse returns the response of e
+func NewEmployeeResponse(e *Employee) EmployeeResponse {
+	return EmployeeResponse{
+		XMLName:          xml.Name{Local: "Employee"},
+		ID:               e.ID,
+		UUID:             e.UUID,
+		FirstName:        e.FirstName,
+		LastName:         e.LastName,
+		Email:            e.Email,
+		EmployeeNumber:   e.EmployeeNumber,
+		Position:         e.Position,
+		Department:       e.Department,
+		Status:           e.Status,
+		HireDate:         e.HireDate,
+		Phone:            e.Phone,
+		ProbationEndDate: e.ProbationEndDate,
+		DateOfBirth:      e.DateOfBirth,
+		NationalID:       e.NationalID,
+		Gender:           e.Gender,
+		PersonalEmail:    e.PersonalEmail,
+		Address:          e.Address,
+		EmailVerifiedAt:  e.EmailVerifiedAt,
+		CreatedAt:        e.CreatedAt,
+		UpdatedAt:        e.UpdatedAt,
+	}
+}
+
+// NewEmployeeResponses returns the responses of employees, named by the
+// list they are rendered in
+func NewEmployeeResponses(employees []Employee) []EmployeeResponse {
+	responses := make([]EmployeeResponse, len(employees))
+	for i := range employees {
+		responses[i] = NewEmployeeResponse(&employees[i])
+		responses[i].XMLName = xml.Name{}
+	}
+	return responses
+}
+
+// EmployeeNumberFormat describes the employee numbers the API accepts, for
+// clients to check them before submitting
+type EmployeeNumberFormat struct {
+	Pattern   string `json:"pattern" example:"^(?:EMP-\\d{5})$"`
+	MaxLength int    `json:"maxLength" example:"50"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// ProbationAuditEntry records that the probation of an employee ended,
+// made ACTIVE by the probation job or by an update
+type ProbationAuditEntry struct {
+	ID               int64  `json:"id"`
+	EmployeeID       int64  `json:"employeeId"`
+	EmployeeNumber   string `json:"employeeNumber"`
+	ProbationEndDate *Date  `json:"probationEndDate"`
+	// Reason is probation_ended when the probation job ended it, empty
+	// when an update did
+	Reason  string    `json:"reason"`
+	EndedAt time.Time `json:"endedAt"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// RetentionAction is what the retention policy does to employees retired
+// for longer than the retention period
+type RetentionAction string
+
+const (
+	RetentionAnonymize RetentionAction = "ANONYMIZE"
+	RetentionDelete    RetentionAction = "DELETE"
+)
+
+// Valid reports whether a is a known retention action
+func (a RetentionAction) Valid() bool {
+	return a == RetentionAnonymize || a == RetentionDelete
+}
+
+// RetentionCandidate is an employee the retention policy applies to
+type RetentionCandidate struct {
+	ID             int64     `json:"id"`
+	EmployeeNumber string    `json:"employeeNumber"`
+	Department     string    `json:"department"`
+	RetiredAt      time.Time `json:"retiredAt"`
+	Archived       bool      `json:"archived"`
+}
+
+// RetentionReport lists the employees the retention policy would purge
+type RetentionReport struct {
+	Action    RetentionAction      `json:"action" enums:"ANONYMIZE,DELETE"`
+	Cutoff    time.Time            `json:"cutoff"`
+	Employees []RetentionCandidate `json:"employees"`
+}
+
+// RetentionAuditEntry records an employee anonymized or deleted by the
+// retention policy
+type RetentionAuditEntry struct {
+	ID             int64           `json:"id"`
+	EmployeeID     int64           `json:"employeeId"`
+	EmployeeNumber string          `json:"employeeNumber"`
+	Action         RetentionAction `json:"action" enums:"ANONYMIZE,DELETE"`
+	RetiredAt      time.Time       `json:"retiredAt"`
+	PurgedAt       time.Time       `json:"purgedAt"`
+}
+
+// Anonymize replaces the personal data of e with placeholders, keeping
+// what is needed for headcount reports: number, position, department,
+// status and hire date. The email stays unique per employee
+func (e *Employee) Anonymize() {
+	e.FirstName = "Anonymized"
+	e.LastName = "Employee"
+	e.Email = "anonymized-" + strconv.FormatInt(e.ID, 10) + "@anonymized.invalid"
+	e.Phone = nil
+	e.DateOfBirth = nil
+	e.NationalID = nil
+	e.Gender = nil
+	e.PersonalEmail = nil
+	e.Address = nil
+	e.EmailVerifiedAt = nil
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Proficiency is how well an employee masters a skill
+type Proficiency string
+
+const (
+	ProficiencyBeginner     Proficiency = "BEGINNER"
+	ProficiencyIntermediate Proficiency = "INTERMEDIATE"
+	ProficiencyAdvanced     Proficiency = "ADVANCED"
+	ProficiencyExpert       Proficiency = "EXPERT"
+)
+
+// Valid reports whether p is a known proficiency level
+func (p Proficiency) Valid() bool {
+	switch p {
+	case ProficiencyBeginner, ProficiencyIntermediate, ProficiencyAdvanced, ProficiencyExpert:
+		return true
+	}
+	return false
+}
+
+// Skill is an entry of the skills catalog. Names are unique regardless
+// of case
+type Skill struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name" example:"Go"`
+	Category    string    `json:"category" example:"Programming languages"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// EmployeeSkill is a skill of the catalog assigned to an employee
+type EmployeeSkill struct {
+	SkillID     int64       `json:"skillId"`
+	Name        string      `json:"name" example:"Go"`
+	Category    string      `json:"category" example:"Programming languages"`
+	Proficiency Proficiency `json:"proficiency" enums:"BEGINNER,INTERMEDIATE,ADVANCED,EXPERT"`
+	UpdatedAt   time.Time   `json:"updatedAt"`
+}
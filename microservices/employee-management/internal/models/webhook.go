@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// DeliveryStatus represents the state of a webhook delivery
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	DeliveryFailed    DeliveryStatus = "FAILED"
+)
+
+// WebhookSubscription is an external endpoint receiving employee events
+// The secret is used to sign deliveries and is never serialized
+type WebhookSubscription struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"-"`
+	EventTypes []string  `json:"eventTypes"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// WebhookDelivery is one attempt to deliver an event to a subscription
+type WebhookDelivery struct {
+	ID             int64          `json:"id"`
+	SubscriptionID int64          `json:"subscriptionId"`
+	EventID        string         `json:"eventId"`
+	EventType      string         `json:"eventType"`
+	Payload        []byte         `json:"-"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	ResponseCode   *int           `json:"responseCode,omitempty"`
+	LastError      *string        `json:"lastError,omitempty"`
+	NextAttemptAt  time.Time      `json:"nextAttemptAt"`
+	DeliveredAt    *time.Time     `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time      `json:"createdAt"`
+}
@@ -0,0 +1,66 @@
+// Package outbox dispatches the events stored in the outbox table to the
+// message broker in the background
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"employee-management/internal/backoff"
+	"employee-management/internal/events"
+	"employee-management/internal/repository"
+)
+
+// Dispatcher polls the outbox and publishes pending events with
+// at-least-once semantics. Failed events are retried with exponential
+// backoff capped at MaxBackoff
+type Dispatcher struct {
+	repo         repository.OutboxRepository
+	publisher    events.Publisher
+	pollInterval time.Duration
+	batchSize    int
+	maxBackoff   time.Duration
+}
+
+// NewDispatcher creates a new Dispatcher
+func NewDispatcher(repo repository.OutboxRepository, publisher events.Publisher, pollInterval time.Duration, batchSize int, maxBackoff time.Duration) *Dispatcher {
+	return &Dispatcher{
+		repo:         repo,
+		publisher:    publisher,
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+		maxBackoff:   maxBackoff,
+	}
+}
+
+// Run dispatches until ctx is done. A full batch is followed immediately by
+// the next one so a backlog drains without waiting for the poll interval
+func (d *Dispatcher) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		n, err := d.repo.Dispatch(ctx, d.batchSize, d.publisher.Publish, d.backoff)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("outbox dispatch failed: %v", err)
+		}
+
+		if n == d.batchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(d.pollInterval)
+		}
+	}
+}
+
+// backoff returns the wait before the given attempt, 1s doubled per attempt
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	return backoff.Exponential(time.Second, d.maxBackoff, attempts)
+}
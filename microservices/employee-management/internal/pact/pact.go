@@ -0,0 +1,130 @@
+// Package pact sets up the provider states named by consumer contracts
+// (Pact), so the contracts of downstream teams can be verified against a
+// running instance. It is only mounted in test mode, on the memory
+// backend, as setting up a state wipes every employee
+package pact
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"employee-management/internal/models"
+)
+
+// Provider states, as consumers name them in their contracts
+const (
+	StateNoEmployees       = "no employees exist"
+	StateEmployeeExists    = "an employee exists"
+	StateRetiredEmployee   = "a retired employee exists"
+	StateProbationEmployee = "an employee on probation exists"
+)
+
+// ErrUnknownState is returned by Setup for a state not listed above
+var ErrUnknownState = errors.New("unknown provider state")
+
+// Employees creates and updates the employees of a state, as
+// EmployeeService does
+type Employees interface {
+	Create(ctx context.Context, e *models.Employee) error
+	Update(ctx context.Context, e *models.Employee) error
+}
+
+// States sets up the provider states
+type States struct {
+	employees Employees
+	reset     func()
+
+	// created numbers the employees of the states, keeping their default
+	// email and number unique until the next reset
+	created atomic.Int64
+}
+
+// NewStates creates States creating employees through employees, reset
+// dropping every employee
+func NewStates(employees Employees, reset func()) *States {
+	return &States{employees: employees, reset: reset}
+}
+
+// Setup sets up state and returns the values consumers can inject in
+// their requests, e.g. the id of the employee created. An employee
+// state takes any field of the employee create request as a param,
+// and status, so a contract can pin the values it asserts on
+func (s *States) Setup(ctx context.Context, state string, params map[string]any) (map[string]any, error) {
+	switch state {
+	case StateNoEmployees:
+		s.Teardown()
+		return map[string]any{}, nil
+	case StateEmployeeExists:
+		return s.employee(ctx, params, "")
+	case StateRetiredEmployee:
+		return s.employee(ctx, params, models.StatusRetired)
+	case StateProbationEmployee:
+		return s.employee(ctx, params, models.StatusProbation)
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownState, state)
+	}
+}
+
+// Teardown drops every employee, so the next interaction starts empty
+// with ids from 1
+func (s *States) Teardown() {
+	s.reset()
+	s.created.Store(0)
+}
+
+// employee creates the employee of params, with status when not empty
+func (s *States) employee(ctx context.Context, params map[string]any, status models.EmployeeStatus) (map[string]any, error) {
+	n := s.created.Add(1)
+	req := models.CreateEmployeeRequest{
+		FirstName:      "Jane",
+		LastName:       "Doe",
+		Email:          fmt.Sprintf("jane.doe.%d@example.com", n),
+		EmployeeNumber: fmt.Sprintf("PACT-%04d", n),
+		Position:       "Software Engineer",
+		Department:     "Engineering",
+	}
+	var override struct {
+		Status models.EmployeeStatus `json:"status"`
+	}
+	if len(params) > 0 {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &req); err != nil {
+			return nil, fmt.Errorf("invalid params: %w", err)
+		}
+		if err := json.Unmarshal(data, &override); err != nil {
+			return nil, fmt.Errorf("invalid params: %w", err)
+		}
+	}
+	if override.Status != "" {
+		status = override.Status
+	}
+	if status == models.StatusProbation && req.ProbationEndDate == nil {
+		end := models.DateOf(time.Now().AddDate(0, 0, 30))
+		req.ProbationEndDate = &end
+	}
+
+	e := req.Employee()
+	if err := s.employees.Create(ctx, e); err != nil {
+		return nil, err
+	}
+	if status != "" && status != e.Status {
+		e.Status = status
+		if err := s.employees.Update(ctx, e); err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]any{
+		"id":             e.ID,
+		"uuid":           e.UUID,
+		"email":          e.Email,
+		"employeeNumber": e.EmployeeNumber,
+	}, nil
+}
@@ -0,0 +1,351 @@
+// Package patch applies JSON Patch (RFC 6902) and JSON Merge Patch
+// (RFC 7396) documents to JSON documents
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Media types of the patch documents
+const (
+	JSONPatchType  = "application/json-patch+json"
+	MergePatchType = "application/merge-patch+json"
+)
+
+// Patch errors
+var (
+	// ErrInvalid is returned for patch documents that are not valid JSON
+	// Patch or Merge Patch
+	ErrInvalid = errors.New("invalid patch document")
+	// ErrUnprocessable is returned for patches that do not apply to the
+	// document, e.g. removing a member it does not have
+	ErrUnprocessable = errors.New("patch does not apply to the document")
+	// ErrTestFailed is returned when a test operation does not match
+	ErrTestFailed = errors.New("patch test operation failed")
+)
+
+// Operation is one operation of a JSON Patch
+type Operation struct {
+	Op    string          `json:"op" enums:"add,remove,replace,move,copy,test"`
+	Path  string          `json:"path" example:"/phone"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty" swaggertype:"object"`
+}
+
+// Apply applies the JSON Patch ops to doc, every operation or none
+func Apply(doc, ops []byte) ([]byte, error) {
+	var operations []Operation
+	if err := json.Unmarshal(ops, &operations); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, op := range operations {
+		if target, err = apply(target, op); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return json.Marshal(target)
+}
+
+// Merge applies the JSON Merge Patch patch to doc: members of patch
+// replace those of doc, objects are merged and null removes a member
+func Merge(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return json.Marshal(merge(target, p))
+}
+
+// merge applies the merge patch p to target
+func merge(target, p any) any {
+	members, ok := p.(map[string]any)
+	if !ok {
+		return p
+	}
+	obj, ok := target.(map[string]any)
+	if !ok {
+		obj = map[string]any{}
+	}
+	for key, value := range members {
+		if value == nil {
+			delete(obj, key)
+			continue
+		}
+		obj[key] = merge(obj[key], value)
+	}
+	return obj
+}
+
+// apply applies a single operation to doc
+func apply(doc any, op Operation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: %s without value", ErrInvalid, op.Op)
+		}
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		switch op.Op {
+		case "add":
+			return add(doc, path, value)
+		case "replace":
+			return update(doc, path, func(any) (any, error) { return value, nil })
+		default:
+			current, err := get(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !equal(current, value) {
+				return nil, fmt.Errorf("%w: %s", ErrTestFailed, op.Path)
+			}
+			return doc, nil
+		}
+	case "remove":
+		return remove(doc, path)
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			// Copied values must not share maps or slices with the source
+			if value, err = clone(value); err != nil {
+				return nil, err
+			}
+			return add(doc, path, value)
+		}
+		if len(path) > len(from) && isPrefix(from, path) {
+			return nil, fmt.Errorf("%w: cannot move %s into itself", ErrInvalid, op.From)
+		}
+		if doc, err = remove(doc, from); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	default:
+		return nil, fmt.Errorf("%w: unknown op %q", ErrInvalid, op.Op)
+	}
+}
+
+// add adds value at path, inserting into arrays and replacing members
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	key := path[len(path)-1]
+	return update(doc, path[:len(path)-1], func(parent any) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			p[key] = value
+			return p, nil
+		case []any:
+			if key == "-" {
+				return append(p, value), nil
+			}
+			i, err := index(key, len(p)+1)
+			if err != nil {
+				return nil, err
+			}
+			p = append(p, nil)
+			copy(p[i+1:], p[i:])
+			p[i] = value
+			return p, nil
+		default:
+			return nil, fmt.Errorf("%w: %s is not a container", ErrUnprocessable, key)
+		}
+	})
+}
+
+// remove removes the member or element at path
+func remove(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: cannot remove the whole document", ErrUnprocessable)
+	}
+	key := path[len(path)-1]
+	return update(doc, path[:len(path)-1], func(parent any) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			if _, ok := p[key]; !ok {
+				return nil, fmt.Errorf("%w: no member %s", ErrUnprocessable, key)
+			}
+			delete(p, key)
+			return p, nil
+		case []any:
+			i, err := index(key, len(p))
+			if err != nil {
+				return nil, err
+			}
+			return append(p[:i], p[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%w: %s is not a container", ErrUnprocessable, key)
+		}
+	})
+}
+
+// update replaces the existing value at path with what fn returns for it
+func update(doc any, path []string, fn func(any) (any, error)) (any, error) {
+	if len(path) == 0 {
+		return fn(doc)
+	}
+	switch d := doc.(type) {
+	case map[string]any:
+		child, ok := d[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("%w: no member %s", ErrUnprocessable, path[0])
+		}
+		value, err := update(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		d[path[0]] = value
+		return d, nil
+	case []any:
+		i, err := index(path[0], len(d))
+		if err != nil {
+			return nil, err
+		}
+		value, err := update(d[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		d[i] = value
+		return d, nil
+	default:
+		return nil, fmt.Errorf("%w: %s is not a container", ErrUnprocessable, path[0])
+	}
+}
+
+// get returns the value at path
+func get(doc any, path []string) (any, error) {
+	var value any
+	_, err := update(doc, path, func(v any) (any, error) {
+		value = v
+		return v, nil
+	})
+	return value, err
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its unescaped
+// reference tokens, none for the whole document
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: path %q does not start with /", ErrInvalid, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// isPrefix reports whether path starts with prefix
+func isPrefix(prefix, path []string) bool {
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// index parses an array index below size
+func index(token string, size int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrUnprocessable, token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i >= size {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrUnprocessable, token)
+	}
+	return i, nil
+}
+
+// decode decodes a JSON value keeping numbers as written
+func decode(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return v, nil
+}
+
+// clone returns a deep copy of a decoded value
+func clone(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decode(data)
+}
+
+// equal compares decoded values, numbers by value
+func equal(a, b any) bool {
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, value := range a {
+			other, ok := b[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, errA := a.Float64()
+		y, errB := b.Float64()
+		return errA == nil && errB == nil && x == y
+	default:
+		return a == b
+	}
+}
@@ -0,0 +1,55 @@
+// Package probation ends the probation of employees: those on PROBATION
+// whose probation end date has lapsed are made ACTIVE
+package probation
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"employee-management/internal/metrics"
+)
+
+// Ender makes ACTIVE the employees whose probation lapsed and returns how
+// many, e.g. service.EmployeeService.EndProbations
+type Ender func(ctx context.Context) (int, error)
+
+// Scheduler runs an Ender periodically
+type Scheduler struct {
+	end Ender
+}
+
+// NewScheduler creates a new Scheduler
+func NewScheduler(end Ender) *Scheduler {
+	return &Scheduler{end: end}
+}
+
+// Run ends the lapsed probations right away, then every interval until
+// ctx is done. A lapse is noticed on the first run after midnight in the
+// service time zone
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce ends the lapsed probations and logs the outcome
+func (s *Scheduler) runOnce(ctx context.Context) {
+	ended, err := s.end(ctx)
+	if ended > 0 {
+		metrics.ProbationsEnded.Add(float64(ended))
+		log.Printf("probation ended for %d employees", ended)
+	}
+	if err != nil && ctx.Err() == nil {
+		log.Printf("probation run failed after %d employees: %v", ended, err)
+	}
+}
@@ -0,0 +1,141 @@
+// Package reminders announces upcoming work anniversaries and birthdays:
+// once a day it publishes a reminder event for every employee of the
+// opted-in departments whose anniversary or birthday is a set number of
+// days ahead, for the notification service to alert their managers
+package reminders
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// AllDepartments opts every department in
+const AllDepartments = "*"
+
+// Source walks every employee, e.g. service.EmployeeService.FindAllStream
+type Source func(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error
+
+// Job publishes the reminders of the day
+type Job struct {
+	source    Source
+	publisher events.Publisher
+	daysAhead int
+
+	// departments are the opted-in departments, all of them when it holds
+	// AllDepartments
+	departments map[string]bool
+}
+
+// NewJob creates a new Job reminding daysAhead days before the day
+func NewJob(source Source, publisher events.Publisher, daysAhead int, departments []string) *Job {
+	opted := map[string]bool{}
+	for _, d := range departments {
+		opted[d] = true
+	}
+	return &Job{source: source, publisher: publisher, daysAhead: daysAhead, departments: opted}
+}
+
+// Remind publishes the reminders of the anniversaries and birthdays
+// daysAhead days after today and returns how many were published.
+// Retired employees are left out. Reminder ids are derived from the
+// employee and the day, so a second run on the same day publishes the
+// same events again and consumers deduplicating by id drop them
+func (j *Job) Remind(ctx context.Context, today models.Date) (int, error) {
+	day := today.AddDays(j.daysAhead)
+
+	var reminders []*events.Event
+	err := j.source(ctx, nil, func(e models.Employee) error {
+		if e.Status == models.StatusRetired || !j.optedIn(e.Department) {
+			return nil
+		}
+
+		if years := day.Time().Year() - e.HireDate.Time().Year(); years > 0 && sameDay(e.HireDate, day) {
+			evt, err := reminder(events.EmployeeAnniversaryUpcoming, &e, day, years)
+			if err != nil {
+				return err
+			}
+			reminders = append(reminders, evt)
+		}
+
+		if e.DateOfBirth != nil && sameDay(*e.DateOfBirth, day) {
+			evt, err := reminder(events.EmployeeBirthdayUpcoming, &e, day, 0)
+			if err != nil {
+				return err
+			}
+			reminders = append(reminders, evt)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, evt := range reminders {
+		if err := j.publisher.Publish(ctx, *evt); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// Run publishes the reminders of the day right away, then every day
+// until ctx is done
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		published, err := j.Remind(ctx, models.Today())
+		if err != nil && ctx.Err() == nil {
+			log.Printf("reminders failed after %d events: %v", published, err)
+		} else if published > 0 {
+			log.Printf("reminders published %d events", published)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// optedIn reports whether department gets reminders
+func (j *Job) optedIn(department string) bool {
+	return j.departments[AllDepartments] || j.departments[department]
+}
+
+// sameDay reports whether the anniversary of date falls on day
+func sameDay(date, day models.Date) bool {
+	return date.Anniversary(day.Time().Year()) == day
+}
+
+// reminder builds the reminder event of t for e on day, its id derived
+// from them
+func reminder(t events.Type, e *models.Employee, day models.Date, years int) (*events.Event, error) {
+	evt, err := events.New(t, e.ID, events.Reminder{
+		EmployeeID: e.ID,
+		Date:       day,
+		Years:      years,
+		Department: e.Department,
+		FirstName:  e.FirstName,
+		LastName:   e.LastName,
+		Email:      e.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	evt.ID = uuid.NewSHA1(uuid.NameSpaceURL, []byte(fmt.Sprintf("%s/%d/%s", t, e.ID, day))).String()
+	return evt, nil
+}
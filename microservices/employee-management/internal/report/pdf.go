@@ -0,0 +1,201 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"employee-management/internal/models"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Template is the layout of the PDF: every page has a header with the
+// organization, title and generation time, and a footer with the scope
+// and page number. The first page summarizes the statistics, the roster
+// table follows with its header repeated on every page
+type Template struct {
+	Title string
+	// PageSize is A4 or Letter, in landscape so the roster fits
+	PageSize string
+}
+
+// Roster columns and their share of the page width
+var rosterColumns = []struct {
+	title string
+	width float64
+}{
+	{"Employee No.", 0.13},
+	{"Name", 0.22},
+	{"Position", 0.2},
+	{"Department", 0.18},
+	{"Status", 0.13},
+	{"Hired", 0.14},
+}
+
+const lineHeight = 6
+
+// WritePDF renders r with t to w
+func WritePDF(w io.Writer, r *Report, t Template) error {
+	pdf := fpdf.New("L", "mm", t.PageSize, "")
+	pdf.SetTitle(t.Title, true)
+	pdf.SetCreator("employee-management", true)
+	pdf.AliasNbPages("")
+	pdf.SetAutoPageBreak(true, 15)
+	// Core fonts are cp1252, names are UTF-8
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	generated := r.GeneratedAt.In(models.Location).Format("2006-01-02 15:04 MST")
+	pdf.SetHeaderFunc(func() {
+		pdf.SetFont("Helvetica", "B", 14)
+		pdf.CellFormat(0, 8, tr(t.Title), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(0, 8, tr("Generated "+generated), "", 1, "R", false, 0, "")
+		if r.Organization != "" {
+			pdf.CellFormat(0, 5, tr(r.Organization), "", 1, "L", false, 0, "")
+		}
+		x, y := pdf.GetXY()
+		pageWidth, _ := pdf.GetPageSize()
+		_, _, right, _ := pdf.GetMargins()
+		pdf.Line(x, y+1, pageWidth-right, y+1)
+		pdf.Ln(4)
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 5, tr(r.Scope), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 5, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+	writeSummary(pdf, tr, r)
+	writeRoster(pdf, tr, r)
+
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	return pdf.Output(w)
+}
+
+// writeSummary writes the statistics section
+func writeSummary(pdf *fpdf.Fpdf, tr func(string) string, r *Report) {
+	heading(pdf, "Summary")
+	s := r.Stats
+	pdf.SetFont("Helvetica", "", 10)
+	for _, row := range [][2]string{
+		{"Headcount", strconv.Itoa(s.Headcount)},
+		{"Hired in the last year", strconv.Itoa(s.NewHires)},
+		{"Average tenure", fmt.Sprintf("%.1f years", s.AverageTenure)},
+	} {
+		pdf.CellFormat(60, lineHeight, row[0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(40, lineHeight, row[1], "", 1, "R", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	if s.Headcount == 0 {
+		return
+	}
+	// Status and department tables side by side
+	left, _, _, _ := pdf.GetMargins()
+	y := pdf.GetY()
+	countTable(pdf, tr, left, y, "Status", s.ByStatus, statusLabel)
+	bottom := pdf.GetY()
+	countTable(pdf, tr, left+110, y, "Department", s.ByDepartment, func(name string) string { return name })
+	pdf.SetY(max(bottom, pdf.GetY()) + 6)
+}
+
+// countTable writes the counts of a group with their share at x, y
+func countTable(pdf *fpdf.Fpdf, tr func(string) string, x, y float64, group string, counts []Count, label func(string) string) {
+	total := 0
+	for _, c := range counts {
+		total += c.Count
+	}
+
+	pdf.SetXY(x, y)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(230, 230, 230)
+	pdf.CellFormat(60, lineHeight, group, "B", 0, "L", true, 0, "")
+	pdf.CellFormat(20, lineHeight, "Employees", "B", 0, "R", true, 0, "")
+	pdf.CellFormat(20, lineHeight, "Share", "B", 1, "R", true, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	for _, c := range counts {
+		pdf.SetX(x)
+		pdf.CellFormat(60, lineHeight, fit(pdf, tr(label(c.Name)), 60), "", 0, "L", false, 0, "")
+		pdf.CellFormat(20, lineHeight, strconv.Itoa(c.Count), "", 0, "R", false, 0, "")
+		pdf.CellFormat(20, lineHeight, fmt.Sprintf("%.0f%%", 100*float64(c.Count)/float64(total)), "", 1, "R", false, 0, "")
+	}
+}
+
+// writeRoster writes the roster table, its header repeated on every page
+func writeRoster(pdf *fpdf.Fpdf, tr func(string) string, r *Report) {
+	heading(pdf, "Roster")
+	if len(r.Roster) == 0 {
+		pdf.SetFont("Helvetica", "I", 10)
+		pdf.CellFormat(0, lineHeight, "No employees match the report filters.", "", 1, "L", false, 0, "")
+		return
+	}
+
+	pageWidth, pageHeight := pdf.GetPageSize()
+	left, _, right, bottom := pdf.GetMargins()
+	width := pageWidth - left - right
+
+	header := func() {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetFillColor(230, 230, 230)
+		for _, col := range rosterColumns {
+			pdf.CellFormat(col.width*width, lineHeight, col.title, "B", 0, "L", true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 9)
+	}
+
+	header()
+	for i, e := range r.Roster {
+		if pdf.GetY()+lineHeight > pageHeight-bottom {
+			pdf.AddPage()
+			header()
+		}
+		cells := []string{
+			e.EmployeeNumber,
+			e.FirstName + " " + e.LastName,
+			e.Position,
+			e.Department,
+			statusLabel(string(e.Status)),
+			e.HireDate.String(),
+		}
+		// Zebra stripes keep long rows readable once printed
+		pdf.SetFillColor(245, 245, 245)
+		for j, col := range rosterColumns {
+			pdf.CellFormat(col.width*width, lineHeight, fit(pdf, tr(cells[j]), col.width*width), "", 0, "L", i%2 == 1, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+}
+
+// heading writes a section heading
+func heading(pdf *fpdf.Fpdf, title string) {
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(0, 8, title, "", 1, "L", false, 0, "")
+}
+
+// fit cuts s with an ellipsis to fit a cell width mm wide
+func fit(pdf *fpdf.Fpdf, s string, width float64) string {
+	width -= 2 * pdf.GetCellMargin()
+	if pdf.GetStringWidth(s) <= width {
+		return s
+	}
+	for s != "" && pdf.GetStringWidth(s+"...") > width {
+		s = s[:len(s)-1]
+	}
+	return s + "..."
+}
+
+// statusLabel turns a status into words, e.g. ON_VACATION into On vacation
+func statusLabel(status string) string {
+	label := strings.ToLower(strings.ReplaceAll(status, "_", " "))
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
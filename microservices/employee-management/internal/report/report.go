@@ -0,0 +1,112 @@
+// Package report builds the printable employee report: the roster of the
+// employees matching the filters and statistics about them, rendered as
+// a PDF
+package report
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"employee-management/internal/models"
+)
+
+// Source walks the employees matching filters, e.g.
+// service.EmployeeService.FindAllStream
+type Source func(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error
+
+// Report is the roster and statistics of a set of employees
+type Report struct {
+	Organization string
+	// Scope describes the filters, e.g. "Department: Engineering"
+	Scope       string
+	GeneratedAt time.Time
+	Today       models.Date
+
+	Roster []models.Employee
+	Stats  Stats
+}
+
+// Stats summarizes the roster
+type Stats struct {
+	Headcount    int
+	ByStatus     []Count
+	ByDepartment []Count
+	// AverageTenure is the mean time at the company in years
+	AverageTenure float64
+	// NewHires were hired within the last year
+	NewHires int
+}
+
+// Count is the number of employees of a group
+type Count struct {
+	Name  string
+	Count int
+}
+
+// Build collects the employees source walks matching filters, keeping
+// those keep accepts, into a report sorted by department and name
+func Build(ctx context.Context, source Source, filters map[string]interface{}, keep func(models.Employee) bool, today models.Date) (*Report, error) {
+	r := &Report{GeneratedAt: time.Now(), Today: today}
+	err := source(ctx, filters, func(e models.Employee) error {
+		if keep(e) {
+			r.Roster = append(r.Roster, e)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(r.Roster, func(a, b models.Employee) int {
+		return cmp.Or(
+			strings.Compare(a.Department, b.Department),
+			strings.Compare(a.LastName, b.LastName),
+			strings.Compare(a.FirstName, b.FirstName),
+		)
+	})
+	r.Stats = stats(r.Roster, today)
+	return r, nil
+}
+
+// stats computes the statistics of roster as of today
+func stats(roster []models.Employee, today models.Date) Stats {
+	s := Stats{Headcount: len(roster)}
+	if len(roster) == 0 {
+		return s
+	}
+
+	statuses := map[string]int{}
+	departments := map[string]int{}
+	var tenure float64 // in days, durations would overflow
+	yearAgo := today.AddDays(-365)
+	for _, e := range roster {
+		statuses[string(e.Status)]++
+		departments[e.Department]++
+		if e.HireDate.Before(today) {
+			tenure += today.Time().Sub(e.HireDate.Time()).Hours() / 24
+		}
+		if e.HireDate.After(yearAgo) && !e.HireDate.After(today) {
+			s.NewHires++
+		}
+	}
+
+	s.ByStatus = counts(statuses)
+	s.ByDepartment = counts(departments)
+	s.AverageTenure = tenure / 365.25 / float64(len(roster))
+	return s
+}
+
+// counts lists groups, the largest first
+func counts(groups map[string]int) []Count {
+	list := make([]Count, 0, len(groups))
+	for name, n := range groups {
+		list = append(list, Count{Name: name, Count: n})
+	}
+	slices.SortFunc(list, func(a, b Count) int {
+		return cmp.Or(b.Count-a.Count, strings.Compare(a.Name, b.Name))
+	})
+	return list
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// archiveColumnList are the columns moved to the archive: the employee
+// and its retention state
+var archiveColumnList = strings.Join(slices.Concat(employeeColumns, []string{"retired_at", "anonymized_at"}), ", ")
+
+// ArchiveRepository moves long retired employees out of the employees
+// table
+type ArchiveRepository interface {
+	// Archive moves up to limit employees retired before cutoff to the
+	// archive and returns their ids
+	Archive(ctx context.Context, cutoff time.Time, limit int) ([]int64, error)
+}
+
+// archiveRepository is the postgresql implementation of ArchiveRepository
+type archiveRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewArchiveRepository creates a new instance of ArchiveRepository
+func NewArchiveRepository(db *pgxpool.Pool) ArchiveRepository {
+	return &archiveRepository{db: db}
+}
+
+// Archive deletes the employees and inserts them into the archive in one
+// statement. Rows locked by a concurrent change are left for the next run
+func (r *archiveRepository) Archive(ctx context.Context, cutoff time.Time, limit int) ([]int64, error) {
+	query := `
+        WITH moved AS (
+            DELETE FROM employee.employees
+            WHERE id IN (
+                SELECT id FROM employee.employees
+                WHERE status = 'RETIRED' AND retired_at < $1
+                ORDER BY retired_at, id
+                LIMIT $2
+                FOR UPDATE SKIP LOCKED
+            )
+            RETURNING ` + archiveColumnList + `
+        )
+        INSERT INTO employee.employees_archive (` + archiveColumnList + `)
+        SELECT ` + archiveColumnList + ` FROM moved
+        RETURNING id
+    `
+
+	rows, err := r.db.Query(ctx, query, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive employees: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan archived employee id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to archive employees: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"employee-management/internal/breaker"
+	"employee-management/internal/events"
+	"employee-management/internal/metrics"
+	"employee-management/internal/models"
+)
+
+// breakerRepository wraps an EmployeeRepository with a circuit breaker so
+// a down or overloaded db fails fast instead of piling up callers
+type breakerRepository struct {
+	next    EmployeeRepository
+	breaker *breaker.Breaker
+
+	// dbErr is set on the repository WithTx passes to fn, whose calls
+	// are part of the transaction's breaker call: they run directly and
+	// record their db failures there
+	dbErr *error
+}
+
+// NewBreakerRepository wraps next with the given circuit breaker
+// Domain errors (not found, duplicates) and canceled requests do not
+// count as failures, only errors coming from the db itself
+func NewBreakerRepository(next EmployeeRepository, b *breaker.Breaker) EmployeeRepository {
+	b.IsFailure = isDBFailure
+	return &breakerRepository{next: next, breaker: b}
+}
+
+// isDBFailure reports whether err means the db is unhealthy
+func isDBFailure(err error) bool {
+	switch {
+	case err == nil,
+		errors.Is(err, context.Canceled),
+		errors.Is(err, ErrEmployeeNotFound),
+		errors.Is(err, ErrEmailAlreadyExists),
+		errors.Is(err, ErrEmployeeNumberAlreadyExists),
+		errors.Is(err, ErrEmployeeAlreadyExists),
+		errors.Is(err, ErrSkillFilterUnsupported),
+		errors.Is(err, ErrArchiveUnsupported):
+		return false
+	default:
+		return true
+	}
+}
+
+// execute runs fn through the breaker counting rejected calls
+func (r *breakerRepository) execute(fn func() error) error {
+	if r.dbErr != nil {
+		err := fn()
+		if isDBFailure(err) {
+			*r.dbErr = err
+		}
+		return err
+	}
+
+	err := r.breaker.Execute(fn)
+	if errors.Is(err, breaker.ErrOpen) {
+		metrics.CircuitRejected.Inc()
+	}
+	return err
+}
+
+func (r *breakerRepository) Create(ctx context.Context, e *models.Employee) error {
+	return r.execute(func() error { return r.next.Create(ctx, e) })
+}
+
+func (r *breakerRepository) FindByID(ctx context.Context, id int64) (*models.Employee, error) {
+	var emp *models.Employee
+	err := r.execute(func() error {
+		var err error
+		emp, err = r.next.FindByID(ctx, id)
+		return err
+	})
+	return emp, err
+}
+
+func (r *breakerRepository) FindByUUID(ctx context.Context, uuid string) (*models.Employee, error) {
+	var emp *models.Employee
+	err := r.execute(func() error {
+		var err error
+		emp, err = r.next.FindByUUID(ctx, uuid)
+		return err
+	})
+	return emp, err
+}
+
+func (r *breakerRepository) FindAll(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, error) {
+	var employees []models.Employee
+	err := r.execute(func() error {
+		var err error
+		employees, err = r.next.FindAll(ctx, limit, offset, filters)
+		return err
+	})
+	return employees, err
+}
+
+func (r *breakerRepository) Count(ctx context.Context, filters map[string]interface{}) (int, error) {
+	var count int
+	err := r.execute(func() error {
+		var err error
+		count, err = r.next.Count(ctx, filters)
+		return err
+	})
+	return count, err
+}
+
+func (r *breakerRepository) FindPage(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, int, error) {
+	var employees []models.Employee
+	var total int
+	err := r.execute(func() error {
+		var err error
+		employees, total, err = r.next.FindPage(ctx, limit, offset, filters)
+		return err
+	})
+	return employees, total, err
+}
+
+// FindAllStream runs the whole iteration as a single breaker call. Errors
+// of fn are returned as they are without counting as failures
+func (r *breakerRepository) FindAllStream(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error {
+	var fnErr error
+	err := r.execute(func() error {
+		err := r.next.FindAllStream(ctx, filters, func(e models.Employee) error {
+			fnErr = fn(e)
+			return fnErr
+		})
+		if fnErr != nil {
+			return nil
+		}
+		return err
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return err
+}
+
+func (r *breakerRepository) Update(ctx context.Context, e *models.Employee) error {
+	return r.execute(func() error { return r.next.Update(ctx, e) })
+}
+
+func (r *breakerRepository) Delete(ctx context.Context, id int64) error {
+	return r.execute(func() error { return r.next.Delete(ctx, id) })
+}
+
+func (r *breakerRepository) Snapshot(ctx context.Context, afterID int64, limit int) ([]models.VersionedEmployee, error) {
+	var employees []models.VersionedEmployee
+	err := r.execute(func() error {
+		var err error
+		employees, err = r.next.Snapshot(ctx, afterID, limit)
+		return err
+	})
+	return employees, err
+}
+
+func (r *breakerRepository) AppendEvent(ctx context.Context, evt *events.Event) error {
+	return r.execute(func() error { return r.next.AppendEvent(ctx, evt) })
+}
+
+func (r *breakerRepository) AppendProbationAudit(ctx context.Context, entry *models.ProbationAuditEntry) error {
+	return r.execute(func() error { return r.next.AppendProbationAudit(ctx, entry) })
+}
+
+// WithTx runs the whole transaction as a single breaker call. Errors of
+// fn are returned as they are, and only count as failures when a call
+// of the repository passed to fn failed in the db: a business rule
+// rolling the transaction back says nothing about the db
+func (r *breakerRepository) WithTx(ctx context.Context, fn func(repo EmployeeRepository) error) error {
+	// A savepoint is part of the breaker call of its transaction
+	if r.dbErr != nil {
+		return r.next.WithTx(ctx, func(repo EmployeeRepository) error {
+			return fn(&breakerRepository{next: repo, breaker: r.breaker, dbErr: r.dbErr})
+		})
+	}
+
+	var fnErr error
+	err := r.execute(func() error {
+		var dbErr error
+		err := r.next.WithTx(ctx, func(repo EmployeeRepository) error {
+			fnErr = fn(&breakerRepository{next: repo, breaker: r.breaker, dbErr: &dbErr})
+			return fnErr
+		})
+		if fnErr != nil {
+			return dbErr
+		}
+		return err
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return err
+}
@@ -0,0 +1,325 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"employee-management/internal/cache"
+	"employee-management/internal/events"
+	"employee-management/internal/metrics"
+	"employee-management/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Cache keys, namespaced in a shared cache
+const (
+	cacheKeyPrefix = "employee-management:employee:"
+	listKeyPrefix  = "employee-management:employees:"
+	listVersionKey = listKeyPrefix + "version"
+)
+
+// cachedRepository caches FindByID and FindPage results and invalidates
+// them on every mutation. Cache failures are logged and the db is used
+// instead
+//
+// Entries are keyed by a version, a random token stored under a version
+// key: one per employee and one for every list. A mutation deletes the
+// version keys once it is done and the next read starts a new version,
+// so an entry loaded before the mutation is never read again, even when
+// it is stored after the mutation invalidated the cache
+type cachedRepository struct {
+	next    EmployeeRepository
+	cache   cache.Cache
+	ttl     time.Duration
+	enabled func() bool
+
+	// verify compares every hit with the db, counting the stale ones
+	verify bool
+
+	// pending collects the ids touched inside a transaction, they are
+	// invalidated again once the transaction has finished
+	pending *[]int64
+}
+
+// NewCachedRepository wraps next with a read-through cache for FindByID
+// and FindPage. enabled is checked on every call so caching can be
+// toggled at runtime. verify, meant for development, reads the db on
+// every hit too and counts the hits that differ as stale
+func NewCachedRepository(next EmployeeRepository, c cache.Cache, ttl time.Duration, enabled func() bool, verify bool) EmployeeRepository {
+	return &cachedRepository{next: next, cache: c, ttl: ttl, enabled: enabled, verify: verify}
+}
+
+// versionKey returns the key of the version of an employee
+func versionKey(id int64) string {
+	return cacheKeyPrefix + strconv.FormatInt(id, 10) + ":version"
+}
+
+// cacheKey returns the cache key of an employee at a version
+func cacheKey(id int64, version string) string {
+	return cacheKeyPrefix + strconv.FormatInt(id, 10) + ":" + version
+}
+
+// listKey returns the cache key of a page of the list at a version, the
+// query is hashed so filters of any length make a short key
+func listKey(version string, limit, offset int, filters map[string]interface{}) (string, error) {
+	query, err := json.Marshal(struct {
+		Limit   int                    `json:"limit"`
+		Offset  int                    `json:"offset"`
+		Filters map[string]interface{} `json:"filters"`
+	}{limit, offset, filters})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(query)
+	return listKeyPrefix + version + ":" + hex.EncodeToString(sum[:]), nil
+}
+
+// EvictEmployees invalidates employees and every cached list in c, for
+// changes made outside this instance that its cachedRepository did not
+// see
+func EvictEmployees(ctx context.Context, c cache.Cache, ids ...int64) error {
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, versionKey(id))
+	}
+	keys = append(keys, listVersionKey)
+	return c.Delete(ctx, keys...)
+}
+
+// CacheInvalidator invalidates employees cached by a cachedRepository,
+// for callers making sure a write they made is never read stale
+type CacheInvalidator struct {
+	cache cache.Cache
+}
+
+// NewCacheInvalidator creates a CacheInvalidator of c
+func NewCacheInvalidator(c cache.Cache) *CacheInvalidator {
+	return &CacheInvalidator{cache: c}
+}
+
+// Invalidate invalidates the employees and every cached list
+func (i *CacheInvalidator) Invalidate(ctx context.Context, ids ...int64) error {
+	return EvictEmployees(ctx, i.cache, ids...)
+}
+
+// Create adds the employee and invalidates the cached lists
+func (r *cachedRepository) Create(ctx context.Context, e *models.Employee) error {
+	err := r.next.Create(ctx, e)
+	r.invalidate(ctx)
+	return err
+}
+
+// version returns the version stored under key, starting a new one when
+// there is none. The new version is stored before the caller reads the
+// db, so a mutation finishing meanwhile deletes it. ok is false when the
+// cache failed and must not be used
+func (r *cachedRepository) version(ctx context.Context, key string) (version string, ok bool) {
+	data, found, err := r.cache.Get(ctx, key)
+	if err != nil {
+		log.Printf("cache get %s failed: %v", key, err)
+		return "", false
+	}
+	if found {
+		return string(data), true
+	}
+
+	version = uuid.NewString()
+	if err := r.cache.Set(ctx, key, []byte(version), r.ttl); err != nil {
+		log.Printf("cache set %s failed: %v", key, err)
+		return "", false
+	}
+	return version, true
+}
+
+// lookup returns the entry cached under key, counting the hit or miss
+func (r *cachedRepository) lookup(ctx context.Context, key string) ([]byte, bool) {
+	data, found, err := r.cache.Get(ctx, key)
+	if err != nil {
+		log.Printf("cache get %s failed: %v", key, err)
+	}
+	if err != nil || !found {
+		metrics.CacheRequests.WithLabelValues("miss").Inc()
+		return nil, false
+	}
+	metrics.CacheRequests.WithLabelValues("hit").Inc()
+	return data, true
+}
+
+// store caches val under key
+func (r *cachedRepository) store(ctx context.Context, key string, val any) {
+	data, err := json.Marshal(val)
+	if err != nil {
+		return
+	}
+	if err := r.cache.Set(ctx, key, data, r.ttl); err != nil {
+		log.Printf("cache set %s failed: %v", key, err)
+	}
+}
+
+// stale reports whether the cached entry differs from fresh, read from
+// the db, counting it by kind (employee, list) when it does
+func stale(kind, key string, cached []byte, fresh any) bool {
+	data, err := json.Marshal(fresh)
+	if err != nil || bytes.Equal(data, cached) {
+		return false
+	}
+	metrics.CacheStaleHits.WithLabelValues(kind).Inc()
+	log.Printf("stale cache hit %s", key)
+	return true
+}
+
+// FindByID returns the cached employee or loads and caches it
+// Reads inside a transaction always go to the db
+func (r *cachedRepository) FindByID(ctx context.Context, id int64) (*models.Employee, error) {
+	if !r.enabled() || r.pending != nil {
+		return r.next.FindByID(ctx, id)
+	}
+
+	version, ok := r.version(ctx, versionKey(id))
+	if !ok {
+		return r.next.FindByID(ctx, id)
+	}
+
+	key := cacheKey(id, version)
+	if data, found := r.lookup(ctx, key); found {
+		var emp models.Employee
+		if err := json.Unmarshal(data, &emp); err == nil {
+			if !r.verify {
+				return &emp, nil
+			}
+			fresh, err := r.next.FindByID(ctx, id)
+			if err != nil || stale("employee", key, data, fresh) {
+				return fresh, err
+			}
+			return &emp, nil
+		}
+	}
+
+	emp, err := r.next.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.store(ctx, key, emp)
+
+	return emp, nil
+}
+
+// FindByUUID goes to the db, the cache is keyed by id
+func (r *cachedRepository) FindByUUID(ctx context.Context, uuid string) (*models.Employee, error) {
+	return r.next.FindByUUID(ctx, uuid)
+}
+
+func (r *cachedRepository) FindAll(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, error) {
+	return r.next.FindAll(ctx, limit, offset, filters)
+}
+
+func (r *cachedRepository) Count(ctx context.Context, filters map[string]interface{}) (int, error) {
+	return r.next.Count(ctx, filters)
+}
+
+// cachedPage is a FindPage result as it is cached
+type cachedPage struct {
+	Employees []models.Employee `json:"employees"`
+	Total     int               `json:"total"`
+}
+
+// FindPage returns the cached page or loads and caches it. Every list
+// shares a version, so any mutation invalidates all of them
+// Reads inside a transaction always go to the db
+func (r *cachedRepository) FindPage(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, int, error) {
+	if !r.enabled() || r.pending != nil {
+		return r.next.FindPage(ctx, limit, offset, filters)
+	}
+
+	version, ok := r.version(ctx, listVersionKey)
+	if !ok {
+		return r.next.FindPage(ctx, limit, offset, filters)
+	}
+	key, err := listKey(version, limit, offset, filters)
+	if err != nil {
+		return r.next.FindPage(ctx, limit, offset, filters)
+	}
+
+	if data, found := r.lookup(ctx, key); found {
+		var page cachedPage
+		if err := json.Unmarshal(data, &page); err == nil {
+			if !r.verify {
+				return page.Employees, page.Total, nil
+			}
+			employees, total, err := r.next.FindPage(ctx, limit, offset, filters)
+			if err != nil || stale("list", key, data, cachedPage{Employees: employees, Total: total}) {
+				return employees, total, err
+			}
+			return page.Employees, page.Total, nil
+		}
+	}
+
+	employees, total, err := r.next.FindPage(ctx, limit, offset, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	r.store(ctx, key, cachedPage{Employees: employees, Total: total})
+
+	return employees, total, nil
+}
+
+func (r *cachedRepository) FindAllStream(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error {
+	return r.next.FindAllStream(ctx, filters, fn)
+}
+
+func (r *cachedRepository) Update(ctx context.Context, e *models.Employee) error {
+	err := r.next.Update(ctx, e)
+	r.invalidate(ctx, e.ID)
+	return err
+}
+
+func (r *cachedRepository) Delete(ctx context.Context, id int64) error {
+	err := r.next.Delete(ctx, id)
+	r.invalidate(ctx, id)
+	return err
+}
+
+func (r *cachedRepository) Snapshot(ctx context.Context, afterID int64, limit int) ([]models.VersionedEmployee, error) {
+	return r.next.Snapshot(ctx, afterID, limit)
+}
+
+func (r *cachedRepository) AppendEvent(ctx context.Context, evt *events.Event) error {
+	return r.next.AppendEvent(ctx, evt)
+}
+
+func (r *cachedRepository) AppendProbationAudit(ctx context.Context, entry *models.ProbationAuditEntry) error {
+	return r.next.AppendProbationAudit(ctx, entry)
+}
+
+// WithTx runs fn in a transaction and invalidates every employee mutated
+// in it, and the lists, after it finishes, so no reader caches
+// uncommitted data
+func (r *cachedRepository) WithTx(ctx context.Context, fn func(repo EmployeeRepository) error) error {
+	pending := []int64{}
+	err := r.next.WithTx(ctx, func(tx EmployeeRepository) error {
+		return fn(&cachedRepository{next: tx, cache: r.cache, ttl: r.ttl, enabled: r.enabled, verify: r.verify, pending: &pending})
+	})
+
+	r.invalidate(ctx, pending...)
+	return err
+}
+
+// invalidate invalidates the employees and the lists
+// It runs even while caching is disabled so stale entries do not survive
+// a flag toggle
+func (r *cachedRepository) invalidate(ctx context.Context, ids ...int64) {
+	if r.pending != nil {
+		*r.pending = append(*r.pending, ids...)
+	}
+
+	if err := EvictEmployees(context.WithoutCancel(ctx), r.cache, ids...); err != nil {
+		log.Printf("cache invalidation failed: %v", err)
+	}
+}
@@ -5,10 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
+	"employee-management/internal/events"
 	"employee-management/internal/models"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -18,15 +19,60 @@ import (
 type EmployeeRepository interface {
 	Create(ctx context.Context, e *models.Employee) error
 	FindByID(ctx context.Context, id int64) (*models.Employee, error)
+
+	// FindByUUID retrieves an employee by their public uuid
+	FindByUUID(ctx context.Context, uuid string) (*models.Employee, error)
+
 	FindAll(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, error)
 	Count(ctx context.Context, filters map[string]interface{}) (int, error)
+
+	// FindPage returns a page of the employees matching filters, newest
+	// first, with the number of employees matching them, in one query
+	FindPage(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, int, error)
+
+	// FindAllStream calls fn for every employee matching filters, by id,
+	// reading them as it goes instead of loading them all. It stops at
+	// the first error of fn and returns it. The rows are read on one
+	// connection until the end, so inside WithTx fn must not use the
+	// transaction
+	FindAllStream(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error
+
 	Update(ctx context.Context, e *models.Employee) error
 	Delete(ctx context.Context, id int64) error
+
+	// Snapshot returns up to limit employees with an id greater than
+	// afterID, by id, each with the version of its latest event
+	Snapshot(ctx context.Context, afterID int64, limit int) ([]models.VersionedEmployee, error)
+
+	// AppendEvent stores a domain event in the outbox and sets its version
+	// Call it inside WithTx so the event commits with the change it
+	// describes, after the change locked the employee row, so events of
+	// the same employee get their versions one at a time
+	AppendEvent(ctx context.Context, evt *events.Event) error
+
+	// AppendProbationAudit records that the probation of an employee
+	// ended and sets the id and time of the entry. Call it inside WithTx
+	// so the entry commits with the status change
+	AppendProbationAudit(ctx context.Context, entry *models.ProbationAuditEntry) error
+
+	// WithTx runs fn with a repository bound to a single transaction
+	// The transaction commits if fn returns nil and rolls back otherwise
+	// Calling WithTx on a transactional repository creates a savepoint
+	WithTx(ctx context.Context, fn func(repo EmployeeRepository) error) error
+}
+
+// dbtx is implemented by both *pgxpool.Pool and pgx.Tx, so the same
+// queries run inside or outside a transaction
+type dbtx interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 }
 
 // employeeRepository is the postgresql implementation of EmployeeRepository
 type employeeRepository struct {
-	db *pgxpool.Pool // db connection pool
+	db dbtx // db connection pool or current transaction
 }
 
 // NewEmployeeRepository creates a new instance of EmployeeRepository
@@ -34,6 +80,48 @@ func NewEmployeeRepository(db *pgxpool.Pool) EmployeeRepository {
 	return &employeeRepository{db: db}
 }
 
+// WithTx runs fn inside a transaction (or a savepoint when already in one)
+func (r *employeeRepository) WithTx(ctx context.Context, fn func(repo EmployeeRepository) error) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		return fn(&employeeRepository{db: tx})
+	})
+}
+
+// AppendEvent inserts the event into the outbox table, one version past
+// the latest event of the employee
+func (r *employeeRepository) AppendEvent(ctx context.Context, evt *events.Event) error {
+	query := `
+        INSERT INTO employee.outbox (event_id, event_type, aggregate_id, aggregate_version, payload, occurred_at)
+        SELECT $1, $2, $3, COALESCE(MAX(aggregate_version), 0) + 1, $4, $5
+        FROM employee.outbox
+        WHERE aggregate_id = $3
+        RETURNING aggregate_version
+    `
+
+	err := r.db.QueryRow(ctx, query, evt.ID, evt.Type, evt.AggregateID, evt.Payload, evt.OccurredAt).Scan(&evt.Version)
+	if err != nil {
+		return fmt.Errorf("failed to append outbox event: %w", err)
+	}
+
+	return nil
+}
+
+// AppendProbationAudit inserts the entry into the probation audit log
+func (r *employeeRepository) AppendProbationAudit(ctx context.Context, entry *models.ProbationAuditEntry) error {
+	query := `
+        INSERT INTO employee.probation_audit (employee_id, employee_number, probation_end_date, reason)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, ended_at
+    `
+
+	err := r.db.QueryRow(ctx, query, entry.EmployeeID, entry.EmployeeNumber, entry.ProbationEndDate, entry.Reason).Scan(&entry.ID, &entry.EndedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert probation audit entry: %w", err)
+	}
+
+	return nil
+}
+
 // Declaration of domain errors.
 var (
 	ErrEmailAlreadyExists          = errors.New("email already exists")
@@ -46,12 +134,15 @@ var (
 func (r *employeeRepository) Create(ctx context.Context, e *models.Employee) error {
 	query := `
         INSERT INTO employee.employees
-        (first_name, last_name, email, employee_number, position, department, status, hire_date)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        (first_name, last_name, email, employee_number, position, department, status, hire_date,
+         phone, date_of_birth, national_id, gender, personal_email,
+         address_street, address_city, address_state, address_postal_code, address_country, uuid,
+         probation_end_date, email_verified_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
         RETURNING id, created_at, updated_at
     `
 
-	err := r.db.QueryRow(ctx, query,
+	args := []any{
 		e.FirstName,
 		e.LastName,
 		e.Email,
@@ -60,7 +151,16 @@ func (r *employeeRepository) Create(ctx context.Context, e *models.Employee) err
 		e.Department,
 		e.Status,
 		e.HireDate,
-	).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
+		e.Phone,
+		e.DateOfBirth,
+		e.NationalID,
+		e.Gender,
+		e.PersonalEmail,
+	}
+	args = append(args, addressValues(e.Address)...)
+	args = append(args, employeeUUID(e), e.ProbationEndDate, e.EmailVerifiedAt)
+
+	err := r.db.QueryRow(ctx, query, args...).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
@@ -81,27 +181,10 @@ func (r *employeeRepository) Create(ctx context.Context, e *models.Employee) err
 
 // FindByID retrieves an employee by their id
 func (r *employeeRepository) FindByID(ctx context.Context, id int64) (*models.Employee, error) {
-	query := `
-        SELECT id, first_name, last_name, email, employee_number, 
-               position, department, status, hire_date, created_at, updated_at
-        FROM employee.employees 
-        WHERE id = $1
-    `
+	query := `SELECT ` + employeeColumnList + ` FROM employee.employees WHERE id = $1`
 
 	var emp models.Employee
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&emp.ID,
-		&emp.FirstName,
-		&emp.LastName,
-		&emp.Email,
-		&emp.EmployeeNumber,
-		&emp.Position,
-		&emp.Department,
-		&emp.Status,
-		&emp.HireDate,
-		&emp.CreatedAt,
-		&emp.UpdatedAt,
-	)
+	err := scanEmployee(r.db.QueryRow(ctx, query, id), &emp)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrEmployeeNotFound
@@ -112,117 +195,193 @@ func (r *employeeRepository) FindByID(ctx context.Context, id int64) (*models.Em
 	return &emp, nil
 }
 
-// FindAll retrives all employees from the db
-func (r *employeeRepository) FindAll(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, error) {
-	baseQuery := `SELECT id, first_name, last_name, email, employee_number, 
-                         position, department, status, hire_date, created_at, updated_at
-                  FROM employee.employees`
-	var conditions []string
-	var args []interface{}
-	argPos := 1
-
-	if dept, ok := filters["department"]; ok && dept != "" {
-		conditions = append(conditions, fmt.Sprintf("department = $%d", argPos))
-		args = append(args, dept)
-		argPos++
+// FindByUUID retrieves an employee by their uuid
+func (r *employeeRepository) FindByUUID(ctx context.Context, uuid string) (*models.Employee, error) {
+	query := `SELECT ` + employeeColumnList + ` FROM employee.employees WHERE uuid = $1`
+
+	var emp models.Employee
+	err := scanEmployee(r.db.QueryRow(ctx, query, uuid), &emp)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmployeeNotFound
+		}
+		return nil, err
 	}
-	if status, ok := filters["status"]; ok && status != "" {
-		conditions = append(conditions, fmt.Sprintf("status = $%d", argPos))
-		args = append(args, status)
-		argPos++
+
+	return &emp, nil
+}
+
+// Snapshot reads a page of employees by id with their latest event version
+// in one statement, so both are from the same point in time
+func (r *employeeRepository) Snapshot(ctx context.Context, afterID int64, limit int) ([]models.VersionedEmployee, error) {
+	query := `
+        SELECT ` + employeeColumnList + `,
+               COALESCE((SELECT MAX(o.aggregate_version) FROM employee.outbox o WHERE o.aggregate_id = employees.id), 0)
+        FROM employee.employees
+        WHERE id > $1
+        ORDER BY id
+        LIMIT $2
+    `
+
+	rows, err := r.db.Query(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
 	}
-	if pos, ok := filters["position"]; ok && pos != "" {
-		conditions = append(conditions, fmt.Sprintf("position = $%d", argPos))
-		args = append(args, pos)
-		argPos++
+	defer rows.Close()
+
+	employees := []models.VersionedEmployee{}
+	for rows.Next() {
+		var emp models.VersionedEmployee
+		if err := scanEmployee(rows, &emp.Employee, &emp.Version); err != nil {
+			return nil, err
+		}
+		employees = append(employees, emp)
 	}
 
-	if len(conditions) > 0 {
-		baseQuery += " WHERE " + strings.Join(conditions, " AND ")
+	return employees, rows.Err()
+}
+
+// FindAll retrives all employees from the db
+func (r *employeeRepository) FindAll(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, error) {
+	employees, _, err := r.findPage(ctx, limit, offset, filters, false)
+	return employees, err
+}
+
+// FindPage returns a page of the employees matching filters with their
+// total, counted by a window function over the same query
+func (r *employeeRepository) FindPage(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, int, error) {
+	employees, total, err := r.findPage(ctx, limit, offset, filters, true)
+	if err == nil && len(employees) == 0 && offset > 0 {
+		// A page past the end has no row to carry the total
+		total, err = r.Count(ctx, filters)
+	}
+	if err != nil {
+		return nil, 0, err
 	}
+	return employees, total, nil
+}
 
-	baseQuery += " ORDER BY created_at DESC"
-	baseQuery += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argPos, argPos+1)
-	args = append(args, limit, offset)
+// findPage selects a page of the employees matching filters, newest
+// first, and with withTotal the number of employees matching them
+func (r *employeeRepository) findPage(ctx context.Context, limit, offset int, filters map[string]interface{}, withTotal bool) ([]models.Employee, int, error) {
+	builder := whereSkill(selectEmployees(postgresSQL, employeesTable(filters), filters), filters)
+	if withTotal {
+		builder = builder.Column("COUNT(*) OVER()")
+	}
+	query, args, err := builder.
+		OrderBy(colCreatedAt + " DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build employees query: %w", err)
+	}
 
-	rows, err := r.db.Query(ctx, baseQuery, args...)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		// Check for specific PostgreSQL errors
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			switch pgErr.Code {
 			case "42P01": // undefined_table
-				return nil, fmt.Errorf("employees table does not exist: %w", err)
+				return nil, 0, fmt.Errorf("employees table does not exist: %w", err)
 			case "42501": // insufficient_privilege
-				return nil, fmt.Errorf("insufficient privileges to access employees: %w", err)
+				return nil, 0, fmt.Errorf("insufficient privileges to access employees: %w", err)
 			}
 		}
-		return nil, fmt.Errorf("failed to query employees: %w", err)
+		return nil, 0, fmt.Errorf("failed to query employees: %w", err)
 	}
 	defer rows.Close()
 
 	var employees []models.Employee
+	var total int
 	for rows.Next() {
 		var emp models.Employee
-		err := rows.Scan(
-			&emp.ID,
-			&emp.FirstName,
-			&emp.LastName,
-			&emp.Email,
-			&emp.EmployeeNumber,
-			&emp.Position,
-			&emp.Department,
-			&emp.Status,
-			&emp.HireDate,
-			&emp.CreatedAt,
-			&emp.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan employee row: %w", err)
+		var extra []any
+		if withTotal {
+			extra = append(extra, &total)
+		}
+		if err := scanEmployee(rows, &emp, extra...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan employee row: %w", err)
 		}
 		employees = append(employees, emp)
 	}
 
 	// Check for any iteration errors
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating employee rows: %w", err)
+		return nil, 0, fmt.Errorf("error iterating employee rows: %w", err)
 	}
 
 	// Returning empty slice (not nil) for no employees is intentional
 	// This makes it easier for callers (no nil check needed)
-	return employees, nil
+	return employees, total, nil
 }
 
+// Count returns the number of employees matching filters
 func (r *employeeRepository) Count(ctx context.Context, filters map[string]interface{}) (int, error) {
-	baseQuery := `SELECT COUNT(*) FROM employee.employees`
-	var conditions []string
-	var args []interface{}
-	argPos := 1
-
-	// same filter logic
-	if dept, ok := filters["department"]; ok && dept != "" {
-		conditions = append(conditions, fmt.Sprintf("department = $%d", argPos))
-		args = append(args, dept)
-		argPos++
+	query, args, err := whereSkill(countEmployees(postgresSQL, employeesTable(filters), filters), filters).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build employees count: %w", err)
+	}
+
+	var count int
+	err = r.db.QueryRow(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// FindAllStream calls fn for every employee matching filters, by id
+func (r *employeeRepository) FindAllStream(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error {
+	query, args, err := whereSkill(selectEmployees(postgresSQL, employeesTable(filters), filters), filters).
+		OrderBy(colID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build employees query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query employees: %w", err)
 	}
-	if status, ok := filters["status"]; ok && status != "" {
-		conditions = append(conditions, fmt.Sprintf("status = $%d", argPos))
-		args = append(args, status)
-		argPos++
+	defer rows.Close()
+
+	for rows.Next() {
+		var emp models.Employee
+		if err := scanEmployee(rows, &emp); err != nil {
+			return fmt.Errorf("failed to scan employee row: %w", err)
+		}
+		if err := fn(emp); err != nil {
+			return err
+		}
 	}
-	if pos, ok := filters["position"]; ok && pos != "" {
-		conditions = append(conditions, fmt.Sprintf("position = $%d", argPos))
-		args = append(args, pos)
-		argPos++
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating employee rows: %w", err)
 	}
 
-	if len(conditions) > 0 {
-		baseQuery += " WHERE " + strings.Join(conditions, " AND ")
+	return nil
+}
+
+// employeesTable returns the table read for filters: the archive, aliased
+// as employees so the same conditions apply, or the current employees
+func employeesTable(filters map[string]interface{}) string {
+	if archived(filters) {
+		return "employee.employees_archive employees"
 	}
+	return "employee.employees"
+}
 
-	var count int
-	err := r.db.QueryRow(ctx, baseQuery, args...).Scan(&count)
-	return count, err
+// whereSkill restricts query to the employees holding the skill of
+// filters, if any
+func whereSkill(query sq.SelectBuilder, filters map[string]interface{}) sq.SelectBuilder {
+	skill, ok := filters[skillFilter]
+	if !ok || skill == "" {
+		return query
+	}
+	return query.Where(sq.Expr(`EXISTS (
+            SELECT 1 FROM employee.employee_skills es
+            JOIN employee.skills s ON s.id = es.skill_id
+            WHERE es.employee_id = employees.id AND LOWER(s.name) = LOWER(?)
+        )`, skill))
 }
 
 // Update modifies an existing employee record
@@ -231,12 +390,15 @@ func (r *employeeRepository) Update(ctx context.Context, e *models.Employee) err
         UPDATE employee.employees 
         SET first_name = $2, last_name = $3, email = $4, 
             employee_number = $5, position = $6, department = $7,
-            status = $8, updated_at = CURRENT_TIMESTAMP
+            status = $8, phone = $9, date_of_birth = $10, national_id = $11,
+            gender = $12, personal_email = $13, address_street = $14,
+            address_city = $15, address_state = $16, address_postal_code = $17,
+            address_country = $18, email_verified_at = $19, updated_at = CURRENT_TIMESTAMP
         WHERE id = $1
         RETURNING updated_at
     `
 
-	result, err := r.db.Exec(ctx, query,
+	args := []any{
 		e.ID,
 		e.FirstName,
 		e.LastName,
@@ -245,8 +407,16 @@ func (r *employeeRepository) Update(ctx context.Context, e *models.Employee) err
 		e.Position,
 		e.Department,
 		e.Status,
-	)
+		e.Phone,
+		e.DateOfBirth,
+		e.NationalID,
+		e.Gender,
+		e.PersonalEmail,
+	}
+	args = append(args, addressValues(e.Address)...)
+	args = append(args, e.EmailVerifiedAt)
 
+	result, err := r.db.Exec(ctx, query, args...)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -264,8 +434,8 @@ func (r *employeeRepository) Update(ctx context.Context, e *models.Employee) err
 		return ErrEmployeeNotFound
 	}
 
-	// Get updated_at if needed
-	err = r.db.QueryRow(ctx, "SELECT updated_at FROM employee.employees WHERE id = $1", e.ID).Scan(&e.UpdatedAt)
+	// Get updated_at if needed, and the uuid the update leaves alone
+	err = r.db.QueryRow(ctx, "SELECT uuid, updated_at FROM employee.employees WHERE id = $1", e.ID).Scan(&e.UUID, &e.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to get updated timestamp: %w", err)
 	}
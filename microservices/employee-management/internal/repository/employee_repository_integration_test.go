@@ -0,0 +1,454 @@
+//go:build integration
+
+// The integration tests run the postgres EmployeeRepository against a
+// disposable PostgreSQL started with testcontainers-go and migrated like
+// production, so the queries and the mapping of pgx errors to domain
+// errors are checked against a real database. They need Docker:
+//
+//	go test -tags integration ./internal/repository/...
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"employee-management/internal/db"
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+	"employee-management/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// postgresImage matches the image of docker-compose.yml
+const postgresImage = "postgres:16-alpine"
+
+var (
+	// pool is the pool of the shared container, started by the first
+	// test needing it
+	pool      *pgxpool.Pool
+	container *postgres.PostgresContainer
+	startOnce sync.Once
+	startErr  error
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if pool != nil {
+		pool.Close()
+	}
+	if container != nil {
+		if err := testcontainers.TerminateContainer(container); err != nil {
+			log.Printf("failed to terminate postgres container: %v", err)
+		}
+	}
+	os.Exit(code)
+}
+
+// newRepository returns the repository of an empty, migrated database,
+// skipping the test when Docker is not available
+func newRepository(t *testing.T) repository.EmployeeRepository {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	startOnce.Do(func() { startErr = start(context.Background()) })
+	if startErr != nil {
+		t.Fatalf("failed to start postgres: %v", startErr)
+	}
+
+	_, err := pool.Exec(context.Background(), `TRUNCATE employee.employees, employee.employees_archive, employee.outbox, employee.probation_audit RESTART IDENTITY CASCADE`)
+	if err != nil {
+		t.Fatalf("failed to empty database: %v", err)
+	}
+	return repository.NewEmployeeRepository(pool)
+}
+
+// start starts the container and migrates its database
+func start(ctx context.Context) error {
+	var err error
+	container, err = postgres.Run(ctx, postgresImage,
+		postgres.WithDatabase("employees"),
+		postgres.WithUsername("employees"),
+		postgres.WithPassword("employees"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(time.Minute),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	url, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		return err
+	}
+	if pool, err = pgxpool.New(ctx, url); err != nil {
+		return err
+	}
+	return db.Migrate(ctx, pool)
+}
+
+// newEmployee returns an unsaved employee, n making its unique fields
+// unique
+func newEmployee(n int) *models.Employee {
+	phone := "+573001234567"
+	return &models.Employee{
+		FirstName:      "Jane",
+		LastName:       fmt.Sprintf("Doe%d", n),
+		Email:          fmt.Sprintf("jane.doe%d@example.com", n),
+		EmployeeNumber: fmt.Sprintf("EMP-%04d", n),
+		Position:       "Engineer",
+		Department:     "Engineering",
+		Status:         models.StatusActive,
+		HireDate:       models.Today(),
+		Phone:          &phone,
+		Address:        &models.Address{Street: "Calle 1", City: "Armenia", Country: "CO"},
+	}
+}
+
+// create saves employees 1 to n
+func create(t *testing.T, repo repository.EmployeeRepository, n int) []*models.Employee {
+	t.Helper()
+	employees := make([]*models.Employee, 0, n)
+	for i := 1; i <= n; i++ {
+		e := newEmployee(i)
+		if err := repo.Create(context.Background(), e); err != nil {
+			t.Fatalf("Create(%d) error = %v", i, err)
+		}
+		employees = append(employees, e)
+	}
+	return employees
+}
+
+func TestCreate(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+
+	e := newEmployee(1)
+	if err := repo.Create(ctx, e); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if e.ID == 0 || e.UUID == "" || e.CreatedAt.IsZero() || e.UpdatedAt.IsZero() {
+		t.Fatalf("Create() left id %d, uuid %q, createdAt %v, updatedAt %v", e.ID, e.UUID, e.CreatedAt, e.UpdatedAt)
+	}
+
+	got, err := repo.FindByID(ctx, e.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if got.Email != e.Email || got.EmployeeNumber != e.EmployeeNumber || *got.Phone != *e.Phone || got.Address == nil || got.Address.City != "Armenia" {
+		t.Errorf("FindByID() = %+v, want the created employee %+v", got, e)
+	}
+}
+
+func TestCreateConstraintViolations(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	existing := create(t, repo, 1)[0]
+
+	tests := []struct {
+		name   string
+		modify func(e *models.Employee)
+		want   error
+	}{
+		{"duplicate email", func(e *models.Employee) { e.Email = existing.Email }, repository.ErrEmailAlreadyExists},
+		{"duplicate email in another case", func(e *models.Employee) { e.Email = "JANE.DOE1@EXAMPLE.COM" }, repository.ErrEmailAlreadyExists},
+		{"duplicate employee number", func(e *models.Employee) { e.EmployeeNumber = existing.EmployeeNumber }, repository.ErrEmployeeNumberAlreadyExists},
+		{"duplicate uuid", func(e *models.Employee) { e.UUID = existing.UUID }, repository.ErrEmployeeAlreadyExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEmployee(2)
+			tt.modify(e)
+			if err := repo.Create(ctx, e); !errors.Is(err, tt.want) {
+				t.Errorf("Create() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindByIDAndUUID(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	e := create(t, repo, 1)[0]
+
+	if got, err := repo.FindByUUID(ctx, e.UUID); err != nil || got.ID != e.ID {
+		t.Errorf("FindByUUID() = %v, %v, want employee %d", got, err, e.ID)
+	}
+	if _, err := repo.FindByID(ctx, e.ID+1); !errors.Is(err, repository.ErrEmployeeNotFound) {
+		t.Errorf("FindByID(missing) error = %v, want %v", err, repository.ErrEmployeeNotFound)
+	}
+	if _, err := repo.FindByUUID(ctx, "6f1c2a7e-0000-4000-8000-000000000000"); !errors.Is(err, repository.ErrEmployeeNotFound) {
+		t.Errorf("FindByUUID(missing) error = %v, want %v", err, repository.ErrEmployeeNotFound)
+	}
+}
+
+func TestFindAllCountAndFindPage(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	employees := create(t, repo, 5)
+
+	sales := employees[0]
+	sales.Department = "Sales"
+	if err := repo.Update(ctx, sales); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		filters map[string]interface{}
+		want    int
+	}{
+		{"no filters", nil, 5},
+		{"department", map[string]interface{}{"department": "Sales"}, 1},
+		{"departments", map[string]interface{}{"department": []string{"Sales", "Engineering"}}, 5},
+		{"status", map[string]interface{}{"status": string(models.StatusRetired)}, 0},
+		{"archived", map[string]interface{}{"archived": true}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.FindAll(ctx, 10, 0, tt.filters)
+			if err != nil || len(got) != tt.want {
+				t.Errorf("FindAll() = %d employees, %v, want %d", len(got), err, tt.want)
+			}
+			if count, err := repo.Count(ctx, tt.filters); err != nil || count != tt.want {
+				t.Errorf("Count() = %d, %v, want %d", count, err, tt.want)
+			}
+		})
+	}
+
+	page, total, err := repo.FindPage(ctx, 2, 0, nil)
+	if err != nil || len(page) != 2 || total != 5 {
+		t.Fatalf("FindPage(2, 0) = %d employees, total %d, %v, want 2 of 5", len(page), total, err)
+	}
+	// Newest first
+	if page[0].ID != employees[4].ID {
+		t.Errorf("FindPage(2, 0) starts with employee %d, want the newest %d", page[0].ID, employees[4].ID)
+	}
+	// A page past the end still has the total
+	page, total, err = repo.FindPage(ctx, 2, 10, nil)
+	if err != nil || len(page) != 0 || total != 5 {
+		t.Errorf("FindPage(2, 10) = %d employees, total %d, %v, want 0 of 5", len(page), total, err)
+	}
+}
+
+func TestFindAllStream(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	employees := create(t, repo, 3)
+
+	var ids []int64
+	err := repo.FindAllStream(ctx, nil, func(e models.Employee) error {
+		ids = append(ids, e.ID)
+		return nil
+	})
+	if err != nil || len(ids) != 3 || ids[0] != employees[0].ID || ids[2] != employees[2].ID {
+		t.Errorf("FindAllStream() visited %v, %v, want the 3 employees by id", ids, err)
+	}
+
+	// The first error of fn stops the iteration and is returned
+	stop := errors.New("stop")
+	visited := 0
+	err = repo.FindAllStream(ctx, nil, func(models.Employee) error {
+		visited++
+		return stop
+	})
+	if !errors.Is(err, stop) || visited != 1 {
+		t.Errorf("FindAllStream() = %v after %d employees, want %v after 1", err, visited, stop)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	e := create(t, repo, 1)[0]
+	createdUUID, createdAt := e.UUID, e.UpdatedAt
+
+	e.UUID = ""
+	e.Position = "Staff Engineer"
+	e.Status = models.StatusOnVacation
+	e.Phone = nil
+	if err := repo.Update(ctx, e); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if e.UUID != createdUUID || !e.UpdatedAt.After(createdAt) {
+		t.Errorf("Update() left uuid %q, updatedAt %v, want %q and after %v", e.UUID, e.UpdatedAt, createdUUID, createdAt)
+	}
+
+	got, err := repo.FindByID(ctx, e.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if got.Position != "Staff Engineer" || got.Status != models.StatusOnVacation || got.Phone != nil {
+		t.Errorf("FindByID() = %+v, want the updated employee", got)
+	}
+}
+
+func TestUpdateConstraintViolations(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	employees := create(t, repo, 2)
+
+	tests := []struct {
+		name   string
+		modify func(e *models.Employee)
+		want   error
+	}{
+		{"duplicate email", func(e *models.Employee) { e.Email = employees[0].Email }, repository.ErrEmailAlreadyExists},
+		{"duplicate employee number", func(e *models.Employee) { e.EmployeeNumber = employees[0].EmployeeNumber }, repository.ErrEmployeeNumberAlreadyExists},
+		{"missing employee", func(e *models.Employee) { e.ID = 1000 }, repository.ErrEmployeeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := *employees[1]
+			tt.modify(&e)
+			if err := repo.Update(ctx, &e); !errors.Is(err, tt.want) {
+				t.Errorf("Update() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	e := create(t, repo, 1)[0]
+
+	if err := repo.Delete(ctx, e.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.FindByID(ctx, e.ID); !errors.Is(err, repository.ErrEmployeeNotFound) {
+		t.Errorf("FindByID(deleted) error = %v, want %v", err, repository.ErrEmployeeNotFound)
+	}
+	if err := repo.Delete(ctx, e.ID); !errors.Is(err, repository.ErrEmployeeNotFound) {
+		t.Errorf("Delete(deleted) error = %v, want %v", err, repository.ErrEmployeeNotFound)
+	}
+}
+
+func TestAppendEventAndSnapshot(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	employees := create(t, repo, 3)
+
+	// Versions count the events of each employee from 1
+	for i, want := range []int64{1, 2, 3} {
+		evt, err := events.New(events.EmployeeUpdated, employees[0].ID, map[string]int{"n": i})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.AppendEvent(ctx, evt); err != nil || evt.Version != want {
+			t.Fatalf("AppendEvent() version = %d, %v, want %d", evt.Version, err, want)
+		}
+	}
+	evt, err := events.New(events.EmployeeCreated, employees[1].ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.AppendEvent(ctx, evt); err != nil || evt.Version != 1 {
+		t.Fatalf("AppendEvent() version = %d, %v, want 1", evt.Version, err)
+	}
+
+	snapshot, err := repo.Snapshot(ctx, 0, 2)
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	if len(snapshot) != 2 || snapshot[0].ID != employees[0].ID || snapshot[0].Version != 3 || snapshot[1].Version != 1 {
+		t.Errorf("Snapshot(0, 2) = %+v, want employees 1 and 2 at versions 3 and 1", snapshot)
+	}
+	snapshot, err = repo.Snapshot(ctx, employees[1].ID, 10)
+	if err != nil || len(snapshot) != 1 || snapshot[0].ID != employees[2].ID || snapshot[0].Version != 0 {
+		t.Errorf("Snapshot(2, 10) = %+v, %v, want employee 3 at version 0", snapshot, err)
+	}
+}
+
+func TestAppendEventDuplicateID(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	e := create(t, repo, 1)[0]
+
+	evt, err := events.New(events.EmployeeCreated, e.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.AppendEvent(ctx, evt); err != nil {
+		t.Fatalf("AppendEvent() error = %v", err)
+	}
+	if err := repo.AppendEvent(ctx, evt); err == nil {
+		t.Error("AppendEvent() of a stored event id succeeded, want a unique violation")
+	}
+}
+
+func TestAppendProbationAudit(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	e := create(t, repo, 1)[0]
+
+	end := models.NewDate(2025, time.June, 30)
+	entry := &models.ProbationAuditEntry{
+		EmployeeID:       e.ID,
+		EmployeeNumber:   e.EmployeeNumber,
+		ProbationEndDate: &end,
+		Reason:           events.ReasonProbationEnded,
+	}
+	if err := repo.AppendProbationAudit(ctx, entry); err != nil {
+		t.Fatalf("AppendProbationAudit() error = %v", err)
+	}
+	if entry.ID == 0 || entry.EndedAt.IsZero() {
+		t.Errorf("AppendProbationAudit() left id %d, endedAt %v", entry.ID, entry.EndedAt)
+	}
+}
+
+func TestWithTx(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+
+	// A transaction returning nil commits
+	err := repo.WithTx(ctx, func(tx repository.EmployeeRepository) error {
+		return tx.Create(ctx, newEmployee(1))
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error = %v", err)
+	}
+
+	// A failed transaction rolls every change back and returns the error
+	failed := errors.New("rule broken")
+	err = repo.WithTx(ctx, func(tx repository.EmployeeRepository) error {
+		if err := tx.Create(ctx, newEmployee(2)); err != nil {
+			return err
+		}
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("WithTx() error = %v, want %v", err, failed)
+	}
+
+	// A constraint violation inside a savepoint leaves the transaction
+	// usable
+	err = repo.WithTx(ctx, func(tx repository.EmployeeRepository) error {
+		err := tx.WithTx(ctx, func(sp repository.EmployeeRepository) error {
+			return sp.Create(ctx, newEmployee(1))
+		})
+		if !errors.Is(err, repository.ErrEmailAlreadyExists) {
+			return fmt.Errorf("savepoint error = %v, want %v", err, repository.ErrEmailAlreadyExists)
+		}
+		return tx.Create(ctx, newEmployee(3))
+	})
+	if err != nil {
+		t.Fatalf("WithTx() with savepoint error = %v", err)
+	}
+
+	if count, err := repo.Count(ctx, nil); err != nil || count != 2 {
+		t.Errorf("Count() = %d, %v, want the 2 committed employees", count, err)
+	}
+}
@@ -0,0 +1,470 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+)
+
+// MemoryStore keeps the employees and their outbox in process memory, for
+// local development and smoke tests without PostgreSQL. Everything is lost
+// on restart
+type MemoryStore struct {
+	// writeMu serializes transactions, a transaction works on a copy of
+	// the state that replaces it on commit
+	writeMu sync.Mutex
+
+	mu    sync.RWMutex
+	state *memoryState
+}
+
+// memoryState is the data of a MemoryStore at one point in time
+type memoryState struct {
+	employees      map[int64]models.Employee
+	outbox         []memoryEvent
+	probationAudit []models.ProbationAuditEntry
+	nextID         int64
+}
+
+// memoryEvent is an outbox row
+type memoryEvent struct {
+	evt           events.Event
+	attempts      int
+	nextAttemptAt time.Time
+	published     bool
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{state: &memoryState{employees: map[int64]models.Employee{}, nextID: 1}}
+}
+
+// Employees returns the EmployeeRepository of the store
+func (s *MemoryStore) Employees() EmployeeRepository {
+	return &memoryEmployeeRepository{store: s}
+}
+
+// Outbox returns the OutboxRepository of the store
+func (s *MemoryStore) Outbox() OutboxRepository {
+	return &memoryOutboxRepository{store: s}
+}
+
+// Reset drops every employee and event, the next employee gets id 1
+// again. It is meant for tests setting up a known state
+func (s *MemoryStore) Reset() {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.Lock()
+	s.state = &memoryState{employees: map[int64]models.Employee{}, nextID: 1}
+	s.mu.Unlock()
+}
+
+// clone returns a copy of st that can be changed independently
+func (st *memoryState) clone() *memoryState {
+	return &memoryState{
+		employees:      maps.Clone(st.employees),
+		outbox:         slices.Clone(st.outbox),
+		probationAudit: slices.Clone(st.probationAudit),
+		nextID:         st.nextID,
+	}
+}
+
+// read runs fn on the committed state
+func (s *MemoryStore) read(fn func(st *memoryState) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(s.state)
+}
+
+// write runs fn on a copy of the committed state and commits it when fn
+// returns nil
+func (s *MemoryStore) write(fn func(st *memoryState) error) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.RLock()
+	next := s.state.clone()
+	s.mu.RUnlock()
+
+	if err := fn(next); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.state = next
+	s.mu.Unlock()
+	return nil
+}
+
+// memoryEmployeeRepository is the in-memory implementation of
+// EmployeeRepository
+type memoryEmployeeRepository struct {
+	store *MemoryStore
+	tx    *memoryState // state of the current transaction, nil outside one
+}
+
+// read runs fn on the transaction state or the committed one
+func (r *memoryEmployeeRepository) read(fn func(st *memoryState) error) error {
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+	return r.store.read(fn)
+}
+
+// write runs fn on the transaction state or in a transaction of its own
+func (r *memoryEmployeeRepository) write(fn func(st *memoryState) error) error {
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+	return r.store.write(fn)
+}
+
+// WithTx runs fn on a copy of the state, kept when fn returns nil. Inside
+// a transaction the copy is taken from it, like a savepoint
+func (r *memoryEmployeeRepository) WithTx(ctx context.Context, fn func(repo EmployeeRepository) error) error {
+	if r.tx != nil {
+		savepoint := r.tx.clone()
+		if err := fn(&memoryEmployeeRepository{store: r.store, tx: savepoint}); err != nil {
+			return err
+		}
+		*r.tx = *savepoint
+		return nil
+	}
+
+	return r.store.write(func(st *memoryState) error {
+		return fn(&memoryEmployeeRepository{store: r.store, tx: st})
+	})
+}
+
+// AppendEvent adds the event to the outbox, one version past the latest
+// event of the employee
+func (r *memoryEmployeeRepository) AppendEvent(ctx context.Context, evt *events.Event) error {
+	return r.write(func(st *memoryState) error {
+		evt.Version = st.version(evt.AggregateID) + 1
+		e := *evt
+		e.Sequence = int64(len(st.outbox)) + 1
+		st.outbox = append(st.outbox, memoryEvent{evt: e, nextAttemptAt: e.OccurredAt})
+		return nil
+	})
+}
+
+// AppendProbationAudit adds the entry to the probation audit log
+func (r *memoryEmployeeRepository) AppendProbationAudit(ctx context.Context, entry *models.ProbationAuditEntry) error {
+	return r.write(func(st *memoryState) error {
+		entry.ID = int64(len(st.probationAudit)) + 1
+		entry.EndedAt = time.Now().UTC()
+		st.probationAudit = append(st.probationAudit, *entry)
+		return nil
+	})
+}
+
+// version returns the version of the latest event of the employee, 0 if none
+func (st *memoryState) version(id int64) int64 {
+	var version int64
+	for _, m := range st.outbox {
+		if m.evt.AggregateID == id && m.evt.Version > version {
+			version = m.evt.Version
+		}
+	}
+	return version
+}
+
+// unique returns the error of the unique constraint e breaks, nil if none
+func (st *memoryState) unique(e *models.Employee) error {
+	for _, other := range st.employees {
+		if other.ID == e.ID {
+			continue
+		}
+		if strings.EqualFold(other.Email, e.Email) {
+			return ErrEmailAlreadyExists
+		}
+		if other.EmployeeNumber == e.EmployeeNumber {
+			return ErrEmployeeNumberAlreadyExists
+		}
+	}
+	return nil
+}
+
+// Create adds the employee with the next id
+func (r *memoryEmployeeRepository) Create(ctx context.Context, e *models.Employee) error {
+	return r.write(func(st *memoryState) error {
+		e.ID = 0
+		if err := st.unique(e); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		employeeUUID(e)
+		e.ID, e.CreatedAt, e.UpdatedAt = st.nextID, now, now
+		st.nextID++
+		st.employees[e.ID] = *e
+		return nil
+	})
+}
+
+// FindByID retrieves an employee by their id
+func (r *memoryEmployeeRepository) FindByID(ctx context.Context, id int64) (*models.Employee, error) {
+	var emp models.Employee
+	err := r.read(func(st *memoryState) error {
+		e, ok := st.employees[id]
+		if !ok {
+			return ErrEmployeeNotFound
+		}
+		emp = e
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emp, nil
+}
+
+// FindByUUID retrieves an employee by their uuid
+func (r *memoryEmployeeRepository) FindByUUID(ctx context.Context, uuid string) (*models.Employee, error) {
+	var emp *models.Employee
+	err := r.read(func(st *memoryState) error {
+		for _, e := range st.employees {
+			if e.UUID == uuid {
+				emp = &e
+				return nil
+			}
+		}
+		return ErrEmployeeNotFound
+	})
+	return emp, err
+}
+
+// Snapshot lists the employees after afterID by id with their latest
+// event version
+func (r *memoryEmployeeRepository) Snapshot(ctx context.Context, afterID int64, limit int) ([]models.VersionedEmployee, error) {
+	employees := []models.VersionedEmployee{}
+	err := r.read(func(st *memoryState) error {
+		for _, e := range st.employees {
+			if e.ID > afterID {
+				employees = append(employees, models.VersionedEmployee{Employee: e, Version: st.version(e.ID)})
+			}
+		}
+		return nil
+	})
+
+	sort.Slice(employees, func(i, j int) bool { return employees[i].ID < employees[j].ID })
+	if len(employees) > limit {
+		employees = employees[:limit]
+	}
+
+	return employees, err
+}
+
+// filter returns the employees matching filters, newest first
+func (st *memoryState) filter(filters map[string]interface{}) []models.Employee {
+	matches := func(value string, key string) bool {
+		want, ok := filters[key]
+		return !ok || filterMatches(want, value)
+	}
+
+	employees := []models.Employee{}
+	for _, e := range st.employees {
+		var address models.Address
+		if e.Address != nil {
+			address = *e.Address
+		}
+		if matches(e.Email, "email") && matches(e.Department, "department") && matches(string(e.Status), "status") && matches(e.Position, "position") &&
+			matches(address.Country, "country") && matches(address.City, "city") {
+			employees = append(employees, e)
+		}
+	}
+
+	sort.Slice(employees, func(i, j int) bool {
+		if !employees[i].CreatedAt.Equal(employees[j].CreatedAt) {
+			return employees[i].CreatedAt.After(employees[j].CreatedAt)
+		}
+		return employees[i].ID > employees[j].ID
+	})
+	return employees
+}
+
+// FindAll retrieves a page of the employees matching filters
+func (r *memoryEmployeeRepository) FindAll(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, error) {
+	if err := rejectPostgresFilters(filters); err != nil {
+		return nil, err
+	}
+	var employees []models.Employee
+	err := r.read(func(st *memoryState) error {
+		employees = st.filter(filters)
+		return nil
+	})
+
+	employees = employees[min(offset, len(employees)):]
+	return employees[:min(limit, len(employees))], err
+}
+
+// FindPage retrieves a page of the employees matching filters with their
+// total, from a single read of the store
+func (r *memoryEmployeeRepository) FindPage(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, int, error) {
+	if err := rejectPostgresFilters(filters); err != nil {
+		return nil, 0, err
+	}
+	var employees []models.Employee
+	err := r.read(func(st *memoryState) error {
+		employees = st.filter(filters)
+		return nil
+	})
+
+	total := len(employees)
+	employees = employees[min(offset, len(employees)):]
+	return employees[:min(limit, len(employees))], total, err
+}
+
+// Count returns the number of employees matching filters
+func (r *memoryEmployeeRepository) Count(ctx context.Context, filters map[string]interface{}) (int, error) {
+	if err := rejectPostgresFilters(filters); err != nil {
+		return 0, err
+	}
+	var count int
+	err := r.read(func(st *memoryState) error {
+		count = len(st.filter(filters))
+		return nil
+	})
+	return count, err
+}
+
+// FindAllStream calls fn for every employee matching filters, by id. The
+// matching employees are copied first, so fn runs without holding the
+// store
+func (r *memoryEmployeeRepository) FindAllStream(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error {
+	if err := rejectPostgresFilters(filters); err != nil {
+		return err
+	}
+	var employees []models.Employee
+	if err := r.read(func(st *memoryState) error {
+		employees = st.filter(filters)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	sort.Slice(employees, func(i, j int) bool { return employees[i].ID < employees[j].ID })
+	for _, e := range employees {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update replaces an existing employee, keeping their hire and creation dates
+func (r *memoryEmployeeRepository) Update(ctx context.Context, e *models.Employee) error {
+	return r.write(func(st *memoryState) error {
+		current, ok := st.employees[e.ID]
+		if !ok {
+			return ErrEmployeeNotFound
+		}
+		if err := st.unique(e); err != nil {
+			return err
+		}
+
+		current.FirstName, current.LastName, current.Email = e.FirstName, e.LastName, e.Email
+		current.EmployeeNumber, current.Position, current.Department = e.EmployeeNumber, e.Position, e.Department
+		current.Phone, current.DateOfBirth, current.NationalID = e.Phone, e.DateOfBirth, e.NationalID
+		current.Gender, current.PersonalEmail, current.Address = e.Gender, e.PersonalEmail, e.Address
+		current.EmailVerifiedAt = e.EmailVerifiedAt
+		current.Status, current.UpdatedAt = e.Status, time.Now().UTC()
+		st.employees[e.ID] = current
+
+		e.UUID, e.UpdatedAt = current.UUID, current.UpdatedAt
+		return nil
+	})
+}
+
+// Delete removes an employee by id
+func (r *memoryEmployeeRepository) Delete(ctx context.Context, id int64) error {
+	return r.write(func(st *memoryState) error {
+		if _, ok := st.employees[id]; !ok {
+			return ErrEmployeeNotFound
+		}
+		delete(st.employees, id)
+		return nil
+	})
+}
+
+// memoryOutboxRepository is the in-memory implementation of
+// OutboxRepository
+type memoryOutboxRepository struct {
+	store *MemoryStore
+}
+
+// Dispatch publishes the due events in order. Events are published outside
+// the store lock and settled afterwards, the store has a single dispatcher
+func (r *memoryOutboxRepository) Dispatch(ctx context.Context, limit int, publish func(ctx context.Context, evt events.Event) error, backoff func(attempts int) time.Duration) (int, error) {
+	var batch []memoryEvent
+	_ = r.store.read(func(st *memoryState) error {
+		now := time.Now()
+		for _, m := range st.outbox {
+			if len(batch) == limit {
+				break
+			}
+			if !m.published && !m.nextAttemptAt.After(now) {
+				batch = append(batch, m)
+			}
+		}
+		return nil
+	})
+
+	published := 0
+	results := make([]error, len(batch))
+	for i, m := range batch {
+		results[i] = publish(ctx, m.evt)
+		if results[i] == nil {
+			published++
+		}
+	}
+
+	err := r.store.write(func(st *memoryState) error {
+		for i, m := range batch {
+			row := &st.outbox[m.evt.Sequence-1]
+			row.attempts++
+			if results[i] != nil {
+				row.nextAttemptAt = time.Now().Add(backoff(row.attempts))
+				continue
+			}
+			row.published = true
+		}
+		return nil
+	})
+
+	return published, err
+}
+
+// FindAfter returns the events after afterSeq in order
+func (r *memoryOutboxRepository) FindAfter(ctx context.Context, afterSeq int64, limit int) ([]events.Event, error) {
+	result := []events.Event{}
+	err := r.store.read(func(st *memoryState) error {
+		for _, m := range st.outbox[min(max(afterSeq, 0), int64(len(st.outbox))):] {
+			if len(result) == limit {
+				break
+			}
+			result = append(result, m.evt)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// LastSequence returns the sequence of the newest event
+func (r *memoryOutboxRepository) LastSequence(ctx context.Context) (int64, error) {
+	var seq int64
+	err := r.store.read(func(st *memoryState) error {
+		seq = int64(len(st.outbox))
+		return nil
+	})
+	return seq, err
+}
@@ -0,0 +1,729 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readconcern"
+)
+
+// mongoClaim is how long Dispatch holds the events it is publishing before
+// another instance may pick them up
+const mongoClaim = time.Minute
+
+// mongoEmployee is the document of an employee. Ids are sequential like
+// in PostgreSQL, drawn from the counters collection
+type mongoEmployee struct {
+	ID             int64          `bson:"_id"`
+	UUID           string         `bson:"uuid"`
+	FirstName      string         `bson:"first_name"`
+	LastName       string         `bson:"last_name"`
+	Email          string         `bson:"email"`
+	EmployeeNumber string         `bson:"employee_number"`
+	Position       string         `bson:"position"`
+	Department     string         `bson:"department"`
+	Status         string         `bson:"status"`
+	HireDate       time.Time      `bson:"hire_date"`
+	ProbationEnd   *time.Time     `bson:"probation_end_date"`
+	EmailVerified  *time.Time     `bson:"email_verified_at"`
+	Phone          *string        `bson:"phone"`
+	DateOfBirth    *time.Time     `bson:"date_of_birth"`
+	NationalID     *string        `bson:"national_id"`
+	Gender         *models.Gender `bson:"gender"`
+	PersonalEmail  *string        `bson:"personal_email"`
+	Address        mongoAddress   `bson:",inline"`
+	CreatedAt      time.Time      `bson:"created_at"`
+	UpdatedAt      time.Time      `bson:"updated_at"`
+}
+
+// mongoAddress holds the address in top level fields named like the SQL
+// columns, so the country and city filters apply unchanged
+type mongoAddress struct {
+	Street     *string `bson:"address_street"`
+	City       *string `bson:"address_city"`
+	State      *string `bson:"address_state"`
+	PostalCode *string `bson:"address_postal_code"`
+	Country    *string `bson:"address_country"`
+}
+
+// newMongoAddress converts an optional address to its fields
+func newMongoAddress(a *models.Address) mongoAddress {
+	if a == nil {
+		return mongoAddress{}
+	}
+	return mongoAddress{
+		Street:     &a.Street,
+		City:       &a.City,
+		State:      nullString(a.State),
+		PostalCode: nullString(a.PostalCode),
+		Country:    &a.Country,
+	}
+}
+
+// model returns the address, nil when the employee has none
+func (a mongoAddress) model() *models.Address {
+	return addressColumns{a.Street, a.City, a.State, a.PostalCode, a.Country}.model()
+}
+
+// mongoDate converts an optional date to the time stored for it
+func mongoDate(d *models.Date) *time.Time {
+	if d == nil {
+		return nil
+	}
+	t := d.Time()
+	return &t
+}
+
+// modelDate converts an optional stored time back to its date
+func modelDate(t *time.Time) *models.Date {
+	if t == nil {
+		return nil
+	}
+	d := models.NewDate(t.UTC().Date())
+	return &d
+}
+
+// utcTime returns an optional stored time in UTC
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+// model converts the document to an Employee
+func (d mongoEmployee) model() models.Employee {
+	y, m, day := d.HireDate.UTC().Date()
+	return models.Employee{
+		ID:               d.ID,
+		UUID:             d.UUID,
+		FirstName:        d.FirstName,
+		LastName:         d.LastName,
+		Email:            d.Email,
+		EmployeeNumber:   d.EmployeeNumber,
+		Position:         d.Position,
+		Department:       d.Department,
+		Status:           models.EmployeeStatus(d.Status),
+		HireDate:         models.NewDate(y, m, day),
+		ProbationEndDate: modelDate(d.ProbationEnd),
+		Phone:            d.Phone,
+		DateOfBirth:      modelDate(d.DateOfBirth),
+		NationalID:       d.NationalID,
+		Gender:           d.Gender,
+		PersonalEmail:    d.PersonalEmail,
+		Address:          d.Address.model(),
+		EmailVerifiedAt:  utcTime(d.EmailVerified),
+		CreatedAt:        d.CreatedAt.UTC(),
+		UpdatedAt:        d.UpdatedAt.UTC(),
+	}
+}
+
+// mongoEvent is the document of an outbox event. The payload is kept as
+// the JSON text it is published with
+type mongoEvent struct {
+	Sequence         int64      `bson:"sequence"`
+	EventID          string     `bson:"event_id"`
+	EventType        string     `bson:"event_type"`
+	AggregateID      int64      `bson:"aggregate_id"`
+	AggregateVersion int64      `bson:"aggregate_version"`
+	Payload          string     `bson:"payload"`
+	OccurredAt       time.Time  `bson:"occurred_at"`
+	Attempts         int        `bson:"attempts"`
+	LastError        *string    `bson:"last_error"`
+	NextAttemptAt    time.Time  `bson:"next_attempt_at"`
+	PublishedAt      *time.Time `bson:"published_at"`
+}
+
+// event converts the document to an Event
+func (d mongoEvent) event() events.Event {
+	return events.Event{
+		Sequence:    d.Sequence,
+		ID:          d.EventID,
+		Type:        events.Type(d.EventType),
+		AggregateID: d.AggregateID,
+		Version:     d.AggregateVersion,
+		OccurredAt:  d.OccurredAt.UTC(),
+		Payload:     json.RawMessage(d.Payload),
+	}
+}
+
+// nextMongoSequence draws the next value of the named counter
+func nextMongoSequence(ctx context.Context, db *mongo.Database, name string) (int64, error) {
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := db.Collection("counters").FindOneAndUpdate(ctx,
+		bson.M{"_id": name},
+		bson.M{"$inc": bson.M{"seq": int64(1)}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to draw %s id: %w", name, err)
+	}
+	return counter.Seq, nil
+}
+
+// mongoEmployeeRepository is the MongoDB implementation of
+// EmployeeRepository. Transactions need a replica set
+type mongoEmployeeRepository struct {
+	db      *mongo.Database
+	session *mongo.Session // session of the current transaction, nil outside one
+}
+
+// NewMongoEmployeeRepository creates a new EmployeeRepository on MongoDB
+func NewMongoEmployeeRepository(db *mongo.Database) EmployeeRepository {
+	return &mongoEmployeeRepository{db: db}
+}
+
+// ctx binds ctx to the transaction, if any
+func (r *mongoEmployeeRepository) ctx(ctx context.Context) context.Context {
+	if r.session != nil {
+		return mongo.NewSessionContext(ctx, r.session)
+	}
+	return ctx
+}
+
+// transaction runs fn in a transaction with the given options, or in the
+// current one. The driver retries fn on transient errors
+func (r *mongoEmployeeRepository) transaction(ctx context.Context, opts *options.TransactionOptionsBuilder, fn func(repo *mongoEmployeeRepository) error) error {
+	if r.session != nil {
+		return fn(r)
+	}
+
+	session, err := r.db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.Background())
+
+	_, err = session.WithTransaction(ctx, func(context.Context) (any, error) {
+		return nil, fn(&mongoEmployeeRepository{db: r.db, session: session})
+	}, opts)
+	return err
+}
+
+// WithTx runs fn inside a transaction. MongoDB has no savepoints, so
+// inside a transaction fn runs in it and its failure aborts all of it
+func (r *mongoEmployeeRepository) WithTx(ctx context.Context, fn func(repo EmployeeRepository) error) error {
+	return r.transaction(ctx, options.Transaction(), func(repo *mongoEmployeeRepository) error {
+		return fn(repo)
+	})
+}
+
+// AppendEvent inserts the event into the outbox, one version past the
+// latest event of the employee
+func (r *mongoEmployeeRepository) AppendEvent(ctx context.Context, evt *events.Event) error {
+	ctx = r.ctx(ctx)
+
+	version, err := r.versions(ctx, []int64{evt.AggregateID})
+	if err != nil {
+		return fmt.Errorf("failed to append outbox event: %w", err)
+	}
+	seq, err := nextMongoSequence(ctx, r.db, "outbox")
+	if err != nil {
+		return fmt.Errorf("failed to append outbox event: %w", err)
+	}
+
+	doc := mongoEvent{
+		Sequence:         seq,
+		EventID:          evt.ID,
+		EventType:        string(evt.Type),
+		AggregateID:      evt.AggregateID,
+		AggregateVersion: version[evt.AggregateID] + 1,
+		Payload:          string(evt.Payload),
+		OccurredAt:       evt.OccurredAt.UTC(),
+		NextAttemptAt:    evt.OccurredAt.UTC(),
+	}
+	if _, err := r.db.Collection("outbox").InsertOne(ctx, doc); err != nil {
+		return fmt.Errorf("failed to append outbox event: %w", err)
+	}
+
+	evt.Version = doc.AggregateVersion
+	return nil
+}
+
+// AppendProbationAudit inserts the entry into the probation_audit
+// collection
+func (r *mongoEmployeeRepository) AppendProbationAudit(ctx context.Context, entry *models.ProbationAuditEntry) error {
+	ctx = r.ctx(ctx)
+
+	id, err := nextMongoSequence(ctx, r.db, "probation_audit")
+	if err != nil {
+		return fmt.Errorf("failed to insert probation audit entry: %w", err)
+	}
+	endedAt := time.Now().UTC()
+
+	_, err = r.db.Collection("probation_audit").InsertOne(ctx, bson.M{
+		"_id":                id,
+		"employee_id":        entry.EmployeeID,
+		"employee_number":    entry.EmployeeNumber,
+		"probation_end_date": mongoDate(entry.ProbationEndDate),
+		"reason":             entry.Reason,
+		"ended_at":           endedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert probation audit entry: %w", err)
+	}
+
+	entry.ID, entry.EndedAt = id, endedAt
+	return nil
+}
+
+// versions returns the version of the latest event of each employee, ids
+// without events are missing
+func (r *mongoEmployeeRepository) versions(ctx context.Context, ids []int64) (map[int64]int64, error) {
+	cursor, err := r.db.Collection("outbox").Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"aggregate_id": bson.M{"$in": ids}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$aggregate_id", "version": bson.M{"$max": "$aggregate_version"}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		ID      int64 `bson:"_id"`
+		Version int64 `bson:"version"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	versions := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		versions[row.ID] = row.Version
+	}
+	return versions, nil
+}
+
+// mongoDuplicate maps a duplicate key error to the domain error of the
+// unique index, nil for other errors
+func mongoDuplicate(err error) error {
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+
+	switch {
+	case strings.Contains(err.Error(), "employees_email_key"):
+		return ErrEmailAlreadyExists
+	case strings.Contains(err.Error(), "employees_employee_number_key"):
+		return ErrEmployeeNumberAlreadyExists
+	default:
+		return ErrEmployeeAlreadyExists
+	}
+}
+
+// Create adds a new employee with the next id
+func (r *mongoEmployeeRepository) Create(ctx context.Context, e *models.Employee) error {
+	ctx = r.ctx(ctx)
+
+	id, err := nextMongoSequence(ctx, r.db, "employees")
+	if err != nil {
+		return err
+	}
+
+	// MongoDB keeps milliseconds
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	doc := mongoEmployee{
+		ID:             id,
+		UUID:           employeeUUID(e),
+		FirstName:      e.FirstName,
+		LastName:       e.LastName,
+		Email:          e.Email,
+		EmployeeNumber: e.EmployeeNumber,
+		Position:       e.Position,
+		Department:     e.Department,
+		Status:         string(e.Status),
+		HireDate:       e.HireDate.Time(),
+		ProbationEnd:   mongoDate(e.ProbationEndDate),
+		EmailVerified:  e.EmailVerifiedAt,
+		Phone:          e.Phone,
+		DateOfBirth:    mongoDate(e.DateOfBirth),
+		NationalID:     e.NationalID,
+		Gender:         e.Gender,
+		PersonalEmail:  e.PersonalEmail,
+		Address:        newMongoAddress(e.Address),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if _, err := r.db.Collection("employees").InsertOne(ctx, doc); err != nil {
+		if dup := mongoDuplicate(err); dup != nil {
+			return dup
+		}
+		return err
+	}
+
+	e.ID, e.CreatedAt, e.UpdatedAt = id, now, now
+	return nil
+}
+
+// FindByID retrieves an employee by their id
+func (r *mongoEmployeeRepository) FindByID(ctx context.Context, id int64) (*models.Employee, error) {
+	var doc mongoEmployee
+	if err := r.db.Collection("employees").FindOne(r.ctx(ctx), bson.M{"_id": id}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrEmployeeNotFound
+		}
+		return nil, err
+	}
+
+	emp := doc.model()
+	return &emp, nil
+}
+
+// FindByUUID retrieves an employee by their uuid
+func (r *mongoEmployeeRepository) FindByUUID(ctx context.Context, uuid string) (*models.Employee, error) {
+	var doc mongoEmployee
+	if err := r.db.Collection("employees").FindOne(r.ctx(ctx), bson.M{"uuid": uuid}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrEmployeeNotFound
+		}
+		return nil, err
+	}
+
+	emp := doc.model()
+	return &emp, nil
+}
+
+// Snapshot reads a page of employees by id with their latest event version
+// in a snapshot transaction, so both are from the same point in time
+func (r *mongoEmployeeRepository) Snapshot(ctx context.Context, afterID int64, limit int) ([]models.VersionedEmployee, error) {
+	employees := []models.VersionedEmployee{}
+
+	opts := options.Transaction().SetReadConcern(readconcern.Snapshot())
+	err := r.transaction(ctx, opts, func(repo *mongoEmployeeRepository) error {
+		ctx := repo.ctx(ctx)
+		employees = employees[:0]
+
+		cursor, err := repo.db.Collection("employees").Find(ctx,
+			bson.M{"_id": bson.M{"$gt": afterID}},
+			options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit)),
+		)
+		if err != nil {
+			return err
+		}
+		var docs []mongoEmployee
+		if err := cursor.All(ctx, &docs); err != nil {
+			return err
+		}
+
+		ids := make([]int64, len(docs))
+		for i, d := range docs {
+			ids[i] = d.ID
+		}
+		versions, err := repo.versions(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range docs {
+			employees = append(employees, models.VersionedEmployee{Employee: d.model(), Version: versions[d.ID]})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return employees, nil
+}
+
+// mongoFilter translates the FindAll and Count filters to a query,
+// []string values with $in
+func mongoFilter(filters map[string]interface{}) bson.M {
+	query := bson.M{}
+	for field, value := range filterEq(filters) {
+		if values, ok := value.([]string); ok {
+			query[field] = bson.M{"$in": values}
+			continue
+		}
+		query[field] = value
+	}
+	return query
+}
+
+// FindAll retrives a page of the employees matching filters, newest first
+func (r *mongoEmployeeRepository) FindAll(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, error) {
+	if err := rejectPostgresFilters(filters); err != nil {
+		return nil, err
+	}
+	ctx = r.ctx(ctx)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := r.db.Collection("employees").Find(ctx, mongoFilter(filters), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query employees: %w", err)
+	}
+
+	var docs []mongoEmployee
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("failed to decode employees: %w", err)
+	}
+
+	var employees []models.Employee
+	for _, d := range docs {
+		employees = append(employees, d.model())
+	}
+
+	return employees, nil
+}
+
+// FindPage retrieves a page of the employees matching filters with their
+// total, both from one $facet aggregation
+func (r *mongoEmployeeRepository) FindPage(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, int, error) {
+	if err := rejectPostgresFilters(filters); err != nil {
+		return nil, 0, err
+	}
+	ctx = r.ctx(ctx)
+
+	pipeline := bson.A{
+		bson.M{"$match": mongoFilter(filters)},
+		bson.M{"$facet": bson.M{
+			"items": bson.A{
+				bson.M{"$sort": bson.D{{Key: "created_at", Value: -1}}},
+				bson.M{"$skip": int64(offset)},
+				bson.M{"$limit": int64(limit)},
+			},
+			"total": bson.A{bson.M{"$count": "n"}},
+		}},
+	}
+	cursor, err := r.db.Collection("employees").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query employees: %w", err)
+	}
+
+	var result []struct {
+		Items []mongoEmployee `bson:"items"`
+		Total []struct {
+			N int `bson:"n"`
+		} `bson:"total"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode employees: %w", err)
+	}
+
+	var employees []models.Employee
+	var total int
+	if len(result) > 0 {
+		for _, d := range result[0].Items {
+			employees = append(employees, d.model())
+		}
+		if len(result[0].Total) > 0 {
+			total = result[0].Total[0].N
+		}
+	}
+
+	return employees, total, nil
+}
+
+// Count returns the number of employees matching filters
+func (r *mongoEmployeeRepository) Count(ctx context.Context, filters map[string]interface{}) (int, error) {
+	if err := rejectPostgresFilters(filters); err != nil {
+		return 0, err
+	}
+	count, err := r.db.Collection("employees").CountDocuments(r.ctx(ctx), mongoFilter(filters))
+	return int(count), err
+}
+
+// FindAllStream calls fn for every employee matching filters, by id,
+// decoding them one at a time from the cursor
+func (r *mongoEmployeeRepository) FindAllStream(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error {
+	if err := rejectPostgresFilters(filters); err != nil {
+		return err
+	}
+	ctx = r.ctx(ctx)
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.db.Collection("employees").Find(ctx, mongoFilter(filters), opts)
+	if err != nil {
+		return fmt.Errorf("failed to query employees: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc mongoEmployee
+		if err := cursor.Decode(&doc); err != nil {
+			return fmt.Errorf("failed to decode employee: %w", err)
+		}
+		if err := fn(doc.model()); err != nil {
+			return err
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("error iterating employees: %w", err)
+	}
+
+	return nil
+}
+
+// Update modifies an existing employee, their hire date is kept
+func (r *mongoEmployeeRepository) Update(ctx context.Context, e *models.Employee) error {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	address := newMongoAddress(e.Address)
+	var updated struct {
+		UUID string `bson:"uuid"`
+	}
+	err := r.db.Collection("employees").FindOneAndUpdate(r.ctx(ctx),
+		bson.M{"_id": e.ID},
+		bson.M{"$set": bson.M{
+			"first_name":      e.FirstName,
+			"last_name":       e.LastName,
+			"email":           e.Email,
+			"employee_number": e.EmployeeNumber,
+			"position":        e.Position,
+			"department":      e.Department,
+			"status":          string(e.Status),
+			"phone":           e.Phone,
+			"date_of_birth":   mongoDate(e.DateOfBirth),
+			"national_id":     e.NationalID,
+			"gender":          e.Gender,
+			"personal_email":  e.PersonalEmail,
+			colStreet:         address.Street,
+			colCity:           address.City,
+			colState:          address.State,
+			colPostalCode:     address.PostalCode,
+			colCountry:        address.Country,
+			colEmailVerified:  e.EmailVerifiedAt,
+			"updated_at":      now,
+		}},
+		options.FindOneAndUpdate().SetProjection(bson.M{"uuid": 1}),
+	).Decode(&updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrEmployeeNotFound
+	}
+	if err != nil {
+		if dup := mongoDuplicate(err); dup != nil {
+			return dup
+		}
+		return fmt.Errorf("failed to update employee: %w", err)
+	}
+
+	e.UUID, e.UpdatedAt = updated.UUID, now
+	return nil
+}
+
+// Delete removes an employee by id
+func (r *mongoEmployeeRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.Collection("employees").DeleteOne(r.ctx(ctx), bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete employee: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return ErrEmployeeNotFound
+	}
+
+	return nil
+}
+
+// mongoOutboxRepository is the MongoDB implementation of OutboxRepository
+type mongoOutboxRepository struct {
+	db *mongo.Database
+}
+
+// NewMongoOutboxRepository creates a new OutboxRepository on MongoDB
+func NewMongoOutboxRepository(db *mongo.Database) OutboxRepository {
+	return &mongoOutboxRepository{db: db}
+}
+
+// Dispatch publishes a batch of due events. MongoDB has no SKIP LOCKED, so
+// each event is claimed by moving its next attempt mongoClaim ahead, and
+// an event another instance claimed first is left to it
+func (r *mongoOutboxRepository) Dispatch(ctx context.Context, limit int, publish func(ctx context.Context, evt events.Event) error, backoff func(attempts int) time.Duration) (int, error) {
+	outbox := r.db.Collection("outbox")
+	now := time.Now().UTC()
+
+	cursor, err := outbox.Find(ctx,
+		bson.M{"published_at": nil, "next_attempt_at": bson.M{"$lte": now}},
+		options.Find().SetSort(bson.D{{Key: "sequence", Value: 1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query outbox: %w", err)
+	}
+	var batch []mongoEvent
+	if err := cursor.All(ctx, &batch); err != nil {
+		return 0, fmt.Errorf("failed to decode outbox events: %w", err)
+	}
+
+	published := 0
+	for _, d := range batch {
+		claim, err := outbox.UpdateOne(ctx,
+			bson.M{"sequence": d.Sequence, "published_at": nil, "next_attempt_at": d.NextAttemptAt},
+			bson.M{"$set": bson.M{"next_attempt_at": now.Add(mongoClaim)}},
+		)
+		if err != nil {
+			return published, fmt.Errorf("failed to claim outbox event: %w", err)
+		}
+		if claim.ModifiedCount == 0 {
+			continue
+		}
+
+		update := bson.M{
+			"$inc": bson.M{"attempts": 1},
+			"$set": bson.M{"last_error": nil, "published_at": time.Now().UTC()},
+		}
+		if err := publish(ctx, d.event()); err != nil {
+			update["$set"] = bson.M{
+				"last_error":      err.Error(),
+				"next_attempt_at": time.Now().UTC().Add(backoff(d.Attempts + 1)),
+			}
+		} else {
+			published++
+		}
+
+		if _, err := outbox.UpdateOne(ctx, bson.M{"sequence": d.Sequence}, update); err != nil {
+			return published, fmt.Errorf("failed to settle outbox event: %w", err)
+		}
+	}
+
+	return published, nil
+}
+
+// FindAfter reads events from the outbox in sequence order
+func (r *mongoOutboxRepository) FindAfter(ctx context.Context, afterSeq int64, limit int) ([]events.Event, error) {
+	cursor, err := r.db.Collection("outbox").Find(ctx,
+		bson.M{"sequence": bson.M{"$gt": afterSeq}},
+		options.Find().SetSort(bson.D{{Key: "sequence", Value: 1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outbox: %w", err)
+	}
+
+	var docs []mongoEvent
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("failed to decode outbox events: %w", err)
+	}
+
+	result := make([]events.Event, len(docs))
+	for i, d := range docs {
+		result[i] = d.event()
+	}
+
+	return result, nil
+}
+
+// LastSequence returns the sequence of the newest event, 0 if empty
+func (r *mongoOutboxRepository) LastSequence(ctx context.Context) (int64, error) {
+	var doc mongoEvent
+	err := r.db.Collection("outbox").FindOne(ctx, bson.M{},
+		options.FindOne().SetSort(bson.D{{Key: "sequence", Value: -1}}),
+	).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+	return doc.Sequence, err
+}
@@ -0,0 +1,556 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL error numbers mapped to domain errors
+const (
+	mysqlDuplicateEntry    = 1062
+	mysqlTableAccessDenied = 1142
+	mysqlTableDoesNotExist = 1146
+	mysqlRowIsReferenced   = 1451
+)
+
+// sqlDBTX is implemented by both *sql.DB and *sql.Tx
+type sqlDBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// mysqlEmployeeRepository is the MySQL implementation of EmployeeRepository
+type mysqlEmployeeRepository struct {
+	db        *sql.DB
+	tx        *sql.Tx // current transaction, nil outside one
+	savepoint int     // nesting depth of WithTx inside tx
+}
+
+// NewMySQLEmployeeRepository creates a new EmployeeRepository on MySQL
+func NewMySQLEmployeeRepository(db *sql.DB) EmployeeRepository {
+	return &mysqlEmployeeRepository{db: db}
+}
+
+// conn returns the transaction or the pool
+func (r *mysqlEmployeeRepository) conn() sqlDBTX {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+// WithTx runs fn inside a transaction (or a savepoint when already in one)
+func (r *mysqlEmployeeRepository) WithTx(ctx context.Context, fn func(repo EmployeeRepository) error) error {
+	if r.tx != nil {
+		name := fmt.Sprintf("sp_%d", r.savepoint+1)
+		if _, err := r.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+			return err
+		}
+		if err := fn(&mysqlEmployeeRepository{db: r.db, tx: r.tx, savepoint: r.savepoint + 1}); err != nil {
+			_, _ = r.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			return err
+		}
+		_, err := r.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(&mysqlEmployeeRepository{db: r.db, tx: tx}); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// AppendEvent inserts the event into the outbox, one version past the
+// latest event of the employee
+func (r *mysqlEmployeeRepository) AppendEvent(ctx context.Context, evt *events.Event) error {
+	query := `
+        INSERT INTO outbox (event_id, event_type, aggregate_id, aggregate_version, payload, occurred_at)
+        SELECT ?, ?, ?, COALESCE(MAX(aggregate_version), 0) + 1, ?, ?
+        FROM outbox
+        WHERE aggregate_id = ?
+    `
+
+	result, err := r.conn().ExecContext(ctx, query, evt.ID, evt.Type, evt.AggregateID, evt.Payload, evt.OccurredAt.UTC(), evt.AggregateID)
+	if err != nil {
+		return fmt.Errorf("failed to append outbox event: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to append outbox event: %w", err)
+	}
+
+	err = r.conn().QueryRowContext(ctx, `SELECT aggregate_version FROM outbox WHERE id = ?`, id).Scan(&evt.Version)
+	if err != nil {
+		return fmt.Errorf("failed to read outbox event version: %w", err)
+	}
+
+	return nil
+}
+
+// AppendProbationAudit inserts the entry into the probation audit log
+func (r *mysqlEmployeeRepository) AppendProbationAudit(ctx context.Context, entry *models.ProbationAuditEntry) error {
+	query := `
+        INSERT INTO probation_audit (employee_id, employee_number, probation_end_date, reason, ended_at)
+        VALUES (?, ?, ?, ?, ?)
+    `
+
+	endedAt := time.Now().UTC()
+	result, err := r.conn().ExecContext(ctx, query, entry.EmployeeID, entry.EmployeeNumber, entry.ProbationEndDate, entry.Reason, endedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert probation audit entry: %w", err)
+	}
+	if entry.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("failed to insert probation audit entry: %w", err)
+	}
+	entry.EndedAt = endedAt
+
+	return nil
+}
+
+// mysqlDuplicate maps a duplicate key error to the domain error of the
+// unique key, nil for other errors
+func mysqlDuplicate(err error) error {
+	var myErr *mysql.MySQLError
+	if !errors.As(err, &myErr) || myErr.Number != mysqlDuplicateEntry {
+		return nil
+	}
+
+	switch {
+	case strings.Contains(myErr.Message, "employees_email_key"):
+		return ErrEmailAlreadyExists
+	case strings.Contains(myErr.Message, "employees_employee_number_key"):
+		return ErrEmployeeNumberAlreadyExists
+	default:
+		return ErrEmployeeAlreadyExists
+	}
+}
+
+// Create adds a new employee to the database
+func (r *mysqlEmployeeRepository) Create(ctx context.Context, e *models.Employee) error {
+	query := `
+        INSERT INTO employees
+        (first_name, last_name, email, employee_number, position, department, status, hire_date,
+         phone, date_of_birth, national_id, gender, personal_email,
+         address_street, address_city, address_state, address_postal_code, address_country, uuid,
+         probation_end_date, email_verified_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	args := []any{
+		e.FirstName,
+		e.LastName,
+		e.Email,
+		e.EmployeeNumber,
+		e.Position,
+		e.Department,
+		e.Status,
+		e.HireDate,
+		e.Phone,
+		e.DateOfBirth,
+		e.NationalID,
+		e.Gender,
+		e.PersonalEmail,
+	}
+	args = append(args, addressValues(e.Address)...)
+	args = append(args, employeeUUID(e), e.ProbationEndDate, e.EmailVerifiedAt)
+
+	result, err := r.conn().ExecContext(ctx, query, args...)
+	if err != nil {
+		if dup := mysqlDuplicate(err); dup != nil {
+			return dup
+		}
+		return err
+	}
+
+	if e.ID, err = result.LastInsertId(); err != nil {
+		return err
+	}
+
+	return r.conn().QueryRowContext(ctx, `SELECT created_at, updated_at FROM employees WHERE id = ?`, e.ID).Scan(&e.CreatedAt, &e.UpdatedAt)
+}
+
+// FindByID retrieves an employee by their id
+func (r *mysqlEmployeeRepository) FindByID(ctx context.Context, id int64) (*models.Employee, error) {
+	query := `SELECT ` + employeeColumnList + ` FROM employees WHERE id = ?`
+
+	var emp models.Employee
+	if err := scanEmployee(r.conn().QueryRowContext(ctx, query, id), &emp); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEmployeeNotFound
+		}
+		return nil, err
+	}
+
+	return &emp, nil
+}
+
+// FindByUUID retrieves an employee by their uuid
+func (r *mysqlEmployeeRepository) FindByUUID(ctx context.Context, uuid string) (*models.Employee, error) {
+	query := `SELECT ` + employeeColumnList + ` FROM employees WHERE uuid = ?`
+
+	var emp models.Employee
+	if err := scanEmployee(r.conn().QueryRowContext(ctx, query, uuid), &emp); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEmployeeNotFound
+		}
+		return nil, err
+	}
+
+	return &emp, nil
+}
+
+// Snapshot reads a page of employees by id with their latest event version
+// in one statement, so both are from the same point in time
+func (r *mysqlEmployeeRepository) Snapshot(ctx context.Context, afterID int64, limit int) ([]models.VersionedEmployee, error) {
+	query := `
+        SELECT ` + employeeColumnList + `,
+               COALESCE((SELECT MAX(o.aggregate_version) FROM outbox o WHERE o.aggregate_id = employees.id), 0)
+        FROM employees
+        WHERE id > ?
+        ORDER BY id
+        LIMIT ?
+    `
+
+	rows, err := r.conn().QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	employees := []models.VersionedEmployee{}
+	for rows.Next() {
+		var emp models.VersionedEmployee
+		if err := scanEmployee(rows, &emp.Employee, &emp.Version); err != nil {
+			return nil, err
+		}
+		employees = append(employees, emp)
+	}
+
+	return employees, rows.Err()
+}
+
+// FindAll retrives a page of the employees matching filters
+func (r *mysqlEmployeeRepository) FindAll(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, error) {
+	employees, _, err := r.findPage(ctx, limit, offset, filters, false)
+	return employees, err
+}
+
+// FindPage returns a page of the employees matching filters with their
+// total, counted by a window function over the same query
+func (r *mysqlEmployeeRepository) FindPage(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]models.Employee, int, error) {
+	employees, total, err := r.findPage(ctx, limit, offset, filters, true)
+	if err == nil && len(employees) == 0 && offset > 0 {
+		// A page past the end has no row to carry the total
+		total, err = r.Count(ctx, filters)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return employees, total, nil
+}
+
+// findPage selects a page of the employees matching filters, newest
+// first, and with withTotal the number of employees matching them
+func (r *mysqlEmployeeRepository) findPage(ctx context.Context, limit, offset int, filters map[string]interface{}, withTotal bool) ([]models.Employee, int, error) {
+	if err := rejectPostgresFilters(filters); err != nil {
+		return nil, 0, err
+	}
+	builder := selectEmployees(mysqlSQL, "employees", filters)
+	if withTotal {
+		builder = builder.Column("COUNT(*) OVER()")
+	}
+	query, args, err := builder.
+		OrderBy(colCreatedAt + " DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build employees query: %w", err)
+	}
+
+	rows, err := r.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		var myErr *mysql.MySQLError
+		if errors.As(err, &myErr) {
+			switch myErr.Number {
+			case mysqlTableDoesNotExist:
+				return nil, 0, fmt.Errorf("employees table does not exist: %w", err)
+			case mysqlTableAccessDenied:
+				return nil, 0, fmt.Errorf("insufficient privileges to access employees: %w", err)
+			}
+		}
+		return nil, 0, fmt.Errorf("failed to query employees: %w", err)
+	}
+	defer rows.Close()
+
+	var employees []models.Employee
+	var total int
+	for rows.Next() {
+		var emp models.Employee
+		var extra []any
+		if withTotal {
+			extra = append(extra, &total)
+		}
+		if err := scanEmployee(rows, &emp, extra...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan employee row: %w", err)
+		}
+		employees = append(employees, emp)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating employee rows: %w", err)
+	}
+
+	return employees, total, nil
+}
+
+// Count returns the number of employees matching filters
+func (r *mysqlEmployeeRepository) Count(ctx context.Context, filters map[string]interface{}) (int, error) {
+	if err := rejectPostgresFilters(filters); err != nil {
+		return 0, err
+	}
+	query, args, err := countEmployees(mysqlSQL, "employees", filters).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build employees count: %w", err)
+	}
+
+	var count int
+	err = r.conn().QueryRowContext(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// FindAllStream calls fn for every employee matching filters, by id
+func (r *mysqlEmployeeRepository) FindAllStream(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error {
+	if err := rejectPostgresFilters(filters); err != nil {
+		return err
+	}
+
+	query, args, err := selectEmployees(mysqlSQL, "employees", filters).
+		OrderBy(colID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build employees query: %w", err)
+	}
+
+	rows, err := r.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query employees: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var emp models.Employee
+		if err := scanEmployee(rows, &emp); err != nil {
+			return fmt.Errorf("failed to scan employee row: %w", err)
+		}
+		if err := fn(emp); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating employee rows: %w", err)
+	}
+
+	return nil
+}
+
+// Update modifies an existing employee record
+func (r *mysqlEmployeeRepository) Update(ctx context.Context, e *models.Employee) error {
+	query := `
+        UPDATE employees
+        SET first_name = ?, last_name = ?, email = ?,
+            employee_number = ?, position = ?, department = ?,
+            status = ?, phone = ?, date_of_birth = ?, national_id = ?,
+            gender = ?, personal_email = ?, address_street = ?,
+            address_city = ?, address_state = ?, address_postal_code = ?,
+            address_country = ?, email_verified_at = ?, updated_at = CURRENT_TIMESTAMP(6)
+        WHERE id = ?
+    `
+
+	args := []any{
+		e.FirstName,
+		e.LastName,
+		e.Email,
+		e.EmployeeNumber,
+		e.Position,
+		e.Department,
+		e.Status,
+		e.Phone,
+		e.DateOfBirth,
+		e.NationalID,
+		e.Gender,
+		e.PersonalEmail,
+	}
+	args = append(args, addressValues(e.Address)...)
+	args = append(args, e.EmailVerifiedAt, e.ID)
+
+	result, err := r.conn().ExecContext(ctx, query, args...)
+	if err != nil {
+		if dup := mysqlDuplicate(err); dup != nil {
+			return dup
+		}
+		return fmt.Errorf("failed to update employee: %w", err)
+	}
+
+	// The DSN sets clientFoundRows, so an update changing nothing still
+	// counts the row it matched
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to update employee: %w", err)
+	} else if n == 0 {
+		return ErrEmployeeNotFound
+	}
+
+	err = r.conn().QueryRowContext(ctx, "SELECT uuid, updated_at FROM employees WHERE id = ?", e.ID).Scan(&e.UUID, &e.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to get updated timestamp: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes an employee from the db by id
+func (r *mysqlEmployeeRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.conn().ExecContext(ctx, `DELETE FROM employees WHERE id = ?`, id)
+	if err != nil {
+		var myErr *mysql.MySQLError
+		if errors.As(err, &myErr) && myErr.Number == mysqlRowIsReferenced {
+			return fmt.Errorf("employee has related records and cannot be deleted: %w", err)
+		}
+		return fmt.Errorf("failed to delete employee: %w", err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to delete employee: %w", err)
+	} else if n == 0 {
+		return ErrEmployeeNotFound
+	}
+
+	return nil
+}
+
+// mysqlOutboxRepository is the MySQL implementation of OutboxRepository
+type mysqlOutboxRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLOutboxRepository creates a new OutboxRepository on MySQL
+func NewMySQLOutboxRepository(db *sql.DB) OutboxRepository {
+	return &mysqlOutboxRepository{db: db}
+}
+
+// Dispatch publishes a batch of due events inside a single transaction
+func (r *mysqlOutboxRepository) Dispatch(ctx context.Context, limit int, publish func(ctx context.Context, evt events.Event) error, backoff func(attempts int) time.Duration) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, event_id, event_type, aggregate_id, aggregate_version, payload, occurred_at, attempts
+        FROM outbox
+        WHERE published_at IS NULL AND next_attempt_at <= CURRENT_TIMESTAMP(6)
+        ORDER BY id
+        LIMIT ?
+        FOR UPDATE SKIP LOCKED`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query outbox: %w", err)
+	}
+
+	type pending struct {
+		attempts int
+		evt      events.Event
+	}
+
+	var batch []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.evt.Sequence, &p.evt.ID, &p.evt.Type, &p.evt.AggregateID, &p.evt.Version, &p.evt.Payload, &p.evt.OccurredAt, &p.attempts); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan outbox row: %w", err)
+		}
+		batch = append(batch, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating outbox rows: %w", err)
+	}
+
+	published := 0
+	for _, p := range batch {
+		if err := publish(ctx, p.evt); err != nil {
+			retryAt := time.Now().UTC().Add(backoff(p.attempts + 1))
+			_, err := tx.ExecContext(ctx,
+				`UPDATE outbox SET attempts = attempts + 1, last_error = ?, next_attempt_at = ? WHERE id = ?`,
+				err.Error(), retryAt, p.evt.Sequence,
+			)
+			if err != nil {
+				return 0, fmt.Errorf("failed to schedule outbox retry: %w", err)
+			}
+			continue
+		}
+
+		_, err := tx.ExecContext(ctx,
+			`UPDATE outbox SET attempts = attempts + 1, last_error = NULL, published_at = CURRENT_TIMESTAMP(6) WHERE id = ?`,
+			p.evt.Sequence,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to mark outbox event published: %w", err)
+		}
+		published++
+	}
+
+	return published, tx.Commit()
+}
+
+// FindAfter reads events from the outbox in sequence order
+func (r *mysqlOutboxRepository) FindAfter(ctx context.Context, afterSeq int64, limit int) ([]events.Event, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, event_id, event_type, aggregate_id, aggregate_version, payload, occurred_at
+        FROM outbox
+        WHERE id > ?
+        ORDER BY id
+        LIMIT ?`, afterSeq, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outbox: %w", err)
+	}
+	defer rows.Close()
+
+	result := []events.Event{}
+	for rows.Next() {
+		var e events.Event
+		if err := rows.Scan(&e.Sequence, &e.ID, &e.Type, &e.AggregateID, &e.Version, &e.Payload, &e.OccurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox row: %w", err)
+		}
+		result = append(result, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outbox rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// LastSequence returns the highest outbox id
+func (r *mysqlOutboxRepository) LastSequence(ctx context.Context) (int64, error) {
+	var seq int64
+	err := r.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM outbox`).Scan(&seq)
+	return seq, err
+}
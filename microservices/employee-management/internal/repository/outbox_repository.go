@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"employee-management/internal/events"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OutboxRepository reads and settles the pending events of the outbox
+type OutboxRepository interface {
+	// Dispatch locks up to limit due events, calls publish for each one
+	// in order and marks them published or schedules a retry after
+	// backoff(attempts). Rows are locked with SKIP LOCKED so several
+	// instances can dispatch concurrently
+	Dispatch(ctx context.Context, limit int, publish func(ctx context.Context, evt events.Event) error, backoff func(attempts int) time.Duration) (int, error)
+
+	// FindAfter returns up to limit events with a sequence greater than
+	// afterSeq in order, published or not
+	FindAfter(ctx context.Context, afterSeq int64, limit int) ([]events.Event, error)
+
+	// LastSequence returns the sequence of the newest event, 0 if empty
+	LastSequence(ctx context.Context) (int64, error)
+}
+
+// outboxRepository is the postgresql implementation of OutboxRepository
+type outboxRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository
+func NewOutboxRepository(db *pgxpool.Pool) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// Dispatch publishes a batch of due events inside a single transaction
+func (r *outboxRepository) Dispatch(ctx context.Context, limit int, publish func(ctx context.Context, evt events.Event) error, backoff func(attempts int) time.Duration) (int, error) {
+	published := 0
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `
+            SELECT id, event_id, event_type, aggregate_id, aggregate_version, payload, occurred_at, attempts
+            FROM employee.outbox
+            WHERE published_at IS NULL AND next_attempt_at <= CURRENT_TIMESTAMP
+            ORDER BY id
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        `
+
+		rows, err := tx.Query(ctx, query, limit)
+		if err != nil {
+			return fmt.Errorf("failed to query outbox: %w", err)
+		}
+
+		type pending struct {
+			rowID    int64
+			attempts int
+			evt      events.Event
+		}
+
+		var batch []pending
+		for rows.Next() {
+			var p pending
+			if err := rows.Scan(&p.rowID, &p.evt.ID, &p.evt.Type, &p.evt.AggregateID, &p.evt.Version, &p.evt.Payload, &p.evt.OccurredAt, &p.attempts); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan outbox row: %w", err)
+			}
+			p.evt.Sequence = p.rowID
+			batch = append(batch, p)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating outbox rows: %w", err)
+		}
+
+		for _, p := range batch {
+			if err := publish(ctx, p.evt); err != nil {
+				retryAt := time.Now().Add(backoff(p.attempts + 1))
+				_, err := tx.Exec(ctx,
+					`UPDATE employee.outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1`,
+					p.rowID, err.Error(), retryAt,
+				)
+				if err != nil {
+					return fmt.Errorf("failed to schedule outbox retry: %w", err)
+				}
+				continue
+			}
+
+			_, err := tx.Exec(ctx,
+				`UPDATE employee.outbox SET attempts = attempts + 1, last_error = NULL, published_at = CURRENT_TIMESTAMP WHERE id = $1`,
+				p.rowID,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to mark outbox event published: %w", err)
+			}
+			published++
+		}
+
+		return nil
+	})
+
+	return published, err
+}
+
+// FindAfter reads events from the outbox in sequence order
+func (r *outboxRepository) FindAfter(ctx context.Context, afterSeq int64, limit int) ([]events.Event, error) {
+	query := `
+        SELECT id, event_id, event_type, aggregate_id, aggregate_version, payload, occurred_at
+        FROM employee.outbox
+        WHERE id > $1
+        ORDER BY id
+        LIMIT $2
+    `
+
+	rows, err := r.db.Query(ctx, query, afterSeq, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outbox: %w", err)
+	}
+	defer rows.Close()
+
+	result := []events.Event{}
+	for rows.Next() {
+		var e events.Event
+		if err := rows.Scan(&e.Sequence, &e.ID, &e.Type, &e.AggregateID, &e.Version, &e.Payload, &e.OccurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox row: %w", err)
+		}
+		result = append(result, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outbox rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// LastSequence returns the highest outbox id
+func (r *outboxRepository) LastSequence(ctx context.Context) (int64, error) {
+	var seq int64
+	err := r.db.QueryRow(ctx, `SELECT COALESCE(MAX(id), 0) FROM employee.outbox`).Scan(&seq)
+	return seq, err
+}
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"employee-management/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// Columns of the employees table. Queries name them through these
+// constants so each column is spelled the same everywhere.
+// TestColumnsExistInMigrations checks them against the migrations, so a
+// column a migration renames must be renamed here too
+const (
+	colID             = "id"
+	colUUID           = "uuid"
+	colFirstName      = "first_name"
+	colLastName       = "last_name"
+	colEmail          = "email"
+	colEmployeeNumber = "employee_number"
+	colPosition       = "position"
+	colDepartment     = "department"
+	colStatus         = "status"
+	colHireDate       = "hire_date"
+	colProbationEnd   = "probation_end_date"
+	colPhone          = "phone"
+	colDateOfBirth    = "date_of_birth"
+	colNationalID     = "national_id"
+	colGender         = "gender"
+	colPersonalEmail  = "personal_email"
+	colStreet         = "address_street"
+	colCity           = "address_city"
+	colState          = "address_state"
+	colPostalCode     = "address_postal_code"
+	colCountry        = "address_country"
+	colEmailVerified  = "email_verified_at"
+	colCreatedAt      = "created_at"
+	colUpdatedAt      = "updated_at"
+)
+
+// employeeColumns are the columns of an employee in the order the scan
+// helpers expect them
+var employeeColumns = []string{
+	colID, colUUID, colFirstName, colLastName, colEmail, colEmployeeNumber,
+	colPosition, colDepartment, colStatus, colHireDate, colProbationEnd,
+	colPhone, colDateOfBirth, colNationalID, colGender, colPersonalEmail,
+	colStreet, colCity, colState, colPostalCode, colCountry,
+	colEmailVerified, colCreatedAt, colUpdatedAt,
+}
+
+// employeeColumnList is employeeColumns for hand written SELECTs
+var employeeColumnList = strings.Join(employeeColumns, ", ")
+
+// scanEmployee scans a row selected with employeeColumns, plus the extra
+// destinations given
+func scanEmployee(row interface{ Scan(dest ...any) error }, emp *models.Employee, extra ...any) error {
+	var addr addressColumns
+	dest := []any{
+		&emp.ID,
+		&emp.UUID,
+		&emp.FirstName,
+		&emp.LastName,
+		&emp.Email,
+		&emp.EmployeeNumber,
+		&emp.Position,
+		&emp.Department,
+		&emp.Status,
+		&emp.HireDate,
+		&emp.ProbationEndDate,
+		&emp.Phone,
+		&emp.DateOfBirth,
+		&emp.NationalID,
+		&emp.Gender,
+		&emp.PersonalEmail,
+		&addr.street,
+		&addr.city,
+		&addr.state,
+		&addr.postalCode,
+		&addr.country,
+		&emp.EmailVerifiedAt,
+		&emp.CreatedAt,
+		&emp.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	emp.Address = addr.model()
+	return nil
+}
+
+// addressColumns are the nullable address columns of an employee. They are
+// all NULL when the employee has no address
+type addressColumns struct {
+	street, city, state, postalCode, country *string
+}
+
+// model returns the address held by the columns, nil when they are NULL
+func (c addressColumns) model() *models.Address {
+	if c.street == nil && c.city == nil && c.country == nil {
+		return nil
+	}
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	return &models.Address{
+		Street:     deref(c.street),
+		City:       deref(c.city),
+		State:      deref(c.state),
+		PostalCode: deref(c.postalCode),
+		Country:    deref(c.country),
+	}
+}
+
+// addressValues returns the values of the address columns of a, in the
+// order of employeeColumns. Empty optional parts are stored as NULL
+func addressValues(a *models.Address) []any {
+	if a == nil {
+		return []any{nil, nil, nil, nil, nil}
+	}
+	return []any{a.Street, a.City, nullString(a.State), nullString(a.PostalCode), a.Country}
+}
+
+// employeeUUID returns the uuid of e, generating one when the caller did
+// not, e.g. employees created by the seed command
+func employeeUUID(e *models.Employee) string {
+	if e.UUID == "" {
+		e.UUID = uuid.NewString()
+	}
+	return e.UUID
+}
+
+// nullString returns nil for an empty s, stored as NULL
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// filterColumns maps the keys of the FindAll and Count filters to the
+// column they match exactly. email, stored lower-cased, looks up the SCIM
+// users by userName
+var filterColumns = map[string]string{
+	"email":      colEmail,
+	"department": colDepartment,
+	"status":     colStatus,
+	"position":   colPosition,
+	"country":    colCountry,
+	"city":       colCity,
+}
+
+// skillFilter is the FindAll and Count filter key of the employees holding
+// a skill, matched by name regardless of case. Skills live in PostgreSQL;
+// the other backends reject the filter
+const skillFilter = "skill"
+
+// archivedFilter is the FindAll and Count filter key that, when true,
+// reads the archived employees instead of the current ones. The archive
+// lives in PostgreSQL; the other backends reject the filter
+const archivedFilter = "archived"
+
+// Errors of the backends without skills or archive asked to filter by them
+var (
+	ErrSkillFilterUnsupported = errors.New("filtering by skill requires the postgres storage backend")
+	ErrArchiveUnsupported     = errors.New("archived employees require the postgres storage backend")
+)
+
+// rejectPostgresFilters returns ErrSkillFilterUnsupported or
+// ErrArchiveUnsupported when filters use a filter only PostgreSQL has
+func rejectPostgresFilters(filters map[string]interface{}) error {
+	if v, ok := filters[skillFilter]; ok && v != "" {
+		return ErrSkillFilterUnsupported
+	}
+	if archived(filters) {
+		return ErrArchiveUnsupported
+	}
+	return nil
+}
+
+// archived reports whether filters select the archived employees
+func archived(filters map[string]interface{}) bool {
+	v, _ := filters[archivedFilter].(bool)
+	return v
+}
+
+// Statement builders of the SQL backends
+var (
+	postgresSQL = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	mysqlSQL    = sq.StatementBuilder.PlaceholderFormat(sq.Question)
+)
+
+// filterEq translates the FindAll and Count filters to equality conditions
+// A []string value matches any of its values, with IN. Unknown keys and
+// empty values are ignored
+func filterEq(filters map[string]interface{}) sq.Eq {
+	eq := sq.Eq{}
+	for key, value := range filters {
+		column, ok := filterColumns[key]
+		if !ok || value == "" {
+			continue
+		}
+		if values, isList := value.([]string); isList && len(values) == 0 {
+			continue
+		}
+		eq[column] = value
+	}
+	return eq
+}
+
+// filterMatches reports whether value matches the filter want of
+// filterEq: equal to it, or to any of its values for a []string
+func filterMatches(want interface{}, value string) bool {
+	switch want := want.(type) {
+	case []string:
+		return len(want) == 0 || slices.Contains(want, value)
+	case string:
+		return want == "" || want == value
+	default:
+		return want == nil
+	}
+}
+
+// selectEmployees starts a SELECT of employeeColumns from table matching
+// filters
+func selectEmployees(b sq.StatementBuilderType, table string, filters map[string]interface{}) sq.SelectBuilder {
+	query := b.Select(employeeColumns...).From(table)
+	if eq := filterEq(filters); len(eq) > 0 {
+		query = query.Where(eq)
+	}
+	return query
+}
+
+// countEmployees builds a COUNT of the employees of table matching filters
+func countEmployees(b sq.StatementBuilderType, table string, filters map[string]interface{}) sq.SelectBuilder {
+	query := b.Select("COUNT(*)").From(table)
+	if eq := filterEq(filters); len(eq) > 0 {
+		query = query.Where(eq)
+	}
+	return query
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// migrationsDir holds the PostgreSQL migrations of internal/db
+const migrationsDir = "../db/migrations"
+
+var (
+	sqlComment  = regexp.MustCompile(`--[^\n]*`)
+	createTable = regexp.MustCompile(`(?is)^CREATE TABLE (?:IF NOT EXISTS )?employee\.employees \((.*)\)$`)
+	alterTable  = regexp.MustCompile(`(?is)^ALTER TABLE employee\.employees (.*)$`)
+	addColumn   = regexp.MustCompile(`(?i)^ADD COLUMN (?:IF NOT EXISTS )?(\w+)`)
+	dropColumn  = regexp.MustCompile(`(?i)^DROP COLUMN (?:IF EXISTS )?(\w+)`)
+	renameCol   = regexp.MustCompile(`(?i)^RENAME COLUMN (\w+) TO (\w+)`)
+)
+
+// TestColumnsExistInMigrations checks every col constant against the
+// columns of employee.employees once all migrations are applied, so a
+// column renamed or dropped by a migration fails here and not in
+// production
+func TestColumnsExistInMigrations(t *testing.T) {
+	columns := migratedColumns(t)
+
+	for name, column := range columnConstants(t) {
+		if !columns[column] {
+			t.Errorf("%s = %q is not a column of employee.employees in %s", name, column, migrationsDir)
+		}
+	}
+}
+
+// columnConstants returns the col constants of query.go by name
+func columnConstants(t *testing.T) map[string]string {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "query.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	constants := map[string]string{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+		for i, name := range spec.Names {
+			if !strings.HasPrefix(name.Name, "col") || i >= len(spec.Values) {
+				continue
+			}
+			if lit, ok := spec.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				constants[name.Name], _ = strconv.Unquote(lit.Value)
+			}
+		}
+		return true
+	})
+	if len(constants) == 0 {
+		t.Fatal("no col constants found in query.go")
+	}
+	return constants
+}
+
+// migratedColumns applies the CREATE TABLE and ALTER TABLE statements of
+// the migrations on employee.employees, in version order, and returns the
+// resulting columns
+func migratedColumns(t *testing.T) map[string]bool {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(migrationsDir, "*.sql"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no migrations in %s: %v", migrationsDir, err)
+	}
+	sort.Strings(paths)
+
+	columns := map[string]bool{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, stmt := range strings.Split(sqlComment.ReplaceAllString(string(data), ""), ";") {
+			stmt = strings.Join(strings.Fields(stmt), " ")
+			if m := createTable.FindStringSubmatch(stmt); m != nil {
+				for _, def := range splitTopLevel(m[1]) {
+					name := strings.ToLower(strings.Fields(def)[0])
+					switch strings.ToUpper(name) {
+					case "CONSTRAINT", "PRIMARY", "UNIQUE", "CHECK", "FOREIGN", "EXCLUDE":
+					default:
+						columns[name] = true
+					}
+				}
+			}
+			if m := alterTable.FindStringSubmatch(stmt); m != nil {
+				for _, action := range splitTopLevel(m[1]) {
+					if m := addColumn.FindStringSubmatch(action); m != nil {
+						columns[strings.ToLower(m[1])] = true
+					} else if m := dropColumn.FindStringSubmatch(action); m != nil {
+						delete(columns, strings.ToLower(m[1]))
+					} else if m := renameCol.FindStringSubmatch(action); m != nil {
+						delete(columns, strings.ToLower(m[1]))
+						columns[strings.ToLower(m[2])] = true
+					}
+				}
+			}
+		}
+	}
+	if len(columns) == 0 {
+		t.Fatalf("no employee.employees table in %s", migrationsDir)
+	}
+	return columns
+}
+
+// splitTopLevel splits s on the commas outside parentheses
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"employee-management/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrNotRetentionCandidate is returned by Purge when the employee is gone,
+// no longer retired since before the cutoff, or already anonymized
+var ErrNotRetentionCandidate = errors.New("employee is not a retention candidate")
+
+// RetentionRepository defines the data operations of the retention policy
+type RetentionRepository interface {
+	// FindExpired lists up to limit employees retired before cutoff and
+	// not anonymized yet, longest retired first
+	FindExpired(ctx context.Context, cutoff time.Time, limit int) ([]models.RetentionCandidate, error)
+
+	// Purge anonymizes or deletes an employee retired before cutoff and
+	// records it in the audit log, in one transaction. fn, if not nil, is
+	// called in the transaction with the anonymized employee, so events
+	// describing the purge commit with it
+	Purge(ctx context.Context, id int64, cutoff time.Time, action models.RetentionAction, fn func(ctx context.Context, tx EmployeeRepository, e *models.Employee) error) (*models.RetentionAuditEntry, error)
+
+	// FindAudit retrieves the audit log, newest first
+	FindAudit(ctx context.Context, limit, offset int) ([]models.RetentionAuditEntry, error)
+}
+
+// retentionRepository is the postgresql implementation of
+// RetentionRepository
+type retentionRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewRetentionRepository creates a new instance of RetentionRepository
+func NewRetentionRepository(db *pgxpool.Pool) RetentionRepository {
+	return &retentionRepository{db: db}
+}
+
+// FindExpired lists the employees retired before cutoff, current and
+// archived
+func (r *retentionRepository) FindExpired(ctx context.Context, cutoff time.Time, limit int) ([]models.RetentionCandidate, error) {
+	query := `
+        SELECT id, employee_number, department, retired_at, archived
+        FROM (
+            SELECT id, employee_number, department, retired_at, FALSE AS archived
+            FROM employee.employees
+            WHERE status = 'RETIRED' AND retired_at < $1 AND anonymized_at IS NULL
+            UNION ALL
+            SELECT id, employee_number, department, retired_at, TRUE
+            FROM employee.employees_archive
+            WHERE status = 'RETIRED' AND retired_at < $1 AND anonymized_at IS NULL
+        ) candidates
+        ORDER BY retired_at, id
+        LIMIT $2
+    `
+
+	rows, err := r.db.Query(ctx, query, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query retention candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := []models.RetentionCandidate{}
+	for rows.Next() {
+		var c models.RetentionCandidate
+		if err := rows.Scan(&c.ID, &c.EmployeeNumber, &c.Department, &c.RetiredAt, &c.Archived); err != nil {
+			return nil, fmt.Errorf("failed to scan retention candidate row: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating retention candidate rows: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// Purge locks the employee, current or archived, checks it is still a
+// candidate, then anonymizes or deletes it and inserts the audit entry
+func (r *retentionRepository) Purge(ctx context.Context, id int64, cutoff time.Time, action models.RetentionAction, fn func(ctx context.Context, tx EmployeeRepository, e *models.Employee) error) (*models.RetentionAuditEntry, error) {
+	var entry *models.RetentionAuditEntry
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var emp models.Employee
+		var retiredAt time.Time
+		table, err := lockRetentionCandidate(ctx, tx, id, cutoff, &emp, &retiredAt)
+		if err != nil {
+			return err
+		}
+
+		emp.Anonymize()
+
+		switch action {
+		case models.RetentionDelete:
+			if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE id = $1`, id); err != nil {
+				return fmt.Errorf("failed to delete employee: %w", err)
+			}
+		default:
+			query := `
+                UPDATE ` + table + `
+                SET first_name = $2, last_name = $3, email = $4, phone = NULL,
+                    date_of_birth = NULL, national_id = NULL, gender = NULL,
+                    personal_email = NULL, address_street = NULL, address_city = NULL,
+                    address_state = NULL, address_postal_code = NULL, address_country = NULL,
+                    email_verified_at = NULL, anonymized_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+                WHERE id = $1
+                RETURNING updated_at
+            `
+			if err := tx.QueryRow(ctx, query, id, emp.FirstName, emp.LastName, emp.Email).Scan(&emp.UpdatedAt); err != nil {
+				return fmt.Errorf("failed to anonymize employee: %w", err)
+			}
+		}
+
+		employees := &employeeRepository{db: tx}
+		if fn != nil {
+			if err := fn(ctx, employees, &emp); err != nil {
+				return err
+			}
+		}
+
+		entry = &models.RetentionAuditEntry{
+			EmployeeID:     id,
+			EmployeeNumber: emp.EmployeeNumber,
+			Action:         action,
+			RetiredAt:      retiredAt,
+		}
+		err = tx.QueryRow(ctx, `
+            INSERT INTO employee.retention_audit (employee_id, employee_number, action, retired_at)
+            VALUES ($1, $2, $3, $4)
+            RETURNING id, purged_at`,
+			entry.EmployeeID, entry.EmployeeNumber, entry.Action, entry.RetiredAt,
+		).Scan(&entry.ID, &entry.PurgedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert retention audit entry: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// lockRetentionCandidate locks and scans the employee if still a
+// candidate, looking in the employees table then in the archive, and
+// returns the table it is in
+func lockRetentionCandidate(ctx context.Context, tx pgx.Tx, id int64, cutoff time.Time, emp *models.Employee, retiredAt *time.Time) (string, error) {
+	for _, table := range []string{"employee.employees", "employee.employees_archive"} {
+		query := `
+            SELECT ` + employeeColumnList + `, retired_at
+            FROM ` + table + `
+            WHERE id = $1 AND status = 'RETIRED' AND retired_at < $2 AND anonymized_at IS NULL
+            FOR UPDATE
+        `
+
+		err := scanEmployee(tx.QueryRow(ctx, query, id, cutoff), emp, retiredAt)
+		if err == nil {
+			return table, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("failed to lock retention candidate: %w", err)
+		}
+	}
+	return "", ErrNotRetentionCandidate
+}
+
+// FindAudit retrieves the audit log, newest first
+func (r *retentionRepository) FindAudit(ctx context.Context, limit, offset int) ([]models.RetentionAuditEntry, error) {
+	query := `
+        SELECT id, employee_id, employee_number, action, retired_at, purged_at
+        FROM employee.retention_audit
+        ORDER BY id DESC
+        LIMIT $1 OFFSET $2
+    `
+
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query retention audit: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.RetentionAuditEntry{}
+	for rows.Next() {
+		var e models.RetentionAuditEntry
+		if err := rows.Scan(&e.ID, &e.EmployeeID, &e.EmployeeNumber, &e.Action, &e.RetiredAt, &e.PurgedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan retention audit row: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating retention audit rows: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"employee-management/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Skill errors
+var (
+	ErrSkillNotFound      = errors.New("skill not found")
+	ErrSkillAlreadyExists = errors.New("skill already exists")
+	ErrSkillInUse         = errors.New("skill is assigned to employees")
+)
+
+// SkillRepository defines the interface for the skills catalog and the
+// skills of each employee
+type SkillRepository interface {
+	Create(ctx context.Context, s *models.Skill) error
+	FindByID(ctx context.Context, id int64) (*models.Skill, error)
+	FindAll(ctx context.Context, category string) ([]models.Skill, error)
+	Delete(ctx context.Context, id int64) error
+
+	// FindByEmployee lists the skills of an employee by name
+	FindByEmployee(ctx context.Context, employeeID int64) ([]models.EmployeeSkill, error)
+
+	// FindByEmployees lists the skills of each of the employees by name,
+	// in one query, keyed by employee id. Employees without skills have
+	// no entry
+	FindByEmployees(ctx context.Context, employeeIDs []int64) (map[int64][]models.EmployeeSkill, error)
+
+	// Assign gives an employee a skill, or changes its proficiency when
+	// already assigned
+	Assign(ctx context.Context, employeeID int64, s *models.EmployeeSkill) error
+
+	// Unassign removes a skill from an employee
+	Unassign(ctx context.Context, employeeID, skillID int64) error
+}
+
+// skillRepository is the postgresql implementation of SkillRepository
+type skillRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSkillRepository creates a new instance of SkillRepository
+func NewSkillRepository(db *pgxpool.Pool) SkillRepository {
+	return &skillRepository{db: db}
+}
+
+// Create adds a skill to the catalog
+func (r *skillRepository) Create(ctx context.Context, s *models.Skill) error {
+	query := `
+        INSERT INTO employee.skills (name, category, description)
+        VALUES ($1, $2, $3)
+        RETURNING id, created_at
+    `
+
+	err := r.db.QueryRow(ctx, query, s.Name, s.Category, s.Description).Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrSkillAlreadyExists
+		}
+		return fmt.Errorf("failed to create skill: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a skill by id
+func (r *skillRepository) FindByID(ctx context.Context, id int64) (*models.Skill, error) {
+	query := `
+        SELECT id, name, category, description, created_at
+        FROM employee.skills
+        WHERE id = $1
+    `
+
+	var s models.Skill
+	err := r.db.QueryRow(ctx, query, id).Scan(&s.ID, &s.Name, &s.Category, &s.Description, &s.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSkillNotFound
+		}
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// FindAll retrieves the catalog by name, only the skills of category when
+// not empty
+func (r *skillRepository) FindAll(ctx context.Context, category string) ([]models.Skill, error) {
+	query := `
+        SELECT id, name, category, description, created_at
+        FROM employee.skills
+        WHERE $1 = '' OR LOWER(category) = LOWER($1)
+        ORDER BY LOWER(name)
+    `
+
+	rows, err := r.db.Query(ctx, query, category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query skills: %w", err)
+	}
+	defer rows.Close()
+
+	skills := []models.Skill{}
+	for rows.Next() {
+		var s models.Skill
+		if err := rows.Scan(&s.ID, &s.Name, &s.Category, &s.Description, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan skill row: %w", err)
+		}
+		skills = append(skills, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating skill rows: %w", err)
+	}
+
+	return skills, nil
+}
+
+// Delete removes a skill no employee holds
+func (r *skillRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM employee.skills WHERE id = $1`, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			return ErrSkillInUse
+		}
+		return fmt.Errorf("failed to delete skill: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrSkillNotFound
+	}
+
+	return nil
+}
+
+// FindByEmployee lists the skills of an employee by name
+func (r *skillRepository) FindByEmployee(ctx context.Context, employeeID int64) ([]models.EmployeeSkill, error) {
+	query := `
+        SELECT s.id, s.name, s.category, es.proficiency, es.updated_at
+        FROM employee.employee_skills es
+        JOIN employee.skills s ON s.id = es.skill_id
+        WHERE es.employee_id = $1
+        ORDER BY LOWER(s.name)
+    `
+
+	rows, err := r.db.Query(ctx, query, employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query employee skills: %w", err)
+	}
+	defer rows.Close()
+
+	skills := []models.EmployeeSkill{}
+	for rows.Next() {
+		var s models.EmployeeSkill
+		if err := rows.Scan(&s.SkillID, &s.Name, &s.Category, &s.Proficiency, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan employee skill row: %w", err)
+		}
+		skills = append(skills, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating employee skill rows: %w", err)
+	}
+
+	return skills, nil
+}
+
+// FindByEmployees lists the skills of each of the employees by name
+func (r *skillRepository) FindByEmployees(ctx context.Context, employeeIDs []int64) (map[int64][]models.EmployeeSkill, error) {
+	query := `
+        SELECT es.employee_id, s.id, s.name, s.category, es.proficiency, es.updated_at
+        FROM employee.employee_skills es
+        JOIN employee.skills s ON s.id = es.skill_id
+        WHERE es.employee_id = ANY($1)
+        ORDER BY es.employee_id, LOWER(s.name)
+    `
+
+	rows, err := r.db.Query(ctx, query, employeeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query employee skills: %w", err)
+	}
+	defer rows.Close()
+
+	skills := make(map[int64][]models.EmployeeSkill)
+	for rows.Next() {
+		var employeeID int64
+		var s models.EmployeeSkill
+		if err := rows.Scan(&employeeID, &s.SkillID, &s.Name, &s.Category, &s.Proficiency, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan employee skill row: %w", err)
+		}
+		skills[employeeID] = append(skills[employeeID], s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating employee skill rows: %w", err)
+	}
+
+	return skills, nil
+}
+
+// Assign gives an employee a skill, or changes its proficiency when
+// already assigned. The name and category of s are filled from the
+// catalog
+func (r *skillRepository) Assign(ctx context.Context, employeeID int64, s *models.EmployeeSkill) error {
+	query := `
+        WITH assigned AS (
+            INSERT INTO employee.employee_skills (employee_id, skill_id, proficiency)
+            VALUES ($1, $2, $3)
+            ON CONFLICT (employee_id, skill_id)
+            DO UPDATE SET proficiency = EXCLUDED.proficiency, updated_at = CURRENT_TIMESTAMP
+            RETURNING skill_id, updated_at
+        )
+        SELECT s.name, s.category, a.updated_at
+        FROM assigned a
+        JOIN employee.skills s ON s.id = a.skill_id
+    `
+
+	err := r.db.QueryRow(ctx, query, employeeID, s.SkillID, s.Proficiency).Scan(&s.Name, &s.Category, &s.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			switch pgErr.ConstraintName {
+			case "employee_skills_employee_id_fkey":
+				return ErrEmployeeNotFound
+			case "employee_skills_skill_id_fkey":
+				return ErrSkillNotFound
+			}
+		}
+		return fmt.Errorf("failed to assign skill: %w", err)
+	}
+
+	return nil
+}
+
+// Unassign removes a skill from an employee
+func (r *skillRepository) Unassign(ctx context.Context, employeeID, skillID int64) error {
+	result, err := r.db.Exec(ctx,
+		`DELETE FROM employee.employee_skills WHERE employee_id = $1 AND skill_id = $2`,
+		employeeID, skillID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to unassign skill: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrSkillNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrWebhookNotFound is returned when a subscription does not exist
+var ErrWebhookNotFound = errors.New("webhook subscription not found")
+
+// WebhookRepository defines the interface for webhook data operations
+type WebhookRepository interface {
+	Create(ctx context.Context, w *models.WebhookSubscription) error
+	FindByID(ctx context.Context, id int64) (*models.WebhookSubscription, error)
+	FindAll(ctx context.Context) ([]models.WebhookSubscription, error)
+	Delete(ctx context.Context, id int64) error
+	FindDeliveries(ctx context.Context, subscriptionID int64, limit, offset int) ([]models.WebhookDelivery, error)
+
+	// Enqueue creates a pending delivery of evt for every active
+	// subscription listening to its type
+	Enqueue(ctx context.Context, evt events.Event) error
+
+	// DispatchDeliveries locks up to limit due deliveries and calls
+	// deliver for each one. Failed deliveries are retried after
+	// backoff(attempts) until maxAttempts, then marked FAILED
+	DispatchDeliveries(ctx context.Context, limit, maxAttempts int, deliver func(ctx context.Context, w models.WebhookSubscription, d models.WebhookDelivery) (int, error), backoff func(attempts int) time.Duration) (int, error)
+}
+
+// webhookRepository is the postgresql implementation of WebhookRepository
+type webhookRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository
+func NewWebhookRepository(db *pgxpool.Pool) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// Create adds a new subscription
+func (r *webhookRepository) Create(ctx context.Context, w *models.WebhookSubscription) error {
+	query := `
+        INSERT INTO employee.webhook_subscriptions (url, secret, event_types, active)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at
+    `
+
+	err := r.db.QueryRow(ctx, query, w.URL, w.Secret, w.EventTypes, w.Active).Scan(&w.ID, &w.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a subscription by id
+func (r *webhookRepository) FindByID(ctx context.Context, id int64) (*models.WebhookSubscription, error) {
+	query := `
+        SELECT id, url, secret, event_types, active, created_at
+        FROM employee.webhook_subscriptions
+        WHERE id = $1
+    `
+
+	var w models.WebhookSubscription
+	err := r.db.QueryRow(ctx, query, id).Scan(&w.ID, &w.URL, &w.Secret, &w.EventTypes, &w.Active, &w.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// FindAll retrieves every subscription
+func (r *webhookRepository) FindAll(ctx context.Context) ([]models.WebhookSubscription, error) {
+	query := `
+        SELECT id, url, secret, event_types, active, created_at
+        FROM employee.webhook_subscriptions
+        ORDER BY id
+    `
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []models.WebhookSubscription{}
+	for rows.Next() {
+		var w models.WebhookSubscription
+		if err := rows.Scan(&w.ID, &w.URL, &w.Secret, &w.EventTypes, &w.Active, &w.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription row: %w", err)
+		}
+		subscriptions = append(subscriptions, w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook subscription rows: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// Delete removes a subscription and its deliveries
+func (r *webhookRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM employee.webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// FindDeliveries retrieves the delivery log of a subscription, newest first
+func (r *webhookRepository) FindDeliveries(ctx context.Context, subscriptionID int64, limit, offset int) ([]models.WebhookDelivery, error) {
+	query := `
+        SELECT id, subscription_id, event_id, event_type, status, attempts,
+               response_code, last_error, next_attempt_at, delivered_at, created_at
+        FROM employee.webhook_deliveries
+        WHERE subscription_id = $1
+        ORDER BY id DESC
+        LIMIT $2 OFFSET $3
+    `
+
+	rows, err := r.db.Query(ctx, query, subscriptionID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var d models.WebhookDelivery
+		err := rows.Scan(
+			&d.ID,
+			&d.SubscriptionID,
+			&d.EventID,
+			&d.EventType,
+			&d.Status,
+			&d.Attempts,
+			&d.ResponseCode,
+			&d.LastError,
+			&d.NextAttemptAt,
+			&d.DeliveredAt,
+			&d.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery row: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook delivery rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// Enqueue inserts one pending delivery per matching subscription
+// Re-enqueuing the same event is a no-op thanks to the unique constraint
+func (r *webhookRepository) Enqueue(ctx context.Context, evt events.Event) error {
+	query := `
+        INSERT INTO employee.webhook_deliveries (subscription_id, event_id, event_type, payload)
+        SELECT id, $1, $2, $3
+        FROM employee.webhook_subscriptions
+        WHERE active AND ($2 = ANY(event_types) OR '*' = ANY(event_types))
+        ON CONFLICT (subscription_id, event_id) DO NOTHING
+    `
+
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.db.Exec(ctx, query, evt.ID, string(evt.Type), payload); err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// DispatchDeliveries delivers a batch of due deliveries in one transaction
+func (r *webhookRepository) DispatchDeliveries(ctx context.Context, limit, maxAttempts int, deliver func(ctx context.Context, w models.WebhookSubscription, d models.WebhookDelivery) (int, error), backoff func(attempts int) time.Duration) (int, error) {
+	delivered := 0
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `
+            SELECT d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.attempts,
+                   s.url, s.secret
+            FROM employee.webhook_deliveries d
+            JOIN employee.webhook_subscriptions s ON s.id = d.subscription_id
+            WHERE d.status = 'PENDING' AND d.next_attempt_at <= CURRENT_TIMESTAMP
+            ORDER BY d.id
+            LIMIT $1
+            FOR UPDATE OF d SKIP LOCKED
+        `
+
+		rows, err := tx.Query(ctx, query, limit)
+		if err != nil {
+			return fmt.Errorf("failed to query webhook deliveries: %w", err)
+		}
+
+		type pending struct {
+			sub      models.WebhookSubscription
+			delivery models.WebhookDelivery
+		}
+
+		var batch []pending
+		for rows.Next() {
+			var p pending
+			err := rows.Scan(
+				&p.delivery.ID,
+				&p.delivery.SubscriptionID,
+				&p.delivery.EventID,
+				&p.delivery.EventType,
+				&p.delivery.Payload,
+				&p.delivery.Attempts,
+				&p.sub.URL,
+				&p.sub.Secret,
+			)
+			if err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan webhook delivery row: %w", err)
+			}
+			p.sub.ID = p.delivery.SubscriptionID
+			batch = append(batch, p)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating webhook delivery rows: %w", err)
+		}
+
+		for _, p := range batch {
+			code, deliverErr := deliver(ctx, p.sub, p.delivery)
+			var responseCode *int
+			if code != 0 {
+				responseCode = &code
+			}
+
+			if deliverErr == nil {
+				_, err := tx.Exec(ctx, `
+                    UPDATE employee.webhook_deliveries
+                    SET status = 'DELIVERED', attempts = attempts + 1, response_code = $2,
+                        last_error = NULL, delivered_at = CURRENT_TIMESTAMP
+                    WHERE id = $1`,
+					p.delivery.ID, responseCode,
+				)
+				if err != nil {
+					return fmt.Errorf("failed to mark webhook delivered: %w", err)
+				}
+				delivered++
+				continue
+			}
+
+			attempts := p.delivery.Attempts + 1
+			status := models.DeliveryPending
+			if attempts >= maxAttempts {
+				status = models.DeliveryFailed
+			}
+
+			_, err := tx.Exec(ctx, `
+                UPDATE employee.webhook_deliveries
+                SET status = $2, attempts = $3, response_code = $4, last_error = $5, next_attempt_at = $6
+                WHERE id = $1`,
+				p.delivery.ID, status, attempts, responseCode, deliverErr.Error(), time.Now().Add(backoff(attempts)),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to schedule webhook retry: %w", err)
+			}
+		}
+
+		return nil
+	})
+
+	return delivered, err
+}
@@ -0,0 +1,181 @@
+// Package retention applies the data retention policy: employees retired
+// for longer than the retention period are anonymized or deleted, each
+// with an audit entry
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"employee-management/internal/cache"
+	"employee-management/internal/events"
+	"employee-management/internal/features"
+	"employee-management/internal/jobs"
+	"employee-management/internal/metrics"
+	"employee-management/internal/models"
+	"employee-management/internal/repository"
+)
+
+// batchSize is how many candidates are read at a time while purging
+const batchSize = 100
+
+// Policy says which employees are purged and how
+type Policy struct {
+	// Years an employee stays retired before being purged
+	Years  int
+	Action models.RetentionAction
+}
+
+// Engine reports and purges the employees past the retention period
+type Engine struct {
+	repo   repository.RetentionRepository
+	policy Policy
+	flags  *features.Flags
+
+	// cache, if not nil, drops the purged employees so reads do not
+	// serve their personal data from it
+	cache cache.Cache
+}
+
+// NewEngine creates a new Engine
+func NewEngine(repo repository.RetentionRepository, policy Policy, flags *features.Flags, c cache.Cache) *Engine {
+	return &Engine{repo: repo, policy: policy, flags: flags, cache: c}
+}
+
+// Cutoff returns the retirement time before which employees are purged
+func (e *Engine) Cutoff(now time.Time) time.Time {
+	return now.AddDate(-e.policy.Years, 0, 0)
+}
+
+// Report lists the employees the next purge would anonymize or delete,
+// without changing anything
+func (e *Engine) Report(ctx context.Context, limit int) (*models.RetentionReport, error) {
+	cutoff := e.Cutoff(time.Now())
+	candidates, err := e.repo.FindExpired(ctx, cutoff, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RetentionReport{Action: e.policy.Action, Cutoff: cutoff, Employees: candidates}, nil
+}
+
+// Purge applies the policy to every candidate and returns how many
+// employees were purged. Employees reinstated since they were listed are
+// skipped. When events are enabled each purge stores employee.updated,
+// or employee.deleted, carrying the anonymized employee
+func (e *Engine) Purge(ctx context.Context) (int, error) {
+	cutoff := e.Cutoff(time.Now())
+	purged := 0
+
+	for {
+		candidates, err := e.repo.FindExpired(ctx, cutoff, batchSize)
+		if err != nil {
+			return purged, err
+		}
+		if len(candidates) == 0 {
+			return purged, nil
+		}
+
+		progress := false
+		for _, c := range candidates {
+			entry, err := e.repo.Purge(ctx, c.ID, cutoff, e.policy.Action, e.appendEvent)
+			if errors.Is(err, repository.ErrNotRetentionCandidate) {
+				continue
+			}
+			if err != nil {
+				return purged, err
+			}
+
+			progress = true
+			purged++
+			metrics.RetentionPurged.WithLabelValues(string(entry.Action)).Inc()
+			log.Printf("retention: employee %d (%s) retired %s %s", entry.EmployeeID, entry.EmployeeNumber, entry.RetiredAt.Format(time.DateOnly), actionVerb(entry.Action))
+
+			if e.cache != nil {
+				if err := repository.EvictEmployees(ctx, e.cache, c.ID); err != nil {
+					log.Printf("cache eviction of employee %d failed: %v", c.ID, err)
+				}
+			}
+		}
+
+		// Candidates skipped by every purge would be listed again
+		if !progress {
+			return purged, nil
+		}
+	}
+}
+
+// Audit retrieves a page of the audit log of purged employees, newest
+// first
+func (e *Engine) Audit(ctx context.Context, page, pageSize int) ([]models.RetentionAuditEntry, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	return e.repo.FindAudit(ctx, pageSize, (page-1)*pageSize)
+}
+
+// Run queues a purge on pool every interval until ctx is done
+func (e *Engine) Run(ctx context.Context, interval time.Duration, pool *jobs.Pool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := pool.Submit(jobs.Task{Name: "retention", Run: e.purge}); err != nil {
+			log.Printf("retention purge not queued: %v", err)
+		}
+	}
+}
+
+// purge runs one purge as a pool task
+func (e *Engine) purge(ctx context.Context) error {
+	purged, err := e.Purge(ctx)
+	if err != nil {
+		return fmt.Errorf("retention purge failed after %d employees: %w", purged, err)
+	}
+	if purged > 0 {
+		log.Printf("retention purged %d employees", purged)
+	}
+	return nil
+}
+
+// appendEvent stores the event describing the purge of emp
+func (e *Engine) appendEvent(ctx context.Context, tx repository.EmployeeRepository, emp *models.Employee) error {
+	if !e.flags.Enabled(features.Events) {
+		return nil
+	}
+
+	t := events.EmployeeUpdated
+	if e.policy.Action == models.RetentionDelete {
+		t = events.EmployeeDeleted
+	}
+
+	evt, err := events.New(t, emp.ID, emp)
+	if err != nil {
+		return err
+	}
+	return tx.AppendEvent(ctx, evt)
+}
+
+// actionVerb describes action in the past tense for the log
+func actionVerb(action models.RetentionAction) string {
+	if action == models.RetentionDelete {
+		return "deleted"
+	}
+	return "anonymized"
+}
@@ -0,0 +1,417 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidFilter is returned for filters that do not parse
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Filter selects users by their attributes, as the filter parameter of
+// a list (RFC 7644 3.4.2.2): comparisons with eq, ne, co, sw, ew, gt,
+// ge, lt and le, presence with pr, and, or, not and parentheses, and
+// value paths such as emails[type eq "work" and value co "@example.com"].
+// Strings compare case insensitively
+type Filter struct {
+	root node
+}
+
+// ParseFilter parses a filter
+func ParseFilter(s string) (*Filter, error) {
+	p := &parser{tokens: tokenize(s)}
+	root, err := p.or()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	return &Filter{root: root}, nil
+}
+
+// Match reports whether u matches f
+func (f *Filter) Match(u *User) bool {
+	return f.root.match(document(u))
+}
+
+// Equal returns the string the users matching f must have as attribute,
+// when f is an eq comparison of attribute alone or and-ed with others, so
+// callers can look them up by it and Match only those
+func (f *Filter) Equal(attribute string) (string, bool) {
+	return equal(f.root, split(attribute))
+}
+
+func equal(n node, path []string) (string, bool) {
+	switch n := n.(type) {
+	case andNode:
+		if value, ok := equal(n.left, path); ok {
+			return value, true
+		}
+		return equal(n.right, path)
+	case compareNode:
+		if value, ok := n.value.(string); ok && n.op == "eq" && slices.Equal(split(n.path), path) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// node is a parsed filter expression, matched against a resource as a
+// JSON document with lower-cased attribute names
+type node interface {
+	match(doc map[string]any) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) match(doc map[string]any) bool { return n.left.match(doc) && n.right.match(doc) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) match(doc map[string]any) bool { return n.left.match(doc) || n.right.match(doc) }
+
+type notNode struct{ inner node }
+
+func (n notNode) match(doc map[string]any) bool { return !n.inner.match(doc) }
+
+// valuePathNode matches the resources with a value of a multi-valued
+// attribute matching the inner filter
+type valuePathNode struct {
+	path  string
+	inner node
+}
+
+func (n valuePathNode) match(doc map[string]any) bool {
+	for _, v := range resolve(doc, n.path) {
+		if item, ok := v.(map[string]any); ok && n.inner.match(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareNode compares an attribute with a value, pr checking it is set
+type compareNode struct {
+	path  string
+	op    string
+	value any
+}
+
+func (n compareNode) match(doc map[string]any) bool {
+	values := resolve(doc, n.path)
+	if n.op == "ne" {
+		return !compareNode{path: n.path, op: "eq", value: n.value}.match(doc)
+	}
+	for _, v := range values {
+		// Multi-valued attributes compare their value sub-attribute
+		if item, ok := v.(map[string]any); ok {
+			v = item["value"]
+		}
+		if n.op == "pr" {
+			if v != nil && v != "" {
+				return true
+			}
+			continue
+		}
+		if compare(n.op, v, n.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compare compares actual with expected by op
+func compare(op string, actual, expected any) bool {
+	switch a := actual.(type) {
+	case string:
+		e, ok := expected.(string)
+		if !ok {
+			return false
+		}
+		a, e = strings.ToLower(a), strings.ToLower(e)
+		switch op {
+		case "eq":
+			return a == e
+		case "co":
+			return strings.Contains(a, e)
+		case "sw":
+			return strings.HasPrefix(a, e)
+		case "ew":
+			return strings.HasSuffix(a, e)
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+	case bool:
+		e, ok := expected.(bool)
+		return ok && op == "eq" && a == e
+	case float64:
+		e, ok := expected.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return a == e
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+	case nil:
+		return op == "eq" && expected == nil
+	}
+	return false
+}
+
+// document returns u as a JSON document with lower-cased attribute names
+func document(u *User) map[string]any {
+	data, _ := json.Marshal(u)
+	var doc any
+	_ = json.Unmarshal(data, &doc)
+	m, _ := lowerKeys(doc).(map[string]any)
+	return m
+}
+
+// lowerKeys lower-cases the attribute names of a JSON value
+func lowerKeys(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[strings.ToLower(k)] = lowerKeys(item)
+		}
+		return m
+	case []any:
+		for i, item := range v {
+			v[i] = lowerKeys(item)
+		}
+	}
+	return v
+}
+
+// resolve returns the values at an attribute path of doc, e.g.
+// name.givenName or an extension attribute such as
+// urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department.
+// Multi-valued attributes give one value per item
+func resolve(doc map[string]any, path string) []any {
+	values := []any{doc}
+	for _, part := range split(path) {
+		var next []any
+		for _, v := range values {
+			m, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+			switch child := m[part].(type) {
+			case nil:
+			case []any:
+				next = append(next, child...)
+			default:
+				next = append(next, child)
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+// split splits an attribute path into the lower-cased attribute names
+// leading to it from the user, the enterprise extension being the first
+// of its attributes and the core schema optional
+func split(path string) []string {
+	path = strings.ToLower(path)
+	core, enterprise := strings.ToLower(SchemaUser), strings.ToLower(SchemaEnterpriseUser)
+	switch {
+	case path == enterprise:
+		return []string{enterprise}
+	case strings.HasPrefix(path, enterprise+":"):
+		return append([]string{enterprise}, strings.Split(path[len(enterprise)+1:], ".")...)
+	case strings.HasPrefix(path, core+":"):
+		path = path[len(core)+1:]
+	}
+	return strings.Split(path, ".")
+}
+
+// token is a lexical token of a filter
+type token struct {
+	text string
+	// quoted is set for string literals, text holding their value
+	quoted bool
+}
+
+// tokenize splits a filter into parentheses, brackets, string literals
+// and words
+func tokenize(s string) []token {
+	var tokens []token
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case strings.IndexByte("()[]", c) >= 0:
+			tokens = append(tokens, token{text: string(c)})
+			i++
+		case c == '"':
+			j := i + 1
+			for j < len(s) && s[j] != '"' {
+				if s[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			var value string
+			if err := json.Unmarshal([]byte(s[i:min(j+1, len(s))]), &value); err != nil {
+				// An unterminated or malformed literal fails to parse
+				tokens = append(tokens, token{text: s[i:]})
+				return tokens
+			}
+			tokens = append(tokens, token{text: value, quoted: true})
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && strings.IndexByte(" \t()[]\"", s[j]) < 0 {
+				j++
+			}
+			tokens = append(tokens, token{text: s[i:j]})
+			i = j
+		}
+	}
+	return tokens
+}
+
+// parser is a recursive descent parser of filters, and binding tighter
+// than or
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// peek returns the lower-cased next word, "" for a string literal or at
+// the end
+func (p *parser) peek() string {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return ""
+	}
+	return strings.ToLower(p.tokens[p.pos].text)
+}
+
+// expect consumes the next token, which must be text
+func (p *parser) expect(text string) error {
+	if p.peek() != text {
+		return fmt.Errorf("expected %q", text)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	for err == nil && p.peek() == "or" {
+		p.pos++
+		var right node
+		if right, err = p.and(); err == nil {
+			left = orNode{left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *parser) and() (node, error) {
+	left, err := p.not()
+	for err == nil && p.peek() == "and" {
+		p.pos++
+		var right node
+		if right, err = p.not(); err == nil {
+			left = andNode{left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *parser) not() (node, error) {
+	switch p.peek() {
+	case "not":
+		p.pos++
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, p.expect(")")
+	case "(":
+		p.pos++
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	}
+	return p.attribute()
+}
+
+// attribute parses a comparison, a presence test or a value path
+func (p *parser) attribute() (node, error) {
+	path := p.peek()
+	if path == "" || strings.Contains("()[]", path) {
+		return nil, errors.New("expected an attribute")
+	}
+	p.pos++
+
+	if p.peek() == "[" {
+		p.pos++
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return valuePathNode{path: path, inner: inner}, p.expect("]")
+	}
+
+	op := p.peek()
+	switch op {
+	case "pr":
+		p.pos++
+		return compareNode{path: path, op: op}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+		p.pos++
+	default:
+		return nil, fmt.Errorf("expected an operator after %q", path)
+	}
+
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("expected a value after %q", op)
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	if t.quoted {
+		return compareNode{path: path, op: op, value: t.text}, nil
+	}
+	switch strings.ToLower(t.text) {
+	case "true":
+		return compareNode{path: path, op: op, value: true}, nil
+	case "false":
+		return compareNode{path: path, op: op, value: false}, nil
+	case "null":
+		return compareNode{path: path, op: op, value: nil}, nil
+	}
+	n, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q", t.text)
+	}
+	return compareNode{path: path, op: op, value: n}, nil
+}
@@ -0,0 +1,80 @@
+package scim
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func FuzzParseFilter(f *testing.F) {
+	seeds := []string{
+		`userName eq "jane.doe@example.com"`,
+		`name.familyName co "doe" and active eq true`,
+		`not (title sw "eng") or displayName pr`,
+		`emails[type eq "work" and value co "@example.com"]`,
+		`meta.lastModified gt "2024-01-01T00:00:00Z"`,
+		`urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department eq "Sales"`,
+		`userName eq 1e309`,
+		`userName eq -0x1`,
+		`userName eq "unterminated`,
+		`userName eq "é\ud800"`,
+		`emails[type eq "work"`,
+		`()`,
+		`and or not`,
+		"userName eq \"\xff\xfe\"",
+		"",
+		strings.Repeat("(", 500) + `active pr` + strings.Repeat(")", 500),
+		strings.Repeat(`not `, 500) + `active pr`,
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+
+	active := true
+	user := &User{
+		UserName: "jane.doe@example.com",
+		Name:     &Name{GivenName: "Jane", FamilyName: "Doe"},
+		Active:   &active,
+		Emails:   []MultiValue{{Value: "jane.doe@example.com", Type: "work", Primary: true}},
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		filter, err := ParseFilter(s)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidFilter) {
+				t.Fatalf("ParseFilter(%q) = %v, want ErrInvalidFilter", s, err)
+			}
+			return
+		}
+		filter.Match(user)
+	})
+}
+
+func TestFilterEqual(t *testing.T) {
+	tests := []struct {
+		filter    string
+		attribute string
+		want      string
+		wantOK    bool
+	}{
+		{`userName eq "jane.doe@example.com"`, "userName", "jane.doe@example.com", true},
+		{`USERNAME eq "jane.doe@example.com"`, "userName", "jane.doe@example.com", true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "jane"`, "userName", "jane", true},
+		{`active eq true and (userName eq "jane")`, "userName", "jane", true},
+		{`externalId eq "42"`, "externalId", "42", true},
+		{`externalId eq "42"`, "userName", "", false},
+		{`userName eq "jane" or active eq true`, "userName", "", false},
+		{`not (userName eq "jane")`, "userName", "", false},
+		{`userName co "jane"`, "userName", "", false},
+		{`userName eq null`, "userName", "", false},
+		{`emails[value eq "jane"]`, "userName", "", false},
+	}
+	for _, tt := range tests {
+		filter, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q) = %v", tt.filter, err)
+		}
+		if got, ok := filter.Equal(tt.attribute); got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseFilter(%q).Equal(%q) = %q, %t, want %q, %t", tt.filter, tt.attribute, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Errors of patches, by the scimType of their response
+var (
+	// ErrInvalidSyntax is returned for operations that are not add,
+	// replace or remove, or lack what they need
+	ErrInvalidSyntax = errors.New("invalid patch operation")
+
+	// ErrInvalidPath is returned for paths that do not parse or cannot
+	// be patched
+	ErrInvalidPath = errors.New("invalid patch path")
+
+	// ErrInvalidValue is returned for values that do not fit their
+	// attribute
+	ErrInvalidValue = errors.New("invalid patch value")
+)
+
+// PatchRequest is the body of a patch of a user
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation adds, replaces or removes the value at a path, e.g.
+// title, name.givenName or emails[type eq "work"].value. Without a path
+// the value is an object of the attributes to add or replace
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Apply applies the operations of r to u in order. Attributes the user
+// does not map to the employee are accepted and dropped, as identity
+// providers send the ones they know about
+func (r *PatchRequest) Apply(u *User) error {
+	doc := document(u)
+	for i, op := range r.Operations {
+		if err := op.apply(doc); err != nil {
+			return fmt.Errorf("operation %d: %w", i+1, err)
+		}
+	}
+
+	// Azure AD sends active as the strings "True" and "False"
+	if s, ok := doc["active"].(string); ok {
+		active, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("%w: active is %q", ErrInvalidValue, s)
+		}
+		doc["active"] = active
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	var patched User
+	if err := json.Unmarshal(data, &patched); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidValue, err)
+	}
+	*u = patched
+	return nil
+}
+
+// apply applies o to doc, a user as a JSON document with lower-cased
+// attribute names
+func (o PatchOperation) apply(doc map[string]any) error {
+	op := strings.ToLower(o.Op)
+	if op != "add" && op != "replace" && op != "remove" {
+		return fmt.Errorf("%w: unsupported op %q", ErrInvalidSyntax, o.Op)
+	}
+
+	var value any
+	if op != "remove" {
+		if len(o.Value) == 0 {
+			return fmt.Errorf("%w: %s needs a value", ErrInvalidSyntax, op)
+		}
+		if err := json.Unmarshal(o.Value, &value); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidValue, err)
+		}
+		value = lowerKeys(value)
+	}
+
+	if o.Path == "" {
+		attrs, ok := value.(map[string]any)
+		if op == "remove" || !ok {
+			return fmt.Errorf("%w: %s without a path needs an object value", ErrInvalidSyntax, op)
+		}
+		for path, v := range attrs {
+			// The enterprise extension is merged, not replaced
+			if ext, ok := v.(map[string]any); ok && path == strings.ToLower(SchemaEnterpriseUser) {
+				for attr, v := range ext {
+					if err := patch(doc, op, path+":"+attr, v); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			if err := patch(doc, op, path, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return patch(doc, op, o.Path, value)
+}
+
+// patch applies op with value to the attribute at path of doc
+func patch(doc map[string]any, op, path string, value any) error {
+	attr, filter, sub, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	parts := split(attr)
+	parent, ok := walk(doc, parts[:len(parts)-1], op != "remove")
+	if !ok {
+		// Removing from an attribute that is not set removes nothing
+		return nil
+	}
+	name := parts[len(parts)-1]
+
+	if filter == nil {
+		switch {
+		case op == "remove":
+			delete(parent, name)
+		case op == "add":
+			// Adding to a multi-valued attribute appends
+			current, isList := parent[name].([]any)
+			values, areList := value.([]any)
+			if isList && areList {
+				parent[name] = append(current, values...)
+			} else {
+				parent[name] = value
+			}
+		default:
+			parent[name] = value
+		}
+		return nil
+	}
+
+	items, _ := parent[name].([]any)
+	kept := items[:0:0]
+	matched := false
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok || !filter.match(m) {
+			kept = append(kept, item)
+			continue
+		}
+		matched = true
+		switch {
+		case op == "remove" && sub == "":
+			continue
+		case op == "remove":
+			delete(m, sub)
+		case sub == "":
+			item = value
+		default:
+			m[sub] = value
+		}
+		kept = append(kept, item)
+	}
+
+	// Setting a value no item matches adds the item the filter describes,
+	// as for emails[type eq "work"].value on a user without a work email
+	if !matched && op != "remove" {
+		item := map[string]any{}
+		if sub == "" {
+			m, ok := value.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%w: %s needs an object value", ErrInvalidValue, path)
+			}
+			item = m
+		} else {
+			item[sub] = value
+		}
+		seed(filter, item)
+		kept = append(kept, item)
+	}
+	parent[name] = kept
+	return nil
+}
+
+// walk returns the object at parts of doc, creating the missing ones
+// when create is set
+func walk(doc map[string]any, parts []string, create bool) (map[string]any, bool) {
+	for _, part := range parts {
+		next, ok := doc[part].(map[string]any)
+		if !ok {
+			if !create {
+				return nil, false
+			}
+			next = map[string]any{}
+			doc[part] = next
+		}
+		doc = next
+	}
+	return doc, true
+}
+
+// parsePath splits a patch path into the attribute, the filter of its
+// items and the sub-attribute set in them, emails, type eq "work" and
+// value for emails[type eq "work"].value
+func parsePath(path string) (attr string, filter node, sub string, err error) {
+	attr, rest, ok := strings.Cut(path, "[")
+	if !ok {
+		if attr == "" {
+			return "", nil, "", fmt.Errorf("%w: empty path", ErrInvalidPath)
+		}
+		return attr, nil, "", nil
+	}
+
+	i := strings.LastIndexByte(rest, ']')
+	if i < 0 || attr == "" {
+		return "", nil, "", fmt.Errorf("%w: %q", ErrInvalidPath, path)
+	}
+	if after := rest[i+1:]; after != "" {
+		if sub, ok = strings.CutPrefix(after, "."); !ok || sub == "" || strings.Contains(sub, ".") {
+			return "", nil, "", fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+	}
+
+	p := &parser{tokens: tokenize(rest[:i])}
+	filter, err = p.or()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	if err != nil {
+		return "", nil, "", fmt.Errorf("%w: %q: %v", ErrInvalidPath, path, err)
+	}
+	return attr, filter, strings.ToLower(sub), nil
+}
+
+// seed sets in item the values the eq comparisons of filter require
+func seed(filter node, item map[string]any) {
+	switch n := filter.(type) {
+	case andNode:
+		seed(n.left, item)
+		seed(n.right, item)
+	case compareNode:
+		if n.op == "eq" && !strings.Contains(n.path, ".") {
+			item[strings.ToLower(n.path)] = n.value
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Package scim maps employees onto SCIM 2.0 (RFC 7643, RFC 7644) users,
+// so identity providers such as Okta or Azure AD provision employees: it
+// holds the resources and messages of the protocol, the filters of user
+// lists and the operations of user patches
+package scim
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"employee-management/internal/models"
+)
+
+// Schemas of the resources and messages
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaEnterpriseUser        = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+)
+
+// ContentType is the media type of SCIM messages
+const ContentType = "application/scim+json"
+
+// User is an employee as a SCIM user. userName and the work email are
+// the employee email, title the position, and the enterprise extension
+// holds the employee number and department. An inactive user is a
+// retired employee
+type User struct {
+	Schemas []string `json:"schemas"`
+	// ID is the employee uuid
+	ID string `json:"id,omitempty"`
+	// ExternalID is the id of the identity provider, accepted but not
+	// stored
+	ExternalID   string          `json:"externalId,omitempty"`
+	UserName     string          `json:"userName"`
+	Name         *Name           `json:"name,omitempty"`
+	DisplayName  string          `json:"displayName,omitempty"`
+	Title        string          `json:"title,omitempty"`
+	Active       *bool           `json:"active,omitempty"`
+	Emails       []MultiValue    `json:"emails,omitempty"`
+	PhoneNumbers []MultiValue    `json:"phoneNumbers,omitempty"`
+	Enterprise   *EnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta         *Meta           `json:"meta,omitempty"`
+}
+
+// Name is the name of a user
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// MultiValue is an email or phone number of a user
+type MultiValue struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// EnterpriseUser is the enterprise extension of a user
+type EnterpriseUser struct {
+	EmployeeNumber string `json:"employeeNumber,omitempty"`
+	Department     string `json:"department,omitempty"`
+}
+
+// Meta describes a resource
+type Meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+	Version      string    `json:"version"`
+}
+
+// ListResponse is a page of the users matching a filter
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []User   `json:"Resources"`
+}
+
+// Error is the body of an error response. Status is the HTTP status as
+// a string, as the protocol has it
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+// NewError returns the error of status
+func NewError(status int, scimType, detail string) Error {
+	return Error{Schemas: []string{SchemaError}, Status: strconv.Itoa(status), ScimType: scimType, Detail: detail}
+}
+
+// NewUser returns the user of e, usersURL the url of the Users endpoint
+func NewUser(e *models.Employee, usersURL string) User {
+	active := e.Status != models.StatusRetired
+	u := User{
+		Schemas:     []string{SchemaUser, SchemaEnterpriseUser},
+		ID:          e.UUID,
+		UserName:    e.Email,
+		Name:        &Name{Formatted: e.FirstName + " " + e.LastName, GivenName: e.FirstName, FamilyName: e.LastName},
+		DisplayName: e.FirstName + " " + e.LastName,
+		Title:       e.Position,
+		Active:      &active,
+		Emails:      []MultiValue{{Value: e.Email, Type: "work", Primary: true}},
+		Enterprise:  &EnterpriseUser{EmployeeNumber: e.EmployeeNumber, Department: e.Department},
+		Meta: &Meta{
+			ResourceType: "User",
+			Created:      e.CreatedAt,
+			LastModified: e.UpdatedAt,
+			Location:     usersURL + "/" + e.UUID,
+			Version:      `W/"` + strconv.FormatInt(e.UpdatedAt.UnixNano(), 10) + `"`,
+		},
+	}
+	if e.Phone != nil {
+		u.PhoneNumbers = []MultiValue{{Value: *e.Phone, Type: "work", Primary: true}}
+	}
+	return u
+}
+
+// IsActive reports whether u is active, as users are unless they say
+// otherwise
+func (u *User) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// Email returns the email of u: the primary email, else the work email,
+// else the first one, else the user name
+func (u *User) Email() string {
+	if v, ok := pick(u.Emails); ok {
+		return v
+	}
+	return u.UserName
+}
+
+// Phone returns the primary phone number of u, nil when u has none
+func (u *User) Phone() *string {
+	if v, ok := pick(u.PhoneNumbers); ok {
+		return &v
+	}
+	return nil
+}
+
+// CreateRequest returns the request creating the employee of u
+func (u *User) CreateRequest() models.CreateEmployeeRequest {
+	req := models.CreateEmployeeRequest{
+		Email:    u.Email(),
+		Position: u.Title,
+		Phone:    u.Phone(),
+	}
+	if u.Name != nil {
+		req.FirstName, req.LastName = u.Name.GivenName, u.Name.FamilyName
+	}
+	if u.Enterprise != nil {
+		req.EmployeeNumber, req.Department = u.Enterprise.EmployeeNumber, u.Enterprise.Department
+	}
+	return req
+}
+
+// Apply replaces the fields of req, the update request of an employee,
+// with those of u. The fields u lacks are cleared, except the employee
+// number and department when u has no enterprise extension, so identity
+// providers not mapping it keep them. The status is left to the caller
+func (u *User) Apply(req *models.UpdateEmployeeRequest) {
+	req.Email = u.Email()
+	req.Position = u.Title
+	req.Phone = u.Phone()
+	req.FirstName, req.LastName = "", ""
+	if u.Name != nil {
+		req.FirstName, req.LastName = u.Name.GivenName, u.Name.FamilyName
+	}
+	if u.Enterprise != nil {
+		req.EmployeeNumber, req.Department = u.Enterprise.EmployeeNumber, u.Enterprise.Department
+	}
+}
+
+// pick returns the primary value of values, else the work one, else the
+// first one
+func pick(values []MultiValue) (string, bool) {
+	for _, v := range values {
+		if v.Primary && v.Value != "" {
+			return v.Value, true
+		}
+	}
+	for _, v := range values {
+		if strings.EqualFold(v.Type, "work") && v.Value != "" {
+			return v.Value, true
+		}
+	}
+	for _, v := range values {
+		if v.Value != "" {
+			return v.Value, true
+		}
+	}
+	return "", false
+}
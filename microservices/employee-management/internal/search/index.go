@@ -0,0 +1,343 @@
+// Package search keeps an Elasticsearch (or OpenSearch) index of the
+// employees in sync with their changes and answers the full-text `q`
+// search of the employee list from it
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"employee-management/internal/models"
+)
+
+// ErrFilterUnsupported is returned by Search for filters the index does
+// not hold, e.g. skills or the archive
+var ErrFilterUnsupported = errors.New("filter is not supported by the search index")
+
+// filterFields maps the employee list filters to the keyword fields of the
+// index they match exactly
+var filterFields = map[string]string{
+	"department": "department.keyword",
+	"status":     "status",
+	"position":   "position.keyword",
+	"country":    "country",
+	"city":       "city.keyword",
+}
+
+// searchFields are the fields q is matched against, boosted by how much
+// a match says about the employee
+var searchFields = []string{
+	"employeeNumber^4",
+	"firstName^3",
+	"lastName^3",
+	"email^2",
+	"position",
+	"department",
+	"city",
+}
+
+// mapping is the body creating the index. Names are text for fuzzy
+// matching, the filterable fields keep a keyword copy for exact terms
+var mapping = map[string]any{
+	"mappings": map[string]any{
+		"properties": map[string]any{
+			"id":             map[string]any{"type": "long"},
+			"firstName":      map[string]any{"type": "text"},
+			"lastName":       map[string]any{"type": "text"},
+			"email":          map[string]any{"type": "text", "analyzer": "simple"},
+			"employeeNumber": map[string]any{"type": "keyword", "normalizer": "lowercase"},
+			"position":       textWithKeyword,
+			"department":     textWithKeyword,
+			"status":         map[string]any{"type": "keyword"},
+			"city":           textWithKeyword,
+			"country":        map[string]any{"type": "keyword"},
+			"indexedAt":      map[string]any{"type": "date"},
+		},
+	},
+	"settings": map[string]any{
+		"analysis": map[string]any{
+			"normalizer": map[string]any{
+				"lowercase": map[string]any{"type": "custom", "filter": []string{"lowercase"}},
+			},
+		},
+	},
+}
+
+var textWithKeyword = map[string]any{
+	"type":   "text",
+	"fields": map[string]any{"keyword": map[string]any{"type": "keyword"}},
+}
+
+// document is what the index holds of an employee: what is searched and
+// filtered on, no personal profile data
+type document struct {
+	ID             int64     `json:"id"`
+	FirstName      string    `json:"firstName"`
+	LastName       string    `json:"lastName"`
+	Email          string    `json:"email"`
+	EmployeeNumber string    `json:"employeeNumber"`
+	Position       string    `json:"position"`
+	Department     string    `json:"department"`
+	Status         string    `json:"status"`
+	City           string    `json:"city,omitempty"`
+	Country        string    `json:"country,omitempty"`
+	IndexedAt      time.Time `json:"indexedAt"`
+}
+
+// newDocument returns the document of e, stamped with indexedAt
+func newDocument(e *models.Employee, indexedAt time.Time) document {
+	doc := document{
+		ID:             e.ID,
+		FirstName:      e.FirstName,
+		LastName:       e.LastName,
+		Email:          e.Email,
+		EmployeeNumber: e.EmployeeNumber,
+		Position:       e.Position,
+		Department:     e.Department,
+		Status:         string(e.Status),
+		IndexedAt:      indexedAt,
+	}
+	if e.Address != nil {
+		doc.City = e.Address.City
+		doc.Country = e.Address.Country
+	}
+	return doc
+}
+
+// Index talks to one index through the Elasticsearch REST API, which
+// OpenSearch serves too
+type Index struct {
+	url      string
+	name     string
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewIndex creates an Index named name on the cluster at baseURL. The
+// credentials are sent with basic auth when username is set
+func NewIndex(baseURL, name, username, password string, timeout time.Duration) *Index {
+	return &Index{
+		url:      strings.TrimSuffix(baseURL, "/"),
+		name:     name,
+		username: username,
+		password: password,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// EnsureIndex creates the index with its mapping when missing and reports
+// whether it did
+func (i *Index) EnsureIndex(ctx context.Context) (bool, error) {
+	status, _, err := i.do(ctx, http.MethodHead, "/"+url.PathEscape(i.name), nil)
+	if err != nil {
+		return false, err
+	}
+	if status == http.StatusOK {
+		return false, nil
+	}
+
+	body, err := json.Marshal(mapping)
+	if err != nil {
+		return false, err
+	}
+	if err := i.expect(ctx, http.MethodPut, "/"+url.PathEscape(i.name), body); err != nil {
+		return false, fmt.Errorf("failed to create search index: %w", err)
+	}
+	return true, nil
+}
+
+// Put indexes e, replacing its previous document
+func (i *Index) Put(ctx context.Context, e *models.Employee) error {
+	body, err := json.Marshal(newDocument(e, time.Now().UTC()))
+	if err != nil {
+		return err
+	}
+	return i.expect(ctx, http.MethodPut, i.docPath(e.ID), body)
+}
+
+// Delete removes the document of the employee, if any
+func (i *Index) Delete(ctx context.Context, id int64) error {
+	status, resp, err := i.do(ctx, http.MethodDelete, i.docPath(id), nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNotFound {
+		return fmt.Errorf("search index answered %d: %s", status, resp)
+	}
+	return nil
+}
+
+// Bulk indexes employees in one request, stamping them with indexedAt
+func (i *Index) Bulk(ctx context.Context, employees []models.Employee, indexedAt time.Time) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for n := range employees {
+		action := map[string]any{"index": map[string]any{"_index": i.name, "_id": strconv.FormatInt(employees[n].ID, 10)}}
+		if err := enc.Encode(action); err != nil {
+			return err
+		}
+		if err := enc.Encode(newDocument(&employees[n], indexedAt)); err != nil {
+			return err
+		}
+	}
+
+	_, resp, err := i.do(ctx, http.MethodPost, "/_bulk", body.Bytes())
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+	if result.Errors {
+		return errors.New("search index rejected part of a bulk request")
+	}
+	return nil
+}
+
+// DeleteIndexedBefore removes the documents last indexed before t, e.g.
+// employees deleted while the index was not told
+func (i *Index) DeleteIndexedBefore(ctx context.Context, t time.Time) error {
+	body, err := json.Marshal(map[string]any{
+		"query": map[string]any{"range": map[string]any{"indexedAt": map[string]any{"lt": t}}},
+	})
+	if err != nil {
+		return err
+	}
+	return i.expect(ctx, http.MethodPost, "/"+url.PathEscape(i.name)+"/_delete_by_query?conflicts=proceed", body)
+}
+
+// Search returns the ids of the employees best matching q, fuzzily and
+// across fields, that also match filters, with the number of matches
+func (i *Index) Search(ctx context.Context, q string, filters map[string]interface{}, limit, offset int) ([]int64, int, error) {
+	terms := []any{}
+	for key, value := range filters {
+		field, ok := filterFields[key]
+		if !ok {
+			return nil, 0, fmt.Errorf("%w: %s", ErrFilterUnsupported, key)
+		}
+		if values, ok := value.([]string); ok {
+			terms = append(terms, map[string]any{"terms": map[string]any{field: values}})
+			continue
+		}
+		terms = append(terms, map[string]any{"term": map[string]any{field: value}})
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"from":             offset,
+		"size":             limit,
+		"_source":          false,
+		"track_total_hits": true,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must": map[string]any{
+					"multi_match": map[string]any{
+						"query":     q,
+						"fields":    searchFields,
+						"fuzziness": "AUTO",
+						"operator":  "and",
+					},
+				},
+				"filter": terms,
+			},
+		},
+		"sort": []any{"_score", map[string]any{"id": "desc"}},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	status, resp, err := i.do(ctx, http.MethodPost, "/"+url.PathEscape(i.name)+"/_search", body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if status != http.StatusOK {
+		return nil, 0, fmt.Errorf("search index answered %d: %s", status, resp)
+	}
+
+	var result struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				ID string `json:"_id"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode search response: %w", err)
+	}
+
+	ids := make([]int64, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		id, err := strconv.ParseInt(hit.ID, 10, 64)
+		if err != nil {
+			return nil, 0, fmt.Errorf("search index returned invalid id %q", hit.ID)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, result.Hits.Total.Value, nil
+}
+
+// docPath is the path of the document of employee id
+func (i *Index) docPath(id int64) string {
+	return "/" + url.PathEscape(i.name) + "/_doc/" + strconv.FormatInt(id, 10)
+}
+
+// expect sends a request and fails unless it is answered with a 2xx
+func (i *Index) expect(ctx context.Context, method, path string, body []byte) error {
+	status, resp, err := i.do(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status > 299 {
+		return fmt.Errorf("search index answered %d: %s", status, resp)
+	}
+	return nil
+}
+
+// do sends a request to the cluster and returns the status and body of
+// the response
+func (i *Index) do(ctx context.Context, method, path string, body []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, i.url+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	if body != nil {
+		contentType := "application/json"
+		if path == "/_bulk" {
+			contentType = "application/x-ndjson"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	if i.username != "" {
+		req.SetBasicAuth(i.username, i.password)
+	}
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("search request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read search response: %w", err)
+	}
+	return resp.StatusCode, data, nil
+}
@@ -0,0 +1,147 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+	"employee-management/internal/repository"
+)
+
+// bulkSize is how many employees a reindex sends per bulk request
+const bulkSize = 500
+
+// Source reads the employees being indexed
+type Source interface {
+	FindByID(ctx context.Context, id int64) (*models.Employee, error)
+	FindAllStream(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error
+}
+
+// Subscription hands out the employee events, e.g. a stream.Hub
+type Subscription func() (<-chan events.Event, func())
+
+// Indexer keeps the index in sync: every employee event re-reads its
+// employee and indexes or removes it, and a periodic reindex catches up
+// with what the events missed (events disabled, a dropped subscription)
+type Indexer struct {
+	index  *Index
+	source Source
+}
+
+// NewIndexer creates a new Indexer
+func NewIndexer(index *Index, source Source) *Indexer {
+	return &Indexer{index: index, source: source}
+}
+
+// Reindex indexes every employee, then removes the documents the run did
+// not touch, i.e. employees deleted or archived since. It returns how
+// many employees were indexed
+func (x *Indexer) Reindex(ctx context.Context) (int, error) {
+	startedAt := time.Now().UTC()
+	indexed := 0
+
+	batch := make([]models.Employee, 0, bulkSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := x.index.Bulk(ctx, batch, startedAt); err != nil {
+			return err
+		}
+		indexed += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	err := x.source.FindAllStream(ctx, nil, func(e models.Employee) error {
+		batch = append(batch, e)
+		if len(batch) < bulkSize {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return indexed, err
+	}
+
+	return indexed, x.index.DeleteIndexedBefore(ctx, startedAt)
+}
+
+// Sync applies the change of one employee to the index
+func (x *Indexer) Sync(ctx context.Context, id int64) error {
+	e, err := x.source.FindByID(ctx, id)
+	if errors.Is(err, repository.ErrEmployeeNotFound) {
+		return x.index.Delete(ctx, id)
+	}
+	if err != nil {
+		return err
+	}
+	return x.index.Put(ctx, e)
+}
+
+// Run creates the index if needed, reindexing it when created, then
+// follows the events of subscribe and reindexes every interval, 0 never,
+// until ctx is done
+func (x *Indexer) Run(ctx context.Context, subscribe Subscription, interval time.Duration) {
+	created, err := x.index.EnsureIndex(ctx)
+	if err != nil {
+		log.Printf("search index setup failed, retrying on the next reindex: %v", err)
+	}
+	if created || err != nil {
+		x.reindex(ctx)
+	}
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	changes, unsubscribe := subscribe()
+	defer func() { unsubscribe() }()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			if _, err := x.index.EnsureIndex(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("search index setup failed: %v", err)
+				continue
+			}
+			x.reindex(ctx)
+		case e, ok := <-changes:
+			if !ok {
+				// Dropped for falling behind, the missed changes are
+				// caught up by a reindex. Changes made while it runs are
+				// left to the next one
+				log.Printf("search indexer fell behind the change stream, reindexing")
+				x.reindex(ctx)
+				changes, unsubscribe = subscribe()
+				continue
+			}
+			if err := x.Sync(ctx, e.AggregateID); err != nil && ctx.Err() == nil {
+				log.Printf("search indexing of employee %d failed: %v", e.AggregateID, err)
+			}
+		}
+	}
+}
+
+// reindex runs Reindex and logs the outcome
+func (x *Indexer) reindex(ctx context.Context) {
+	indexed, err := x.Reindex(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("search reindex failed after %d employees: %v", indexed, err)
+		}
+		return
+	}
+	log.Printf("search reindex indexed %d employees", indexed)
+}
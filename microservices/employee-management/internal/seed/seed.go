@@ -0,0 +1,116 @@
+// Package seed loads demo employees for development and demo environments
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"employee-management/internal/models"
+	"employee-management/internal/repository"
+)
+
+// NumberPrefix starts the employee number of every demo employee
+const NumberPrefix = "DEMO-"
+
+// MaxCount bounds the number of demo employees a run creates
+const MaxCount = 10000
+
+// departments lists the demo departments with their positions
+var departments = []struct {
+	Name      string
+	Positions []string
+}{
+	{"Engineering", []string{"Software Engineer", "Senior Software Engineer", "Engineering Manager", "QA Engineer", "DevOps Engineer"}},
+	{"Human Resources", []string{"HR Generalist", "Recruiter", "HR Manager"}},
+	{"Finance", []string{"Accountant", "Financial Analyst", "Payroll Specialist"}},
+	{"Sales", []string{"Account Executive", "Sales Representative", "Sales Manager"}},
+	{"Marketing", []string{"Marketing Specialist", "Content Writer", "Product Marketing Manager"}},
+	{"Operations", []string{"Operations Analyst", "Office Manager", "Logistics Coordinator"}},
+	{"Customer Support", []string{"Support Agent", "Support Team Lead"}},
+}
+
+var firstNames = []string{
+	"Ana", "Andres", "Camila", "Carlos", "Daniela", "David", "Diana", "Felipe",
+	"Gabriela", "Juan", "Laura", "Luis", "Maria", "Mateo", "Natalia", "Nicolas",
+	"Paula", "Santiago", "Sofia", "Valentina", "Alejandro", "Isabella", "Sebastian", "Mariana",
+}
+
+var lastNames = []string{
+	"Garcia", "Rodriguez", "Martinez", "Lopez", "Gonzalez", "Hernandez", "Perez", "Sanchez",
+	"Ramirez", "Torres", "Flores", "Rivera", "Gomez", "Diaz", "Cruz", "Morales",
+	"Ortiz", "Gutierrez", "Castro", "Vargas", "Rojas", "Jimenez", "Moreno", "Herrera",
+}
+
+// Result counts what a run did
+type Result struct {
+	Created int
+	Skipped int
+}
+
+// Employees returns the first count demo employees. The list is the same
+// on every call, which is what makes seeding idempotent
+func Employees(count int) []models.Employee {
+	today := models.Today().Time()
+	employees := make([]models.Employee, 0, count)
+	for i := 1; i <= count; i++ {
+		// Strides coprime with the list lengths spread the combinations
+		first := firstNames[(i*5)%len(firstNames)]
+		last := lastNames[(i*7)%len(lastNames)]
+		dept := departments[i%len(departments)]
+		position := dept.Positions[(i/len(departments))%len(dept.Positions)]
+
+		status := models.StatusActive
+		switch {
+		case i%25 == 0:
+			status = models.StatusRetired
+		case i%10 == 0:
+			status = models.StatusOnVacation
+		}
+
+		// Hire dates spread over the last ten years
+		hired := today.AddDate(0, 0, -((i * 97) % 3650))
+
+		employees = append(employees, models.Employee{
+			FirstName:      first,
+			LastName:       last,
+			Email:          fmt.Sprintf("%s.%s.%d@demo.example.com", strings.ToLower(first), strings.ToLower(last), i),
+			EmployeeNumber: fmt.Sprintf("%s%04d", NumberPrefix, i),
+			Position:       position,
+			Department:     dept.Name,
+			Status:         status,
+			HireDate:       models.DateOf(hired),
+		})
+	}
+	return employees
+}
+
+// Run creates the first count demo employees, skipping the ones already
+// there. Employees are written straight to the repository, so no domain
+// events are stored for them
+func Run(ctx context.Context, repo repository.EmployeeRepository, count int) (Result, error) {
+	var result Result
+	if count < 1 || count > MaxCount {
+		return result, fmt.Errorf("count must be between 1 and %d", MaxCount)
+	}
+
+	for _, e := range Employees(count) {
+		err := repo.Create(ctx, &e)
+		switch {
+		case err == nil:
+			result.Created++
+		case errors.Is(err, repository.ErrEmployeeNumberAlreadyExists),
+			errors.Is(err, repository.ErrEmailAlreadyExists),
+			errors.Is(err, repository.ErrEmployeeAlreadyExists):
+			result.Skipped++
+		default:
+			return result, fmt.Errorf("failed to seed employee %s: %w", e.EmployeeNumber, err)
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
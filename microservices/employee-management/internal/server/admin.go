@@ -0,0 +1,55 @@
+package server
+
+import (
+	"crypto/subtle"
+	"log"
+	"net"
+	"net/http"
+	"net/http/pprof"
+
+	"employee-management/internal/config"
+)
+
+// RunAdmin starts the admin listener with the pprof endpoints in the
+// background. It does nothing unless pprof is enabled in the config or
+// debugging is on. The listener binds to the admin host (localhost by default) and, when an
+// admin token is set, requires it as a bearer token. The config requires
+// one unless the admin host is loopback
+func RunAdmin(cfg *config.Config) {
+	if !cfg.ServePprof() {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	addr := net.JoinHostPort(cfg.AdminHost, cfg.AdminPort)
+	go func() {
+		log.Printf("pprof available at http://%s/debug/pprof/", addr)
+		if err := http.ListenAndServe(addr, requireToken(cfg.AdminToken, mux)); err != nil {
+			log.Printf("admin listener stopped: %v", err)
+		}
+	}()
+}
+
+// requireToken rejects requests without the bearer token
+// An empty token disables the check, only allowed on a loopback host
+func requireToken(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, expected) != 1 {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
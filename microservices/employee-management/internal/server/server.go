@@ -0,0 +1,105 @@
+// Package server runs the HTTP server, terminating TLS when configured
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"employee-management/internal/config"
+
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// New returns the server of handler on the configured port, for Run
+func New(cfg *config.Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:    ":" + cfg.ServerPort,
+		Handler: handler,
+	}
+}
+
+// Run starts serving srv until it is shut down, when it returns nil
+// Uses TLS when cert files or autocert domains are set, and optionally
+// starts a plain HTTP listener that redirects to HTTPS
+func Run(cfg *config.Config, srv *http.Server) error {
+	if err := run(cfg, srv); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops srv gracefully: the listener closes and in-flight
+// requests get up to timeout to finish before their connections are
+// closed, streams included
+func Shutdown(srv *http.Server, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("requests still running after %s, closing their connections", timeout)
+		srv.Close()
+	}
+}
+
+// run serves srv, see Run
+func run(cfg *config.Config, srv *http.Server) error {
+	if !cfg.TLSEnabled() {
+		log.Printf("Employee service running on :%s", cfg.ServerPort)
+		return srv.ListenAndServe()
+	}
+
+	redirect := redirectHandler(cfg.ServerPort)
+
+	switch {
+	case cfg.TLSAutocertDomains != "":
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(envconfig.SplitList(cfg.TLSAutocertDomains)...),
+			Cache:      autocert.DirCache(cfg.TLSAutocertCacheDir),
+		}
+		srv.TLSConfig = manager.TLSConfig()
+		// ACME http-01 challenges must be answered on the plain listener
+		redirect = manager.HTTPHandler(redirect)
+	default:
+		reloader, err := newCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return err
+		}
+		srv.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: reloader.GetCertificate,
+		}
+	}
+
+	if cfg.HTTPRedirectPort != "" {
+		go func() {
+			log.Printf("Redirecting HTTP on :%s to HTTPS", cfg.HTTPRedirectPort)
+			if err := http.ListenAndServe(":"+cfg.HTTPRedirectPort, redirect); err != nil {
+				log.Printf("HTTP redirect listener stopped: %v", err)
+			}
+		}()
+	}
+
+	log.Printf("Employee service running on :%s (TLS)", cfg.ServerPort)
+	return srv.ListenAndServeTLS("", "")
+}
+
+// redirectHandler sends every request to the same path over HTTPS
+func redirectHandler(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
@@ -3,28 +3,145 @@ package service
 
 import (
 	"context"
+	"errors"
+	"log"
+	"strings"
 	"time"
 
+	"employee-management/internal/events"
+	"employee-management/internal/features"
 	"employee-management/internal/models"
 	"employee-management/internal/repository"
+	"employee-management/internal/validator"
+	"employee-management/internal/verification"
+
+	"github.com/google/uuid"
 )
 
+// ErrSearchDisabled is returned by Search when no search index is
+// configured
+var ErrSearchDisabled = errors.New("search is not enabled")
+
+// Errors of the employee status rules
+var (
+	// ErrRehireRequired is returned by Update moving a retired employee
+	// to another status, which only Rehire does
+	ErrRehireRequired = errors.New("retired employees return to active through a rehire")
+
+	// ErrEmployeeRetired is returned by Update editing a retired
+	// employee, who is kept as they left
+	ErrEmployeeRetired = errors.New("retired employees cannot be edited")
+
+	// ErrNotRetired is returned by Rehire for an employee who is not
+	// retired
+	ErrNotRetired = errors.New("employee is not retired")
+
+	// ErrProbationStatus is returned by Update moving an employee to
+	// PROBATION, which they are only in from their hire
+	ErrProbationStatus = errors.New("employees are only on probation from their hire")
+)
+
+// Errors of email verification
+var (
+	// ErrVerificationDisabled is returned by VerifyEmail and
+	// RequestEmailVerification when email verification, or the events
+	// delivering its emails, are off
+	ErrVerificationDisabled = errors.New("email verification is not enabled")
+
+	// ErrEmailAlreadyVerified is returned by RequestEmailVerification for
+	// an employee whose email is verified
+	ErrEmailAlreadyVerified = errors.New("email is already verified")
+)
+
+// MaxBulkUpdate is the most employees a bulk update changes, keeping its
+// transaction short
+const MaxBulkUpdate = 1000
+
+// ErrBulkTooLarge is returned by BulkUpdate when the filter matches more
+// than MaxBulkUpdate employees
+var ErrBulkTooLarge = errors.New("bulk update matches too many employees")
+
+// Searcher ranks employees by how well they match a full-text query
+type Searcher interface {
+	// Search returns a page of the ids of the employees matching q and
+	// filters, best match first, with the number of matches
+	Search(ctx context.Context, q string, filters map[string]interface{}, limit, offset int) ([]int64, int, error)
+}
+
+// Invalidator drops the cached reads of employees
+type Invalidator interface {
+	// Invalidate invalidates the employees and every cached list
+	Invalidate(ctx context.Context, ids ...int64) error
+}
+
 // EmployeeService handles business logic for employee operations
 // It acts as an intermediary between API handlers and the data repository
 type EmployeeService struct {
-	repo repository.EmployeeRepository
+	repo  repository.EmployeeRepository
+	flags *features.Flags
+
+	// searcher answers Search, nil when search is disabled
+	searcher Searcher
+
+	// invalidator is called once a write is done, nil without a cache
+	invalidator Invalidator
+
+	// idFormat is how the API addresses employees
+	idFormat models.IDFormat
+
+	// verifier signs the email verification links, nil when email
+	// verification is disabled
+	verifier *verification.Verifier
 }
 
 // NewEmployeeService creates a new instance of EmployeeService
-func NewEmployeeService(repo repository.EmployeeRepository) *EmployeeService {
-	return &EmployeeService{repo: repo}
+// searcher may be nil, disabling Search, invalidator may be nil when
+// nothing is cached and verifier may be nil, disabling email verification
+func NewEmployeeService(repo repository.EmployeeRepository, flags *features.Flags, searcher Searcher, invalidator Invalidator, idFormat models.IDFormat, verifier *verification.Verifier) *EmployeeService {
+	return &EmployeeService{repo: repo, flags: flags, searcher: searcher, invalidator: invalidator, idFormat: idFormat, verifier: verifier}
+}
+
+// IDFormat returns how the API addresses employees
+func (s *EmployeeService) IDFormat() models.IDFormat {
+	return s.idFormat
 }
 
-// Create adds a new employee to the database
+// Create adds a new employee to the database, hired today unless a hire
+// date is given, on PROBATION when they have a probation end date
+// When events are enabled the employee.created event is stored in the
+// outbox in the same transaction, followed by
+// employee.email_verification_requested when email verification is on
 func (s *EmployeeService) Create(ctx context.Context, e *models.Employee) error {
+	e.FirstName = validator.NormalizeName(e.FirstName)
+	e.LastName = validator.NormalizeName(e.LastName)
+	e.Email = normalizeEmail(e.Email)
+	e.Phone = normalizePhone(e.Phone)
 	e.Status = models.StatusActive
-	e.HireDate = time.Now()
-	return s.repo.Create(ctx, e)
+	if e.ProbationEndDate != nil {
+		e.Status = models.StatusProbation
+	}
+	if e.HireDate.IsZero() {
+		e.HireDate = models.Today()
+	}
+	e.UUID = uuid.NewString()
+	e.EmailVerifiedAt = nil
+
+	if !s.flags.Enabled(features.Events) {
+		return s.repo.Create(ctx, e)
+	}
+
+	return s.repo.WithTx(ctx, func(repo repository.EmployeeRepository) error {
+		if err := repo.Create(ctx, e); err != nil {
+			return err
+		}
+		if err := appendEvent(ctx, repo, events.EmployeeCreated, e.ID, e); err != nil {
+			return err
+		}
+		if s.verifier != nil {
+			return s.requestVerification(ctx, repo, e)
+		}
+		return nil
+	})
 }
 
 // FindByID retrieves an employee by id
@@ -49,25 +166,557 @@ func (s *EmployeeService) FindAll(ctx context.Context, page, pageSize int, filte
 
 	offset := (page - 1) * pageSize
 
-	employees, err := s.repo.FindAll(ctx, pageSize, offset, filters)
+	return s.repo.FindPage(ctx, pageSize, offset, filters)
+}
+
+// FindAllWithoutTotal retrieves a page of the employees without counting
+// them, reporting instead whether a next page exists by reading one more
+// employee than the page holds
+func (s *EmployeeService) FindAllWithoutTotal(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]models.Employee, bool, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	employees, err := s.repo.FindAll(ctx, pageSize+1, (page-1)*pageSize, filters)
 	if err != nil {
-		return nil, 0, err
+		return nil, false, err
+	}
+	if len(employees) > pageSize {
+		return employees[:pageSize], true, nil
+	}
+	return employees, false, nil
+}
+
+// FindByUUID retrieves an employee by their public uuid
+func (s *EmployeeService) FindByUUID(ctx context.Context, uuid string) (*models.Employee, error) {
+	return s.repo.FindByUUID(ctx, uuid)
+}
+
+// Search retrieves a page of the employees matching the full-text query
+// q and filters, best match first. The page is read from the database by
+// id, so employees deleted since they were indexed are left out
+func (s *EmployeeService) Search(ctx context.Context, q string, page, pageSize int, filters map[string]interface{}) ([]models.Employee, int, error) {
+	if s.searcher == nil {
+		return nil, 0, ErrSearchDisabled
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
 	}
 
-	total, err := s.repo.Count(ctx, filters)
+	ids, total, err := s.searcher.Search(ctx, q, filters, pageSize, (page-1)*pageSize)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	employees := make([]models.Employee, 0, len(ids))
+	for _, id := range ids {
+		e, err := s.repo.FindByID(ctx, id)
+		if errors.Is(err, repository.ErrEmployeeNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		employees = append(employees, *e)
+	}
+
 	return employees, total, nil
 }
 
+// FindAllStream calls fn for every employee matching filters, by id,
+// without loading them all at once. Meant for jobs walking every employee,
+// such as exports and search indexing
+func (s *EmployeeService) FindAllStream(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error {
+	return s.repo.FindAllStream(ctx, filters, fn)
+}
+
+// Snapshot retrieves a page of employees by id with the version of their
+// latest event, for consumers bootstrapping a copy of the employees
+func (s *EmployeeService) Snapshot(ctx context.Context, afterID int64, limit int) ([]models.VersionedEmployee, error) {
+	return s.repo.Snapshot(ctx, afterID, limit)
+}
+
 // Update updates an employee
+// A retired employee cannot be edited nor moved to another status, they
+// come back through Rehire
+// The email stays verified unless it changed, a new email is verified
+// again
+// When events are enabled employee.updated, and employee.status_changed if
+// the status changed, are stored in the outbox in the same transaction,
+// and employee.email_verification_requested if the email changed and
+// email verification is on
 func (s *EmployeeService) Update(ctx context.Context, e *models.Employee) error {
-	return s.repo.Update(ctx, e)
+	e.FirstName = validator.NormalizeName(e.FirstName)
+	e.LastName = validator.NormalizeName(e.LastName)
+	e.Email = normalizeEmail(e.Email)
+	e.Phone = normalizePhone(e.Phone)
+
+	return s.save(ctx, e, func(current, e *models.Employee) error {
+		keepVerification(current, e)
+		return checkTransition(current, e)
+	}, "")
+}
+
+// VerifyEmail marks as verified the email of the employee a verification
+// token was issued to and returns them. The token stops working once the
+// email changes, verifying twice is harmless
+func (s *EmployeeService) VerifyEmail(ctx context.Context, token string) (*models.Employee, error) {
+	if s.verifier == nil {
+		return nil, ErrVerificationDisabled
+	}
+
+	id, err := s.verifier.ID(token)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.repo.FindByID(ctx, id)
+	if errors.Is(err, repository.ErrEmployeeNotFound) {
+		return nil, verification.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifier.Check(token, e.Email); err != nil {
+		return nil, err
+	}
+	if e.EmailVerifiedAt != nil {
+		return e, nil
+	}
+
+	now := time.Now().UTC()
+	e.EmailVerifiedAt = &now
+	err = s.save(ctx, e, func(current, _ *models.Employee) error {
+		if current.Email != e.Email {
+			return verification.ErrInvalidToken
+		}
+		return nil
+	}, "")
+	if errors.Is(err, repository.ErrEmployeeNotFound) {
+		return nil, verification.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// RequestEmailVerification sends the employee a new verification link,
+// storing employee.email_verification_requested in the outbox. The links
+// sent before keep working until they expire
+func (s *EmployeeService) RequestEmailVerification(ctx context.Context, id int64) error {
+	if s.verifier == nil || !s.flags.Enabled(features.Events) {
+		return ErrVerificationDisabled
+	}
+
+	return s.repo.WithTx(ctx, func(repo repository.EmployeeRepository) error {
+		e, err := repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if e.EmailVerifiedAt != nil {
+			return ErrEmailAlreadyVerified
+		}
+		return s.requestVerification(ctx, repo, e)
+	})
+}
+
+// Rehire moves a retired employee back to ACTIVE, keeping the rest of
+// their record, and returns them
+func (s *EmployeeService) Rehire(ctx context.Context, id int64) (*models.Employee, error) {
+	e, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	e.Status = models.StatusActive
+
+	err = s.save(ctx, e, func(current, _ *models.Employee) error {
+		if current.Status != models.StatusRetired {
+			return ErrNotRetired
+		}
+		return nil
+	}, events.ReasonRehire)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// RehireWith moves a retired employee back to ACTIVE with the changes of
+// e, in one transaction so no other update lands between the rehire and
+// the changes. It is stored as a rehire: employee.updated and
+// employee.status_changed with the reason rehire
+func (s *EmployeeService) RehireWith(ctx context.Context, e *models.Employee) error {
+	e.FirstName = validator.NormalizeName(e.FirstName)
+	e.LastName = validator.NormalizeName(e.LastName)
+	e.Email = normalizeEmail(e.Email)
+	e.Phone = normalizePhone(e.Phone)
+	e.Status = models.StatusActive
+
+	return s.save(ctx, e, func(current, e *models.Employee) error {
+		if current.Status != models.StatusRetired {
+			return ErrNotRetired
+		}
+		keepVerification(current, e)
+		return nil
+	}, events.ReasonRehire)
+}
+
+// EndProbations makes ACTIVE the employees on PROBATION whose probation
+// end date has lapsed and returns how many. Employees whose status
+// changed meanwhile are skipped. Each change is recorded in the probation
+// audit log, and when events are enabled stores employee.status_changed
+// with the reason probation_ended
+func (s *EmployeeService) EndProbations(ctx context.Context) (int, error) {
+	today := models.Today()
+
+	var lapsed []int64
+	err := s.repo.FindAllStream(ctx, map[string]interface{}{"status": string(models.StatusProbation)}, func(e models.Employee) error {
+		if probationLapsed(&e, today) {
+			lapsed = append(lapsed, e.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	ended := 0
+	for _, id := range lapsed {
+		e, err := s.repo.FindByID(ctx, id)
+		if errors.Is(err, repository.ErrEmployeeNotFound) {
+			continue
+		}
+		if err != nil {
+			return ended, err
+		}
+		e.Status = models.StatusActive
+
+		err = s.save(ctx, e, func(current, _ *models.Employee) error {
+			if current.Status != models.StatusProbation || !probationLapsed(current, today) {
+				return errProbationChanged
+			}
+			return nil
+		}, events.ReasonProbationEnded)
+		if errors.Is(err, errProbationChanged) || errors.Is(err, repository.ErrEmployeeNotFound) {
+			continue
+		}
+		if err != nil {
+			return ended, err
+		}
+		ended++
+	}
+
+	return ended, nil
+}
+
+// BulkUpdate sets changes on every employee matching filters in one
+// transaction and returns the outcome for each of them, by id. Retired
+// employees are skipped, they cannot be edited. When events are enabled
+// an employee.updated event of every updated employee is stored in the
+// outbox in the same transaction, so consumers get the whole batch or
+// none of it. A dry run returns the same outcomes without changing
+// anything
+func (s *EmployeeService) BulkUpdate(ctx context.Context, filters map[string]interface{}, changes models.BulkChanges, dryRun bool) ([]models.BulkResult, error) {
+	if dryRun {
+		return s.bulkUpdate(ctx, s.repo, filters, changes, true)
+	}
+
+	var results []models.BulkResult
+	err := s.repo.WithTx(ctx, func(repo repository.EmployeeRepository) error {
+		var err error
+		results, err = s.bulkUpdate(ctx, repo, filters, changes, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var updated []int64
+	for _, r := range results {
+		if r.Result == models.BulkUpdated {
+			updated = append(updated, r.ID)
+		}
+	}
+	if len(updated) > 0 {
+		s.invalidate(ctx, updated...)
+	}
+	return results, nil
+}
+
+// bulkUpdate decides the outcome of BulkUpdate for every matched
+// employee, storing the updates unless dryRun
+func (s *EmployeeService) bulkUpdate(ctx context.Context, repo repository.EmployeeRepository, filters map[string]interface{}, changes models.BulkChanges, dryRun bool) ([]models.BulkResult, error) {
+	var matched []models.Employee
+	err := repo.FindAllStream(ctx, filters, func(e models.Employee) error {
+		if len(matched) == MaxBulkUpdate {
+			return ErrBulkTooLarge
+		}
+		matched = append(matched, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.BulkResult, 0, len(matched))
+	for i := range matched {
+		e := &matched[i]
+		result := models.BulkResult{ID: e.ID, UUID: e.UUID, Result: models.BulkUpdated}
+
+		if e.Status == models.StatusRetired {
+			result.Result = models.BulkSkipped
+			result.Reason = "Retired employees cannot be edited"
+			results = append(results, result)
+			continue
+		}
+
+		result.Changes = changes.Apply(e)
+		if len(result.Changes) == 0 {
+			result.Result = models.BulkUnchanged
+			results = append(results, result)
+			continue
+		}
+
+		if !dryRun {
+			if err := repo.Update(ctx, e); err != nil {
+				return nil, err
+			}
+			if s.flags.Enabled(features.Events) {
+				if err := appendEvent(ctx, repo, events.EmployeeUpdated, e.ID, e); err != nil {
+					return nil, err
+				}
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// errProbationChanged skips an employee whose probation was changed
+// between listing and ending it
+var errProbationChanged = errors.New("probation changed")
+
+// probationLapsed reports whether the probation of e ended before today
+func probationLapsed(e *models.Employee, today models.Date) bool {
+	return e.ProbationEndDate != nil && e.ProbationEndDate.Before(today)
+}
+
+// save stores the changes of e once check allows them against the stored
+// employee, with their events when events are enabled. reason explains a
+// status change not made by an update. The cached reads of e are
+// invalidated once it is saved
+func (s *EmployeeService) save(ctx context.Context, e *models.Employee, check func(current, e *models.Employee) error, reason string) error {
+	err := s.update(ctx, e, check, reason)
+	if err == nil {
+		s.invalidate(ctx, e.ID)
+	}
+	return err
+}
+
+// update stores the changes of e for save. The stored employee is read,
+// checked and written in one transaction, events enabled or not, together
+// with the probation audit entry when e leaves PROBATION for ACTIVE
+func (s *EmployeeService) update(ctx context.Context, e *models.Employee, check func(current, e *models.Employee) error, reason string) error {
+	return s.repo.WithTx(ctx, func(repo repository.EmployeeRepository) error {
+		current, err := repo.FindByID(ctx, e.ID)
+		if err != nil {
+			return err
+		}
+		if err := check(current, e); err != nil {
+			return err
+		}
+
+		if err := repo.Update(ctx, e); err != nil {
+			return err
+		}
+
+		if current.Status == models.StatusProbation && e.Status == models.StatusActive {
+			err := repo.AppendProbationAudit(ctx, &models.ProbationAuditEntry{
+				EmployeeID:       e.ID,
+				EmployeeNumber:   e.EmployeeNumber,
+				ProbationEndDate: current.ProbationEndDate,
+				Reason:           reason,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if !s.flags.Enabled(features.Events) {
+			return nil
+		}
+
+		if err := appendEvent(ctx, repo, events.EmployeeUpdated, e.ID, e); err != nil {
+			return err
+		}
+
+		if s.verifier != nil && current.Email != e.Email {
+			if err := s.requestVerification(ctx, repo, e); err != nil {
+				return err
+			}
+		}
+
+		if current.Status != e.Status {
+			change := events.StatusChange{
+				EmployeeID: e.ID,
+				From:       current.Status,
+				To:         e.Status,
+				Department: e.Department,
+				Reason:     reason,
+				FirstName:  e.FirstName,
+				LastName:   e.LastName,
+				Email:      e.Email,
+			}
+			return appendEvent(ctx, repo, events.EmployeeStatusChanged, e.ID, change)
+		}
+
+		return nil
+	})
+}
+
+// keepVerification keeps the email of e verified when it is the email of
+// current, a new email is verified again
+func keepVerification(current, e *models.Employee) {
+	e.EmailVerifiedAt = nil
+	if current.Email == e.Email {
+		e.EmailVerifiedAt = current.EmailVerifiedAt
+	}
+}
+
+// checkTransition enforces the status rules of an update: a retired
+// employee stays retired and unchanged, and nobody is put on probation
+func checkTransition(current, e *models.Employee) error {
+	if e.Status == models.StatusProbation && current.Status != models.StatusProbation {
+		return ErrProbationStatus
+	}
+	if current.Status != models.StatusRetired {
+		return nil
+	}
+	if e.Status != models.StatusRetired {
+		return ErrRehireRequired
+	}
+	if !sameRecord(current, e) {
+		return ErrEmployeeRetired
+	}
+	return nil
+}
+
+// sameRecord reports whether the editable fields of a and b are equal
+func sameRecord(a, b *models.Employee) bool {
+	return a.FirstName == b.FirstName && a.LastName == b.LastName &&
+		a.Email == b.Email && a.EmployeeNumber == b.EmployeeNumber &&
+		a.Position == b.Position && a.Department == b.Department &&
+		sameString(a.Phone, b.Phone) && sameString(a.NationalID, b.NationalID) &&
+		sameString(a.PersonalEmail, b.PersonalEmail) &&
+		(a.DateOfBirth == nil) == (b.DateOfBirth == nil) && (a.DateOfBirth == nil || *a.DateOfBirth == *b.DateOfBirth) &&
+		(a.Gender == nil) == (b.Gender == nil) && (a.Gender == nil || *a.Gender == *b.Gender) &&
+		(a.Address == nil) == (b.Address == nil) && (a.Address == nil || *a.Address == *b.Address)
 }
 
-// Delete removes an employee
+// sameString reports whether two optional strings are equal
+func sameString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Delete removes an employee and invalidates their cached reads
+// When events are enabled employee.deleted is stored in the outbox in the
+// same transaction
 func (s *EmployeeService) Delete(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	err := s.delete(ctx, id)
+	if err == nil {
+		s.invalidate(ctx, id)
+	}
+	return err
+}
+
+// delete removes an employee for Delete
+func (s *EmployeeService) delete(ctx context.Context, id int64) error {
+	if !s.flags.Enabled(features.Events) {
+		return s.repo.Delete(ctx, id)
+	}
+
+	return s.repo.WithTx(ctx, func(repo repository.EmployeeRepository) error {
+		current, err := repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := repo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return appendEvent(ctx, repo, events.EmployeeDeleted, id, current)
+	})
+}
+
+// invalidate drops the cached reads of the employees after a write, so
+// they are not read stale whichever layer cached them. A failure is only
+// logged, the write itself is done
+func (s *EmployeeService) invalidate(ctx context.Context, ids ...int64) {
+	if s.invalidator == nil {
+		return
+	}
+	if err := s.invalidator.Invalidate(context.WithoutCancel(ctx), ids...); err != nil {
+		log.Printf("cache invalidation of employees %v failed: %v", ids, err)
+	}
+}
+
+// normalizeEmail lower-cases an email so addresses differing only in case
+// are the same employee
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// normalizePhone stores phone numbers in E.164, so they are dialable and
+// compare equal whatever the grouping they were entered with. Numbers
+// that cannot be normalized, not checked by the caller, are kept as they
+// are
+func normalizePhone(phone *string) *string {
+	if phone == nil {
+		return nil
+	}
+	if normalized, ok := validator.NormalizePhone(*phone); ok {
+		return &normalized
+	}
+	return phone
+}
+
+// requestVerification stores in the outbox the event sending e a link
+// to verify their email
+func (s *EmployeeService) requestVerification(ctx context.Context, repo repository.EmployeeRepository, e *models.Employee) error {
+	link, expires := s.verifier.Link(e.ID, e.Email)
+	return appendEvent(ctx, repo, events.EmployeeEmailVerificationRequested, e.ID, events.EmailVerification{
+		EmployeeID:      e.ID,
+		FirstName:       e.FirstName,
+		LastName:        e.LastName,
+		Email:           e.Email,
+		VerificationURL: link,
+		ExpiresAt:       expires,
+	})
+}
+
+// appendEvent builds a domain event and stores it in the outbox
+func appendEvent(ctx context.Context, repo repository.EmployeeRepository, t events.Type, id int64, payload any) error {
+	evt, err := events.New(t, id, payload)
+	if err != nil {
+		return err
+	}
+	return repo.AppendEvent(ctx, evt)
 }
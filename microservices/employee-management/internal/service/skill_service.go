@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+
+	"employee-management/internal/models"
+	"employee-management/internal/repository"
+)
+
+// SkillService handles business logic for the skills catalog and the
+// skills of each employee
+type SkillService struct {
+	repo      repository.SkillRepository
+	employees repository.EmployeeRepository
+}
+
+// NewSkillService creates a new instance of SkillService
+func NewSkillService(repo repository.SkillRepository, employees repository.EmployeeRepository) *SkillService {
+	return &SkillService{repo: repo, employees: employees}
+}
+
+// Create adds a skill to the catalog
+func (s *SkillService) Create(ctx context.Context, skill *models.Skill) error {
+	return s.repo.Create(ctx, skill)
+}
+
+// FindByID retrieves a skill by id
+func (s *SkillService) FindByID(ctx context.Context, id int64) (*models.Skill, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// FindAll retrieves the catalog, only the skills of category when not empty
+func (s *SkillService) FindAll(ctx context.Context, category string) ([]models.Skill, error) {
+	return s.repo.FindAll(ctx, category)
+}
+
+// Delete removes a skill no employee holds
+func (s *SkillService) Delete(ctx context.Context, id int64) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// EmployeeSkills lists the skills of an employee
+func (s *SkillService) EmployeeSkills(ctx context.Context, employeeID int64) ([]models.EmployeeSkill, error) {
+	if _, err := s.employees.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByEmployee(ctx, employeeID)
+}
+
+// SkillsByEmployee lists the skills of each of the employees, keyed by
+// employee id, loading them at once
+func (s *SkillService) SkillsByEmployee(ctx context.Context, employeeIDs []int64) (map[int64][]models.EmployeeSkill, error) {
+	if len(employeeIDs) == 0 {
+		return map[int64][]models.EmployeeSkill{}, nil
+	}
+	return s.repo.FindByEmployees(ctx, employeeIDs)
+}
+
+// Assign gives an employee a skill at the given proficiency
+func (s *SkillService) Assign(ctx context.Context, employeeID int64, skill *models.EmployeeSkill) error {
+	return s.repo.Assign(ctx, employeeID, skill)
+}
+
+// Unassign removes a skill from an employee
+func (s *SkillService) Unassign(ctx context.Context, employeeID, skillID int64) error {
+	return s.repo.Unassign(ctx, employeeID, skillID)
+}
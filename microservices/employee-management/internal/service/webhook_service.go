@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	"employee-management/internal/models"
+	"employee-management/internal/repository"
+)
+
+// WebhookService handles business logic for webhook subscriptions
+type WebhookService struct {
+	repo repository.WebhookRepository
+}
+
+// NewWebhookService creates a new instance of WebhookService
+func NewWebhookService(repo repository.WebhookRepository) *WebhookService {
+	return &WebhookService{repo: repo}
+}
+
+// Create registers a new active subscription
+func (s *WebhookService) Create(ctx context.Context, w *models.WebhookSubscription) error {
+	w.Active = true
+	return s.repo.Create(ctx, w)
+}
+
+// FindByID retrieves a subscription by id
+func (s *WebhookService) FindByID(ctx context.Context, id int64) (*models.WebhookSubscription, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// FindAll retrieves every subscription
+func (s *WebhookService) FindAll(ctx context.Context) ([]models.WebhookSubscription, error) {
+	return s.repo.FindAll(ctx)
+}
+
+// Delete removes a subscription
+func (s *WebhookService) Delete(ctx context.Context, id int64) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// Deliveries retrieves a page of the delivery log of a subscription
+func (s *WebhookService) Deliveries(ctx context.Context, id int64, page, pageSize int) ([]models.WebhookDelivery, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	return s.repo.FindDeliveries(ctx, id, pageSize, (page-1)*pageSize)
+}
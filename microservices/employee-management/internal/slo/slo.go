@@ -0,0 +1,147 @@
+// Package slo tracks per route service level objectives: the share of
+// requests that must be good, answered below 5xx within the route's
+// latency, and how fast each route burns its error budget
+package slo
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// buckets is how many slots the window is split into
+const buckets = 60
+
+// Tracker counts good and bad requests per route over a sliding window
+type Tracker struct {
+	target    float64
+	latency   time.Duration
+	latencies map[string]time.Duration
+	window    time.Duration
+	step      time.Duration
+	now       func() time.Time
+
+	mu     sync.Mutex
+	routes map[string]*series
+}
+
+// series counts the requests of one route, slot by slot
+type series struct {
+	epochs []int64 // which step each slot holds
+	total  []int64
+	bad    []int64
+}
+
+// RouteReport is the state of the objective of one route over the window
+type RouteReport struct {
+	Route    string  `json:"route" example:"GET /employees-service/api/v1/employees/:id"`
+	Target   float64 `json:"target" example:"0.99"`
+	Latency  string  `json:"latency" example:"300ms"`
+	Requests int64   `json:"requests" example:"1200"`
+	Bad      int64   `json:"bad" example:"30"`
+	// BurnRate is the error rate over the allowed one: 1 spends the budget
+	// exactly over the window, above 1 faster
+	BurnRate float64 `json:"burnRate" example:"2.5"`
+	// BudgetRemaining is the share of the window's error budget left, below
+	// 0 once overspent
+	BudgetRemaining float64 `json:"budgetRemaining" example:"-1.5"`
+}
+
+// NewTracker creates a Tracker where target of the requests of a route
+// must be good, within latency unless latencies overrides it by
+// "METHOD /route/pattern", measured over window
+func NewTracker(target float64, latency time.Duration, latencies map[string]time.Duration, window time.Duration) *Tracker {
+	return &Tracker{
+		target:    target,
+		latency:   latency,
+		latencies: latencies,
+		window:    window,
+		step:      max(window/buckets, time.Second),
+		now:       time.Now,
+		routes:    map[string]*series{},
+	}
+}
+
+// Window returns the window burn rates are computed over
+func (t *Tracker) Window() time.Duration {
+	return t.window
+}
+
+// Latency returns the latency objective of route
+func (t *Tracker) Latency(route string) time.Duration {
+	if d, ok := t.latencies[route]; ok {
+		return d
+	}
+	return t.latency
+}
+
+// Observe counts a request of route and reports whether it was good
+func (t *Tracker) Observe(route string, status int, took time.Duration) bool {
+	good := status < http.StatusInternalServerError && took <= t.Latency(route)
+
+	epoch := t.now().UnixNano() / int64(t.step)
+	slot := int(epoch % buckets)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.routes[route]
+	if !ok {
+		s = &series{epochs: make([]int64, buckets), total: make([]int64, buckets), bad: make([]int64, buckets)}
+		t.routes[route] = s
+	}
+	if s.epochs[slot] != epoch {
+		s.epochs[slot], s.total[slot], s.bad[slot] = epoch, 0, 0
+	}
+	s.total[slot]++
+	if !good {
+		s.bad[slot]++
+	}
+	return good
+}
+
+// Report returns the routes seen in the window, the fastest burning first
+func (t *Tracker) Report() []RouteReport {
+	epoch := t.now().UnixNano() / int64(t.step)
+	budget := 1 - t.target
+
+	t.mu.Lock()
+	reports := make([]RouteReport, 0, len(t.routes))
+	for route, s := range t.routes {
+		var total, bad int64
+		for i := range buckets {
+			if epoch-s.epochs[i] < buckets {
+				total += s.total[i]
+				bad += s.bad[i]
+			}
+		}
+		if total == 0 {
+			continue
+		}
+
+		burn := float64(bad) / float64(total) / budget
+		reports = append(reports, RouteReport{
+			Route:           route,
+			Target:          t.target,
+			Latency:         t.Latency(route).String(),
+			Requests:        total,
+			Bad:             bad,
+			BurnRate:        burn,
+			BudgetRemaining: 1 - burn,
+		})
+	}
+	t.mu.Unlock()
+
+	slices.SortFunc(reports, func(a, b RouteReport) int {
+		switch {
+		case a.BurnRate > b.BurnRate:
+			return -1
+		case a.BurnRate < b.BurnRate:
+			return 1
+		}
+		return strings.Compare(a.Route, b.Route)
+	})
+	return reports
+}
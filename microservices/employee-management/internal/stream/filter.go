@@ -0,0 +1,66 @@
+package stream
+
+import (
+	"encoding/json"
+	"strings"
+
+	"employee-management/internal/events"
+)
+
+// Filter selects the events a live client wants. Empty fields match
+// everything
+type Filter struct {
+	Department string   `json:"department,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	EmployeeID int64    `json:"employeeId,omitempty"`
+	Types      []string `json:"types,omitempty"`
+}
+
+// eventFields are the payload fields filters look at. Employee payloads
+// carry status, status changes carry to
+type eventFields struct {
+	Department string `json:"department"`
+	Status     string `json:"status"`
+	To         string `json:"to"`
+}
+
+// Matches reports whether e passes the filter
+func (f Filter) Matches(e events.Event) bool {
+	if f.EmployeeID != 0 && e.AggregateID != f.EmployeeID {
+		return false
+	}
+
+	if len(f.Types) > 0 {
+		found := false
+		for _, t := range f.Types {
+			if t == string(e.Type) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.Department == "" && f.Status == "" {
+		return true
+	}
+
+	var fields eventFields
+	if err := json.Unmarshal(e.Payload, &fields); err != nil {
+		return false
+	}
+	if fields.Status == "" {
+		fields.Status = fields.To
+	}
+
+	if f.Department != "" && !strings.EqualFold(fields.Department, f.Department) {
+		return false
+	}
+	if f.Status != "" && !strings.EqualFold(fields.Status, f.Status) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,122 @@
+// Package stream broadcasts employee change events to live clients
+// (SSE, WebSocket) by tailing the outbox table
+package stream
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"employee-management/internal/events"
+	"employee-management/internal/repository"
+)
+
+// subscriberBuffer is the number of events queued per subscriber before it
+// is considered too slow and disconnected
+const subscriberBuffer = 64
+
+// Hub polls the outbox once for every instance and fans new events out to
+// the subscribers. A subscriber that cannot keep up is dropped, it can
+// reconnect and resume from its last sequence
+type Hub struct {
+	repo         repository.OutboxRepository
+	pollInterval time.Duration
+	wake         chan struct{}
+
+	mu          sync.Mutex
+	subscribers map[chan events.Event]struct{}
+}
+
+// NewHub creates a new Hub
+func NewHub(repo repository.OutboxRepository, pollInterval time.Duration) *Hub {
+	return &Hub{
+		repo:         repo,
+		pollInterval: pollInterval,
+		wake:         make(chan struct{}, 1),
+		subscribers:  map[chan events.Event]struct{}{},
+	}
+}
+
+// Subscribe registers a subscriber. The channel is closed when the
+// subscriber is dropped or unsubscribe is called
+func (h *Hub) Subscribe() (<-chan events.Event, func()) {
+	ch := make(chan events.Event, subscriberBuffer)
+
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[ch]; ok {
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+// Notify makes the hub poll the outbox now instead of at the next tick,
+// e.g. when told a new event was stored. It never blocks
+func (h *Hub) Notify() {
+	select {
+	case h.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Replay returns up to limit events after seq, used to resume a stream
+func (h *Hub) Replay(ctx context.Context, afterSeq int64, limit int) ([]events.Event, error) {
+	return h.repo.FindAfter(ctx, afterSeq, limit)
+}
+
+// Run tails the outbox until ctx is done, starting from the newest event
+func (h *Hub) Run(ctx context.Context) {
+	last, err := h.repo.LastSequence(ctx)
+	if err != nil {
+		log.Printf("stream hub failed to read last sequence: %v", err)
+	}
+
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-h.wake:
+		}
+
+		batch, err := h.repo.FindAfter(ctx, last, 500)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("stream hub poll failed: %v", err)
+			}
+			continue
+		}
+
+		for _, e := range batch {
+			h.broadcast(e)
+			last = e.Sequence
+		}
+	}
+}
+
+// broadcast sends e to every subscriber, dropping the slow ones
+func (h *Hub) broadcast(e events.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- e:
+		default:
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"regexp"
+
+	"employee-management/internal/models"
+
+	playground "github.com/go-playground/validator/v10"
+	"golang.org/x/text/language"
+)
+
+// postalCodes are the postal code formats of the countries that use them.
+// A country listed here requires a postal code
+var postalCodes = map[string]*regexp.Regexp{
+	"AR": regexp.MustCompile(`^([A-Z][0-9]{4}[A-Z]{3}|[0-9]{4})$`),
+	"AU": regexp.MustCompile(`^[0-9]{4}$`),
+	"BR": regexp.MustCompile(`^[0-9]{5}-?[0-9]{3}$`),
+	"CA": regexp.MustCompile(`^[A-Z][0-9][A-Z] ?[0-9][A-Z][0-9]$`),
+	"CL": regexp.MustCompile(`^[0-9]{7}$`),
+	"CO": regexp.MustCompile(`^[0-9]{6}$`),
+	"DE": regexp.MustCompile(`^[0-9]{5}$`),
+	"ES": regexp.MustCompile(`^[0-9]{5}$`),
+	"FR": regexp.MustCompile(`^[0-9]{5}$`),
+	"GB": regexp.MustCompile(`^[A-Z]{1,2}[0-9][A-Z0-9]? ?[0-9][A-Z]{2}$`),
+	"IN": regexp.MustCompile(`^[0-9]{6}$`),
+	"IT": regexp.MustCompile(`^[0-9]{5}$`),
+	"JP": regexp.MustCompile(`^[0-9]{3}-?[0-9]{4}$`),
+	"MX": regexp.MustCompile(`^[0-9]{5}$`),
+	"NL": regexp.MustCompile(`^[0-9]{4} ?[A-Z]{2}$`),
+	"PE": regexp.MustCompile(`^[0-9]{5}$`),
+	"US": regexp.MustCompile(`^[0-9]{5}(-[0-9]{4})?$`),
+}
+
+// stateCountries are the countries whose addresses require a state or
+// province
+var stateCountries = map[string]bool{
+	"AU": true, "BR": true, "CA": true, "IN": true, "MX": true, "US": true,
+}
+
+// otherPostalCode is the loose format accepted for the other countries
+var otherPostalCode = regexp.MustCompile(`^[A-Z0-9][A-Z0-9 -]{1,9}$`)
+
+// IsValidCountry reports whether code is an ISO 3166-1 alpha-2 country
+func IsValidCountry(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+	region, err := language.ParseRegion(code)
+	return err == nil && region.IsCountry() && region.String() == code
+}
+
+// validateAddress checks the state and postal code of an address against
+// the rules of its country, the struct level part of its validation. The
+// address is checked normalized, as it is stored
+func validateAddress(sl playground.StructLevel) {
+	a := sl.Current().Interface().(models.Address)
+	a.Normalize()
+	if !IsValidCountry(a.Country) {
+		return
+	}
+
+	if stateCountries[a.Country] && a.State == "" {
+		sl.ReportError(a.State, "state", "State", "state_required", a.Country)
+	}
+
+	switch format, ok := postalCodes[a.Country]; {
+	case ok && a.PostalCode == "":
+		sl.ReportError(a.PostalCode, "postalCode", "PostalCode", "postal_code_required", a.Country)
+	case ok && !format.MatchString(a.PostalCode):
+		sl.ReportError(a.PostalCode, "postalCode", "PostalCode", "postal_code_country", a.Country)
+	case !ok && a.PostalCode != "" && !otherPostalCode.MatchString(a.PostalCode):
+		sl.ReportError(a.PostalCode, "postalCode", "PostalCode", "postal_code", "")
+	}
+}
@@ -0,0 +1,194 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"employee-management/internal/models"
+
+	playground "github.com/go-playground/validator/v10"
+	"go.yaml.in/yaml/v3"
+)
+
+// Rule is a deployment specific check of a field of the employee create
+// and update requests, on top of the built-in ones. Exactly one of OneOf,
+// Domains and Pattern is set
+type Rule struct {
+	// Field is the JSON name of the checked field, e.g. department
+	Field string `yaml:"field"`
+
+	// OneOf lists the values the field may take
+	OneOf []string `yaml:"one_of"`
+
+	// Domains lists the email domains an email field may be at
+	Domains []string `yaml:"domains"`
+
+	// Pattern is a regular expression the whole field must match
+	Pattern string `yaml:"pattern"`
+
+	// Message replaces the default error message
+	Message string `yaml:"message"`
+
+	pattern *regexp.Regexp
+}
+
+// ruleFields are the request fields rules may check
+var ruleFields = map[string]bool{
+	"firstName": true, "lastName": true, "email": true, "employeeNumber": true,
+	"position": true, "department": true, "phone": true, "nationalId": true,
+	"personalEmail": true,
+}
+
+// rules are the loaded deployment rules
+var rules []Rule
+
+// LoadRules reads the deployment rules from a YAML file listing them under
+// rules, and checks every employee create and update request against
+// them. It returns how many rules were loaded
+func LoadRules(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read validation rules file: %w", err)
+	}
+
+	var file struct {
+		Rules []Rule `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return 0, fmt.Errorf("failed to parse validation rules file %s: %w", path, err)
+	}
+
+	for i := range file.Rules {
+		if err := file.Rules[i].compile(); err != nil {
+			return 0, fmt.Errorf("validation rule %d: %w", i+1, err)
+		}
+	}
+
+	rules = file.Rules
+	for i, r := range rules {
+		format, params := r.message()
+		configuredMessages[r.Field+"."+ruleTag(i)] = func() (string, []string) { return format, params }
+	}
+	engine.RegisterStructValidation(checkRules, models.CreateEmployeeRequest{}, models.UpdateEmployeeRequest{})
+
+	return len(rules), nil
+}
+
+// compile checks the rule is well formed and compiles its pattern
+func (r *Rule) compile() error {
+	if !ruleFields[r.Field] {
+		return fmt.Errorf("field %q cannot be checked", r.Field)
+	}
+
+	set := 0
+	for _, ok := range []bool{len(r.OneOf) > 0, len(r.Domains) > 0, r.Pattern != ""} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("exactly one of one_of, domains and pattern must be set")
+	}
+
+	if len(r.Domains) > 0 && r.Field != "email" && r.Field != "personalEmail" {
+		return fmt.Errorf("domains only apply to email fields, not %s", r.Field)
+	}
+
+	if r.Pattern != "" {
+		re, err := regexp.Compile(`^(?:` + r.Pattern + `)$`)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		r.pattern = re
+	}
+
+	return nil
+}
+
+// check reports whether value passes the rule
+func (r *Rule) check(value string) bool {
+	switch {
+	case len(r.OneOf) > 0:
+		for _, v := range r.OneOf {
+			if value == v {
+				return true
+			}
+		}
+		return false
+	case len(r.Domains) > 0:
+		_, domain, _ := strings.Cut(strings.ToLower(strings.TrimSpace(value)), "@")
+		for _, d := range r.Domains {
+			if domain == strings.ToLower(d) {
+				return true
+			}
+		}
+		return false
+	default:
+		return r.pattern.MatchString(value)
+	}
+}
+
+// message is the error of a value failing the rule, its format and the
+// params filled in
+func (r *Rule) message() (string, []string) {
+	switch {
+	case r.Message != "":
+		return r.Message, nil
+	case len(r.OneOf) > 0:
+		return "%s must be one of %s", []string{r.Field, strings.Join(r.OneOf, ", ")}
+	case len(r.Domains) > 0:
+		return "%s must be an address at %s", []string{r.Field, strings.Join(r.Domains, ", ")}
+	default:
+		return "%s must match the pattern %s", []string{r.Field, r.Pattern}
+	}
+}
+
+// ruleTag is the tag the i-th rule reports its failures with
+func ruleTag(i int) string {
+	return "rule_" + strconv.Itoa(i+1)
+}
+
+// checkRules runs the deployment rules on a request. Optional fields left
+// out are not checked
+func checkRules(sl playground.StructLevel) {
+	v := sl.Current()
+	for i, r := range rules {
+		value, ok := jsonField(v, r.Field)
+		if !ok {
+			continue
+		}
+		if !r.check(value) {
+			sl.ReportError(value, r.Field, r.Field, ruleTag(i), "")
+		}
+	}
+}
+
+// jsonField returns the string value of the field of struct v whose JSON
+// name is name, false when there is none or it is a nil pointer
+func jsonField(v reflect.Value, name string) (string, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if tag != name {
+			continue
+		}
+
+		f := v.Field(i)
+		if f.Kind() == reflect.Pointer {
+			if f.IsNil() {
+				return "", false
+			}
+			f = f.Elem()
+		}
+		if f.Kind() != reflect.String {
+			return "", false
+		}
+		return strings.TrimSpace(f.String()), true
+	}
+	return "", false
+}
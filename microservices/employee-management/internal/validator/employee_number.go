@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// defaultEmployeeNumberPattern is the employee number format when the
+// deployment configures none
+const defaultEmployeeNumberPattern = `^[A-Za-z0-9][A-Za-z0-9._-]{0,49}$`
+
+// maxEmployeeNumberLength is the most characters an employee number can
+// be stored with, whatever the pattern
+const maxEmployeeNumberLength = 50
+
+var (
+	employeeNumberPattern = defaultEmployeeNumberPattern
+	employeeNumberRegex   = regexp.MustCompile(defaultEmployeeNumberPattern)
+)
+
+// SetEmployeeNumberPattern replaces the default employee number format
+// with pattern, a regular expression the whole number must match, e.g.
+// EMP-\d{5}. "" restores the default
+func SetEmployeeNumberPattern(pattern string) error {
+	if pattern == "" {
+		employeeNumberPattern = defaultEmployeeNumberPattern
+		employeeNumberRegex = regexp.MustCompile(defaultEmployeeNumberPattern)
+		return nil
+	}
+
+	anchored := `^(?:` + pattern + `)$`
+	re, err := regexp.Compile(anchored)
+	if err != nil {
+		return err
+	}
+	employeeNumberPattern, employeeNumberRegex = anchored, re
+	return nil
+}
+
+// EmployeeNumberPattern returns the anchored regular expression employee
+// numbers must match
+func EmployeeNumberPattern() string {
+	return employeeNumberPattern
+}
+
+// MaxEmployeeNumberLength returns the most characters of an employee
+// number
+func MaxEmployeeNumberLength() int {
+	return maxEmployeeNumberLength
+}
+
+// isEmployeeNumber checks number matches the pattern and fits its column
+func isEmployeeNumber(number string) bool {
+	return utf8.RuneCountInString(number) <= maxEmployeeNumberLength && employeeNumberRegex.MatchString(number)
+}
+
+// employeeNumberMessage is the error of an employee number not matching,
+// naming the pattern when the deployment configured one
+func employeeNumberMessage() (string, []string) {
+	if employeeNumberPattern == defaultEmployeeNumberPattern {
+		return "Employee number must have 1 to 50 letters, digits, dots, underscores or dashes", nil
+	}
+	return "Employee number must match the pattern %s", []string{employeeNumberPattern}
+}
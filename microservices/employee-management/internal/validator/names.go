@@ -0,0 +1,50 @@
+package validator
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxNameLength is the most characters of a first or last name
+const maxNameLength = 100
+
+// NormalizeName trims a name, collapses the spaces between its parts and
+// composes it to NFC, so a name is stored the same way however its
+// accents were typed
+func NormalizeName(name string) string {
+	return norm.NFC.String(strings.Join(strings.Fields(name), " "))
+}
+
+// isPersonName checks a normalized name has at most 100 characters, all
+// letters and their marks, its parts separated by a space, hyphen or
+// apostrophe, e.g. "José", "Núñez", "O'Brien" or "Mary-Jane"
+func isPersonName(name string) bool {
+	name = NormalizeName(name)
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return false
+	}
+
+	separated := true
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r):
+			separated = false
+		case unicode.Is(unicode.M, r):
+			if separated {
+				return false
+			}
+		case r == ' ' || r == '-' || r == '\'' || r == '’':
+			if separated {
+				return false
+			}
+			separated = true
+		default:
+			return false
+		}
+	}
+
+	return !separated
+}
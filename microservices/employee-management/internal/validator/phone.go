@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// callingCodes are the country calling codes national phone numbers can
+// default to
+var callingCodes = map[string]string{
+	"AR": "54", "AU": "61", "BO": "591", "BR": "55", "CA": "1", "CL": "56",
+	"CO": "57", "CR": "506", "DE": "49", "EC": "593", "ES": "34", "FR": "33",
+	"GB": "44", "IN": "91", "IT": "39", "JP": "81", "MX": "52", "NL": "31",
+	"PA": "507", "PE": "51", "PT": "351", "PY": "595", "US": "1", "UY": "598",
+	"VE": "58",
+}
+
+// e164Regex is an E.164 number: + and up to 15 digits, the first of the
+// country calling code never 0
+var e164Regex = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// phoneSeparators are the characters people group phone digits with
+var phoneSeparators = strings.NewReplacer(" ", "", ".", "", "-", "", "(", "", ")", "")
+
+// defaultCallingCode prefixes national phone numbers, none when only
+// international numbers are accepted
+var defaultCallingCode string
+
+// SetDefaultPhoneCountry sets the country whose calling code national
+// phone numbers get, "" to accept international numbers only
+func SetDefaultPhoneCountry(country string) error {
+	if country == "" {
+		defaultCallingCode = ""
+		return nil
+	}
+
+	code, ok := callingCodes[strings.ToUpper(country)]
+	if !ok {
+		return fmt.Errorf("no calling code known for country %q", country)
+	}
+	defaultCallingCode = code
+	return nil
+}
+
+// NormalizePhone returns phone in E.164, e.g. +573001234567. Numbers
+// starting with + or 00 are international, the others get the default
+// calling code with their trunk 0 dropped. It reports false when phone
+// is not a valid number
+func NormalizePhone(phone string) (string, bool) {
+	p := phoneSeparators.Replace(strings.TrimSpace(phone))
+
+	switch {
+	case strings.HasPrefix(p, "+"):
+	case strings.HasPrefix(p, "00"):
+		p = "+" + p[2:]
+	case defaultCallingCode != "":
+		p = "+" + defaultCallingCode + strings.TrimPrefix(p, "0")
+	default:
+		return "", false
+	}
+
+	return p, e164Regex.MatchString(p)
+}
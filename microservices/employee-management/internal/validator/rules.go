@@ -0,0 +1,250 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"employee-management/internal/api"
+	"employee-management/internal/events"
+	"employee-management/internal/models"
+
+	"github.com/gin-gonic/gin/binding"
+	playground "github.com/go-playground/validator/v10"
+)
+
+// messages are the error messages of the failed rules, by field and tag.
+// A %s is replaced with the parameter of the rule
+var messages = map[string]string{
+	"email.required":                          "Email is required",
+	"email.email_address":                     "Email format is invalid",
+	"employeeNumber.required":                 "Employee number is required",
+	"firstName.notblank":                      "First name is required",
+	"firstName.person_name":                   "First name must have at most 100 letters, with spaces, hyphens or apostrophes between its parts",
+	"lastName.notblank":                       "Last name is required",
+	"lastName.person_name":                    "Last name must have at most 100 letters, with spaces, hyphens or apostrophes between its parts",
+	"status.employee_status":                  "Status must be one of ACTIVE, ON_VACATION, RETIRED, PROBATION",
+	"phone.phone":                             "Phone must be a valid number, international or national to the default country, e.g. +57 300 123 4567",
+	"dateOfBirth.date_of_birth":               "Date of birth must be in the past and not before 1900-01-01",
+	"hireDate.hire_date":                      "Hire date must not be in the future nor before 1950-01-01",
+	"probationEndDate.probation_end_date":     "Probation end date must be in the future",
+	"nationalId.national_id":                  "National ID must have 4 to 50 letters, digits, dots or dashes",
+	"gender.gender":                           "Gender must be one of FEMALE, MALE, NON_BINARY, UNDISCLOSED",
+	"personalEmail.personal_email":            "Personal email format is invalid",
+	"personalEmail.max":                       "Personal email format is invalid",
+	"address.street.notblank":                 "Street is required",
+	"address.street.max":                      "Street must have at most 255 characters",
+	"address.city.notblank":                   "City is required",
+	"address.city.max":                        "City must have at most 100 characters",
+	"address.state.max":                       "State must have at most 100 characters",
+	"address.state.state_required":            "State is required for addresses in %s",
+	"address.country.iso_country":             "Country must be an ISO 3166-1 alpha-2 code (e.g. CO, US)",
+	"address.postalCode.postal_code_required": "Postal code is required for addresses in %s",
+	"address.postalCode.postal_code_country":  "Postal code is not valid for %s",
+	"address.postalCode.postal_code":          "Postal code must have 2 to 10 letters, digits, spaces or dashes",
+	"url.webhook_url":                         "URL must be an absolute http or https url",
+	"secret.min":                              "Secret must be at least 16 characters",
+	"eventTypes.required":                     "At least one event type is required",
+	"eventTypes.min":                          "At least one event type is required",
+	"eventTypes.event_type":                   "Unknown event type",
+	"name.notblank":                           "Name is required",
+	"name.max":                                "Name must have at most 100 characters",
+	"category.max":                            "Category must have at most 100 characters",
+	"proficiency.proficiency":                 "Proficiency must be one of BEGINNER, INTERMEDIATE, ADVANCED, EXPERT",
+	"filter.status.employee_status":           "Status must be one of ACTIVE, ON_VACATION, RETIRED, PROBATION",
+	"filter.country.len":                      "Country must be an ISO 3166-1 alpha-2 code (e.g. CO, US)",
+	"set.department.notblank":                 "Department must not be blank",
+	"set.position.notblank":                   "Position must not be blank",
+}
+
+// configuredMessages are the messages depending on the configuration, by
+// field and tag, taking precedence over messages. They return the message
+// format and the params filled in
+var configuredMessages = map[string]func() (string, []string){
+	"employeeNumber.employee_number": employeeNumberMessage,
+}
+
+// hiddenValues are the fields whose rejected value is left out of the
+// error, being personal or secret
+var hiddenValues = map[string]bool{
+	"nationalId":     true,
+	"address.street": true,
+	"secret":         true,
+}
+
+// indexRegex matches the slice indexes of a field namespace
+var indexRegex = regexp.MustCompile(`\[\d+\]`)
+
+// engine is the validator gin binds requests with, its `binding` tags
+// checked by ShouldBindJSON
+var engine = binding.Validator.Engine().(*playground.Validate)
+
+func init() {
+	engine.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return f.Name
+		}
+		return name
+	})
+
+	rules := map[string]playground.Func{
+		"notblank":           stringRule(func(s string) bool { return strings.TrimSpace(s) != "" }),
+		"email_address":      stringRule(IsValidEmail),
+		"personal_email":     optionalRule(IsValidEmail),
+		"employee_number":    stringRule(isEmployeeNumber),
+		"person_name":        stringRule(isPersonName),
+		"employee_status":    stringRule(func(s string) bool { return models.EmployeeStatus(s).Valid() }),
+		"phone":              optionalRule(isPhone),
+		"national_id":        optionalRule(nationalIDRegex.MatchString),
+		"gender":             optionalRule(func(s string) bool { return models.Gender(s).Valid() }),
+		"iso_country":        stringRule(func(s string) bool { return IsValidCountry(strings.ToUpper(strings.TrimSpace(s))) }),
+		"webhook_url":        stringRule(isWebhookURL),
+		"event_type":         stringRule(func(s string) bool { return s == "*" || events.IsKnown(s) }),
+		"proficiency":        stringRule(func(s string) bool { return models.Proficiency(s).Valid() }),
+		"date_of_birth":      isDateOfBirth,
+		"hire_date":          isHireDate,
+		"probation_end_date": isProbationEndDate,
+	}
+	for tag, fn := range rules {
+		if err := engine.RegisterValidation(tag, fn); err != nil {
+			panic(fmt.Sprintf("validator: failed to register %s: %v", tag, err))
+		}
+	}
+
+	engine.RegisterStructValidation(validateAddress, models.Address{})
+}
+
+// stringRule adapts a check of a string field to a validation func
+func stringRule(fn func(string) bool) playground.Func {
+	return func(fl playground.FieldLevel) bool {
+		return fn(fl.Field().String())
+	}
+}
+
+// optionalRule is a stringRule for the optional profile fields, which
+// pass when blank as NormalizeProfile clears them
+func optionalRule(fn func(string) bool) playground.Func {
+	return func(fl playground.FieldLevel) bool {
+		s := strings.TrimSpace(fl.Field().String())
+		return s == "" || fn(s)
+	}
+}
+
+// isPhone checks phone can be normalized to E.164
+func isPhone(phone string) bool {
+	_, ok := NormalizePhone(phone)
+	return ok
+}
+
+// isDateOfBirth checks a date of birth is in the past and not before 1900
+func isDateOfBirth(fl playground.FieldLevel) bool {
+	d, ok := fl.Field().Interface().(models.Date)
+	return ok && !d.Before(minDateOfBirth) && d.Before(models.Today())
+}
+
+// isHireDate checks a hire date is not in the future nor before 1950
+func isHireDate(fl playground.FieldLevel) bool {
+	d, ok := fl.Field().Interface().(models.Date)
+	return ok && !d.Before(minHireDate) && !d.After(models.Today())
+}
+
+// isProbationEndDate checks a probation end date is in the future, a
+// lapsed one would end the probation right away
+func isProbationEndDate(fl playground.FieldLevel) bool {
+	d, ok := fl.Field().Interface().(models.Date)
+	return ok && d.After(models.Today())
+}
+
+// isWebhookURL checks rawURL is an absolute http or https url
+func isWebhookURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// Struct validates v against its `binding` tags, the way gin does when
+// binding a request, for input that does not come through gin
+func Struct(v interface{}) ValidationResult {
+	result := ValidationResult{IsValid: true, Errors: []api.ErrorDetail{}}
+
+	if errs, ok := FieldErrors(engine.Struct(v)); ok {
+		result.Errors = errs
+		result.IsValid = false
+	}
+
+	return result
+}
+
+// FieldErrors translates the field errors of a failed validation into
+// error details. It reports false when err is not a validation error,
+// e.g. a malformed JSON body
+func FieldErrors(err error) ([]api.ErrorDetail, bool) {
+	var fieldErrs playground.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return nil, false
+	}
+
+	details := make([]api.ErrorDetail, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		details = append(details, fieldError(fe, fieldName(fe.Namespace())))
+	}
+
+	return details, true
+}
+
+// fieldName turns the namespace of a field error, e.g.
+// Employee.address.city, into the field reported, address.city. Slice
+// indexes are dropped, eventTypes[1] is reported as eventTypes
+func fieldName(namespace string) string {
+	if _, field, ok := strings.Cut(namespace, "."); ok {
+		namespace = field
+	}
+	return indexRegex.ReplaceAllString(namespace, "")
+}
+
+// fieldError builds the error detail of a failed rule of field, its code
+// the field and the tag
+func fieldError(fe playground.FieldError, field string) api.ErrorDetail {
+	code := field + "." + fe.Tag()
+	var detail api.ErrorDetail
+	format, ok := messages[code]
+	switch configured, found := configuredMessages[code]; {
+	case found:
+		format, params := configured()
+		detail = api.NewErrorDetail(field, code, format, params...)
+	case !ok:
+		detail = api.NewErrorDetail(field, code, "%s is invalid (%s)", field, fe.Tag())
+	case strings.Contains(format, "%s"):
+		detail = api.NewErrorDetail(field, code, format, fe.Param())
+	default:
+		detail = api.NewErrorDetail(field, code, format)
+	}
+
+	if !hiddenValues[field] && fe.Tag() != "required" {
+		detail.RejectedValue = rejectedValue(fe.Value())
+	}
+	return detail
+}
+
+// rejectedValue formats the value of a failed field, blank for structs
+// and empty values
+func rejectedValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.String {
+		return rv.String()
+	}
+	return ""
+}
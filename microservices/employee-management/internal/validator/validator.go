@@ -1,16 +1,39 @@
 // Package validator define structure validations
+// Request payloads are checked through their `binding` tags by the
+// go-playground validator gin binds with, extended with the rules of this
+// package, and the failures translated into error details
 package validator
 
 import (
 	"net/mail"
 	"regexp"
 	"strconv"
-	"strings"
+	"time"
+	"unicode/utf8"
 
 	"employee-management/internal/api"
+	"employee-management/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	emailRegex      = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	nationalIDRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.-]{2,48}[A-Za-z0-9]$`)
 )
 
-var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+// maxEmailLength is the longest address a mail server accepts, RFC 5321
+const maxEmailLength = 254
+
+// maxIDLength bounds path ids echoed back as rejected values, no int64 or
+// uuid is longer
+const maxIDLength = 36
+
+// minDateOfBirth is the earliest date of birth accepted
+var minDateOfBirth = models.NewDate(1900, time.January, 1)
+
+// minHireDate is the earliest hire date accepted
+var minHireDate = models.NewDate(1950, time.January, 1)
 
 // ValidationResult contains the result of a validation
 type ValidationResult struct {
@@ -18,58 +41,17 @@ type ValidationResult struct {
 	Errors  []api.ErrorDetail
 }
 
-// ValidateEmployee validates employee data
-func ValidateEmployee(email, employeeNumber, firstName, lastName string) ValidationResult {
-	result := ValidationResult{IsValid: true, Errors: []api.ErrorDetail{}}
-
-	// Validate email
-	if email == "" {
-		result.Errors = append(result.Errors, api.ErrorDetail{
-			Field:   "email",
-			Message: "Email is required",
-		})
-		result.IsValid = false
-	} else if !IsValidEmail(email) {
-		result.Errors = append(result.Errors, api.ErrorDetail{
-			Field:         "email",
-			Message:       "Email format is invalid",
-			RejectedValue: email,
-		})
-		result.IsValid = false
-	}
-
-	// Validate employee number
-	if employeeNumber == "" {
-		result.Errors = append(result.Errors, api.ErrorDetail{
-			Field:   "employeeNumber",
-			Message: "Employee number is required",
-		})
-		result.IsValid = false
-	}
-
-	// Validate name
-	if strings.TrimSpace(firstName) == "" {
-		result.Errors = append(result.Errors, api.ErrorDetail{
-			Field:   "firstName",
-			Message: "First name is required",
-		})
-		result.IsValid = false
-	}
-
-	// Validate last name
-	if strings.TrimSpace(lastName) == "" {
-		result.Errors = append(result.Errors, api.ErrorDetail{
-			Field:   "lastName",
-			Message: "Last name is required",
-		})
-		result.IsValid = false
-	}
-
-	return result
+// Merge adds the errors of other to r
+func (r *ValidationResult) Merge(other ValidationResult) {
+	r.Errors = append(r.Errors, other.Errors...)
+	r.IsValid = r.IsValid && other.IsValid
 }
 
 // IsValidEmail validates the format of a email
 func IsValidEmail(email string) bool {
+	if len(email) > maxEmailLength {
+		return false
+	}
 	_, err := mail.ParseAddress(email)
 	return err == nil && emailRegex.MatchString(email)
 }
@@ -78,19 +60,40 @@ func IsValidEmail(email string) bool {
 func ValidateID(idStr string) (int64, []api.ErrorDetail) {
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return 0, []api.ErrorDetail{{
-			Field:         "id",
-			Message:       "ID must be a valid integer",
-			RejectedValue: idStr,
-		}}
+		detail := api.NewErrorDetail("id", "id.integer", "ID must be a valid integer")
+		detail.RejectedValue = rejectedID(idStr)
+		return 0, []api.ErrorDetail{detail}
 	}
 
 	if id <= 0 {
-		return 0, []api.ErrorDetail{{
-			Field:   "id",
-			Message: "ID must be a positive number",
-		}}
+		return 0, []api.ErrorDetail{api.NewErrorDetail("id", "id.positive", "ID must be a positive number")}
 	}
 
 	return id, nil
 }
+
+// rejectedID cuts an invalid id to maxIDLength bytes, without splitting a
+// rune, so a huge path segment is not echoed back in full
+func rejectedID(s string) string {
+	if len(s) <= maxIDLength {
+		return s
+	}
+	cut := maxIDLength
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
+
+// ValidateUUID validates an employee uuid and returns it in its canonical
+// lower-case form
+func ValidateUUID(s string) (string, []api.ErrorDetail) {
+	id, err := uuid.Parse(s)
+	if err != nil || len(s) != 36 {
+		detail := api.NewErrorDetail("id", "id.uuid", "ID must be a valid UUID")
+		detail.RejectedValue = rejectedID(s)
+		return "", []api.ErrorDetail{detail}
+	}
+
+	return id.String(), nil
+}
@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func FuzzIsValidEmail(f *testing.F) {
+	seeds := []string{
+		"jane.doe@example.com",
+		"JANE+hr@mail.example.co",
+		"",
+		"@",
+		"jane",
+		"jane@",
+		"@example.com",
+		"jane@example",
+		"jane doe@example.com",
+		"Jane Doe <jane@example.com>",
+		"jane@@example.com",
+		"jané@exämple.com",
+		"jane@example.com\x00",
+		"\xff\xfe@example.com",
+		"\"quoted\"@example.com",
+		"jane@[127.0.0.1]",
+		strings.Repeat("a", 243) + "@example.com",
+		strings.Repeat("a", 244) + "@example.com",
+		strings.Repeat("(", 1000) + "@example.com",
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, email string) {
+		if !IsValidEmail(email) {
+			return
+		}
+		if len(email) > maxEmailLength {
+			t.Fatalf("IsValidEmail accepted %d bytes, longer than %d", len(email), maxEmailLength)
+		}
+		if _, err := mail.ParseAddress(email); err != nil {
+			t.Fatalf("IsValidEmail accepted %q, which does not parse: %v", email, err)
+		}
+	})
+}
+
+func FuzzValidateID(f *testing.F) {
+	seeds := []string{
+		"1",
+		"42",
+		"9223372036854775807",
+		"9223372036854775808",
+		"-9223372036854775808",
+		"0",
+		"-1",
+		"+7",
+		"007",
+		"",
+		" 1",
+		"1e3",
+		"0x1F",
+		"1_000",
+		"١٢٣",
+		"１２３",
+		"abc",
+		"\xff",
+		"ñandú-" + strings.Repeat("é", 40),
+		strings.Repeat("9", 10000),
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		id, errs := ValidateID(s)
+		if errs == nil {
+			if id <= 0 {
+				t.Fatalf("ValidateID(%q) = %d, want a positive id", s, id)
+			}
+			return
+		}
+		if id != 0 {
+			t.Fatalf("ValidateID(%q) = %d with errors, want 0", s, id)
+		}
+		for _, e := range errs {
+			if len(e.RejectedValue) > maxIDLength {
+				t.Fatalf("ValidateID(%q) echoes %d bytes, longer than %d", s, len(e.RejectedValue), maxIDLength)
+			}
+			if utf8.ValidString(s) && !utf8.ValidString(e.RejectedValue) {
+				t.Fatalf("ValidateID(%q) echoes a split rune %q", s, e.RejectedValue)
+			}
+		}
+	})
+}
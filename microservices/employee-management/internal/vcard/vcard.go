@@ -0,0 +1,63 @@
+// Package vcard writes the contact details of employees as vCards (RFC
+// 6350) for corporate address books
+package vcard
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+
+	"employee-management/internal/models"
+)
+
+// Source walks the employees matching filters, e.g.
+// service.EmployeeService.FindAllStream
+type Source func(ctx context.Context, filters map[string]interface{}, fn func(models.Employee) error) error
+
+// Write writes a vCard of each employee: name, work email, position as
+// title and department as unit of organization. The uuid makes the card
+// of an employee replace its earlier import instead of duplicating it
+func Write(w io.Writer, organization string, employees ...models.Employee) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		bw.WriteString(fold(s))
+	}
+	for _, e := range employees {
+		line("BEGIN:VCARD")
+		line("VERSION:4.0")
+		line("UID:urn:uuid:" + e.UUID)
+		line("KIND:individual")
+		line("FN:" + escape(e.FirstName+" "+e.LastName))
+		line("N:" + escape(e.LastName) + ";" + escape(e.FirstName) + ";;;")
+		line("EMAIL;TYPE=work:" + escape(e.Email))
+		line("TITLE:" + escape(e.Position))
+		line("ORG:" + escape(organization) + ";" + escape(e.Department))
+		line("REV:" + e.UpdatedAt.UTC().Format("20060102T150405Z"))
+		line("END:VCARD")
+	}
+	return bw.Flush()
+}
+
+// escape escapes a text value or component
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// fold terminates a content line with CRLF, folding it into lines of at
+// most 75 octets without splitting a UTF-8 sequence
+func fold(s string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+	return b.String()
+}
@@ -0,0 +1,82 @@
+// Package verification issues and checks the signed tokens of the links
+// employees open to confirm their email address
+package verification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken is returned for tokens that are malformed, forged,
+// expired or issued for another email
+var ErrInvalidToken = errors.New("invalid or expired verification token")
+
+// Verifier issues tokens "<employee id>.<expiry unix>.<signature>", the
+// signature the unpadded base64url HMAC-SHA256 of "<employee id>.<expiry
+// unix>.<email>" with the key. The email is signed but not part of the
+// token, so links do not expose it and stop working once it changes
+type Verifier struct {
+	key  []byte
+	ttl  time.Duration
+	link string
+	now  func() time.Time
+}
+
+// New creates a Verifier signing with key tokens valid for ttl, its links
+// link with the token as query parameter
+func New(key string, ttl time.Duration, link string) *Verifier {
+	return &Verifier{key: []byte(key), ttl: ttl, link: link, now: time.Now}
+}
+
+// Link returns the verification link of the employee id with email and
+// when it expires
+func (v *Verifier) Link(id int64, email string) (string, time.Time) {
+	expires := v.now().Add(v.ttl).Truncate(time.Second)
+	payload := strconv.FormatInt(id, 10) + "." + strconv.FormatInt(expires.Unix(), 10)
+	token := payload + "." + v.sign(payload, email)
+
+	sep := "?"
+	if strings.Contains(v.link, "?") {
+		sep = "&"
+	}
+	return v.link + sep + "token=" + url.QueryEscape(token), expires.UTC()
+}
+
+// ID returns the employee id of a token that has not expired. Check then
+// tells whether it was issued for their email
+func (v *Verifier) ID(token string) (int64, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, ErrInvalidToken
+	}
+	id, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || v.now().Unix() > expires {
+		return 0, ErrInvalidToken
+	}
+	return id, nil
+}
+
+// Check reports whether token was issued for email
+func (v *Verifier) Check(token, email string) error {
+	i := strings.LastIndexByte(token, '.')
+	if i < 0 || !hmac.Equal([]byte(token[i+1:]), []byte(v.sign(token[:i], email))) {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+func (v *Verifier) sign(payload, email string) string {
+	mac := hmac.New(sha256.New, v.key)
+	mac.Write([]byte(payload + "." + email))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
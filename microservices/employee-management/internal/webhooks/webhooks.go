@@ -0,0 +1,160 @@
+// Package webhooks delivers employee events to external HTTP endpoints
+// signed with HMAC-SHA256, as an alternative to consuming from a broker
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"employee-management/internal/backoff"
+	"employee-management/internal/events"
+	"employee-management/internal/jobs"
+	"employee-management/internal/models"
+	"employee-management/internal/repository"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+)
+
+// Publisher fans events out to the matching webhook subscriptions before
+// handing them to the next publisher, so webhooks get every event the
+// outbox dispatches
+type Publisher struct {
+	events.Publisher
+	repo repository.WebhookRepository
+}
+
+// NewPublisher wraps next with webhook fan-out
+func NewPublisher(next events.Publisher, repo repository.WebhookRepository) *Publisher {
+	return &Publisher{Publisher: next, repo: repo}
+}
+
+// Publish enqueues the webhook deliveries then publishes to the broker
+func (p *Publisher) Publish(ctx context.Context, e events.Event) error {
+	if err := p.repo.Enqueue(ctx, e); err != nil {
+		return err
+	}
+	return p.Publisher.Publish(ctx, e)
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>" with secret
+// Receivers recompute it to authenticate the delivery and reject old
+// timestamps to prevent replays
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Deliverer posts pending deliveries to their subscription urls, retrying
+// failures with exponential backoff up to MaxAttempts
+type Deliverer struct {
+	repo         repository.WebhookRepository
+	client       *http.Client
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+	maxBackoff   time.Duration
+}
+
+// NewDeliverer creates a new Deliverer
+func NewDeliverer(repo repository.WebhookRepository, timeout, pollInterval time.Duration, batchSize, maxAttempts int, maxBackoff time.Duration) *Deliverer {
+	return &Deliverer{
+		repo:         repo,
+		client:       &http.Client{Timeout: timeout},
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+		maxAttempts:  maxAttempts,
+		maxBackoff:   maxBackoff,
+	}
+}
+
+// Run delivers until ctx is done, a batch at a time as a task on pool,
+// so a batch being delivered at shutdown is finished and recorded
+func (d *Deliverer) Run(ctx context.Context, pool *jobs.Pool) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		// Retries are the deliveries' own, the task runs once
+		dispatched := make(chan int, 1)
+		err := pool.Submit(jobs.Task{Name: "webhook-dispatch", Attempts: 1, Run: func(ctx context.Context) error {
+			n, err := d.repo.DispatchDeliveries(ctx, d.batchSize, d.maxAttempts, d.deliver, d.backoff)
+			dispatched <- n
+			return err
+		}})
+		if err != nil {
+			log.Printf("webhook dispatch not queued: %v", err)
+			timer.Reset(d.pollInterval)
+			continue
+		}
+
+		var n int
+		select {
+		case <-ctx.Done():
+			return
+		case n = <-dispatched:
+		}
+
+		if n == d.batchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(d.pollInterval)
+		}
+	}
+}
+
+// deliver posts one delivery. Any non 2xx response is a failure
+func (d *Deliverer) deliver(ctx context.Context, w models.WebhookSubscription, delivery models.WebhookDelivery) (int, error) {
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "employee-management-webhooks")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, "sha256="+Sign(w.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint answered %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff returns the wait before the given attempt, 5s doubled per attempt
+func (d *Deliverer) backoff(attempts int) time.Duration {
+	return backoff.Exponential(5*time.Second, d.maxBackoff, attempts)
+}
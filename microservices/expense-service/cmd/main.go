@@ -0,0 +1,90 @@
+package main
+
+//	@title			Expense Service API
+//	@version		1.0
+//	@description	Employee expense claims with receipts, manager approval and payroll exports
+//	@termsOfService	http://swagger.io/terms/
+
+//	@contact.name	API Support
+//	@contact.email	josed.amayar@uqvirtual.edu.co
+
+//	@host		localhost:8087
+//	@BasePath	/expense-service/api/v1
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"expense-service/internal/config"
+	"expense-service/internal/db"
+	"expense-service/internal/handlers"
+	"expense-service/internal/repository"
+	"expense-service/internal/service"
+
+	_ "expense-service/docs" // Swagger docs
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+func main() {
+	cfg := config.Load()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbPool := db.NewPostgresPool(cfg)
+	defer dbPool.Close()
+
+	if cfg.MigrateOnStartup {
+		if err := db.Migrate(ctx, dbPool); err != nil {
+			log.Fatalf("database migration failed: %v", err)
+		}
+	}
+
+	// Employees and their managers are checked in employee-management
+	employeeClient := employees.NewClient(cfg.EmployeeServiceURL, cfg.EmployeeServiceTimeout)
+	repo := repository.NewExpenseRepository(dbPool)
+	expenseService := service.NewExpenseService(repo, employeeClient)
+
+	handler := handlers.NewExpenseHandler(expenseService, cfg.ReceiptMaxSize)
+	healthHandler := httpkit.NewHealthHandler("expense-service", dbPool)
+
+	router := httpkit.NewRouter()
+
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	v1 := router.Group("/expense-service/api/v1")
+	{
+		v1.GET("/health", healthHandler.HealthCheck)
+
+		v1.POST("/expenses", handler.SubmitExpense)
+		v1.GET("/expenses", handler.GetAllExpenses)
+		v1.GET("/expenses/:id", handler.GetExpenseByID)
+		v1.POST("/expenses/:id/receipts", handler.UploadReceipt)
+		v1.GET("/expenses/:id/receipts/:receiptId", handler.DownloadReceipt)
+		v1.POST("/expenses/:id/approve", handler.ApproveExpense)
+		v1.POST("/expenses/:id/reject", handler.RejectExpense)
+
+		v1.POST("/payroll-exports", handler.CreateExport)
+		v1.GET("/payroll-exports", handler.GetAllExports)
+		v1.GET("/payroll-exports/:id", handler.GetExportByID)
+	}
+
+	srv := &http.Server{Addr: ":" + cfg.ServerPort, Handler: router}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	log.Printf("Expense service running on :%s", cfg.ServerPort)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
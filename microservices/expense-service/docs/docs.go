@@ -0,0 +1,904 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "email": "josed.amayar@uqvirtual.edu.co"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/expenses": {
+            "get": {
+                "description": "Retrieves expenses newest first, optionally filtered by employee, manager and status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expenses"
+                ],
+                "summary": "List expenses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Employee id",
+                        "name": "employeeId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Approver id",
+                        "name": "managerId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "SUBMITTED",
+                            "APPROVED",
+                            "REJECTED",
+                            "EXPORTED"
+                        ],
+                        "type": "string",
+                        "description": "Expense status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Expenses",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httpkit.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Expense"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Records an expense of an employee for their manager to approve. Receipts are uploaded afterwards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expenses"
+                ],
+                "summary": "Submit an expense",
+                "parameters": [
+                    {
+                        "description": "Expense data",
+                        "name": "expense",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExpenseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Expense submitted",
+                        "schema": {
+                            "$ref": "#/definitions/models.Expense"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee or manager not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Employee or manager is retired",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Employee service unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/{id}": {
+            "get": {
+                "description": "Retrieves an expense with the list of its receipts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Expenses"
+                ],
+                "summary": "Get an expense",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Expense",
+                        "schema": {
+                            "$ref": "#/definitions/models.Expense"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid expense ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Expense not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/{id}/approve": {
+            "post": {
+                "description": "The manager of a submitted expense with at least one receipt approves it for the next payroll export",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approvals"
+                ],
+                "summary": "Approve an expense",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Approving manager and optional note",
+                        "name": "decision",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DecisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Expense approved",
+                        "schema": {
+                            "$ref": "#/definitions/models.Expense"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid expense ID or JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Manager is not the approver of the expense",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Expense not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Expense is no longer submitted or has no receipt",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/{id}/receipts": {
+            "post": {
+                "description": "Attaches a PDF, JPEG or PNG receipt to a submitted expense, sent as the multipart field file",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Receipts"
+                ],
+                "summary": "Upload a receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Receipt file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Receipt stored",
+                        "schema": {
+                            "$ref": "#/definitions/models.Receipt"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid expense ID or missing file",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Expense not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Expense is no longer submitted",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Receipt too large",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported receipt type",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/{id}/receipts/{receiptId}": {
+            "get": {
+                "description": "Returns the receipt file as uploaded",
+                "produces": [
+                    "application/pdf",
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "Receipts"
+                ],
+                "summary": "Download a receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Receipt ID",
+                        "name": "receiptId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Receipt file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid expense or receipt ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Receipt not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/{id}/reject": {
+            "post": {
+                "description": "The manager of a submitted expense refuses it, the note giving the reason is required",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approvals"
+                ],
+                "summary": "Reject an expense",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejecting manager and reason",
+                        "name": "decision",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DecisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Expense rejected",
+                        "schema": {
+                            "$ref": "#/definitions/models.Expense"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid expense ID, JSON format or missing note",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Manager is not the approver of the expense",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Expense not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Expense is no longer submitted",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll-exports": {
+            "get": {
+                "description": "Retrieves payroll exports newest first, without their lines",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "List payroll exports",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exports",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httpkit.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.PayrollExport"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Moves every approved expense into a new payroll export, with the amount to reimburse per employee and currency",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Export to payroll",
+                "responses": {
+                    "201": {
+                        "description": "Export created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PayrollExport"
+                        }
+                    },
+                    "409": {
+                        "description": "No approved expenses to export",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll-exports/{id}": {
+            "get": {
+                "description": "Retrieves an export with the amount to reimburse per employee and currency, as JSON or as CSV for payroll with Accept: text/csv",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Get a payroll export",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Export ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export",
+                        "schema": {
+                            "$ref": "#/definitions/models.PayrollExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid export ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Export not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.DecisionRequest": {
+            "type": "object",
+            "properties": {
+                "managerId": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Over the meals limit"
+                }
+            }
+        },
+        "handlers.ExpenseRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "125.40"
+                },
+                "category": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Category"
+                        }
+                    ],
+                    "example": "TRAVEL"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Taxi to the client office"
+                },
+                "employeeId": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "incurredOn": {
+                    "type": "string",
+                    "example": "2026-03-14"
+                },
+                "managerId": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "httpkit.ErrorResponse": {
+            "description": "Standard error response structure",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpkit.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "pagination": {
+                    "$ref": "#/definitions/httpkit.PaginationMeta"
+                }
+            }
+        },
+        "httpkit.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                },
+                "total_records": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Category": {
+            "type": "string",
+            "enum": [
+                "TRAVEL",
+                "LODGING",
+                "MEALS",
+                "EQUIPMENT",
+                "OTHER"
+            ],
+            "x-enum-varnames": [
+                "CategoryTravel",
+                "CategoryLodging",
+                "CategoryMeals",
+                "CategoryEquipment",
+                "CategoryOther"
+            ]
+        },
+        "models.Expense": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount is a decimal with up to two digits, kept as a string so it is\nnever rounded",
+                    "type": "string",
+                    "example": "125.40"
+                },
+                "category": {
+                    "$ref": "#/definitions/models.Category"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "decidedAt": {
+                    "type": "string"
+                },
+                "decisionNote": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "employeeId": {
+                    "type": "integer"
+                },
+                "exportId": {
+                    "description": "ExportID is the payroll export that paid the expense",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "incurredOn": {
+                    "type": "string",
+                    "example": "2026-03-14"
+                },
+                "managerId": {
+                    "type": "integer"
+                },
+                "receipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Receipt"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/models.ExpenseStatus"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExpenseStatus": {
+            "type": "string",
+            "enum": [
+                "SUBMITTED",
+                "APPROVED",
+                "REJECTED",
+                "EXPORTED"
+            ],
+            "x-enum-varnames": [
+                "StatusSubmitted",
+                "StatusApproved",
+                "StatusRejected",
+                "StatusExported"
+            ]
+        },
+        "models.PayrollExport": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "expenseCount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PayrollLine"
+                    }
+                }
+            }
+        },
+        "models.PayrollLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "310.90"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "employeeId": {
+                    "type": "integer"
+                },
+                "expenseCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Receipt": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "expenseId": {
+                    "type": "integer"
+                },
+                "fileName": {
+                    "type": "string",
+                    "example": "taxi.pdf"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "uploadedAt": {
+                    "type": "string"
+                }
+            }
+        }
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:8087",
+	BasePath:         "/expense-service/api/v1",
+	Schemes:          []string{},
+	Title:            "Expense Service API",
+	Description:      "Employee expense claims with receipts, manager approval and payroll exports",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
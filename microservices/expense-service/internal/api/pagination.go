@@ -0,0 +1,13 @@
+// Package api holds the query parameters of the list endpoints
+package api
+
+import "github.com/Josedzzz/microservices-fp/pkg/httpkit"
+
+// PaginationQuery represents the query parameters of the expense and
+// payroll export lists
+type PaginationQuery struct {
+	httpkit.PageQuery
+	EmployeeID int64  `form:"employeeId" binding:"omitempty,min=1"`
+	ManagerID  int64  `form:"managerId" binding:"omitempty,min=1"`
+	Status     string `form:"status" binding:"omitempty,oneof=SUBMITTED APPROVED REJECTED EXPORTED"`
+}
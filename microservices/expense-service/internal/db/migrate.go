@@ -0,0 +1,35 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit/postgres"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrator applies the migrations to the expenses schema, lock 7341007 is
+// held while migrating so several instances starting at once do not race
+var migrator = postgres.Migrator{
+	Schema: "expenses",
+	LockID: 7341007,
+	Files:  must(fs.Sub(migrationFiles, "migrations")),
+}
+
+// Migrate applies every pending migration in version order
+func Migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	return migrator.Migrate(ctx, pool)
+}
+
+// must panics on err, for values known at build time
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
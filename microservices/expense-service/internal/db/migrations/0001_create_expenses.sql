@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS expenses.payroll_exports (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	expense_count INTEGER NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS expenses.expenses (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	employee_id BIGINT NOT NULL,
+	manager_id BIGINT NOT NULL,
+	category VARCHAR(20) NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	amount NUMERIC(12, 2) NOT NULL,
+	currency CHAR(3) NOT NULL,
+	incurred_on DATE NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'SUBMITTED',
+	decided_at TIMESTAMPTZ,
+	decision_note TEXT NOT NULL DEFAULT '',
+	export_id BIGINT REFERENCES expenses.payroll_exports (id),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CHECK (amount > 0),
+	CHECK (manager_id <> employee_id)
+);
+
+CREATE INDEX IF NOT EXISTS expenses_employee_idx ON expenses.expenses (employee_id);
+CREATE INDEX IF NOT EXISTS expenses_manager_idx ON expenses.expenses (manager_id, status);
+CREATE INDEX IF NOT EXISTS expenses_export_idx ON expenses.expenses (export_id);
+
+CREATE TABLE IF NOT EXISTS expenses.receipts (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	expense_id BIGINT NOT NULL REFERENCES expenses.expenses (id) ON DELETE CASCADE,
+	file_name VARCHAR(255) NOT NULL,
+	content_type VARCHAR(100) NOT NULL,
+	size BIGINT NOT NULL,
+	content BYTEA NOT NULL,
+	uploaded_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS receipts_expense_idx ON expenses.receipts (expense_id);
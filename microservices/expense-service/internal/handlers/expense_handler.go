@@ -0,0 +1,408 @@
+// Package handlers exposes the expense service over HTTP
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"expense-service/internal/api"
+	"expense-service/internal/models"
+	"expense-service/internal/repository"
+	"expense-service/internal/service"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// amountPattern matches a positive decimal with up to two digits
+var amountPattern = regexp.MustCompile(`^\d{1,10}(\.\d{1,2})?$`)
+
+// currencyPattern matches an ISO 4217 code
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ExpenseHandler handles HTTP requests for expenses, receipts, approvals
+// and payroll exports
+type ExpenseHandler struct {
+	service        *service.ExpenseService
+	receiptMaxSize int64
+}
+
+// NewExpenseHandler creates a new ExpenseHandler instance
+func NewExpenseHandler(s *service.ExpenseService, receiptMaxSize int64) *ExpenseHandler {
+	return &ExpenseHandler{service: s, receiptMaxSize: receiptMaxSize}
+}
+
+// ExpenseRequest is the payload to submit an expense
+type ExpenseRequest struct {
+	EmployeeID  int64           `json:"employeeId" example:"7"`
+	ManagerID   int64           `json:"managerId" example:"2"`
+	Category    models.Category `json:"category" example:"TRAVEL"`
+	Amount      string          `json:"amount" example:"125.40"`
+	Currency    string          `json:"currency" example:"USD"`
+	IncurredOn  string          `json:"incurredOn" example:"2026-03-14"`
+	Description string          `json:"description" example:"Taxi to the client office"`
+}
+
+// DecisionRequest is the payload to approve or reject an expense
+type DecisionRequest struct {
+	ManagerID int64  `json:"managerId" example:"2"`
+	Note      string `json:"note" example:"Over the meals limit"`
+}
+
+// SubmitExpense godoc
+//
+//	@Summary		Submit an expense
+//	@Description	Records an expense of an employee for their manager to approve. Receipts are uploaded afterwards
+//	@Tags			Expenses
+//	@Accept			json
+//	@Produce		json
+//	@Param			expense	body		ExpenseRequest		true	"Expense data"
+//	@Success		201		{object}	models.Expense		"Expense submitted"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid JSON format or validation failed"
+//	@Failure		404		{object}	httpkit.ErrorResponse	"Employee or manager not found"
+//	@Failure		409		{object}	httpkit.ErrorResponse	"Employee or manager is retired"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Failure		503		{object}	httpkit.ErrorResponse	"Employee service unavailable"
+//	@Router			/expenses [post]
+func (h *ExpenseHandler) SubmitExpense(c *gin.Context) {
+	var req ExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	expense := models.Expense{
+		EmployeeID:  req.EmployeeID,
+		ManagerID:   req.ManagerID,
+		Category:    req.Category,
+		Amount:      strings.TrimSpace(req.Amount),
+		Currency:    strings.ToUpper(strings.TrimSpace(req.Currency)),
+		IncurredOn:  req.IncurredOn,
+		Description: strings.TrimSpace(req.Description),
+	}
+	incurredOn, dateErr := time.Parse(time.DateOnly, expense.IncurredOn)
+	switch {
+	case expense.EmployeeID < 1 || expense.ManagerID < 1:
+		httpkit.BadRequest(c, "Employee ID and manager ID are required")
+		return
+	case expense.EmployeeID == expense.ManagerID:
+		httpkit.BadRequest(c, "Employees cannot approve their own expenses")
+		return
+	case !expense.Category.Valid():
+		httpkit.BadRequest(c, "Category must be TRAVEL, LODGING, MEALS, EQUIPMENT or OTHER")
+		return
+	case !amountPattern.MatchString(expense.Amount) || strings.Trim(expense.Amount, "0.") == "":
+		httpkit.BadRequest(c, "Amount must be a positive decimal with up to two digits, e.g. 125.40")
+		return
+	case !currencyPattern.MatchString(expense.Currency):
+		httpkit.BadRequest(c, "Currency must be a three letter ISO code")
+		return
+	case dateErr != nil:
+		httpkit.BadRequest(c, "Incurred on must be a YYYY-MM-DD date")
+		return
+	case incurredOn.After(time.Now()):
+		httpkit.BadRequest(c, "Incurred on cannot be in the future")
+		return
+	}
+
+	if err := h.service.Submit(c.Request.Context(), &expense); err != nil {
+		switch {
+		case errors.Is(err, employees.ErrNotFound):
+			httpkit.NotFound(c, "Employee not found")
+		case errors.Is(err, service.ErrManagerNotFound):
+			httpkit.NotFound(c, "Manager not found")
+		case errors.Is(err, service.ErrEmployeeRetired):
+			httpkit.Error(c, http.StatusConflict, err.Error())
+		case errors.Is(err, employees.ErrUnavailable):
+			httpkit.Error(c, http.StatusServiceUnavailable, "Employee service unavailable")
+		default:
+			httpkit.InternalServerError(c, "Failed to submit expense")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, expense)
+}
+
+// GetAllExpenses godoc
+//
+//	@Summary		List expenses
+//	@Description	Retrieves expenses newest first, optionally filtered by employee, manager and status
+//	@Tags			Expenses
+//	@Produce		json
+//	@Param			page		query		int					false	"Page number"	default(1)
+//	@Param			page_size	query		int					false	"Page size"		default(20)
+//	@Param			employeeId	query		int					false	"Employee id"
+//	@Param			managerId	query		int					false	"Approver id"
+//	@Param			status		query		string				false	"Expense status"	Enums(SUBMITTED, APPROVED, REJECTED, EXPORTED)
+//	@Success		200			{object}	httpkit.PaginatedResponse{data=[]models.Expense}	"Expenses"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid query parameters"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/expenses [get]
+func (h *ExpenseHandler) GetAllExpenses(c *gin.Context) {
+	var query api.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpkit.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	query.Normalize()
+
+	filter := repository.ExpenseFilter{
+		EmployeeID: query.EmployeeID,
+		ManagerID:  query.ManagerID,
+		Status:     models.ExpenseStatus(query.Status),
+	}
+
+	expenses, total, err := h.service.FindExpenses(c.Request.Context(), filter, query.Page, query.PageSize)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve expenses")
+		return
+	}
+
+	c.JSON(http.StatusOK, httpkit.Paginate(expenses, query.PageQuery, total))
+}
+
+// GetExpenseByID godoc
+//
+//	@Summary		Get an expense
+//	@Description	Retrieves an expense with the list of its receipts
+//	@Tags			Expenses
+//	@Produce		json
+//	@Param			id	path		int					true	"Expense ID"
+//	@Success		200	{object}	models.Expense		"Expense"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid expense ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Expense not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/expenses/{id} [get]
+func (h *ExpenseHandler) GetExpenseByID(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid expense ID")
+	if !ok {
+		return
+	}
+
+	expense, err := h.service.FindExpense(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrExpenseNotFound):
+			httpkit.NotFound(c, "Expense not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve expense")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, expense)
+}
+
+// UploadReceipt godoc
+//
+//	@Summary		Upload a receipt
+//	@Description	Attaches a PDF, JPEG or PNG receipt to a submitted expense, sent as the multipart field file
+//	@Tags			Receipts
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		int					true	"Expense ID"
+//	@Param			file	formData	file				true	"Receipt file"
+//	@Success		201		{object}	models.Receipt		"Receipt stored"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid expense ID or missing file"
+//	@Failure		404		{object}	httpkit.ErrorResponse	"Expense not found"
+//	@Failure		409		{object}	httpkit.ErrorResponse	"Expense is no longer submitted"
+//	@Failure		413		{object}	httpkit.ErrorResponse	"Receipt too large"
+//	@Failure		415		{object}	httpkit.ErrorResponse	"Unsupported receipt type"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/expenses/{id}/receipts [post]
+func (h *ExpenseHandler) UploadReceipt(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid expense ID")
+	if !ok {
+		return
+	}
+
+	// Leave room for the multipart framing around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.receiptMaxSize+1<<20)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			httpkit.Error(c, http.StatusRequestEntityTooLarge, "Receipt too large")
+			return
+		}
+		httpkit.BadRequest(c, "Receipt file is required")
+		return
+	}
+	if header.Size > h.receiptMaxSize {
+		httpkit.Error(c, http.StatusRequestEntityTooLarge, "Receipt too large")
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to read receipt")
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to read receipt")
+		return
+	}
+
+	receipt, err := h.service.AddReceipt(c.Request.Context(), id, filepath.Base(header.Filename), content)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnsupportedReceipt):
+			httpkit.Error(c, http.StatusUnsupportedMediaType, err.Error())
+		case errors.Is(err, repository.ErrExpenseNotFound):
+			httpkit.NotFound(c, "Expense not found")
+		case errors.Is(err, repository.ErrNotSubmitted):
+			httpkit.Error(c, http.StatusConflict, err.Error())
+		default:
+			httpkit.InternalServerError(c, "Failed to store receipt")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, receipt)
+}
+
+// DownloadReceipt godoc
+//
+//	@Summary		Download a receipt
+//	@Description	Returns the receipt file as uploaded
+//	@Tags			Receipts
+//	@Produce		application/pdf,image/jpeg,image/png
+//	@Param			id			path		int					true	"Expense ID"
+//	@Param			receiptId	path		int					true	"Receipt ID"
+//	@Success		200			{file}		file				"Receipt file"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid expense or receipt ID"
+//	@Failure		404			{object}	httpkit.ErrorResponse	"Receipt not found"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/expenses/{id}/receipts/{receiptId} [get]
+func (h *ExpenseHandler) DownloadReceipt(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid expense ID")
+	if !ok {
+		return
+	}
+	receiptID, ok := pathID(c, "receiptId", "Invalid receipt ID")
+	if !ok {
+		return
+	}
+
+	receipt, content, err := h.service.FindReceipt(c.Request.Context(), id, receiptID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrReceiptNotFound):
+			httpkit.NotFound(c, "Receipt not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve receipt")
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+strings.ReplaceAll(receipt.FileName, `"`, "")+`"`)
+	c.Data(http.StatusOK, receipt.ContentType, content)
+}
+
+// ApproveExpense godoc
+//
+//	@Summary		Approve an expense
+//	@Description	The manager of a submitted expense with at least one receipt approves it for the next payroll export
+//	@Tags			Approvals
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Expense ID"
+//	@Param			decision	body		DecisionRequest		true	"Approving manager and optional note"
+//	@Success		200			{object}	models.Expense		"Expense approved"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid expense ID or JSON format"
+//	@Failure		403			{object}	httpkit.ErrorResponse	"Manager is not the approver of the expense"
+//	@Failure		404			{object}	httpkit.ErrorResponse	"Expense not found"
+//	@Failure		409			{object}	httpkit.ErrorResponse	"Expense is no longer submitted or has no receipt"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/expenses/{id}/approve [post]
+func (h *ExpenseHandler) ApproveExpense(c *gin.Context) {
+	h.decide(c, models.StatusApproved)
+}
+
+// RejectExpense godoc
+//
+//	@Summary		Reject an expense
+//	@Description	The manager of a submitted expense refuses it, the note giving the reason is required
+//	@Tags			Approvals
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Expense ID"
+//	@Param			decision	body		DecisionRequest		true	"Rejecting manager and reason"
+//	@Success		200			{object}	models.Expense		"Expense rejected"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid expense ID, JSON format or missing note"
+//	@Failure		403			{object}	httpkit.ErrorResponse	"Manager is not the approver of the expense"
+//	@Failure		404			{object}	httpkit.ErrorResponse	"Expense not found"
+//	@Failure		409			{object}	httpkit.ErrorResponse	"Expense is no longer submitted"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/expenses/{id}/reject [post]
+func (h *ExpenseHandler) RejectExpense(c *gin.Context) {
+	h.decide(c, models.StatusRejected)
+}
+
+// decide approves or rejects the expense in the path
+func (h *ExpenseHandler) decide(c *gin.Context, status models.ExpenseStatus) {
+	id, ok := pathID(c, "id", "Invalid expense ID")
+	if !ok {
+		return
+	}
+
+	var req DecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+	req.Note = strings.TrimSpace(req.Note)
+	if req.ManagerID < 1 {
+		httpkit.BadRequest(c, "Manager ID is required")
+		return
+	}
+	if status == models.StatusRejected && req.Note == "" {
+		httpkit.BadRequest(c, "Note is required to reject an expense")
+		return
+	}
+
+	var expense *models.Expense
+	var err error
+	if status == models.StatusApproved {
+		expense, err = h.service.Approve(c.Request.Context(), id, req.ManagerID, req.Note)
+	} else {
+		expense, err = h.service.Reject(c.Request.Context(), id, req.ManagerID, req.Note)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrExpenseNotFound):
+			httpkit.NotFound(c, "Expense not found")
+		case errors.Is(err, repository.ErrNotApprover):
+			httpkit.Error(c, http.StatusForbidden, "Manager is not the approver of the expense")
+		case errors.Is(err, repository.ErrNotSubmitted), errors.Is(err, repository.ErrReceiptRequired):
+			httpkit.Error(c, http.StatusConflict, err.Error())
+		default:
+			httpkit.InternalServerError(c, "Failed to record decision")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, expense)
+}
+
+// pathID parses a positive id path parameter, answering 400 with message
+// when it is invalid
+func pathID(c *gin.Context, name, message string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id < 1 {
+		httpkit.BadRequest(c, message)
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"expense-service/internal/api"
+	"expense-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateExport godoc
+//
+//	@Summary		Export to payroll
+//	@Description	Moves every approved expense into a new payroll export, with the amount to reimburse per employee and currency
+//	@Tags			Payroll
+//	@Produce		json
+//	@Success		201	{object}	models.PayrollExport	"Export created"
+//	@Failure		409	{object}	httpkit.ErrorResponse		"No approved expenses to export"
+//	@Failure		500	{object}	httpkit.ErrorResponse		"Internal server error"
+//	@Router			/payroll-exports [post]
+func (h *ExpenseHandler) CreateExport(c *gin.Context) {
+	export, err := h.service.Export(c.Request.Context())
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNothingToExport):
+			httpkit.Error(c, http.StatusConflict, "No approved expenses to export")
+		default:
+			httpkit.InternalServerError(c, "Failed to export expenses")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, export)
+}
+
+// GetAllExports godoc
+//
+//	@Summary		List payroll exports
+//	@Description	Retrieves payroll exports newest first, without their lines
+//	@Tags			Payroll
+//	@Produce		json
+//	@Param			page		query		int					false	"Page number"	default(1)
+//	@Param			page_size	query		int					false	"Page size"		default(20)
+//	@Success		200			{object}	httpkit.PaginatedResponse{data=[]models.PayrollExport}	"Exports"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid query parameters"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/payroll-exports [get]
+func (h *ExpenseHandler) GetAllExports(c *gin.Context) {
+	var query api.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpkit.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	query.Normalize()
+
+	exports, total, err := h.service.FindExports(c.Request.Context(), query.Page, query.PageSize)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve payroll exports")
+		return
+	}
+
+	c.JSON(http.StatusOK, httpkit.Paginate(exports, query.PageQuery, total))
+}
+
+// GetExportByID godoc
+//
+//	@Summary		Get a payroll export
+//	@Description	Retrieves an export with the amount to reimburse per employee and currency, as JSON or as CSV for payroll with Accept: text/csv
+//	@Tags			Payroll
+//	@Produce		json,text/csv
+//	@Param			id	path		int						true	"Export ID"
+//	@Success		200	{object}	models.PayrollExport	"Export"
+//	@Failure		400	{object}	httpkit.ErrorResponse		"Invalid export ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse		"Export not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse		"Internal server error"
+//	@Router			/payroll-exports/{id} [get]
+func (h *ExpenseHandler) GetExportByID(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid export ID")
+	if !ok {
+		return
+	}
+
+	export, err := h.service.FindExport(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrExportNotFound):
+			httpkit.NotFound(c, "Export not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve payroll export")
+		}
+		return
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, "text/csv") != "text/csv" {
+		c.JSON(http.StatusOK, export)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", `attachment; filename="payroll-export-`+strconv.FormatInt(export.ID, 10)+`.csv"`)
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{"employeeId", "currency", "amount", "expenseCount"})
+	for _, line := range export.Lines {
+		_ = w.Write([]string{
+			strconv.FormatInt(line.EmployeeID, 10),
+			line.Currency,
+			line.Amount,
+			strconv.Itoa(line.ExpenseCount),
+		})
+	}
+	w.Flush()
+}
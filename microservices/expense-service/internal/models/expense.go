@@ -0,0 +1,91 @@
+// Package models define the core data structures of the expense service
+package models
+
+import "time"
+
+// Category is what an expense was spent on
+type Category string
+
+const (
+	CategoryTravel    Category = "TRAVEL"
+	CategoryLodging   Category = "LODGING"
+	CategoryMeals     Category = "MEALS"
+	CategoryEquipment Category = "EQUIPMENT"
+	CategoryOther     Category = "OTHER"
+)
+
+// Valid reports whether c is a known category
+func (c Category) Valid() bool {
+	switch c {
+	case CategoryTravel, CategoryLodging, CategoryMeals, CategoryEquipment, CategoryOther:
+		return true
+	}
+	return false
+}
+
+// ExpenseStatus is where an expense is in the approval flow
+type ExpenseStatus string
+
+const (
+	StatusSubmitted ExpenseStatus = "SUBMITTED"
+	StatusApproved  ExpenseStatus = "APPROVED"
+	StatusRejected  ExpenseStatus = "REJECTED"
+	StatusExported  ExpenseStatus = "EXPORTED"
+)
+
+// Valid reports whether s is a known status
+func (s ExpenseStatus) Valid() bool {
+	switch s {
+	case StatusSubmitted, StatusApproved, StatusRejected, StatusExported:
+		return true
+	}
+	return false
+}
+
+// Expense is an amount an employee asks to be reimbursed
+type Expense struct {
+	ID         int64    `json:"id"`
+	EmployeeID int64    `json:"employeeId"`
+	ManagerID  int64    `json:"managerId"`
+	Category   Category `json:"category"`
+	// Amount is a decimal with up to two digits, kept as a string so it is
+	// never rounded
+	Amount       string        `json:"amount" example:"125.40"`
+	Currency     string        `json:"currency" example:"USD"`
+	IncurredOn   string        `json:"incurredOn" example:"2026-03-14"`
+	Description  string        `json:"description"`
+	Status       ExpenseStatus `json:"status"`
+	DecidedAt    *time.Time    `json:"decidedAt,omitempty"`
+	DecisionNote string        `json:"decisionNote,omitempty"`
+	// ExportID is the payroll export that paid the expense
+	ExportID  *int64    `json:"exportId,omitempty"`
+	Receipts  []Receipt `json:"receipts,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Receipt is a proof of an expense, the file is downloaded separately
+type Receipt struct {
+	ID          int64     `json:"id"`
+	ExpenseID   int64     `json:"expenseId"`
+	FileName    string    `json:"fileName" example:"taxi.pdf"`
+	ContentType string    `json:"contentType" example:"application/pdf"`
+	Size        int64     `json:"size"`
+	UploadedAt  time.Time `json:"uploadedAt"`
+}
+
+// PayrollExport is a batch of approved expenses handed to payroll
+type PayrollExport struct {
+	ID           int64         `json:"id"`
+	ExpenseCount int           `json:"expenseCount"`
+	CreatedAt    time.Time     `json:"createdAt"`
+	Lines        []PayrollLine `json:"lines,omitempty"`
+}
+
+// PayrollLine is the amount to reimburse an employee in one currency
+type PayrollLine struct {
+	EmployeeID   int64  `json:"employeeId"`
+	Currency     string `json:"currency" example:"USD"`
+	Amount       string `json:"amount" example:"310.90"`
+	ExpenseCount int    `json:"expenseCount"`
+}
@@ -0,0 +1,370 @@
+// Package repository implements the data access layer of the expense service
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"expense-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Errors returned by ExpenseRepository
+var (
+	ErrExpenseNotFound = errors.New("expense not found")
+	ErrReceiptNotFound = errors.New("receipt not found")
+	ErrExportNotFound  = errors.New("payroll export not found")
+	ErrNotSubmitted    = errors.New("expense is no longer submitted")
+	ErrNotApprover     = errors.New("manager is not the approver of the expense")
+	ErrReceiptRequired = errors.New("expense has no receipt")
+	ErrNothingToExport = errors.New("no approved expenses to export")
+)
+
+// ExpenseFilter narrows FindExpenses, zero values match everything
+type ExpenseFilter struct {
+	EmployeeID int64
+	ManagerID  int64
+	Status     models.ExpenseStatus
+}
+
+// ExpenseRepository defines the interface for expense data operations
+type ExpenseRepository interface {
+	CreateExpense(ctx context.Context, e *models.Expense) error
+	// FindExpense retrieves an expense with its receipts
+	FindExpense(ctx context.Context, id int64) (*models.Expense, error)
+	FindExpenses(ctx context.Context, filter ExpenseFilter, limit, offset int) ([]models.Expense, int, error)
+
+	// AddReceipt attaches a file to a SUBMITTED expense
+	AddReceipt(ctx context.Context, r *models.Receipt, content []byte) error
+	FindReceipt(ctx context.Context, expenseID, receiptID int64) (*models.Receipt, []byte, error)
+
+	// Decide approves or rejects a SUBMITTED expense on behalf of its
+	// manager. Approval needs at least one receipt
+	Decide(ctx context.Context, id, managerID int64, status models.ExpenseStatus, note string) (*models.Expense, error)
+
+	// CreateExport moves every APPROVED expense into a new payroll export
+	CreateExport(ctx context.Context) (*models.PayrollExport, error)
+	// FindExport retrieves an export with its lines per employee and currency
+	FindExport(ctx context.Context, id int64) (*models.PayrollExport, error)
+	FindExports(ctx context.Context, limit, offset int) ([]models.PayrollExport, int, error)
+}
+
+// expenseRepository is the postgresql implementation of ExpenseRepository
+type expenseRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewExpenseRepository creates a new instance of ExpenseRepository
+func NewExpenseRepository(db *pgxpool.Pool) ExpenseRepository {
+	return &expenseRepository{db: db}
+}
+
+// expenseColumns are the columns scanned by scanExpense
+const expenseColumns = `
+        id, employee_id, manager_id, category, amount::text, currency, to_char(incurred_on, 'YYYY-MM-DD'),
+        description, status, decided_at, decision_note, export_id, created_at, updated_at
+    `
+
+// scanExpense scans a row selected with expenseColumns
+func scanExpense(row pgx.Row) (models.Expense, error) {
+	var e models.Expense
+	err := row.Scan(
+		&e.ID, &e.EmployeeID, &e.ManagerID, &e.Category, &e.Amount, &e.Currency, &e.IncurredOn,
+		&e.Description, &e.Status, &e.DecidedAt, &e.DecisionNote, &e.ExportID, &e.CreatedAt, &e.UpdatedAt,
+	)
+	return e, err
+}
+
+// CreateExpense inserts a SUBMITTED expense
+func (r *expenseRepository) CreateExpense(ctx context.Context, e *models.Expense) error {
+	query := `
+        INSERT INTO expenses.expenses (employee_id, manager_id, category, amount, currency, incurred_on, description)
+        VALUES ($1, $2, $3, $4::numeric, $5, $6::date, $7)
+        RETURNING ` + expenseColumns
+
+	created, err := scanExpense(r.db.QueryRow(ctx, query,
+		e.EmployeeID, e.ManagerID, e.Category, e.Amount, e.Currency, e.IncurredOn, e.Description))
+	if err != nil {
+		return fmt.Errorf("failed to create expense: %w", err)
+	}
+
+	*e = created
+	return nil
+}
+
+// FindExpense retrieves an expense and the metadata of its receipts
+func (r *expenseRepository) FindExpense(ctx context.Context, id int64) (*models.Expense, error) {
+	e, err := scanExpense(r.db.QueryRow(ctx, `SELECT `+expenseColumns+` FROM expenses.expenses WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrExpenseNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+        SELECT id, expense_id, file_name, content_type, size, uploaded_at
+        FROM expenses.receipts
+        WHERE expense_id = $1
+        ORDER BY uploaded_at, id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query receipts: %w", err)
+	}
+	defer rows.Close()
+
+	e.Receipts = []models.Receipt{}
+	for rows.Next() {
+		var rc models.Receipt
+		if err := rows.Scan(&rc.ID, &rc.ExpenseID, &rc.FileName, &rc.ContentType, &rc.Size, &rc.UploadedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan receipt row: %w", err)
+		}
+		e.Receipts = append(e.Receipts, rc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating receipt rows: %w", err)
+	}
+
+	return &e, nil
+}
+
+// FindExpenses retrieves a page of expenses newest first with the total
+// count
+func (r *expenseRepository) FindExpenses(ctx context.Context, filter ExpenseFilter, limit, offset int) ([]models.Expense, int, error) {
+	where := `WHERE ($1 = 0 OR employee_id = $1) AND ($2 = 0 OR manager_id = $2) AND ($3 = '' OR status = $3)`
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM expenses.expenses `+where, filter.EmployeeID, filter.ManagerID, filter.Status).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count expenses: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+expenseColumns+` FROM expenses.expenses `+where+`
+        ORDER BY created_at DESC, id DESC
+        LIMIT $4 OFFSET $5`, filter.EmployeeID, filter.ManagerID, filter.Status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query expenses: %w", err)
+	}
+	defer rows.Close()
+
+	expenses := []models.Expense{}
+	for rows.Next() {
+		e, err := scanExpense(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan expense row: %w", err)
+		}
+		expenses = append(expenses, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating expense rows: %w", err)
+	}
+
+	return expenses, total, nil
+}
+
+// AddReceipt stores the file while the expense is still SUBMITTED
+func (r *expenseRepository) AddReceipt(ctx context.Context, rc *models.Receipt, content []byte) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := lockExpense(ctx, tx, rc.ExpenseID); err != nil {
+			return err
+		}
+
+		err := tx.QueryRow(ctx, `
+            INSERT INTO expenses.receipts (expense_id, file_name, content_type, size, content)
+            VALUES ($1, $2, $3, $4, $5)
+            RETURNING id, uploaded_at`, rc.ExpenseID, rc.FileName, rc.ContentType, rc.Size, content).Scan(&rc.ID, &rc.UploadedAt)
+		if err != nil {
+			return fmt.Errorf("failed to store receipt: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindReceipt retrieves a receipt of the expense with its content
+func (r *expenseRepository) FindReceipt(ctx context.Context, expenseID, receiptID int64) (*models.Receipt, []byte, error) {
+	var rc models.Receipt
+	var content []byte
+	err := r.db.QueryRow(ctx, `
+        SELECT id, expense_id, file_name, content_type, size, uploaded_at, content
+        FROM expenses.receipts
+        WHERE id = $1 AND expense_id = $2`, receiptID, expenseID).Scan(
+		&rc.ID, &rc.ExpenseID, &rc.FileName, &rc.ContentType, &rc.Size, &rc.UploadedAt, &content)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrReceiptNotFound
+		}
+		return nil, nil, err
+	}
+
+	return &rc, content, nil
+}
+
+// Decide records the decision of the manager
+func (r *expenseRepository) Decide(ctx context.Context, id, managerID int64, status models.ExpenseStatus, note string) (*models.Expense, error) {
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		approver, err := lockExpense(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if approver != managerID {
+			return ErrNotApprover
+		}
+
+		if status == models.StatusApproved {
+			var receipts bool
+			if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM expenses.receipts WHERE expense_id = $1)`, id).Scan(&receipts); err != nil {
+				return fmt.Errorf("failed to check receipts: %w", err)
+			}
+			if !receipts {
+				return ErrReceiptRequired
+			}
+		}
+
+		_, err = tx.Exec(ctx, `
+            UPDATE expenses.expenses
+            SET status = $2, decision_note = $3, decided_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1`, id, status, note)
+		if err != nil {
+			return fmt.Errorf("failed to update expense: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindExpense(ctx, id)
+}
+
+// CreateExport locks the approved expenses so a concurrent export cannot
+// pay them twice
+func (r *expenseRepository) CreateExport(ctx context.Context) (*models.PayrollExport, error) {
+	var id int64
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `SELECT id FROM expenses.expenses WHERE status = 'APPROVED' FOR UPDATE`)
+		if err != nil {
+			return fmt.Errorf("failed to lock approved expenses: %w", err)
+		}
+		ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+		if err != nil {
+			return fmt.Errorf("failed to lock approved expenses: %w", err)
+		}
+		if len(ids) == 0 {
+			return ErrNothingToExport
+		}
+
+		err = tx.QueryRow(ctx, `
+            INSERT INTO expenses.payroll_exports (expense_count) VALUES ($1)
+            RETURNING id`, len(ids)).Scan(&id)
+		if err != nil {
+			return fmt.Errorf("failed to create payroll export: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+            UPDATE expenses.expenses SET status = 'EXPORTED', export_id = $1, updated_at = CURRENT_TIMESTAMP
+            WHERE id = ANY($2)`, id, ids)
+		if err != nil {
+			return fmt.Errorf("failed to export expenses: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindExport(ctx, id)
+}
+
+// FindExport retrieves an export with the amounts summed per employee and
+// currency
+func (r *expenseRepository) FindExport(ctx context.Context, id int64) (*models.PayrollExport, error) {
+	var export models.PayrollExport
+	err := r.db.QueryRow(ctx, `SELECT id, expense_count, created_at FROM expenses.payroll_exports WHERE id = $1`, id).Scan(
+		&export.ID, &export.ExpenseCount, &export.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrExportNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+        SELECT employee_id, currency, SUM(amount)::text, COUNT(*)
+        FROM expenses.expenses
+        WHERE export_id = $1
+        GROUP BY employee_id, currency
+        ORDER BY employee_id, currency`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payroll lines: %w", err)
+	}
+	defer rows.Close()
+
+	export.Lines = []models.PayrollLine{}
+	for rows.Next() {
+		var line models.PayrollLine
+		if err := rows.Scan(&line.EmployeeID, &line.Currency, &line.Amount, &line.ExpenseCount); err != nil {
+			return nil, fmt.Errorf("failed to scan payroll line row: %w", err)
+		}
+		export.Lines = append(export.Lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payroll line rows: %w", err)
+	}
+
+	return &export, nil
+}
+
+// FindExports retrieves a page of exports newest first with the total count
+func (r *expenseRepository) FindExports(ctx context.Context, limit, offset int) ([]models.PayrollExport, int, error) {
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM expenses.payroll_exports`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count payroll exports: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+        SELECT id, expense_count, created_at
+        FROM expenses.payroll_exports
+        ORDER BY created_at DESC, id DESC
+        LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query payroll exports: %w", err)
+	}
+	defer rows.Close()
+
+	exports := []models.PayrollExport{}
+	for rows.Next() {
+		var export models.PayrollExport
+		if err := rows.Scan(&export.ID, &export.ExpenseCount, &export.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan payroll export row: %w", err)
+		}
+		exports = append(exports, export)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating payroll export rows: %w", err)
+	}
+
+	return exports, total, nil
+}
+
+// lockExpense locks a SUBMITTED expense and returns its manager
+func lockExpense(ctx context.Context, tx pgx.Tx, id int64) (int64, error) {
+	var managerID int64
+	var status models.ExpenseStatus
+	err := tx.QueryRow(ctx, `SELECT manager_id, status FROM expenses.expenses WHERE id = $1 FOR UPDATE`, id).Scan(&managerID, &status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrExpenseNotFound
+		}
+		return 0, fmt.Errorf("failed to lock expense: %w", err)
+	}
+
+	if status != models.StatusSubmitted {
+		return 0, fmt.Errorf("%w: it is %s", ErrNotSubmitted, status)
+	}
+	return managerID, nil
+}
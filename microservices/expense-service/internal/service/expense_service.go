@@ -0,0 +1,129 @@
+// Package service contains the business logic of the expense service
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"expense-service/internal/models"
+	"expense-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+)
+
+var (
+	// ErrEmployeeRetired is returned when a retired employee submits an
+	// expense or is named as the approver
+	ErrEmployeeRetired = errors.New("employee is retired")
+	// ErrManagerNotFound is returned when the approver does not exist
+	ErrManagerNotFound = errors.New("manager not found")
+	// ErrUnsupportedReceipt is returned for receipts that are not a PDF,
+	// JPEG or PNG
+	ErrUnsupportedReceipt = errors.New("receipt must be a PDF, JPEG or PNG file")
+)
+
+// receiptTypes are the content types accepted for receipts
+var receiptTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+// ExpenseService manages expense claims, their receipts and approval, and
+// the payroll exports of the approved amounts
+type ExpenseService struct {
+	repo      repository.ExpenseRepository
+	employees *employees.Client
+}
+
+// NewExpenseService creates a new ExpenseService instance
+func NewExpenseService(repo repository.ExpenseRepository, employeeClient *employees.Client) *ExpenseService {
+	return &ExpenseService{repo: repo, employees: employeeClient}
+}
+
+// Submit records an expense of an employee for their manager to approve.
+// Both must exist in employee-management and not be retired
+func (s *ExpenseService) Submit(ctx context.Context, e *models.Expense) error {
+	employee, err := s.employees.Get(ctx, e.EmployeeID)
+	if err != nil {
+		return err
+	}
+	if employee.Status == employees.StatusRetired {
+		return ErrEmployeeRetired
+	}
+
+	manager, err := s.employees.Get(ctx, e.ManagerID)
+	if err != nil {
+		if errors.Is(err, employees.ErrNotFound) {
+			return ErrManagerNotFound
+		}
+		return err
+	}
+	if manager.Status == employees.StatusRetired {
+		return fmt.Errorf("%w: the manager cannot approve expenses", ErrEmployeeRetired)
+	}
+
+	return s.repo.CreateExpense(ctx, e)
+}
+
+// FindExpense retrieves an expense with its receipts
+func (s *ExpenseService) FindExpense(ctx context.Context, id int64) (*models.Expense, error) {
+	return s.repo.FindExpense(ctx, id)
+}
+
+// FindExpenses retrieves a page of expenses with the total count
+func (s *ExpenseService) FindExpenses(ctx context.Context, filter repository.ExpenseFilter, page, pageSize int) ([]models.Expense, int, error) {
+	return s.repo.FindExpenses(ctx, filter, pageSize, (page-1)*pageSize)
+}
+
+// AddReceipt attaches a file to a submitted expense. The type is sniffed
+// from the content, whatever the client claims
+func (s *ExpenseService) AddReceipt(ctx context.Context, expenseID int64, fileName string, content []byte) (*models.Receipt, error) {
+	contentType := http.DetectContentType(content)
+	if !receiptTypes[contentType] {
+		return nil, ErrUnsupportedReceipt
+	}
+
+	receipt := models.Receipt{
+		ExpenseID:   expenseID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        int64(len(content)),
+	}
+	if err := s.repo.AddReceipt(ctx, &receipt, content); err != nil {
+		return nil, err
+	}
+	return &receipt, nil
+}
+
+// FindReceipt retrieves a receipt with its content
+func (s *ExpenseService) FindReceipt(ctx context.Context, expenseID, receiptID int64) (*models.Receipt, []byte, error) {
+	return s.repo.FindReceipt(ctx, expenseID, receiptID)
+}
+
+// Approve accepts the expense for reimbursement, only its manager can
+func (s *ExpenseService) Approve(ctx context.Context, id, managerID int64, note string) (*models.Expense, error) {
+	return s.repo.Decide(ctx, id, managerID, models.StatusApproved, note)
+}
+
+// Reject refuses the expense, only its manager can
+func (s *ExpenseService) Reject(ctx context.Context, id, managerID int64, note string) (*models.Expense, error) {
+	return s.repo.Decide(ctx, id, managerID, models.StatusRejected, note)
+}
+
+// Export hands every approved expense not yet paid to payroll
+func (s *ExpenseService) Export(ctx context.Context) (*models.PayrollExport, error) {
+	return s.repo.CreateExport(ctx)
+}
+
+// FindExport retrieves a payroll export with its lines
+func (s *ExpenseService) FindExport(ctx context.Context, id int64) (*models.PayrollExport, error) {
+	return s.repo.FindExport(ctx, id)
+}
+
+// FindExports retrieves a page of payroll exports with the total count
+func (s *ExpenseService) FindExports(ctx context.Context, page, pageSize int) ([]models.PayrollExport, int, error) {
+	return s.repo.FindExports(ctx, pageSize, (page-1)*pageSize)
+}
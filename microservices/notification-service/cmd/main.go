@@ -0,0 +1,123 @@
+package main
+
+//	@title			Notification Service API
+//	@version		1.0
+//	@description	Email and SMS notifications sent on employee events, with their delivery status
+//	@termsOfService	http://swagger.io/terms/
+
+//	@contact.name	API Support
+//	@contact.email	josed.amayar@uqvirtual.edu.co
+
+//	@host		localhost:8082
+//	@BasePath	/notifications-service/api/v1
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"notification-service/internal/config"
+	"notification-service/internal/db"
+	"notification-service/internal/events"
+	"notification-service/internal/handlers"
+	"notification-service/internal/providers"
+	"notification-service/internal/repository"
+	"notification-service/internal/sender"
+	"notification-service/internal/service"
+	"notification-service/internal/templates"
+
+	_ "notification-service/docs" // Swagger docs
+
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+func main() {
+	cfg := config.Load()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbPool := db.NewPostgresPool(cfg)
+	defer dbPool.Close()
+
+	if cfg.MigrateOnStartup {
+		if err := db.Migrate(ctx, dbPool); err != nil {
+			log.Fatalf("database migration failed: %v", err)
+		}
+	}
+
+	tmpl, err := templates.Load(cfg.TemplatesDir)
+	if err != nil {
+		log.Fatalf("failed to load templates: %v", err)
+	}
+
+	emailSender, err := providers.NewEmailSender(cfg)
+	if err != nil {
+		log.Fatalf("failed to create email provider: %v", err)
+	}
+	smsSender, err := providers.NewSMSSender(cfg)
+	if err != nil {
+		log.Fatalf("failed to create sms provider: %v", err)
+	}
+
+	repo := repository.NewNotificationRepository(dbPool)
+	notificationService := service.NewNotificationService(repo, tmpl, envconfig.SplitList(cfg.SMSRecipients))
+
+	// Employee events are turned into pending notifications...
+	consumer, err := events.NewConsumer(cfg)
+	if err != nil {
+		log.Fatalf("failed to create event consumer: %v", err)
+	}
+	defer consumer.Close()
+
+	go func() {
+		if err := consumer.Consume(ctx, notificationService.HandleEvent); err != nil {
+			log.Fatalf("event consumer stopped: %v", err)
+		}
+	}()
+
+	// ...which the sender delivers through the providers
+	go sender.New(
+		repo,
+		emailSender,
+		smsSender,
+		cfg.SendTimeout,
+		cfg.SendPollInterval,
+		cfg.SendBatchSize,
+		cfg.SendMaxAttempts,
+		cfg.SendMaxBackoff,
+	).Run(ctx)
+
+	handler := handlers.NewNotificationHandler(notificationService, cfg.TwilioAuthToken, cfg.TwilioStatusCallback)
+	healthHandler := httpkit.NewHealthHandler("notification-service", dbPool)
+
+	router := httpkit.NewRouter()
+
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	v1 := router.Group("/notifications-service/api/v1")
+	{
+		v1.GET("/health", healthHandler.HealthCheck)
+		v1.GET("/notifications", handler.GetAllNotifications)
+		v1.GET("/notifications/:id", handler.GetNotificationByID)
+		v1.POST("/events", handler.PostEvent)
+		v1.POST("/providers/twilio/status", handler.TwilioStatusCallback)
+	}
+
+	srv := &http.Server{Addr: ":" + cfg.ServerPort, Handler: router}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	log.Printf("Notification service running on :%s, consuming from %s", cfg.ServerPort, cfg.EventBroker)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+// Package api holds the query parameters of the list endpoints
+package api
+
+import "github.com/Josedzzz/microservices-fp/pkg/httpkit"
+
+// PaginationQuery represents the query parameters of the notification list
+type PaginationQuery struct {
+	httpkit.PageQuery
+	EmployeeID int64  `form:"employeeId" binding:"omitempty,min=1"`
+	Status     string `form:"status" binding:"omitempty,oneof=PENDING SENT DELIVERED FAILED"`
+	Channel    string `form:"channel" binding:"omitempty,oneof=EMAIL SMS"`
+}
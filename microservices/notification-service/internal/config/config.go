@@ -0,0 +1,279 @@
+// Package config loads the notification service configuration from
+// defaults, a YAML file, env variables and CLI flags, in that order
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/mail"
+	"os"
+	"time"
+
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"github.com/joho/godotenv"
+)
+
+// Config holds configuration loaded from defaults, file, env and flags
+type Config struct {
+	ServerPort string `yaml:"server_port"`
+
+	DBHost     string `yaml:"db_host"`
+	DBPort     string `yaml:"db_port"`
+	DBName     string `yaml:"db_name"`
+	DBUser     string `yaml:"db_user"`
+	DBPassword string `yaml:"db_password"`
+	DBSSLMode  string `yaml:"db_sslmode"`
+	DBMaxConns int    `yaml:"db_max_conns"`
+
+	DBRetryMaxWait time.Duration `yaml:"db_retry_max_wait"`
+
+	MigrateOnStartup bool `yaml:"migrate_on_startup"`
+
+	// Broker the employee events are consumed from: nats, kafka or rabbitmq
+	EventBroker string `yaml:"event_broker"`
+
+	NATSURL           string `yaml:"nats_url"`
+	NATSStream        string `yaml:"nats_stream"`
+	NATSSubjectPrefix string `yaml:"nats_subject_prefix"`
+	NATSConsumer      string `yaml:"nats_consumer"`
+
+	KafkaBrokers     string `yaml:"kafka_brokers"`
+	KafkaTopicPrefix string `yaml:"kafka_topic_prefix"`
+	KafkaGroupID     string `yaml:"kafka_group_id"`
+
+	RabbitMQURL              string `yaml:"rabbitmq_url"`
+	RabbitMQExchange         string `yaml:"rabbitmq_exchange"`
+	RabbitMQRoutingKeyPrefix string `yaml:"rabbitmq_routing_key_prefix"`
+	RabbitMQQueue            string `yaml:"rabbitmq_queue"`
+
+	// Email provider: log, smtp or sendgrid
+	EmailProvider  string `yaml:"email_provider"`
+	EmailFrom      string `yaml:"email_from"`
+	SMTPHost       string `yaml:"smtp_host"`
+	SMTPPort       string `yaml:"smtp_port"`
+	SMTPUsername   string `yaml:"smtp_username"`
+	SMTPPassword   string `yaml:"smtp_password"`
+	SendGridAPIKey string `yaml:"sendgrid_api_key"`
+
+	// SMS provider: log or twilio
+	SMSProvider      string `yaml:"sms_provider"`
+	SMSFrom          string `yaml:"sms_from"`
+	TwilioAccountSID string `yaml:"twilio_account_sid"`
+	TwilioAuthToken  string `yaml:"twilio_auth_token"`
+	// TwilioStatusCallback is the public url of the status callback
+	// endpoint, empty disables delivery receipts
+	TwilioStatusCallback string `yaml:"twilio_status_callback"`
+
+	// SMSRecipients are the HR numbers texted on employee events
+	SMSRecipients string `yaml:"sms_recipients"`
+	// TemplatesDir overrides the embedded templates file by file
+	TemplatesDir string `yaml:"templates_dir"`
+
+	SendTimeout      time.Duration `yaml:"send_timeout"`
+	SendPollInterval time.Duration `yaml:"send_poll_interval"`
+	SendBatchSize    int           `yaml:"send_batch_size"`
+	SendMaxAttempts  int           `yaml:"send_max_attempts"`
+	SendMaxBackoff   time.Duration `yaml:"send_max_backoff"`
+}
+
+// options lists every setting that can be overridden by env or flags
+var options = []envconfig.Option[Config]{
+	{Env: "SERVER_PORT", Flag: "port", Usage: "HTTP server port", Set: envconfig.String(func(c *Config) *string { return &c.ServerPort })},
+	{Env: "DB_HOST", Flag: "db-host", Usage: "database host", Set: envconfig.String(func(c *Config) *string { return &c.DBHost })},
+	{Env: "DB_PORT", Flag: "db-port", Usage: "database port", Set: envconfig.String(func(c *Config) *string { return &c.DBPort })},
+	{Env: "DB_NAME", Flag: "db-name", Usage: "database name", Set: envconfig.String(func(c *Config) *string { return &c.DBName })},
+	{Env: "DB_USER", Flag: "db-user", Usage: "database user", Set: envconfig.String(func(c *Config) *string { return &c.DBUser })},
+	{Env: "DB_PASSWORD", Flag: "db-password", Usage: "database password", Set: envconfig.String(func(c *Config) *string { return &c.DBPassword })},
+	{Env: "DB_SSL_MODE", Flag: "db-sslmode", Usage: "database sslmode", Set: envconfig.String(func(c *Config) *string { return &c.DBSSLMode })},
+	{Env: "DB_MAX_CONNS", Flag: "db-max-conns", Usage: "maximum open db connections", Set: envconfig.Int(func(c *Config) *int { return &c.DBMaxConns })},
+	{Env: "DB_RETRY_MAX_WAIT", Flag: "db-retry-max-wait", Usage: "how long to wait for the db at startup", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DBRetryMaxWait })},
+	{Env: "MIGRATE_ON_STARTUP", Flag: "migrate-on-startup", Usage: "apply pending migrations at startup", Set: envconfig.Bool(func(c *Config) *bool { return &c.MigrateOnStartup })},
+	{Env: "EVENT_BROKER", Flag: "event-broker", Usage: "broker employee events are consumed from: nats, kafka or rabbitmq", Set: envconfig.String(func(c *Config) *string { return &c.EventBroker })},
+	{Env: "NATS_URL", Flag: "nats-url", Usage: "nats server url", Set: envconfig.String(func(c *Config) *string { return &c.NATSURL })},
+	{Env: "NATS_STREAM", Flag: "nats-stream", Usage: "jetstream stream holding the employee events", Set: envconfig.String(func(c *Config) *string { return &c.NATSStream })},
+	{Env: "NATS_SUBJECT_PREFIX", Flag: "nats-subject-prefix", Usage: "prefix of the event subjects", Set: envconfig.String(func(c *Config) *string { return &c.NATSSubjectPrefix })},
+	{Env: "NATS_CONSUMER", Flag: "nats-consumer", Usage: "durable jetstream consumer name", Set: envconfig.String(func(c *Config) *string { return &c.NATSConsumer })},
+	{Env: "KAFKA_BROKERS", Flag: "kafka-brokers", Usage: "comma separated kafka brokers", Set: envconfig.String(func(c *Config) *string { return &c.KafkaBrokers })},
+	{Env: "KAFKA_TOPIC_PREFIX", Flag: "kafka-topic-prefix", Usage: "prefix of the event topics", Set: envconfig.String(func(c *Config) *string { return &c.KafkaTopicPrefix })},
+	{Env: "KAFKA_GROUP_ID", Flag: "kafka-group-id", Usage: "kafka consumer group", Set: envconfig.String(func(c *Config) *string { return &c.KafkaGroupID })},
+	{Env: "RABBITMQ_URL", Flag: "rabbitmq-url", Usage: "rabbitmq url", Set: envconfig.String(func(c *Config) *string { return &c.RabbitMQURL })},
+	{Env: "RABBITMQ_EXCHANGE", Flag: "rabbitmq-exchange", Usage: "topic exchange the events are published to", Set: envconfig.String(func(c *Config) *string { return &c.RabbitMQExchange })},
+	{Env: "RABBITMQ_ROUTING_KEY_PREFIX", Flag: "rabbitmq-routing-key-prefix", Usage: "prefix of the event routing keys", Set: envconfig.String(func(c *Config) *string { return &c.RabbitMQRoutingKeyPrefix })},
+	{Env: "RABBITMQ_QUEUE", Flag: "rabbitmq-queue", Usage: "durable queue bound to the exchange", Set: envconfig.String(func(c *Config) *string { return &c.RabbitMQQueue })},
+	{Env: "EMAIL_PROVIDER", Flag: "email-provider", Usage: "email provider: log, smtp or sendgrid", Set: envconfig.String(func(c *Config) *string { return &c.EmailProvider })},
+	{Env: "EMAIL_FROM", Flag: "email-from", Usage: "sender address of the emails", Set: envconfig.String(func(c *Config) *string { return &c.EmailFrom })},
+	{Env: "SMTP_HOST", Flag: "smtp-host", Usage: "smtp server host", Set: envconfig.String(func(c *Config) *string { return &c.SMTPHost })},
+	{Env: "SMTP_PORT", Flag: "smtp-port", Usage: "smtp server port", Set: envconfig.String(func(c *Config) *string { return &c.SMTPPort })},
+	{Env: "SMTP_USERNAME", Flag: "smtp-username", Usage: "smtp user, empty for no auth", Set: envconfig.String(func(c *Config) *string { return &c.SMTPUsername })},
+	{Env: "SMTP_PASSWORD", Flag: "smtp-password", Usage: "smtp password", Set: envconfig.String(func(c *Config) *string { return &c.SMTPPassword })},
+	{Env: "SENDGRID_API_KEY", Flag: "sendgrid-api-key", Usage: "sendgrid api key", Set: envconfig.String(func(c *Config) *string { return &c.SendGridAPIKey })},
+	{Env: "SMS_PROVIDER", Flag: "sms-provider", Usage: "sms provider: log or twilio", Set: envconfig.String(func(c *Config) *string { return &c.SMSProvider })},
+	{Env: "SMS_FROM", Flag: "sms-from", Usage: "sender number of the sms, E.164", Set: envconfig.String(func(c *Config) *string { return &c.SMSFrom })},
+	{Env: "TWILIO_ACCOUNT_SID", Flag: "twilio-account-sid", Usage: "twilio account sid", Set: envconfig.String(func(c *Config) *string { return &c.TwilioAccountSID })},
+	{Env: "TWILIO_AUTH_TOKEN", Flag: "twilio-auth-token", Usage: "twilio auth token, also verifies status callbacks", Set: envconfig.String(func(c *Config) *string { return &c.TwilioAuthToken })},
+	{Env: "TWILIO_STATUS_CALLBACK", Flag: "twilio-status-callback", Usage: "public url of the twilio status callback endpoint", Set: envconfig.String(func(c *Config) *string { return &c.TwilioStatusCallback })},
+	{Env: "SMS_RECIPIENTS", Flag: "sms-recipients", Usage: "comma separated numbers texted on employee events", Set: envconfig.String(func(c *Config) *string { return &c.SMSRecipients })},
+	{Env: "TEMPLATES_DIR", Flag: "templates-dir", Usage: "directory overriding the embedded templates", Set: envconfig.String(func(c *Config) *string { return &c.TemplatesDir })},
+	{Env: "SEND_TIMEOUT", Flag: "send-timeout", Usage: "timeout of a provider call", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.SendTimeout })},
+	{Env: "SEND_POLL_INTERVAL", Flag: "send-poll-interval", Usage: "how often pending notifications are polled", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.SendPollInterval })},
+	{Env: "SEND_BATCH_SIZE", Flag: "send-batch-size", Usage: "notifications sent per poll", Set: envconfig.Int(func(c *Config) *int { return &c.SendBatchSize })},
+	{Env: "SEND_MAX_ATTEMPTS", Flag: "send-max-attempts", Usage: "attempts before a notification is marked failed", Set: envconfig.Int(func(c *Config) *int { return &c.SendMaxAttempts })},
+	{Env: "SEND_MAX_BACKOFF", Flag: "send-max-backoff", Usage: "maximum wait between send retries", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.SendMaxBackoff })},
+}
+
+// Load reads the .env file, then builds the config from the process args
+// Exits if the config is invalid
+func Load() *Config {
+	_ = godotenv.Load()
+
+	cfg, err := load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	return cfg
+}
+
+// load builds and validates the config from the given CLI args
+func load(args []string) (*Config, error) {
+	cfg := defaults()
+	fs := flag.NewFlagSet("notification-service", flag.ContinueOnError)
+	if err := envconfig.Load(fs, args, cfg, options); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// defaults returns the config used when nothing else is set
+func defaults() *Config {
+	return &Config{
+		ServerPort: "8082",
+
+		DBHost:     "localhost",
+		DBPort:     "5432",
+		DBName:     "notifications",
+		DBUser:     "notification_user",
+		DBSSLMode:  "disable",
+		DBMaxConns: 5,
+
+		DBRetryMaxWait: time.Minute,
+
+		MigrateOnStartup: true,
+
+		EventBroker: "nats",
+
+		NATSURL:           "nats://localhost:4222",
+		NATSStream:        "HR_EVENTS",
+		NATSSubjectPrefix: "hr.",
+		NATSConsumer:      "notification-service",
+
+		KafkaTopicPrefix: "hr.",
+		KafkaGroupID:     "notification-service",
+
+		RabbitMQExchange: "hr.events",
+		RabbitMQQueue:    "notification-service",
+
+		EmailProvider: "log",
+		EmailFrom:     "HR <hr@example.com>",
+		SMTPPort:      "587",
+
+		SMSProvider: "log",
+
+		SendTimeout:      10 * time.Second,
+		SendPollInterval: 2 * time.Second,
+		SendBatchSize:    50,
+		SendMaxAttempts:  5,
+		SendMaxBackoff:   time.Hour,
+	}
+}
+
+// Validate checks the final config and returns every problem found
+func (c *Config) Validate() error {
+	var errs []error
+
+	if err := envconfig.ValidatePort(c.ServerPort); err != nil {
+		errs = append(errs, fmt.Errorf("server port: %w", err))
+	}
+	if err := envconfig.ValidatePort(c.DBPort); err != nil {
+		errs = append(errs, fmt.Errorf("db port: %w", err))
+	}
+	if c.DBMaxConns < 1 {
+		errs = append(errs, errors.New("db max conns must be at least 1"))
+	}
+
+	switch c.EventBroker {
+	case "nats":
+		if c.NATSURL == "" || c.NATSStream == "" || c.NATSConsumer == "" {
+			errs = append(errs, errors.New("nats url, stream and consumer are required for the nats broker"))
+		}
+	case "kafka":
+		if len(envconfig.SplitList(c.KafkaBrokers)) == 0 || c.KafkaGroupID == "" {
+			errs = append(errs, errors.New("kafka brokers and group id are required for the kafka broker"))
+		}
+	case "rabbitmq":
+		if c.RabbitMQURL == "" || c.RabbitMQExchange == "" || c.RabbitMQQueue == "" {
+			errs = append(errs, errors.New("rabbitmq url, exchange and queue are required for the rabbitmq broker"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("event broker: unknown broker %q", c.EventBroker))
+	}
+
+	if _, err := mail.ParseAddress(c.EmailFrom); err != nil {
+		errs = append(errs, fmt.Errorf("email from: %w", err))
+	}
+	switch c.EmailProvider {
+	case "log":
+	case "smtp":
+		if c.SMTPHost == "" {
+			errs = append(errs, errors.New("smtp host is required for the smtp provider"))
+		}
+		if err := envconfig.ValidatePort(c.SMTPPort); err != nil {
+			errs = append(errs, fmt.Errorf("smtp port: %w", err))
+		}
+	case "sendgrid":
+		if c.SendGridAPIKey == "" {
+			errs = append(errs, errors.New("sendgrid api key is required for the sendgrid provider"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("email provider: unknown provider %q", c.EmailProvider))
+	}
+
+	switch c.SMSProvider {
+	case "log":
+	case "twilio":
+		if c.TwilioAccountSID == "" || c.TwilioAuthToken == "" || c.SMSFrom == "" {
+			errs = append(errs, errors.New("twilio account sid, auth token and sms from are required for the twilio provider"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("sms provider: unknown provider %q", c.SMSProvider))
+	}
+
+	if c.SendTimeout <= 0 || c.SendPollInterval <= 0 || c.SendMaxBackoff <= 0 {
+		errs = append(errs, errors.New("send timeout, poll interval and max backoff must be positive"))
+	}
+	if c.SendBatchSize < 1 || c.SendMaxAttempts < 1 {
+		errs = append(errs, errors.New("send batch size and max attempts must be at least 1"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// DatabaseURL creates the connection url to the db
+func (c *Config) DatabaseURL() string {
+	return fmt.Sprintf(
+		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
+		c.DBUser,
+		c.DBPassword,
+		c.DBHost,
+		c.DBPort,
+		c.DBName,
+		c.DBSSLMode,
+	)
+}
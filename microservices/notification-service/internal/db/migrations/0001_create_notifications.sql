@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS notification.notifications (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	event_id UUID NOT NULL,
+	event_type VARCHAR(100) NOT NULL,
+	employee_id BIGINT NOT NULL,
+	channel VARCHAR(10) NOT NULL,
+	recipient VARCHAR(255) NOT NULL,
+	template VARCHAR(100) NOT NULL,
+	subject TEXT,
+	body TEXT NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+	provider VARCHAR(20),
+	provider_message_id VARCHAR(255),
+	attempts INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT,
+	next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	sent_at TIMESTAMPTZ,
+	delivered_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (event_id, channel, recipient)
+);
+
+CREATE INDEX IF NOT EXISTS notifications_pending_idx
+	ON notification.notifications (next_attempt_at)
+	WHERE status = 'PENDING';
+
+CREATE INDEX IF NOT EXISTS notifications_employee_idx
+	ON notification.notifications (employee_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS notifications_provider_message_idx
+	ON notification.notifications (provider, provider_message_id);
@@ -0,0 +1,175 @@
+// Package handlers exposes the notification service over HTTP
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"notification-service/internal/api"
+	"notification-service/internal/events"
+	"notification-service/internal/models"
+	"notification-service/internal/providers"
+	"notification-service/internal/repository"
+	"notification-service/internal/service"
+
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// NotificationHandler handles HTTP requests for notifications
+type NotificationHandler struct {
+	service *service.NotificationService
+
+	// Twilio callbacks are verified against the token and the public url
+	twilioAuthToken      string
+	twilioStatusCallback string
+}
+
+// NewNotificationHandler creates a new NotificationHandler instance
+func NewNotificationHandler(s *service.NotificationService, twilioAuthToken, twilioStatusCallback string) *NotificationHandler {
+	return &NotificationHandler{service: s, twilioAuthToken: twilioAuthToken, twilioStatusCallback: twilioStatusCallback}
+}
+
+// GetAllNotifications godoc
+//
+//	@Summary		List notifications
+//	@Description	Retrieves notifications newest first, optionally filtered by employee, status and channel
+//	@Tags			Notifications
+//	@Produce		json
+//	@Param			page		query		int					false	"Page number"	default(1)
+//	@Param			page_size	query		int					false	"Page size"		default(20)
+//	@Param			employeeId	query		int					false	"Employee id"
+//	@Param			status		query		string				false	"Delivery status"	Enums(PENDING, SENT, DELIVERED, FAILED)
+//	@Param			channel		query		string				false	"Channel"			Enums(EMAIL, SMS)
+//	@Success		200			{object}	httpkit.PaginatedResponse{data=[]models.Notification}	"Notifications"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid query parameters"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/notifications [get]
+func (h *NotificationHandler) GetAllNotifications(c *gin.Context) {
+	var query api.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpkit.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	query.Normalize()
+
+	filter := repository.NotificationFilter{
+		EmployeeID: query.EmployeeID,
+		Status:     models.NotificationStatus(query.Status),
+		Channel:    models.Channel(query.Channel),
+	}
+
+	notifications, total, err := h.service.FindAll(c.Request.Context(), filter, query.Page, query.PageSize)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve notifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, httpkit.Paginate(notifications, query.PageQuery, total))
+}
+
+// GetNotificationByID godoc
+//
+//	@Summary		Get a notification
+//	@Description	Retrieves a notification with its delivery status
+//	@Tags			Notifications
+//	@Produce		json
+//	@Param			id	path		int					true	"Notification ID"
+//	@Success		200	{object}	models.Notification	"Notification"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid notification ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Notification not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/notifications/{id} [get]
+func (h *NotificationHandler) GetNotificationByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		httpkit.BadRequest(c, "Invalid notification ID")
+		return
+	}
+
+	notification, err := h.service.FindByID(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotificationNotFound):
+			httpkit.NotFound(c, "Notification not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve notification")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, notification)
+}
+
+// TwilioStatusCallback godoc
+//
+//	@Summary		Twilio delivery receipt
+//	@Description	Status callback called by Twilio, authenticated with the X-Twilio-Signature header
+//	@Tags			Providers
+//	@Accept			x-www-form-urlencoded
+//	@Param			X-Twilio-Signature	header		string				true	"Request signature"
+//	@Param			MessageSid			formData	string				true	"Message SID"
+//	@Param			MessageStatus		formData	string				true	"Message status"
+//	@Param			ErrorCode			formData	string				false	"Error code"
+//	@Success		204
+//	@Failure		403	{object}	httpkit.ErrorResponse	"Invalid signature"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/providers/twilio/status [post]
+func (h *NotificationHandler) TwilioStatusCallback(c *gin.Context) {
+	if err := c.Request.ParseForm(); err != nil {
+		httpkit.BadRequest(c, "Invalid form")
+		return
+	}
+
+	signature := c.GetHeader("X-Twilio-Signature")
+	if h.twilioAuthToken == "" || !providers.VerifyTwilioSignature(h.twilioAuthToken, h.twilioStatusCallback, c.Request.PostForm, signature) {
+		httpkit.Error(c, http.StatusForbidden, "Invalid signature")
+		return
+	}
+
+	form := c.Request.PostForm
+	if err := h.service.HandleTwilioStatus(c.Request.Context(), form.Get("MessageSid"), form.Get("MessageStatus"), form.Get("ErrorCode")); err != nil {
+		log.Printf("failed to record twilio status: %v", err)
+		httpkit.InternalServerError(c, "Failed to record delivery status")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PostEvent godoc
+//
+//	@Summary		Deliver an event
+//	@Description	Handles an event posted by another service instead of through the broker, e.g. employee.offboarded from saga-service. The event id makes a retried post send nothing twice. Events without templates send nothing
+//	@Tags			Events
+//	@Accept			json
+//	@Param			event	body	events.Event	true	"Event with a UUID id"
+//	@Success		202		"Event accepted"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid JSON format, id or type"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/events [post]
+func (h *NotificationHandler) PostEvent(c *gin.Context) {
+	var e events.Event
+	if err := c.ShouldBindJSON(&e); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+	if err := uuid.Validate(e.ID); err != nil {
+		httpkit.BadRequest(c, "Event id must be a UUID")
+		return
+	}
+	if e.Type == "" {
+		httpkit.BadRequest(c, "Event type is required")
+		return
+	}
+
+	if err := h.service.HandleEvent(c.Request.Context(), e); err != nil {
+		httpkit.InternalServerError(c, "Failed to handle event")
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
@@ -0,0 +1,50 @@
+// Package models define the core data structures of the notification service
+package models
+
+import "time"
+
+// Channel is the medium a notification is sent through
+type Channel string
+
+const (
+	ChannelEmail Channel = "EMAIL"
+	ChannelSMS   Channel = "SMS"
+)
+
+// NotificationStatus represents the state of a notification
+type NotificationStatus string
+
+const (
+	// StatusPending is waiting to be sent or retried
+	StatusPending NotificationStatus = "PENDING"
+	// StatusSent was accepted by the provider
+	StatusSent NotificationStatus = "SENT"
+	// StatusDelivered was confirmed delivered by the provider
+	StatusDelivered NotificationStatus = "DELIVERED"
+	// StatusFailed ran out of attempts or was rejected after sending
+	StatusFailed NotificationStatus = "FAILED"
+)
+
+// Notification is a message rendered for one employee event and one
+// recipient, with its delivery state
+type Notification struct {
+	ID                int64              `json:"id"`
+	EventID           string             `json:"eventId"`
+	EventType         string             `json:"eventType"`
+	EmployeeID        int64              `json:"employeeId"`
+	Channel           Channel            `json:"channel"`
+	Recipient         string             `json:"recipient"`
+	Template          string             `json:"template"`
+	Subject           string             `json:"subject,omitempty"`
+	Body              string             `json:"body"`
+	Status            NotificationStatus `json:"status"`
+	Provider          *string            `json:"provider,omitempty"`
+	ProviderMessageID *string            `json:"providerMessageId,omitempty"`
+	Attempts          int                `json:"attempts"`
+	LastError         *string            `json:"lastError,omitempty"`
+	NextAttemptAt     time.Time          `json:"nextAttemptAt"`
+	SentAt            *time.Time         `json:"sentAt,omitempty"`
+	DeliveredAt       *time.Time         `json:"deliveredAt,omitempty"`
+	CreatedAt         time.Time          `json:"createdAt"`
+	UpdatedAt         time.Time          `json:"updatedAt"`
+}
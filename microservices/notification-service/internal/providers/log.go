@@ -0,0 +1,25 @@
+package providers
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// LogSender only logs messages, used when no provider is configured
+type LogSender struct{}
+
+func (LogSender) Name() string { return "log" }
+
+// SendEmail logs the email
+func (LogSender) SendEmail(_ context.Context, m Email) (string, error) {
+	log.Printf("email to %s: %s\n%s", m.To, m.Subject, m.Body)
+	return uuid.NewString(), nil
+}
+
+// SendSMS logs the text message
+func (LogSender) SendSMS(_ context.Context, m SMS) (string, error) {
+	log.Printf("sms to %s: %s", m.To, m.Body)
+	return uuid.NewString(), nil
+}
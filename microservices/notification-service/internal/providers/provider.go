@@ -0,0 +1,77 @@
+// Package providers sends emails and text messages through pluggable
+// providers (SMTP, SendGrid, Twilio, or the log for development)
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"notification-service/internal/config"
+)
+
+// ErrPermanent marks failures that retrying cannot fix, e.g. an invalid
+// recipient. Notifications failing with it are not retried
+var ErrPermanent = errors.New("permanent delivery failure")
+
+// Email is a plain text email to one recipient
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// SMS is a text message to one E.164 number
+type SMS struct {
+	To   string
+	Body string
+}
+
+// EmailSender sends emails, returning the provider message id
+type EmailSender interface {
+	Name() string
+	SendEmail(ctx context.Context, m Email) (string, error)
+}
+
+// SMSSender sends text messages, returning the provider message id
+type SMSSender interface {
+	Name() string
+	SendSMS(ctx context.Context, m SMS) (string, error)
+}
+
+// NewEmailSender creates the email provider selected by the config
+func NewEmailSender(cfg *config.Config) (EmailSender, error) {
+	switch cfg.EmailProvider {
+	case "log":
+		return LogSender{}, nil
+	case "smtp":
+		return NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.EmailFrom), nil
+	case "sendgrid":
+		return NewSendGridSender(cfg.SendGridAPIKey, cfg.EmailFrom, cfg.SendTimeout), nil
+	default:
+		return nil, fmt.Errorf("unknown email provider %q", cfg.EmailProvider)
+	}
+}
+
+// NewSMSSender creates the SMS provider selected by the config
+func NewSMSSender(cfg *config.Config) (SMSSender, error) {
+	switch cfg.SMSProvider {
+	case "log":
+		return LogSender{}, nil
+	case "twilio":
+		return NewTwilioSender(cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.SMSFrom, cfg.TwilioStatusCallback, cfg.SendTimeout), nil
+	default:
+		return nil, fmt.Errorf("unknown sms provider %q", cfg.SMSProvider)
+	}
+}
+
+// statusError turns a failed provider response into an error, permanent
+// for client errors other than rate limiting
+func statusError(provider string, status int, detail string) error {
+	err := fmt.Errorf("%s answered %d: %s", provider, status, detail)
+	if status >= 400 && status < 500 && status != http.StatusTooManyRequests && status != http.StatusRequestTimeout {
+		return fmt.Errorf("%w: %w", ErrPermanent, err)
+	}
+	return err
+}
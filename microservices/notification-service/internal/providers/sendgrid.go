@@ -0,0 +1,72 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/mail"
+	"time"
+)
+
+// sendGridURL is the v3 mail send endpoint
+const sendGridURL = "https://api.sendgrid.com/v3/mail/send"
+
+// SendGridSender sends emails through the SendGrid v3 API
+type SendGridSender struct {
+	apiKey string
+	from   string
+	client *http.Client
+}
+
+// NewSendGridSender creates a sender using apiKey
+func NewSendGridSender(apiKey, from string, timeout time.Duration) *SendGridSender {
+	return &SendGridSender{apiKey: apiKey, from: from, client: &http.Client{Timeout: timeout}}
+}
+
+func (s *SendGridSender) Name() string { return "sendgrid" }
+
+// sendGridAddress is an email address in the SendGrid API
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+// SendEmail sends m, the X-Message-Id of the answer is the provider id
+func (s *SendGridSender) SendEmail(ctx context.Context, m Email) (string, error) {
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"personalizations": []map[string]any{{"to": []sendGridAddress{{Email: m.To}}}},
+		"from":             sendGridAddress{Email: from.Address, Name: from.Name},
+		"subject":          m.Subject,
+		"content":          []map[string]string{{"type": "text/plain", "value": m.Body}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sendGridURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return "", statusError("sendgrid", resp.StatusCode, string(detail))
+	}
+
+	return resp.Header.Get("X-Message-Id"), nil
+}
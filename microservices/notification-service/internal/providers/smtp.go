@@ -0,0 +1,117 @@
+package providers
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SMTPSender sends emails through an SMTP relay, upgrading to TLS with
+// STARTTLS when the server offers it
+type SMTPSender struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPSender creates a sender relaying through host:port
+func NewSMTPSender(host, port, username, password, from string) *SMTPSender {
+	return &SMTPSender{host: host, port: port, username: username, password: password, from: from}
+}
+
+func (s *SMTPSender) Name() string { return "smtp" }
+
+// SendEmail sends m, the generated Message-ID is the provider id
+func (s *SMTPSender) SendEmail(ctx context.Context, m Email) (string, error) {
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return "", err
+	}
+	to, err := mail.ParseAddress(m.To)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid recipient %q", ErrPermanent, m.To)
+	}
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", net.JoinHostPort(s.host, s.port))
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return "", err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}); err != nil {
+			return "", fmt.Errorf("smtp starttls failed: %w", err)
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return "", fmt.Errorf("smtp auth failed: %w", err)
+		}
+	}
+
+	messageID := fmt.Sprintf("<%s@%s>", uuid.NewString(), domainOf(from.Address))
+
+	if err := client.Mail(from.Address); err != nil {
+		return "", err
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		// 5xx on RCPT means the mailbox is rejected for good
+		var protoErr *textproto.Error
+		if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+			return "", fmt.Errorf("%w: %w", ErrPermanent, err)
+		}
+		return "", err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return "", err
+	}
+
+	headers := []string{
+		"From: " + from.String(),
+		"To: " + to.String(),
+		"Subject: " + mime.QEncoding.Encode("utf-8", m.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"Message-ID: " + messageID,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: 8bit",
+	}
+	body := strings.Join(headers, "\r\n") + "\r\n\r\n" + strings.ReplaceAll(m.Body, "\n", "\r\n")
+	if _, err := w.Write([]byte(body)); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return messageID, client.Quit()
+}
+
+// domainOf returns the domain of an email address
+func domainOf(address string) string {
+	_, domain, _ := strings.Cut(address, "@")
+	return domain
+}
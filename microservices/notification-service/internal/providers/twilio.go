@@ -0,0 +1,99 @@
+package providers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// twilioURL is the base of the Twilio REST API
+const twilioURL = "https://api.twilio.com/2010-04-01/Accounts/"
+
+// TwilioSender sends text messages through the Twilio Messages API
+// With a status callback url Twilio reports the final delivery status
+type TwilioSender struct {
+	accountSID     string
+	authToken      string
+	from           string
+	statusCallback string
+	client         *http.Client
+}
+
+// NewTwilioSender creates a sender for the account
+func NewTwilioSender(accountSID, authToken, from, statusCallback string, timeout time.Duration) *TwilioSender {
+	return &TwilioSender{
+		accountSID:     accountSID,
+		authToken:      authToken,
+		from:           from,
+		statusCallback: statusCallback,
+		client:         &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *TwilioSender) Name() string { return "twilio" }
+
+// SendSMS sends m, the message sid is the provider id
+func (s *TwilioSender) SendSMS(ctx context.Context, m SMS) (string, error) {
+	form := url.Values{"To": {m.To}, "From": {s.from}, "Body": {m.Body}}
+	if s.statusCallback != "" {
+		form.Set("StatusCallback", s.statusCallback)
+	}
+
+	endpoint := twilioURL + url.PathEscape(s.accountSID) + "/Messages.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		SID     string `json:"sid"`
+		Message string `json:"message"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", statusError("twilio", resp.StatusCode, result.Message)
+	}
+
+	return result.SID, nil
+}
+
+// VerifyTwilioSignature checks the X-Twilio-Signature of a callback: the
+// base64 HMAC-SHA1 of the full url followed by the sorted form params
+func VerifyTwilioSignature(authToken, callbackURL string, params url.Values, signature string) bool {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(callbackURL)
+	for _, k := range keys {
+		for _, v := range params[k] {
+			b.WriteString(k)
+			b.WriteString(v)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(b.String()))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
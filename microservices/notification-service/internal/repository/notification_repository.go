@@ -0,0 +1,261 @@
+// Package repository provides data access to the notifications
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"notification-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrNotificationNotFound is returned when a notification does not exist
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// NotificationFilter narrows FindAll, zero values match everything
+type NotificationFilter struct {
+	EmployeeID int64
+	Status     models.NotificationStatus
+	Channel    models.Channel
+}
+
+// SendFunc sends one notification and returns the provider used and its
+// message id
+type SendFunc func(ctx context.Context, n models.Notification) (provider, messageID string, err error)
+
+// NotificationRepository defines the interface for notification data operations
+type NotificationRepository interface {
+	// Enqueue stores pending notifications. Notifications already stored
+	// for the same event, channel and recipient are skipped, so
+	// redelivered events do not send twice
+	Enqueue(ctx context.Context, notifications []models.Notification) error
+	FindByID(ctx context.Context, id int64) (*models.Notification, error)
+	FindAll(ctx context.Context, filter NotificationFilter, limit, offset int) ([]models.Notification, int, error)
+
+	// DispatchPending locks up to limit due notifications and calls send
+	// for each one. Failures are retried after backoff(attempts) until
+	// maxAttempts, or failed at once when permanent(err)
+	DispatchPending(ctx context.Context, limit, maxAttempts int, send SendFunc, backoff func(attempts int) time.Duration, permanent func(err error) bool) (int, error)
+
+	// UpdateDeliveryStatus records a delivery receipt from a provider
+	UpdateDeliveryStatus(ctx context.Context, provider, messageID string, status models.NotificationStatus, reason string) error
+}
+
+// notificationRepository is the postgresql implementation of NotificationRepository
+type notificationRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewNotificationRepository creates a new instance of NotificationRepository
+func NewNotificationRepository(db *pgxpool.Pool) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// notificationColumns are the columns scanned by scanNotification
+const notificationColumns = `
+        id, event_id, event_type, employee_id, channel, recipient, template,
+        COALESCE(subject, ''), body, status, provider, provider_message_id, attempts,
+        last_error, next_attempt_at, sent_at, delivered_at, created_at, updated_at
+    `
+
+// scanNotification scans a row selected with notificationColumns
+func scanNotification(row pgx.Row) (models.Notification, error) {
+	var n models.Notification
+	err := row.Scan(
+		&n.ID,
+		&n.EventID,
+		&n.EventType,
+		&n.EmployeeID,
+		&n.Channel,
+		&n.Recipient,
+		&n.Template,
+		&n.Subject,
+		&n.Body,
+		&n.Status,
+		&n.Provider,
+		&n.ProviderMessageID,
+		&n.Attempts,
+		&n.LastError,
+		&n.NextAttemptAt,
+		&n.SentAt,
+		&n.DeliveredAt,
+		&n.CreatedAt,
+		&n.UpdatedAt,
+	)
+	return n, err
+}
+
+// Enqueue inserts the notifications in one batch
+func (r *notificationRepository) Enqueue(ctx context.Context, notifications []models.Notification) error {
+	query := `
+        INSERT INTO notification.notifications
+            (event_id, event_type, employee_id, channel, recipient, template, subject, body)
+        VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8)
+        ON CONFLICT (event_id, channel, recipient) DO NOTHING
+    `
+
+	batch := &pgx.Batch{}
+	for _, n := range notifications {
+		batch.Queue(query, n.EventID, n.EventType, n.EmployeeID, n.Channel, n.Recipient, n.Template, n.Subject, n.Body)
+	}
+
+	if err := r.db.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to enqueue notifications: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a notification by id
+func (r *notificationRepository) FindByID(ctx context.Context, id int64) (*models.Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notification.notifications WHERE id = $1`
+
+	n, err := scanNotification(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, err
+	}
+
+	return &n, nil
+}
+
+// FindAll retrieves a page of notifications, newest first, with the total
+// number matching the filter
+func (r *notificationRepository) FindAll(ctx context.Context, filter NotificationFilter, limit, offset int) ([]models.Notification, int, error) {
+	where := `
+        WHERE ($1 = 0 OR employee_id = $1)
+          AND ($2 = '' OR status = $2)
+          AND ($3 = '' OR channel = $3)
+    `
+	args := []any{filter.EmployeeID, string(filter.Status), string(filter.Channel)}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM notification.notifications`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	query := `SELECT ` + notificationColumns + ` FROM notification.notifications` + where + `
+        ORDER BY id DESC
+        LIMIT $4 OFFSET $5
+    `
+
+	rows, err := r.db.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan notification row: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating notification rows: %w", err)
+	}
+
+	return notifications, total, nil
+}
+
+// DispatchPending sends a batch of due notifications in one transaction
+func (r *notificationRepository) DispatchPending(ctx context.Context, limit, maxAttempts int, send SendFunc, backoff func(attempts int) time.Duration, permanent func(err error) bool) (int, error) {
+	sent := 0
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `SELECT ` + notificationColumns + `
+            FROM notification.notifications
+            WHERE status = 'PENDING' AND next_attempt_at <= CURRENT_TIMESTAMP
+            ORDER BY id
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        `
+
+		rows, err := tx.Query(ctx, query, limit)
+		if err != nil {
+			return fmt.Errorf("failed to query pending notifications: %w", err)
+		}
+
+		var batch []models.Notification
+		for rows.Next() {
+			n, err := scanNotification(rows)
+			if err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan notification row: %w", err)
+			}
+			batch = append(batch, n)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating notification rows: %w", err)
+		}
+
+		for _, n := range batch {
+			provider, messageID, sendErr := send(ctx, n)
+
+			if sendErr == nil {
+				_, err := tx.Exec(ctx, `
+                    UPDATE notification.notifications
+                    SET status = 'SENT', attempts = attempts + 1, provider = $2, provider_message_id = NULLIF($3, ''),
+                        last_error = NULL, sent_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+                    WHERE id = $1`,
+					n.ID, provider, messageID,
+				)
+				if err != nil {
+					return fmt.Errorf("failed to mark notification sent: %w", err)
+				}
+				sent++
+				continue
+			}
+
+			attempts := n.Attempts + 1
+			status := models.StatusPending
+			if attempts >= maxAttempts || permanent(sendErr) {
+				status = models.StatusFailed
+			}
+
+			_, err := tx.Exec(ctx, `
+                UPDATE notification.notifications
+                SET status = $2, attempts = $3, provider = $4, last_error = $5, next_attempt_at = $6,
+                    updated_at = CURRENT_TIMESTAMP
+                WHERE id = $1`,
+				n.ID, status, attempts, provider, sendErr.Error(), time.Now().Add(backoff(attempts)),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to schedule notification retry: %w", err)
+			}
+		}
+
+		return nil
+	})
+
+	return sent, err
+}
+
+// UpdateDeliveryStatus moves a sent notification to its final status
+// Receipts for unknown messages are ignored
+func (r *notificationRepository) UpdateDeliveryStatus(ctx context.Context, provider, messageID string, status models.NotificationStatus, reason string) error {
+	query := `
+        UPDATE notification.notifications
+        SET status = $3,
+            last_error = NULLIF($4, ''),
+            delivered_at = CASE WHEN $3 = 'DELIVERED' THEN CURRENT_TIMESTAMP ELSE delivered_at END,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE provider = $1 AND provider_message_id = $2 AND status IN ('SENT', 'DELIVERED')
+    `
+
+	if _, err := r.db.Exec(ctx, query, provider, messageID, status, reason); err != nil {
+		return fmt.Errorf("failed to update delivery status: %w", err)
+	}
+
+	return nil
+}
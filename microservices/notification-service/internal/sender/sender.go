@@ -0,0 +1,94 @@
+// Package sender delivers the stored notifications through the providers,
+// retrying failures with exponential backoff
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"notification-service/internal/backoff"
+	"notification-service/internal/models"
+	"notification-service/internal/providers"
+	"notification-service/internal/repository"
+)
+
+// Sender polls pending notifications and sends them
+type Sender struct {
+	repo         repository.NotificationRepository
+	email        providers.EmailSender
+	sms          providers.SMSSender
+	timeout      time.Duration
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+	maxBackoff   time.Duration
+}
+
+// New creates a new Sender
+func New(repo repository.NotificationRepository, email providers.EmailSender, sms providers.SMSSender, timeout, pollInterval time.Duration, batchSize, maxAttempts int, maxBackoff time.Duration) *Sender {
+	return &Sender{
+		repo:         repo,
+		email:        email,
+		sms:          sms,
+		timeout:      timeout,
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+		maxAttempts:  maxAttempts,
+		maxBackoff:   maxBackoff,
+	}
+}
+
+// Run sends until ctx is done
+func (s *Sender) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		n, err := s.repo.DispatchPending(ctx, s.batchSize, s.maxAttempts, s.send, s.backoff, isPermanent)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("notification dispatch failed: %v", err)
+		}
+
+		if n == s.batchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(s.pollInterval)
+		}
+	}
+}
+
+// send hands one notification to the provider of its channel
+func (s *Sender) send(ctx context.Context, n models.Notification) (string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	switch n.Channel {
+	case models.ChannelEmail:
+		id, err := s.email.SendEmail(ctx, providers.Email{To: n.Recipient, Subject: n.Subject, Body: n.Body})
+		return s.email.Name(), id, err
+	case models.ChannelSMS:
+		id, err := s.sms.SendSMS(ctx, providers.SMS{To: n.Recipient, Body: n.Body})
+		return s.sms.Name(), id, err
+	default:
+		return "", "", fmt.Errorf("%w: unknown channel %s", providers.ErrPermanent, n.Channel)
+	}
+}
+
+// backoff returns the wait before the given attempt, 30s doubled per attempt
+func (s *Sender) backoff(attempts int) time.Duration {
+	return backoff.Exponential(30*time.Second, s.maxBackoff, attempts)
+}
+
+// isPermanent reports whether retrying err is pointless
+func isPermanent(err error) bool {
+	return errors.Is(err, providers.ErrPermanent)
+}
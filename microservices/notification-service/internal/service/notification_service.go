@@ -0,0 +1,170 @@
+// Package service contains the business logic of the notification service
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"notification-service/internal/events"
+	"notification-service/internal/models"
+	"notification-service/internal/repository"
+	"notification-service/internal/templates"
+)
+
+// TemplateData is what the templates are rendered with
+type TemplateData struct {
+	Event    events.Event
+	Employee events.Employee
+	// From and To are set for employee.status_changed
+	From string
+	To   string
+	// VerificationURL and ExpiresAt are set for
+	// employee.email_verification_requested
+	VerificationURL string
+	ExpiresAt       time.Time
+}
+
+// NotificationService turns employee events into notifications and
+// exposes their delivery state
+type NotificationService struct {
+	repo          repository.NotificationRepository
+	templates     *templates.Set
+	smsRecipients []string
+}
+
+// NewNotificationService creates a new NotificationService instance
+// smsRecipients are the HR numbers texted when an event has an SMS template
+func NewNotificationService(repo repository.NotificationRepository, t *templates.Set, smsRecipients []string) *NotificationService {
+	return &NotificationService{repo: repo, templates: t, smsRecipients: smsRecipients}
+}
+
+// HandleEvent renders the templates of the event and stores the
+// notifications for the sender. Emails go to the employee, SMS to the HR
+// recipients. Events without templates are ignored
+func (s *NotificationService) HandleEvent(ctx context.Context, e events.Event) error {
+	data, names, err := templateData(e)
+	if err != nil {
+		// A payload we cannot read will not get better on redelivery
+		log.Printf("skipping event %s: %v", e.ID, err)
+		return nil
+	}
+
+	var notifications []models.Notification
+
+	add := func(channel models.Channel, recipients []string) error {
+		rendered, ok, err := s.templates.Render(names, channel, data)
+		if err != nil || !ok {
+			return err
+		}
+		for _, to := range recipients {
+			notifications = append(notifications, models.Notification{
+				EventID:    e.ID,
+				EventType:  string(e.Type),
+				EmployeeID: e.AggregateID,
+				Channel:    channel,
+				Recipient:  to,
+				Template:   rendered.Template,
+				Subject:    rendered.Subject,
+				Body:       rendered.Body,
+			})
+		}
+		return nil
+	}
+
+	if data.Employee.Email != "" {
+		if err := add(models.ChannelEmail, []string{data.Employee.Email}); err != nil {
+			return err
+		}
+	}
+	if err := add(models.ChannelSMS, s.smsRecipients); err != nil {
+		return err
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+	return s.repo.Enqueue(ctx, notifications)
+}
+
+// templateData decodes the event payload and returns the template names
+// to try, most specific first
+func templateData(e events.Event) (TemplateData, []string, error) {
+	data := TemplateData{Event: e}
+	names := []string{string(e.Type)}
+
+	switch e.Type {
+	case events.EmployeeStatusChanged:
+		var change events.StatusChange
+		if err := json.Unmarshal(e.Payload, &change); err != nil {
+			return data, nil, fmt.Errorf("invalid status change payload: %w", err)
+		}
+		data.Employee = events.Employee{
+			ID:         change.EmployeeID,
+			FirstName:  change.FirstName,
+			LastName:   change.LastName,
+			Email:      change.Email,
+			Department: change.Department,
+			Status:     change.To,
+		}
+		data.From, data.To = change.From, change.To
+		names = append([]string{string(e.Type) + "." + strings.ToLower(change.To)}, names...)
+	case events.EmployeeEmailVerificationRequested:
+		var verification events.EmailVerification
+		if err := json.Unmarshal(e.Payload, &verification); err != nil {
+			return data, nil, fmt.Errorf("invalid email verification payload: %w", err)
+		}
+		data.Employee = events.Employee{
+			ID:        verification.EmployeeID,
+			FirstName: verification.FirstName,
+			LastName:  verification.LastName,
+			Email:     verification.Email,
+		}
+		data.VerificationURL, data.ExpiresAt = verification.VerificationURL, verification.ExpiresAt
+	default:
+		if err := json.Unmarshal(e.Payload, &data.Employee); err != nil {
+			return data, nil, fmt.Errorf("invalid employee payload: %w", err)
+		}
+	}
+
+	return data, names, nil
+}
+
+// FindByID retrieves a notification by id
+func (s *NotificationService) FindByID(ctx context.Context, id int64) (*models.Notification, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// FindAll retrieves a page of notifications matching filter
+func (s *NotificationService) FindAll(ctx context.Context, filter repository.NotificationFilter, page, pageSize int) ([]models.Notification, int, error) {
+	return s.repo.FindAll(ctx, filter, pageSize, (page-1)*pageSize)
+}
+
+// twilioStatuses maps final Twilio message statuses to ours, intermediate
+// ones (queued, sending, sent) are not recorded
+var twilioStatuses = map[string]models.NotificationStatus{
+	"delivered":   models.StatusDelivered,
+	"undelivered": models.StatusFailed,
+	"failed":      models.StatusFailed,
+}
+
+// HandleTwilioStatus records a Twilio delivery receipt
+func (s *NotificationService) HandleTwilioStatus(ctx context.Context, messageSID, messageStatus, errorCode string) error {
+	status, ok := twilioStatuses[messageStatus]
+	if !ok {
+		return nil
+	}
+
+	reason := ""
+	if status == models.StatusFailed {
+		reason = "twilio " + messageStatus
+		if errorCode != "" {
+			reason += " (error " + errorCode + ")"
+		}
+	}
+
+	return s.repo.UpdateDeliveryStatus(ctx, "twilio", messageSID, status, reason)
+}
@@ -0,0 +1,117 @@
+// Package templates renders the notification texts. Templates are
+// text/template files named <name>.<channel>.tmpl, where name is the event
+// type optionally followed by a qualifier (e.g.
+// employee.status_changed.retired). Email templates define a "subject"
+// and a "body" block, SMS templates are the body
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"text/template"
+
+	"notification-service/internal/models"
+)
+
+//go:embed defaults/*.tmpl
+var defaultFiles embed.FS
+
+// Rendered is a notification text ready to send
+type Rendered struct {
+	Template string
+	Subject  string
+	Body     string
+}
+
+// Set holds the parsed templates keyed by file name
+type Set struct {
+	templates map[string]*template.Template
+}
+
+// Load parses the embedded templates, then the ones in dir (if set)
+// which replace embedded templates of the same name
+func Load(dir string) (*Set, error) {
+	s := &Set{templates: map[string]*template.Template{}}
+
+	if err := s.parseFS(defaultFiles, "defaults"); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := s.parseFS(os.DirFS(dir), "."); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// parseFS parses every .tmpl file of dir in fsys
+func (s *Set) parseFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("failed to read templates: %w", err)
+	}
+
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".tmpl")
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, dir+"/"+entry.Name())
+		if err != nil {
+			return err
+		}
+
+		t, err := template.New(name).Option("missingkey=error").Parse(string(data))
+		if err != nil {
+			return fmt.Errorf("template %s: %w", entry.Name(), err)
+		}
+		if strings.HasSuffix(name, ".email") && (t.Lookup("subject") == nil || t.Lookup("body") == nil) {
+			return fmt.Errorf("template %s must define a subject and a body", entry.Name())
+		}
+
+		s.templates[name] = t
+	}
+
+	return nil
+}
+
+// Render renders the first of names that has a template for channel
+// ok is false when none of them has one, meaning nothing is sent
+func (s *Set) Render(names []string, channel models.Channel, data any) (r Rendered, ok bool, err error) {
+	suffix := "." + strings.ToLower(string(channel))
+
+	for _, name := range names {
+		t, found := s.templates[name+suffix]
+		if !found {
+			continue
+		}
+
+		r.Template = name + suffix
+		if channel == models.ChannelEmail {
+			if r.Subject, err = execute(t, "subject", data); err != nil {
+				return r, true, err
+			}
+			r.Body, err = execute(t, "body", data)
+		} else {
+			r.Body, err = execute(t, name+suffix, data)
+		}
+		return r, true, err
+	}
+
+	return r, false, nil
+}
+
+// execute runs the named template, trimming surrounding blank lines
+func execute(t *template.Template, name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", t.Name(), err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,88 @@
+package main
+
+//	@title			Recruitment Service API
+//	@version		1.0
+//	@description	Job postings, candidates moving through interview stages and their conversion into employees
+//	@termsOfService	http://swagger.io/terms/
+
+//	@contact.name	API Support
+//	@contact.email	josed.amayar@uqvirtual.edu.co
+
+//	@host		localhost:8084
+//	@BasePath	/recruitment-service/api/v1
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"recruitment-service/internal/config"
+	"recruitment-service/internal/db"
+	"recruitment-service/internal/handlers"
+	"recruitment-service/internal/repository"
+	"recruitment-service/internal/service"
+
+	_ "recruitment-service/docs" // Swagger docs
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+func main() {
+	cfg := config.Load()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbPool := db.NewPostgresPool(cfg)
+	defer dbPool.Close()
+
+	if cfg.MigrateOnStartup {
+		if err := db.Migrate(ctx, dbPool); err != nil {
+			log.Fatalf("database migration failed: %v", err)
+		}
+	}
+
+	// Hired candidates are created as employees in employee-management
+	employeeClient := employees.NewClient(cfg.EmployeeServiceURL, cfg.EmployeeServiceTimeout)
+	recruitmentService := service.NewRecruitmentService(repository.NewRecruitmentRepository(dbPool), employeeClient)
+
+	handler := handlers.NewRecruitmentHandler(recruitmentService)
+	healthHandler := httpkit.NewHealthHandler("recruitment-service", dbPool)
+
+	router := httpkit.NewRouter()
+
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	v1 := router.Group("/recruitment-service/api/v1")
+	{
+		v1.GET("/health", healthHandler.HealthCheck)
+
+		v1.POST("/postings", handler.CreatePosting)
+		v1.GET("/postings", handler.GetAllPostings)
+		v1.GET("/postings/:id", handler.GetPostingByID)
+		v1.POST("/postings/:id/close", handler.ClosePosting)
+		v1.POST("/postings/:id/candidates", handler.CreateCandidate)
+		v1.GET("/postings/:id/candidates", handler.GetPostingCandidates)
+
+		v1.GET("/candidates/:id", handler.GetCandidateByID)
+		v1.POST("/candidates/:id/stage", handler.MoveCandidate)
+		v1.POST("/candidates/:id/convert", handler.ConvertCandidate)
+	}
+
+	srv := &http.Server{Addr: ":" + cfg.ServerPort, Handler: router}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	log.Printf("Recruitment service running on :%s", cfg.ServerPort)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
@@ -0,0 +1,811 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "email": "josed.amayar@uqvirtual.edu.co"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/candidates/{id}": {
+            "get": {
+                "description": "Retrieves a candidate with their stage history and, once hired, the linked employee id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Get a candidate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Candidate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Candidate",
+                        "schema": {
+                            "$ref": "#/definitions/models.Candidate"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid candidate ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Candidate not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/convert": {
+            "post": {
+                "description": "Creates the employee of a candidate at the OFFER stage in employee-management, links the employee id and moves the candidate to HIRED",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Convert a candidate to an employee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Candidate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Employee data",
+                        "name": "employee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ConvertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Candidate hired",
+                        "schema": {
+                            "$ref": "#/definitions/models.Candidate"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid candidate ID or JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Candidate not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Candidate not at OFFER, already hired, or employee rejected by employee-management",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Employee service unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/stage": {
+            "post": {
+                "description": "Moves a candidate to another interview stage: APPLIED, SCREENING, INTERVIEW (repeatable per round), OFFER, or REJECTED from any open stage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Move a candidate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Candidate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Next stage",
+                        "name": "stage",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Candidate moved",
+                        "schema": {
+                            "$ref": "#/definitions/models.Candidate"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid candidate ID or stage",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Candidate not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Stage not reachable from the current one",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/postings": {
+            "get": {
+                "description": "Retrieves job postings newest first, optionally filtered by status and department",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Postings"
+                ],
+                "summary": "List job postings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "OPEN",
+                            "CLOSED"
+                        ],
+                        "type": "string",
+                        "description": "Posting status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Department",
+                        "name": "department",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job postings",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httpkit.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.JobPosting"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Opens a job posting for a position of a department",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Postings"
+                ],
+                "summary": "Open a job posting",
+                "parameters": [
+                    {
+                        "description": "Job posting data",
+                        "name": "posting",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PostingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Job posting created",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobPosting"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/postings/{id}": {
+            "get": {
+                "description": "Retrieves a job posting by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Postings"
+                ],
+                "summary": "Get a job posting",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job posting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job posting",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobPosting"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job posting ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job posting not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/postings/{id}/candidates": {
+            "get": {
+                "description": "Retrieves the candidates of a job posting in application order, optionally at one stage",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Candidates of a job posting",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job posting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "APPLIED",
+                            "SCREENING",
+                            "INTERVIEW",
+                            "OFFER",
+                            "HIRED",
+                            "REJECTED"
+                        ],
+                        "type": "string",
+                        "description": "Stage",
+                        "name": "stage",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Candidates",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Candidate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job posting ID or stage",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job posting not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a candidate at the APPLIED stage to an open job posting",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Apply to a job posting",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job posting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Candidate data",
+                        "name": "candidate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CandidateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Candidate created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Candidate"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job posting not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Job posting closed or candidate already applied",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/postings/{id}/close": {
+            "post": {
+                "description": "Stops a job posting from accepting candidates. Candidates already in the pipeline can still be hired",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Postings"
+                ],
+                "summary": "Close a job posting",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job posting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job posting closed",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobPosting"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job posting ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job posting not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.CandidateRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "ana.gomez@example.com"
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "Ana"
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Gómez"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+573001234567"
+                }
+            }
+        },
+        "handlers.ConvertRequest": {
+            "type": "object",
+            "properties": {
+                "department": {
+                    "type": "string"
+                },
+                "employeeNumber": {
+                    "type": "string",
+                    "example": "EMP-0042"
+                },
+                "position": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PostingRequest": {
+            "type": "object",
+            "properties": {
+                "department": {
+                    "type": "string",
+                    "example": "Engineering"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string",
+                    "example": "Developer"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Backend Developer"
+                }
+            }
+        },
+        "handlers.StageRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Technical interview passed"
+                },
+                "stage": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Stage"
+                        }
+                    ],
+                    "example": "INTERVIEW"
+                }
+            }
+        },
+        "httpkit.ErrorResponse": {
+            "description": "Standard error response structure",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpkit.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "pagination": {
+                    "$ref": "#/definitions/httpkit.PaginationMeta"
+                }
+            }
+        },
+        "httpkit.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                },
+                "total_records": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Candidate": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "employeeId": {
+                    "description": "EmployeeID links the employee-management record once hired",
+                    "type": "integer"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "hiredAt": {
+                    "type": "string"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StageChange"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "postingId": {
+                    "type": "integer"
+                },
+                "stage": {
+                    "$ref": "#/definitions/models.Stage"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JobPosting": {
+            "type": "object",
+            "properties": {
+                "closedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.PostingStatus"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PostingStatus": {
+            "type": "string",
+            "enum": [
+                "OPEN",
+                "CLOSED"
+            ],
+            "x-enum-varnames": [
+                "PostingOpen",
+                "PostingClosed"
+            ]
+        },
+        "models.Stage": {
+            "type": "string",
+            "enum": [
+                "APPLIED",
+                "SCREENING",
+                "INTERVIEW",
+                "OFFER",
+                "HIRED",
+                "REJECTED"
+            ],
+            "x-enum-varnames": [
+                "StageApplied",
+                "StageScreening",
+                "StageInterview",
+                "StageOffer",
+                "StageHired",
+                "StageRejected"
+            ]
+        },
+        "models.StageChange": {
+            "type": "object",
+            "properties": {
+                "changedAt": {
+                    "type": "string"
+                },
+                "from": {
+                    "$ref": "#/definitions/models.Stage"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "to": {
+                    "$ref": "#/definitions/models.Stage"
+                }
+            }
+        }
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:8084",
+	BasePath:         "/recruitment-service/api/v1",
+	Schemes:          []string{},
+	Title:            "Recruitment Service API",
+	Description:      "Job postings, candidates moving through interview stages and their conversion into employees",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
@@ -0,0 +1,11 @@
+// Package api holds the query parameters of the list endpoints
+package api
+
+import "github.com/Josedzzz/microservices-fp/pkg/httpkit"
+
+// PaginationQuery represents the query parameters of the job posting list
+type PaginationQuery struct {
+	httpkit.PageQuery
+	Status     string `form:"status" binding:"omitempty,oneof=OPEN CLOSED"`
+	Department string `form:"department"`
+}
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS recruitment.job_postings (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	title VARCHAR(255) NOT NULL,
+	department VARCHAR(100) NOT NULL,
+	position VARCHAR(100) NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	closed_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS recruitment.candidates (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	posting_id BIGINT NOT NULL REFERENCES recruitment.job_postings (id) ON DELETE CASCADE,
+	first_name VARCHAR(100) NOT NULL,
+	last_name VARCHAR(100) NOT NULL,
+	email VARCHAR(255) NOT NULL,
+	phone VARCHAR(30) NOT NULL DEFAULT '',
+	stage VARCHAR(20) NOT NULL DEFAULT 'APPLIED',
+	-- Set once the candidate is converted into an employee
+	employee_id BIGINT UNIQUE,
+	hired_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (posting_id, email)
+);
+
+CREATE INDEX IF NOT EXISTS candidates_posting_stage_idx ON recruitment.candidates (posting_id, stage);
+
+CREATE TABLE IF NOT EXISTS recruitment.stage_changes (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	candidate_id BIGINT NOT NULL REFERENCES recruitment.candidates (id) ON DELETE CASCADE,
+	from_stage VARCHAR(20),
+	to_stage VARCHAR(20) NOT NULL,
+	note TEXT NOT NULL DEFAULT '',
+	changed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS stage_changes_candidate_idx ON recruitment.stage_changes (candidate_id, id);
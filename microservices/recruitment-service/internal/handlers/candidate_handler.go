@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	"recruitment-service/internal/models"
+	"recruitment-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// CandidateRequest is the payload of an application
+type CandidateRequest struct {
+	FirstName string `json:"firstName" example:"Ana"`
+	LastName  string `json:"lastName" example:"Gómez"`
+	Email     string `json:"email" example:"ana.gomez@example.com"`
+	Phone     string `json:"phone" example:"+573001234567"`
+}
+
+// StageRequest is the payload to move a candidate through the pipeline
+type StageRequest struct {
+	Stage models.Stage `json:"stage" example:"INTERVIEW"`
+	Note  string       `json:"note" example:"Technical interview passed"`
+}
+
+// ConvertRequest is the payload to hire a candidate. Position and
+// department default to the ones of the job posting
+type ConvertRequest struct {
+	EmployeeNumber string `json:"employeeNumber" example:"EMP-0042"`
+	Position       string `json:"position"`
+	Department     string `json:"department"`
+}
+
+// CreateCandidate godoc
+//
+//	@Summary		Apply to a job posting
+//	@Description	Adds a candidate at the APPLIED stage to an open job posting
+//	@Tags			Candidates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Job posting ID"
+//	@Param			candidate	body		CandidateRequest	true	"Candidate data"
+//	@Success		201			{object}	models.Candidate	"Candidate created"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid JSON format or validation failed"
+//	@Failure		404			{object}	httpkit.ErrorResponse	"Job posting not found"
+//	@Failure		409			{object}	httpkit.ErrorResponse	"Job posting closed or candidate already applied"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/postings/{id}/candidates [post]
+func (h *RecruitmentHandler) CreateCandidate(c *gin.Context) {
+	postingID, ok := pathID(c, "Invalid job posting ID")
+	if !ok {
+		return
+	}
+
+	var req CandidateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	candidate := models.Candidate{
+		PostingID: postingID,
+		FirstName: strings.TrimSpace(req.FirstName),
+		LastName:  strings.TrimSpace(req.LastName),
+		Email:     strings.ToLower(strings.TrimSpace(req.Email)),
+		Phone:     strings.TrimSpace(req.Phone),
+	}
+	if candidate.FirstName == "" || candidate.LastName == "" {
+		httpkit.BadRequest(c, "First and last name are required")
+		return
+	}
+	if addr, err := mail.ParseAddress(candidate.Email); err != nil || addr.Address != candidate.Email {
+		httpkit.BadRequest(c, "Invalid email")
+		return
+	}
+
+	if err := h.service.Apply(c.Request.Context(), &candidate); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrPostingNotFound):
+			httpkit.NotFound(c, "Job posting not found")
+		case errors.Is(err, repository.ErrPostingClosed), errors.Is(err, repository.ErrCandidateExists):
+			httpkit.Error(c, http.StatusConflict, err.Error())
+		default:
+			httpkit.InternalServerError(c, "Failed to create candidate")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, candidate)
+}
+
+// GetPostingCandidates godoc
+//
+//	@Summary		Candidates of a job posting
+//	@Description	Retrieves the candidates of a job posting in application order, optionally at one stage
+//	@Tags			Candidates
+//	@Produce		json
+//	@Param			id		path		int					true	"Job posting ID"
+//	@Param			stage	query		string				false	"Stage"	Enums(APPLIED, SCREENING, INTERVIEW, OFFER, HIRED, REJECTED)
+//	@Success		200		{array}		models.Candidate	"Candidates"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid job posting ID or stage"
+//	@Failure		404		{object}	httpkit.ErrorResponse	"Job posting not found"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/postings/{id}/candidates [get]
+func (h *RecruitmentHandler) GetPostingCandidates(c *gin.Context) {
+	postingID, ok := pathID(c, "Invalid job posting ID")
+	if !ok {
+		return
+	}
+
+	stage := models.Stage(c.Query("stage"))
+	if stage != "" && !stage.Valid() {
+		httpkit.BadRequest(c, "Invalid stage")
+		return
+	}
+
+	candidates, err := h.service.FindCandidates(c.Request.Context(), postingID, stage)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrPostingNotFound):
+			httpkit.NotFound(c, "Job posting not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve candidates")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, candidates)
+}
+
+// GetCandidateByID godoc
+//
+//	@Summary		Get a candidate
+//	@Description	Retrieves a candidate with their stage history and, once hired, the linked employee id
+//	@Tags			Candidates
+//	@Produce		json
+//	@Param			id	path		int					true	"Candidate ID"
+//	@Success		200	{object}	models.Candidate	"Candidate"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid candidate ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Candidate not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/candidates/{id} [get]
+func (h *RecruitmentHandler) GetCandidateByID(c *gin.Context) {
+	id, ok := pathID(c, "Invalid candidate ID")
+	if !ok {
+		return
+	}
+
+	candidate, err := h.service.FindCandidate(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCandidateNotFound):
+			httpkit.NotFound(c, "Candidate not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve candidate")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, candidate)
+}
+
+// MoveCandidate godoc
+//
+//	@Summary		Move a candidate
+//	@Description	Moves a candidate to another interview stage: APPLIED, SCREENING, INTERVIEW (repeatable per round), OFFER, or REJECTED from any open stage
+//	@Tags			Candidates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"Candidate ID"
+//	@Param			stage	body		StageRequest		true	"Next stage"
+//	@Success		200		{object}	models.Candidate	"Candidate moved"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid candidate ID or stage"
+//	@Failure		404		{object}	httpkit.ErrorResponse	"Candidate not found"
+//	@Failure		409		{object}	httpkit.ErrorResponse	"Stage not reachable from the current one"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/candidates/{id}/stage [post]
+func (h *RecruitmentHandler) MoveCandidate(c *gin.Context) {
+	id, ok := pathID(c, "Invalid candidate ID")
+	if !ok {
+		return
+	}
+
+	var req StageRequest
+	if err := c.ShouldBindJSON(&req); err != nil || !req.Stage.Valid() {
+		httpkit.BadRequest(c, "Invalid stage")
+		return
+	}
+
+	candidate, err := h.service.MoveCandidate(c.Request.Context(), id, req.Stage, strings.TrimSpace(req.Note))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCandidateNotFound):
+			httpkit.NotFound(c, "Candidate not found")
+		case errors.Is(err, repository.ErrInvalidTransition):
+			httpkit.Error(c, http.StatusConflict, err.Error())
+		default:
+			httpkit.InternalServerError(c, "Failed to move candidate")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, candidate)
+}
+
+// ConvertCandidate godoc
+//
+//	@Summary		Convert a candidate to an employee
+//	@Description	Creates the employee of a candidate at the OFFER stage in employee-management, links the employee id and moves the candidate to HIRED
+//	@Tags			Candidates
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Candidate ID"
+//	@Param			employee	body		ConvertRequest		true	"Employee data"
+//	@Success		201			{object}	models.Candidate	"Candidate hired"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid candidate ID or JSON format"
+//	@Failure		404			{object}	httpkit.ErrorResponse	"Candidate not found"
+//	@Failure		409			{object}	httpkit.ErrorResponse	"Candidate not at OFFER, already hired, or employee rejected by employee-management"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Failure		503			{object}	httpkit.ErrorResponse	"Employee service unavailable"
+//	@Router			/candidates/{id}/convert [post]
+func (h *RecruitmentHandler) ConvertCandidate(c *gin.Context) {
+	id, ok := pathID(c, "Invalid candidate ID")
+	if !ok {
+		return
+	}
+
+	var req ConvertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+	if strings.TrimSpace(req.EmployeeNumber) == "" {
+		httpkit.BadRequest(c, "Employee number is required")
+		return
+	}
+
+	candidate, err := h.service.Convert(
+		c.Request.Context(),
+		id,
+		strings.TrimSpace(req.EmployeeNumber),
+		strings.TrimSpace(req.Position),
+		strings.TrimSpace(req.Department),
+	)
+	if err != nil {
+		var rejected *employees.RejectedError
+		switch {
+		case errors.As(err, &rejected):
+			httpkit.Error(c, http.StatusConflict, "Employee service rejected the employee: "+rejected.Message)
+		case errors.Is(err, repository.ErrCandidateNotFound):
+			httpkit.NotFound(c, "Candidate not found")
+		case errors.Is(err, repository.ErrCandidateConverted), errors.Is(err, repository.ErrInvalidTransition):
+			httpkit.Error(c, http.StatusConflict, err.Error())
+		case errors.Is(err, employees.ErrUnavailable):
+			httpkit.Error(c, http.StatusServiceUnavailable, "Employee service unavailable")
+		default:
+			httpkit.InternalServerError(c, "Failed to convert candidate")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, candidate)
+}
@@ -0,0 +1,178 @@
+// Package handlers exposes the recruitment service over HTTP
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"recruitment-service/internal/api"
+	"recruitment-service/internal/models"
+	"recruitment-service/internal/repository"
+	"recruitment-service/internal/service"
+
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// RecruitmentHandler handles HTTP requests for job postings and candidates
+type RecruitmentHandler struct {
+	service *service.RecruitmentService
+}
+
+// NewRecruitmentHandler creates a new RecruitmentHandler instance
+func NewRecruitmentHandler(s *service.RecruitmentService) *RecruitmentHandler {
+	return &RecruitmentHandler{service: s}
+}
+
+// PostingRequest is the payload to open a job posting
+type PostingRequest struct {
+	Title       string `json:"title" example:"Backend Developer"`
+	Department  string `json:"department" example:"Engineering"`
+	Position    string `json:"position" example:"Developer"`
+	Description string `json:"description"`
+}
+
+// CreatePosting godoc
+//
+//	@Summary		Open a job posting
+//	@Description	Opens a job posting for a position of a department
+//	@Tags			Postings
+//	@Accept			json
+//	@Produce		json
+//	@Param			posting	body		PostingRequest		true	"Job posting data"
+//	@Success		201		{object}	models.JobPosting	"Job posting created"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid JSON format or validation failed"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/postings [post]
+func (h *RecruitmentHandler) CreatePosting(c *gin.Context) {
+	var req PostingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	posting := models.JobPosting{
+		Title:       strings.TrimSpace(req.Title),
+		Department:  strings.TrimSpace(req.Department),
+		Position:    strings.TrimSpace(req.Position),
+		Description: strings.TrimSpace(req.Description),
+	}
+	if posting.Title == "" || posting.Department == "" || posting.Position == "" {
+		httpkit.BadRequest(c, "Title, department and position are required")
+		return
+	}
+
+	if err := h.service.CreatePosting(c.Request.Context(), &posting); err != nil {
+		httpkit.InternalServerError(c, "Failed to create job posting")
+		return
+	}
+
+	c.JSON(http.StatusCreated, posting)
+}
+
+// GetAllPostings godoc
+//
+//	@Summary		List job postings
+//	@Description	Retrieves job postings newest first, optionally filtered by status and department
+//	@Tags			Postings
+//	@Produce		json
+//	@Param			page		query		int					false	"Page number"	default(1)
+//	@Param			page_size	query		int					false	"Page size"		default(20)
+//	@Param			status		query		string				false	"Posting status"	Enums(OPEN, CLOSED)
+//	@Param			department	query		string				false	"Department"
+//	@Success		200			{object}	httpkit.PaginatedResponse{data=[]models.JobPosting}	"Job postings"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid query parameters"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/postings [get]
+func (h *RecruitmentHandler) GetAllPostings(c *gin.Context) {
+	var query api.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpkit.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	query.Normalize()
+
+	postings, total, err := h.service.FindPostings(c.Request.Context(), models.PostingStatus(query.Status), query.Department, query.Page, query.PageSize)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve job postings")
+		return
+	}
+
+	c.JSON(http.StatusOK, httpkit.Paginate(postings, query.PageQuery, total))
+}
+
+// GetPostingByID godoc
+//
+//	@Summary		Get a job posting
+//	@Description	Retrieves a job posting by its ID
+//	@Tags			Postings
+//	@Produce		json
+//	@Param			id	path		int					true	"Job posting ID"
+//	@Success		200	{object}	models.JobPosting	"Job posting"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid job posting ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Job posting not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/postings/{id} [get]
+func (h *RecruitmentHandler) GetPostingByID(c *gin.Context) {
+	id, ok := pathID(c, "Invalid job posting ID")
+	if !ok {
+		return
+	}
+
+	posting, err := h.service.FindPosting(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrPostingNotFound):
+			httpkit.NotFound(c, "Job posting not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve job posting")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, posting)
+}
+
+// ClosePosting godoc
+//
+//	@Summary		Close a job posting
+//	@Description	Stops a job posting from accepting candidates. Candidates already in the pipeline can still be hired
+//	@Tags			Postings
+//	@Produce		json
+//	@Param			id	path		int					true	"Job posting ID"
+//	@Success		200	{object}	models.JobPosting	"Job posting closed"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid job posting ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Job posting not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/postings/{id}/close [post]
+func (h *RecruitmentHandler) ClosePosting(c *gin.Context) {
+	id, ok := pathID(c, "Invalid job posting ID")
+	if !ok {
+		return
+	}
+
+	posting, err := h.service.ClosePosting(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrPostingNotFound):
+			httpkit.NotFound(c, "Job posting not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to close job posting")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, posting)
+}
+
+// pathID parses the positive id path parameter, answering 400 otherwise
+func pathID(c *gin.Context, message string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		httpkit.BadRequest(c, message)
+		return 0, false
+	}
+	return id, true
+}
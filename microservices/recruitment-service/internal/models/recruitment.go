@@ -0,0 +1,90 @@
+// Package models define the core data structures of the recruitment service
+package models
+
+import "time"
+
+// PostingStatus tells whether a job posting accepts candidates
+type PostingStatus string
+
+const (
+	PostingOpen   PostingStatus = "OPEN"
+	PostingClosed PostingStatus = "CLOSED"
+)
+
+// JobPosting is an open position candidates apply to
+type JobPosting struct {
+	ID          int64         `json:"id"`
+	Title       string        `json:"title"`
+	Department  string        `json:"department"`
+	Position    string        `json:"position"`
+	Description string        `json:"description"`
+	Status      PostingStatus `json:"status"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	ClosedAt    *time.Time    `json:"closedAt,omitempty"`
+}
+
+// Stage is the step of the hiring pipeline a candidate is at
+type Stage string
+
+const (
+	StageApplied   Stage = "APPLIED"
+	StageScreening Stage = "SCREENING"
+	StageInterview Stage = "INTERVIEW"
+	StageOffer     Stage = "OFFER"
+	StageHired     Stage = "HIRED"
+	StageRejected  Stage = "REJECTED"
+)
+
+// stageTransitions lists the stages each stage can move to. HIRED is only
+// reached by converting the candidate into an employee
+var stageTransitions = map[Stage][]Stage{
+	StageApplied:   {StageScreening, StageInterview, StageRejected},
+	StageScreening: {StageInterview, StageRejected},
+	StageInterview: {StageInterview, StageOffer, StageRejected},
+	StageOffer:     {StageHired, StageRejected},
+}
+
+// Valid reports whether s is a known stage
+func (s Stage) Valid() bool {
+	switch s {
+	case StageApplied, StageScreening, StageInterview, StageOffer, StageHired, StageRejected:
+		return true
+	}
+	return false
+}
+
+// CanMoveTo reports whether a candidate at s may move to next. INTERVIEW
+// may repeat, one change per interview round
+func (s Stage) CanMoveTo(next Stage) bool {
+	for _, allowed := range stageTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Candidate is a person applying to a job posting
+type Candidate struct {
+	ID        int64  `json:"id"`
+	PostingID int64  `json:"postingId"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone,omitempty"`
+	Stage     Stage  `json:"stage"`
+	// EmployeeID links the employee-management record once hired
+	EmployeeID *int64        `json:"employeeId,omitempty"`
+	HiredAt    *time.Time    `json:"hiredAt,omitempty"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	UpdatedAt  time.Time     `json:"updatedAt"`
+	History    []StageChange `json:"history,omitempty"`
+}
+
+// StageChange records a move of a candidate through the pipeline
+type StageChange struct {
+	From      Stage     `json:"from,omitempty"`
+	To        Stage     `json:"to"`
+	Note      string    `json:"note,omitempty"`
+	ChangedAt time.Time `json:"changedAt"`
+}
@@ -0,0 +1,337 @@
+// Package repository implements the data access layer of the recruitment
+// service
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"recruitment-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Errors returned by RecruitmentRepository
+var (
+	ErrPostingNotFound    = errors.New("job posting not found")
+	ErrPostingClosed      = errors.New("job posting is closed")
+	ErrCandidateNotFound  = errors.New("candidate not found")
+	ErrCandidateExists    = errors.New("candidate already applied to the job posting")
+	ErrInvalidTransition  = errors.New("candidate cannot move to that stage")
+	ErrCandidateConverted = errors.New("candidate is already an employee")
+)
+
+// RecruitmentRepository defines the interface for recruitment data operations
+type RecruitmentRepository interface {
+	CreatePosting(ctx context.Context, p *models.JobPosting) error
+	FindPosting(ctx context.Context, id int64) (*models.JobPosting, error)
+	FindPostings(ctx context.Context, status models.PostingStatus, department string, limit, offset int) ([]models.JobPosting, int, error)
+	ClosePosting(ctx context.Context, id int64) error
+
+	// CreateCandidate adds an APPLIED candidate to an open posting
+	CreateCandidate(ctx context.Context, c *models.Candidate) error
+	// FindCandidate returns the candidate with their stage history
+	FindCandidate(ctx context.Context, id int64) (*models.Candidate, error)
+	FindCandidates(ctx context.Context, postingID int64, stage models.Stage) ([]models.Candidate, error)
+	MoveCandidate(ctx context.Context, id int64, to models.Stage, note string) error
+
+	// Convert locks an OFFER candidate, calls create to add the employee
+	// and links the returned employee id, moving them to HIRED. The lock
+	// is held during create so a candidate is converted at most once
+	Convert(ctx context.Context, id int64, create func(c *models.Candidate) (int64, error)) error
+}
+
+// recruitmentRepository is the postgresql implementation of RecruitmentRepository
+type recruitmentRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewRecruitmentRepository creates a new instance of RecruitmentRepository
+func NewRecruitmentRepository(db *pgxpool.Pool) RecruitmentRepository {
+	return &recruitmentRepository{db: db}
+}
+
+// postingColumns are the columns scanned by scanPosting
+const postingColumns = `id, title, department, position, description, status, created_at, closed_at`
+
+// scanPosting scans a row selected with postingColumns
+func scanPosting(row pgx.Row) (models.JobPosting, error) {
+	var p models.JobPosting
+	err := row.Scan(&p.ID, &p.Title, &p.Department, &p.Position, &p.Description, &p.Status, &p.CreatedAt, &p.ClosedAt)
+	return p, err
+}
+
+// candidateColumns are the columns scanned by scanCandidate
+const candidateColumns = `
+        id, posting_id, first_name, last_name, email, phone, stage, employee_id, hired_at, created_at, updated_at
+    `
+
+// scanCandidate scans a row selected with candidateColumns
+func scanCandidate(row pgx.Row) (models.Candidate, error) {
+	var c models.Candidate
+	err := row.Scan(&c.ID, &c.PostingID, &c.FirstName, &c.LastName, &c.Email, &c.Phone, &c.Stage,
+		&c.EmployeeID, &c.HiredAt, &c.CreatedAt, &c.UpdatedAt)
+	return c, err
+}
+
+// CreatePosting adds an open job posting
+func (r *recruitmentRepository) CreatePosting(ctx context.Context, p *models.JobPosting) error {
+	query := `
+        INSERT INTO recruitment.job_postings (title, department, position, description)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, status, created_at
+    `
+
+	err := r.db.QueryRow(ctx, query, p.Title, p.Department, p.Position, p.Description).Scan(&p.ID, &p.Status, &p.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create job posting: %w", err)
+	}
+
+	return nil
+}
+
+// FindPosting retrieves a job posting
+func (r *recruitmentRepository) FindPosting(ctx context.Context, id int64) (*models.JobPosting, error) {
+	p, err := scanPosting(r.db.QueryRow(ctx, `SELECT `+postingColumns+` FROM recruitment.job_postings WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPostingNotFound
+		}
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// FindPostings retrieves a page of postings newest first with the total count
+func (r *recruitmentRepository) FindPostings(ctx context.Context, status models.PostingStatus, department string, limit, offset int) ([]models.JobPosting, int, error) {
+	where := `WHERE ($1 = '' OR status = $1) AND ($2 = '' OR department = $2)`
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM recruitment.job_postings `+where, status, department).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count job postings: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+postingColumns+` FROM recruitment.job_postings `+where+`
+        ORDER BY created_at DESC, id DESC
+        LIMIT $3 OFFSET $4`, status, department, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query job postings: %w", err)
+	}
+	defer rows.Close()
+
+	postings := []models.JobPosting{}
+	for rows.Next() {
+		p, err := scanPosting(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan job posting row: %w", err)
+		}
+		postings = append(postings, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating job posting rows: %w", err)
+	}
+
+	return postings, total, nil
+}
+
+// ClosePosting stops a posting from accepting candidates, closing a closed
+// posting is a no-op
+func (r *recruitmentRepository) ClosePosting(ctx context.Context, id int64) error {
+	result, err := r.db.Exec(ctx, `
+        UPDATE recruitment.job_postings
+        SET status = 'CLOSED', closed_at = COALESCE(closed_at, CURRENT_TIMESTAMP)
+        WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to close job posting: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrPostingNotFound
+	}
+
+	return nil
+}
+
+// CreateCandidate inserts the candidate and their first stage change
+func (r *recruitmentRepository) CreateCandidate(ctx context.Context, c *models.Candidate) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var status models.PostingStatus
+		err := tx.QueryRow(ctx, `SELECT status FROM recruitment.job_postings WHERE id = $1 FOR SHARE`, c.PostingID).Scan(&status)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrPostingNotFound
+			}
+			return fmt.Errorf("failed to lock job posting: %w", err)
+		}
+		if status != models.PostingOpen {
+			return ErrPostingClosed
+		}
+
+		err = tx.QueryRow(ctx, `
+            INSERT INTO recruitment.candidates (posting_id, first_name, last_name, email, phone)
+            VALUES ($1, $2, $3, $4, $5)
+            RETURNING id, stage, created_at, updated_at`,
+			c.PostingID, c.FirstName, c.LastName, c.Email, c.Phone).Scan(&c.ID, &c.Stage, &c.CreatedAt, &c.UpdatedAt)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return ErrCandidateExists
+			}
+			return fmt.Errorf("failed to create candidate: %w", err)
+		}
+
+		return addStageChange(ctx, tx, c.ID, "", c.Stage, "")
+	})
+}
+
+// FindCandidate retrieves a candidate and their stage history oldest first
+func (r *recruitmentRepository) FindCandidate(ctx context.Context, id int64) (*models.Candidate, error) {
+	c, err := scanCandidate(r.db.QueryRow(ctx, `SELECT `+candidateColumns+` FROM recruitment.candidates WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCandidateNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+        SELECT COALESCE(from_stage, ''), to_stage, note, changed_at
+        FROM recruitment.stage_changes
+        WHERE candidate_id = $1
+        ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stage changes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change models.StageChange
+		if err := rows.Scan(&change.From, &change.To, &change.Note, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan stage change row: %w", err)
+		}
+		c.History = append(c.History, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stage change rows: %w", err)
+	}
+
+	return &c, nil
+}
+
+// FindCandidates retrieves the candidates of a posting, optionally at one stage
+func (r *recruitmentRepository) FindCandidates(ctx context.Context, postingID int64, stage models.Stage) ([]models.Candidate, error) {
+	if _, err := r.FindPosting(ctx, postingID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+candidateColumns+`
+        FROM recruitment.candidates
+        WHERE posting_id = $1 AND ($2 = '' OR stage = $2)
+        ORDER BY created_at, id`, postingID, stage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := []models.Candidate{}
+	for rows.Next() {
+		c, err := scanCandidate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan candidate row: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating candidate rows: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// MoveCandidate changes the stage of the candidate if the pipeline allows it
+func (r *recruitmentRepository) MoveCandidate(ctx context.Context, id int64, to models.Stage, note string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		from, err := lockCandidateStage(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// HIRED is reserved for Convert, which links the employee
+		if to == models.StageHired || !from.CanMoveTo(to) {
+			return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE recruitment.candidates SET stage = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`, id, to); err != nil {
+			return fmt.Errorf("failed to update candidate stage: %w", err)
+		}
+
+		return addStageChange(ctx, tx, id, from, to, note)
+	})
+}
+
+// Convert links the employee created by create to the candidate
+func (r *recruitmentRepository) Convert(ctx context.Context, id int64, create func(c *models.Candidate) (int64, error)) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		c, err := scanCandidate(tx.QueryRow(ctx, `SELECT `+candidateColumns+` FROM recruitment.candidates WHERE id = $1 FOR UPDATE`, id))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrCandidateNotFound
+			}
+			return fmt.Errorf("failed to lock candidate: %w", err)
+		}
+
+		switch {
+		case c.EmployeeID != nil:
+			return ErrCandidateConverted
+		case !c.Stage.CanMoveTo(models.StageHired):
+			return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, c.Stage, models.StageHired)
+		}
+
+		employeeID, err := create(&c)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+            UPDATE recruitment.candidates
+            SET stage = 'HIRED', employee_id = $2, hired_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1`, id, employeeID)
+		if err != nil {
+			return fmt.Errorf("failed to link employee %d: %w", employeeID, err)
+		}
+
+		return addStageChange(ctx, tx, id, c.Stage, models.StageHired, fmt.Sprintf("converted to employee %d", employeeID))
+	})
+}
+
+// lockCandidateStage locks the candidate row and returns its stage
+func lockCandidateStage(ctx context.Context, tx pgx.Tx, id int64) (models.Stage, error) {
+	var stage models.Stage
+	err := tx.QueryRow(ctx, `SELECT stage FROM recruitment.candidates WHERE id = $1 FOR UPDATE`, id).Scan(&stage)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrCandidateNotFound
+		}
+		return "", fmt.Errorf("failed to lock candidate: %w", err)
+	}
+	return stage, nil
+}
+
+// addStageChange appends to the stage history, from is empty for the
+// first stage
+func addStageChange(ctx context.Context, tx pgx.Tx, candidateID int64, from, to models.Stage, note string) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO recruitment.stage_changes (candidate_id, from_stage, to_stage, note)
+        VALUES ($1, NULLIF($2, ''), $3, $4)`, candidateID, from, to, note)
+	if err != nil {
+		return fmt.Errorf("failed to record stage change: %w", err)
+	}
+	return nil
+}
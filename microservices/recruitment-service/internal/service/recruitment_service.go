@@ -0,0 +1,105 @@
+// Package service contains the business logic of the recruitment service
+package service
+
+import (
+	"context"
+
+	"recruitment-service/internal/models"
+	"recruitment-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+)
+
+// RecruitmentService manages job postings and candidates, and hires
+// candidates into employee-management
+type RecruitmentService struct {
+	repo      repository.RecruitmentRepository
+	employees *employees.Client
+}
+
+// NewRecruitmentService creates a new RecruitmentService instance
+func NewRecruitmentService(repo repository.RecruitmentRepository, employeeClient *employees.Client) *RecruitmentService {
+	return &RecruitmentService{repo: repo, employees: employeeClient}
+}
+
+// CreatePosting adds an open job posting
+func (s *RecruitmentService) CreatePosting(ctx context.Context, p *models.JobPosting) error {
+	return s.repo.CreatePosting(ctx, p)
+}
+
+// FindPosting retrieves a job posting
+func (s *RecruitmentService) FindPosting(ctx context.Context, id int64) (*models.JobPosting, error) {
+	return s.repo.FindPosting(ctx, id)
+}
+
+// FindPostings retrieves a page of job postings
+func (s *RecruitmentService) FindPostings(ctx context.Context, status models.PostingStatus, department string, page, pageSize int) ([]models.JobPosting, int, error) {
+	return s.repo.FindPostings(ctx, status, department, pageSize, (page-1)*pageSize)
+}
+
+// ClosePosting stops a job posting from accepting candidates
+func (s *RecruitmentService) ClosePosting(ctx context.Context, id int64) (*models.JobPosting, error) {
+	if err := s.repo.ClosePosting(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.FindPosting(ctx, id)
+}
+
+// Apply adds a candidate to an open job posting
+func (s *RecruitmentService) Apply(ctx context.Context, c *models.Candidate) error {
+	return s.repo.CreateCandidate(ctx, c)
+}
+
+// FindCandidate retrieves a candidate with their stage history
+func (s *RecruitmentService) FindCandidate(ctx context.Context, id int64) (*models.Candidate, error) {
+	return s.repo.FindCandidate(ctx, id)
+}
+
+// FindCandidates retrieves the candidates of a job posting
+func (s *RecruitmentService) FindCandidates(ctx context.Context, postingID int64, stage models.Stage) ([]models.Candidate, error) {
+	return s.repo.FindCandidates(ctx, postingID, stage)
+}
+
+// MoveCandidate moves a candidate to the next stage of the pipeline
+func (s *RecruitmentService) MoveCandidate(ctx context.Context, id int64, to models.Stage, note string) (*models.Candidate, error) {
+	if err := s.repo.MoveCandidate(ctx, id, to, note); err != nil {
+		return nil, err
+	}
+	return s.repo.FindCandidate(ctx, id)
+}
+
+// Convert creates the employee of a candidate with an accepted offer in
+// employee-management and links both records. The position and department
+// come from the job posting unless given
+func (s *RecruitmentService) Convert(ctx context.Context, id int64, employeeNumber, position, department string) (*models.Candidate, error) {
+	err := s.repo.Convert(ctx, id, func(c *models.Candidate) (int64, error) {
+		posting, err := s.repo.FindPosting(ctx, c.PostingID)
+		if err != nil {
+			return 0, err
+		}
+		if position == "" {
+			position = posting.Position
+		}
+		if department == "" {
+			department = posting.Department
+		}
+
+		employee, err := s.employees.Create(ctx, employees.NewEmployee{
+			FirstName:      c.FirstName,
+			LastName:       c.LastName,
+			Email:          c.Email,
+			EmployeeNumber: employeeNumber,
+			Position:       position,
+			Department:     department,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return employee.ID, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindCandidate(ctx, id)
+}
@@ -0,0 +1,96 @@
+package main
+
+//	@title			Saga Service API
+//	@version		1.0
+//	@description	Orchestrates workflows spanning several services, like offboarding an employee, as sagas with compensation and persisted state
+//	@termsOfService	http://swagger.io/terms/
+
+//	@contact.name	API Support
+//	@contact.email	josed.amayar@uqvirtual.edu.co
+
+//	@host		localhost:8091
+//	@BasePath	/saga-service/api/v1
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"saga-service/internal/config"
+	"saga-service/internal/db"
+	"saga-service/internal/handlers"
+	"saga-service/internal/remote"
+	"saga-service/internal/repository"
+	"saga-service/internal/saga"
+	"saga-service/internal/service"
+
+	_ "saga-service/docs" // Swagger docs
+
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+func main() {
+	cfg := config.Load()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbPool := db.NewPostgresPool(cfg)
+	defer dbPool.Close()
+
+	if cfg.MigrateOnStartup {
+		if err := db.Migrate(ctx, dbPool); err != nil {
+			log.Fatalf("database migration failed: %v", err)
+		}
+	}
+
+	repo := repository.NewSagaRepository(dbPool)
+
+	// Steps call the services directly, not through the gateway
+	client := remote.NewClient(cfg.StepTimeout)
+	termination := saga.Termination(client, saga.Services{
+		Employees:     cfg.EmployeeServiceURL,
+		Assets:        cfg.AssetServiceURL,
+		Notifications: cfg.NotificationServiceURL,
+		Payroll:       cfg.PayrollServiceURL,
+	})
+
+	// Advances new sagas and resumes the ones a restart left half way
+	orchestrator := saga.NewOrchestrator(repo, []saga.Definition{termination},
+		cfg.StepTimeout, cfg.SagaPollInterval, cfg.SagaLease, cfg.SagaStepMaxAttempts, cfg.SagaStepMaxBackoff)
+	go orchestrator.Run(ctx)
+
+	sagaService := service.NewSagaService(repo, orchestrator)
+
+	handler := handlers.NewSagaHandler(sagaService)
+	healthHandler := httpkit.NewHealthHandler("saga-service", dbPool)
+
+	router := httpkit.NewRouter()
+
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	v1 := router.Group("/saga-service/api/v1")
+	{
+		v1.GET("/health", healthHandler.HealthCheck)
+
+		v1.POST("/sagas/termination", handler.StartTermination)
+		v1.GET("/sagas", handler.GetAllSagas)
+		v1.GET("/sagas/:id", handler.GetSagaByID)
+	}
+
+	srv := &http.Server{Addr: ":" + cfg.ServerPort, Handler: router}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	log.Printf("Saga service running on :%s", cfg.ServerPort)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
@@ -0,0 +1,430 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "email": "josed.amayar@uqvirtual.edu.co"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/sagas": {
+            "get": {
+                "description": "Retrieves the sagas without their steps, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sagas"
+                ],
+                "summary": "List sagas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "termination"
+                        ],
+                        "type": "string",
+                        "description": "Saga type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "RUNNING",
+                            "COMPLETED",
+                            "COMPENSATING",
+                            "COMPENSATED",
+                            "FAILED"
+                        ],
+                        "type": "string",
+                        "description": "Saga status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Employee id",
+                        "name": "employeeId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sagas",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/httpkit.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Saga"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sagas/termination": {
+            "post": {
+                "description": "Starts a termination saga: retire the employee in employee-management, open their asset reclaim checklist, stop their payroll and notify HR. Runs in the background, poll GET /sagas/{id} for its status. When a step fails for good the done steps are undone in reverse order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sagas"
+                ],
+                "summary": "Offboard an employee",
+                "parameters": [
+                    {
+                        "description": "Employee to offboard",
+                        "name": "termination",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TerminationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Saga started",
+                        "schema": {
+                            "$ref": "#/definitions/models.Saga"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format, employee ID or reason",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A termination of the employee is already in flight",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sagas/{id}": {
+            "get": {
+                "description": "Retrieves a saga with the status, attempts and error of each step",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sagas"
+                ],
+                "summary": "Get a saga",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saga ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saga",
+                        "schema": {
+                            "$ref": "#/definitions/models.Saga"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid saga ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Saga not found",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httpkit.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.TerminationRequest": {
+            "type": "object",
+            "properties": {
+                "employeeId": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "End of contract"
+                }
+            }
+        },
+        "httpkit.ErrorResponse": {
+            "description": "Standard error response structure",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpkit.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "pagination": {
+                    "$ref": "#/definitions/httpkit.PaginationMeta"
+                }
+            }
+        },
+        "httpkit.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                },
+                "total_records": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Saga": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "Data holds what the steps recorded for later steps and compensations",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "employeeId": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "description": "FinishedAt is set once the saga is COMPLETED, COMPENSATED or FAILED",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f1c2a8e-7b1d-4c55-9a4e-0c2f8d1e6b7a"
+                },
+                "nextAttemptAt": {
+                    "description": "NextAttemptAt is when the orchestrator picks the saga up again",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "End of contract"
+                },
+                "requestedBy": {
+                    "type": "string"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SagaStatus"
+                        }
+                    ],
+                    "example": "RUNNING"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Step"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "termination"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SagaStatus": {
+            "type": "string",
+            "enum": [
+                "RUNNING",
+                "COMPLETED",
+                "COMPENSATING",
+                "COMPENSATED",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
+                "SagaRunning",
+                "SagaCompleted",
+                "SagaCompensating",
+                "SagaCompensated",
+                "SagaFailed"
+            ]
+        },
+        "models.Step": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "compensatedAt": {
+                    "type": "string"
+                },
+                "compensationAttempts": {
+                    "description": "CompensationAttempts counts the tries to undo the step",
+                    "type": "integer",
+                    "example": 0
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "retire-employee"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.StepStatus"
+                        }
+                    ],
+                    "example": "DONE"
+                }
+            }
+        },
+        "models.StepStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "DONE",
+                "SKIPPED",
+                "FAILED",
+                "COMPENSATED",
+                "COMPENSATION_FAILED"
+            ],
+            "x-enum-varnames": [
+                "StepPending",
+                "StepDone",
+                "StepSkipped",
+                "StepFailed",
+                "StepCompensated",
+                "StepCompensationFailed"
+            ]
+        }
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:8091",
+	BasePath:         "/saga-service/api/v1",
+	Schemes:          []string{},
+	Title:            "Saga Service API",
+	Description:      "Orchestrates workflows spanning several services, like offboarding an employee, as sagas with compensation and persisted state",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
@@ -0,0 +1,13 @@
+// Package api holds the query parameters of the list endpoints
+package api
+
+import "github.com/Josedzzz/microservices-fp/pkg/httpkit"
+
+// PaginationQuery represents the query parameters of the saga list.
+// Status is checked by the handler
+type PaginationQuery struct {
+	httpkit.PageQuery
+	Type       string `form:"type"`
+	Status     string `form:"status"`
+	EmployeeID int64  `form:"employeeId" binding:"omitempty,min=1"`
+}
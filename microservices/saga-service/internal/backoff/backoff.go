@@ -0,0 +1,17 @@
+// Package backoff computes retry delays
+package backoff
+
+import "time"
+
+// Exponential returns base doubled for every attempt after the first,
+// capped at max. Attempts start at 1
+func Exponential(base, max time.Duration, attempt int) time.Duration {
+	wait := base
+	for i := 1; i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	return wait
+}
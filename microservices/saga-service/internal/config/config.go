@@ -0,0 +1,198 @@
+// Package config loads the saga service configuration from
+// defaults, a YAML file, env variables and CLI flags, in that order
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/Josedzzz/microservices-fp/pkg/envconfig"
+	"github.com/joho/godotenv"
+)
+
+// Config holds configuration loaded from defaults, file, env and flags
+type Config struct {
+	ServerPort string `yaml:"server_port"`
+
+	DBHost     string `yaml:"db_host"`
+	DBPort     string `yaml:"db_port"`
+	DBName     string `yaml:"db_name"`
+	DBUser     string `yaml:"db_user"`
+	DBPassword string `yaml:"db_password"`
+	DBSSLMode  string `yaml:"db_sslmode"`
+	DBMaxConns int    `yaml:"db_max_conns"`
+
+	DBRetryMaxWait time.Duration `yaml:"db_retry_max_wait"`
+
+	MigrateOnStartup bool `yaml:"migrate_on_startup"`
+
+	// Versioned API bases of the services the steps call. An empty
+	// PayrollServiceURL skips the payroll step
+	EmployeeServiceURL     string `yaml:"employee_service_url"`
+	AssetServiceURL        string `yaml:"asset_service_url"`
+	NotificationServiceURL string `yaml:"notification_service_url"`
+	PayrollServiceURL      string `yaml:"payroll_service_url"`
+
+	// StepTimeout bounds one step or compensation, all its calls included
+	StepTimeout time.Duration `yaml:"step_timeout"`
+
+	SagaPollInterval time.Duration `yaml:"saga_poll_interval"`
+	// SagaLease is how long an instance holds a saga it advances, after
+	// which another instance may take it over
+	SagaLease           time.Duration `yaml:"saga_lease"`
+	SagaStepMaxAttempts int           `yaml:"saga_step_max_attempts"`
+	SagaStepMaxBackoff  time.Duration `yaml:"saga_step_max_backoff"`
+}
+
+// options lists every setting that can be overridden by env or flags
+var options = []envconfig.Option[Config]{
+	{Env: "SERVER_PORT", Flag: "port", Usage: "HTTP server port", Set: envconfig.String(func(c *Config) *string { return &c.ServerPort })},
+	{Env: "DB_HOST", Flag: "db-host", Usage: "database host", Set: envconfig.String(func(c *Config) *string { return &c.DBHost })},
+	{Env: "DB_PORT", Flag: "db-port", Usage: "database port", Set: envconfig.String(func(c *Config) *string { return &c.DBPort })},
+	{Env: "DB_NAME", Flag: "db-name", Usage: "database name", Set: envconfig.String(func(c *Config) *string { return &c.DBName })},
+	{Env: "DB_USER", Flag: "db-user", Usage: "database user", Set: envconfig.String(func(c *Config) *string { return &c.DBUser })},
+	{Env: "DB_PASSWORD", Flag: "db-password", Usage: "database password", Set: envconfig.String(func(c *Config) *string { return &c.DBPassword })},
+	{Env: "DB_SSL_MODE", Flag: "db-sslmode", Usage: "database sslmode", Set: envconfig.String(func(c *Config) *string { return &c.DBSSLMode })},
+	{Env: "DB_MAX_CONNS", Flag: "db-max-conns", Usage: "maximum open db connections", Set: envconfig.Int(func(c *Config) *int { return &c.DBMaxConns })},
+	{Env: "DB_RETRY_MAX_WAIT", Flag: "db-retry-max-wait", Usage: "how long to wait for the db at startup", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.DBRetryMaxWait })},
+	{Env: "MIGRATE_ON_STARTUP", Flag: "migrate-on-startup", Usage: "apply pending migrations at startup", Set: envconfig.Bool(func(c *Config) *bool { return &c.MigrateOnStartup })},
+	{Env: "EMPLOYEE_SERVICE_URL", Flag: "employee-service-url", Usage: "employee-management API base url", Set: envconfig.String(func(c *Config) *string { return &c.EmployeeServiceURL })},
+	{Env: "ASSET_SERVICE_URL", Flag: "asset-service-url", Usage: "asset-service API base url", Set: envconfig.String(func(c *Config) *string { return &c.AssetServiceURL })},
+	{Env: "NOTIFICATION_SERVICE_URL", Flag: "notification-service-url", Usage: "notification-service API base url", Set: envconfig.String(func(c *Config) *string { return &c.NotificationServiceURL })},
+	{Env: "PAYROLL_SERVICE_URL", Flag: "payroll-service-url", Usage: "payroll API base url, empty skips the payroll step", Set: envconfig.String(func(c *Config) *string { return &c.PayrollServiceURL })},
+	{Env: "STEP_TIMEOUT", Flag: "step-timeout", Usage: "timeout of one saga step", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.StepTimeout })},
+	{Env: "SAGA_POLL_INTERVAL", Flag: "saga-poll-interval", Usage: "how often to look for due sagas", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.SagaPollInterval })},
+	{Env: "SAGA_LEASE", Flag: "saga-lease", Usage: "how long an instance holds a saga", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.SagaLease })},
+	{Env: "SAGA_STEP_MAX_ATTEMPTS", Flag: "saga-step-max-attempts", Usage: "tries of a failing step before compensating", Set: envconfig.Int(func(c *Config) *int { return &c.SagaStepMaxAttempts })},
+	{Env: "SAGA_STEP_MAX_BACKOFF", Flag: "saga-step-max-backoff", Usage: "maximum wait between step retries", Set: envconfig.Duration(func(c *Config) *time.Duration { return &c.SagaStepMaxBackoff })},
+}
+
+// Load reads the .env file, then builds the config from the process args
+// Exits if the config is invalid
+func Load() *Config {
+	_ = godotenv.Load()
+
+	cfg, err := load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	return cfg
+}
+
+// load builds and validates the config from the given CLI args
+func load(args []string) (*Config, error) {
+	cfg := defaults()
+	fs := flag.NewFlagSet("saga-service", flag.ContinueOnError)
+	if err := envconfig.Load(fs, args, cfg, options); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// defaults returns the config used when nothing else is set
+func defaults() *Config {
+	return &Config{
+		ServerPort: "8091",
+
+		DBHost:     "localhost",
+		DBPort:     "5432",
+		DBName:     "sagas",
+		DBUser:     "saga_user",
+		DBSSLMode:  "disable",
+		DBMaxConns: 5,
+
+		DBRetryMaxWait: time.Minute,
+
+		MigrateOnStartup: true,
+
+		EmployeeServiceURL:     "http://localhost:8081/employees-service/api/v1",
+		AssetServiceURL:        "http://localhost:8086/asset-service/api/v1",
+		NotificationServiceURL: "http://localhost:8082/notifications-service/api/v1",
+
+		StepTimeout: 10 * time.Second,
+
+		SagaPollInterval:    2 * time.Second,
+		SagaLease:           time.Minute,
+		SagaStepMaxAttempts: 5,
+		SagaStepMaxBackoff:  5 * time.Minute,
+	}
+}
+
+// Validate checks the final config and returns every problem found
+func (c *Config) Validate() error {
+	var errs []error
+
+	if err := envconfig.ValidatePort(c.ServerPort); err != nil {
+		errs = append(errs, fmt.Errorf("server port: %w", err))
+	}
+	if err := envconfig.ValidatePort(c.DBPort); err != nil {
+		errs = append(errs, fmt.Errorf("db port: %w", err))
+	}
+	if c.DBMaxConns < 1 {
+		errs = append(errs, errors.New("db max conns must be at least 1"))
+	}
+	if err := validateURL(c.EmployeeServiceURL); err != nil {
+		errs = append(errs, fmt.Errorf("employee service url: %w", err))
+	}
+	if err := validateURL(c.AssetServiceURL); err != nil {
+		errs = append(errs, fmt.Errorf("asset service url: %w", err))
+	}
+	if err := validateURL(c.NotificationServiceURL); err != nil {
+		errs = append(errs, fmt.Errorf("notification service url: %w", err))
+	}
+	if c.PayrollServiceURL != "" {
+		if err := validateURL(c.PayrollServiceURL); err != nil {
+			errs = append(errs, fmt.Errorf("payroll service url: %w", err))
+		}
+	}
+	if c.StepTimeout <= 0 {
+		errs = append(errs, errors.New("step timeout must be positive"))
+	}
+	if c.SagaPollInterval <= 0 {
+		errs = append(errs, errors.New("saga poll interval must be positive"))
+	}
+	if c.SagaLease < 2*c.StepTimeout {
+		errs = append(errs, errors.New("saga lease must be at least twice the step timeout"))
+	}
+	if c.SagaStepMaxAttempts < 1 {
+		errs = append(errs, errors.New("saga step max attempts must be at least 1"))
+	}
+	if c.SagaStepMaxBackoff <= 0 {
+		errs = append(errs, errors.New("saga step max backoff must be positive"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// DatabaseURL creates the connection url to the db
+func (c *Config) DatabaseURL() string {
+	return fmt.Sprintf(
+		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
+		c.DBUser,
+		c.DBPassword,
+		c.DBHost,
+		c.DBPort,
+		c.DBName,
+		c.DBSSLMode,
+	)
+}
+
+// validateURL checks that raw is an absolute http(s) url
+func validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q must be an http(s) url", raw)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+-- data holds the values steps hand to later steps and to their
+-- compensations, e.g. the status to restore or the checklist to cancel
+CREATE TABLE IF NOT EXISTS sagas.sagas (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	type VARCHAR(50) NOT NULL,
+	employee_id BIGINT NOT NULL,
+	reason TEXT NOT NULL DEFAULT '',
+	requested_by VARCHAR(255),
+	status VARCHAR(20) NOT NULL DEFAULT 'RUNNING',
+	data JSONB NOT NULL DEFAULT '{}',
+	error TEXT,
+	next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	lease_id UUID,
+	locked_until TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	finished_at TIMESTAMPTZ
+);
+
+-- One saga of a type in flight per employee
+CREATE UNIQUE INDEX IF NOT EXISTS sagas_in_flight_idx ON sagas.sagas (type, employee_id)
+	WHERE status IN ('RUNNING', 'COMPENSATING');
+
+CREATE INDEX IF NOT EXISTS sagas_due_idx ON sagas.sagas (next_attempt_at)
+	WHERE status IN ('RUNNING', 'COMPENSATING');
+
+CREATE INDEX IF NOT EXISTS sagas_employee_idx ON sagas.sagas (employee_id);
+
+CREATE TABLE IF NOT EXISTS sagas.saga_steps (
+	saga_id UUID NOT NULL REFERENCES sagas.sagas (id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	name VARCHAR(50) NOT NULL,
+	status VARCHAR(30) NOT NULL DEFAULT 'PENDING',
+	attempts INTEGER NOT NULL DEFAULT 0,
+	compensation_attempts INTEGER NOT NULL DEFAULT 0,
+	error TEXT,
+	finished_at TIMESTAMPTZ,
+	compensated_at TIMESTAMPTZ,
+	PRIMARY KEY (saga_id, position)
+);
@@ -0,0 +1,155 @@
+// Package handlers exposes the saga service over HTTP
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"saga-service/internal/api"
+	"saga-service/internal/models"
+	"saga-service/internal/repository"
+	"saga-service/internal/saga"
+	"saga-service/internal/service"
+
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// userHeader is the identity header set by the gateway from the bearer
+// token, recorded as who requested a saga
+const userHeader = "X-User-ID"
+
+// SagaHandler handles HTTP requests for sagas
+type SagaHandler struct {
+	service *service.SagaService
+}
+
+// NewSagaHandler creates a new SagaHandler instance
+func NewSagaHandler(s *service.SagaService) *SagaHandler {
+	return &SagaHandler{service: s}
+}
+
+// TerminationRequest is the payload to start an offboarding
+type TerminationRequest struct {
+	EmployeeID int64  `json:"employeeId" example:"42"`
+	Reason     string `json:"reason" example:"End of contract"`
+}
+
+// StartTermination godoc
+//
+//	@Summary		Offboard an employee
+//	@Description	Starts a termination saga: retire the employee in employee-management, open their asset reclaim checklist, stop their payroll and notify HR. Runs in the background, poll GET /sagas/{id} for its status. When a step fails for good the done steps are undone in reverse order
+//	@Tags			Sagas
+//	@Accept			json
+//	@Produce		json
+//	@Param			termination	body		TerminationRequest		true	"Employee to offboard"
+//	@Success		202			{object}	models.Saga				"Saga started"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid JSON format, employee ID or reason"
+//	@Failure		409			{object}	httpkit.ErrorResponse	"A termination of the employee is already in flight"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/sagas/termination [post]
+func (h *SagaHandler) StartTermination(c *gin.Context) {
+	var req TerminationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+	if req.EmployeeID < 1 {
+		httpkit.BadRequest(c, "Invalid employee ID")
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if len(req.Reason) > 500 {
+		httpkit.BadRequest(c, "Reason must be at most 500 characters")
+		return
+	}
+
+	var requestedBy *string
+	if user := c.GetHeader(userHeader); user != "" {
+		requestedBy = &user
+	}
+
+	s, err := h.service.Start(c.Request.Context(), saga.TerminationType, req.EmployeeID, req.Reason, requestedBy)
+	if err != nil {
+		if errors.Is(err, repository.ErrSagaInFlight) {
+			httpkit.Error(c, http.StatusConflict, "A termination of the employee is already in flight")
+			return
+		}
+		httpkit.InternalServerError(c, "Failed to start termination")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, s)
+}
+
+// GetAllSagas godoc
+//
+//	@Summary		List sagas
+//	@Description	Retrieves the sagas without their steps, newest first
+//	@Tags			Sagas
+//	@Produce		json
+//	@Param			page		query		int		false	"Page number"	default(1)
+//	@Param			page_size	query		int		false	"Page size"		default(20)
+//	@Param			type		query		string	false	"Saga type"		Enums(termination)
+//	@Param			status		query		string	false	"Saga status"	Enums(RUNNING, COMPLETED, COMPENSATING, COMPENSATED, FAILED)
+//	@Param			employeeId	query		int		false	"Employee id"
+//	@Success		200			{object}	httpkit.PaginatedResponse{data=[]models.Saga}	"Sagas"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid query parameters"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/sagas [get]
+func (h *SagaHandler) GetAllSagas(c *gin.Context) {
+	var query api.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpkit.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	status := models.SagaStatus(query.Status)
+	if status != "" && !status.Valid() {
+		httpkit.BadRequest(c, "Invalid status")
+		return
+	}
+	query.Normalize()
+
+	filter := repository.SagaFilter{Type: query.Type, Status: status, EmployeeID: query.EmployeeID}
+	sagas, total, err := h.service.FindAll(c.Request.Context(), filter, query.Page, query.PageSize)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve sagas")
+		return
+	}
+
+	c.JSON(http.StatusOK, httpkit.Paginate(sagas, query.PageQuery, total))
+}
+
+// GetSagaByID godoc
+//
+//	@Summary		Get a saga
+//	@Description	Retrieves a saga with the status, attempts and error of each step
+//	@Tags			Sagas
+//	@Produce		json
+//	@Param			id	path		string					true	"Saga ID (UUID)"
+//	@Success		200	{object}	models.Saga				"Saga"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid saga ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Saga not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/sagas/{id} [get]
+func (h *SagaHandler) GetSagaByID(c *gin.Context) {
+	id := c.Param("id")
+	if err := uuid.Validate(id); err != nil {
+		httpkit.BadRequest(c, "Invalid saga ID")
+		return
+	}
+
+	s, err := h.service.Find(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrSagaNotFound) {
+			httpkit.NotFound(c, "Saga not found")
+			return
+		}
+		httpkit.InternalServerError(c, "Failed to retrieve saga")
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
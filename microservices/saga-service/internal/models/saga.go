@@ -0,0 +1,83 @@
+// Package models define the core data structures of the saga service
+package models
+
+import "time"
+
+// SagaStatus is where a saga is in its lifecycle
+type SagaStatus string
+
+const (
+	// SagaRunning runs the steps in order
+	SagaRunning SagaStatus = "RUNNING"
+	// SagaCompleted ran every step
+	SagaCompleted SagaStatus = "COMPLETED"
+	// SagaCompensating undoes the done steps in reverse order after a
+	// step failed
+	SagaCompensating SagaStatus = "COMPENSATING"
+	// SagaCompensated undid every done step
+	SagaCompensated SagaStatus = "COMPENSATED"
+	// SagaFailed could not undo a step, it needs a person to finish it
+	SagaFailed SagaStatus = "FAILED"
+)
+
+// Valid reports whether s is a known status
+func (s SagaStatus) Valid() bool {
+	switch s {
+	case SagaRunning, SagaCompleted, SagaCompensating, SagaCompensated, SagaFailed:
+		return true
+	}
+	return false
+}
+
+// InFlight reports whether the orchestrator still has work on the saga
+func (s SagaStatus) InFlight() bool {
+	return s == SagaRunning || s == SagaCompensating
+}
+
+// StepStatus is the outcome of one step
+type StepStatus string
+
+const (
+	StepPending            StepStatus = "PENDING"
+	StepDone               StepStatus = "DONE"
+	StepSkipped            StepStatus = "SKIPPED"
+	StepFailed             StepStatus = "FAILED"
+	StepCompensated        StepStatus = "COMPENSATED"
+	StepCompensationFailed StepStatus = "COMPENSATION_FAILED"
+)
+
+// Saga is one run of a workflow spanning several services
+type Saga struct {
+	ID          string     `json:"id" example:"3f1c2a8e-7b1d-4c55-9a4e-0c2f8d1e6b7a"`
+	Type        string     `json:"type" example:"termination"`
+	EmployeeID  int64      `json:"employeeId" example:"42"`
+	Reason      string     `json:"reason" example:"End of contract"`
+	RequestedBy *string    `json:"requestedBy,omitempty"`
+	Status      SagaStatus `json:"status" example:"RUNNING"`
+	// Data holds what the steps recorded for later steps and compensations
+	Data  map[string]string `json:"data"`
+	Error *string           `json:"error,omitempty"`
+	Steps []Step            `json:"steps,omitempty"`
+	// NextAttemptAt is when the orchestrator picks the saga up again
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	// FinishedAt is set once the saga is COMPLETED, COMPENSATED or FAILED
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+
+	// LeaseID identifies the orchestrator claim the saga was loaded with
+	LeaseID string `json:"-"`
+}
+
+// Step is the state of one step of a saga
+type Step struct {
+	Position int        `json:"position" example:"1"`
+	Name     string     `json:"name" example:"retire-employee"`
+	Status   StepStatus `json:"status" example:"DONE"`
+	Attempts int        `json:"attempts" example:"1"`
+	// CompensationAttempts counts the tries to undo the step
+	CompensationAttempts int        `json:"compensationAttempts" example:"0"`
+	Error                *string    `json:"error,omitempty"`
+	FinishedAt           *time.Time `json:"finishedAt,omitempty"`
+	CompensatedAt        *time.Time `json:"compensatedAt,omitempty"`
+}
@@ -0,0 +1,89 @@
+// Package remote is the JSON over HTTP client the saga steps call the
+// other services with
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// StatusError is returned when a service answers with a non 2xx status
+type StatusError struct {
+	Method string
+	URL    string
+	Code   int
+	// Body is the start of the response body, usually the error message
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s answered %d: %s", e.Method, e.URL, e.Code, e.Body)
+}
+
+// Retryable reports whether err may go away by itself: the service could
+// not be reached, timed out, was overloaded or failed internally. Other
+// answers, like 404 or 409, will not change on retry
+func Retryable(err error) bool {
+	var se *StatusError
+	if !errors.As(err, &se) {
+		return true
+	}
+	return se.Code >= 500 || se.Code == http.StatusRequestTimeout || se.Code == http.StatusTooManyRequests
+}
+
+// Client calls the JSON APIs of the other services
+type Client struct {
+	http *http.Client
+}
+
+// NewClient creates a client whose calls time out after timeout
+func NewClient(timeout time.Duration) *Client {
+	return &Client{http: &http.Client{Timeout: timeout}}
+}
+
+// Do sends in as the JSON body, when not nil, and decodes the response
+// into out, when not nil
+func (c *Client) Do(ctx context.Context, method, url string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &StatusError{Method: method, URL: url, Code: resp.StatusCode, Body: string(bytes.TrimSpace(msg))}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response of %s %s: %w", method, url, err)
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+// Package repository implements the data access layer of the saga service
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"saga-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Errors returned by SagaRepository
+var (
+	ErrSagaNotFound = errors.New("saga not found")
+	// ErrSagaInFlight is returned when the employee already has a running
+	// or compensating saga of the type
+	ErrSagaInFlight = errors.New("a saga of this type is already in flight for the employee")
+	// ErrLeaseLost is returned when saving a saga whose claim expired and
+	// was taken by another orchestrator
+	ErrLeaseLost = errors.New("saga lease lost")
+)
+
+// SagaFilter narrows FindAll, zero values match everything
+type SagaFilter struct {
+	Type       string
+	Status     models.SagaStatus
+	EmployeeID int64
+}
+
+// SagaRepository defines the interface for saga data operations
+type SagaRepository interface {
+	// Create inserts a RUNNING saga with a PENDING row per step name
+	Create(ctx context.Context, s *models.Saga, steps []string) error
+	// Find retrieves a saga with its steps
+	Find(ctx context.Context, id string) (*models.Saga, error)
+	// FindAll retrieves a page of sagas without their steps, newest first
+	FindAll(ctx context.Context, filter SagaFilter, limit, offset int) ([]models.Saga, int, error)
+
+	// Claim leases up to limit due sagas in flight for lease and returns
+	// them with their steps. A saga whose lease ran out, because its
+	// orchestrator stopped, can be claimed again
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]models.Saga, error)
+	// Save stores the status, data and error of a claimed saga and, when
+	// not nil, the state of step. With release set the lease is given up
+	Save(ctx context.Context, s *models.Saga, step *models.Step, release bool) error
+}
+
+// sagaRepository is the postgresql implementation of SagaRepository
+type sagaRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSagaRepository creates a new instance of SagaRepository
+func NewSagaRepository(db *pgxpool.Pool) SagaRepository {
+	return &sagaRepository{db: db}
+}
+
+// sagaColumns are the columns scanned by scanSaga
+const sagaColumns = `
+        id::text, type, employee_id, reason, requested_by, status, data, error,
+        next_attempt_at, created_at, updated_at, finished_at
+    `
+
+// scanSaga scans a row selected with sagaColumns, plus the extra
+// destinations given
+func scanSaga(row pgx.Row, extra ...any) (models.Saga, error) {
+	var s models.Saga
+	dest := []any{
+		&s.ID, &s.Type, &s.EmployeeID, &s.Reason, &s.RequestedBy, &s.Status, &s.Data, &s.Error,
+		&s.NextAttemptAt, &s.CreatedAt, &s.UpdatedAt, &s.FinishedAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	return s, err
+}
+
+// stepColumns are the columns of a step loaded by loadSteps
+const stepColumns = `position, name, status, attempts, compensation_attempts, error, finished_at, compensated_at`
+
+// Create inserts the saga and its steps in one transaction
+func (r *sagaRepository) Create(ctx context.Context, s *models.Saga, steps []string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+            INSERT INTO sagas.sagas (type, employee_id, reason, requested_by)
+            VALUES ($1, $2, $3, $4)
+            RETURNING id::text, status, data, next_attempt_at, created_at, updated_at`,
+			s.Type, s.EmployeeID, s.Reason, s.RequestedBy,
+		).Scan(&s.ID, &s.Status, &s.Data, &s.NextAttemptAt, &s.CreatedAt, &s.UpdatedAt)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return ErrSagaInFlight
+			}
+			return fmt.Errorf("failed to create saga: %w", err)
+		}
+
+		s.Steps = make([]models.Step, len(steps))
+		for i, name := range steps {
+			s.Steps[i] = models.Step{Position: i + 1, Name: name, Status: models.StepPending}
+			if _, err := tx.Exec(ctx, `
+                INSERT INTO sagas.saga_steps (saga_id, position, name)
+                VALUES ($1, $2, $3)`, s.ID, i+1, name); err != nil {
+				return fmt.Errorf("failed to create saga step: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Find retrieves a saga with its steps
+func (r *sagaRepository) Find(ctx context.Context, id string) (*models.Saga, error) {
+	s, err := scanSaga(r.db.QueryRow(ctx, `SELECT `+sagaColumns+` FROM sagas.sagas WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSagaNotFound
+		}
+		return nil, err
+	}
+
+	sagas := []models.Saga{s}
+	if err := r.loadSteps(ctx, sagas); err != nil {
+		return nil, err
+	}
+
+	return &sagas[0], nil
+}
+
+// FindAll retrieves a page of sagas, newest first, with the total number
+// matching the filter
+func (r *sagaRepository) FindAll(ctx context.Context, filter SagaFilter, limit, offset int) ([]models.Saga, int, error) {
+	where := `
+        WHERE ($1 = '' OR type = $1)
+          AND ($2 = '' OR status = $2)
+          AND ($3 = 0 OR employee_id = $3)
+    `
+	args := []any{filter.Type, string(filter.Status), filter.EmployeeID}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM sagas.sagas`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count sagas: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+sagaColumns+` FROM sagas.sagas`+where+`
+        ORDER BY created_at DESC, id
+        LIMIT $4 OFFSET $5`, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query sagas: %w", err)
+	}
+	defer rows.Close()
+
+	sagas := []models.Saga{}
+	for rows.Next() {
+		s, err := scanSaga(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan saga row: %w", err)
+		}
+		sagas = append(sagas, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating saga rows: %w", err)
+	}
+
+	return sagas, total, nil
+}
+
+// Claim leases the due sagas with a new lease id each
+func (r *sagaRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]models.Saga, error) {
+	rows, err := r.db.Query(ctx, `
+        UPDATE sagas.sagas
+        SET lease_id = gen_random_uuid(),
+            locked_until = CURRENT_TIMESTAMP + $2 * INTERVAL '1 millisecond'
+        WHERE id IN (
+            SELECT id FROM sagas.sagas
+            WHERE status IN ('RUNNING', 'COMPENSATING')
+              AND next_attempt_at <= CURRENT_TIMESTAMP
+              AND (locked_until IS NULL OR locked_until < CURRENT_TIMESTAMP)
+            ORDER BY next_attempt_at
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING `+sagaColumns+`, lease_id::text`, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim sagas: %w", err)
+	}
+	defer rows.Close()
+
+	var sagas []models.Saga
+	for rows.Next() {
+		var leaseID string
+		s, err := scanSaga(rows, &leaseID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan saga row: %w", err)
+		}
+		s.LeaseID = leaseID
+		sagas = append(sagas, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saga rows: %w", err)
+	}
+
+	if err := r.loadSteps(ctx, sagas); err != nil {
+		return nil, err
+	}
+
+	return sagas, nil
+}
+
+// Save updates the saga and the step in one transaction, as long as the
+// lease of the saga is still held
+func (r *sagaRepository) Save(ctx context.Context, s *models.Saga, step *models.Step, release bool) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+            UPDATE sagas.sagas
+            SET status = $3, data = $4, error = $5, next_attempt_at = $6,
+                lease_id = CASE WHEN $7 THEN NULL ELSE lease_id END,
+                locked_until = CASE WHEN $7 THEN NULL ELSE locked_until END,
+                finished_at = CASE WHEN $3 IN ('COMPLETED', 'COMPENSATED', 'FAILED') THEN CURRENT_TIMESTAMP END,
+                updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1 AND lease_id = $2
+            RETURNING updated_at, finished_at`,
+			s.ID, s.LeaseID, s.Status, s.Data, s.Error, s.NextAttemptAt, release,
+		).Scan(&s.UpdatedAt, &s.FinishedAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrLeaseLost
+			}
+			return fmt.Errorf("failed to save saga: %w", err)
+		}
+
+		if step == nil {
+			return nil
+		}
+
+		if _, err := tx.Exec(ctx, `
+            UPDATE sagas.saga_steps
+            SET status = $3, attempts = $4, compensation_attempts = $5, error = $6,
+                finished_at = $7, compensated_at = $8
+            WHERE saga_id = $1 AND position = $2`,
+			s.ID, step.Position, step.Status, step.Attempts, step.CompensationAttempts, step.Error,
+			step.FinishedAt, step.CompensatedAt,
+		); err != nil {
+			return fmt.Errorf("failed to save saga step: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// loadSteps fills the steps of sagas in one query
+func (r *sagaRepository) loadSteps(ctx context.Context, sagas []models.Saga) error {
+	if len(sagas) == 0 {
+		return nil
+	}
+
+	index := make(map[string]int, len(sagas))
+	ids := make([]string, len(sagas))
+	for i, s := range sagas {
+		index[s.ID] = i
+		ids[i] = s.ID
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT saga_id::text, `+stepColumns+`
+        FROM sagas.saga_steps
+        WHERE saga_id = ANY($1::uuid[])
+        ORDER BY saga_id, position`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to query saga steps: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sagaID string
+		var st models.Step
+		if err := rows.Scan(&sagaID, &st.Position, &st.Name, &st.Status, &st.Attempts, &st.CompensationAttempts,
+			&st.Error, &st.FinishedAt, &st.CompensatedAt); err != nil {
+			return fmt.Errorf("failed to scan saga step row: %w", err)
+		}
+		i := index[sagaID]
+		sagas[i].Steps = append(sagas[i].Steps, st)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating saga step rows: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,291 @@
+// Package saga runs workflows spanning several services as sagas: steps
+// run in order, and when one fails for good the steps already done are
+// compensated in reverse order. The state is stored after every step, so
+// a saga left half way by a restart resumes where it stopped
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"saga-service/internal/backoff"
+	"saga-service/internal/models"
+	"saga-service/internal/remote"
+	"saga-service/internal/repository"
+)
+
+// ErrSkipped is returned by an action that had nothing to do, e.g. an
+// optional service that is not configured. The step is recorded SKIPPED
+// and is not compensated
+var ErrSkipped = errors.New("step skipped")
+
+// Step is one action of a workflow and the compensation undoing it
+type Step struct {
+	Name   string
+	Action func(ctx context.Context, s *models.Saga) error
+	// Compensate is nil for steps that need no undoing
+	Compensate func(ctx context.Context, s *models.Saga) error
+}
+
+// Definition is a workflow the orchestrator can run
+type Definition struct {
+	Type  string
+	Steps []Step
+}
+
+// StepNames returns the names of the steps in order
+func (d Definition) StepNames() []string {
+	names := make([]string, len(d.Steps))
+	for i, st := range d.Steps {
+		names[i] = st.Name
+	}
+	return names
+}
+
+// Orchestrator claims the due sagas and advances them
+type Orchestrator struct {
+	repo         repository.SagaRepository
+	definitions  map[string]Definition
+	stepTimeout  time.Duration
+	pollInterval time.Duration
+	lease        time.Duration
+	maxAttempts  int
+	maxBackoff   time.Duration
+	nudge        chan struct{}
+}
+
+// NewOrchestrator creates an Orchestrator running the given definitions
+// A step failing with a retryable error is tried maxAttempts times, with
+// exponential backoff up to maxBackoff, before the saga compensates
+func NewOrchestrator(repo repository.SagaRepository, definitions []Definition, stepTimeout, pollInterval, lease time.Duration, maxAttempts int, maxBackoff time.Duration) *Orchestrator {
+	defs := make(map[string]Definition, len(definitions))
+	for _, d := range definitions {
+		defs[d.Type] = d
+	}
+
+	return &Orchestrator{
+		repo:         repo,
+		definitions:  defs,
+		stepTimeout:  stepTimeout,
+		pollInterval: pollInterval,
+		lease:        lease,
+		maxAttempts:  maxAttempts,
+		maxBackoff:   maxBackoff,
+		nudge:        make(chan struct{}, 1),
+	}
+}
+
+// Definition returns the workflow of type t
+func (o *Orchestrator) Definition(t string) (Definition, bool) {
+	d, ok := o.definitions[t]
+	return d, ok
+}
+
+// Nudge makes Run look for due sagas now instead of at the next poll
+func (o *Orchestrator) Nudge() {
+	select {
+	case o.nudge <- struct{}{}:
+	default:
+	}
+}
+
+// Run advances sagas until ctx is done. Sagas stopped half way, by a
+// restart or a crash, are picked up again once their lease runs out
+func (o *Orchestrator) Run(ctx context.Context) {
+	const batchSize = 10
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-o.nudge:
+		}
+
+		sagas, err := o.repo.Claim(ctx, batchSize, o.lease)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("saga claim failed: %v", err)
+		}
+
+		for i := range sagas {
+			if err := o.advance(ctx, &sagas[i]); err != nil && ctx.Err() == nil {
+				log.Printf("saga %s: %v", sagas[i].ID, err)
+			}
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if len(sagas) == batchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(o.pollInterval)
+		}
+	}
+}
+
+// advance runs or compensates the steps of a claimed saga until it
+// finishes or has to wait for a retry
+func (o *Orchestrator) advance(ctx context.Context, s *models.Saga) error {
+	def, ok := o.definitions[s.Type]
+	if !ok || len(def.Steps) != len(s.Steps) {
+		msg := fmt.Sprintf("unknown saga type %q", s.Type)
+		s.Status, s.Error = models.SagaFailed, &msg
+		return o.repo.Save(ctx, s, nil, true)
+	}
+	if s.Data == nil {
+		s.Data = map[string]string{}
+	}
+
+	// Leave room for one more step before the lease runs out, the saga
+	// is claimed again right away
+	deadline := time.Now().Add(o.lease - o.stepTimeout)
+
+	for {
+		if time.Now().After(deadline) {
+			return o.repo.Save(ctx, s, nil, true)
+		}
+		var done bool
+		var err error
+		switch s.Status {
+		case models.SagaRunning:
+			done, err = o.runNext(ctx, def, s)
+		case models.SagaCompensating:
+			done, err = o.compensateNext(ctx, def, s)
+		default:
+			return nil
+		}
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// runNext runs the first pending step. It reports done once the saga
+// waits for a retry or was completed
+func (o *Orchestrator) runNext(ctx context.Context, def Definition, s *models.Saga) (bool, error) {
+	i := firstStep(s.Steps, models.StepPending)
+	if i < 0 {
+		s.Status, s.Error = models.SagaCompleted, nil
+		log.Printf("saga %s (%s, employee %d) completed", s.ID, s.Type, s.EmployeeID)
+		return true, o.repo.Save(ctx, s, nil, true)
+	}
+
+	st := &s.Steps[i]
+	err := o.call(ctx, def.Steps[i].Action, s)
+	st.Attempts++
+	now := time.Now()
+
+	switch {
+	case err == nil || errors.Is(err, ErrSkipped):
+		st.Status, st.Error, st.FinishedAt = models.StepDone, nil, &now
+		if err != nil {
+			st.Status = models.StepSkipped
+		}
+		return false, o.repo.Save(ctx, s, st, false)
+
+	case remote.Retryable(err) && st.Attempts < o.maxAttempts:
+		msg := err.Error()
+		st.Error = &msg
+		s.NextAttemptAt = now.Add(o.backoff(st.Attempts))
+		return true, o.repo.Save(ctx, s, st, true)
+
+	default:
+		msg := err.Error()
+		st.Status, st.Error, st.FinishedAt = models.StepFailed, &msg, &now
+		failure := fmt.Sprintf("step %s failed: %s", st.Name, msg)
+		s.Status, s.Error = models.SagaCompensating, &failure
+		log.Printf("saga %s (%s, employee %d) compensating: %s", s.ID, s.Type, s.EmployeeID, failure)
+		return false, o.repo.Save(ctx, s, st, false)
+	}
+}
+
+// compensateNext undoes the last done step. It reports done once the
+// saga waits for a retry or was compensated or failed
+func (o *Orchestrator) compensateNext(ctx context.Context, def Definition, s *models.Saga) (bool, error) {
+	i := lastStep(s.Steps, models.StepDone)
+	if i < 0 {
+		s.Status = models.SagaCompensated
+		log.Printf("saga %s (%s, employee %d) compensated", s.ID, s.Type, s.EmployeeID)
+		return true, o.repo.Save(ctx, s, nil, true)
+	}
+
+	st := &s.Steps[i]
+	var err error
+	if def.Steps[i].Compensate != nil {
+		err = o.call(ctx, def.Steps[i].Compensate, s)
+		st.CompensationAttempts++
+	}
+	now := time.Now()
+
+	switch {
+	case err == nil:
+		st.Status, st.Error, st.CompensatedAt = models.StepCompensated, nil, &now
+		return false, o.repo.Save(ctx, s, st, false)
+
+	case st.CompensationAttempts < o.maxAttempts:
+		// Compensations are retried whatever the error, giving up leaves
+		// the services inconsistent
+		msg := err.Error()
+		st.Error = &msg
+		s.NextAttemptAt = now.Add(o.backoff(st.CompensationAttempts))
+		return true, o.repo.Save(ctx, s, st, true)
+
+	default:
+		msg := err.Error()
+		st.Status, st.Error = models.StepCompensationFailed, &msg
+		failure := fmt.Sprintf("%s; compensation of step %s failed: %s", deref(s.Error), st.Name, msg)
+		s.Status, s.Error = models.SagaFailed, &failure
+		log.Printf("saga %s (%s, employee %d) failed: %s", s.ID, s.Type, s.EmployeeID, failure)
+		return true, o.repo.Save(ctx, s, st, true)
+	}
+}
+
+// call runs fn with the step timeout
+func (o *Orchestrator) call(ctx context.Context, fn func(ctx context.Context, s *models.Saga) error, s *models.Saga) error {
+	ctx, cancel := context.WithTimeout(ctx, o.stepTimeout)
+	defer cancel()
+	return fn(ctx, s)
+}
+
+// backoff returns the wait before the given attempt, 10s doubled per attempt
+func (o *Orchestrator) backoff(attempts int) time.Duration {
+	return backoff.Exponential(10*time.Second, o.maxBackoff, attempts)
+}
+
+// firstStep returns the index of the first step with status, -1 if none
+func firstStep(steps []models.Step, status models.StepStatus) int {
+	for i, st := range steps {
+		if st.Status == status {
+			return i
+		}
+	}
+	return -1
+}
+
+// lastStep returns the index of the last step with status, -1 if none
+func lastStep(steps []models.Step, status models.StepStatus) int {
+	for i := len(steps) - 1; i >= 0; i-- {
+		if steps[i].Status == status {
+			return i
+		}
+	}
+	return -1
+}
+
+// deref returns the string s points to, "" for nil
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,165 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"saga-service/internal/models"
+	"saga-service/internal/remote"
+)
+
+// TerminationType is the type of the offboarding saga
+const TerminationType = "termination"
+
+// Keys of the data of a termination saga
+const (
+	keyPreviousStatus = "previousStatus"
+	keyChecklistID    = "checklistId"
+)
+
+// statusRetired is the employee-management status of terminated employees
+const statusRetired = "RETIRED"
+
+// Services are the versioned API bases the termination steps call. An
+// empty Payroll skips the payroll step
+type Services struct {
+	Employees     string
+	Assets        string
+	Notifications string
+	Payroll       string
+}
+
+// termination holds what the termination steps need
+type termination struct {
+	client *remote.Client
+	svc    Services
+}
+
+// Termination returns the offboarding workflow: retire the employee in
+// employee-management, open their asset reclaim checklist, stop their
+// payroll and tell HR through notification-service
+func Termination(client *remote.Client, svc Services) Definition {
+	svc.Employees = strings.TrimSuffix(svc.Employees, "/")
+	svc.Assets = strings.TrimSuffix(svc.Assets, "/")
+	svc.Notifications = strings.TrimSuffix(svc.Notifications, "/")
+	svc.Payroll = strings.TrimSuffix(svc.Payroll, "/")
+	t := &termination{client: client, svc: svc}
+
+	return Definition{
+		Type: TerminationType,
+		Steps: []Step{
+			// Recorded in a step of its own so a retried retire cannot
+			// read back the status it set
+			{Name: "snapshot-employee", Action: t.snapshotEmployee},
+			{Name: "retire-employee", Action: t.retireEmployee, Compensate: t.restoreEmployee},
+			{Name: "reclaim-assets", Action: t.reclaimAssets, Compensate: t.cancelReclaim},
+			{Name: "stop-payroll", Action: t.stopPayroll, Compensate: t.resumePayroll},
+			{Name: "notify-hr", Action: t.notifyHR},
+		},
+	}
+}
+
+// employeeURL is the url of the employee of s in employee-management
+func (t *termination) employeeURL(s *models.Saga) string {
+	return t.svc.Employees + "/employees/" + strconv.FormatInt(s.EmployeeID, 10)
+}
+
+// snapshotEmployee records the status to restore on compensation. It
+// fails for good when the employee does not exist
+func (t *termination) snapshotEmployee(ctx context.Context, s *models.Saga) error {
+	var employee struct {
+		Status string `json:"status"`
+	}
+	if err := t.client.Do(ctx, http.MethodGet, t.employeeURL(s), nil, &employee); err != nil {
+		return err
+	}
+
+	s.Data[keyPreviousStatus] = employee.Status
+	return nil
+}
+
+// retireEmployee sets the employee RETIRED
+func (t *termination) retireEmployee(ctx context.Context, s *models.Saga) error {
+	return t.setStatus(ctx, s, statusRetired)
+}
+
+// restoreEmployee puts back the status the employee had
+func (t *termination) restoreEmployee(ctx context.Context, s *models.Saga) error {
+	return t.setStatus(ctx, s, s.Data[keyPreviousStatus])
+}
+
+// setStatus replaces the employee with the record read back with status
+// changed, employee-management only offers a full update
+func (t *termination) setStatus(ctx context.Context, s *models.Saga, status string) error {
+	var employee map[string]any
+	if err := t.client.Do(ctx, http.MethodGet, t.employeeURL(s), nil, &employee); err != nil {
+		return err
+	}
+	if employee["status"] == status {
+		return nil
+	}
+
+	employee["status"] = status
+	return t.client.Do(ctx, http.MethodPut, t.employeeURL(s), employee, nil)
+}
+
+// reclaimAssets opens the reclaim checklist of the employee in
+// asset-service. The saga id as reference makes a retry return the same
+// checklist
+func (t *termination) reclaimAssets(ctx context.Context, s *models.Saga) error {
+	url := t.svc.Assets + "/employees/" + strconv.FormatInt(s.EmployeeID, 10) + "/reclaim"
+
+	var checklist struct {
+		ID int64 `json:"id"`
+	}
+	if err := t.client.Do(ctx, http.MethodPost, url, map[string]string{"reference": "saga:" + s.ID}, &checklist); err != nil {
+		return err
+	}
+
+	s.Data[keyChecklistID] = strconv.FormatInt(checklist.ID, 10)
+	return nil
+}
+
+// cancelReclaim cancels the checklist opened by reclaimAssets
+func (t *termination) cancelReclaim(ctx context.Context, s *models.Saga) error {
+	url := t.svc.Assets + "/checklists/" + s.Data[keyChecklistID] + "/cancel"
+	return t.client.Do(ctx, http.MethodPost, url, nil, nil)
+}
+
+// stopPayroll stops the payroll of the employee, skipped when no payroll
+// service is configured
+func (t *termination) stopPayroll(ctx context.Context, s *models.Saga) error {
+	if t.svc.Payroll == "" {
+		return ErrSkipped
+	}
+	url := t.svc.Payroll + "/employees/" + strconv.FormatInt(s.EmployeeID, 10) + "/stop"
+	return t.client.Do(ctx, http.MethodPost, url, map[string]string{"reference": "saga:" + s.ID}, nil)
+}
+
+// resumePayroll undoes stopPayroll
+func (t *termination) resumePayroll(ctx context.Context, s *models.Saga) error {
+	url := t.svc.Payroll + "/employees/" + strconv.FormatInt(s.EmployeeID, 10) + "/resume"
+	return t.client.Do(ctx, http.MethodPost, url, map[string]string{"reference": "saga:" + s.ID}, nil)
+}
+
+// notifyHR posts employee.offboarded to notification-service. The saga id
+// is the event id, so a retry sends nothing twice
+func (t *termination) notifyHR(ctx context.Context, s *models.Saga) error {
+	var employee json.RawMessage
+	if err := t.client.Do(ctx, http.MethodGet, t.employeeURL(s), nil, &employee); err != nil {
+		return err
+	}
+
+	event := map[string]any{
+		"id":          s.ID,
+		"type":        "employee.offboarded",
+		"aggregateId": s.EmployeeID,
+		"occurredAt":  time.Now().UTC(),
+		"payload":     employee,
+	}
+	return t.client.Do(ctx, http.MethodPost, t.svc.Notifications+"/events", event, nil)
+}
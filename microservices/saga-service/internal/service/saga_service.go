@@ -0,0 +1,53 @@
+// Package service contains the business logic of the saga service
+package service
+
+import (
+	"context"
+	"errors"
+
+	"saga-service/internal/models"
+	"saga-service/internal/repository"
+	"saga-service/internal/saga"
+)
+
+// ErrUnknownType is returned when starting a saga of a type the
+// orchestrator does not run
+var ErrUnknownType = errors.New("unknown saga type")
+
+// SagaService starts sagas and exposes their state
+type SagaService struct {
+	repo         repository.SagaRepository
+	orchestrator *saga.Orchestrator
+}
+
+// NewSagaService creates a new SagaService instance
+func NewSagaService(repo repository.SagaRepository, orchestrator *saga.Orchestrator) *SagaService {
+	return &SagaService{repo: repo, orchestrator: orchestrator}
+}
+
+// Start stores a RUNNING saga of type t and has the orchestrator pick it
+// up at once. The employee can only have one saga of a type in flight
+func (s *SagaService) Start(ctx context.Context, t string, employeeID int64, reason string, requestedBy *string) (*models.Saga, error) {
+	def, ok := s.orchestrator.Definition(t)
+	if !ok {
+		return nil, ErrUnknownType
+	}
+
+	sg := &models.Saga{Type: t, EmployeeID: employeeID, Reason: reason, RequestedBy: requestedBy}
+	if err := s.repo.Create(ctx, sg, def.StepNames()); err != nil {
+		return nil, err
+	}
+
+	s.orchestrator.Nudge()
+	return sg, nil
+}
+
+// Find retrieves a saga with its steps
+func (s *SagaService) Find(ctx context.Context, id string) (*models.Saga, error) {
+	return s.repo.Find(ctx, id)
+}
+
+// FindAll retrieves a page of sagas matching filter
+func (s *SagaService) FindAll(ctx context.Context, filter repository.SagaFilter, page, pageSize int) ([]models.Saga, int, error) {
+	return s.repo.FindAll(ctx, filter, pageSize, (page-1)*pageSize)
+}
@@ -0,0 +1,93 @@
+package main
+
+//	@title			Scheduling Service API
+//	@version		1.0
+//	@description	Shift rosters, employee assignments and conflict detection
+//	@termsOfService	http://swagger.io/terms/
+
+//	@contact.name	API Support
+//	@contact.email	josed.amayar@uqvirtual.edu.co
+
+//	@host		localhost:8083
+//	@BasePath	/scheduling-service/api/v1
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"scheduling-service/internal/config"
+	"scheduling-service/internal/db"
+	"scheduling-service/internal/handlers"
+	"scheduling-service/internal/repository"
+	"scheduling-service/internal/service"
+
+	_ "scheduling-service/docs" // Swagger docs
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+func main() {
+	cfg := config.Load()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbPool := db.NewPostgresPool(cfg)
+	defer dbPool.Close()
+
+	if cfg.MigrateOnStartup {
+		if err := db.Migrate(ctx, dbPool); err != nil {
+			log.Fatalf("database migration failed: %v", err)
+		}
+	}
+
+	// Employee statuses come from employee-management, so people
+	// ON_VACATION cannot be rostered
+	employeeClient := employees.NewClient(cfg.EmployeeServiceURL, cfg.EmployeeServiceTimeout)
+	scheduleService := service.NewScheduleService(repository.NewScheduleRepository(dbPool), employeeClient, cfg.MaxShiftLength)
+
+	handler := handlers.NewScheduleHandler(scheduleService)
+	healthHandler := httpkit.NewHealthHandler("scheduling-service", dbPool)
+
+	router := httpkit.NewRouter()
+
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	v1 := router.Group("/scheduling-service/api/v1")
+	{
+		v1.GET("/health", healthHandler.HealthCheck)
+
+		v1.POST("/rosters", handler.CreateRoster)
+		v1.GET("/rosters", handler.GetAllRosters)
+		v1.GET("/rosters/:id", handler.GetRosterByID)
+		v1.GET("/rosters/:id/conflicts", handler.GetRosterConflicts)
+		v1.POST("/rosters/:id/publish", handler.PublishRoster)
+		v1.POST("/rosters/:id/shifts", handler.CreateShift)
+
+		v1.GET("/shifts", handler.GetAllShifts)
+		v1.GET("/shifts/:id", handler.GetShiftByID)
+		v1.DELETE("/shifts/:id", handler.DeleteShift)
+		v1.POST("/shifts/:id/assignments", handler.AssignEmployee)
+		v1.DELETE("/shifts/:id/assignments/:employeeId", handler.UnassignEmployee)
+
+		v1.GET("/employees/:id/shifts", handler.GetEmployeeShifts)
+	}
+
+	srv := &http.Server{Addr: ":" + cfg.ServerPort, Handler: router}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	log.Printf("Scheduling service running on :%s", cfg.ServerPort)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
@@ -0,0 +1,10 @@
+// Package api holds the query parameters of the list endpoints
+package api
+
+import "github.com/Josedzzz/microservices-fp/pkg/httpkit"
+
+// PaginationQuery represents the query parameters of the roster list
+type PaginationQuery struct {
+	httpkit.PageQuery
+	Department string `form:"department"`
+}
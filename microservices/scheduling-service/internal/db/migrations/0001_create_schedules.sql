@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS scheduling.rosters (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	department VARCHAR(100) NOT NULL,
+	period_start DATE NOT NULL,
+	period_end DATE NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'DRAFT',
+	published_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CHECK (period_end >= period_start)
+);
+
+CREATE TABLE IF NOT EXISTS scheduling.shifts (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	roster_id BIGINT NOT NULL REFERENCES scheduling.rosters (id) ON DELETE CASCADE,
+	name VARCHAR(100) NOT NULL,
+	starts_at TIMESTAMPTZ NOT NULL,
+	ends_at TIMESTAMPTZ NOT NULL,
+	required_staff INTEGER NOT NULL DEFAULT 1,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CHECK (ends_at > starts_at),
+	CHECK (required_staff > 0)
+);
+
+CREATE INDEX IF NOT EXISTS shifts_roster_idx ON scheduling.shifts (roster_id);
+CREATE INDEX IF NOT EXISTS shifts_time_idx ON scheduling.shifts (starts_at, ends_at);
+
+CREATE TABLE IF NOT EXISTS scheduling.assignments (
+	shift_id BIGINT NOT NULL REFERENCES scheduling.shifts (id) ON DELETE CASCADE,
+	employee_id BIGINT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (shift_id, employee_id)
+);
+
+CREATE INDEX IF NOT EXISTS assignments_employee_idx ON scheduling.assignments (employee_id);
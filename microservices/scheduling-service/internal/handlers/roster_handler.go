@@ -0,0 +1,244 @@
+// Package handlers exposes the scheduling service over HTTP
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"scheduling-service/internal/api"
+	"scheduling-service/internal/models"
+	"scheduling-service/internal/repository"
+	"scheduling-service/internal/service"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// ScheduleHandler handles HTTP requests for rosters, shifts and assignments
+type ScheduleHandler struct {
+	service *service.ScheduleService
+}
+
+// NewScheduleHandler creates a new ScheduleHandler instance
+func NewScheduleHandler(s *service.ScheduleService) *ScheduleHandler {
+	return &ScheduleHandler{service: s}
+}
+
+// RosterRequest is the payload to create a roster
+type RosterRequest struct {
+	Name        string `json:"name" example:"Front desk, week 45"`
+	Department  string `json:"department" example:"Operations"`
+	PeriodStart string `json:"periodStart" example:"2026-11-02"`
+	PeriodEnd   string `json:"periodEnd" example:"2026-11-08"`
+}
+
+// ConflictsResponse lists the conflicts of a roster
+type ConflictsResponse struct {
+	RosterID  int64             `json:"rosterId"`
+	Conflicts []models.Conflict `json:"conflicts"`
+}
+
+// CreateRoster godoc
+//
+//	@Summary		Create a roster
+//	@Description	Creates a draft roster for a department over an inclusive period of days
+//	@Tags			Rosters
+//	@Accept			json
+//	@Produce		json
+//	@Param			roster	body		RosterRequest		true	"Roster data"
+//	@Success		201		{object}	models.Roster		"Roster created"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid JSON format or validation failed"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/rosters [post]
+func (h *ScheduleHandler) CreateRoster(c *gin.Context) {
+	var req RosterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	req.Department = strings.TrimSpace(req.Department)
+	if req.Name == "" || req.Department == "" {
+		httpkit.BadRequest(c, "Name and department are required")
+		return
+	}
+
+	start, err := time.Parse(time.DateOnly, req.PeriodStart)
+	if err != nil {
+		httpkit.BadRequest(c, "Period start must be a YYYY-MM-DD date")
+		return
+	}
+	end, err := time.Parse(time.DateOnly, req.PeriodEnd)
+	if err != nil {
+		httpkit.BadRequest(c, "Period end must be a YYYY-MM-DD date")
+		return
+	}
+	if end.Before(start) {
+		httpkit.BadRequest(c, "Period end must not be before its start")
+		return
+	}
+
+	roster := models.Roster{Name: req.Name, Department: req.Department, PeriodStart: req.PeriodStart, PeriodEnd: req.PeriodEnd}
+	if err := h.service.CreateRoster(c.Request.Context(), &roster); err != nil {
+		httpkit.InternalServerError(c, "Failed to create roster")
+		return
+	}
+
+	c.JSON(http.StatusCreated, roster)
+}
+
+// GetAllRosters godoc
+//
+//	@Summary		List rosters
+//	@Description	Retrieves rosters newest period first, optionally filtered by department
+//	@Tags			Rosters
+//	@Produce		json
+//	@Param			page		query		int					false	"Page number"	default(1)
+//	@Param			page_size	query		int					false	"Page size"		default(20)
+//	@Param			department	query		string				false	"Department"
+//	@Success		200			{object}	httpkit.PaginatedResponse{data=[]models.Roster}	"Rosters"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid query parameters"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/rosters [get]
+func (h *ScheduleHandler) GetAllRosters(c *gin.Context) {
+	var query api.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpkit.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	query.Normalize()
+
+	rosters, total, err := h.service.FindRosters(c.Request.Context(), query.Department, query.Page, query.PageSize)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve rosters")
+		return
+	}
+
+	c.JSON(http.StatusOK, httpkit.Paginate(rosters, query.PageQuery, total))
+}
+
+// GetRosterByID godoc
+//
+//	@Summary		Get a roster
+//	@Description	Retrieves a roster with its shifts and assigned employees
+//	@Tags			Rosters
+//	@Produce		json
+//	@Param			id	path		int					true	"Roster ID"
+//	@Success		200	{object}	models.Roster		"Roster"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid roster ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Roster not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/rosters/{id} [get]
+func (h *ScheduleHandler) GetRosterByID(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid roster ID")
+	if !ok {
+		return
+	}
+
+	roster, err := h.service.FindRoster(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRosterNotFound):
+			httpkit.NotFound(c, "Roster not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve roster")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, roster)
+}
+
+// GetRosterConflicts godoc
+//
+//	@Summary		Roster conflicts
+//	@Description	Lists overlapping shifts, employees who are not ACTIVE (e.g. ON_VACATION) and understaffed shifts
+//	@Tags			Rosters
+//	@Produce		json
+//	@Param			id	path		int					true	"Roster ID"
+//	@Success		200	{object}	ConflictsResponse	"Conflicts, empty when the roster can be published"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid roster ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Roster not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Failure		503	{object}	httpkit.ErrorResponse	"Employee service unavailable"
+//	@Router			/rosters/{id}/conflicts [get]
+func (h *ScheduleHandler) GetRosterConflicts(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid roster ID")
+	if !ok {
+		return
+	}
+
+	conflicts, err := h.service.Conflicts(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRosterNotFound):
+			httpkit.NotFound(c, "Roster not found")
+		case errors.Is(err, employees.ErrUnavailable):
+			httpkit.Error(c, http.StatusServiceUnavailable, "Employee service unavailable")
+		default:
+			httpkit.InternalServerError(c, "Failed to check roster conflicts")
+		}
+		return
+	}
+	if conflicts == nil {
+		conflicts = []models.Conflict{}
+	}
+
+	c.JSON(http.StatusOK, ConflictsResponse{RosterID: id, Conflicts: conflicts})
+}
+
+// PublishRoster godoc
+//
+//	@Summary		Publish a roster
+//	@Description	Publishes a draft roster. Rosters with conflicts are rejected with the list of conflicts
+//	@Tags			Rosters
+//	@Produce		json
+//	@Param			id	path		int					true	"Roster ID"
+//	@Success		200	{object}	models.Roster		"Roster published"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid roster ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Roster not found"
+//	@Failure		409	{object}	ConflictsResponse	"Roster has conflicts or is already published"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Failure		503	{object}	httpkit.ErrorResponse	"Employee service unavailable"
+//	@Router			/rosters/{id}/publish [post]
+func (h *ScheduleHandler) PublishRoster(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid roster ID")
+	if !ok {
+		return
+	}
+
+	roster, err := h.service.Publish(c.Request.Context(), id)
+	if err != nil {
+		var conflicts *service.ConflictsError
+		switch {
+		case errors.As(err, &conflicts):
+			c.JSON(http.StatusConflict, ConflictsResponse{RosterID: id, Conflicts: conflicts.Conflicts})
+		case errors.Is(err, repository.ErrRosterNotFound):
+			httpkit.NotFound(c, "Roster not found")
+		case errors.Is(err, repository.ErrRosterPublished):
+			httpkit.Error(c, http.StatusConflict, "Roster is already published")
+		case errors.Is(err, employees.ErrUnavailable):
+			httpkit.Error(c, http.StatusServiceUnavailable, "Employee service unavailable")
+		default:
+			httpkit.InternalServerError(c, "Failed to publish roster")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, roster)
+}
+
+// pathID parses a positive id path parameter, answering 400 otherwise
+func pathID(c *gin.Context, name, message string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id < 1 {
+		httpkit.BadRequest(c, message)
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"scheduling-service/internal/models"
+	"scheduling-service/internal/repository"
+	"scheduling-service/internal/service"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// ShiftRequest is the payload to add a shift to a roster
+type ShiftRequest struct {
+	Name          string    `json:"name" example:"Morning"`
+	StartsAt      time.Time `json:"startsAt" example:"2026-11-02T07:00:00Z"`
+	EndsAt        time.Time `json:"endsAt" example:"2026-11-02T15:00:00Z"`
+	RequiredStaff int       `json:"requiredStaff" example:"2"`
+}
+
+// AssignmentRequest is the payload to roster an employee on a shift
+type AssignmentRequest struct {
+	EmployeeID int64 `json:"employeeId" example:"42"`
+}
+
+// ShiftQuery represents the query parameters of the shift list
+type ShiftQuery struct {
+	Department string    `form:"department"`
+	EmployeeID int64     `form:"employeeId" binding:"omitempty,min=1"`
+	From       time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// CreateShift godoc
+//
+//	@Summary		Add a shift
+//	@Description	Adds a shift inside the roster period
+//	@Tags			Shifts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"Roster ID"
+//	@Param			shift	body		ShiftRequest		true	"Shift data"
+//	@Success		201		{object}	models.Shift		"Shift created"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid JSON format or validation failed"
+//	@Failure		404		{object}	httpkit.ErrorResponse	"Roster not found"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/rosters/{id}/shifts [post]
+func (h *ScheduleHandler) CreateShift(c *gin.Context) {
+	rosterID, ok := pathID(c, "id", "Invalid roster ID")
+	if !ok {
+		return
+	}
+
+	var req ShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	switch {
+	case req.Name == "":
+		httpkit.BadRequest(c, "Name is required")
+		return
+	case req.StartsAt.IsZero() || !req.EndsAt.After(req.StartsAt):
+		httpkit.BadRequest(c, "Shift must end after it starts")
+		return
+	case req.RequiredStaff < 1:
+		httpkit.BadRequest(c, "Required staff must be at least 1")
+		return
+	}
+
+	shift := models.Shift{
+		RosterID:      rosterID,
+		Name:          req.Name,
+		StartsAt:      req.StartsAt.UTC(),
+		EndsAt:        req.EndsAt.UTC(),
+		RequiredStaff: req.RequiredStaff,
+	}
+	if err := h.service.CreateShift(c.Request.Context(), &shift); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRosterNotFound):
+			httpkit.NotFound(c, "Roster not found")
+		case errors.Is(err, service.ErrOutsidePeriod), errors.Is(err, service.ErrShiftTooLong):
+			httpkit.BadRequest(c, err.Error())
+		default:
+			httpkit.InternalServerError(c, "Failed to create shift")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, shift)
+}
+
+// GetAllShifts godoc
+//
+//	@Summary		List shifts
+//	@Description	Retrieves shifts ordered by start, filtered by department, employee and a time window
+//	@Tags			Shifts
+//	@Produce		json
+//	@Param			department	query		string				false	"Department"
+//	@Param			employeeId	query		int					false	"Assigned employee id"
+//	@Param			from		query		string				false	"Shifts ending after (RFC 3339)"
+//	@Param			to			query		string				false	"Shifts starting before (RFC 3339)"
+//	@Success		200			{array}		models.Shift		"Shifts"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid query parameters"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/shifts [get]
+func (h *ScheduleHandler) GetAllShifts(c *gin.Context) {
+	var query ShiftQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpkit.BadRequest(c, "Invalid query parameters")
+		return
+	}
+
+	h.listShifts(c, repository.ShiftFilter{
+		Department: query.Department,
+		EmployeeID: query.EmployeeID,
+		From:       query.From,
+		To:         query.To,
+	})
+}
+
+// GetEmployeeShifts godoc
+//
+//	@Summary		Shifts of an employee
+//	@Description	Retrieves the shifts an employee is rostered on, ordered by start
+//	@Tags			Shifts
+//	@Produce		json
+//	@Param			id		path		int					true	"Employee ID"
+//	@Param			from	query		string				false	"Shifts ending after (RFC 3339)"
+//	@Param			to		query		string				false	"Shifts starting before (RFC 3339)"
+//	@Success		200		{array}		models.Shift		"Shifts"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid employee ID or query parameters"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/employees/{id}/shifts [get]
+func (h *ScheduleHandler) GetEmployeeShifts(c *gin.Context) {
+	employeeID, ok := pathID(c, "id", "Invalid employee ID")
+	if !ok {
+		return
+	}
+
+	var query ShiftQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpkit.BadRequest(c, "Invalid query parameters")
+		return
+	}
+
+	h.listShifts(c, repository.ShiftFilter{EmployeeID: employeeID, From: query.From, To: query.To})
+}
+
+func (h *ScheduleHandler) listShifts(c *gin.Context, filter repository.ShiftFilter) {
+	shifts, err := h.service.FindShifts(c.Request.Context(), filter)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve shifts")
+		return
+	}
+
+	c.JSON(http.StatusOK, shifts)
+}
+
+// GetShiftByID godoc
+//
+//	@Summary		Get a shift
+//	@Description	Retrieves a shift with its assigned employees
+//	@Tags			Shifts
+//	@Produce		json
+//	@Param			id	path		int					true	"Shift ID"
+//	@Success		200	{object}	models.Shift		"Shift"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid shift ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Shift not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/shifts/{id} [get]
+func (h *ScheduleHandler) GetShiftByID(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid shift ID")
+	if !ok {
+		return
+	}
+
+	shift, err := h.service.FindShift(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrShiftNotFound):
+			httpkit.NotFound(c, "Shift not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve shift")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, shift)
+}
+
+// DeleteShift godoc
+//
+//	@Summary		Delete a shift
+//	@Description	Deletes a shift and its assignments
+//	@Tags			Shifts
+//	@Param			id	path	int	true	"Shift ID"
+//	@Success		204	"Shift deleted successfully (no content)"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid shift ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Shift not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/shifts/{id} [delete]
+func (h *ScheduleHandler) DeleteShift(c *gin.Context) {
+	id, ok := pathID(c, "id", "Invalid shift ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteShift(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrShiftNotFound):
+			httpkit.NotFound(c, "Shift not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to delete shift")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AssignEmployee godoc
+//
+//	@Summary		Assign an employee
+//	@Description	Rosters an ACTIVE or PROBATION employee on the shift. Employees ON_VACATION or RETIRED, double bookings and full shifts are rejected
+//	@Tags			Assignments
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Shift ID"
+//	@Param			assignment	body		AssignmentRequest	true	"Employee to assign"
+//	@Success		200			{object}	models.Shift		"Shift with the employee assigned"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid shift ID or JSON format"
+//	@Failure		404			{object}	httpkit.ErrorResponse	"Shift or employee not found"
+//	@Failure		409			{object}	httpkit.ErrorResponse	"Employee unavailable, already assigned, double booked or shift full"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Failure		503			{object}	httpkit.ErrorResponse	"Employee service unavailable"
+//	@Router			/shifts/{id}/assignments [post]
+func (h *ScheduleHandler) AssignEmployee(c *gin.Context) {
+	shiftID, ok := pathID(c, "id", "Invalid shift ID")
+	if !ok {
+		return
+	}
+
+	var req AssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.EmployeeID < 1 {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	if err := h.service.Assign(c.Request.Context(), shiftID, req.EmployeeID); err != nil {
+		var overlap *repository.OverlapError
+		switch {
+		case errors.As(err, &overlap):
+			httpkit.Error(c, http.StatusConflict, fmt.Sprintf("Employee already works overlapping shift %d", overlap.ShiftID))
+		case errors.Is(err, employees.ErrNotFound):
+			httpkit.NotFound(c, "Employee not found")
+		case errors.Is(err, repository.ErrShiftNotFound):
+			httpkit.NotFound(c, "Shift not found")
+		case errors.Is(err, service.ErrEmployeeUnavailable),
+			errors.Is(err, repository.ErrAlreadyAssigned),
+			errors.Is(err, repository.ErrShiftFull):
+			httpkit.Error(c, http.StatusConflict, err.Error())
+		case errors.Is(err, employees.ErrUnavailable):
+			httpkit.Error(c, http.StatusServiceUnavailable, "Employee service unavailable")
+		default:
+			httpkit.InternalServerError(c, "Failed to assign employee")
+		}
+		return
+	}
+
+	h.GetShiftByID(c)
+}
+
+// UnassignEmployee godoc
+//
+//	@Summary		Unassign an employee
+//	@Description	Removes an employee from the shift
+//	@Tags			Assignments
+//	@Param			id			path	int	true	"Shift ID"
+//	@Param			employeeId	path	int	true	"Employee ID"
+//	@Success		204			"Employee unassigned (no content)"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid shift or employee ID"
+//	@Failure		404			{object}	httpkit.ErrorResponse	"Employee is not assigned to the shift"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/shifts/{id}/assignments/{employeeId} [delete]
+func (h *ScheduleHandler) UnassignEmployee(c *gin.Context) {
+	shiftID, ok := pathID(c, "id", "Invalid shift ID")
+	if !ok {
+		return
+	}
+	employeeID, ok := pathID(c, "employeeId", "Invalid employee ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Unassign(c.Request.Context(), shiftID, employeeID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAssignmentMissing):
+			httpkit.NotFound(c, "Employee is not assigned to the shift")
+		default:
+			httpkit.InternalServerError(c, "Failed to unassign employee")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,61 @@
+// Package models define the core data structures of the scheduling service
+package models
+
+import "time"
+
+// RosterStatus represents the lifecycle of a roster
+type RosterStatus string
+
+const (
+	// RosterDraft can still be changed freely
+	RosterDraft RosterStatus = "DRAFT"
+	// RosterPublished was checked for conflicts and shared with employees
+	RosterPublished RosterStatus = "PUBLISHED"
+)
+
+// Roster is the schedule of a department over a period
+type Roster struct {
+	ID          int64        `json:"id"`
+	Name        string       `json:"name"`
+	Department  string       `json:"department"`
+	PeriodStart string       `json:"periodStart" example:"2026-11-02"`
+	PeriodEnd   string       `json:"periodEnd" example:"2026-11-08"`
+	Status      RosterStatus `json:"status"`
+	PublishedAt *time.Time   `json:"publishedAt,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	Shifts      []Shift      `json:"shifts,omitempty"`
+}
+
+// Shift is a block of time in a roster staffed by employees
+type Shift struct {
+	ID            int64     `json:"id"`
+	RosterID      int64     `json:"rosterId"`
+	Name          string    `json:"name"`
+	StartsAt      time.Time `json:"startsAt"`
+	EndsAt        time.Time `json:"endsAt"`
+	RequiredStaff int       `json:"requiredStaff"`
+	EmployeeIDs   []int64   `json:"employeeIds"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// ConflictType classifies a roster conflict
+type ConflictType string
+
+const (
+	// ConflictOverlap is an employee assigned to overlapping shifts
+	ConflictOverlap ConflictType = "OVERLAP"
+	// ConflictUnavailable is an employee who is not ACTIVE
+	ConflictUnavailable ConflictType = "UNAVAILABLE"
+	// ConflictUnderstaffed is a shift with fewer employees than required
+	ConflictUnderstaffed ConflictType = "UNDERSTAFFED"
+)
+
+// Conflict is a problem preventing a roster from being published
+type Conflict struct {
+	Type       ConflictType `json:"type"`
+	ShiftID    int64        `json:"shiftId"`
+	EmployeeID int64        `json:"employeeId,omitempty"`
+	// OtherShiftID is the overlapping shift for OVERLAP conflicts
+	OtherShiftID int64  `json:"otherShiftId,omitempty"`
+	Message      string `json:"message"`
+}
@@ -0,0 +1,404 @@
+// Package repository provides data access to rosters, shifts and assignments
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"scheduling-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Errors returned by ScheduleRepository
+var (
+	ErrRosterNotFound    = errors.New("roster not found")
+	ErrRosterPublished   = errors.New("roster is already published")
+	ErrShiftNotFound     = errors.New("shift not found")
+	ErrShiftFull         = errors.New("shift is fully staffed")
+	ErrAlreadyAssigned   = errors.New("employee is already assigned to the shift")
+	ErrAssignmentMissing = errors.New("employee is not assigned to the shift")
+)
+
+// OverlapError is returned when an employee already works a shift
+// overlapping the one being assigned
+type OverlapError struct {
+	ShiftID int64
+}
+
+func (e *OverlapError) Error() string {
+	return fmt.Sprintf("employee already works overlapping shift %d", e.ShiftID)
+}
+
+// ShiftFilter narrows FindShifts, zero values match everything
+type ShiftFilter struct {
+	Department string
+	EmployeeID int64
+	From       time.Time
+	To         time.Time
+}
+
+// ScheduleRepository defines the interface for schedule data operations
+type ScheduleRepository interface {
+	CreateRoster(ctx context.Context, r *models.Roster) error
+	// FindRoster returns the roster with its shifts
+	FindRoster(ctx context.Context, id int64) (*models.Roster, error)
+	FindRosters(ctx context.Context, department string, limit, offset int) ([]models.Roster, int, error)
+	PublishRoster(ctx context.Context, id int64) error
+
+	CreateShift(ctx context.Context, s *models.Shift) error
+	FindShift(ctx context.Context, id int64) (*models.Shift, error)
+	FindShifts(ctx context.Context, filter ShiftFilter) ([]models.Shift, error)
+	DeleteShift(ctx context.Context, id int64) error
+
+	// Assign adds the employee to the shift. It fails with an
+	// *OverlapError if they work an overlapping shift, and ErrShiftFull
+	// once the required staff is reached
+	Assign(ctx context.Context, shiftID, employeeID int64) error
+	Unassign(ctx context.Context, shiftID, employeeID int64) error
+
+	// FindOverlaps lists the assignments of the roster overlapping another
+	// shift of the same employee, in any roster
+	FindOverlaps(ctx context.Context, rosterID int64) ([]models.Conflict, error)
+}
+
+// scheduleRepository is the postgresql implementation of ScheduleRepository
+type scheduleRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewScheduleRepository creates a new instance of ScheduleRepository
+func NewScheduleRepository(db *pgxpool.Pool) ScheduleRepository {
+	return &scheduleRepository{db: db}
+}
+
+// shiftColumns are the columns scanned by scanShift
+const shiftColumns = `
+        s.id, s.roster_id, s.name, s.starts_at, s.ends_at, s.required_staff, s.created_at,
+        COALESCE((SELECT array_agg(a.employee_id ORDER BY a.employee_id)
+                  FROM scheduling.assignments a WHERE a.shift_id = s.id), '{}')
+    `
+
+// scanShift scans a row selected with shiftColumns
+func scanShift(row pgx.Row) (models.Shift, error) {
+	var s models.Shift
+	err := row.Scan(&s.ID, &s.RosterID, &s.Name, &s.StartsAt, &s.EndsAt, &s.RequiredStaff, &s.CreatedAt, &s.EmployeeIDs)
+	return s, err
+}
+
+// rosterColumns are the columns scanned by scanRoster
+const rosterColumns = `
+        id, name, department, to_char(period_start, 'YYYY-MM-DD'), to_char(period_end, 'YYYY-MM-DD'),
+        status, published_at, created_at
+    `
+
+// scanRoster scans a row selected with rosterColumns
+func scanRoster(row pgx.Row) (models.Roster, error) {
+	var r models.Roster
+	err := row.Scan(&r.ID, &r.Name, &r.Department, &r.PeriodStart, &r.PeriodEnd, &r.Status, &r.PublishedAt, &r.CreatedAt)
+	return r, err
+}
+
+// CreateRoster adds a draft roster
+func (r *scheduleRepository) CreateRoster(ctx context.Context, roster *models.Roster) error {
+	query := `
+        INSERT INTO scheduling.rosters (name, department, period_start, period_end)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, status, created_at
+    `
+
+	err := r.db.QueryRow(ctx, query, roster.Name, roster.Department, roster.PeriodStart, roster.PeriodEnd).
+		Scan(&roster.ID, &roster.Status, &roster.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create roster: %w", err)
+	}
+
+	return nil
+}
+
+// FindRoster retrieves a roster and its shifts ordered by start
+func (r *scheduleRepository) FindRoster(ctx context.Context, id int64) (*models.Roster, error) {
+	roster, err := scanRoster(r.db.QueryRow(ctx, `SELECT `+rosterColumns+` FROM scheduling.rosters WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRosterNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+shiftColumns+` FROM scheduling.shifts s WHERE s.roster_id = $1 ORDER BY s.starts_at, s.id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query roster shifts: %w", err)
+	}
+	defer rows.Close()
+
+	roster.Shifts = []models.Shift{}
+	for rows.Next() {
+		s, err := scanShift(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shift row: %w", err)
+		}
+		roster.Shifts = append(roster.Shifts, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shift rows: %w", err)
+	}
+
+	return &roster, nil
+}
+
+// FindRosters retrieves a page of rosters, latest period first
+func (r *scheduleRepository) FindRosters(ctx context.Context, department string, limit, offset int) ([]models.Roster, int, error) {
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM scheduling.rosters WHERE $1 = '' OR department = $1`, department).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count rosters: %w", err)
+	}
+
+	query := `SELECT ` + rosterColumns + `
+        FROM scheduling.rosters
+        WHERE $1 = '' OR department = $1
+        ORDER BY period_start DESC, id DESC
+        LIMIT $2 OFFSET $3
+    `
+
+	rows, err := r.db.Query(ctx, query, department, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query rosters: %w", err)
+	}
+	defer rows.Close()
+
+	rosters := []models.Roster{}
+	for rows.Next() {
+		roster, err := scanRoster(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan roster row: %w", err)
+		}
+		rosters = append(rosters, roster)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating roster rows: %w", err)
+	}
+
+	return rosters, total, nil
+}
+
+// PublishRoster marks a draft roster as published
+func (r *scheduleRepository) PublishRoster(ctx context.Context, id int64) error {
+	result, err := r.db.Exec(ctx, `
+        UPDATE scheduling.rosters
+        SET status = 'PUBLISHED', published_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = 'DRAFT'`, id)
+	if err != nil {
+		return fmt.Errorf("failed to publish roster: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		if _, err := r.FindRoster(ctx, id); err != nil {
+			return err
+		}
+		return ErrRosterPublished
+	}
+	return nil
+}
+
+// CreateShift adds a shift to a roster
+func (r *scheduleRepository) CreateShift(ctx context.Context, s *models.Shift) error {
+	query := `
+        INSERT INTO scheduling.shifts (roster_id, name, starts_at, ends_at, required_staff)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at
+    `
+
+	err := r.db.QueryRow(ctx, query, s.RosterID, s.Name, s.StartsAt, s.EndsAt, s.RequiredStaff).Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrRosterNotFound
+		}
+		return fmt.Errorf("failed to create shift: %w", err)
+	}
+
+	s.EmployeeIDs = []int64{}
+	return nil
+}
+
+// FindShift retrieves a shift with its assigned employees
+func (r *scheduleRepository) FindShift(ctx context.Context, id int64) (*models.Shift, error) {
+	s, err := scanShift(r.db.QueryRow(ctx, `SELECT `+shiftColumns+` FROM scheduling.shifts s WHERE s.id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrShiftNotFound
+		}
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// FindShifts retrieves the shifts matching filter ordered by start
+func (r *scheduleRepository) FindShifts(ctx context.Context, filter ShiftFilter) ([]models.Shift, error) {
+	query := `SELECT ` + shiftColumns + `
+        FROM scheduling.shifts s
+        JOIN scheduling.rosters r ON r.id = s.roster_id
+        WHERE ($1 = '' OR r.department = $1)
+          AND ($2 = 0 OR EXISTS (SELECT 1 FROM scheduling.assignments a WHERE a.shift_id = s.id AND a.employee_id = $2))
+          AND ($3::timestamptz IS NULL OR s.ends_at > $3)
+          AND ($4::timestamptz IS NULL OR s.starts_at < $4)
+        ORDER BY s.starts_at, s.id
+        LIMIT 1000
+    `
+
+	rows, err := r.db.Query(ctx, query, filter.Department, filter.EmployeeID, nullTime(filter.From), nullTime(filter.To))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shifts: %w", err)
+	}
+	defer rows.Close()
+
+	shifts := []models.Shift{}
+	for rows.Next() {
+		s, err := scanShift(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shift row: %w", err)
+		}
+		shifts = append(shifts, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shift rows: %w", err)
+	}
+
+	return shifts, nil
+}
+
+// DeleteShift removes a shift and its assignments
+func (r *scheduleRepository) DeleteShift(ctx context.Context, id int64) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM scheduling.shifts WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete shift: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrShiftNotFound
+	}
+
+	return nil
+}
+
+// Assign checks and inserts the assignment in one transaction. The shift
+// row and an advisory lock on the employee serialize concurrent
+// assignments, so two overlapping shifts cannot be assigned at once
+func (r *scheduleRepository) Assign(ctx context.Context, shiftID, employeeID int64) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var startsAt, endsAt time.Time
+		var required, assigned int
+		var already bool
+		err := tx.QueryRow(ctx, `
+            SELECT starts_at, ends_at, required_staff,
+                   (SELECT COUNT(*) FROM scheduling.assignments WHERE shift_id = s.id),
+                   EXISTS (SELECT 1 FROM scheduling.assignments WHERE shift_id = s.id AND employee_id = $2)
+            FROM scheduling.shifts s
+            WHERE id = $1
+            FOR UPDATE`, shiftID, employeeID).Scan(&startsAt, &endsAt, &required, &assigned, &already)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrShiftNotFound
+			}
+			return fmt.Errorf("failed to lock shift: %w", err)
+		}
+
+		switch {
+		case already:
+			return ErrAlreadyAssigned
+		case assigned >= required:
+			return ErrShiftFull
+		}
+
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('scheduling.employee'), ($1 % 2147483647)::int)`, employeeID); err != nil {
+			return fmt.Errorf("failed to lock employee schedule: %w", err)
+		}
+
+		var overlapping int64
+		err = tx.QueryRow(ctx, `
+            SELECT s.id
+            FROM scheduling.assignments a
+            JOIN scheduling.shifts s ON s.id = a.shift_id
+            WHERE a.employee_id = $1 AND s.id <> $2 AND s.starts_at < $4 AND s.ends_at > $3
+            ORDER BY s.starts_at
+            LIMIT 1`, employeeID, shiftID, startsAt, endsAt).Scan(&overlapping)
+		switch {
+		case err == nil:
+			return &OverlapError{ShiftID: overlapping}
+		case !errors.Is(err, pgx.ErrNoRows):
+			return fmt.Errorf("failed to check overlapping shifts: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO scheduling.assignments (shift_id, employee_id) VALUES ($1, $2)`, shiftID, employeeID)
+		if err != nil {
+			return fmt.Errorf("failed to assign employee: %w", err)
+		}
+		return nil
+	})
+}
+
+// Unassign removes the employee from the shift
+func (r *scheduleRepository) Unassign(ctx context.Context, shiftID, employeeID int64) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM scheduling.assignments WHERE shift_id = $1 AND employee_id = $2`, shiftID, employeeID)
+	if err != nil {
+		return fmt.Errorf("failed to unassign employee: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAssignmentMissing
+	}
+
+	return nil
+}
+
+// FindOverlaps self-joins the assignments of the roster's employees
+func (r *scheduleRepository) FindOverlaps(ctx context.Context, rosterID int64) ([]models.Conflict, error) {
+	query := `
+        SELECT s1.id, a1.employee_id, s2.id
+        FROM scheduling.shifts s1
+        JOIN scheduling.assignments a1 ON a1.shift_id = s1.id
+        JOIN scheduling.assignments a2 ON a2.employee_id = a1.employee_id AND a2.shift_id <> a1.shift_id
+        JOIN scheduling.shifts s2 ON s2.id = a2.shift_id
+        WHERE s1.roster_id = $1 AND s2.starts_at < s1.ends_at AND s2.ends_at > s1.starts_at
+        ORDER BY s1.starts_at, a1.employee_id
+    `
+
+	rows, err := r.db.Query(ctx, query, rosterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query overlapping shifts: %w", err)
+	}
+	defer rows.Close()
+
+	conflicts := []models.Conflict{}
+	for rows.Next() {
+		c := models.Conflict{Type: models.ConflictOverlap}
+		if err := rows.Scan(&c.ShiftID, &c.EmployeeID, &c.OtherShiftID); err != nil {
+			return nil, fmt.Errorf("failed to scan overlap row: %w", err)
+		}
+		c.Message = fmt.Sprintf("employee %d also works overlapping shift %d", c.EmployeeID, c.OtherShiftID)
+		conflicts = append(conflicts, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating overlap rows: %w", err)
+	}
+
+	return conflicts, nil
+}
+
+// nullTime maps the zero time to NULL
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
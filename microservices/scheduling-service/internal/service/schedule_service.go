@@ -0,0 +1,189 @@
+// Package service contains the business logic of the scheduling service
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"scheduling-service/internal/models"
+	"scheduling-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+)
+
+var (
+	// ErrEmployeeUnavailable is returned when rostering an employee who is
+	// not working (ON_VACATION or RETIRED)
+	ErrEmployeeUnavailable = errors.New("employee is not available for shifts")
+	// ErrOutsidePeriod is returned for shifts outside the roster period
+	ErrOutsidePeriod = errors.New("shift is outside the roster period")
+	// ErrShiftTooLong is returned for shifts longer than the configured max
+	ErrShiftTooLong = errors.New("shift is longer than allowed")
+)
+
+// ConflictsError is returned when publishing a roster with conflicts
+type ConflictsError struct {
+	Conflicts []models.Conflict
+}
+
+func (e *ConflictsError) Error() string {
+	return fmt.Sprintf("roster has %d conflicts", len(e.Conflicts))
+}
+
+// ScheduleService manages rosters and shift assignments, checking the
+// employees against employee-management
+type ScheduleService struct {
+	repo           repository.ScheduleRepository
+	employees      *employees.Client
+	maxShiftLength time.Duration
+}
+
+// NewScheduleService creates a new ScheduleService instance
+func NewScheduleService(repo repository.ScheduleRepository, employeeClient *employees.Client, maxShiftLength time.Duration) *ScheduleService {
+	return &ScheduleService{repo: repo, employees: employeeClient, maxShiftLength: maxShiftLength}
+}
+
+// CreateRoster adds a draft roster
+func (s *ScheduleService) CreateRoster(ctx context.Context, r *models.Roster) error {
+	return s.repo.CreateRoster(ctx, r)
+}
+
+// FindRoster retrieves a roster with its shifts
+func (s *ScheduleService) FindRoster(ctx context.Context, id int64) (*models.Roster, error) {
+	return s.repo.FindRoster(ctx, id)
+}
+
+// FindRosters retrieves a page of rosters
+func (s *ScheduleService) FindRosters(ctx context.Context, department string, page, pageSize int) ([]models.Roster, int, error) {
+	return s.repo.FindRosters(ctx, department, pageSize, (page-1)*pageSize)
+}
+
+// CreateShift adds a shift inside the roster period
+func (s *ScheduleService) CreateShift(ctx context.Context, shift *models.Shift) error {
+	roster, err := s.repo.FindRoster(ctx, shift.RosterID)
+	if err != nil {
+		return err
+	}
+
+	if shift.EndsAt.Sub(shift.StartsAt) > s.maxShiftLength {
+		return ErrShiftTooLong
+	}
+
+	// The period is inclusive, so a shift may end at midnight after the
+	// last day
+	start, _ := time.Parse(time.DateOnly, roster.PeriodStart)
+	end, _ := time.Parse(time.DateOnly, roster.PeriodEnd)
+	if shift.StartsAt.Before(start) || shift.EndsAt.After(end.AddDate(0, 0, 1)) {
+		return ErrOutsidePeriod
+	}
+
+	return s.repo.CreateShift(ctx, shift)
+}
+
+// FindShift retrieves a shift
+func (s *ScheduleService) FindShift(ctx context.Context, id int64) (*models.Shift, error) {
+	return s.repo.FindShift(ctx, id)
+}
+
+// FindShifts retrieves the shifts matching filter
+func (s *ScheduleService) FindShifts(ctx context.Context, filter repository.ShiftFilter) ([]models.Shift, error) {
+	return s.repo.FindShifts(ctx, filter)
+}
+
+// DeleteShift removes a shift
+func (s *ScheduleService) DeleteShift(ctx context.Context, id int64) error {
+	return s.repo.DeleteShift(ctx, id)
+}
+
+// Assign rosters the employee on the shift. The employee must exist and
+// be ACTIVE or on PROBATION in employee-management
+func (s *ScheduleService) Assign(ctx context.Context, shiftID, employeeID int64) error {
+	employee, err := s.employees.Get(ctx, employeeID)
+	if err != nil {
+		return err
+	}
+	if !employees.Working(employee.Status) {
+		return fmt.Errorf("%w: status is %s", ErrEmployeeUnavailable, employee.Status)
+	}
+
+	return s.repo.Assign(ctx, shiftID, employeeID)
+}
+
+// Unassign removes the employee from the shift
+func (s *ScheduleService) Unassign(ctx context.Context, shiftID, employeeID int64) error {
+	return s.repo.Unassign(ctx, shiftID, employeeID)
+}
+
+// Conflicts lists what prevents the roster from being published:
+// overlapping shifts, employees who no longer work (their status may
+// have changed since they were assigned) and understaffed shifts
+func (s *ScheduleService) Conflicts(ctx context.Context, rosterID int64) ([]models.Conflict, error) {
+	roster, err := s.repo.FindRoster(ctx, rosterID)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, err := s.repo.FindOverlaps(ctx, rosterID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := map[int64]string{}
+	for _, shift := range roster.Shifts {
+		if len(shift.EmployeeIDs) < shift.RequiredStaff {
+			conflicts = append(conflicts, models.Conflict{
+				Type:    models.ConflictUnderstaffed,
+				ShiftID: shift.ID,
+				Message: fmt.Sprintf("shift has %d of %d required employees", len(shift.EmployeeIDs), shift.RequiredStaff),
+			})
+		}
+
+		for _, id := range shift.EmployeeIDs {
+			status, seen := statuses[id]
+			if !seen {
+				employee, err := s.employees.Get(ctx, id)
+				switch {
+				case errors.Is(err, employees.ErrNotFound):
+					status = "DELETED"
+				case err != nil:
+					return nil, err
+				default:
+					status = employee.Status
+				}
+				statuses[id] = status
+			}
+
+			if !employees.Working(status) {
+				conflicts = append(conflicts, models.Conflict{
+					Type:       models.ConflictUnavailable,
+					ShiftID:    shift.ID,
+					EmployeeID: id,
+					Message:    fmt.Sprintf("employee %d is %s", id, status),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(conflicts, func(i, j int) bool { return conflicts[i].ShiftID < conflicts[j].ShiftID })
+	return conflicts, nil
+}
+
+// Publish publishes the roster if it has no conflicts, otherwise returns
+// a *ConflictsError listing them
+func (s *ScheduleService) Publish(ctx context.Context, rosterID int64) (*models.Roster, error) {
+	conflicts, err := s.Conflicts(ctx, rosterID)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		return nil, &ConflictsError{Conflicts: conflicts}
+	}
+
+	if err := s.repo.PublishRoster(ctx, rosterID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindRoster(ctx, rosterID)
+}
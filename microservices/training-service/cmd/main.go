@@ -0,0 +1,95 @@
+package main
+
+//	@title			Training Service API
+//	@version		1.0
+//	@description	Required trainings and certifications per position, employee completions and expiry alerts
+//	@termsOfService	http://swagger.io/terms/
+
+//	@contact.name	API Support
+//	@contact.email	josed.amayar@uqvirtual.edu.co
+
+//	@host		localhost:8085
+//	@BasePath	/training-service/api/v1
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"training-service/internal/alerts"
+	"training-service/internal/config"
+	"training-service/internal/db"
+	"training-service/internal/handlers"
+	"training-service/internal/repository"
+	"training-service/internal/service"
+
+	_ "training-service/docs" // Swagger docs
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+func main() {
+	cfg := config.Load()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbPool := db.NewPostgresPool(cfg)
+	defer dbPool.Close()
+
+	if cfg.MigrateOnStartup {
+		if err := db.Migrate(ctx, dbPool); err != nil {
+			log.Fatalf("database migration failed: %v", err)
+		}
+	}
+
+	// Positions and departments come from employee-management
+	employeeClient := employees.NewClient(cfg.EmployeeServiceURL, cfg.EmployeeServiceTimeout)
+	repo := repository.NewTrainingRepository(dbPool)
+	trainingService := service.NewTrainingService(repo, employeeClient, cfg.AlertWindow)
+
+	go alerts.New(repo, employeeClient, cfg.AlertWindow, cfg.AlertInterval).Run(ctx)
+
+	handler := handlers.NewTrainingHandler(trainingService)
+	healthHandler := httpkit.NewHealthHandler("training-service", dbPool)
+
+	router := httpkit.NewRouter()
+
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	v1 := router.Group("/training-service/api/v1")
+	{
+		v1.GET("/health", healthHandler.HealthCheck)
+
+		v1.POST("/courses", handler.CreateCourse)
+		v1.GET("/courses", handler.GetAllCourses)
+		v1.GET("/courses/:id", handler.GetCourseByID)
+		v1.POST("/courses/:id/requirements", handler.AddRequirement)
+		v1.GET("/courses/:id/requirements", handler.GetRequirements)
+		v1.DELETE("/courses/:id/requirements", handler.RemoveRequirement)
+
+		v1.POST("/employees/:id/completions", handler.AddCompletion)
+		v1.GET("/employees/:id/completions", handler.GetCompletions)
+		v1.GET("/employees/:id/compliance", handler.GetCompliance)
+
+		v1.GET("/alerts", handler.GetAllAlerts)
+		v1.POST("/alerts/:id/acknowledge", handler.AcknowledgeAlert)
+	}
+
+	srv := &http.Server{Addr: ":" + cfg.ServerPort, Handler: router}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	log.Printf("Training service running on :%s", cfg.ServerPort)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
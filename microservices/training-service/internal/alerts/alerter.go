@@ -0,0 +1,108 @@
+// Package alerts raises alerts for required certifications that are about
+// to expire or have expired
+package alerts
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"training-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+)
+
+// Alerter periodically checks the expiring completions against the
+// current position and department of each employee
+type Alerter struct {
+	repo      repository.TrainingRepository
+	employees *employees.Client
+	window    time.Duration
+	interval  time.Duration
+}
+
+// New creates a new Alerter
+func New(repo repository.TrainingRepository, employeeClient *employees.Client, window, interval time.Duration) *Alerter {
+	return &Alerter{repo: repo, employees: employeeClient, window: window, interval: interval}
+}
+
+// Run checks every interval until ctx is done
+func (a *Alerter) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		if err := a.check(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("expiry check failed: %v", err)
+		}
+		timer.Reset(a.interval)
+	}
+}
+
+// check raises an alert for each due completion of a course still
+// required for the employee. Completions of courses no longer required,
+// of retired or deleted employees, are skipped
+func (a *Alerter) check(ctx context.Context) error {
+	before := time.Now().UTC().Add(a.window).Format(time.DateOnly)
+	due, err := a.repo.FindUnalerted(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	// Required course ids per employee, fetched once per check
+	required := map[int64]map[int64]bool{}
+	for _, alert := range due {
+		courses, seen := required[alert.EmployeeID]
+		if !seen {
+			courses, err = a.requiredCourses(ctx, alert.EmployeeID)
+			if err != nil {
+				return err
+			}
+			required[alert.EmployeeID] = courses
+		}
+		if !courses[alert.CourseID] {
+			continue
+		}
+
+		if err := a.repo.CreateAlert(ctx, &alert); err != nil {
+			return err
+		}
+		if alert.ID != 0 {
+			log.Printf("certification alert: %s %s of employee %d on %s", alert.CourseName, alert.Kind, alert.EmployeeID, alert.ExpiresOn)
+		}
+	}
+
+	return nil
+}
+
+// requiredCourses returns the courses required for the employee, none if
+// they were deleted or retired
+func (a *Alerter) requiredCourses(ctx context.Context, employeeID int64) (map[int64]bool, error) {
+	employee, err := a.employees.Get(ctx, employeeID)
+	switch {
+	case errors.Is(err, employees.ErrNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	case employee.Status == employees.StatusRetired:
+		return nil, nil
+	}
+
+	courses, err := a.repo.FindRequiredCourses(ctx, employee.Position, employee.Department)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[int64]bool, len(courses))
+	for _, c := range courses {
+		ids[c.ID] = true
+	}
+	return ids, nil
+}
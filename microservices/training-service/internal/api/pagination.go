@@ -0,0 +1,12 @@
+// Package api holds the query parameters of the list endpoints
+package api
+
+import "github.com/Josedzzz/microservices-fp/pkg/httpkit"
+
+// PaginationQuery represents the query parameters of the alert list
+type PaginationQuery struct {
+	httpkit.PageQuery
+	EmployeeID int64  `form:"employeeId" binding:"omitempty,min=1"`
+	Kind       string `form:"kind" binding:"omitempty,oneof=EXPIRING EXPIRED"`
+	Open       bool   `form:"open"`
+}
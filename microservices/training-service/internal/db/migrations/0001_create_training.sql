@@ -0,0 +1,50 @@
+CREATE TABLE IF NOT EXISTS training.courses (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	name VARCHAR(255) NOT NULL UNIQUE,
+	kind VARCHAR(20) NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	-- NULL for trainings that never expire
+	validity_days INTEGER CHECK (validity_days > 0),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- An empty department applies the requirement to the position in every
+-- department
+CREATE TABLE IF NOT EXISTS training.requirements (
+	course_id BIGINT NOT NULL REFERENCES training.courses (id) ON DELETE CASCADE,
+	position VARCHAR(100) NOT NULL,
+	department VARCHAR(100) NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (course_id, position, department)
+);
+
+CREATE INDEX IF NOT EXISTS requirements_position_idx ON training.requirements (position, department);
+
+CREATE TABLE IF NOT EXISTS training.completions (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	employee_id BIGINT NOT NULL,
+	course_id BIGINT NOT NULL REFERENCES training.courses (id) ON DELETE CASCADE,
+	completed_on DATE NOT NULL,
+	expires_on DATE,
+	certificate_ref VARCHAR(255) NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS completions_employee_course_idx ON training.completions (employee_id, course_id, completed_on DESC);
+CREATE INDEX IF NOT EXISTS completions_expires_idx ON training.completions (expires_on) WHERE expires_on IS NOT NULL;
+
+-- One alert per completion and kind, so each expiry is raised once before
+-- and once after the date
+CREATE TABLE IF NOT EXISTS training.alerts (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	completion_id BIGINT NOT NULL REFERENCES training.completions (id) ON DELETE CASCADE,
+	employee_id BIGINT NOT NULL,
+	course_id BIGINT NOT NULL,
+	kind VARCHAR(20) NOT NULL,
+	expires_on DATE NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	acknowledged_at TIMESTAMPTZ,
+	UNIQUE (completion_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS alerts_open_idx ON training.alerts (created_at) WHERE acknowledged_at IS NULL;
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"training-service/internal/api"
+	"training-service/internal/models"
+	"training-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// GetAllAlerts godoc
+//
+//	@Summary		List expiry alerts
+//	@Description	Retrieves the alerts raised for required courses expiring soon (EXPIRING) or expired (EXPIRED), newest first
+//	@Tags			Alerts
+//	@Produce		json
+//	@Param			page		query		int					false	"Page number"	default(1)
+//	@Param			page_size	query		int					false	"Page size"		default(20)
+//	@Param			employeeId	query		int					false	"Employee id"
+//	@Param			kind		query		string				false	"Alert kind"	Enums(EXPIRING, EXPIRED)
+//	@Param			open		query		bool				false	"Only alerts not acknowledged"
+//	@Success		200			{object}	httpkit.PaginatedResponse{data=[]models.Alert}	"Alerts"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid query parameters"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/alerts [get]
+func (h *TrainingHandler) GetAllAlerts(c *gin.Context) {
+	var query api.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httpkit.BadRequest(c, "Invalid query parameters")
+		return
+	}
+	query.Normalize()
+
+	filter := repository.AlertFilter{
+		EmployeeID: query.EmployeeID,
+		Kind:       models.AlertKind(query.Kind),
+		Open:       query.Open,
+	}
+
+	alerts, total, err := h.service.FindAlerts(c.Request.Context(), filter, query.Page, query.PageSize)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve alerts")
+		return
+	}
+
+	c.JSON(http.StatusOK, httpkit.Paginate(alerts, query.PageQuery, total))
+}
+
+// AcknowledgeAlert godoc
+//
+//	@Summary		Acknowledge an alert
+//	@Description	Marks an alert as handled
+//	@Tags			Alerts
+//	@Param			id	path	int	true	"Alert ID"
+//	@Success		204	"Alert acknowledged (no content)"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid alert ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Alert not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/alerts/{id}/acknowledge [post]
+func (h *TrainingHandler) AcknowledgeAlert(c *gin.Context) {
+	id, ok := pathID(c, "Invalid alert ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.AcknowledgeAlert(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAlertNotFound):
+			httpkit.NotFound(c, "Alert not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to acknowledge alert")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
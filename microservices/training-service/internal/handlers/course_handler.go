@@ -0,0 +1,275 @@
+// Package handlers exposes the training service over HTTP
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"training-service/internal/models"
+	"training-service/internal/repository"
+	"training-service/internal/service"
+
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// TrainingHandler handles HTTP requests for courses, requirements,
+// completions and alerts
+type TrainingHandler struct {
+	service *service.TrainingService
+}
+
+// NewTrainingHandler creates a new TrainingHandler instance
+func NewTrainingHandler(s *service.TrainingService) *TrainingHandler {
+	return &TrainingHandler{service: s}
+}
+
+// CourseRequest is the payload to add a course
+type CourseRequest struct {
+	Name         string            `json:"name" example:"Forklift operation"`
+	Kind         models.CourseKind `json:"kind" example:"CERTIFICATION"`
+	Description  string            `json:"description"`
+	ValidityDays *int              `json:"validityDays" example:"365"`
+}
+
+// RequirementRequest is the payload to require a course for a position
+type RequirementRequest struct {
+	Position   string `json:"position" example:"Warehouse Operator"`
+	Department string `json:"department" example:"Logistics"`
+}
+
+// CreateCourse godoc
+//
+//	@Summary		Add a course
+//	@Description	Adds a training or certification. Completions expire validityDays after completion, never when omitted
+//	@Tags			Courses
+//	@Accept			json
+//	@Produce		json
+//	@Param			course	body		CourseRequest		true	"Course data"
+//	@Success		201		{object}	models.Course		"Course created"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid JSON format or validation failed"
+//	@Failure		409		{object}	httpkit.ErrorResponse	"Course name already exists"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/courses [post]
+func (h *TrainingHandler) CreateCourse(c *gin.Context) {
+	var req CourseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	course := models.Course{
+		Name:         strings.TrimSpace(req.Name),
+		Kind:         req.Kind,
+		Description:  strings.TrimSpace(req.Description),
+		ValidityDays: req.ValidityDays,
+	}
+	switch {
+	case course.Name == "":
+		httpkit.BadRequest(c, "Name is required")
+		return
+	case course.Kind != models.KindTraining && course.Kind != models.KindCertification:
+		httpkit.BadRequest(c, "Kind must be TRAINING or CERTIFICATION")
+		return
+	case course.ValidityDays != nil && *course.ValidityDays < 1:
+		httpkit.BadRequest(c, "Validity days must be at least 1")
+		return
+	}
+
+	if err := h.service.CreateCourse(c.Request.Context(), &course); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCourseExists):
+			httpkit.Error(c, http.StatusConflict, "Course name already exists")
+		default:
+			httpkit.InternalServerError(c, "Failed to create course")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, course)
+}
+
+// GetAllCourses godoc
+//
+//	@Summary		List courses
+//	@Description	Retrieves the courses by name, optionally of one kind
+//	@Tags			Courses
+//	@Produce		json
+//	@Param			kind	query		string				false	"Course kind"	Enums(TRAINING, CERTIFICATION)
+//	@Success		200		{array}		models.Course		"Courses"
+//	@Failure		400		{object}	httpkit.ErrorResponse	"Invalid kind"
+//	@Failure		500		{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/courses [get]
+func (h *TrainingHandler) GetAllCourses(c *gin.Context) {
+	kind := models.CourseKind(c.Query("kind"))
+	if kind != "" && kind != models.KindTraining && kind != models.KindCertification {
+		httpkit.BadRequest(c, "Invalid kind")
+		return
+	}
+
+	courses, err := h.service.FindCourses(c.Request.Context(), kind)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve courses")
+		return
+	}
+
+	c.JSON(http.StatusOK, courses)
+}
+
+// GetCourseByID godoc
+//
+//	@Summary		Get a course
+//	@Description	Retrieves a course by its ID
+//	@Tags			Courses
+//	@Produce		json
+//	@Param			id	path		int					true	"Course ID"
+//	@Success		200	{object}	models.Course		"Course"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid course ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Course not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/courses/{id} [get]
+func (h *TrainingHandler) GetCourseByID(c *gin.Context) {
+	id, ok := pathID(c, "Invalid course ID")
+	if !ok {
+		return
+	}
+
+	course, err := h.service.FindCourse(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCourseNotFound):
+			httpkit.NotFound(c, "Course not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve course")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, course)
+}
+
+// AddRequirement godoc
+//
+//	@Summary		Require a course
+//	@Description	Makes the course mandatory for a position, in one department or in all of them when department is empty
+//	@Tags			Requirements
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Course ID"
+//	@Param			requirement	body		RequirementRequest	true	"Position and department"
+//	@Success		201			{object}	models.Requirement	"Requirement added"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid course ID or JSON format"
+//	@Failure		404			{object}	httpkit.ErrorResponse	"Course not found"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/courses/{id}/requirements [post]
+func (h *TrainingHandler) AddRequirement(c *gin.Context) {
+	id, ok := pathID(c, "Invalid course ID")
+	if !ok {
+		return
+	}
+
+	var req RequirementRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Position) == "" {
+		httpkit.BadRequest(c, "Position is required")
+		return
+	}
+
+	requirement := models.Requirement{
+		CourseID:   id,
+		Position:   strings.TrimSpace(req.Position),
+		Department: strings.TrimSpace(req.Department),
+	}
+	if err := h.service.AddRequirement(c.Request.Context(), &requirement); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCourseNotFound):
+			httpkit.NotFound(c, "Course not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to add requirement")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, requirement)
+}
+
+// GetRequirements godoc
+//
+//	@Summary		Requirements of a course
+//	@Description	Retrieves the positions the course is required for
+//	@Tags			Requirements
+//	@Produce		json
+//	@Param			id	path		int					true	"Course ID"
+//	@Success		200	{array}		models.Requirement	"Requirements"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid course ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Course not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/courses/{id}/requirements [get]
+func (h *TrainingHandler) GetRequirements(c *gin.Context) {
+	id, ok := pathID(c, "Invalid course ID")
+	if !ok {
+		return
+	}
+
+	requirements, err := h.service.FindRequirements(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCourseNotFound):
+			httpkit.NotFound(c, "Course not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to retrieve requirements")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, requirements)
+}
+
+// RemoveRequirement godoc
+//
+//	@Summary		Remove a requirement
+//	@Description	Stops requiring the course for a position
+//	@Tags			Requirements
+//	@Param			id			path	int		true	"Course ID"
+//	@Param			position	query	string	true	"Position"
+//	@Param			department	query	string	false	"Department, empty for the all departments requirement"
+//	@Success		204			"Requirement removed (no content)"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid course ID or position"
+//	@Failure		404			{object}	httpkit.ErrorResponse	"Requirement not found"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/courses/{id}/requirements [delete]
+func (h *TrainingHandler) RemoveRequirement(c *gin.Context) {
+	id, ok := pathID(c, "Invalid course ID")
+	if !ok {
+		return
+	}
+
+	position := strings.TrimSpace(c.Query("position"))
+	if position == "" {
+		httpkit.BadRequest(c, "Position is required")
+		return
+	}
+
+	if err := h.service.RemoveRequirement(c.Request.Context(), id, position, strings.TrimSpace(c.Query("department"))); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRequirementNotFound):
+			httpkit.NotFound(c, "Requirement not found")
+		default:
+			httpkit.InternalServerError(c, "Failed to remove requirement")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// pathID parses the positive id path parameter, answering 400 otherwise
+func pathID(c *gin.Context, message string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		httpkit.BadRequest(c, message)
+		return 0, false
+	}
+	return id, true
+}
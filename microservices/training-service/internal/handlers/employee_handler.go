@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"training-service/internal/models"
+	"training-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+	"github.com/Josedzzz/microservices-fp/pkg/httpkit"
+	"github.com/gin-gonic/gin"
+)
+
+// CompletionRequest is the payload to record a completed course
+type CompletionRequest struct {
+	CourseID       int64  `json:"courseId" example:"1"`
+	CompletedOn    string `json:"completedOn" example:"2026-03-01"`
+	CertificateRef string `json:"certificateRef" example:"CERT-12345"`
+}
+
+// AddCompletion godoc
+//
+//	@Summary		Record a completion
+//	@Description	Records an employee completing a course on a day, its expiry follows from the course validity
+//	@Tags			Completions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Employee ID"
+//	@Param			completion	body		CompletionRequest	true	"Completion data"
+//	@Success		201			{object}	models.Completion	"Completion recorded"
+//	@Failure		400			{object}	httpkit.ErrorResponse	"Invalid employee ID, JSON format or date"
+//	@Failure		404			{object}	httpkit.ErrorResponse	"Employee or course not found"
+//	@Failure		500			{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Failure		503			{object}	httpkit.ErrorResponse	"Employee service unavailable"
+//	@Router			/employees/{id}/completions [post]
+func (h *TrainingHandler) AddCompletion(c *gin.Context) {
+	employeeID, ok := pathID(c, "Invalid employee ID")
+	if !ok {
+		return
+	}
+
+	var req CompletionRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.CourseID < 1 {
+		httpkit.BadRequest(c, "Invalid JSON format")
+		return
+	}
+
+	completedOn, err := time.Parse(time.DateOnly, req.CompletedOn)
+	if err != nil {
+		httpkit.BadRequest(c, "Completed on must be a YYYY-MM-DD date")
+		return
+	}
+	if completedOn.After(time.Now().UTC()) {
+		httpkit.BadRequest(c, "Completed on must not be in the future")
+		return
+	}
+
+	completion := models.Completion{
+		EmployeeID:     employeeID,
+		CourseID:       req.CourseID,
+		CompletedOn:    req.CompletedOn,
+		CertificateRef: strings.TrimSpace(req.CertificateRef),
+	}
+	if err := h.service.AddCompletion(c.Request.Context(), &completion); err != nil {
+		switch {
+		case errors.Is(err, employees.ErrNotFound):
+			httpkit.NotFound(c, "Employee not found")
+		case errors.Is(err, repository.ErrCourseNotFound):
+			httpkit.NotFound(c, "Course not found")
+		case errors.Is(err, employees.ErrUnavailable):
+			httpkit.Error(c, http.StatusServiceUnavailable, "Employee service unavailable")
+		default:
+			httpkit.InternalServerError(c, "Failed to record completion")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, completion)
+}
+
+// GetCompletions godoc
+//
+//	@Summary		Completions of an employee
+//	@Description	Retrieves every course completion of an employee, newest first
+//	@Tags			Completions
+//	@Produce		json
+//	@Param			id	path		int					true	"Employee ID"
+//	@Success		200	{array}		models.Completion	"Completions"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid employee ID"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Router			/employees/{id}/completions [get]
+func (h *TrainingHandler) GetCompletions(c *gin.Context) {
+	employeeID, ok := pathID(c, "Invalid employee ID")
+	if !ok {
+		return
+	}
+
+	completions, err := h.service.FindCompletions(c.Request.Context(), employeeID)
+	if err != nil {
+		httpkit.InternalServerError(c, "Failed to retrieve completions")
+		return
+	}
+
+	c.JSON(http.StatusOK, completions)
+}
+
+// GetCompliance godoc
+//
+//	@Summary		Training status of an employee
+//	@Description	Checks the courses required for the employee's current position and department in employee-management against their latest completions
+//	@Tags			Completions
+//	@Produce		json
+//	@Param			id	path		int					true	"Employee ID"
+//	@Success		200	{object}	models.Compliance	"Required courses with their status"
+//	@Failure		400	{object}	httpkit.ErrorResponse	"Invalid employee ID"
+//	@Failure		404	{object}	httpkit.ErrorResponse	"Employee not found"
+//	@Failure		500	{object}	httpkit.ErrorResponse	"Internal server error"
+//	@Failure		503	{object}	httpkit.ErrorResponse	"Employee service unavailable"
+//	@Router			/employees/{id}/compliance [get]
+func (h *TrainingHandler) GetCompliance(c *gin.Context) {
+	employeeID, ok := pathID(c, "Invalid employee ID")
+	if !ok {
+		return
+	}
+
+	compliance, err := h.service.Compliance(c.Request.Context(), employeeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, employees.ErrNotFound):
+			httpkit.NotFound(c, "Employee not found")
+		case errors.Is(err, employees.ErrUnavailable):
+			httpkit.Error(c, http.StatusServiceUnavailable, "Employee service unavailable")
+		default:
+			httpkit.InternalServerError(c, "Failed to check compliance")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, compliance)
+}
@@ -0,0 +1,94 @@
+// Package models define the core data structures of the training service
+package models
+
+import "time"
+
+// CourseKind tells trainings and certifications apart
+type CourseKind string
+
+const (
+	KindTraining      CourseKind = "TRAINING"
+	KindCertification CourseKind = "CERTIFICATION"
+)
+
+// Course is a training or certification employees complete
+type Course struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Kind        CourseKind `json:"kind"`
+	Description string     `json:"description"`
+	// ValidityDays is how long a completion stays valid, nil if it never
+	// expires
+	ValidityDays *int      `json:"validityDays,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Requirement makes a course mandatory for a position, in one department
+// or in all of them when Department is empty
+type Requirement struct {
+	CourseID   int64     `json:"courseId"`
+	Position   string    `json:"position"`
+	Department string    `json:"department,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// Completion records an employee finishing a course
+type Completion struct {
+	ID             int64     `json:"id"`
+	EmployeeID     int64     `json:"employeeId"`
+	CourseID       int64     `json:"courseId"`
+	CompletedOn    string    `json:"completedOn" example:"2026-03-01"`
+	ExpiresOn      *string   `json:"expiresOn,omitempty" example:"2027-03-01"`
+	CertificateRef string    `json:"certificateRef,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// ComplianceStatus is the state of a required course for an employee
+type ComplianceStatus string
+
+const (
+	StatusCompleted ComplianceStatus = "COMPLETED"
+	// StatusExpiring is completed but expires within the alert window
+	StatusExpiring ComplianceStatus = "EXPIRING"
+	StatusExpired  ComplianceStatus = "EXPIRED"
+	StatusMissing  ComplianceStatus = "MISSING"
+)
+
+// CourseStatus is a required course with the latest completion of the
+// employee
+type CourseStatus struct {
+	Course     Course           `json:"course"`
+	Status     ComplianceStatus `json:"status"`
+	Completion *Completion      `json:"completion,omitempty"`
+}
+
+// Compliance lists the required courses of an employee for their current
+// position and department
+type Compliance struct {
+	EmployeeID int64          `json:"employeeId"`
+	Position   string         `json:"position"`
+	Department string         `json:"department"`
+	Compliant  bool           `json:"compliant"`
+	Courses    []CourseStatus `json:"courses"`
+}
+
+// AlertKind tells whether an alert warns before or after the expiry
+type AlertKind string
+
+const (
+	AlertExpiring AlertKind = "EXPIRING"
+	AlertExpired  AlertKind = "EXPIRED"
+)
+
+// Alert is an expiry of a required course completion
+type Alert struct {
+	ID             int64      `json:"id"`
+	CompletionID   int64      `json:"completionId"`
+	EmployeeID     int64      `json:"employeeId"`
+	CourseID       int64      `json:"courseId"`
+	CourseName     string     `json:"courseName"`
+	Kind           AlertKind  `json:"kind"`
+	ExpiresOn      string     `json:"expiresOn" example:"2027-03-01"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"`
+}
@@ -0,0 +1,422 @@
+// Package repository implements the data access layer of the training
+// service
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"training-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Errors returned by TrainingRepository
+var (
+	ErrCourseNotFound      = errors.New("course not found")
+	ErrCourseExists        = errors.New("course name already exists")
+	ErrRequirementNotFound = errors.New("requirement not found")
+	ErrAlertNotFound       = errors.New("alert not found")
+)
+
+// AlertFilter narrows FindAlerts, zero values match everything
+type AlertFilter struct {
+	EmployeeID int64
+	Kind       models.AlertKind
+	// Open keeps only alerts that were not acknowledged
+	Open bool
+}
+
+// TrainingRepository defines the interface for training data operations
+type TrainingRepository interface {
+	CreateCourse(ctx context.Context, c *models.Course) error
+	FindCourse(ctx context.Context, id int64) (*models.Course, error)
+	FindCourses(ctx context.Context, kind models.CourseKind) ([]models.Course, error)
+
+	AddRequirement(ctx context.Context, r *models.Requirement) error
+	RemoveRequirement(ctx context.Context, courseID int64, position, department string) error
+	FindRequirements(ctx context.Context, courseID int64) ([]models.Requirement, error)
+	// FindRequiredCourses returns the courses required for the position,
+	// in the department or in every department
+	FindRequiredCourses(ctx context.Context, position, department string) ([]models.Course, error)
+
+	// AddCompletion stores the completion, computing its expiry from the
+	// validity of the course
+	AddCompletion(ctx context.Context, c *models.Completion) error
+	FindCompletions(ctx context.Context, employeeID int64) ([]models.Completion, error)
+	// LatestCompletions returns the latest completion of each course the
+	// employee completed, keyed by course id
+	LatestCompletions(ctx context.Context, employeeID int64) (map[int64]models.Completion, error)
+
+	// FindUnalerted returns the latest completions expiring on or before
+	// the date (YYYY-MM-DD) that have no alert of the kind their expiry
+	// calls for yet
+	FindUnalerted(ctx context.Context, before string) ([]models.Alert, error)
+	CreateAlert(ctx context.Context, a *models.Alert) error
+	FindAlerts(ctx context.Context, filter AlertFilter, limit, offset int) ([]models.Alert, int, error)
+	AcknowledgeAlert(ctx context.Context, id int64) error
+}
+
+// trainingRepository is the postgresql implementation of TrainingRepository
+type trainingRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewTrainingRepository creates a new instance of TrainingRepository
+func NewTrainingRepository(db *pgxpool.Pool) TrainingRepository {
+	return &trainingRepository{db: db}
+}
+
+// courseColumns are the columns scanned by scanCourse
+const courseColumns = `c.id, c.name, c.kind, c.description, c.validity_days, c.created_at`
+
+// scanCourse scans a row selected with courseColumns
+func scanCourse(row pgx.Row) (models.Course, error) {
+	var c models.Course
+	err := row.Scan(&c.ID, &c.Name, &c.Kind, &c.Description, &c.ValidityDays, &c.CreatedAt)
+	return c, err
+}
+
+// completionColumns are the columns scanned by scanCompletion
+const completionColumns = `
+        id, employee_id, course_id, to_char(completed_on, 'YYYY-MM-DD'), to_char(expires_on, 'YYYY-MM-DD'),
+        certificate_ref, created_at
+    `
+
+// scanCompletion scans a row selected with completionColumns
+func scanCompletion(row pgx.Row) (models.Completion, error) {
+	var c models.Completion
+	err := row.Scan(&c.ID, &c.EmployeeID, &c.CourseID, &c.CompletedOn, &c.ExpiresOn, &c.CertificateRef, &c.CreatedAt)
+	return c, err
+}
+
+// CreateCourse adds a course
+func (r *trainingRepository) CreateCourse(ctx context.Context, c *models.Course) error {
+	query := `
+        INSERT INTO training.courses (name, kind, description, validity_days)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at
+    `
+
+	err := r.db.QueryRow(ctx, query, c.Name, c.Kind, c.Description, c.ValidityDays).Scan(&c.ID, &c.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrCourseExists
+		}
+		return fmt.Errorf("failed to create course: %w", err)
+	}
+
+	return nil
+}
+
+// FindCourse retrieves a course
+func (r *trainingRepository) FindCourse(ctx context.Context, id int64) (*models.Course, error) {
+	c, err := scanCourse(r.db.QueryRow(ctx, `SELECT `+courseColumns+` FROM training.courses c WHERE c.id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCourseNotFound
+		}
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// FindCourses retrieves the courses by name, optionally of one kind
+func (r *trainingRepository) FindCourses(ctx context.Context, kind models.CourseKind) ([]models.Course, error) {
+	return r.queryCourses(ctx, `SELECT `+courseColumns+` FROM training.courses c WHERE ($1 = '' OR c.kind = $1) ORDER BY c.name`, kind)
+}
+
+// FindRequiredCourses retrieves the courses required for the position
+func (r *trainingRepository) FindRequiredCourses(ctx context.Context, position, department string) ([]models.Course, error) {
+	return r.queryCourses(ctx, `SELECT DISTINCT `+courseColumns+`
+        FROM training.courses c
+        JOIN training.requirements rq ON rq.course_id = c.id
+        WHERE rq.position = $1 AND (rq.department = '' OR rq.department = $2)
+        ORDER BY c.name`, position, department)
+}
+
+func (r *trainingRepository) queryCourses(ctx context.Context, query string, args ...any) ([]models.Course, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query courses: %w", err)
+	}
+	defer rows.Close()
+
+	courses := []models.Course{}
+	for rows.Next() {
+		c, err := scanCourse(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan course row: %w", err)
+		}
+		courses = append(courses, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating course rows: %w", err)
+	}
+
+	return courses, nil
+}
+
+// AddRequirement makes the course mandatory for the position, adding an
+// existing requirement again is a no-op
+func (r *trainingRepository) AddRequirement(ctx context.Context, rq *models.Requirement) error {
+	query := `
+        INSERT INTO training.requirements (course_id, position, department)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (course_id, position, department) DO UPDATE SET position = EXCLUDED.position
+        RETURNING created_at
+    `
+
+	err := r.db.QueryRow(ctx, query, rq.CourseID, rq.Position, rq.Department).Scan(&rq.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrCourseNotFound
+		}
+		return fmt.Errorf("failed to add requirement: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveRequirement drops a requirement
+func (r *trainingRepository) RemoveRequirement(ctx context.Context, courseID int64, position, department string) error {
+	result, err := r.db.Exec(ctx, `
+        DELETE FROM training.requirements
+        WHERE course_id = $1 AND position = $2 AND department = $3`, courseID, position, department)
+	if err != nil {
+		return fmt.Errorf("failed to remove requirement: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrRequirementNotFound
+	}
+
+	return nil
+}
+
+// FindRequirements retrieves the positions a course is required for
+func (r *trainingRepository) FindRequirements(ctx context.Context, courseID int64) ([]models.Requirement, error) {
+	if _, err := r.FindCourse(ctx, courseID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+        SELECT course_id, position, department, created_at
+        FROM training.requirements
+        WHERE course_id = $1
+        ORDER BY position, department`, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query requirements: %w", err)
+	}
+	defer rows.Close()
+
+	requirements := []models.Requirement{}
+	for rows.Next() {
+		var rq models.Requirement
+		if err := rows.Scan(&rq.CourseID, &rq.Position, &rq.Department, &rq.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan requirement row: %w", err)
+		}
+		requirements = append(requirements, rq)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating requirement rows: %w", err)
+	}
+
+	return requirements, nil
+}
+
+// AddCompletion inserts the completion with the expiry of its course
+func (r *trainingRepository) AddCompletion(ctx context.Context, c *models.Completion) error {
+	query := `
+        INSERT INTO training.completions (employee_id, course_id, completed_on, expires_on, certificate_ref)
+        SELECT $1, c.id, $3::date, $3::date + c.validity_days, $4
+        FROM training.courses c
+        WHERE c.id = $2
+        RETURNING id, to_char(expires_on, 'YYYY-MM-DD'), created_at
+    `
+
+	err := r.db.QueryRow(ctx, query, c.EmployeeID, c.CourseID, c.CompletedOn, c.CertificateRef).Scan(&c.ID, &c.ExpiresOn, &c.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCourseNotFound
+		}
+		return fmt.Errorf("failed to add completion: %w", err)
+	}
+
+	return nil
+}
+
+// FindCompletions retrieves every completion of the employee, newest first
+func (r *trainingRepository) FindCompletions(ctx context.Context, employeeID int64) ([]models.Completion, error) {
+	return r.queryCompletions(ctx, `SELECT `+completionColumns+`
+        FROM training.completions
+        WHERE employee_id = $1
+        ORDER BY completed_on DESC, id DESC`, employeeID)
+}
+
+// LatestCompletions retrieves the latest completion per course
+func (r *trainingRepository) LatestCompletions(ctx context.Context, employeeID int64) (map[int64]models.Completion, error) {
+	completions, err := r.queryCompletions(ctx, `SELECT DISTINCT ON (course_id) `+completionColumns+`
+        FROM training.completions
+        WHERE employee_id = $1
+        ORDER BY course_id, completed_on DESC, id DESC`, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[int64]models.Completion, len(completions))
+	for _, c := range completions {
+		latest[c.CourseID] = c
+	}
+	return latest, nil
+}
+
+func (r *trainingRepository) queryCompletions(ctx context.Context, query string, args ...any) ([]models.Completion, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query completions: %w", err)
+	}
+	defer rows.Close()
+
+	completions := []models.Completion{}
+	for rows.Next() {
+		c, err := scanCompletion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan completion row: %w", err)
+		}
+		completions = append(completions, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating completion rows: %w", err)
+	}
+
+	return completions, nil
+}
+
+// FindUnalerted picks the latest completions, so a renewed certification
+// does not alert for its previous completion
+func (r *trainingRepository) FindUnalerted(ctx context.Context, before string) ([]models.Alert, error) {
+	query := `
+        WITH latest AS (
+            SELECT DISTINCT ON (employee_id, course_id) id, employee_id, course_id, expires_on
+            FROM training.completions
+            ORDER BY employee_id, course_id, completed_on DESC, id DESC
+        ), due AS (
+            SELECT l.*, CASE WHEN l.expires_on < CURRENT_DATE THEN 'EXPIRED' ELSE 'EXPIRING' END AS kind
+            FROM latest l
+            WHERE l.expires_on <= $1::date
+        )
+        SELECT d.id, d.employee_id, d.course_id, c.name, d.kind, to_char(d.expires_on, 'YYYY-MM-DD')
+        FROM due d
+        JOIN training.courses c ON c.id = d.course_id
+        WHERE NOT EXISTS (SELECT 1 FROM training.alerts a WHERE a.completion_id = d.id AND a.kind = d.kind)
+        ORDER BY d.expires_on, d.id
+    `
+
+	rows, err := r.db.Query(ctx, query, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expiring completions: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []models.Alert{}
+	for rows.Next() {
+		var a models.Alert
+		if err := rows.Scan(&a.CompletionID, &a.EmployeeID, &a.CourseID, &a.CourseName, &a.Kind, &a.ExpiresOn); err != nil {
+			return nil, fmt.Errorf("failed to scan expiring completion row: %w", err)
+		}
+		alerts = append(alerts, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating expiring completion rows: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// CreateAlert stores the alert, raising the same alert twice is a no-op
+func (r *trainingRepository) CreateAlert(ctx context.Context, a *models.Alert) error {
+	query := `
+        INSERT INTO training.alerts (completion_id, employee_id, course_id, kind, expires_on)
+        VALUES ($1, $2, $3, $4, $5::date)
+        ON CONFLICT (completion_id, kind) DO NOTHING
+        RETURNING id, created_at
+    `
+
+	err := r.db.QueryRow(ctx, query, a.CompletionID, a.EmployeeID, a.CourseID, a.Kind, a.ExpiresOn).Scan(&a.ID, &a.CreatedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to create alert: %w", err)
+	}
+
+	return nil
+}
+
+// FindAlerts retrieves a page of alerts newest first with the total count
+func (r *trainingRepository) FindAlerts(ctx context.Context, filter AlertFilter, limit, offset int) ([]models.Alert, int, error) {
+	where := `
+        WHERE ($1 = 0 OR a.employee_id = $1)
+          AND ($2 = '' OR a.kind = $2)
+          AND (NOT $3 OR a.acknowledged_at IS NULL)
+    `
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM training.alerts a `+where, filter.EmployeeID, filter.Kind, filter.Open).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count alerts: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+        SELECT a.id, a.completion_id, a.employee_id, a.course_id, c.name, a.kind,
+               to_char(a.expires_on, 'YYYY-MM-DD'), a.created_at, a.acknowledged_at
+        FROM training.alerts a
+        JOIN training.courses c ON c.id = a.course_id `+where+`
+        ORDER BY a.created_at DESC, a.id DESC
+        LIMIT $4 OFFSET $5`, filter.EmployeeID, filter.Kind, filter.Open, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []models.Alert{}
+	for rows.Next() {
+		var a models.Alert
+		err := rows.Scan(&a.ID, &a.CompletionID, &a.EmployeeID, &a.CourseID, &a.CourseName, &a.Kind,
+			&a.ExpiresOn, &a.CreatedAt, &a.AcknowledgedAt)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan alert row: %w", err)
+		}
+		alerts = append(alerts, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating alert rows: %w", err)
+	}
+
+	return alerts, total, nil
+}
+
+// AcknowledgeAlert marks the alert as handled, acknowledging twice keeps
+// the first time
+func (r *trainingRepository) AcknowledgeAlert(ctx context.Context, id int64) error {
+	result, err := r.db.Exec(ctx, `
+        UPDATE training.alerts SET acknowledged_at = COALESCE(acknowledged_at, CURRENT_TIMESTAMP)
+        WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge alert: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAlertNotFound
+	}
+
+	return nil
+}
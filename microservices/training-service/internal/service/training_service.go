@@ -0,0 +1,131 @@
+// Package service contains the business logic of the training service
+package service
+
+import (
+	"context"
+	"time"
+
+	"training-service/internal/models"
+	"training-service/internal/repository"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employees"
+)
+
+// TrainingService manages courses, their requirements per position and
+// the completions of employees
+type TrainingService struct {
+	repo        repository.TrainingRepository
+	employees   *employees.Client
+	alertWindow time.Duration
+}
+
+// NewTrainingService creates a new TrainingService instance
+func NewTrainingService(repo repository.TrainingRepository, employeeClient *employees.Client, alertWindow time.Duration) *TrainingService {
+	return &TrainingService{repo: repo, employees: employeeClient, alertWindow: alertWindow}
+}
+
+// CreateCourse adds a training or certification
+func (s *TrainingService) CreateCourse(ctx context.Context, c *models.Course) error {
+	return s.repo.CreateCourse(ctx, c)
+}
+
+// FindCourse retrieves a course
+func (s *TrainingService) FindCourse(ctx context.Context, id int64) (*models.Course, error) {
+	return s.repo.FindCourse(ctx, id)
+}
+
+// FindCourses retrieves the courses, optionally of one kind
+func (s *TrainingService) FindCourses(ctx context.Context, kind models.CourseKind) ([]models.Course, error) {
+	return s.repo.FindCourses(ctx, kind)
+}
+
+// AddRequirement makes the course mandatory for a position
+func (s *TrainingService) AddRequirement(ctx context.Context, r *models.Requirement) error {
+	return s.repo.AddRequirement(ctx, r)
+}
+
+// RemoveRequirement drops a requirement
+func (s *TrainingService) RemoveRequirement(ctx context.Context, courseID int64, position, department string) error {
+	return s.repo.RemoveRequirement(ctx, courseID, position, department)
+}
+
+// FindRequirements retrieves the positions a course is required for
+func (s *TrainingService) FindRequirements(ctx context.Context, courseID int64) ([]models.Requirement, error) {
+	return s.repo.FindRequirements(ctx, courseID)
+}
+
+// AddCompletion records an employee completing a course. The employee
+// must exist in employee-management
+func (s *TrainingService) AddCompletion(ctx context.Context, c *models.Completion) error {
+	if _, err := s.employees.Get(ctx, c.EmployeeID); err != nil {
+		return err
+	}
+	return s.repo.AddCompletion(ctx, c)
+}
+
+// FindCompletions retrieves the completions of an employee
+func (s *TrainingService) FindCompletions(ctx context.Context, employeeID int64) ([]models.Completion, error) {
+	return s.repo.FindCompletions(ctx, employeeID)
+}
+
+// Compliance checks the latest completions of an employee against the
+// courses required for their current position and department
+func (s *TrainingService) Compliance(ctx context.Context, employeeID int64) (*models.Compliance, error) {
+	employee, err := s.employees.Get(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := s.repo.FindRequiredCourses(ctx, employee.Position, employee.Department)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.repo.LatestCompletions(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now().UTC().Format(time.DateOnly)
+	alertFrom := time.Now().UTC().Add(s.alertWindow).Format(time.DateOnly)
+
+	compliance := &models.Compliance{
+		EmployeeID: employee.ID,
+		Position:   employee.Position,
+		Department: employee.Department,
+		Compliant:  true,
+		Courses:    make([]models.CourseStatus, 0, len(required)),
+	}
+	for _, course := range required {
+		status := models.CourseStatus{Course: course, Status: models.StatusMissing}
+		if completion, ok := latest[course.ID]; ok {
+			status.Completion = &completion
+			// Dates are YYYY-MM-DD, so they compare as strings
+			switch expires := completion.ExpiresOn; {
+			case expires != nil && *expires < today:
+				status.Status = models.StatusExpired
+			case expires != nil && *expires <= alertFrom:
+				status.Status = models.StatusExpiring
+			default:
+				status.Status = models.StatusCompleted
+			}
+		}
+
+		if status.Status == models.StatusMissing || status.Status == models.StatusExpired {
+			compliance.Compliant = false
+		}
+		compliance.Courses = append(compliance.Courses, status)
+	}
+
+	return compliance, nil
+}
+
+// FindAlerts retrieves a page of expiry alerts
+func (s *TrainingService) FindAlerts(ctx context.Context, filter repository.AlertFilter, page, pageSize int) ([]models.Alert, int, error) {
+	return s.repo.FindAlerts(ctx, filter, pageSize, (page-1)*pageSize)
+}
+
+// AcknowledgeAlert marks an alert as handled
+func (s *TrainingService) AcknowledgeAlert(ctx context.Context, id int64) error {
+	return s.repo.AcknowledgeAlert(ctx, id)
+}
@@ -0,0 +1,161 @@
+// Package employees is the client of the employee-management API
+package employees
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Employment statuses of employee-management
+const (
+	StatusActive     = "ACTIVE"
+	StatusProbation  = "PROBATION"
+	StatusOnVacation = "ON_VACATION"
+	StatusRetired    = "RETIRED"
+)
+
+// Working reports whether employees of status are at work: ACTIVE, or
+// ACTIVE on probation
+func Working(status string) bool {
+	return status == StatusActive || status == StatusProbation
+}
+
+var (
+	// ErrNotFound is returned when the employee does not exist
+	ErrNotFound = errors.New("employee not found")
+	// ErrUnavailable is returned when employee-management cannot be reached
+	// or answers with an unexpected error
+	ErrUnavailable = errors.New("employee service unavailable")
+)
+
+// Employee is the part of the employee record the services use
+type Employee struct {
+	ID         int64  `json:"id"`
+	FirstName  string `json:"firstName"`
+	LastName   string `json:"lastName"`
+	Email      string `json:"email"`
+	Position   string `json:"position"`
+	Department string `json:"department"`
+	Status     string `json:"status"`
+	HireDate   string `json:"hireDate"`
+}
+
+// Client calls employee-management over HTTP
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewClient creates a client for the versioned API at baseURL, e.g.
+// http://employees:8081/employees-service/api/v1
+func NewClient(baseURL string, timeout time.Duration) *Client {
+	return &Client{baseURL: strings.TrimSuffix(baseURL, "/"), http: &http.Client{Timeout: timeout}}
+}
+
+// Get fetches the employee with id
+func (c *Client) Get(ctx context.Context, id int64) (*Employee, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/employees/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("%w: answered %s", ErrUnavailable, resp.Status)
+	}
+
+	var e Employee
+	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
+		return nil, fmt.Errorf("%w: invalid employee: %w", ErrUnavailable, err)
+	}
+	return &e, nil
+}
+
+// NewEmployee is the body of the employee-management create endpoint
+type NewEmployee struct {
+	FirstName      string `json:"firstName"`
+	LastName       string `json:"lastName"`
+	Email          string `json:"email"`
+	EmployeeNumber string `json:"employeeNumber"`
+	Position       string `json:"position"`
+	Department     string `json:"department"`
+}
+
+// FieldError is a validation error reported by employee-management
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// RejectedError is returned when employee-management refuses to create the
+// employee, e.g. failed validation (400) or a taken email or employee
+// number (409)
+type RejectedError struct {
+	Status  int          `json:"-"`
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("employee service rejected the employee: %s", e.Message)
+}
+
+// Create adds the employee in employee-management, which hires them as
+// ACTIVE today
+func (c *Client) Create(ctx context.Context, e NewEmployee) (*Employee, error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/employees", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+	case http.StatusBadRequest, http.StatusConflict:
+		rejected := &RejectedError{Status: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(rejected); err != nil || rejected.Message == "" {
+			rejected.Message = resp.Status
+		}
+		for _, fe := range rejected.Errors {
+			rejected.Message += fmt.Sprintf("; %s: %s", fe.Field, fe.Message)
+		}
+		return nil, rejected
+	default:
+		return nil, fmt.Errorf("%w: answered %s", ErrUnavailable, resp.Status)
+	}
+
+	var created Employee
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("%w: invalid employee: %w", ErrUnavailable, err)
+	}
+	return &created, nil
+}
@@ -0,0 +1,3 @@
+module github.com/Josedzzz/microservices-fp/pkg/employees
+
+go 1.24.2
@@ -0,0 +1,60 @@
+package employeesync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnavailable is returned when employee-management cannot be reached
+// or answers with an unexpected error
+var ErrUnavailable = errors.New("employee service unavailable")
+
+// HTTPSource reads the snapshot from the employee-management API
+type HTTPSource struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewHTTPSource creates a source for the versioned API at baseURL, e.g.
+// http://employees:8081/employees-service/api/v1
+func NewHTTPSource(baseURL string, timeout time.Duration) *HTTPSource {
+	return &HTTPSource{baseURL: strings.TrimSuffix(baseURL, "/"), http: &http.Client{Timeout: timeout}}
+}
+
+// Snapshot fetches one page of GET /employees/snapshot
+func (s *HTTPSource) Snapshot(ctx context.Context, after int64, limit int) ([]Employee, error) {
+	query := url.Values{}
+	query.Set("after", strconv.FormatInt(after, 10))
+	query.Set("limit", strconv.Itoa(limit))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/employees/snapshot?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: answered %s", ErrUnavailable, resp.Status)
+	}
+
+	var page struct {
+		Data []Employee `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("%w: invalid snapshot: %w", ErrUnavailable, err)
+	}
+	return page.Data, nil
+}
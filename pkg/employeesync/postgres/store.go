@@ -0,0 +1,86 @@
+// Package postgres is the PostgreSQL Store of employeesync
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Josedzzz/microservices-fp/pkg/employeesync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Store keeps the copy in the employees table of a schema, created by a
+// migration of the service as shown in the module README
+type Store struct {
+	db    *pgxpool.Pool
+	table string
+}
+
+// NewStore creates a Store on <schema>.employees
+func NewStore(db *pgxpool.Pool, schema string) *Store {
+	return &Store{db: db, table: pgx.Identifier{schema, "employees"}.Sanitize()}
+}
+
+// Version returns the version held, 0 for unknown employees
+func (s *Store) Version(ctx context.Context, id int64) (int64, error) {
+	var version int64
+	err := s.db.QueryRow(ctx, `SELECT version FROM `+s.table+` WHERE id = $1`, id).Scan(&version)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("failed to read employee version: %w", err)
+	}
+	return version, nil
+}
+
+// Put upserts the employee when e is newer than the row
+func (s *Store) Put(ctx context.Context, e employeesync.Employee) error {
+	_, err := s.db.Exec(ctx, `
+        INSERT INTO `+s.table+` AS t (id, first_name, last_name, department, status, version)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (id) DO UPDATE
+        SET first_name = EXCLUDED.first_name, last_name = EXCLUDED.last_name,
+            department = EXCLUDED.department, status = EXCLUDED.status,
+            version = EXCLUDED.version, deleted = FALSE, synced_at = CURRENT_TIMESTAMP
+        WHERE t.version < EXCLUDED.version`,
+		e.ID, e.FirstName, e.LastName, e.Department, e.Status, e.Version,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store employee: %w", err)
+	}
+	return nil
+}
+
+// Delete turns the row into a tombstone at version
+func (s *Store) Delete(ctx context.Context, id, version int64) error {
+	_, err := s.db.Exec(ctx, `
+        INSERT INTO `+s.table+` AS t (id, version, deleted)
+        VALUES ($1, $2, TRUE)
+        ON CONFLICT (id) DO UPDATE
+        SET version = EXCLUDED.version, deleted = TRUE, synced_at = CURRENT_TIMESTAMP
+        WHERE t.version < EXCLUDED.version`,
+		id, version,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete employee: %w", err)
+	}
+	return nil
+}
+
+// Get returns the employee unless unknown or deleted
+func (s *Store) Get(ctx context.Context, id int64) (*employeesync.Employee, error) {
+	var e employeesync.Employee
+	err := s.db.QueryRow(ctx, `
+        SELECT id, first_name, last_name, department, status, version
+        FROM `+s.table+`
+        WHERE id = $1 AND NOT deleted`, id,
+	).Scan(&e.ID, &e.FirstName, &e.LastName, &e.Department, &e.Status, &e.Version)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, employeesync.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read employee: %w", err)
+	}
+	return &e, nil
+}
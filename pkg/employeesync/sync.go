@@ -0,0 +1,212 @@
+// Package employeesync keeps a local copy of the employees of
+// employee-management from its domain events, for services that need the
+// name, department or status of employees without calling it.
+//
+// The copy is bootstrapped from the snapshot endpoint, then kept up to
+// date by Handle. Every event carries the version of its employee, counted
+// from 1 without gaps: an event one past the version held is applied, an
+// older one is a duplicate, and a bigger jump means an event was missed,
+// so the employee is fetched again from the snapshot
+package employeesync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// Employee event types published by employee-management
+const (
+	EmployeeCreated       = "employee.created"
+	EmployeeUpdated       = "employee.updated"
+	EmployeeDeleted       = "employee.deleted"
+	EmployeeStatusChanged = "employee.status_changed"
+)
+
+// ErrNotFound is returned by Store.Get for employees the copy does not
+// hold or that were deleted
+var ErrNotFound = errors.New("employee not found")
+
+// Employee is the copy of an employee
+type Employee struct {
+	ID         int64  `json:"id"`
+	FirstName  string `json:"firstName"`
+	LastName   string `json:"lastName"`
+	Department string `json:"department"`
+	Status     string `json:"status"`
+	// Version is the version of the latest event applied
+	Version int64 `json:"version"`
+}
+
+// Event is an employee event as published by employee-management
+type Event struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID int64           `json:"aggregateId"`
+	Version     int64           `json:"version"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// Store holds the copy. Deleted employees are kept as tombstones with
+// their last version, so late events cannot bring them back
+type Store interface {
+	// Version returns the version held for the employee, deleted ones
+	// included, 0 when the employee was never seen
+	Version(ctx context.Context, id int64) (int64, error)
+	// Put stores e unless the version held is at least e.Version
+	Put(ctx context.Context, e Employee) error
+	// Delete marks the employee deleted at version unless the version
+	// held is at least version
+	Delete(ctx context.Context, id, version int64) error
+	// Get returns the employee, ErrNotFound when unknown or deleted
+	Get(ctx context.Context, id int64) (*Employee, error)
+}
+
+// Source reads the snapshot of employee-management
+type Source interface {
+	// Snapshot returns up to limit employees with an id greater than
+	// after, by id, each with the version of its latest event
+	Snapshot(ctx context.Context, after int64, limit int) ([]Employee, error)
+}
+
+// snapshotPageSize is how many employees Bootstrap reads per request
+const snapshotPageSize = 500
+
+// Syncer applies the events and snapshots to a Store
+type Syncer struct {
+	store  Store
+	source Source
+}
+
+// New creates a Syncer keeping store up to date from source and events
+func New(store Store, source Source) *Syncer {
+	return &Syncer{store: store, source: source}
+}
+
+// Bootstrap copies the whole snapshot into the store. Employees the store
+// already holds at the same or a newer version are left alone, so it can
+// run on every start and while events are handled
+func (s *Syncer) Bootstrap(ctx context.Context) error {
+	var after int64
+	copied := 0
+
+	for {
+		page, err := s.source.Snapshot(ctx, after, snapshotPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to read employee snapshot after %d: %w", after, err)
+		}
+
+		for _, e := range page {
+			if err := s.store.Put(ctx, e); err != nil {
+				return err
+			}
+		}
+		copied += len(page)
+
+		if len(page) < snapshotPageSize {
+			log.Printf("employeesync: snapshot of %d employees copied", copied)
+			return nil
+		}
+		after = page[len(page)-1].ID
+	}
+}
+
+// Handle applies one employee event. Events of other types that carry a
+// version, such as employee.email_verification_requested, change nothing
+// in the copy but still take the next version of their employee, so it is
+// recorded for the event after them not to look like a gap. An error
+// means the store or the snapshot could not be reached and the event
+// should be redelivered
+func (s *Syncer) Handle(ctx context.Context, e Event) error {
+	// Events published before versioning are covered by the snapshot
+	if e.Version == 0 || e.AggregateID == 0 {
+		return nil
+	}
+
+	held, err := s.store.Version(ctx, e.AggregateID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case e.Version <= held:
+		return nil
+	case e.Version > held+1:
+		log.Printf("employeesync: employee %d jumped from version %d to %d, fetching it again", e.AggregateID, held, e.Version)
+		return s.Resync(ctx, e.AggregateID, e.Version)
+	}
+
+	switch e.Type {
+	case EmployeeCreated, EmployeeUpdated, EmployeeDeleted, EmployeeStatusChanged:
+		return s.apply(ctx, e)
+	default:
+		return s.advance(ctx, e)
+	}
+}
+
+// advance records the version of an event that does not change the
+// employee. An employee the copy does not hold is fetched from the
+// snapshot instead
+func (s *Syncer) advance(ctx context.Context, e Event) error {
+	employee, err := s.store.Get(ctx, e.AggregateID)
+	if errors.Is(err, ErrNotFound) {
+		return s.Resync(ctx, e.AggregateID, e.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	employee.Version = e.Version
+	return s.store.Put(ctx, *employee)
+}
+
+// Resync replaces the copy of one employee with its snapshot. An employee
+// missing from the snapshot was deleted, and is marked deleted at seen,
+// the newest version an event showed
+func (s *Syncer) Resync(ctx context.Context, id, seen int64) error {
+	page, err := s.source.Snapshot(ctx, id-1, 1)
+	if err != nil {
+		return fmt.Errorf("failed to read employee %d from the snapshot: %w", id, err)
+	}
+
+	if len(page) == 1 && page[0].ID == id {
+		return s.store.Put(ctx, page[0])
+	}
+	return s.store.Delete(ctx, id, seen)
+}
+
+// apply stores the change the event describes
+func (s *Syncer) apply(ctx context.Context, e Event) error {
+	employee := Employee{ID: e.AggregateID, Version: e.Version}
+
+	switch e.Type {
+	case EmployeeDeleted:
+		return s.store.Delete(ctx, e.AggregateID, e.Version)
+
+	case EmployeeStatusChanged:
+		var change struct {
+			To         string `json:"to"`
+			Department string `json:"department"`
+			FirstName  string `json:"firstName"`
+			LastName   string `json:"lastName"`
+		}
+		if err := json.Unmarshal(e.Payload, &change); err != nil {
+			// Left for the next event to detect as a gap and resync
+			log.Printf("employeesync: skipping event %s: %v", e.ID, err)
+			return nil
+		}
+		employee.FirstName, employee.LastName = change.FirstName, change.LastName
+		employee.Department, employee.Status = change.Department, change.To
+
+	default:
+		if err := json.Unmarshal(e.Payload, &employee); err != nil {
+			log.Printf("employeesync: skipping event %s: %v", e.ID, err)
+			return nil
+		}
+		employee.ID, employee.Version = e.AggregateID, e.Version
+	}
+
+	return s.store.Put(ctx, employee)
+}
@@ -0,0 +1,115 @@
+package employeesync
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+// memoryStore is a Store in a map, tombstones included
+type memoryStore struct {
+	employees map[int64]Employee
+	deleted   map[int64]bool
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{employees: map[int64]Employee{}, deleted: map[int64]bool{}}
+}
+
+func (m *memoryStore) Version(_ context.Context, id int64) (int64, error) {
+	return m.employees[id].Version, nil
+}
+
+func (m *memoryStore) Put(_ context.Context, e Employee) error {
+	if m.employees[e.ID].Version >= e.Version {
+		return nil
+	}
+	m.employees[e.ID] = e
+	delete(m.deleted, e.ID)
+	return nil
+}
+
+func (m *memoryStore) Delete(_ context.Context, id, version int64) error {
+	if m.employees[id].Version >= version {
+		return nil
+	}
+	m.employees[id] = Employee{ID: id, Version: version}
+	m.deleted[id] = true
+	return nil
+}
+
+func (m *memoryStore) Get(_ context.Context, id int64) (*Employee, error) {
+	e, ok := m.employees[id]
+	if !ok || m.deleted[id] {
+		return nil, ErrNotFound
+	}
+	return &e, nil
+}
+
+// countingSource serves a fixed snapshot and counts the reads
+type countingSource struct {
+	employees []Employee
+	reads     int
+}
+
+func (s *countingSource) Snapshot(_ context.Context, after int64, limit int) ([]Employee, error) {
+	s.reads++
+	var page []Employee
+	for _, e := range s.employees {
+		if e.ID > after && len(page) < limit {
+			page = append(page, e)
+		}
+	}
+	return page, nil
+}
+
+func TestHandleRecordsVersionOfOtherEvents(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	source := &countingSource{employees: []Employee{{ID: 1, FirstName: "Jane", Department: "Support", Status: "ACTIVE", Version: 3}}}
+	syncer := New(store, source)
+
+	created, _ := json.Marshal(Employee{FirstName: "Jane", Department: "Sales", Status: "ACTIVE"})
+	updated, _ := json.Marshal(Employee{FirstName: "Jane", Department: "Support", Status: "ACTIVE"})
+	stream := []Event{
+		{ID: "a", Type: EmployeeCreated, AggregateID: 1, Version: 1, Payload: created},
+		{ID: "b", Type: "employee.email_verification_requested", AggregateID: 1, Version: 2, Payload: json.RawMessage(`{"email":"jane@example.com"}`)},
+		{ID: "c", Type: EmployeeUpdated, AggregateID: 1, Version: 3, Payload: updated},
+		{ID: "d", Type: "employee.birthday_upcoming", AggregateID: 1, Payload: json.RawMessage(`{}`)},
+	}
+	for _, e := range stream {
+		if err := syncer.Handle(ctx, e); err != nil {
+			t.Fatalf("Handle(%s): %v", e.Type, err)
+		}
+	}
+
+	if source.reads != 0 {
+		t.Fatalf("snapshot read %d times, want no resync", source.reads)
+	}
+	got, err := store.Get(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Version != 3 || got.Department != "Support" {
+		t.Fatalf("employee = %+v, want version 3 in Support", got)
+	}
+}
+
+func TestHandleResyncsOtherEventsOfUnknownEmployees(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	source := &countingSource{employees: []Employee{{ID: 2, FirstName: "John", Version: 1}}}
+	syncer := New(store, source)
+
+	err := syncer.Handle(ctx, Event{ID: "a", Type: "employee.email_verification_requested", AggregateID: 2, Version: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if source.reads != 1 {
+		t.Fatalf("snapshot read %d times, want 1", source.reads)
+	}
+	if got, err := store.Get(ctx, 2); err != nil || got.Version != 1 {
+		t.Fatalf("employee = %+v, %v, want version 1", got, err)
+	}
+}
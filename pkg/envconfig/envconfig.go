@@ -0,0 +1,166 @@
+// Package envconfig builds the config of a service from its defaults, a
+// YAML file, env variables and CLI flags, in that order
+package envconfig
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Option binds a field of the config C to its env variable and CLI flag
+type Option[C any] struct {
+	Env   string
+	Flag  string
+	Usage string
+	Set   func(c *C, val string) error
+}
+
+// Load parses args with fs and merges into cfg, which holds the defaults,
+// the YAML file of -config (env CONFIG_FILE), then the env variables and
+// the flags of options. Flags of the service itself can be defined on fs
+// before, and read from it after
+func Load[C any](fs *flag.FlagSet, args []string, cfg *C, options []Option[C]) error {
+	configPath := fs.String("config", getEnv("CONFIG_FILE", ""), "path to YAML config file")
+	for _, o := range options {
+		fs.String(o.Flag, "", o.Usage+" (env "+o.Env+")")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *configPath != "" {
+		if err := loadFile(*configPath, cfg); err != nil {
+			return err
+		}
+	}
+
+	for _, o := range options {
+		if val, ok := os.LookupEnv(o.Env); ok {
+			if err := o.Set(cfg, val); err != nil {
+				return fmt.Errorf("env %s: %w", o.Env, err)
+			}
+		}
+	}
+
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		for _, o := range options {
+			if o.Flag == f.Name {
+				if err := o.Set(cfg, f.Value.String()); err != nil {
+					flagErr = errors.Join(flagErr, fmt.Errorf("flag -%s: %w", f.Name, err))
+				}
+			}
+		}
+	})
+
+	return flagErr
+}
+
+// loadFile merges the YAML file at path into cfg
+func loadFile(path string, cfg any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// String returns a setter storing the raw value
+func String[C any](field func(c *C) *string) func(c *C, val string) error {
+	return func(c *C, val string) error {
+		*field(c) = val
+		return nil
+	}
+}
+
+// Int returns a setter that parses the value as an int
+func Int[C any](field func(c *C) *int) func(c *C, val string) error {
+	return func(c *C, val string) error {
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			return err
+		}
+		*field(c) = n
+		return nil
+	}
+}
+
+// Float returns a setter that parses the value as a float64
+func Float[C any](field func(c *C) *float64) func(c *C, val string) error {
+	return func(c *C, val string) error {
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return err
+		}
+		*field(c) = f
+		return nil
+	}
+}
+
+// Bool returns a setter that parses the value as a bool
+func Bool[C any](field func(c *C) *bool) func(c *C, val string) error {
+	return func(c *C, val string) error {
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return err
+		}
+		*field(c) = b
+		return nil
+	}
+}
+
+// Duration returns a setter that parses the value as a time.Duration
+func Duration[C any](field func(c *C) *time.Duration) func(c *C, val string) error {
+	return func(c *C, val string) error {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+		*field(c) = d
+		return nil
+	}
+}
+
+// ValidatePort checks that port is a number in the valid TCP range
+func ValidatePort(port string) error {
+	n, err := strconv.Atoi(port)
+	if err != nil {
+		return fmt.Errorf("%q is not numeric", port)
+	}
+	if n < 1 || n > 65535 {
+		return fmt.Errorf("%d is out of range 1-65535", n)
+	}
+	return nil
+}
+
+// SplitList splits a comma separated setting dropping empty entries
+func SplitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnv returns env variable value or default if not set
+func getEnv(key, defaultVal string) string {
+	if val, ok := os.LookupEnv(key); ok {
+		return val
+	}
+	return defaultVal
+}
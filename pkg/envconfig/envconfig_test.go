@@ -0,0 +1,58 @@
+package envconfig
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Port    string        `yaml:"port"`
+	Host    string        `yaml:"host"`
+	Workers int           `yaml:"workers"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+var testOptions = []Option[testConfig]{
+	{Env: "TEST_PORT", Flag: "port", Usage: "port", Set: String(func(c *testConfig) *string { return &c.Port })},
+	{Env: "TEST_HOST", Flag: "host", Usage: "host", Set: String(func(c *testConfig) *string { return &c.Host })},
+	{Env: "TEST_WORKERS", Flag: "workers", Usage: "workers", Set: Int(func(c *testConfig) *int { return &c.Workers })},
+	{Env: "TEST_TIMEOUT", Flag: "timeout", Usage: "timeout", Set: Duration(func(c *testConfig) *time.Duration { return &c.Timeout })},
+}
+
+func TestLoadOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("port: \"8000\"\nhost: file\nworkers: 2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("TEST_HOST", "env")
+	t.Setenv("TEST_WORKERS", "3")
+
+	cfg := &testConfig{Port: "7000", Timeout: time.Second}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	verbose := fs.Bool("verbose", false, "flag of the service")
+	if err := Load(fs, []string{"-workers", "4", "-verbose", "extra"}, cfg, testOptions); err != nil {
+		t.Fatal(err)
+	}
+
+	want := testConfig{Port: "8000", Host: "env", Workers: 4, Timeout: time.Second}
+	if *cfg != want {
+		t.Fatalf("config = %+v, want %+v", *cfg, want)
+	}
+	if !*verbose || len(fs.Args()) != 1 {
+		t.Fatalf("service flags not parsed: verbose %t, args %v", *verbose, fs.Args())
+	}
+}
+
+func TestLoadInvalidValues(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("TEST_WORKERS", "many")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	if err := Load(fs, nil, &testConfig{}, testOptions); err == nil {
+		t.Fatal("Load accepted an invalid env value")
+	}
+}
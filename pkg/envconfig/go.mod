@@ -0,0 +1,5 @@
+module github.com/Josedzzz/microservices-fp/pkg/envconfig
+
+go 1.24.2
+
+require go.yaml.in/yaml/v3 v3.0.4
@@ -0,0 +1,16 @@
+package eventconsumer
+
+import "time"
+
+// exponential returns base doubled for every attempt after the first,
+// capped at max. Attempts start at 1
+func exponential(base, max time.Duration, attempt int) time.Duration {
+	wait := base
+	for i := 1; i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	return wait
+}
@@ -0,0 +1,76 @@
+// Package eventconsumer receives the employee events employee-management
+// publishes, from NATS JetStream, Kafka or RabbitMQ
+package eventconsumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Type is the name of a domain event
+type Type string
+
+// Employee lifecycle events published by employee-management
+const (
+	EmployeeCreated       Type = "employee.created"
+	EmployeeUpdated       Type = "employee.updated"
+	EmployeeDeleted       Type = "employee.deleted"
+	EmployeeStatusChanged Type = "employee.status_changed"
+)
+
+// Event is a domain event as delivered by the brokers
+type Event struct {
+	ID          string          `json:"id"`
+	Type        Type            `json:"type"`
+	AggregateID int64           `json:"aggregateId"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	Payload     json.RawMessage `json:"payload"`
+	// Version numbers the events of the employee without gaps
+	Version int64 `json:"version"`
+}
+
+// Handler processes one event. An error makes the broker redeliver it
+type Handler func(ctx context.Context, e Event) error
+
+// Consumer receives events from a broker with a durable subscription
+type Consumer interface {
+	// Consume calls handler for every event until ctx is done
+	Consume(ctx context.Context, handler Handler) error
+	Close() error
+}
+
+// Config selects the broker and names what the consumer subscribes to
+type Config struct {
+	// Broker is nats, kafka or rabbitmq
+	Broker string
+
+	NATSURL           string
+	NATSStream        string
+	NATSSubjectPrefix string
+	NATSConsumer      string
+
+	KafkaBrokers     []string
+	KafkaTopicPrefix string
+	KafkaGroupID     string
+
+	RabbitMQURL              string
+	RabbitMQExchange         string
+	RabbitMQRoutingKeyPrefix string
+	RabbitMQQueue            string
+}
+
+// New creates the consumer of the configured broker
+func New(cfg Config) (Consumer, error) {
+	switch cfg.Broker {
+	case "nats":
+		return NewNATSConsumer(cfg.NATSURL, cfg.NATSStream, cfg.NATSSubjectPrefix, cfg.NATSConsumer)
+	case "kafka":
+		return NewKafkaConsumer(cfg.KafkaBrokers, cfg.KafkaTopicPrefix, cfg.KafkaGroupID), nil
+	case "rabbitmq":
+		return NewRabbitMQConsumer(cfg.RabbitMQURL, cfg.RabbitMQExchange, cfg.RabbitMQRoutingKeyPrefix, cfg.RabbitMQQueue), nil
+	default:
+		return nil, fmt.Errorf("unknown event broker %q", cfg.Broker)
+	}
+}
@@ -0,0 +1,81 @@
+package eventconsumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaConsumer reads the per event type topics as a consumer group
+// Offsets are committed only after the handler succeeds, and a failing
+// event is retried before moving on so the events of one employee stay
+// ordered
+type KafkaConsumer struct {
+	reader *kafka.Reader
+}
+
+// NewKafkaConsumer creates a group reader over <prefix><type> topics
+func NewKafkaConsumer(brokers []string, topicPrefix, groupID string) *KafkaConsumer {
+	topics := []string{
+		topicPrefix + string(EmployeeCreated),
+		topicPrefix + string(EmployeeUpdated),
+		topicPrefix + string(EmployeeDeleted),
+		topicPrefix + string(EmployeeStatusChanged),
+	}
+
+	return &KafkaConsumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:     brokers,
+			GroupID:     groupID,
+			GroupTopics: topics,
+			StartOffset: kafka.FirstOffset,
+		}),
+	}
+}
+
+// Consume fetches messages until ctx is done
+func (c *KafkaConsumer) Consume(ctx context.Context, handler Handler) error {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to fetch kafka message: %w", err)
+		}
+
+		var e Event
+		if err := json.Unmarshal(msg.Value, &e); err != nil {
+			log.Printf("dropping malformed event on %s: %v", msg.Topic, err)
+		} else {
+			for attempt := 1; ; attempt++ {
+				err := handler(ctx, e)
+				if err == nil {
+					break
+				}
+				wait := exponential(time.Second, time.Minute, attempt)
+				log.Printf("event %s handler failed, retrying in %s: %v", e.ID, wait, err)
+
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(wait):
+				}
+			}
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil && ctx.Err() == nil {
+			return fmt.Errorf("failed to commit kafka offset: %w", err)
+		}
+	}
+}
+
+// Close leaves the group and closes the connections
+func (c *KafkaConsumer) Close() error {
+	return c.reader.Close()
+}
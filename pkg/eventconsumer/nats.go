@@ -0,0 +1,79 @@
+package eventconsumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATSConsumer reads the JetStream stream the employee service publishes
+// to with a durable consumer, so events published while the service was
+// down are delivered once it is back
+type NATSConsumer struct {
+	conn          *nats.Conn
+	js            jetstream.JetStream
+	stream        string
+	subjectPrefix string
+	durable       string
+}
+
+// NewNATSConsumer connects to NATS. The stream is provisioned by the
+// publisher, so it is only looked up when consuming
+func NewNATSConsumer(url, stream, subjectPrefix, durable string) (*NATSConsumer, error) {
+	conn, err := nats.Connect(url, nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create jetstream context: %w", err)
+	}
+
+	return &NATSConsumer{conn: conn, js: js, stream: stream, subjectPrefix: subjectPrefix, durable: durable}, nil
+}
+
+// Consume acks messages when handler succeeds and naks them otherwise
+func (c *NATSConsumer) Consume(ctx context.Context, handler Handler) error {
+	consumer, err := c.js.CreateOrUpdateConsumer(ctx, c.stream, jetstream.ConsumerConfig{
+		Durable:       c.durable,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		FilterSubject: c.subjectPrefix + "employee.>",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create jetstream consumer %s: %w", c.durable, err)
+	}
+
+	consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
+		var e Event
+		if err := json.Unmarshal(msg.Data(), &e); err != nil {
+			log.Printf("dropping malformed event on %s: %v", msg.Subject(), err)
+			_ = msg.Term()
+			return
+		}
+
+		if err := handler(ctx, e); err != nil {
+			log.Printf("event %s handler failed, redelivering: %v", e.ID, err)
+			_ = msg.Nak()
+			return
+		}
+		_ = msg.Ack()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to consume jetstream: %w", err)
+	}
+
+	<-ctx.Done()
+	consumeCtx.Stop()
+	return nil
+}
+
+// Close drains pending messages and closes the connection
+func (c *NATSConsumer) Close() error {
+	return c.conn.Drain()
+}
@@ -0,0 +1,97 @@
+package eventconsumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// RabbitMQConsumer reads a durable queue bound to the employee events
+// exchange. Messages are acked after the handler succeeds and requeued
+// otherwise, and the connection is re-established when it drops
+type RabbitMQConsumer struct {
+	url              string
+	exchange         string
+	routingKeyPrefix string
+	queue            string
+}
+
+// NewRabbitMQConsumer creates the consumer, connecting on Consume
+func NewRabbitMQConsumer(url, exchange, routingKeyPrefix, queue string) *RabbitMQConsumer {
+	return &RabbitMQConsumer{url: url, exchange: exchange, routingKeyPrefix: routingKeyPrefix, queue: queue}
+}
+
+// Consume reads the queue until ctx is done, reconnecting every 5s while
+// the broker is unreachable
+func (c *RabbitMQConsumer) Consume(ctx context.Context, handler Handler) error {
+	for {
+		err := c.consume(ctx, handler)
+		if ctx.Err() != nil {
+			return nil
+		}
+		log.Printf("rabbitmq consumer stopped, reconnecting: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// consume runs one connection until it drops or ctx is done
+func (c *RabbitMQConsumer) consume(ctx context.Context, handler Handler) error {
+	conn, err := amqp.Dial(c.url)
+	if err != nil {
+		return fmt.Errorf("failed to connect to rabbitmq: %w", err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open rabbitmq channel: %w", err)
+	}
+
+	if err := ch.ExchangeDeclare(c.exchange, "topic", true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare rabbitmq exchange: %w", err)
+	}
+	if _, err := ch.QueueDeclare(c.queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare rabbitmq queue: %w", err)
+	}
+	if err := ch.QueueBind(c.queue, c.routingKeyPrefix+"employee.*", c.exchange, false, nil); err != nil {
+		return fmt.Errorf("failed to bind rabbitmq queue: %w", err)
+	}
+	if err := ch.Qos(10, 0, false); err != nil {
+		return fmt.Errorf("failed to set rabbitmq prefetch: %w", err)
+	}
+
+	deliveries, err := ch.ConsumeWithContext(ctx, c.queue, "", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to consume rabbitmq queue: %w", err)
+	}
+
+	for d := range deliveries {
+		var e Event
+		if err := json.Unmarshal(d.Body, &e); err != nil {
+			log.Printf("dropping malformed event %s: %v", d.MessageId, err)
+			_ = d.Reject(false)
+			continue
+		}
+
+		if err := handler(ctx, e); err != nil {
+			log.Printf("event %s handler failed, requeueing: %v", e.ID, err)
+			_ = d.Nack(false, true)
+			continue
+		}
+		_ = d.Ack(false)
+	}
+
+	return amqp.ErrClosed
+}
+
+// Close does nothing, connections are closed when Consume returns
+func (c *RabbitMQConsumer) Close() error { return nil }
@@ -0,0 +1,45 @@
+package httpkit
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Pinger is a database that can be checked, e.g. *pgxpool.Pool
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// HealthHandler handles the health endpoint of a service
+type HealthHandler struct {
+	service string
+	db      Pinger
+}
+
+// NewHealthHandler creates a new HealthHandler instance reporting service
+// and the status of db
+func NewHealthHandler(service string, db Pinger) *HealthHandler {
+	return &HealthHandler{service: service, db: db}
+}
+
+// HealthCheck handles GET /health
+// Answers 503 while the db is unreachable
+func (h *HealthHandler) HealthCheck(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+	defer cancel()
+
+	status, code, database := "UP", http.StatusOK, "UP"
+	if err := h.db.Ping(ctx); err != nil {
+		status, code, database = "DOWN", http.StatusServiceUnavailable, "DOWN"
+	}
+
+	c.JSON(code, gin.H{
+		"status":    status,
+		"service":   h.service,
+		"timestamp": time.Now().UTC(),
+		"database":  gin.H{"status": database},
+	})
+}
@@ -0,0 +1,56 @@
+package httpkit
+
+// Default and largest page sizes of the list endpoints
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// PageQuery is the page and page_size query parameters, embed it in the
+// query struct of a list endpoint
+type PageQuery struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// Normalize fills in the first page and the default page size
+func (q *PageQuery) Normalize() {
+	if q.Page == 0 {
+		q.Page = 1
+	}
+	if q.PageSize == 0 {
+		q.PageSize = DefaultPageSize
+	}
+}
+
+// Offset is the number of records before the page
+func (q PageQuery) Offset() int {
+	return (q.Page - 1) * q.PageSize
+}
+
+// PaginatedResponse is a generic structure for paginated results
+type PaginatedResponse struct {
+	Data       any            `json:"data"`
+	Pagination PaginationMeta `json:"pagination"`
+}
+
+// PaginationMeta contains metadata about the pagination
+type PaginationMeta struct {
+	CurrentPage  int `json:"current_page"`
+	PageSize     int `json:"page_size"`
+	TotalPages   int `json:"total_pages"`
+	TotalRecords int `json:"total_records"`
+}
+
+// Paginate wraps a page of data with its pagination metadata
+func Paginate(data any, q PageQuery, total int) PaginatedResponse {
+	return PaginatedResponse{
+		Data: data,
+		Pagination: PaginationMeta{
+			CurrentPage:  q.Page,
+			PageSize:     q.PageSize,
+			TotalPages:   (total + q.PageSize - 1) / q.PageSize,
+			TotalRecords: total,
+		},
+	}
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Migrator applies the migrations of a service to its own schema
+type Migrator struct {
+	// Schema holds the tables of the service and its schema_migrations
+	Schema string
+	// LockID is the advisory lock key held while migrating so several
+	// instances starting at once do not race. Each service has its own
+	LockID int64
+	// Files holds the migrations named <version>_<name>.sql, e.g.
+	// 0002_add_phone.sql, at its root
+	Files fs.FS
+}
+
+// Migration is a versioned schema change embedded in the binary
+type Migration struct {
+	Version   int64
+	Name      string
+	SQL       string
+	AppliedAt *time.Time
+}
+
+// Migrate applies every pending migration in version order
+// Each migration runs in its own transaction together with the insert
+// into schema_migrations, so a failed migration leaves no partial state
+func (m Migrator) Migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", m.LockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", m.LockID)
+
+	migrations, err := m.Status(ctx, pool)
+	if err != nil {
+		return err
+	}
+
+	for _, mig := range migrations {
+		if mig.AppliedAt != nil {
+			continue
+		}
+
+		err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, mig.SQL); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx,
+				"INSERT INTO "+m.table()+" (version, name) VALUES ($1, $2)",
+				mig.Version, mig.Name,
+			)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %04d_%s failed: %w", mig.Version, mig.Name, err)
+		}
+
+		log.Printf("applied migration %04d_%s", mig.Version, mig.Name)
+	}
+
+	return nil
+}
+
+// Status returns every migration with the time it was applied, nil for
+// pending ones
+func (m Migrator) Status(ctx context.Context, pool *pgxpool.Pool) ([]Migration, error) {
+	if err := m.ensureTable(ctx, pool); err != nil {
+		return nil, err
+	}
+
+	migrations, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pool.Query(ctx, "SELECT version, applied_at FROM "+m.table())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range migrations {
+		if at, ok := applied[migrations[i].Version]; ok {
+			migrations[i].AppliedAt = &at
+		}
+	}
+
+	return migrations, nil
+}
+
+// table is the quoted name of the table tracking applied migrations
+func (m Migrator) table() string {
+	return pgx.Identifier{m.Schema, "schema_migrations"}.Sanitize()
+}
+
+// ensureTable creates the schema and the table tracking applied
+// migrations
+func (m Migrator) ensureTable(ctx context.Context, pool *pgxpool.Pool) error {
+	query := `
+	CREATE SCHEMA IF NOT EXISTS ` + pgx.Identifier{m.Schema}.Sanitize() + `;
+	CREATE TABLE IF NOT EXISTS ` + m.table() + ` (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	`
+
+	_, err := pool.Exec(ctx, query)
+	return err
+}
+
+// load reads the migration files sorted by version
+func (m Migrator) load() ([]Migration, error) {
+	entries, err := fs.ReadDir(m.Files, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	seen := map[int64]string{}
+	for _, entry := range entries {
+		file := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(file, ".sql") {
+			continue
+		}
+		base := strings.TrimSuffix(file, ".sql")
+
+		versionStr, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s must be named <version>_<name>.sql", file)
+		}
+
+		version, err := strconv.ParseInt(versionStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has an invalid version: %w", file, err)
+		}
+		if other, dup := seen[version]; dup {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, file, version)
+		}
+		seen[version] = file
+
+		sql, err := fs.ReadFile(m.Files, file)
+		if err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, Migration{Version: version, Name: name, SQL: string(sql)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
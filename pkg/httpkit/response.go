@@ -0,0 +1,49 @@
+// Package httpkit holds the HTTP plumbing shared by the services: the
+// error and paginated responses, the gin router setup and the health
+// endpoint
+package httpkit
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorResponse is the standard struct for error response
+//
+//	@Description	Standard error response structure
+type ErrorResponse struct {
+	Status    int       `json:"status"`
+	Error     string    `json:"error"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+	Path      string    `json:"path"`
+}
+
+// Error creates a simple error response
+func Error(c *gin.Context, status int, message string) {
+	response := ErrorResponse{
+		Status:    status,
+		Error:     http.StatusText(status),
+		Message:   message,
+		Timestamp: time.Now().UTC(),
+		Path:      c.Request.URL.Path,
+	}
+	c.JSON(status, response)
+}
+
+// InternalServerError for 500 errors
+func InternalServerError(c *gin.Context, message string) {
+	Error(c, http.StatusInternalServerError, message)
+}
+
+// BadRequest for 400 errors
+func BadRequest(c *gin.Context, message string) {
+	Error(c, http.StatusBadRequest, message)
+}
+
+// NotFound for 404 errors
+func NotFound(c *gin.Context, message string) {
+	Error(c, http.StatusNotFound, message)
+}
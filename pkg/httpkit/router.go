@@ -0,0 +1,22 @@
+package httpkit
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// NewRouter creates the gin engine every service starts from: release
+// mode, the gateway on localhost as the only trusted proxy, request
+// logging, panic recovery and JSON 404s
+func NewRouter() *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.SetTrustedProxies([]string{"127.0.0.1"})
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+
+	router.NoRoute(func(c *gin.Context) {
+		NotFound(c, "Resource not found")
+	})
+
+	return router
+}